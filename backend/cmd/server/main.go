@@ -116,9 +116,10 @@ func runSetupServer() {
 		r.Use(web.ServeEmbeddedFrontend())
 	}
 
-	// Get server address from config.yaml or environment variables (SERVER_HOST, SERVER_PORT)
-	// This allows users to run setup on a different address if needed
-	addr := config.GetServerAddress()
+	// Get the setup wizard bind address, which defaults to the main server address but can be
+	// overridden independently via config.yaml "setup.bind_address" or the SETUP_BIND_ADDRESS
+	// environment variable (e.g. to restrict the wizard to localhost).
+	addr := config.GetSetupBindAddress()
 	log.Printf("Setup wizard available at http://%s", addr)
 	log.Println("Complete the setup wizard to configure Sub2API")
 
@@ -43,6 +43,10 @@ type Account struct {
 	Concurrency int `json:"concurrency,omitempty"`
 	// Priority holds the value of the "priority" field.
 	Priority int `json:"priority,omitempty"`
+	// AffinityGroup holds the value of the "affinity_group" field.
+	AffinityGroup string `json:"affinity_group,omitempty"`
+	// MaxLineSize holds the value of the "max_line_size" field.
+	MaxLineSize int `json:"max_line_size,omitempty"`
 	// RateMultiplier holds the value of the "rate_multiplier" field.
 	RateMultiplier float64 `json:"rate_multiplier,omitempty"`
 	// Status holds the value of the "status" field.
@@ -69,6 +73,12 @@ type Account struct {
 	SessionWindowEnd *time.Time `json:"session_window_end,omitempty"`
 	// SessionWindowStatus holds the value of the "session_window_status" field.
 	SessionWindowStatus *string `json:"session_window_status,omitempty"`
+	// SessionWindowUtilization holds the value of the "session_window_utilization" field.
+	SessionWindowUtilization *int `json:"session_window_utilization,omitempty"`
+	// QuietHoursStartMinute holds the value of the "quiet_hours_start_minute" field.
+	QuietHoursStartMinute *int `json:"quiet_hours_start_minute,omitempty"`
+	// QuietHoursEndMinute holds the value of the "quiet_hours_end_minute" field.
+	QuietHoursEndMinute *int `json:"quiet_hours_end_minute,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the AccountQuery when eager-loading is set.
 	Edges        AccountEdges `json:"edges"`
@@ -139,9 +149,9 @@ func (*Account) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullBool)
 		case account.FieldRateMultiplier:
 			values[i] = new(sql.NullFloat64)
-		case account.FieldID, account.FieldProxyID, account.FieldConcurrency, account.FieldPriority:
+		case account.FieldID, account.FieldProxyID, account.FieldConcurrency, account.FieldPriority, account.FieldMaxLineSize, account.FieldSessionWindowUtilization, account.FieldQuietHoursStartMinute, account.FieldQuietHoursEndMinute:
 			values[i] = new(sql.NullInt64)
-		case account.FieldName, account.FieldNotes, account.FieldPlatform, account.FieldType, account.FieldStatus, account.FieldErrorMessage, account.FieldSessionWindowStatus:
+		case account.FieldName, account.FieldNotes, account.FieldPlatform, account.FieldType, account.FieldAffinityGroup, account.FieldStatus, account.FieldErrorMessage, account.FieldSessionWindowStatus:
 			values[i] = new(sql.NullString)
 		case account.FieldCreatedAt, account.FieldUpdatedAt, account.FieldDeletedAt, account.FieldLastUsedAt, account.FieldExpiresAt, account.FieldRateLimitedAt, account.FieldRateLimitResetAt, account.FieldOverloadUntil, account.FieldSessionWindowStart, account.FieldSessionWindowEnd:
 			values[i] = new(sql.NullTime)
@@ -245,6 +255,18 @@ func (_m *Account) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Priority = int(value.Int64)
 			}
+		case account.FieldAffinityGroup:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field affinity_group", values[i])
+			} else if value.Valid {
+				_m.AffinityGroup = value.String
+			}
+		case account.FieldMaxLineSize:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_line_size", values[i])
+			} else if value.Valid {
+				_m.MaxLineSize = int(value.Int64)
+			}
 		case account.FieldRateMultiplier:
 			if value, ok := values[i].(*sql.NullFloat64); !ok {
 				return fmt.Errorf("unexpected type %T for field rate_multiplier", values[i])
@@ -332,6 +354,27 @@ func (_m *Account) assignValues(columns []string, values []any) error {
 				_m.SessionWindowStatus = new(string)
 				*_m.SessionWindowStatus = value.String
 			}
+		case account.FieldSessionWindowUtilization:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field session_window_utilization", values[i])
+			} else if value.Valid {
+				_m.SessionWindowUtilization = new(int)
+				*_m.SessionWindowUtilization = int(value.Int64)
+			}
+		case account.FieldQuietHoursStartMinute:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field quiet_hours_start_minute", values[i])
+			} else if value.Valid {
+				_m.QuietHoursStartMinute = new(int)
+				*_m.QuietHoursStartMinute = int(value.Int64)
+			}
+		case account.FieldQuietHoursEndMinute:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field quiet_hours_end_minute", values[i])
+			} else if value.Valid {
+				_m.QuietHoursEndMinute = new(int)
+				*_m.QuietHoursEndMinute = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -430,6 +473,12 @@ func (_m *Account) String() string {
 	builder.WriteString("priority=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Priority))
 	builder.WriteString(", ")
+	builder.WriteString("affinity_group=")
+	builder.WriteString(_m.AffinityGroup)
+	builder.WriteString(", ")
+	builder.WriteString("max_line_size=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxLineSize))
+	builder.WriteString(", ")
 	builder.WriteString("rate_multiplier=")
 	builder.WriteString(fmt.Sprintf("%v", _m.RateMultiplier))
 	builder.WriteString(", ")
@@ -486,6 +535,21 @@ func (_m *Account) String() string {
 		builder.WriteString("session_window_status=")
 		builder.WriteString(*v)
 	}
+	builder.WriteString(", ")
+	if v := _m.SessionWindowUtilization; v != nil {
+		builder.WriteString("session_window_utilization=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.QuietHoursStartMinute; v != nil {
+		builder.WriteString("quiet_hours_start_minute=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.QuietHoursEndMinute; v != nil {
+		builder.WriteString("quiet_hours_end_minute=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
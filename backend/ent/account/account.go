@@ -39,6 +39,10 @@ const (
 	FieldConcurrency = "concurrency"
 	// FieldPriority holds the string denoting the priority field in the database.
 	FieldPriority = "priority"
+	// FieldAffinityGroup holds the string denoting the affinity_group field in the database.
+	FieldAffinityGroup = "affinity_group"
+	// FieldMaxLineSize holds the string denoting the max_line_size field in the database.
+	FieldMaxLineSize = "max_line_size"
 	// FieldRateMultiplier holds the string denoting the rate_multiplier field in the database.
 	FieldRateMultiplier = "rate_multiplier"
 	// FieldStatus holds the string denoting the status field in the database.
@@ -65,6 +69,12 @@ const (
 	FieldSessionWindowEnd = "session_window_end"
 	// FieldSessionWindowStatus holds the string denoting the session_window_status field in the database.
 	FieldSessionWindowStatus = "session_window_status"
+	// FieldSessionWindowUtilization holds the string denoting the session_window_utilization field in the database.
+	FieldSessionWindowUtilization = "session_window_utilization"
+	// FieldQuietHoursStartMinute holds the string denoting the quiet_hours_start_minute field in the database.
+	FieldQuietHoursStartMinute = "quiet_hours_start_minute"
+	// FieldQuietHoursEndMinute holds the string denoting the quiet_hours_end_minute field in the database.
+	FieldQuietHoursEndMinute = "quiet_hours_end_minute"
 	// EdgeGroups holds the string denoting the groups edge name in mutations.
 	EdgeGroups = "groups"
 	// EdgeProxy holds the string denoting the proxy edge name in mutations.
@@ -118,6 +128,8 @@ var Columns = []string{
 	FieldProxyID,
 	FieldConcurrency,
 	FieldPriority,
+	FieldAffinityGroup,
+	FieldMaxLineSize,
 	FieldRateMultiplier,
 	FieldStatus,
 	FieldErrorMessage,
@@ -131,6 +143,9 @@ var Columns = []string{
 	FieldSessionWindowStart,
 	FieldSessionWindowEnd,
 	FieldSessionWindowStatus,
+	FieldSessionWindowUtilization,
+	FieldQuietHoursStartMinute,
+	FieldQuietHoursEndMinute,
 }
 
 var (
@@ -177,6 +192,12 @@ var (
 	DefaultConcurrency int
 	// DefaultPriority holds the default value on creation for the "priority" field.
 	DefaultPriority int
+	// DefaultAffinityGroup holds the default value on creation for the "affinity_group" field.
+	DefaultAffinityGroup string
+	// AffinityGroupValidator is a validator for the "affinity_group" field. It is called by the builders before save.
+	AffinityGroupValidator func(string) error
+	// DefaultMaxLineSize holds the default value on creation for the "max_line_size" field.
+	DefaultMaxLineSize int
 	// DefaultRateMultiplier holds the default value on creation for the "rate_multiplier" field.
 	DefaultRateMultiplier float64
 	// DefaultStatus holds the default value on creation for the "status" field.
@@ -189,6 +210,10 @@ var (
 	DefaultSchedulable bool
 	// SessionWindowStatusValidator is a validator for the "session_window_status" field. It is called by the builders before save.
 	SessionWindowStatusValidator func(string) error
+	// QuietHoursStartMinuteValidator is a validator for the "quiet_hours_start_minute" field. It is called by the builders before save.
+	QuietHoursStartMinuteValidator func(int) error
+	// QuietHoursEndMinuteValidator is a validator for the "quiet_hours_end_minute" field. It is called by the builders before save.
+	QuietHoursEndMinuteValidator func(int) error
 )
 
 // OrderOption defines the ordering options for the Account queries.
@@ -249,6 +274,16 @@ func ByPriority(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPriority, opts...).ToFunc()
 }
 
+// ByAffinityGroup orders the results by the affinity_group field.
+func ByAffinityGroup(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAffinityGroup, opts...).ToFunc()
+}
+
+// ByMaxLineSize orders the results by the max_line_size field.
+func ByMaxLineSize(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxLineSize, opts...).ToFunc()
+}
+
 // ByRateMultiplier orders the results by the rate_multiplier field.
 func ByRateMultiplier(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldRateMultiplier, opts...).ToFunc()
@@ -314,6 +349,21 @@ func BySessionWindowStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSessionWindowStatus, opts...).ToFunc()
 }
 
+// BySessionWindowUtilization orders the results by the session_window_utilization field.
+func BySessionWindowUtilization(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSessionWindowUtilization, opts...).ToFunc()
+}
+
+// ByQuietHoursStartMinute orders the results by the quiet_hours_start_minute field.
+func ByQuietHoursStartMinute(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQuietHoursStartMinute, opts...).ToFunc()
+}
+
+// ByQuietHoursEndMinute orders the results by the quiet_hours_end_minute field.
+func ByQuietHoursEndMinute(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQuietHoursEndMinute, opts...).ToFunc()
+}
+
 // ByGroupsCount orders the results by groups count.
 func ByGroupsCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
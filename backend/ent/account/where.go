@@ -105,6 +105,16 @@ func Priority(v int) predicate.Account {
 	return predicate.Account(sql.FieldEQ(FieldPriority, v))
 }
 
+// AffinityGroup applies equality check predicate on the "affinity_group" field. It's identical to AffinityGroupEQ.
+func AffinityGroup(v string) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldAffinityGroup, v))
+}
+
+// MaxLineSize applies equality check predicate on the "max_line_size" field. It's identical to MaxLineSizeEQ.
+func MaxLineSize(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldMaxLineSize, v))
+}
+
 // RateMultiplier applies equality check predicate on the "rate_multiplier" field. It's identical to RateMultiplierEQ.
 func RateMultiplier(v float64) predicate.Account {
 	return predicate.Account(sql.FieldEQ(FieldRateMultiplier, v))
@@ -170,6 +180,21 @@ func SessionWindowStatus(v string) predicate.Account {
 	return predicate.Account(sql.FieldEQ(FieldSessionWindowStatus, v))
 }
 
+// SessionWindowUtilization applies equality check predicate on the "session_window_utilization" field. It's identical to SessionWindowUtilizationEQ.
+func SessionWindowUtilization(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldSessionWindowUtilization, v))
+}
+
+// QuietHoursStartMinute applies equality check predicate on the "quiet_hours_start_minute" field. It's identical to QuietHoursStartMinuteEQ.
+func QuietHoursStartMinute(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursEndMinute applies equality check predicate on the "quiet_hours_end_minute" field. It's identical to QuietHoursEndMinuteEQ.
+func QuietHoursEndMinute(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldQuietHoursEndMinute, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Account {
 	return predicate.Account(sql.FieldEQ(FieldCreatedAt, v))
@@ -680,6 +705,121 @@ func PriorityLTE(v int) predicate.Account {
 	return predicate.Account(sql.FieldLTE(FieldPriority, v))
 }
 
+// AffinityGroupEQ applies the EQ predicate on the "affinity_group" field.
+func AffinityGroupEQ(v string) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldAffinityGroup, v))
+}
+
+// AffinityGroupNEQ applies the NEQ predicate on the "affinity_group" field.
+func AffinityGroupNEQ(v string) predicate.Account {
+	return predicate.Account(sql.FieldNEQ(FieldAffinityGroup, v))
+}
+
+// AffinityGroupIn applies the In predicate on the "affinity_group" field.
+func AffinityGroupIn(vs ...string) predicate.Account {
+	return predicate.Account(sql.FieldIn(FieldAffinityGroup, vs...))
+}
+
+// AffinityGroupNotIn applies the NotIn predicate on the "affinity_group" field.
+func AffinityGroupNotIn(vs ...string) predicate.Account {
+	return predicate.Account(sql.FieldNotIn(FieldAffinityGroup, vs...))
+}
+
+// AffinityGroupGT applies the GT predicate on the "affinity_group" field.
+func AffinityGroupGT(v string) predicate.Account {
+	return predicate.Account(sql.FieldGT(FieldAffinityGroup, v))
+}
+
+// AffinityGroupGTE applies the GTE predicate on the "affinity_group" field.
+func AffinityGroupGTE(v string) predicate.Account {
+	return predicate.Account(sql.FieldGTE(FieldAffinityGroup, v))
+}
+
+// AffinityGroupLT applies the LT predicate on the "affinity_group" field.
+func AffinityGroupLT(v string) predicate.Account {
+	return predicate.Account(sql.FieldLT(FieldAffinityGroup, v))
+}
+
+// AffinityGroupLTE applies the LTE predicate on the "affinity_group" field.
+func AffinityGroupLTE(v string) predicate.Account {
+	return predicate.Account(sql.FieldLTE(FieldAffinityGroup, v))
+}
+
+// AffinityGroupContains applies the Contains predicate on the "affinity_group" field.
+func AffinityGroupContains(v string) predicate.Account {
+	return predicate.Account(sql.FieldContains(FieldAffinityGroup, v))
+}
+
+// AffinityGroupHasPrefix applies the HasPrefix predicate on the "affinity_group" field.
+func AffinityGroupHasPrefix(v string) predicate.Account {
+	return predicate.Account(sql.FieldHasPrefix(FieldAffinityGroup, v))
+}
+
+// AffinityGroupHasSuffix applies the HasSuffix predicate on the "affinity_group" field.
+func AffinityGroupHasSuffix(v string) predicate.Account {
+	return predicate.Account(sql.FieldHasSuffix(FieldAffinityGroup, v))
+}
+
+// AffinityGroupIsNil applies the IsNil predicate on the "affinity_group" field.
+func AffinityGroupIsNil() predicate.Account {
+	return predicate.Account(sql.FieldIsNull(FieldAffinityGroup))
+}
+
+// AffinityGroupNotNil applies the NotNil predicate on the "affinity_group" field.
+func AffinityGroupNotNil() predicate.Account {
+	return predicate.Account(sql.FieldNotNull(FieldAffinityGroup))
+}
+
+// AffinityGroupEqualFold applies the EqualFold predicate on the "affinity_group" field.
+func AffinityGroupEqualFold(v string) predicate.Account {
+	return predicate.Account(sql.FieldEqualFold(FieldAffinityGroup, v))
+}
+
+// AffinityGroupContainsFold applies the ContainsFold predicate on the "affinity_group" field.
+func AffinityGroupContainsFold(v string) predicate.Account {
+	return predicate.Account(sql.FieldContainsFold(FieldAffinityGroup, v))
+}
+
+// MaxLineSizeEQ applies the EQ predicate on the "max_line_size" field.
+func MaxLineSizeEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldMaxLineSize, v))
+}
+
+// MaxLineSizeNEQ applies the NEQ predicate on the "max_line_size" field.
+func MaxLineSizeNEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldNEQ(FieldMaxLineSize, v))
+}
+
+// MaxLineSizeIn applies the In predicate on the "max_line_size" field.
+func MaxLineSizeIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldIn(FieldMaxLineSize, vs...))
+}
+
+// MaxLineSizeNotIn applies the NotIn predicate on the "max_line_size" field.
+func MaxLineSizeNotIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldNotIn(FieldMaxLineSize, vs...))
+}
+
+// MaxLineSizeGT applies the GT predicate on the "max_line_size" field.
+func MaxLineSizeGT(v int) predicate.Account {
+	return predicate.Account(sql.FieldGT(FieldMaxLineSize, v))
+}
+
+// MaxLineSizeGTE applies the GTE predicate on the "max_line_size" field.
+func MaxLineSizeGTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldGTE(FieldMaxLineSize, v))
+}
+
+// MaxLineSizeLT applies the LT predicate on the "max_line_size" field.
+func MaxLineSizeLT(v int) predicate.Account {
+	return predicate.Account(sql.FieldLT(FieldMaxLineSize, v))
+}
+
+// MaxLineSizeLTE applies the LTE predicate on the "max_line_size" field.
+func MaxLineSizeLTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldLTE(FieldMaxLineSize, v))
+}
+
 // RateMultiplierEQ applies the EQ predicate on the "rate_multiplier" field.
 func RateMultiplierEQ(v float64) predicate.Account {
 	return predicate.Account(sql.FieldEQ(FieldRateMultiplier, v))
@@ -1305,6 +1445,156 @@ func SessionWindowStatusContainsFold(v string) predicate.Account {
 	return predicate.Account(sql.FieldContainsFold(FieldSessionWindowStatus, v))
 }
 
+// SessionWindowUtilizationEQ applies the EQ predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationNEQ applies the NEQ predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationNEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldNEQ(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationIn applies the In predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldIn(FieldSessionWindowUtilization, vs...))
+}
+
+// SessionWindowUtilizationNotIn applies the NotIn predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationNotIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldNotIn(FieldSessionWindowUtilization, vs...))
+}
+
+// SessionWindowUtilizationGT applies the GT predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationGT(v int) predicate.Account {
+	return predicate.Account(sql.FieldGT(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationGTE applies the GTE predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationGTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldGTE(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationLT applies the LT predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationLT(v int) predicate.Account {
+	return predicate.Account(sql.FieldLT(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationLTE applies the LTE predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationLTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldLTE(FieldSessionWindowUtilization, v))
+}
+
+// SessionWindowUtilizationIsNil applies the IsNil predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationIsNil() predicate.Account {
+	return predicate.Account(sql.FieldIsNull(FieldSessionWindowUtilization))
+}
+
+// SessionWindowUtilizationNotNil applies the NotNil predicate on the "session_window_utilization" field.
+func SessionWindowUtilizationNotNil() predicate.Account {
+	return predicate.Account(sql.FieldNotNull(FieldSessionWindowUtilization))
+}
+
+// QuietHoursStartMinuteEQ applies the EQ predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteNEQ applies the NEQ predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteNEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldNEQ(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteIn applies the In predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldIn(FieldQuietHoursStartMinute, vs...))
+}
+
+// QuietHoursStartMinuteNotIn applies the NotIn predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteNotIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldNotIn(FieldQuietHoursStartMinute, vs...))
+}
+
+// QuietHoursStartMinuteGT applies the GT predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteGT(v int) predicate.Account {
+	return predicate.Account(sql.FieldGT(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteGTE applies the GTE predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteGTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldGTE(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteLT applies the LT predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteLT(v int) predicate.Account {
+	return predicate.Account(sql.FieldLT(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteLTE applies the LTE predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteLTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldLTE(FieldQuietHoursStartMinute, v))
+}
+
+// QuietHoursStartMinuteIsNil applies the IsNil predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteIsNil() predicate.Account {
+	return predicate.Account(sql.FieldIsNull(FieldQuietHoursStartMinute))
+}
+
+// QuietHoursStartMinuteNotNil applies the NotNil predicate on the "quiet_hours_start_minute" field.
+func QuietHoursStartMinuteNotNil() predicate.Account {
+	return predicate.Account(sql.FieldNotNull(FieldQuietHoursStartMinute))
+}
+
+// QuietHoursEndMinuteEQ applies the EQ predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldEQ(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteNEQ applies the NEQ predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteNEQ(v int) predicate.Account {
+	return predicate.Account(sql.FieldNEQ(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteIn applies the In predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldIn(FieldQuietHoursEndMinute, vs...))
+}
+
+// QuietHoursEndMinuteNotIn applies the NotIn predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteNotIn(vs ...int) predicate.Account {
+	return predicate.Account(sql.FieldNotIn(FieldQuietHoursEndMinute, vs...))
+}
+
+// QuietHoursEndMinuteGT applies the GT predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteGT(v int) predicate.Account {
+	return predicate.Account(sql.FieldGT(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteGTE applies the GTE predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteGTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldGTE(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteLT applies the LT predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteLT(v int) predicate.Account {
+	return predicate.Account(sql.FieldLT(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteLTE applies the LTE predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteLTE(v int) predicate.Account {
+	return predicate.Account(sql.FieldLTE(FieldQuietHoursEndMinute, v))
+}
+
+// QuietHoursEndMinuteIsNil applies the IsNil predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteIsNil() predicate.Account {
+	return predicate.Account(sql.FieldIsNull(FieldQuietHoursEndMinute))
+}
+
+// QuietHoursEndMinuteNotNil applies the NotNil predicate on the "quiet_hours_end_minute" field.
+func QuietHoursEndMinuteNotNil() predicate.Account {
+	return predicate.Account(sql.FieldNotNull(FieldQuietHoursEndMinute))
+}
+
 // HasGroups applies the HasEdge predicate on the "groups" edge.
 func HasGroups() predicate.Account {
 	return predicate.Account(func(s *sql.Selector) {
@@ -153,6 +153,34 @@ func (_c *AccountCreate) SetNillablePriority(v *int) *AccountCreate {
 	return _c
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (_c *AccountCreate) SetAffinityGroup(v string) *AccountCreate {
+	_c.mutation.SetAffinityGroup(v)
+	return _c
+}
+
+// SetNillableAffinityGroup sets the "affinity_group" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableAffinityGroup(v *string) *AccountCreate {
+	if v != nil {
+		_c.SetAffinityGroup(*v)
+	}
+	return _c
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (_c *AccountCreate) SetMaxLineSize(v int) *AccountCreate {
+	_c.mutation.SetMaxLineSize(v)
+	return _c
+}
+
+// SetNillableMaxLineSize sets the "max_line_size" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableMaxLineSize(v *int) *AccountCreate {
+	if v != nil {
+		_c.SetMaxLineSize(*v)
+	}
+	return _c
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (_c *AccountCreate) SetRateMultiplier(v float64) *AccountCreate {
 	_c.mutation.SetRateMultiplier(v)
@@ -335,6 +363,48 @@ func (_c *AccountCreate) SetNillableSessionWindowStatus(v *string) *AccountCreat
 	return _c
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (_c *AccountCreate) SetSessionWindowUtilization(v int) *AccountCreate {
+	_c.mutation.SetSessionWindowUtilization(v)
+	return _c
+}
+
+// SetNillableSessionWindowUtilization sets the "session_window_utilization" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableSessionWindowUtilization(v *int) *AccountCreate {
+	if v != nil {
+		_c.SetSessionWindowUtilization(*v)
+	}
+	return _c
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (_c *AccountCreate) SetQuietHoursStartMinute(v int) *AccountCreate {
+	_c.mutation.SetQuietHoursStartMinute(v)
+	return _c
+}
+
+// SetNillableQuietHoursStartMinute sets the "quiet_hours_start_minute" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableQuietHoursStartMinute(v *int) *AccountCreate {
+	if v != nil {
+		_c.SetQuietHoursStartMinute(*v)
+	}
+	return _c
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (_c *AccountCreate) SetQuietHoursEndMinute(v int) *AccountCreate {
+	_c.mutation.SetQuietHoursEndMinute(v)
+	return _c
+}
+
+// SetNillableQuietHoursEndMinute sets the "quiet_hours_end_minute" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableQuietHoursEndMinute(v *int) *AccountCreate {
+	if v != nil {
+		_c.SetQuietHoursEndMinute(*v)
+	}
+	return _c
+}
+
 // AddGroupIDs adds the "groups" edge to the Group entity by IDs.
 func (_c *AccountCreate) AddGroupIDs(ids ...int64) *AccountCreate {
 	_c.mutation.AddGroupIDs(ids...)
@@ -443,6 +513,14 @@ func (_c *AccountCreate) defaults() error {
 		v := account.DefaultPriority
 		_c.mutation.SetPriority(v)
 	}
+	if _, ok := _c.mutation.AffinityGroup(); !ok {
+		v := account.DefaultAffinityGroup
+		_c.mutation.SetAffinityGroup(v)
+	}
+	if _, ok := _c.mutation.MaxLineSize(); !ok {
+		v := account.DefaultMaxLineSize
+		_c.mutation.SetMaxLineSize(v)
+	}
 	if _, ok := _c.mutation.RateMultiplier(); !ok {
 		v := account.DefaultRateMultiplier
 		_c.mutation.SetRateMultiplier(v)
@@ -506,6 +584,14 @@ func (_c *AccountCreate) check() error {
 	if _, ok := _c.mutation.Priority(); !ok {
 		return &ValidationError{Name: "priority", err: errors.New(`ent: missing required field "Account.priority"`)}
 	}
+	if v, ok := _c.mutation.AffinityGroup(); ok {
+		if err := account.AffinityGroupValidator(v); err != nil {
+			return &ValidationError{Name: "affinity_group", err: fmt.Errorf(`ent: validator failed for field "Account.affinity_group": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MaxLineSize(); !ok {
+		return &ValidationError{Name: "max_line_size", err: errors.New(`ent: missing required field "Account.max_line_size"`)}
+	}
 	if _, ok := _c.mutation.RateMultiplier(); !ok {
 		return &ValidationError{Name: "rate_multiplier", err: errors.New(`ent: missing required field "Account.rate_multiplier"`)}
 	}
@@ -528,6 +614,16 @@ func (_c *AccountCreate) check() error {
 			return &ValidationError{Name: "session_window_status", err: fmt.Errorf(`ent: validator failed for field "Account.session_window_status": %w`, err)}
 		}
 	}
+	if v, ok := _c.mutation.QuietHoursStartMinute(); ok {
+		if err := account.QuietHoursStartMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_start_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_start_minute": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.QuietHoursEndMinute(); ok {
+		if err := account.QuietHoursEndMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_end_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_end_minute": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -599,6 +695,14 @@ func (_c *AccountCreate) createSpec() (*Account, *sqlgraph.CreateSpec) {
 		_spec.SetField(account.FieldPriority, field.TypeInt, value)
 		_node.Priority = value
 	}
+	if value, ok := _c.mutation.AffinityGroup(); ok {
+		_spec.SetField(account.FieldAffinityGroup, field.TypeString, value)
+		_node.AffinityGroup = value
+	}
+	if value, ok := _c.mutation.MaxLineSize(); ok {
+		_spec.SetField(account.FieldMaxLineSize, field.TypeInt, value)
+		_node.MaxLineSize = value
+	}
 	if value, ok := _c.mutation.RateMultiplier(); ok {
 		_spec.SetField(account.FieldRateMultiplier, field.TypeFloat64, value)
 		_node.RateMultiplier = value
@@ -651,6 +755,18 @@ func (_c *AccountCreate) createSpec() (*Account, *sqlgraph.CreateSpec) {
 		_spec.SetField(account.FieldSessionWindowStatus, field.TypeString, value)
 		_node.SessionWindowStatus = &value
 	}
+	if value, ok := _c.mutation.SessionWindowUtilization(); ok {
+		_spec.SetField(account.FieldSessionWindowUtilization, field.TypeInt, value)
+		_node.SessionWindowUtilization = &value
+	}
+	if value, ok := _c.mutation.QuietHoursStartMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursStartMinute, field.TypeInt, value)
+		_node.QuietHoursStartMinute = &value
+	}
+	if value, ok := _c.mutation.QuietHoursEndMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursEndMinute, field.TypeInt, value)
+		_node.QuietHoursEndMinute = &value
+	}
 	if nodes := _c.mutation.GroupsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -918,6 +1034,42 @@ func (u *AccountUpsert) AddPriority(v int) *AccountUpsert {
 	return u
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (u *AccountUpsert) SetAffinityGroup(v string) *AccountUpsert {
+	u.Set(account.FieldAffinityGroup, v)
+	return u
+}
+
+// UpdateAffinityGroup sets the "affinity_group" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateAffinityGroup() *AccountUpsert {
+	u.SetExcluded(account.FieldAffinityGroup)
+	return u
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (u *AccountUpsert) ClearAffinityGroup() *AccountUpsert {
+	u.SetNull(account.FieldAffinityGroup)
+	return u
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (u *AccountUpsert) SetMaxLineSize(v int) *AccountUpsert {
+	u.Set(account.FieldMaxLineSize, v)
+	return u
+}
+
+// UpdateMaxLineSize sets the "max_line_size" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateMaxLineSize() *AccountUpsert {
+	u.SetExcluded(account.FieldMaxLineSize)
+	return u
+}
+
+// AddMaxLineSize adds v to the "max_line_size" field.
+func (u *AccountUpsert) AddMaxLineSize(v int) *AccountUpsert {
+	u.Add(account.FieldMaxLineSize, v)
+	return u
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (u *AccountUpsert) SetRateMultiplier(v float64) *AccountUpsert {
 	u.Set(account.FieldRateMultiplier, v)
@@ -1134,6 +1286,78 @@ func (u *AccountUpsert) ClearSessionWindowStatus() *AccountUpsert {
 	return u
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (u *AccountUpsert) SetSessionWindowUtilization(v int) *AccountUpsert {
+	u.Set(account.FieldSessionWindowUtilization, v)
+	return u
+}
+
+// UpdateSessionWindowUtilization sets the "session_window_utilization" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateSessionWindowUtilization() *AccountUpsert {
+	u.SetExcluded(account.FieldSessionWindowUtilization)
+	return u
+}
+
+// AddSessionWindowUtilization adds v to the "session_window_utilization" field.
+func (u *AccountUpsert) AddSessionWindowUtilization(v int) *AccountUpsert {
+	u.Add(account.FieldSessionWindowUtilization, v)
+	return u
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (u *AccountUpsert) ClearSessionWindowUtilization() *AccountUpsert {
+	u.SetNull(account.FieldSessionWindowUtilization)
+	return u
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (u *AccountUpsert) SetQuietHoursStartMinute(v int) *AccountUpsert {
+	u.Set(account.FieldQuietHoursStartMinute, v)
+	return u
+}
+
+// UpdateQuietHoursStartMinute sets the "quiet_hours_start_minute" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateQuietHoursStartMinute() *AccountUpsert {
+	u.SetExcluded(account.FieldQuietHoursStartMinute)
+	return u
+}
+
+// AddQuietHoursStartMinute adds v to the "quiet_hours_start_minute" field.
+func (u *AccountUpsert) AddQuietHoursStartMinute(v int) *AccountUpsert {
+	u.Add(account.FieldQuietHoursStartMinute, v)
+	return u
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (u *AccountUpsert) ClearQuietHoursStartMinute() *AccountUpsert {
+	u.SetNull(account.FieldQuietHoursStartMinute)
+	return u
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (u *AccountUpsert) SetQuietHoursEndMinute(v int) *AccountUpsert {
+	u.Set(account.FieldQuietHoursEndMinute, v)
+	return u
+}
+
+// UpdateQuietHoursEndMinute sets the "quiet_hours_end_minute" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateQuietHoursEndMinute() *AccountUpsert {
+	u.SetExcluded(account.FieldQuietHoursEndMinute)
+	return u
+}
+
+// AddQuietHoursEndMinute adds v to the "quiet_hours_end_minute" field.
+func (u *AccountUpsert) AddQuietHoursEndMinute(v int) *AccountUpsert {
+	u.Add(account.FieldQuietHoursEndMinute, v)
+	return u
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (u *AccountUpsert) ClearQuietHoursEndMinute() *AccountUpsert {
+	u.SetNull(account.FieldQuietHoursEndMinute)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1368,6 +1592,48 @@ func (u *AccountUpsertOne) UpdatePriority() *AccountUpsertOne {
 	})
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (u *AccountUpsertOne) SetAffinityGroup(v string) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetAffinityGroup(v)
+	})
+}
+
+// UpdateAffinityGroup sets the "affinity_group" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateAffinityGroup() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateAffinityGroup()
+	})
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (u *AccountUpsertOne) ClearAffinityGroup() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearAffinityGroup()
+	})
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (u *AccountUpsertOne) SetMaxLineSize(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetMaxLineSize(v)
+	})
+}
+
+// AddMaxLineSize adds v to the "max_line_size" field.
+func (u *AccountUpsertOne) AddMaxLineSize(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddMaxLineSize(v)
+	})
+}
+
+// UpdateMaxLineSize sets the "max_line_size" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateMaxLineSize() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateMaxLineSize()
+	})
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (u *AccountUpsertOne) SetRateMultiplier(v float64) *AccountUpsertOne {
 	return u.Update(func(s *AccountUpsert) {
@@ -1620,6 +1886,90 @@ func (u *AccountUpsertOne) ClearSessionWindowStatus() *AccountUpsertOne {
 	})
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (u *AccountUpsertOne) SetSessionWindowUtilization(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetSessionWindowUtilization(v)
+	})
+}
+
+// AddSessionWindowUtilization adds v to the "session_window_utilization" field.
+func (u *AccountUpsertOne) AddSessionWindowUtilization(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddSessionWindowUtilization(v)
+	})
+}
+
+// UpdateSessionWindowUtilization sets the "session_window_utilization" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateSessionWindowUtilization() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateSessionWindowUtilization()
+	})
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (u *AccountUpsertOne) ClearSessionWindowUtilization() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearSessionWindowUtilization()
+	})
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (u *AccountUpsertOne) SetQuietHoursStartMinute(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetQuietHoursStartMinute(v)
+	})
+}
+
+// AddQuietHoursStartMinute adds v to the "quiet_hours_start_minute" field.
+func (u *AccountUpsertOne) AddQuietHoursStartMinute(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddQuietHoursStartMinute(v)
+	})
+}
+
+// UpdateQuietHoursStartMinute sets the "quiet_hours_start_minute" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateQuietHoursStartMinute() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateQuietHoursStartMinute()
+	})
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (u *AccountUpsertOne) ClearQuietHoursStartMinute() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearQuietHoursStartMinute()
+	})
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (u *AccountUpsertOne) SetQuietHoursEndMinute(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetQuietHoursEndMinute(v)
+	})
+}
+
+// AddQuietHoursEndMinute adds v to the "quiet_hours_end_minute" field.
+func (u *AccountUpsertOne) AddQuietHoursEndMinute(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddQuietHoursEndMinute(v)
+	})
+}
+
+// UpdateQuietHoursEndMinute sets the "quiet_hours_end_minute" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateQuietHoursEndMinute() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateQuietHoursEndMinute()
+	})
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (u *AccountUpsertOne) ClearQuietHoursEndMinute() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearQuietHoursEndMinute()
+	})
+}
+
 // Exec executes the query.
 func (u *AccountUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2020,6 +2370,48 @@ func (u *AccountUpsertBulk) UpdatePriority() *AccountUpsertBulk {
 	})
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (u *AccountUpsertBulk) SetAffinityGroup(v string) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetAffinityGroup(v)
+	})
+}
+
+// UpdateAffinityGroup sets the "affinity_group" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateAffinityGroup() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateAffinityGroup()
+	})
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (u *AccountUpsertBulk) ClearAffinityGroup() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearAffinityGroup()
+	})
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (u *AccountUpsertBulk) SetMaxLineSize(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetMaxLineSize(v)
+	})
+}
+
+// AddMaxLineSize adds v to the "max_line_size" field.
+func (u *AccountUpsertBulk) AddMaxLineSize(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddMaxLineSize(v)
+	})
+}
+
+// UpdateMaxLineSize sets the "max_line_size" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateMaxLineSize() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateMaxLineSize()
+	})
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (u *AccountUpsertBulk) SetRateMultiplier(v float64) *AccountUpsertBulk {
 	return u.Update(func(s *AccountUpsert) {
@@ -2272,6 +2664,90 @@ func (u *AccountUpsertBulk) ClearSessionWindowStatus() *AccountUpsertBulk {
 	})
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (u *AccountUpsertBulk) SetSessionWindowUtilization(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetSessionWindowUtilization(v)
+	})
+}
+
+// AddSessionWindowUtilization adds v to the "session_window_utilization" field.
+func (u *AccountUpsertBulk) AddSessionWindowUtilization(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddSessionWindowUtilization(v)
+	})
+}
+
+// UpdateSessionWindowUtilization sets the "session_window_utilization" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateSessionWindowUtilization() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateSessionWindowUtilization()
+	})
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (u *AccountUpsertBulk) ClearSessionWindowUtilization() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearSessionWindowUtilization()
+	})
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (u *AccountUpsertBulk) SetQuietHoursStartMinute(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetQuietHoursStartMinute(v)
+	})
+}
+
+// AddQuietHoursStartMinute adds v to the "quiet_hours_start_minute" field.
+func (u *AccountUpsertBulk) AddQuietHoursStartMinute(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddQuietHoursStartMinute(v)
+	})
+}
+
+// UpdateQuietHoursStartMinute sets the "quiet_hours_start_minute" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateQuietHoursStartMinute() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateQuietHoursStartMinute()
+	})
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (u *AccountUpsertBulk) ClearQuietHoursStartMinute() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearQuietHoursStartMinute()
+	})
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (u *AccountUpsertBulk) SetQuietHoursEndMinute(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetQuietHoursEndMinute(v)
+	})
+}
+
+// AddQuietHoursEndMinute adds v to the "quiet_hours_end_minute" field.
+func (u *AccountUpsertBulk) AddQuietHoursEndMinute(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddQuietHoursEndMinute(v)
+	})
+}
+
+// UpdateQuietHoursEndMinute sets the "quiet_hours_end_minute" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateQuietHoursEndMinute() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateQuietHoursEndMinute()
+	})
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (u *AccountUpsertBulk) ClearQuietHoursEndMinute() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearQuietHoursEndMinute()
+	})
+}
+
 // Exec executes the query.
 func (u *AccountUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
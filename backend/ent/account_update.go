@@ -193,6 +193,47 @@ func (_u *AccountUpdate) AddPriority(v int) *AccountUpdate {
 	return _u
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (_u *AccountUpdate) SetAffinityGroup(v string) *AccountUpdate {
+	_u.mutation.SetAffinityGroup(v)
+	return _u
+}
+
+// SetNillableAffinityGroup sets the "affinity_group" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableAffinityGroup(v *string) *AccountUpdate {
+	if v != nil {
+		_u.SetAffinityGroup(*v)
+	}
+	return _u
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (_u *AccountUpdate) ClearAffinityGroup() *AccountUpdate {
+	_u.mutation.ClearAffinityGroup()
+	return _u
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (_u *AccountUpdate) SetMaxLineSize(v int) *AccountUpdate {
+	_u.mutation.ResetMaxLineSize()
+	_u.mutation.SetMaxLineSize(v)
+	return _u
+}
+
+// SetNillableMaxLineSize sets the "max_line_size" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableMaxLineSize(v *int) *AccountUpdate {
+	if v != nil {
+		_u.SetMaxLineSize(*v)
+	}
+	return _u
+}
+
+// AddMaxLineSize adds value to the "max_line_size" field.
+func (_u *AccountUpdate) AddMaxLineSize(v int) *AccountUpdate {
+	_u.mutation.AddMaxLineSize(v)
+	return _u
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (_u *AccountUpdate) SetRateMultiplier(v float64) *AccountUpdate {
 	_u.mutation.ResetRateMultiplier()
@@ -436,6 +477,87 @@ func (_u *AccountUpdate) ClearSessionWindowStatus() *AccountUpdate {
 	return _u
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (_u *AccountUpdate) SetSessionWindowUtilization(v int) *AccountUpdate {
+	_u.mutation.ResetSessionWindowUtilization()
+	_u.mutation.SetSessionWindowUtilization(v)
+	return _u
+}
+
+// SetNillableSessionWindowUtilization sets the "session_window_utilization" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableSessionWindowUtilization(v *int) *AccountUpdate {
+	if v != nil {
+		_u.SetSessionWindowUtilization(*v)
+	}
+	return _u
+}
+
+// AddSessionWindowUtilization adds value to the "session_window_utilization" field.
+func (_u *AccountUpdate) AddSessionWindowUtilization(v int) *AccountUpdate {
+	_u.mutation.AddSessionWindowUtilization(v)
+	return _u
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (_u *AccountUpdate) ClearSessionWindowUtilization() *AccountUpdate {
+	_u.mutation.ClearSessionWindowUtilization()
+	return _u
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (_u *AccountUpdate) SetQuietHoursStartMinute(v int) *AccountUpdate {
+	_u.mutation.ResetQuietHoursStartMinute()
+	_u.mutation.SetQuietHoursStartMinute(v)
+	return _u
+}
+
+// SetNillableQuietHoursStartMinute sets the "quiet_hours_start_minute" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableQuietHoursStartMinute(v *int) *AccountUpdate {
+	if v != nil {
+		_u.SetQuietHoursStartMinute(*v)
+	}
+	return _u
+}
+
+// AddQuietHoursStartMinute adds value to the "quiet_hours_start_minute" field.
+func (_u *AccountUpdate) AddQuietHoursStartMinute(v int) *AccountUpdate {
+	_u.mutation.AddQuietHoursStartMinute(v)
+	return _u
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (_u *AccountUpdate) ClearQuietHoursStartMinute() *AccountUpdate {
+	_u.mutation.ClearQuietHoursStartMinute()
+	return _u
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (_u *AccountUpdate) SetQuietHoursEndMinute(v int) *AccountUpdate {
+	_u.mutation.ResetQuietHoursEndMinute()
+	_u.mutation.SetQuietHoursEndMinute(v)
+	return _u
+}
+
+// SetNillableQuietHoursEndMinute sets the "quiet_hours_end_minute" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableQuietHoursEndMinute(v *int) *AccountUpdate {
+	if v != nil {
+		_u.SetQuietHoursEndMinute(*v)
+	}
+	return _u
+}
+
+// AddQuietHoursEndMinute adds value to the "quiet_hours_end_minute" field.
+func (_u *AccountUpdate) AddQuietHoursEndMinute(v int) *AccountUpdate {
+	_u.mutation.AddQuietHoursEndMinute(v)
+	return _u
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (_u *AccountUpdate) ClearQuietHoursEndMinute() *AccountUpdate {
+	_u.mutation.ClearQuietHoursEndMinute()
+	return _u
+}
+
 // AddGroupIDs adds the "groups" edge to the Group entity by IDs.
 func (_u *AccountUpdate) AddGroupIDs(ids ...int64) *AccountUpdate {
 	_u.mutation.AddGroupIDs(ids...)
@@ -583,6 +705,11 @@ func (_u *AccountUpdate) check() error {
 			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Account.type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.AffinityGroup(); ok {
+		if err := account.AffinityGroupValidator(v); err != nil {
+			return &ValidationError{Name: "affinity_group", err: fmt.Errorf(`ent: validator failed for field "Account.affinity_group": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Status(); ok {
 		if err := account.StatusValidator(v); err != nil {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Account.status": %w`, err)}
@@ -593,6 +720,16 @@ func (_u *AccountUpdate) check() error {
 			return &ValidationError{Name: "session_window_status", err: fmt.Errorf(`ent: validator failed for field "Account.session_window_status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.QuietHoursStartMinute(); ok {
+		if err := account.QuietHoursStartMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_start_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_start_minute": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.QuietHoursEndMinute(); ok {
+		if err := account.QuietHoursEndMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_end_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_end_minute": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -650,6 +787,18 @@ func (_u *AccountUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(account.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.AffinityGroup(); ok {
+		_spec.SetField(account.FieldAffinityGroup, field.TypeString, value)
+	}
+	if _u.mutation.AffinityGroupCleared() {
+		_spec.ClearField(account.FieldAffinityGroup, field.TypeString)
+	}
+	if value, ok := _u.mutation.MaxLineSize(); ok {
+		_spec.SetField(account.FieldMaxLineSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxLineSize(); ok {
+		_spec.AddField(account.FieldMaxLineSize, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.RateMultiplier(); ok {
 		_spec.SetField(account.FieldRateMultiplier, field.TypeFloat64, value)
 	}
@@ -719,6 +868,33 @@ func (_u *AccountUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.SessionWindowStatusCleared() {
 		_spec.ClearField(account.FieldSessionWindowStatus, field.TypeString)
 	}
+	if value, ok := _u.mutation.SessionWindowUtilization(); ok {
+		_spec.SetField(account.FieldSessionWindowUtilization, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSessionWindowUtilization(); ok {
+		_spec.AddField(account.FieldSessionWindowUtilization, field.TypeInt, value)
+	}
+	if _u.mutation.SessionWindowUtilizationCleared() {
+		_spec.ClearField(account.FieldSessionWindowUtilization, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuietHoursStartMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursStartMinute, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuietHoursStartMinute(); ok {
+		_spec.AddField(account.FieldQuietHoursStartMinute, field.TypeInt, value)
+	}
+	if _u.mutation.QuietHoursStartMinuteCleared() {
+		_spec.ClearField(account.FieldQuietHoursStartMinute, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuietHoursEndMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursEndMinute, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuietHoursEndMinute(); ok {
+		_spec.AddField(account.FieldQuietHoursEndMinute, field.TypeInt, value)
+	}
+	if _u.mutation.QuietHoursEndMinuteCleared() {
+		_spec.ClearField(account.FieldQuietHoursEndMinute, field.TypeInt)
+	}
 	if _u.mutation.GroupsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -1032,6 +1208,47 @@ func (_u *AccountUpdateOne) AddPriority(v int) *AccountUpdateOne {
 	return _u
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (_u *AccountUpdateOne) SetAffinityGroup(v string) *AccountUpdateOne {
+	_u.mutation.SetAffinityGroup(v)
+	return _u
+}
+
+// SetNillableAffinityGroup sets the "affinity_group" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableAffinityGroup(v *string) *AccountUpdateOne {
+	if v != nil {
+		_u.SetAffinityGroup(*v)
+	}
+	return _u
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (_u *AccountUpdateOne) ClearAffinityGroup() *AccountUpdateOne {
+	_u.mutation.ClearAffinityGroup()
+	return _u
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (_u *AccountUpdateOne) SetMaxLineSize(v int) *AccountUpdateOne {
+	_u.mutation.ResetMaxLineSize()
+	_u.mutation.SetMaxLineSize(v)
+	return _u
+}
+
+// SetNillableMaxLineSize sets the "max_line_size" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableMaxLineSize(v *int) *AccountUpdateOne {
+	if v != nil {
+		_u.SetMaxLineSize(*v)
+	}
+	return _u
+}
+
+// AddMaxLineSize adds value to the "max_line_size" field.
+func (_u *AccountUpdateOne) AddMaxLineSize(v int) *AccountUpdateOne {
+	_u.mutation.AddMaxLineSize(v)
+	return _u
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (_u *AccountUpdateOne) SetRateMultiplier(v float64) *AccountUpdateOne {
 	_u.mutation.ResetRateMultiplier()
@@ -1275,6 +1492,87 @@ func (_u *AccountUpdateOne) ClearSessionWindowStatus() *AccountUpdateOne {
 	return _u
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (_u *AccountUpdateOne) SetSessionWindowUtilization(v int) *AccountUpdateOne {
+	_u.mutation.ResetSessionWindowUtilization()
+	_u.mutation.SetSessionWindowUtilization(v)
+	return _u
+}
+
+// SetNillableSessionWindowUtilization sets the "session_window_utilization" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableSessionWindowUtilization(v *int) *AccountUpdateOne {
+	if v != nil {
+		_u.SetSessionWindowUtilization(*v)
+	}
+	return _u
+}
+
+// AddSessionWindowUtilization adds value to the "session_window_utilization" field.
+func (_u *AccountUpdateOne) AddSessionWindowUtilization(v int) *AccountUpdateOne {
+	_u.mutation.AddSessionWindowUtilization(v)
+	return _u
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (_u *AccountUpdateOne) ClearSessionWindowUtilization() *AccountUpdateOne {
+	_u.mutation.ClearSessionWindowUtilization()
+	return _u
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (_u *AccountUpdateOne) SetQuietHoursStartMinute(v int) *AccountUpdateOne {
+	_u.mutation.ResetQuietHoursStartMinute()
+	_u.mutation.SetQuietHoursStartMinute(v)
+	return _u
+}
+
+// SetNillableQuietHoursStartMinute sets the "quiet_hours_start_minute" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableQuietHoursStartMinute(v *int) *AccountUpdateOne {
+	if v != nil {
+		_u.SetQuietHoursStartMinute(*v)
+	}
+	return _u
+}
+
+// AddQuietHoursStartMinute adds value to the "quiet_hours_start_minute" field.
+func (_u *AccountUpdateOne) AddQuietHoursStartMinute(v int) *AccountUpdateOne {
+	_u.mutation.AddQuietHoursStartMinute(v)
+	return _u
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (_u *AccountUpdateOne) ClearQuietHoursStartMinute() *AccountUpdateOne {
+	_u.mutation.ClearQuietHoursStartMinute()
+	return _u
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (_u *AccountUpdateOne) SetQuietHoursEndMinute(v int) *AccountUpdateOne {
+	_u.mutation.ResetQuietHoursEndMinute()
+	_u.mutation.SetQuietHoursEndMinute(v)
+	return _u
+}
+
+// SetNillableQuietHoursEndMinute sets the "quiet_hours_end_minute" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableQuietHoursEndMinute(v *int) *AccountUpdateOne {
+	if v != nil {
+		_u.SetQuietHoursEndMinute(*v)
+	}
+	return _u
+}
+
+// AddQuietHoursEndMinute adds value to the "quiet_hours_end_minute" field.
+func (_u *AccountUpdateOne) AddQuietHoursEndMinute(v int) *AccountUpdateOne {
+	_u.mutation.AddQuietHoursEndMinute(v)
+	return _u
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (_u *AccountUpdateOne) ClearQuietHoursEndMinute() *AccountUpdateOne {
+	_u.mutation.ClearQuietHoursEndMinute()
+	return _u
+}
+
 // AddGroupIDs adds the "groups" edge to the Group entity by IDs.
 func (_u *AccountUpdateOne) AddGroupIDs(ids ...int64) *AccountUpdateOne {
 	_u.mutation.AddGroupIDs(ids...)
@@ -1435,6 +1733,11 @@ func (_u *AccountUpdateOne) check() error {
 			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Account.type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.AffinityGroup(); ok {
+		if err := account.AffinityGroupValidator(v); err != nil {
+			return &ValidationError{Name: "affinity_group", err: fmt.Errorf(`ent: validator failed for field "Account.affinity_group": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Status(); ok {
 		if err := account.StatusValidator(v); err != nil {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Account.status": %w`, err)}
@@ -1445,6 +1748,16 @@ func (_u *AccountUpdateOne) check() error {
 			return &ValidationError{Name: "session_window_status", err: fmt.Errorf(`ent: validator failed for field "Account.session_window_status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.QuietHoursStartMinute(); ok {
+		if err := account.QuietHoursStartMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_start_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_start_minute": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.QuietHoursEndMinute(); ok {
+		if err := account.QuietHoursEndMinuteValidator(v); err != nil {
+			return &ValidationError{Name: "quiet_hours_end_minute", err: fmt.Errorf(`ent: validator failed for field "Account.quiet_hours_end_minute": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -1519,6 +1832,18 @@ func (_u *AccountUpdateOne) sqlSave(ctx context.Context) (_node *Account, err er
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(account.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.AffinityGroup(); ok {
+		_spec.SetField(account.FieldAffinityGroup, field.TypeString, value)
+	}
+	if _u.mutation.AffinityGroupCleared() {
+		_spec.ClearField(account.FieldAffinityGroup, field.TypeString)
+	}
+	if value, ok := _u.mutation.MaxLineSize(); ok {
+		_spec.SetField(account.FieldMaxLineSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxLineSize(); ok {
+		_spec.AddField(account.FieldMaxLineSize, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.RateMultiplier(); ok {
 		_spec.SetField(account.FieldRateMultiplier, field.TypeFloat64, value)
 	}
@@ -1588,6 +1913,33 @@ func (_u *AccountUpdateOne) sqlSave(ctx context.Context) (_node *Account, err er
 	if _u.mutation.SessionWindowStatusCleared() {
 		_spec.ClearField(account.FieldSessionWindowStatus, field.TypeString)
 	}
+	if value, ok := _u.mutation.SessionWindowUtilization(); ok {
+		_spec.SetField(account.FieldSessionWindowUtilization, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSessionWindowUtilization(); ok {
+		_spec.AddField(account.FieldSessionWindowUtilization, field.TypeInt, value)
+	}
+	if _u.mutation.SessionWindowUtilizationCleared() {
+		_spec.ClearField(account.FieldSessionWindowUtilization, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuietHoursStartMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursStartMinute, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuietHoursStartMinute(); ok {
+		_spec.AddField(account.FieldQuietHoursStartMinute, field.TypeInt, value)
+	}
+	if _u.mutation.QuietHoursStartMinuteCleared() {
+		_spec.ClearField(account.FieldQuietHoursStartMinute, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuietHoursEndMinute(); ok {
+		_spec.SetField(account.FieldQuietHoursEndMinute, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuietHoursEndMinute(); ok {
+		_spec.AddField(account.FieldQuietHoursEndMinute, field.TypeInt, value)
+	}
+	if _u.mutation.QuietHoursEndMinuteCleared() {
+		_spec.ClearField(account.FieldQuietHoursEndMinute, field.TypeInt)
+	}
 	if _u.mutation.GroupsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -23,6 +23,8 @@ type AccountGroup struct {
 	GroupID int64 `json:"group_id,omitempty"`
 	// Priority holds the value of the "priority" field.
 	Priority int `json:"priority,omitempty"`
+	// 为该分组在此账号上预留的并发槽位数；预留槽位只能被该分组占用，超出部分（账号总并发 - 预留槽位）由绑定该账号的所有分组共享
+	ReservedSlots int `json:"reserved_slots,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -69,7 +71,7 @@ func (*AccountGroup) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case accountgroup.FieldAccountID, accountgroup.FieldGroupID, accountgroup.FieldPriority:
+		case accountgroup.FieldAccountID, accountgroup.FieldGroupID, accountgroup.FieldPriority, accountgroup.FieldReservedSlots:
 			values[i] = new(sql.NullInt64)
 		case accountgroup.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -106,6 +108,12 @@ func (_m *AccountGroup) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Priority = int(value.Int64)
 			}
+		case accountgroup.FieldReservedSlots:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field reserved_slots", values[i])
+			} else if value.Valid {
+				_m.ReservedSlots = int(value.Int64)
+			}
 		case accountgroup.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -166,6 +174,9 @@ func (_m *AccountGroup) String() string {
 	builder.WriteString("priority=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Priority))
 	builder.WriteString(", ")
+	builder.WriteString("reserved_slots=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ReservedSlots))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
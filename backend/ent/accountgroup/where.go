@@ -25,6 +25,11 @@ func Priority(v int) predicate.AccountGroup {
 	return predicate.AccountGroup(sql.FieldEQ(FieldPriority, v))
 }
 
+// ReservedSlots applies equality check predicate on the "reserved_slots" field. It's identical to ReservedSlotsEQ.
+func ReservedSlots(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldEQ(FieldReservedSlots, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.AccountGroup {
 	return predicate.AccountGroup(sql.FieldEQ(FieldCreatedAt, v))
@@ -110,6 +115,46 @@ func PriorityLTE(v int) predicate.AccountGroup {
 	return predicate.AccountGroup(sql.FieldLTE(FieldPriority, v))
 }
 
+// ReservedSlotsEQ applies the EQ predicate on the "reserved_slots" field.
+func ReservedSlotsEQ(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldEQ(FieldReservedSlots, v))
+}
+
+// ReservedSlotsNEQ applies the NEQ predicate on the "reserved_slots" field.
+func ReservedSlotsNEQ(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldNEQ(FieldReservedSlots, v))
+}
+
+// ReservedSlotsIn applies the In predicate on the "reserved_slots" field.
+func ReservedSlotsIn(vs ...int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldIn(FieldReservedSlots, vs...))
+}
+
+// ReservedSlotsNotIn applies the NotIn predicate on the "reserved_slots" field.
+func ReservedSlotsNotIn(vs ...int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldNotIn(FieldReservedSlots, vs...))
+}
+
+// ReservedSlotsGT applies the GT predicate on the "reserved_slots" field.
+func ReservedSlotsGT(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldGT(FieldReservedSlots, v))
+}
+
+// ReservedSlotsGTE applies the GTE predicate on the "reserved_slots" field.
+func ReservedSlotsGTE(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldGTE(FieldReservedSlots, v))
+}
+
+// ReservedSlotsLT applies the LT predicate on the "reserved_slots" field.
+func ReservedSlotsLT(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldLT(FieldReservedSlots, v))
+}
+
+// ReservedSlotsLTE applies the LTE predicate on the "reserved_slots" field.
+func ReservedSlotsLTE(v int) predicate.AccountGroup {
+	return predicate.AccountGroup(sql.FieldLTE(FieldReservedSlots, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.AccountGroup {
 	return predicate.AccountGroup(sql.FieldEQ(FieldCreatedAt, v))
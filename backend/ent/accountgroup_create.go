@@ -50,6 +50,20 @@ func (_c *AccountGroupCreate) SetNillablePriority(v *int) *AccountGroupCreate {
 	return _c
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (_c *AccountGroupCreate) SetReservedSlots(v int) *AccountGroupCreate {
+	_c.mutation.SetReservedSlots(v)
+	return _c
+}
+
+// SetNillableReservedSlots sets the "reserved_slots" field if the given value is not nil.
+func (_c *AccountGroupCreate) SetNillableReservedSlots(v *int) *AccountGroupCreate {
+	if v != nil {
+		_c.SetReservedSlots(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *AccountGroupCreate) SetCreatedAt(v time.Time) *AccountGroupCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -113,6 +127,10 @@ func (_c *AccountGroupCreate) defaults() {
 		v := accountgroup.DefaultPriority
 		_c.mutation.SetPriority(v)
 	}
+	if _, ok := _c.mutation.ReservedSlots(); !ok {
+		v := accountgroup.DefaultReservedSlots
+		_c.mutation.SetReservedSlots(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := accountgroup.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -130,6 +148,9 @@ func (_c *AccountGroupCreate) check() error {
 	if _, ok := _c.mutation.Priority(); !ok {
 		return &ValidationError{Name: "priority", err: errors.New(`ent: missing required field "AccountGroup.priority"`)}
 	}
+	if _, ok := _c.mutation.ReservedSlots(); !ok {
+		return &ValidationError{Name: "reserved_slots", err: errors.New(`ent: missing required field "AccountGroup.reserved_slots"`)}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AccountGroup.created_at"`)}
 	}
@@ -166,6 +187,10 @@ func (_c *AccountGroupCreate) createSpec() (*AccountGroup, *sqlgraph.CreateSpec)
 		_spec.SetField(accountgroup.FieldPriority, field.TypeInt, value)
 		_node.Priority = value
 	}
+	if value, ok := _c.mutation.ReservedSlots(); ok {
+		_spec.SetField(accountgroup.FieldReservedSlots, field.TypeInt, value)
+		_node.ReservedSlots = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(accountgroup.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -298,6 +323,24 @@ func (u *AccountGroupUpsert) AddPriority(v int) *AccountGroupUpsert {
 	return u
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (u *AccountGroupUpsert) SetReservedSlots(v int) *AccountGroupUpsert {
+	u.Set(accountgroup.FieldReservedSlots, v)
+	return u
+}
+
+// UpdateReservedSlots sets the "reserved_slots" field to the value that was provided on create.
+func (u *AccountGroupUpsert) UpdateReservedSlots() *AccountGroupUpsert {
+	u.SetExcluded(accountgroup.FieldReservedSlots)
+	return u
+}
+
+// AddReservedSlots adds v to the "reserved_slots" field.
+func (u *AccountGroupUpsert) AddReservedSlots(v int) *AccountGroupUpsert {
+	u.Add(accountgroup.FieldReservedSlots, v)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -392,6 +435,27 @@ func (u *AccountGroupUpsertOne) UpdatePriority() *AccountGroupUpsertOne {
 	})
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (u *AccountGroupUpsertOne) SetReservedSlots(v int) *AccountGroupUpsertOne {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.SetReservedSlots(v)
+	})
+}
+
+// AddReservedSlots adds v to the "reserved_slots" field.
+func (u *AccountGroupUpsertOne) AddReservedSlots(v int) *AccountGroupUpsertOne {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.AddReservedSlots(v)
+	})
+}
+
+// UpdateReservedSlots sets the "reserved_slots" field to the value that was provided on create.
+func (u *AccountGroupUpsertOne) UpdateReservedSlots() *AccountGroupUpsertOne {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.UpdateReservedSlots()
+	})
+}
+
 // Exec executes the query.
 func (u *AccountGroupUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -629,6 +693,27 @@ func (u *AccountGroupUpsertBulk) UpdatePriority() *AccountGroupUpsertBulk {
 	})
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (u *AccountGroupUpsertBulk) SetReservedSlots(v int) *AccountGroupUpsertBulk {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.SetReservedSlots(v)
+	})
+}
+
+// AddReservedSlots adds v to the "reserved_slots" field.
+func (u *AccountGroupUpsertBulk) AddReservedSlots(v int) *AccountGroupUpsertBulk {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.AddReservedSlots(v)
+	})
+}
+
+// UpdateReservedSlots sets the "reserved_slots" field to the value that was provided on create.
+func (u *AccountGroupUpsertBulk) UpdateReservedSlots() *AccountGroupUpsertBulk {
+	return u.Update(func(s *AccountGroupUpsert) {
+		s.UpdateReservedSlots()
+	})
+}
+
 // Exec executes the query.
 func (u *AccountGroupUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -78,6 +78,27 @@ func (_u *AccountGroupUpdate) AddPriority(v int) *AccountGroupUpdate {
 	return _u
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (_u *AccountGroupUpdate) SetReservedSlots(v int) *AccountGroupUpdate {
+	_u.mutation.ResetReservedSlots()
+	_u.mutation.SetReservedSlots(v)
+	return _u
+}
+
+// SetNillableReservedSlots sets the "reserved_slots" field if the given value is not nil.
+func (_u *AccountGroupUpdate) SetNillableReservedSlots(v *int) *AccountGroupUpdate {
+	if v != nil {
+		_u.SetReservedSlots(*v)
+	}
+	return _u
+}
+
+// AddReservedSlots adds value to the "reserved_slots" field.
+func (_u *AccountGroupUpdate) AddReservedSlots(v int) *AccountGroupUpdate {
+	_u.mutation.AddReservedSlots(v)
+	return _u
+}
+
 // SetAccount sets the "account" edge to the Account entity.
 func (_u *AccountGroupUpdate) SetAccount(v *Account) *AccountGroupUpdate {
 	return _u.SetAccountID(v.ID)
@@ -161,6 +182,12 @@ func (_u *AccountGroupUpdate) sqlSave(ctx context.Context) (_node int, err error
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(accountgroup.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.ReservedSlots(); ok {
+		_spec.SetField(accountgroup.FieldReservedSlots, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedReservedSlots(); ok {
+		_spec.AddField(accountgroup.FieldReservedSlots, field.TypeInt, value)
+	}
 	if _u.mutation.AccountCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -288,6 +315,27 @@ func (_u *AccountGroupUpdateOne) AddPriority(v int) *AccountGroupUpdateOne {
 	return _u
 }
 
+// SetReservedSlots sets the "reserved_slots" field.
+func (_u *AccountGroupUpdateOne) SetReservedSlots(v int) *AccountGroupUpdateOne {
+	_u.mutation.ResetReservedSlots()
+	_u.mutation.SetReservedSlots(v)
+	return _u
+}
+
+// SetNillableReservedSlots sets the "reserved_slots" field if the given value is not nil.
+func (_u *AccountGroupUpdateOne) SetNillableReservedSlots(v *int) *AccountGroupUpdateOne {
+	if v != nil {
+		_u.SetReservedSlots(*v)
+	}
+	return _u
+}
+
+// AddReservedSlots adds value to the "reserved_slots" field.
+func (_u *AccountGroupUpdateOne) AddReservedSlots(v int) *AccountGroupUpdateOne {
+	_u.mutation.AddReservedSlots(v)
+	return _u
+}
+
 // SetAccount sets the "account" edge to the Account entity.
 func (_u *AccountGroupUpdateOne) SetAccount(v *Account) *AccountGroupUpdateOne {
 	return _u.SetAccountID(v.ID)
@@ -403,6 +451,12 @@ func (_u *AccountGroupUpdateOne) sqlSave(ctx context.Context) (_node *AccountGro
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(accountgroup.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.ReservedSlots(); ok {
+		_spec.SetField(accountgroup.FieldReservedSlots, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedReservedSlots(); ok {
+		_spec.AddField(accountgroup.FieldReservedSlots, field.TypeInt, value)
+	}
 	if _u.mutation.AccountCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -38,6 +38,8 @@ type Group struct {
 	Platform string `json:"platform,omitempty"`
 	// SubscriptionType holds the value of the "subscription_type" field.
 	SubscriptionType string `json:"subscription_type,omitempty"`
+	// 计费展示货币代码，仅影响前端展示（如预估费用、用量账单），内部计费计算始终以美元为基准单位
+	Currency string `json:"currency,omitempty"`
 	// DailyLimitUsd holds the value of the "daily_limit_usd" field.
 	DailyLimitUsd *float64 `json:"daily_limit_usd,omitempty"`
 	// WeeklyLimitUsd holds the value of the "weekly_limit_usd" field.
@@ -68,6 +70,32 @@ type Group struct {
 	SupportedModelScopes []string `json:"supported_model_scopes,omitempty"`
 	// 分组显示排序，数值越小越靠前
 	SortOrder int `json:"sort_order,omitempty"`
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写，透传客户端原始 metadata
+	DisableMetadataRewrite bool `json:"disable_metadata_rewrite,omitempty"`
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；NULL 表示使用全局配置
+	MaxMessages *int `json:"max_messages,omitempty"`
+	// 分组每日请求次数上限，与 daily_limit_usd 的费用限额相互独立；NULL 或 <=0 表示不限制
+	DailyRequestLimit *int `json:"daily_request_limit,omitempty"`
+	// 分组级上游默认请求头，在构建上游请求时应用于该分组下的 API-key 账号；认证类头部不受影响
+	UpstreamHeaders map[string]string `json:"upstream_headers,omitempty"`
+	// 订阅限额用尽后的计费策略：subscription_only 始终按订阅计费，fallback_balance 改为从余额扣费
+	SubscriptionOverflowPolicy string `json:"subscription_overflow_policy,omitempty"`
+	// Intent 路由配置：{"intent": [account_id1, account_id2], ...}，按 x-sub2api-intent 请求头精确匹配
+	IntentRouting map[string][]int64 `json:"intent_routing,omitempty"`
+	// 是否启用 Intent 路由配置
+	IntentRoutingEnabled bool `json:"intent_routing_enabled,omitempty"`
+	// 允许的上游端点白名单：messages, count_tokens，为空表示不限制
+	AllowedEndpoints []string `json:"allowed_endpoints,omitempty"`
+	// 是否要求客户端必须携带 anthropic-version 请求头，缺失时拒绝请求而非默认填充
+	RequireAnthropicVersion bool `json:"require_anthropic_version,omitempty"`
+	// 流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发；NULL 表示不限制
+	MaxOutputTokens *int `json:"max_output_tokens,omitempty"`
+	// 混合调度下是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户
+	MixedSchedulingNativeSaturationOnly bool `json:"mixed_scheduling_native_saturation_only,omitempty"`
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；NULL 或 <=0 表示不限制
+	WindowCostLimitUsd *float64 `json:"window_cost_limit_usd,omitempty"`
+	// window_cost_limit_usd 对应的滚动窗口时长（小时）；NULL 或 <=0 时默认为 5 小时
+	WindowCostWindowHours *int `json:"window_cost_window_hours,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the GroupQuery when eager-loading is set.
 	Edges        GroupEdges `json:"edges"`
@@ -174,15 +202,15 @@ func (*Group) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case group.FieldModelRouting, group.FieldSupportedModelScopes:
+		case group.FieldModelRouting, group.FieldSupportedModelScopes, group.FieldUpstreamHeaders, group.FieldIntentRouting, group.FieldAllowedEndpoints:
 			values[i] = new([]byte)
-		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled, group.FieldMcpXMLInject:
+		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled, group.FieldMcpXMLInject, group.FieldDisableMetadataRewrite, group.FieldIntentRoutingEnabled, group.FieldRequireAnthropicVersion, group.FieldMixedSchedulingNativeSaturationOnly:
 			values[i] = new(sql.NullBool)
-		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k:
+		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k, group.FieldWindowCostLimitUsd:
 			values[i] = new(sql.NullFloat64)
-		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldFallbackGroupIDOnInvalidRequest, group.FieldSortOrder:
+		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldFallbackGroupIDOnInvalidRequest, group.FieldSortOrder, group.FieldMaxMessages, group.FieldDailyRequestLimit, group.FieldMaxOutputTokens, group.FieldWindowCostWindowHours:
 			values[i] = new(sql.NullInt64)
-		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType:
+		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType, group.FieldCurrency, group.FieldSubscriptionOverflowPolicy:
 			values[i] = new(sql.NullString)
 		case group.FieldCreatedAt, group.FieldUpdatedAt, group.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
@@ -269,6 +297,12 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.SubscriptionType = value.String
 			}
+		case group.FieldCurrency:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field currency", values[i])
+			} else if value.Valid {
+				_m.Currency = value.String
+			}
 		case group.FieldDailyLimitUsd:
 			if value, ok := values[i].(*sql.NullFloat64); !ok {
 				return fmt.Errorf("unexpected type %T for field daily_limit_usd", values[i])
@@ -371,6 +405,95 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.SortOrder = int(value.Int64)
 			}
+		case group.FieldDisableMetadataRewrite:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field disable_metadata_rewrite", values[i])
+			} else if value.Valid {
+				_m.DisableMetadataRewrite = value.Bool
+			}
+		case group.FieldMaxMessages:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_messages", values[i])
+			} else if value.Valid {
+				_m.MaxMessages = new(int)
+				*_m.MaxMessages = int(value.Int64)
+			}
+		case group.FieldDailyRequestLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field daily_request_limit", values[i])
+			} else if value.Valid {
+				_m.DailyRequestLimit = new(int)
+				*_m.DailyRequestLimit = int(value.Int64)
+			}
+		case group.FieldUpstreamHeaders:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field upstream_headers", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.UpstreamHeaders); err != nil {
+					return fmt.Errorf("unmarshal field upstream_headers: %w", err)
+				}
+			}
+		case group.FieldSubscriptionOverflowPolicy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field subscription_overflow_policy", values[i])
+			} else if value.Valid {
+				_m.SubscriptionOverflowPolicy = value.String
+			}
+		case group.FieldIntentRouting:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field intent_routing", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.IntentRouting); err != nil {
+					return fmt.Errorf("unmarshal field intent_routing: %w", err)
+				}
+			}
+		case group.FieldIntentRoutingEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field intent_routing_enabled", values[i])
+			} else if value.Valid {
+				_m.IntentRoutingEnabled = value.Bool
+			}
+		case group.FieldAllowedEndpoints:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_endpoints", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AllowedEndpoints); err != nil {
+					return fmt.Errorf("unmarshal field allowed_endpoints: %w", err)
+				}
+			}
+		case group.FieldRequireAnthropicVersion:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_anthropic_version", values[i])
+			} else if value.Valid {
+				_m.RequireAnthropicVersion = value.Bool
+			}
+		case group.FieldMaxOutputTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_output_tokens", values[i])
+			} else if value.Valid {
+				_m.MaxOutputTokens = new(int)
+				*_m.MaxOutputTokens = int(value.Int64)
+			}
+		case group.FieldMixedSchedulingNativeSaturationOnly:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mixed_scheduling_native_saturation_only", values[i])
+			} else if value.Valid {
+				_m.MixedSchedulingNativeSaturationOnly = value.Bool
+			}
+		case group.FieldWindowCostLimitUsd:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field window_cost_limit_usd", values[i])
+			} else if value.Valid {
+				_m.WindowCostLimitUsd = new(float64)
+				*_m.WindowCostLimitUsd = value.Float64
+			}
+		case group.FieldWindowCostWindowHours:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field window_cost_window_hours", values[i])
+			} else if value.Valid {
+				_m.WindowCostWindowHours = new(int)
+				*_m.WindowCostWindowHours = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -481,6 +604,9 @@ func (_m *Group) String() string {
 	builder.WriteString("subscription_type=")
 	builder.WriteString(_m.SubscriptionType)
 	builder.WriteString(", ")
+	builder.WriteString("currency=")
+	builder.WriteString(_m.Currency)
+	builder.WriteString(", ")
 	if v := _m.DailyLimitUsd; v != nil {
 		builder.WriteString("daily_limit_usd=")
 		builder.WriteString(fmt.Sprintf("%v", *v))
@@ -541,6 +667,55 @@ func (_m *Group) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("sort_order=")
 	builder.WriteString(fmt.Sprintf("%v", _m.SortOrder))
+	builder.WriteString(", ")
+	builder.WriteString("disable_metadata_rewrite=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DisableMetadataRewrite))
+	builder.WriteString(", ")
+	if v := _m.MaxMessages; v != nil {
+		builder.WriteString("max_messages=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.DailyRequestLimit; v != nil {
+		builder.WriteString("daily_request_limit=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("upstream_headers=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpstreamHeaders))
+	builder.WriteString(", ")
+	builder.WriteString("subscription_overflow_policy=")
+	builder.WriteString(_m.SubscriptionOverflowPolicy)
+	builder.WriteString(", ")
+	builder.WriteString("intent_routing=")
+	builder.WriteString(fmt.Sprintf("%v", _m.IntentRouting))
+	builder.WriteString(", ")
+	builder.WriteString("intent_routing_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.IntentRoutingEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("allowed_endpoints=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowedEndpoints))
+	builder.WriteString(", ")
+	builder.WriteString("require_anthropic_version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireAnthropicVersion))
+	builder.WriteString(", ")
+	if v := _m.MaxOutputTokens; v != nil {
+		builder.WriteString("max_output_tokens=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("mixed_scheduling_native_saturation_only=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MixedSchedulingNativeSaturationOnly))
+	builder.WriteString(", ")
+	if v := _m.WindowCostLimitUsd; v != nil {
+		builder.WriteString("window_cost_limit_usd=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.WindowCostWindowHours; v != nil {
+		builder.WriteString("window_cost_window_hours=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
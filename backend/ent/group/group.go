@@ -35,6 +35,8 @@ const (
 	FieldPlatform = "platform"
 	// FieldSubscriptionType holds the string denoting the subscription_type field in the database.
 	FieldSubscriptionType = "subscription_type"
+	// FieldCurrency holds the string denoting the currency field in the database.
+	FieldCurrency = "currency"
 	// FieldDailyLimitUsd holds the string denoting the daily_limit_usd field in the database.
 	FieldDailyLimitUsd = "daily_limit_usd"
 	// FieldWeeklyLimitUsd holds the string denoting the weekly_limit_usd field in the database.
@@ -65,6 +67,32 @@ const (
 	FieldSupportedModelScopes = "supported_model_scopes"
 	// FieldSortOrder holds the string denoting the sort_order field in the database.
 	FieldSortOrder = "sort_order"
+	// FieldDisableMetadataRewrite holds the string denoting the disable_metadata_rewrite field in the database.
+	FieldDisableMetadataRewrite = "disable_metadata_rewrite"
+	// FieldMaxMessages holds the string denoting the max_messages field in the database.
+	FieldMaxMessages = "max_messages"
+	// FieldDailyRequestLimit holds the string denoting the daily_request_limit field in the database.
+	FieldDailyRequestLimit = "daily_request_limit"
+	// FieldUpstreamHeaders holds the string denoting the upstream_headers field in the database.
+	FieldUpstreamHeaders = "upstream_headers"
+	// FieldSubscriptionOverflowPolicy holds the string denoting the subscription_overflow_policy field in the database.
+	FieldSubscriptionOverflowPolicy = "subscription_overflow_policy"
+	// FieldIntentRouting holds the string denoting the intent_routing field in the database.
+	FieldIntentRouting = "intent_routing"
+	// FieldIntentRoutingEnabled holds the string denoting the intent_routing_enabled field in the database.
+	FieldIntentRoutingEnabled = "intent_routing_enabled"
+	// FieldAllowedEndpoints holds the string denoting the allowed_endpoints field in the database.
+	FieldAllowedEndpoints = "allowed_endpoints"
+	// FieldRequireAnthropicVersion holds the string denoting the require_anthropic_version field in the database.
+	FieldRequireAnthropicVersion = "require_anthropic_version"
+	// FieldMaxOutputTokens holds the string denoting the max_output_tokens field in the database.
+	FieldMaxOutputTokens = "max_output_tokens"
+	// FieldMixedSchedulingNativeSaturationOnly holds the string denoting the mixed_scheduling_native_saturation_only field in the database.
+	FieldMixedSchedulingNativeSaturationOnly = "mixed_scheduling_native_saturation_only"
+	// FieldWindowCostLimitUsd holds the string denoting the window_cost_limit_usd field in the database.
+	FieldWindowCostLimitUsd = "window_cost_limit_usd"
+	// FieldWindowCostWindowHours holds the string denoting the window_cost_window_hours field in the database.
+	FieldWindowCostWindowHours = "window_cost_window_hours"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -150,6 +178,7 @@ var Columns = []string{
 	FieldStatus,
 	FieldPlatform,
 	FieldSubscriptionType,
+	FieldCurrency,
 	FieldDailyLimitUsd,
 	FieldWeeklyLimitUsd,
 	FieldMonthlyLimitUsd,
@@ -165,6 +194,19 @@ var Columns = []string{
 	FieldMcpXMLInject,
 	FieldSupportedModelScopes,
 	FieldSortOrder,
+	FieldDisableMetadataRewrite,
+	FieldMaxMessages,
+	FieldDailyRequestLimit,
+	FieldUpstreamHeaders,
+	FieldSubscriptionOverflowPolicy,
+	FieldIntentRouting,
+	FieldIntentRoutingEnabled,
+	FieldAllowedEndpoints,
+	FieldRequireAnthropicVersion,
+	FieldMaxOutputTokens,
+	FieldMixedSchedulingNativeSaturationOnly,
+	FieldWindowCostLimitUsd,
+	FieldWindowCostWindowHours,
 }
 
 var (
@@ -218,6 +260,10 @@ var (
 	DefaultSubscriptionType string
 	// SubscriptionTypeValidator is a validator for the "subscription_type" field. It is called by the builders before save.
 	SubscriptionTypeValidator func(string) error
+	// DefaultCurrency holds the default value on creation for the "currency" field.
+	DefaultCurrency string
+	// CurrencyValidator is a validator for the "currency" field. It is called by the builders before save.
+	CurrencyValidator func(string) error
 	// DefaultDefaultValidityDays holds the default value on creation for the "default_validity_days" field.
 	DefaultDefaultValidityDays int
 	// DefaultClaudeCodeOnly holds the default value on creation for the "claude_code_only" field.
@@ -230,6 +276,18 @@ var (
 	DefaultSupportedModelScopes []string
 	// DefaultSortOrder holds the default value on creation for the "sort_order" field.
 	DefaultSortOrder int
+	// DefaultDisableMetadataRewrite holds the default value on creation for the "disable_metadata_rewrite" field.
+	DefaultDisableMetadataRewrite bool
+	// DefaultSubscriptionOverflowPolicy holds the default value on creation for the "subscription_overflow_policy" field.
+	DefaultSubscriptionOverflowPolicy string
+	// SubscriptionOverflowPolicyValidator is a validator for the "subscription_overflow_policy" field. It is called by the builders before save.
+	SubscriptionOverflowPolicyValidator func(string) error
+	// DefaultIntentRoutingEnabled holds the default value on creation for the "intent_routing_enabled" field.
+	DefaultIntentRoutingEnabled bool
+	// DefaultRequireAnthropicVersion holds the default value on creation for the "require_anthropic_version" field.
+	DefaultRequireAnthropicVersion bool
+	// DefaultMixedSchedulingNativeSaturationOnly holds the default value on creation for the "mixed_scheduling_native_saturation_only" field.
+	DefaultMixedSchedulingNativeSaturationOnly bool
 )
 
 // OrderOption defines the ordering options for the Group queries.
@@ -290,6 +348,11 @@ func BySubscriptionType(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSubscriptionType, opts...).ToFunc()
 }
 
+// ByCurrency orders the results by the currency field.
+func ByCurrency(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCurrency, opts...).ToFunc()
+}
+
 // ByDailyLimitUsd orders the results by the daily_limit_usd field.
 func ByDailyLimitUsd(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldDailyLimitUsd, opts...).ToFunc()
@@ -355,6 +418,56 @@ func BySortOrder(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSortOrder, opts...).ToFunc()
 }
 
+// ByDisableMetadataRewrite orders the results by the disable_metadata_rewrite field.
+func ByDisableMetadataRewrite(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDisableMetadataRewrite, opts...).ToFunc()
+}
+
+// ByMaxMessages orders the results by the max_messages field.
+func ByMaxMessages(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxMessages, opts...).ToFunc()
+}
+
+// ByDailyRequestLimit orders the results by the daily_request_limit field.
+func ByDailyRequestLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDailyRequestLimit, opts...).ToFunc()
+}
+
+// BySubscriptionOverflowPolicy orders the results by the subscription_overflow_policy field.
+func BySubscriptionOverflowPolicy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSubscriptionOverflowPolicy, opts...).ToFunc()
+}
+
+// ByIntentRoutingEnabled orders the results by the intent_routing_enabled field.
+func ByIntentRoutingEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIntentRoutingEnabled, opts...).ToFunc()
+}
+
+// ByRequireAnthropicVersion orders the results by the require_anthropic_version field.
+func ByRequireAnthropicVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireAnthropicVersion, opts...).ToFunc()
+}
+
+// ByMaxOutputTokens orders the results by the max_output_tokens field.
+func ByMaxOutputTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxOutputTokens, opts...).ToFunc()
+}
+
+// ByMixedSchedulingNativeSaturationOnly orders the results by the mixed_scheduling_native_saturation_only field.
+func ByMixedSchedulingNativeSaturationOnly(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMixedSchedulingNativeSaturationOnly, opts...).ToFunc()
+}
+
+// ByWindowCostLimitUsd orders the results by the window_cost_limit_usd field.
+func ByWindowCostLimitUsd(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldWindowCostLimitUsd, opts...).ToFunc()
+}
+
+// ByWindowCostWindowHours orders the results by the window_cost_window_hours field.
+func ByWindowCostWindowHours(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldWindowCostWindowHours, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
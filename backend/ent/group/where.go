@@ -105,6 +105,11 @@ func SubscriptionType(v string) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldSubscriptionType, v))
 }
 
+// Currency applies equality check predicate on the "currency" field. It's identical to CurrencyEQ.
+func Currency(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldCurrency, v))
+}
+
 // DailyLimitUsd applies equality check predicate on the "daily_limit_usd" field. It's identical to DailyLimitUsdEQ.
 func DailyLimitUsd(v float64) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldDailyLimitUsd, v))
@@ -170,6 +175,56 @@ func SortOrder(v int) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldSortOrder, v))
 }
 
+// DisableMetadataRewrite applies equality check predicate on the "disable_metadata_rewrite" field. It's identical to DisableMetadataRewriteEQ.
+func DisableMetadataRewrite(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDisableMetadataRewrite, v))
+}
+
+// MaxMessages applies equality check predicate on the "max_messages" field. It's identical to MaxMessagesEQ.
+func MaxMessages(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMaxMessages, v))
+}
+
+// DailyRequestLimit applies equality check predicate on the "daily_request_limit" field. It's identical to DailyRequestLimitEQ.
+func DailyRequestLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDailyRequestLimit, v))
+}
+
+// SubscriptionOverflowPolicy applies equality check predicate on the "subscription_overflow_policy" field. It's identical to SubscriptionOverflowPolicyEQ.
+func SubscriptionOverflowPolicy(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSubscriptionOverflowPolicy, v))
+}
+
+// IntentRoutingEnabled applies equality check predicate on the "intent_routing_enabled" field. It's identical to IntentRoutingEnabledEQ.
+func IntentRoutingEnabled(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldIntentRoutingEnabled, v))
+}
+
+// RequireAnthropicVersion applies equality check predicate on the "require_anthropic_version" field. It's identical to RequireAnthropicVersionEQ.
+func RequireAnthropicVersion(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldRequireAnthropicVersion, v))
+}
+
+// MaxOutputTokens applies equality check predicate on the "max_output_tokens" field. It's identical to MaxOutputTokensEQ.
+func MaxOutputTokens(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMaxOutputTokens, v))
+}
+
+// MixedSchedulingNativeSaturationOnly applies equality check predicate on the "mixed_scheduling_native_saturation_only" field. It's identical to MixedSchedulingNativeSaturationOnlyEQ.
+func MixedSchedulingNativeSaturationOnly(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMixedSchedulingNativeSaturationOnly, v))
+}
+
+// WindowCostLimitUsd applies equality check predicate on the "window_cost_limit_usd" field. It's identical to WindowCostLimitUsdEQ.
+func WindowCostLimitUsd(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostWindowHours applies equality check predicate on the "window_cost_window_hours" field. It's identical to WindowCostWindowHoursEQ.
+func WindowCostWindowHours(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldWindowCostWindowHours, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldCreatedAt, v))
@@ -685,6 +740,71 @@ func SubscriptionTypeContainsFold(v string) predicate.Group {
 	return predicate.Group(sql.FieldContainsFold(FieldSubscriptionType, v))
 }
 
+// CurrencyEQ applies the EQ predicate on the "currency" field.
+func CurrencyEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldCurrency, v))
+}
+
+// CurrencyNEQ applies the NEQ predicate on the "currency" field.
+func CurrencyNEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldCurrency, v))
+}
+
+// CurrencyIn applies the In predicate on the "currency" field.
+func CurrencyIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldCurrency, vs...))
+}
+
+// CurrencyNotIn applies the NotIn predicate on the "currency" field.
+func CurrencyNotIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldCurrency, vs...))
+}
+
+// CurrencyGT applies the GT predicate on the "currency" field.
+func CurrencyGT(v string) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldCurrency, v))
+}
+
+// CurrencyGTE applies the GTE predicate on the "currency" field.
+func CurrencyGTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldCurrency, v))
+}
+
+// CurrencyLT applies the LT predicate on the "currency" field.
+func CurrencyLT(v string) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldCurrency, v))
+}
+
+// CurrencyLTE applies the LTE predicate on the "currency" field.
+func CurrencyLTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldCurrency, v))
+}
+
+// CurrencyContains applies the Contains predicate on the "currency" field.
+func CurrencyContains(v string) predicate.Group {
+	return predicate.Group(sql.FieldContains(FieldCurrency, v))
+}
+
+// CurrencyHasPrefix applies the HasPrefix predicate on the "currency" field.
+func CurrencyHasPrefix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasPrefix(FieldCurrency, v))
+}
+
+// CurrencyHasSuffix applies the HasSuffix predicate on the "currency" field.
+func CurrencyHasSuffix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasSuffix(FieldCurrency, v))
+}
+
+// CurrencyEqualFold applies the EqualFold predicate on the "currency" field.
+func CurrencyEqualFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldEqualFold(FieldCurrency, v))
+}
+
+// CurrencyContainsFold applies the ContainsFold predicate on the "currency" field.
+func CurrencyContainsFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldContainsFold(FieldCurrency, v))
+}
+
 // DailyLimitUsdEQ applies the EQ predicate on the "daily_limit_usd" field.
 func DailyLimitUsdEQ(v float64) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldDailyLimitUsd, v))
@@ -1205,6 +1325,391 @@ func SortOrderLTE(v int) predicate.Group {
 	return predicate.Group(sql.FieldLTE(FieldSortOrder, v))
 }
 
+// DisableMetadataRewriteEQ applies the EQ predicate on the "disable_metadata_rewrite" field.
+func DisableMetadataRewriteEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDisableMetadataRewrite, v))
+}
+
+// DisableMetadataRewriteNEQ applies the NEQ predicate on the "disable_metadata_rewrite" field.
+func DisableMetadataRewriteNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDisableMetadataRewrite, v))
+}
+
+// MaxMessagesEQ applies the EQ predicate on the "max_messages" field.
+func MaxMessagesEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMaxMessages, v))
+}
+
+// MaxMessagesNEQ applies the NEQ predicate on the "max_messages" field.
+func MaxMessagesNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldMaxMessages, v))
+}
+
+// MaxMessagesIn applies the In predicate on the "max_messages" field.
+func MaxMessagesIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldMaxMessages, vs...))
+}
+
+// MaxMessagesNotIn applies the NotIn predicate on the "max_messages" field.
+func MaxMessagesNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldMaxMessages, vs...))
+}
+
+// MaxMessagesGT applies the GT predicate on the "max_messages" field.
+func MaxMessagesGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldMaxMessages, v))
+}
+
+// MaxMessagesGTE applies the GTE predicate on the "max_messages" field.
+func MaxMessagesGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldMaxMessages, v))
+}
+
+// MaxMessagesLT applies the LT predicate on the "max_messages" field.
+func MaxMessagesLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldMaxMessages, v))
+}
+
+// MaxMessagesLTE applies the LTE predicate on the "max_messages" field.
+func MaxMessagesLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldMaxMessages, v))
+}
+
+// MaxMessagesIsNil applies the IsNil predicate on the "max_messages" field.
+func MaxMessagesIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldMaxMessages))
+}
+
+// MaxMessagesNotNil applies the NotNil predicate on the "max_messages" field.
+func MaxMessagesNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldMaxMessages))
+}
+
+// DailyRequestLimitEQ applies the EQ predicate on the "daily_request_limit" field.
+func DailyRequestLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitNEQ applies the NEQ predicate on the "daily_request_limit" field.
+func DailyRequestLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitIn applies the In predicate on the "daily_request_limit" field.
+func DailyRequestLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDailyRequestLimit, vs...))
+}
+
+// DailyRequestLimitNotIn applies the NotIn predicate on the "daily_request_limit" field.
+func DailyRequestLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDailyRequestLimit, vs...))
+}
+
+// DailyRequestLimitGT applies the GT predicate on the "daily_request_limit" field.
+func DailyRequestLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitGTE applies the GTE predicate on the "daily_request_limit" field.
+func DailyRequestLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitLT applies the LT predicate on the "daily_request_limit" field.
+func DailyRequestLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitLTE applies the LTE predicate on the "daily_request_limit" field.
+func DailyRequestLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDailyRequestLimit, v))
+}
+
+// DailyRequestLimitIsNil applies the IsNil predicate on the "daily_request_limit" field.
+func DailyRequestLimitIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldDailyRequestLimit))
+}
+
+// DailyRequestLimitNotNil applies the NotNil predicate on the "daily_request_limit" field.
+func DailyRequestLimitNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldDailyRequestLimit))
+}
+
+// UpstreamHeadersIsNil applies the IsNil predicate on the "upstream_headers" field.
+func UpstreamHeadersIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldUpstreamHeaders))
+}
+
+// UpstreamHeadersNotNil applies the NotNil predicate on the "upstream_headers" field.
+func UpstreamHeadersNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldUpstreamHeaders))
+}
+
+// SubscriptionOverflowPolicyEQ applies the EQ predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyNEQ applies the NEQ predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyNEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyIn applies the In predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldSubscriptionOverflowPolicy, vs...))
+}
+
+// SubscriptionOverflowPolicyNotIn applies the NotIn predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyNotIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldSubscriptionOverflowPolicy, vs...))
+}
+
+// SubscriptionOverflowPolicyGT applies the GT predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyGT(v string) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyGTE applies the GTE predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyGTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyLT applies the LT predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyLT(v string) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyLTE applies the LTE predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyLTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyContains applies the Contains predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyContains(v string) predicate.Group {
+	return predicate.Group(sql.FieldContains(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyHasPrefix applies the HasPrefix predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyHasPrefix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasPrefix(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyHasSuffix applies the HasSuffix predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyHasSuffix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasSuffix(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyEqualFold applies the EqualFold predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyEqualFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldEqualFold(FieldSubscriptionOverflowPolicy, v))
+}
+
+// SubscriptionOverflowPolicyContainsFold applies the ContainsFold predicate on the "subscription_overflow_policy" field.
+func SubscriptionOverflowPolicyContainsFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldContainsFold(FieldSubscriptionOverflowPolicy, v))
+}
+
+// IntentRoutingIsNil applies the IsNil predicate on the "intent_routing" field.
+func IntentRoutingIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldIntentRouting))
+}
+
+// IntentRoutingNotNil applies the NotNil predicate on the "intent_routing" field.
+func IntentRoutingNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldIntentRouting))
+}
+
+// IntentRoutingEnabledEQ applies the EQ predicate on the "intent_routing_enabled" field.
+func IntentRoutingEnabledEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldIntentRoutingEnabled, v))
+}
+
+// IntentRoutingEnabledNEQ applies the NEQ predicate on the "intent_routing_enabled" field.
+func IntentRoutingEnabledNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldIntentRoutingEnabled, v))
+}
+
+// AllowedEndpointsIsNil applies the IsNil predicate on the "allowed_endpoints" field.
+func AllowedEndpointsIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldAllowedEndpoints))
+}
+
+// AllowedEndpointsNotNil applies the NotNil predicate on the "allowed_endpoints" field.
+func AllowedEndpointsNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldAllowedEndpoints))
+}
+
+// RequireAnthropicVersionEQ applies the EQ predicate on the "require_anthropic_version" field.
+func RequireAnthropicVersionEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldRequireAnthropicVersion, v))
+}
+
+// RequireAnthropicVersionNEQ applies the NEQ predicate on the "require_anthropic_version" field.
+func RequireAnthropicVersionNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldRequireAnthropicVersion, v))
+}
+
+// MaxOutputTokensEQ applies the EQ predicate on the "max_output_tokens" field.
+func MaxOutputTokensEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensNEQ applies the NEQ predicate on the "max_output_tokens" field.
+func MaxOutputTokensNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensIn applies the In predicate on the "max_output_tokens" field.
+func MaxOutputTokensIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldMaxOutputTokens, vs...))
+}
+
+// MaxOutputTokensNotIn applies the NotIn predicate on the "max_output_tokens" field.
+func MaxOutputTokensNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldMaxOutputTokens, vs...))
+}
+
+// MaxOutputTokensGT applies the GT predicate on the "max_output_tokens" field.
+func MaxOutputTokensGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensGTE applies the GTE predicate on the "max_output_tokens" field.
+func MaxOutputTokensGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensLT applies the LT predicate on the "max_output_tokens" field.
+func MaxOutputTokensLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensLTE applies the LTE predicate on the "max_output_tokens" field.
+func MaxOutputTokensLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldMaxOutputTokens, v))
+}
+
+// MaxOutputTokensIsNil applies the IsNil predicate on the "max_output_tokens" field.
+func MaxOutputTokensIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldMaxOutputTokens))
+}
+
+// MaxOutputTokensNotNil applies the NotNil predicate on the "max_output_tokens" field.
+func MaxOutputTokensNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldMaxOutputTokens))
+}
+
+// MixedSchedulingNativeSaturationOnlyEQ applies the EQ predicate on the "mixed_scheduling_native_saturation_only" field.
+func MixedSchedulingNativeSaturationOnlyEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldMixedSchedulingNativeSaturationOnly, v))
+}
+
+// MixedSchedulingNativeSaturationOnlyNEQ applies the NEQ predicate on the "mixed_scheduling_native_saturation_only" field.
+func MixedSchedulingNativeSaturationOnlyNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldMixedSchedulingNativeSaturationOnly, v))
+}
+
+// WindowCostLimitUsdEQ applies the EQ predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdNEQ applies the NEQ predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdNEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdIn applies the In predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldWindowCostLimitUsd, vs...))
+}
+
+// WindowCostLimitUsdNotIn applies the NotIn predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdNotIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldWindowCostLimitUsd, vs...))
+}
+
+// WindowCostLimitUsdGT applies the GT predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdGT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdGTE applies the GTE predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdGTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdLT applies the LT predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdLT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdLTE applies the LTE predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdLTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldWindowCostLimitUsd, v))
+}
+
+// WindowCostLimitUsdIsNil applies the IsNil predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldWindowCostLimitUsd))
+}
+
+// WindowCostLimitUsdNotNil applies the NotNil predicate on the "window_cost_limit_usd" field.
+func WindowCostLimitUsdNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldWindowCostLimitUsd))
+}
+
+// WindowCostWindowHoursEQ applies the EQ predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursNEQ applies the NEQ predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursIn applies the In predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldWindowCostWindowHours, vs...))
+}
+
+// WindowCostWindowHoursNotIn applies the NotIn predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldWindowCostWindowHours, vs...))
+}
+
+// WindowCostWindowHoursGT applies the GT predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursGTE applies the GTE predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursLT applies the LT predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursLTE applies the LTE predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldWindowCostWindowHours, v))
+}
+
+// WindowCostWindowHoursIsNil applies the IsNil predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldWindowCostWindowHours))
+}
+
+// WindowCostWindowHoursNotNil applies the NotNil predicate on the "window_cost_window_hours" field.
+func WindowCostWindowHoursNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldWindowCostWindowHours))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
@@ -160,6 +160,20 @@ func (_c *GroupCreate) SetNillableSubscriptionType(v *string) *GroupCreate {
 	return _c
 }
 
+// SetCurrency sets the "currency" field.
+func (_c *GroupCreate) SetCurrency(v string) *GroupCreate {
+	_c.mutation.SetCurrency(v)
+	return _c
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableCurrency(v *string) *GroupCreate {
+	if v != nil {
+		_c.SetCurrency(*v)
+	}
+	return _c
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (_c *GroupCreate) SetDailyLimitUsd(v float64) *GroupCreate {
 	_c.mutation.SetDailyLimitUsd(v)
@@ -354,6 +368,164 @@ func (_c *GroupCreate) SetNillableSortOrder(v *int) *GroupCreate {
 	return _c
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (_c *GroupCreate) SetDisableMetadataRewrite(v bool) *GroupCreate {
+	_c.mutation.SetDisableMetadataRewrite(v)
+	return _c
+}
+
+// SetNillableDisableMetadataRewrite sets the "disable_metadata_rewrite" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDisableMetadataRewrite(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetDisableMetadataRewrite(*v)
+	}
+	return _c
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (_c *GroupCreate) SetMaxMessages(v int) *GroupCreate {
+	_c.mutation.SetMaxMessages(v)
+	return _c
+}
+
+// SetNillableMaxMessages sets the "max_messages" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableMaxMessages(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetMaxMessages(*v)
+	}
+	return _c
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (_c *GroupCreate) SetDailyRequestLimit(v int) *GroupCreate {
+	_c.mutation.SetDailyRequestLimit(v)
+	return _c
+}
+
+// SetNillableDailyRequestLimit sets the "daily_request_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDailyRequestLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDailyRequestLimit(*v)
+	}
+	return _c
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (_c *GroupCreate) SetUpstreamHeaders(v map[string]string) *GroupCreate {
+	_c.mutation.SetUpstreamHeaders(v)
+	return _c
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (_c *GroupCreate) SetSubscriptionOverflowPolicy(v string) *GroupCreate {
+	_c.mutation.SetSubscriptionOverflowPolicy(v)
+	return _c
+}
+
+// SetNillableSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableSubscriptionOverflowPolicy(v *string) *GroupCreate {
+	if v != nil {
+		_c.SetSubscriptionOverflowPolicy(*v)
+	}
+	return _c
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (_c *GroupCreate) SetIntentRouting(v map[string][]int64) *GroupCreate {
+	_c.mutation.SetIntentRouting(v)
+	return _c
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (_c *GroupCreate) SetIntentRoutingEnabled(v bool) *GroupCreate {
+	_c.mutation.SetIntentRoutingEnabled(v)
+	return _c
+}
+
+// SetNillableIntentRoutingEnabled sets the "intent_routing_enabled" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableIntentRoutingEnabled(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetIntentRoutingEnabled(*v)
+	}
+	return _c
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (_c *GroupCreate) SetAllowedEndpoints(v []string) *GroupCreate {
+	_c.mutation.SetAllowedEndpoints(v)
+	return _c
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (_c *GroupCreate) SetRequireAnthropicVersion(v bool) *GroupCreate {
+	_c.mutation.SetRequireAnthropicVersion(v)
+	return _c
+}
+
+// SetNillableRequireAnthropicVersion sets the "require_anthropic_version" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableRequireAnthropicVersion(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetRequireAnthropicVersion(*v)
+	}
+	return _c
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (_c *GroupCreate) SetMaxOutputTokens(v int) *GroupCreate {
+	_c.mutation.SetMaxOutputTokens(v)
+	return _c
+}
+
+// SetNillableMaxOutputTokens sets the "max_output_tokens" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableMaxOutputTokens(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetMaxOutputTokens(*v)
+	}
+	return _c
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (_c *GroupCreate) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupCreate {
+	_c.mutation.SetMixedSchedulingNativeSaturationOnly(v)
+	return _c
+}
+
+// SetNillableMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableMixedSchedulingNativeSaturationOnly(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetMixedSchedulingNativeSaturationOnly(*v)
+	}
+	return _c
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (_c *GroupCreate) SetWindowCostLimitUsd(v float64) *GroupCreate {
+	_c.mutation.SetWindowCostLimitUsd(v)
+	return _c
+}
+
+// SetNillableWindowCostLimitUsd sets the "window_cost_limit_usd" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableWindowCostLimitUsd(v *float64) *GroupCreate {
+	if v != nil {
+		_c.SetWindowCostLimitUsd(*v)
+	}
+	return _c
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (_c *GroupCreate) SetWindowCostWindowHours(v int) *GroupCreate {
+	_c.mutation.SetWindowCostWindowHours(v)
+	return _c
+}
+
+// SetNillableWindowCostWindowHours sets the "window_cost_window_hours" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableWindowCostWindowHours(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetWindowCostWindowHours(*v)
+	}
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *GroupCreate) AddAPIKeyIDs(ids ...int64) *GroupCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -515,6 +687,10 @@ func (_c *GroupCreate) defaults() error {
 		v := group.DefaultSubscriptionType
 		_c.mutation.SetSubscriptionType(v)
 	}
+	if _, ok := _c.mutation.Currency(); !ok {
+		v := group.DefaultCurrency
+		_c.mutation.SetCurrency(v)
+	}
 	if _, ok := _c.mutation.DefaultValidityDays(); !ok {
 		v := group.DefaultDefaultValidityDays
 		_c.mutation.SetDefaultValidityDays(v)
@@ -539,6 +715,26 @@ func (_c *GroupCreate) defaults() error {
 		v := group.DefaultSortOrder
 		_c.mutation.SetSortOrder(v)
 	}
+	if _, ok := _c.mutation.DisableMetadataRewrite(); !ok {
+		v := group.DefaultDisableMetadataRewrite
+		_c.mutation.SetDisableMetadataRewrite(v)
+	}
+	if _, ok := _c.mutation.SubscriptionOverflowPolicy(); !ok {
+		v := group.DefaultSubscriptionOverflowPolicy
+		_c.mutation.SetSubscriptionOverflowPolicy(v)
+	}
+	if _, ok := _c.mutation.IntentRoutingEnabled(); !ok {
+		v := group.DefaultIntentRoutingEnabled
+		_c.mutation.SetIntentRoutingEnabled(v)
+	}
+	if _, ok := _c.mutation.RequireAnthropicVersion(); !ok {
+		v := group.DefaultRequireAnthropicVersion
+		_c.mutation.SetRequireAnthropicVersion(v)
+	}
+	if _, ok := _c.mutation.MixedSchedulingNativeSaturationOnly(); !ok {
+		v := group.DefaultMixedSchedulingNativeSaturationOnly
+		_c.mutation.SetMixedSchedulingNativeSaturationOnly(v)
+	}
 	return nil
 }
 
@@ -588,6 +784,14 @@ func (_c *GroupCreate) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.Currency(); !ok {
+		return &ValidationError{Name: "currency", err: errors.New(`ent: missing required field "Group.currency"`)}
+	}
+	if v, ok := _c.mutation.Currency(); ok {
+		if err := group.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "Group.currency": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.DefaultValidityDays(); !ok {
 		return &ValidationError{Name: "default_validity_days", err: errors.New(`ent: missing required field "Group.default_validity_days"`)}
 	}
@@ -606,6 +810,26 @@ func (_c *GroupCreate) check() error {
 	if _, ok := _c.mutation.SortOrder(); !ok {
 		return &ValidationError{Name: "sort_order", err: errors.New(`ent: missing required field "Group.sort_order"`)}
 	}
+	if _, ok := _c.mutation.DisableMetadataRewrite(); !ok {
+		return &ValidationError{Name: "disable_metadata_rewrite", err: errors.New(`ent: missing required field "Group.disable_metadata_rewrite"`)}
+	}
+	if _, ok := _c.mutation.SubscriptionOverflowPolicy(); !ok {
+		return &ValidationError{Name: "subscription_overflow_policy", err: errors.New(`ent: missing required field "Group.subscription_overflow_policy"`)}
+	}
+	if v, ok := _c.mutation.SubscriptionOverflowPolicy(); ok {
+		if err := group.SubscriptionOverflowPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "subscription_overflow_policy", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_overflow_policy": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.IntentRoutingEnabled(); !ok {
+		return &ValidationError{Name: "intent_routing_enabled", err: errors.New(`ent: missing required field "Group.intent_routing_enabled"`)}
+	}
+	if _, ok := _c.mutation.RequireAnthropicVersion(); !ok {
+		return &ValidationError{Name: "require_anthropic_version", err: errors.New(`ent: missing required field "Group.require_anthropic_version"`)}
+	}
+	if _, ok := _c.mutation.MixedSchedulingNativeSaturationOnly(); !ok {
+		return &ValidationError{Name: "mixed_scheduling_native_saturation_only", err: errors.New(`ent: missing required field "Group.mixed_scheduling_native_saturation_only"`)}
+	}
 	return nil
 }
 
@@ -673,6 +897,10 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldSubscriptionType, field.TypeString, value)
 		_node.SubscriptionType = value
 	}
+	if value, ok := _c.mutation.Currency(); ok {
+		_spec.SetField(group.FieldCurrency, field.TypeString, value)
+		_node.Currency = value
+	}
 	if value, ok := _c.mutation.DailyLimitUsd(); ok {
 		_spec.SetField(group.FieldDailyLimitUsd, field.TypeFloat64, value)
 		_node.DailyLimitUsd = &value
@@ -733,6 +961,58 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldSortOrder, field.TypeInt, value)
 		_node.SortOrder = value
 	}
+	if value, ok := _c.mutation.DisableMetadataRewrite(); ok {
+		_spec.SetField(group.FieldDisableMetadataRewrite, field.TypeBool, value)
+		_node.DisableMetadataRewrite = value
+	}
+	if value, ok := _c.mutation.MaxMessages(); ok {
+		_spec.SetField(group.FieldMaxMessages, field.TypeInt, value)
+		_node.MaxMessages = &value
+	}
+	if value, ok := _c.mutation.DailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDailyRequestLimit, field.TypeInt, value)
+		_node.DailyRequestLimit = &value
+	}
+	if value, ok := _c.mutation.UpstreamHeaders(); ok {
+		_spec.SetField(group.FieldUpstreamHeaders, field.TypeJSON, value)
+		_node.UpstreamHeaders = value
+	}
+	if value, ok := _c.mutation.SubscriptionOverflowPolicy(); ok {
+		_spec.SetField(group.FieldSubscriptionOverflowPolicy, field.TypeString, value)
+		_node.SubscriptionOverflowPolicy = value
+	}
+	if value, ok := _c.mutation.IntentRouting(); ok {
+		_spec.SetField(group.FieldIntentRouting, field.TypeJSON, value)
+		_node.IntentRouting = value
+	}
+	if value, ok := _c.mutation.IntentRoutingEnabled(); ok {
+		_spec.SetField(group.FieldIntentRoutingEnabled, field.TypeBool, value)
+		_node.IntentRoutingEnabled = value
+	}
+	if value, ok := _c.mutation.AllowedEndpoints(); ok {
+		_spec.SetField(group.FieldAllowedEndpoints, field.TypeJSON, value)
+		_node.AllowedEndpoints = value
+	}
+	if value, ok := _c.mutation.RequireAnthropicVersion(); ok {
+		_spec.SetField(group.FieldRequireAnthropicVersion, field.TypeBool, value)
+		_node.RequireAnthropicVersion = value
+	}
+	if value, ok := _c.mutation.MaxOutputTokens(); ok {
+		_spec.SetField(group.FieldMaxOutputTokens, field.TypeInt, value)
+		_node.MaxOutputTokens = &value
+	}
+	if value, ok := _c.mutation.MixedSchedulingNativeSaturationOnly(); ok {
+		_spec.SetField(group.FieldMixedSchedulingNativeSaturationOnly, field.TypeBool, value)
+		_node.MixedSchedulingNativeSaturationOnly = value
+	}
+	if value, ok := _c.mutation.WindowCostLimitUsd(); ok {
+		_spec.SetField(group.FieldWindowCostLimitUsd, field.TypeFloat64, value)
+		_node.WindowCostLimitUsd = &value
+	}
+	if value, ok := _c.mutation.WindowCostWindowHours(); ok {
+		_spec.SetField(group.FieldWindowCostWindowHours, field.TypeInt, value)
+		_node.WindowCostWindowHours = &value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1015,6 +1295,18 @@ func (u *GroupUpsert) UpdateSubscriptionType() *GroupUpsert {
 	return u
 }
 
+// SetCurrency sets the "currency" field.
+func (u *GroupUpsert) SetCurrency(v string) *GroupUpsert {
+	u.Set(group.FieldCurrency, v)
+	return u
+}
+
+// UpdateCurrency sets the "currency" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateCurrency() *GroupUpsert {
+	u.SetExcluded(group.FieldCurrency)
+	return u
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (u *GroupUpsert) SetDailyLimitUsd(v float64) *GroupUpsert {
 	u.Set(group.FieldDailyLimitUsd, v)
@@ -1309,6 +1601,240 @@ func (u *GroupUpsert) AddSortOrder(v int) *GroupUpsert {
 	return u
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (u *GroupUpsert) SetDisableMetadataRewrite(v bool) *GroupUpsert {
+	u.Set(group.FieldDisableMetadataRewrite, v)
+	return u
+}
+
+// UpdateDisableMetadataRewrite sets the "disable_metadata_rewrite" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDisableMetadataRewrite() *GroupUpsert {
+	u.SetExcluded(group.FieldDisableMetadataRewrite)
+	return u
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (u *GroupUpsert) SetMaxMessages(v int) *GroupUpsert {
+	u.Set(group.FieldMaxMessages, v)
+	return u
+}
+
+// UpdateMaxMessages sets the "max_messages" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateMaxMessages() *GroupUpsert {
+	u.SetExcluded(group.FieldMaxMessages)
+	return u
+}
+
+// AddMaxMessages adds v to the "max_messages" field.
+func (u *GroupUpsert) AddMaxMessages(v int) *GroupUpsert {
+	u.Add(group.FieldMaxMessages, v)
+	return u
+}
+
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (u *GroupUpsert) ClearMaxMessages() *GroupUpsert {
+	u.SetNull(group.FieldMaxMessages)
+	return u
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (u *GroupUpsert) SetDailyRequestLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDailyRequestLimit, v)
+	return u
+}
+
+// UpdateDailyRequestLimit sets the "daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDailyRequestLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDailyRequestLimit)
+	return u
+}
+
+// AddDailyRequestLimit adds v to the "daily_request_limit" field.
+func (u *GroupUpsert) AddDailyRequestLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDailyRequestLimit, v)
+	return u
+}
+
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (u *GroupUpsert) ClearDailyRequestLimit() *GroupUpsert {
+	u.SetNull(group.FieldDailyRequestLimit)
+	return u
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (u *GroupUpsert) SetUpstreamHeaders(v map[string]string) *GroupUpsert {
+	u.Set(group.FieldUpstreamHeaders, v)
+	return u
+}
+
+// UpdateUpstreamHeaders sets the "upstream_headers" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateUpstreamHeaders() *GroupUpsert {
+	u.SetExcluded(group.FieldUpstreamHeaders)
+	return u
+}
+
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (u *GroupUpsert) ClearUpstreamHeaders() *GroupUpsert {
+	u.SetNull(group.FieldUpstreamHeaders)
+	return u
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (u *GroupUpsert) SetSubscriptionOverflowPolicy(v string) *GroupUpsert {
+	u.Set(group.FieldSubscriptionOverflowPolicy, v)
+	return u
+}
+
+// UpdateSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateSubscriptionOverflowPolicy() *GroupUpsert {
+	u.SetExcluded(group.FieldSubscriptionOverflowPolicy)
+	return u
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (u *GroupUpsert) SetIntentRouting(v map[string][]int64) *GroupUpsert {
+	u.Set(group.FieldIntentRouting, v)
+	return u
+}
+
+// UpdateIntentRouting sets the "intent_routing" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateIntentRouting() *GroupUpsert {
+	u.SetExcluded(group.FieldIntentRouting)
+	return u
+}
+
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (u *GroupUpsert) ClearIntentRouting() *GroupUpsert {
+	u.SetNull(group.FieldIntentRouting)
+	return u
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (u *GroupUpsert) SetIntentRoutingEnabled(v bool) *GroupUpsert {
+	u.Set(group.FieldIntentRoutingEnabled, v)
+	return u
+}
+
+// UpdateIntentRoutingEnabled sets the "intent_routing_enabled" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateIntentRoutingEnabled() *GroupUpsert {
+	u.SetExcluded(group.FieldIntentRoutingEnabled)
+	return u
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (u *GroupUpsert) SetAllowedEndpoints(v []string) *GroupUpsert {
+	u.Set(group.FieldAllowedEndpoints, v)
+	return u
+}
+
+// UpdateAllowedEndpoints sets the "allowed_endpoints" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateAllowedEndpoints() *GroupUpsert {
+	u.SetExcluded(group.FieldAllowedEndpoints)
+	return u
+}
+
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (u *GroupUpsert) ClearAllowedEndpoints() *GroupUpsert {
+	u.SetNull(group.FieldAllowedEndpoints)
+	return u
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (u *GroupUpsert) SetRequireAnthropicVersion(v bool) *GroupUpsert {
+	u.Set(group.FieldRequireAnthropicVersion, v)
+	return u
+}
+
+// UpdateRequireAnthropicVersion sets the "require_anthropic_version" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateRequireAnthropicVersion() *GroupUpsert {
+	u.SetExcluded(group.FieldRequireAnthropicVersion)
+	return u
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (u *GroupUpsert) SetMaxOutputTokens(v int) *GroupUpsert {
+	u.Set(group.FieldMaxOutputTokens, v)
+	return u
+}
+
+// UpdateMaxOutputTokens sets the "max_output_tokens" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateMaxOutputTokens() *GroupUpsert {
+	u.SetExcluded(group.FieldMaxOutputTokens)
+	return u
+}
+
+// AddMaxOutputTokens adds v to the "max_output_tokens" field.
+func (u *GroupUpsert) AddMaxOutputTokens(v int) *GroupUpsert {
+	u.Add(group.FieldMaxOutputTokens, v)
+	return u
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (u *GroupUpsert) ClearMaxOutputTokens() *GroupUpsert {
+	u.SetNull(group.FieldMaxOutputTokens)
+	return u
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (u *GroupUpsert) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupUpsert {
+	u.Set(group.FieldMixedSchedulingNativeSaturationOnly, v)
+	return u
+}
+
+// UpdateMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateMixedSchedulingNativeSaturationOnly() *GroupUpsert {
+	u.SetExcluded(group.FieldMixedSchedulingNativeSaturationOnly)
+	return u
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (u *GroupUpsert) SetWindowCostLimitUsd(v float64) *GroupUpsert {
+	u.Set(group.FieldWindowCostLimitUsd, v)
+	return u
+}
+
+// UpdateWindowCostLimitUsd sets the "window_cost_limit_usd" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateWindowCostLimitUsd() *GroupUpsert {
+	u.SetExcluded(group.FieldWindowCostLimitUsd)
+	return u
+}
+
+// AddWindowCostLimitUsd adds v to the "window_cost_limit_usd" field.
+func (u *GroupUpsert) AddWindowCostLimitUsd(v float64) *GroupUpsert {
+	u.Add(group.FieldWindowCostLimitUsd, v)
+	return u
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (u *GroupUpsert) ClearWindowCostLimitUsd() *GroupUpsert {
+	u.SetNull(group.FieldWindowCostLimitUsd)
+	return u
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (u *GroupUpsert) SetWindowCostWindowHours(v int) *GroupUpsert {
+	u.Set(group.FieldWindowCostWindowHours, v)
+	return u
+}
+
+// UpdateWindowCostWindowHours sets the "window_cost_window_hours" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateWindowCostWindowHours() *GroupUpsert {
+	u.SetExcluded(group.FieldWindowCostWindowHours)
+	return u
+}
+
+// AddWindowCostWindowHours adds v to the "window_cost_window_hours" field.
+func (u *GroupUpsert) AddWindowCostWindowHours(v int) *GroupUpsert {
+	u.Add(group.FieldWindowCostWindowHours, v)
+	return u
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (u *GroupUpsert) ClearWindowCostWindowHours() *GroupUpsert {
+	u.SetNull(group.FieldWindowCostWindowHours)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1501,17 +2027,31 @@ func (u *GroupUpsertOne) UpdateSubscriptionType() *GroupUpsertOne {
 	})
 }
 
-// SetDailyLimitUsd sets the "daily_limit_usd" field.
-func (u *GroupUpsertOne) SetDailyLimitUsd(v float64) *GroupUpsertOne {
+// SetCurrency sets the "currency" field.
+func (u *GroupUpsertOne) SetCurrency(v string) *GroupUpsertOne {
 	return u.Update(func(s *GroupUpsert) {
-		s.SetDailyLimitUsd(v)
+		s.SetCurrency(v)
 	})
 }
 
-// AddDailyLimitUsd adds v to the "daily_limit_usd" field.
-func (u *GroupUpsertOne) AddDailyLimitUsd(v float64) *GroupUpsertOne {
+// UpdateCurrency sets the "currency" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateCurrency() *GroupUpsertOne {
 	return u.Update(func(s *GroupUpsert) {
-		s.AddDailyLimitUsd(v)
+		s.UpdateCurrency()
+	})
+}
+
+// SetDailyLimitUsd sets the "daily_limit_usd" field.
+func (u *GroupUpsertOne) SetDailyLimitUsd(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDailyLimitUsd(v)
+	})
+}
+
+// AddDailyLimitUsd adds v to the "daily_limit_usd" field.
+func (u *GroupUpsertOne) AddDailyLimitUsd(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDailyLimitUsd(v)
 	})
 }
 
@@ -1844,6 +2384,279 @@ func (u *GroupUpsertOne) UpdateSortOrder() *GroupUpsertOne {
 	})
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (u *GroupUpsertOne) SetDisableMetadataRewrite(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDisableMetadataRewrite(v)
+	})
+}
+
+// UpdateDisableMetadataRewrite sets the "disable_metadata_rewrite" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDisableMetadataRewrite() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDisableMetadataRewrite()
+	})
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (u *GroupUpsertOne) SetMaxMessages(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMaxMessages(v)
+	})
+}
+
+// AddMaxMessages adds v to the "max_messages" field.
+func (u *GroupUpsertOne) AddMaxMessages(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddMaxMessages(v)
+	})
+}
+
+// UpdateMaxMessages sets the "max_messages" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateMaxMessages() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMaxMessages()
+	})
+}
+
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (u *GroupUpsertOne) ClearMaxMessages() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearMaxMessages()
+	})
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (u *GroupUpsertOne) SetDailyRequestLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDailyRequestLimit(v)
+	})
+}
+
+// AddDailyRequestLimit adds v to the "daily_request_limit" field.
+func (u *GroupUpsertOne) AddDailyRequestLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDailyRequestLimit(v)
+	})
+}
+
+// UpdateDailyRequestLimit sets the "daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDailyRequestLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDailyRequestLimit()
+	})
+}
+
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (u *GroupUpsertOne) ClearDailyRequestLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDailyRequestLimit()
+	})
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (u *GroupUpsertOne) SetUpstreamHeaders(v map[string]string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetUpstreamHeaders(v)
+	})
+}
+
+// UpdateUpstreamHeaders sets the "upstream_headers" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateUpstreamHeaders() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateUpstreamHeaders()
+	})
+}
+
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (u *GroupUpsertOne) ClearUpstreamHeaders() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearUpstreamHeaders()
+	})
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (u *GroupUpsertOne) SetSubscriptionOverflowPolicy(v string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSubscriptionOverflowPolicy(v)
+	})
+}
+
+// UpdateSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateSubscriptionOverflowPolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSubscriptionOverflowPolicy()
+	})
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (u *GroupUpsertOne) SetIntentRouting(v map[string][]int64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetIntentRouting(v)
+	})
+}
+
+// UpdateIntentRouting sets the "intent_routing" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateIntentRouting() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateIntentRouting()
+	})
+}
+
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (u *GroupUpsertOne) ClearIntentRouting() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearIntentRouting()
+	})
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (u *GroupUpsertOne) SetIntentRoutingEnabled(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetIntentRoutingEnabled(v)
+	})
+}
+
+// UpdateIntentRoutingEnabled sets the "intent_routing_enabled" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateIntentRoutingEnabled() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateIntentRoutingEnabled()
+	})
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (u *GroupUpsertOne) SetAllowedEndpoints(v []string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetAllowedEndpoints(v)
+	})
+}
+
+// UpdateAllowedEndpoints sets the "allowed_endpoints" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateAllowedEndpoints() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateAllowedEndpoints()
+	})
+}
+
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (u *GroupUpsertOne) ClearAllowedEndpoints() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearAllowedEndpoints()
+	})
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (u *GroupUpsertOne) SetRequireAnthropicVersion(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRequireAnthropicVersion(v)
+	})
+}
+
+// UpdateRequireAnthropicVersion sets the "require_anthropic_version" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateRequireAnthropicVersion() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRequireAnthropicVersion()
+	})
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (u *GroupUpsertOne) SetMaxOutputTokens(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMaxOutputTokens(v)
+	})
+}
+
+// AddMaxOutputTokens adds v to the "max_output_tokens" field.
+func (u *GroupUpsertOne) AddMaxOutputTokens(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddMaxOutputTokens(v)
+	})
+}
+
+// UpdateMaxOutputTokens sets the "max_output_tokens" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateMaxOutputTokens() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMaxOutputTokens()
+	})
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (u *GroupUpsertOne) ClearMaxOutputTokens() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearMaxOutputTokens()
+	})
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (u *GroupUpsertOne) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMixedSchedulingNativeSaturationOnly(v)
+	})
+}
+
+// UpdateMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateMixedSchedulingNativeSaturationOnly() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMixedSchedulingNativeSaturationOnly()
+	})
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (u *GroupUpsertOne) SetWindowCostLimitUsd(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetWindowCostLimitUsd(v)
+	})
+}
+
+// AddWindowCostLimitUsd adds v to the "window_cost_limit_usd" field.
+func (u *GroupUpsertOne) AddWindowCostLimitUsd(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddWindowCostLimitUsd(v)
+	})
+}
+
+// UpdateWindowCostLimitUsd sets the "window_cost_limit_usd" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateWindowCostLimitUsd() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateWindowCostLimitUsd()
+	})
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (u *GroupUpsertOne) ClearWindowCostLimitUsd() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearWindowCostLimitUsd()
+	})
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (u *GroupUpsertOne) SetWindowCostWindowHours(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetWindowCostWindowHours(v)
+	})
+}
+
+// AddWindowCostWindowHours adds v to the "window_cost_window_hours" field.
+func (u *GroupUpsertOne) AddWindowCostWindowHours(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddWindowCostWindowHours(v)
+	})
+}
+
+// UpdateWindowCostWindowHours sets the "window_cost_window_hours" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateWindowCostWindowHours() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateWindowCostWindowHours()
+	})
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (u *GroupUpsertOne) ClearWindowCostWindowHours() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearWindowCostWindowHours()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2202,6 +3015,20 @@ func (u *GroupUpsertBulk) UpdateSubscriptionType() *GroupUpsertBulk {
 	})
 }
 
+// SetCurrency sets the "currency" field.
+func (u *GroupUpsertBulk) SetCurrency(v string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetCurrency(v)
+	})
+}
+
+// UpdateCurrency sets the "currency" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateCurrency() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateCurrency()
+	})
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (u *GroupUpsertBulk) SetDailyLimitUsd(v float64) *GroupUpsertBulk {
 	return u.Update(func(s *GroupUpsert) {
@@ -2545,6 +3372,279 @@ func (u *GroupUpsertBulk) UpdateSortOrder() *GroupUpsertBulk {
 	})
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (u *GroupUpsertBulk) SetDisableMetadataRewrite(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDisableMetadataRewrite(v)
+	})
+}
+
+// UpdateDisableMetadataRewrite sets the "disable_metadata_rewrite" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDisableMetadataRewrite() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDisableMetadataRewrite()
+	})
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (u *GroupUpsertBulk) SetMaxMessages(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMaxMessages(v)
+	})
+}
+
+// AddMaxMessages adds v to the "max_messages" field.
+func (u *GroupUpsertBulk) AddMaxMessages(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddMaxMessages(v)
+	})
+}
+
+// UpdateMaxMessages sets the "max_messages" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateMaxMessages() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMaxMessages()
+	})
+}
+
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (u *GroupUpsertBulk) ClearMaxMessages() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearMaxMessages()
+	})
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (u *GroupUpsertBulk) SetDailyRequestLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDailyRequestLimit(v)
+	})
+}
+
+// AddDailyRequestLimit adds v to the "daily_request_limit" field.
+func (u *GroupUpsertBulk) AddDailyRequestLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDailyRequestLimit(v)
+	})
+}
+
+// UpdateDailyRequestLimit sets the "daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDailyRequestLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDailyRequestLimit()
+	})
+}
+
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (u *GroupUpsertBulk) ClearDailyRequestLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDailyRequestLimit()
+	})
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (u *GroupUpsertBulk) SetUpstreamHeaders(v map[string]string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetUpstreamHeaders(v)
+	})
+}
+
+// UpdateUpstreamHeaders sets the "upstream_headers" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateUpstreamHeaders() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateUpstreamHeaders()
+	})
+}
+
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (u *GroupUpsertBulk) ClearUpstreamHeaders() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearUpstreamHeaders()
+	})
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (u *GroupUpsertBulk) SetSubscriptionOverflowPolicy(v string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSubscriptionOverflowPolicy(v)
+	})
+}
+
+// UpdateSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateSubscriptionOverflowPolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSubscriptionOverflowPolicy()
+	})
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (u *GroupUpsertBulk) SetIntentRouting(v map[string][]int64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetIntentRouting(v)
+	})
+}
+
+// UpdateIntentRouting sets the "intent_routing" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateIntentRouting() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateIntentRouting()
+	})
+}
+
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (u *GroupUpsertBulk) ClearIntentRouting() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearIntentRouting()
+	})
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (u *GroupUpsertBulk) SetIntentRoutingEnabled(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetIntentRoutingEnabled(v)
+	})
+}
+
+// UpdateIntentRoutingEnabled sets the "intent_routing_enabled" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateIntentRoutingEnabled() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateIntentRoutingEnabled()
+	})
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (u *GroupUpsertBulk) SetAllowedEndpoints(v []string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetAllowedEndpoints(v)
+	})
+}
+
+// UpdateAllowedEndpoints sets the "allowed_endpoints" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateAllowedEndpoints() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateAllowedEndpoints()
+	})
+}
+
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (u *GroupUpsertBulk) ClearAllowedEndpoints() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearAllowedEndpoints()
+	})
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (u *GroupUpsertBulk) SetRequireAnthropicVersion(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRequireAnthropicVersion(v)
+	})
+}
+
+// UpdateRequireAnthropicVersion sets the "require_anthropic_version" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateRequireAnthropicVersion() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRequireAnthropicVersion()
+	})
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (u *GroupUpsertBulk) SetMaxOutputTokens(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMaxOutputTokens(v)
+	})
+}
+
+// AddMaxOutputTokens adds v to the "max_output_tokens" field.
+func (u *GroupUpsertBulk) AddMaxOutputTokens(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddMaxOutputTokens(v)
+	})
+}
+
+// UpdateMaxOutputTokens sets the "max_output_tokens" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateMaxOutputTokens() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMaxOutputTokens()
+	})
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (u *GroupUpsertBulk) ClearMaxOutputTokens() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearMaxOutputTokens()
+	})
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (u *GroupUpsertBulk) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetMixedSchedulingNativeSaturationOnly(v)
+	})
+}
+
+// UpdateMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateMixedSchedulingNativeSaturationOnly() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateMixedSchedulingNativeSaturationOnly()
+	})
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (u *GroupUpsertBulk) SetWindowCostLimitUsd(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetWindowCostLimitUsd(v)
+	})
+}
+
+// AddWindowCostLimitUsd adds v to the "window_cost_limit_usd" field.
+func (u *GroupUpsertBulk) AddWindowCostLimitUsd(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddWindowCostLimitUsd(v)
+	})
+}
+
+// UpdateWindowCostLimitUsd sets the "window_cost_limit_usd" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateWindowCostLimitUsd() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateWindowCostLimitUsd()
+	})
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (u *GroupUpsertBulk) ClearWindowCostLimitUsd() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearWindowCostLimitUsd()
+	})
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (u *GroupUpsertBulk) SetWindowCostWindowHours(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetWindowCostWindowHours(v)
+	})
+}
+
+// AddWindowCostWindowHours adds v to the "window_cost_window_hours" field.
+func (u *GroupUpsertBulk) AddWindowCostWindowHours(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddWindowCostWindowHours(v)
+	})
+}
+
+// UpdateWindowCostWindowHours sets the "window_cost_window_hours" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateWindowCostWindowHours() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateWindowCostWindowHours()
+	})
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (u *GroupUpsertBulk) ClearWindowCostWindowHours() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearWindowCostWindowHours()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
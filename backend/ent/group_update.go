@@ -172,6 +172,20 @@ func (_u *GroupUpdate) SetNillableSubscriptionType(v *string) *GroupUpdate {
 	return _u
 }
 
+// SetCurrency sets the "currency" field.
+func (_u *GroupUpdate) SetCurrency(v string) *GroupUpdate {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableCurrency(v *string) *GroupUpdate {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (_u *GroupUpdate) SetDailyLimitUsd(v float64) *GroupUpdate {
 	_u.mutation.ResetDailyLimitUsd()
@@ -496,6 +510,253 @@ func (_u *GroupUpdate) AddSortOrder(v int) *GroupUpdate {
 	return _u
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (_u *GroupUpdate) SetDisableMetadataRewrite(v bool) *GroupUpdate {
+	_u.mutation.SetDisableMetadataRewrite(v)
+	return _u
+}
+
+// SetNillableDisableMetadataRewrite sets the "disable_metadata_rewrite" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDisableMetadataRewrite(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetDisableMetadataRewrite(*v)
+	}
+	return _u
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (_u *GroupUpdate) SetMaxMessages(v int) *GroupUpdate {
+	_u.mutation.ResetMaxMessages()
+	_u.mutation.SetMaxMessages(v)
+	return _u
+}
+
+// SetNillableMaxMessages sets the "max_messages" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableMaxMessages(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetMaxMessages(*v)
+	}
+	return _u
+}
+
+// AddMaxMessages adds value to the "max_messages" field.
+func (_u *GroupUpdate) AddMaxMessages(v int) *GroupUpdate {
+	_u.mutation.AddMaxMessages(v)
+	return _u
+}
+
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (_u *GroupUpdate) ClearMaxMessages() *GroupUpdate {
+	_u.mutation.ClearMaxMessages()
+	return _u
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (_u *GroupUpdate) SetDailyRequestLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDailyRequestLimit()
+	_u.mutation.SetDailyRequestLimit(v)
+	return _u
+}
+
+// SetNillableDailyRequestLimit sets the "daily_request_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDailyRequestLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDailyRequestLimit(*v)
+	}
+	return _u
+}
+
+// AddDailyRequestLimit adds value to the "daily_request_limit" field.
+func (_u *GroupUpdate) AddDailyRequestLimit(v int) *GroupUpdate {
+	_u.mutation.AddDailyRequestLimit(v)
+	return _u
+}
+
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (_u *GroupUpdate) ClearDailyRequestLimit() *GroupUpdate {
+	_u.mutation.ClearDailyRequestLimit()
+	return _u
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (_u *GroupUpdate) SetUpstreamHeaders(v map[string]string) *GroupUpdate {
+	_u.mutation.SetUpstreamHeaders(v)
+	return _u
+}
+
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (_u *GroupUpdate) ClearUpstreamHeaders() *GroupUpdate {
+	_u.mutation.ClearUpstreamHeaders()
+	return _u
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (_u *GroupUpdate) SetSubscriptionOverflowPolicy(v string) *GroupUpdate {
+	_u.mutation.SetSubscriptionOverflowPolicy(v)
+	return _u
+}
+
+// SetNillableSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableSubscriptionOverflowPolicy(v *string) *GroupUpdate {
+	if v != nil {
+		_u.SetSubscriptionOverflowPolicy(*v)
+	}
+	return _u
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (_u *GroupUpdate) SetIntentRouting(v map[string][]int64) *GroupUpdate {
+	_u.mutation.SetIntentRouting(v)
+	return _u
+}
+
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (_u *GroupUpdate) ClearIntentRouting() *GroupUpdate {
+	_u.mutation.ClearIntentRouting()
+	return _u
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (_u *GroupUpdate) SetIntentRoutingEnabled(v bool) *GroupUpdate {
+	_u.mutation.SetIntentRoutingEnabled(v)
+	return _u
+}
+
+// SetNillableIntentRoutingEnabled sets the "intent_routing_enabled" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableIntentRoutingEnabled(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetIntentRoutingEnabled(*v)
+	}
+	return _u
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (_u *GroupUpdate) SetAllowedEndpoints(v []string) *GroupUpdate {
+	_u.mutation.SetAllowedEndpoints(v)
+	return _u
+}
+
+// AppendAllowedEndpoints appends value to the "allowed_endpoints" field.
+func (_u *GroupUpdate) AppendAllowedEndpoints(v []string) *GroupUpdate {
+	_u.mutation.AppendAllowedEndpoints(v)
+	return _u
+}
+
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (_u *GroupUpdate) ClearAllowedEndpoints() *GroupUpdate {
+	_u.mutation.ClearAllowedEndpoints()
+	return _u
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (_u *GroupUpdate) SetRequireAnthropicVersion(v bool) *GroupUpdate {
+	_u.mutation.SetRequireAnthropicVersion(v)
+	return _u
+}
+
+// SetNillableRequireAnthropicVersion sets the "require_anthropic_version" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableRequireAnthropicVersion(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetRequireAnthropicVersion(*v)
+	}
+	return _u
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (_u *GroupUpdate) SetMaxOutputTokens(v int) *GroupUpdate {
+	_u.mutation.ResetMaxOutputTokens()
+	_u.mutation.SetMaxOutputTokens(v)
+	return _u
+}
+
+// SetNillableMaxOutputTokens sets the "max_output_tokens" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableMaxOutputTokens(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetMaxOutputTokens(*v)
+	}
+	return _u
+}
+
+// AddMaxOutputTokens adds value to the "max_output_tokens" field.
+func (_u *GroupUpdate) AddMaxOutputTokens(v int) *GroupUpdate {
+	_u.mutation.AddMaxOutputTokens(v)
+	return _u
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (_u *GroupUpdate) ClearMaxOutputTokens() *GroupUpdate {
+	_u.mutation.ClearMaxOutputTokens()
+	return _u
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (_u *GroupUpdate) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupUpdate {
+	_u.mutation.SetMixedSchedulingNativeSaturationOnly(v)
+	return _u
+}
+
+// SetNillableMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableMixedSchedulingNativeSaturationOnly(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetMixedSchedulingNativeSaturationOnly(*v)
+	}
+	return _u
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (_u *GroupUpdate) SetWindowCostLimitUsd(v float64) *GroupUpdate {
+	_u.mutation.ResetWindowCostLimitUsd()
+	_u.mutation.SetWindowCostLimitUsd(v)
+	return _u
+}
+
+// SetNillableWindowCostLimitUsd sets the "window_cost_limit_usd" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableWindowCostLimitUsd(v *float64) *GroupUpdate {
+	if v != nil {
+		_u.SetWindowCostLimitUsd(*v)
+	}
+	return _u
+}
+
+// AddWindowCostLimitUsd adds value to the "window_cost_limit_usd" field.
+func (_u *GroupUpdate) AddWindowCostLimitUsd(v float64) *GroupUpdate {
+	_u.mutation.AddWindowCostLimitUsd(v)
+	return _u
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (_u *GroupUpdate) ClearWindowCostLimitUsd() *GroupUpdate {
+	_u.mutation.ClearWindowCostLimitUsd()
+	return _u
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (_u *GroupUpdate) SetWindowCostWindowHours(v int) *GroupUpdate {
+	_u.mutation.ResetWindowCostWindowHours()
+	_u.mutation.SetWindowCostWindowHours(v)
+	return _u
+}
+
+// SetNillableWindowCostWindowHours sets the "window_cost_window_hours" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableWindowCostWindowHours(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetWindowCostWindowHours(*v)
+	}
+	return _u
+}
+
+// AddWindowCostWindowHours adds value to the "window_cost_window_hours" field.
+func (_u *GroupUpdate) AddWindowCostWindowHours(v int) *GroupUpdate {
+	_u.mutation.AddWindowCostWindowHours(v)
+	return _u
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (_u *GroupUpdate) ClearWindowCostWindowHours() *GroupUpdate {
+	_u.mutation.ClearWindowCostWindowHours()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdate) AddAPIKeyIDs(ids ...int64) *GroupUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -781,6 +1042,16 @@ func (_u *GroupUpdate) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Currency(); ok {
+		if err := group.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "Group.currency": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.SubscriptionOverflowPolicy(); ok {
+		if err := group.SubscriptionOverflowPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "subscription_overflow_policy", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_overflow_policy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -832,6 +1103,9 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.SubscriptionType(); ok {
 		_spec.SetField(group.FieldSubscriptionType, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(group.FieldCurrency, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.DailyLimitUsd(); ok {
 		_spec.SetField(group.FieldDailyLimitUsd, field.TypeFloat64, value)
 	}
@@ -939,6 +1213,89 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedSortOrder(); ok {
 		_spec.AddField(group.FieldSortOrder, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.DisableMetadataRewrite(); ok {
+		_spec.SetField(group.FieldDisableMetadataRewrite, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxMessages(); ok {
+		_spec.SetField(group.FieldMaxMessages, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxMessages(); ok {
+		_spec.AddField(group.FieldMaxMessages, field.TypeInt, value)
+	}
+	if _u.mutation.MaxMessagesCleared() {
+		_spec.ClearField(group.FieldMaxMessages, field.TypeInt)
+	}
+	if value, ok := _u.mutation.DailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDailyRequestLimit(); ok {
+		_spec.AddField(group.FieldDailyRequestLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DailyRequestLimitCleared() {
+		_spec.ClearField(group.FieldDailyRequestLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.UpstreamHeaders(); ok {
+		_spec.SetField(group.FieldUpstreamHeaders, field.TypeJSON, value)
+	}
+	if _u.mutation.UpstreamHeadersCleared() {
+		_spec.ClearField(group.FieldUpstreamHeaders, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SubscriptionOverflowPolicy(); ok {
+		_spec.SetField(group.FieldSubscriptionOverflowPolicy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.IntentRouting(); ok {
+		_spec.SetField(group.FieldIntentRouting, field.TypeJSON, value)
+	}
+	if _u.mutation.IntentRoutingCleared() {
+		_spec.ClearField(group.FieldIntentRouting, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IntentRoutingEnabled(); ok {
+		_spec.SetField(group.FieldIntentRoutingEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowedEndpoints(); ok {
+		_spec.SetField(group.FieldAllowedEndpoints, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedEndpoints(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldAllowedEndpoints, value)
+		})
+	}
+	if _u.mutation.AllowedEndpointsCleared() {
+		_spec.ClearField(group.FieldAllowedEndpoints, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RequireAnthropicVersion(); ok {
+		_spec.SetField(group.FieldRequireAnthropicVersion, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxOutputTokens(); ok {
+		_spec.SetField(group.FieldMaxOutputTokens, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxOutputTokens(); ok {
+		_spec.AddField(group.FieldMaxOutputTokens, field.TypeInt, value)
+	}
+	if _u.mutation.MaxOutputTokensCleared() {
+		_spec.ClearField(group.FieldMaxOutputTokens, field.TypeInt)
+	}
+	if value, ok := _u.mutation.MixedSchedulingNativeSaturationOnly(); ok {
+		_spec.SetField(group.FieldMixedSchedulingNativeSaturationOnly, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.WindowCostLimitUsd(); ok {
+		_spec.SetField(group.FieldWindowCostLimitUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedWindowCostLimitUsd(); ok {
+		_spec.AddField(group.FieldWindowCostLimitUsd, field.TypeFloat64, value)
+	}
+	if _u.mutation.WindowCostLimitUsdCleared() {
+		_spec.ClearField(group.FieldWindowCostLimitUsd, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.WindowCostWindowHours(); ok {
+		_spec.SetField(group.FieldWindowCostWindowHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedWindowCostWindowHours(); ok {
+		_spec.AddField(group.FieldWindowCostWindowHours, field.TypeInt, value)
+	}
+	if _u.mutation.WindowCostWindowHoursCleared() {
+		_spec.ClearField(group.FieldWindowCostWindowHours, field.TypeInt)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1390,6 +1747,20 @@ func (_u *GroupUpdateOne) SetNillableSubscriptionType(v *string) *GroupUpdateOne
 	return _u
 }
 
+// SetCurrency sets the "currency" field.
+func (_u *GroupUpdateOne) SetCurrency(v string) *GroupUpdateOne {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableCurrency(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (_u *GroupUpdateOne) SetDailyLimitUsd(v float64) *GroupUpdateOne {
 	_u.mutation.ResetDailyLimitUsd()
@@ -1714,6 +2085,253 @@ func (_u *GroupUpdateOne) AddSortOrder(v int) *GroupUpdateOne {
 	return _u
 }
 
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (_u *GroupUpdateOne) SetDisableMetadataRewrite(v bool) *GroupUpdateOne {
+	_u.mutation.SetDisableMetadataRewrite(v)
+	return _u
+}
+
+// SetNillableDisableMetadataRewrite sets the "disable_metadata_rewrite" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDisableMetadataRewrite(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDisableMetadataRewrite(*v)
+	}
+	return _u
+}
+
+// SetMaxMessages sets the "max_messages" field.
+func (_u *GroupUpdateOne) SetMaxMessages(v int) *GroupUpdateOne {
+	_u.mutation.ResetMaxMessages()
+	_u.mutation.SetMaxMessages(v)
+	return _u
+}
+
+// SetNillableMaxMessages sets the "max_messages" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableMaxMessages(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetMaxMessages(*v)
+	}
+	return _u
+}
+
+// AddMaxMessages adds value to the "max_messages" field.
+func (_u *GroupUpdateOne) AddMaxMessages(v int) *GroupUpdateOne {
+	_u.mutation.AddMaxMessages(v)
+	return _u
+}
+
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (_u *GroupUpdateOne) ClearMaxMessages() *GroupUpdateOne {
+	_u.mutation.ClearMaxMessages()
+	return _u
+}
+
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (_u *GroupUpdateOne) SetDailyRequestLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDailyRequestLimit()
+	_u.mutation.SetDailyRequestLimit(v)
+	return _u
+}
+
+// SetNillableDailyRequestLimit sets the "daily_request_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDailyRequestLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDailyRequestLimit(*v)
+	}
+	return _u
+}
+
+// AddDailyRequestLimit adds value to the "daily_request_limit" field.
+func (_u *GroupUpdateOne) AddDailyRequestLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDailyRequestLimit(v)
+	return _u
+}
+
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (_u *GroupUpdateOne) ClearDailyRequestLimit() *GroupUpdateOne {
+	_u.mutation.ClearDailyRequestLimit()
+	return _u
+}
+
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (_u *GroupUpdateOne) SetUpstreamHeaders(v map[string]string) *GroupUpdateOne {
+	_u.mutation.SetUpstreamHeaders(v)
+	return _u
+}
+
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (_u *GroupUpdateOne) ClearUpstreamHeaders() *GroupUpdateOne {
+	_u.mutation.ClearUpstreamHeaders()
+	return _u
+}
+
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (_u *GroupUpdateOne) SetSubscriptionOverflowPolicy(v string) *GroupUpdateOne {
+	_u.mutation.SetSubscriptionOverflowPolicy(v)
+	return _u
+}
+
+// SetNillableSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableSubscriptionOverflowPolicy(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetSubscriptionOverflowPolicy(*v)
+	}
+	return _u
+}
+
+// SetIntentRouting sets the "intent_routing" field.
+func (_u *GroupUpdateOne) SetIntentRouting(v map[string][]int64) *GroupUpdateOne {
+	_u.mutation.SetIntentRouting(v)
+	return _u
+}
+
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (_u *GroupUpdateOne) ClearIntentRouting() *GroupUpdateOne {
+	_u.mutation.ClearIntentRouting()
+	return _u
+}
+
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (_u *GroupUpdateOne) SetIntentRoutingEnabled(v bool) *GroupUpdateOne {
+	_u.mutation.SetIntentRoutingEnabled(v)
+	return _u
+}
+
+// SetNillableIntentRoutingEnabled sets the "intent_routing_enabled" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableIntentRoutingEnabled(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetIntentRoutingEnabled(*v)
+	}
+	return _u
+}
+
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (_u *GroupUpdateOne) SetAllowedEndpoints(v []string) *GroupUpdateOne {
+	_u.mutation.SetAllowedEndpoints(v)
+	return _u
+}
+
+// AppendAllowedEndpoints appends value to the "allowed_endpoints" field.
+func (_u *GroupUpdateOne) AppendAllowedEndpoints(v []string) *GroupUpdateOne {
+	_u.mutation.AppendAllowedEndpoints(v)
+	return _u
+}
+
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (_u *GroupUpdateOne) ClearAllowedEndpoints() *GroupUpdateOne {
+	_u.mutation.ClearAllowedEndpoints()
+	return _u
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (_u *GroupUpdateOne) SetRequireAnthropicVersion(v bool) *GroupUpdateOne {
+	_u.mutation.SetRequireAnthropicVersion(v)
+	return _u
+}
+
+// SetNillableRequireAnthropicVersion sets the "require_anthropic_version" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableRequireAnthropicVersion(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetRequireAnthropicVersion(*v)
+	}
+	return _u
+}
+
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (_u *GroupUpdateOne) SetMaxOutputTokens(v int) *GroupUpdateOne {
+	_u.mutation.ResetMaxOutputTokens()
+	_u.mutation.SetMaxOutputTokens(v)
+	return _u
+}
+
+// SetNillableMaxOutputTokens sets the "max_output_tokens" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableMaxOutputTokens(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetMaxOutputTokens(*v)
+	}
+	return _u
+}
+
+// AddMaxOutputTokens adds value to the "max_output_tokens" field.
+func (_u *GroupUpdateOne) AddMaxOutputTokens(v int) *GroupUpdateOne {
+	_u.mutation.AddMaxOutputTokens(v)
+	return _u
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (_u *GroupUpdateOne) ClearMaxOutputTokens() *GroupUpdateOne {
+	_u.mutation.ClearMaxOutputTokens()
+	return _u
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (_u *GroupUpdateOne) SetMixedSchedulingNativeSaturationOnly(v bool) *GroupUpdateOne {
+	_u.mutation.SetMixedSchedulingNativeSaturationOnly(v)
+	return _u
+}
+
+// SetNillableMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableMixedSchedulingNativeSaturationOnly(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetMixedSchedulingNativeSaturationOnly(*v)
+	}
+	return _u
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (_u *GroupUpdateOne) SetWindowCostLimitUsd(v float64) *GroupUpdateOne {
+	_u.mutation.ResetWindowCostLimitUsd()
+	_u.mutation.SetWindowCostLimitUsd(v)
+	return _u
+}
+
+// SetNillableWindowCostLimitUsd sets the "window_cost_limit_usd" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableWindowCostLimitUsd(v *float64) *GroupUpdateOne {
+	if v != nil {
+		_u.SetWindowCostLimitUsd(*v)
+	}
+	return _u
+}
+
+// AddWindowCostLimitUsd adds value to the "window_cost_limit_usd" field.
+func (_u *GroupUpdateOne) AddWindowCostLimitUsd(v float64) *GroupUpdateOne {
+	_u.mutation.AddWindowCostLimitUsd(v)
+	return _u
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (_u *GroupUpdateOne) ClearWindowCostLimitUsd() *GroupUpdateOne {
+	_u.mutation.ClearWindowCostLimitUsd()
+	return _u
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (_u *GroupUpdateOne) SetWindowCostWindowHours(v int) *GroupUpdateOne {
+	_u.mutation.ResetWindowCostWindowHours()
+	_u.mutation.SetWindowCostWindowHours(v)
+	return _u
+}
+
+// SetNillableWindowCostWindowHours sets the "window_cost_window_hours" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableWindowCostWindowHours(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetWindowCostWindowHours(*v)
+	}
+	return _u
+}
+
+// AddWindowCostWindowHours adds value to the "window_cost_window_hours" field.
+func (_u *GroupUpdateOne) AddWindowCostWindowHours(v int) *GroupUpdateOne {
+	_u.mutation.AddWindowCostWindowHours(v)
+	return _u
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (_u *GroupUpdateOne) ClearWindowCostWindowHours() *GroupUpdateOne {
+	_u.mutation.ClearWindowCostWindowHours()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdateOne) AddAPIKeyIDs(ids ...int64) *GroupUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -2012,6 +2630,16 @@ func (_u *GroupUpdateOne) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Currency(); ok {
+		if err := group.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "Group.currency": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.SubscriptionOverflowPolicy(); ok {
+		if err := group.SubscriptionOverflowPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "subscription_overflow_policy", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_overflow_policy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -2080,6 +2708,9 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if value, ok := _u.mutation.SubscriptionType(); ok {
 		_spec.SetField(group.FieldSubscriptionType, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(group.FieldCurrency, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.DailyLimitUsd(); ok {
 		_spec.SetField(group.FieldDailyLimitUsd, field.TypeFloat64, value)
 	}
@@ -2187,6 +2818,89 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if value, ok := _u.mutation.AddedSortOrder(); ok {
 		_spec.AddField(group.FieldSortOrder, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.DisableMetadataRewrite(); ok {
+		_spec.SetField(group.FieldDisableMetadataRewrite, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxMessages(); ok {
+		_spec.SetField(group.FieldMaxMessages, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxMessages(); ok {
+		_spec.AddField(group.FieldMaxMessages, field.TypeInt, value)
+	}
+	if _u.mutation.MaxMessagesCleared() {
+		_spec.ClearField(group.FieldMaxMessages, field.TypeInt)
+	}
+	if value, ok := _u.mutation.DailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDailyRequestLimit(); ok {
+		_spec.AddField(group.FieldDailyRequestLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DailyRequestLimitCleared() {
+		_spec.ClearField(group.FieldDailyRequestLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.UpstreamHeaders(); ok {
+		_spec.SetField(group.FieldUpstreamHeaders, field.TypeJSON, value)
+	}
+	if _u.mutation.UpstreamHeadersCleared() {
+		_spec.ClearField(group.FieldUpstreamHeaders, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SubscriptionOverflowPolicy(); ok {
+		_spec.SetField(group.FieldSubscriptionOverflowPolicy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.IntentRouting(); ok {
+		_spec.SetField(group.FieldIntentRouting, field.TypeJSON, value)
+	}
+	if _u.mutation.IntentRoutingCleared() {
+		_spec.ClearField(group.FieldIntentRouting, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IntentRoutingEnabled(); ok {
+		_spec.SetField(group.FieldIntentRoutingEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowedEndpoints(); ok {
+		_spec.SetField(group.FieldAllowedEndpoints, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedEndpoints(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldAllowedEndpoints, value)
+		})
+	}
+	if _u.mutation.AllowedEndpointsCleared() {
+		_spec.ClearField(group.FieldAllowedEndpoints, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RequireAnthropicVersion(); ok {
+		_spec.SetField(group.FieldRequireAnthropicVersion, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxOutputTokens(); ok {
+		_spec.SetField(group.FieldMaxOutputTokens, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxOutputTokens(); ok {
+		_spec.AddField(group.FieldMaxOutputTokens, field.TypeInt, value)
+	}
+	if _u.mutation.MaxOutputTokensCleared() {
+		_spec.ClearField(group.FieldMaxOutputTokens, field.TypeInt)
+	}
+	if value, ok := _u.mutation.MixedSchedulingNativeSaturationOnly(); ok {
+		_spec.SetField(group.FieldMixedSchedulingNativeSaturationOnly, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.WindowCostLimitUsd(); ok {
+		_spec.SetField(group.FieldWindowCostLimitUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedWindowCostLimitUsd(); ok {
+		_spec.AddField(group.FieldWindowCostLimitUsd, field.TypeFloat64, value)
+	}
+	if _u.mutation.WindowCostLimitUsdCleared() {
+		_spec.ClearField(group.FieldWindowCostLimitUsd, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.WindowCostWindowHours(); ok {
+		_spec.SetField(group.FieldWindowCostWindowHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedWindowCostWindowHours(); ok {
+		_spec.AddField(group.FieldWindowCostWindowHours, field.TypeInt, value)
+	}
+	if _u.mutation.WindowCostWindowHoursCleared() {
+		_spec.ClearField(group.FieldWindowCostWindowHours, field.TypeInt)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
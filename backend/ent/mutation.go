@@ -24,6 +24,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
 	"github.com/Wei-Shaw/sub2api/ent/proxy"
 	"github.com/Wei-Shaw/sub2api/ent/redeemcode"
+	"github.com/Wei-Shaw/sub2api/ent/sessionbinding"
 	"github.com/Wei-Shaw/sub2api/ent/setting"
 	"github.com/Wei-Shaw/sub2api/ent/usagecleanuptask"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
@@ -55,6 +56,7 @@ const (
 	TypePromoCodeUsage          = "PromoCodeUsage"
 	TypeProxy                   = "Proxy"
 	TypeRedeemCode              = "RedeemCode"
+	TypeSessionBinding          = "SessionBinding"
 	TypeSetting                 = "Setting"
 	TypeUsageCleanupTask        = "UsageCleanupTask"
 	TypeUsageLog                = "UsageLog"
@@ -1428,48 +1430,57 @@ func (m *APIKeyMutation) ResetEdge(name string) error {
 // AccountMutation represents an operation that mutates the Account nodes in the graph.
 type AccountMutation struct {
 	config
-	op                    Op
-	typ                   string
-	id                    *int64
-	created_at            *time.Time
-	updated_at            *time.Time
-	deleted_at            *time.Time
-	name                  *string
-	notes                 *string
-	platform              *string
-	_type                 *string
-	credentials           *map[string]interface{}
-	extra                 *map[string]interface{}
-	concurrency           *int
-	addconcurrency        *int
-	priority              *int
-	addpriority           *int
-	rate_multiplier       *float64
-	addrate_multiplier    *float64
-	status                *string
-	error_message         *string
-	last_used_at          *time.Time
-	expires_at            *time.Time
-	auto_pause_on_expired *bool
-	schedulable           *bool
-	rate_limited_at       *time.Time
-	rate_limit_reset_at   *time.Time
-	overload_until        *time.Time
-	session_window_start  *time.Time
-	session_window_end    *time.Time
-	session_window_status *string
-	clearedFields         map[string]struct{}
-	groups                map[int64]struct{}
-	removedgroups         map[int64]struct{}
-	clearedgroups         bool
-	proxy                 *int64
-	clearedproxy          bool
-	usage_logs            map[int64]struct{}
-	removedusage_logs     map[int64]struct{}
-	clearedusage_logs     bool
-	done                  bool
-	oldValue              func(context.Context) (*Account, error)
-	predicates            []predicate.Account
+	op                            Op
+	typ                           string
+	id                            *int64
+	created_at                    *time.Time
+	updated_at                    *time.Time
+	deleted_at                    *time.Time
+	name                          *string
+	notes                         *string
+	platform                      *string
+	_type                         *string
+	credentials                   *map[string]interface{}
+	extra                         *map[string]interface{}
+	concurrency                   *int
+	addconcurrency                *int
+	priority                      *int
+	addpriority                   *int
+	affinity_group                *string
+	max_line_size                 *int
+	addmax_line_size              *int
+	rate_multiplier               *float64
+	addrate_multiplier            *float64
+	status                        *string
+	error_message                 *string
+	last_used_at                  *time.Time
+	expires_at                    *time.Time
+	auto_pause_on_expired         *bool
+	schedulable                   *bool
+	rate_limited_at               *time.Time
+	rate_limit_reset_at           *time.Time
+	overload_until                *time.Time
+	session_window_start          *time.Time
+	session_window_end            *time.Time
+	session_window_status         *string
+	session_window_utilization    *int
+	addsession_window_utilization *int
+	quiet_hours_start_minute      *int
+	addquiet_hours_start_minute   *int
+	quiet_hours_end_minute        *int
+	addquiet_hours_end_minute     *int
+	clearedFields                 map[string]struct{}
+	groups                        map[int64]struct{}
+	removedgroups                 map[int64]struct{}
+	clearedgroups                 bool
+	proxy                         *int64
+	clearedproxy                  bool
+	usage_logs                    map[int64]struct{}
+	removedusage_logs             map[int64]struct{}
+	clearedusage_logs             bool
+	done                          bool
+	oldValue                      func(context.Context) (*Account, error)
+	predicates                    []predicate.Account
 }
 
 var _ ent.Mutation = (*AccountMutation)(nil)
@@ -2081,6 +2092,111 @@ func (m *AccountMutation) ResetPriority() {
 	m.addpriority = nil
 }
 
+// SetAffinityGroup sets the "affinity_group" field.
+func (m *AccountMutation) SetAffinityGroup(s string) {
+	m.affinity_group = &s
+}
+
+// AffinityGroup returns the value of the "affinity_group" field in the mutation.
+func (m *AccountMutation) AffinityGroup() (r string, exists bool) {
+	v := m.affinity_group
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAffinityGroup returns the old "affinity_group" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldAffinityGroup(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAffinityGroup is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAffinityGroup requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAffinityGroup: %w", err)
+	}
+	return oldValue.AffinityGroup, nil
+}
+
+// ClearAffinityGroup clears the value of the "affinity_group" field.
+func (m *AccountMutation) ClearAffinityGroup() {
+	m.affinity_group = nil
+	m.clearedFields[account.FieldAffinityGroup] = struct{}{}
+}
+
+// AffinityGroupCleared returns if the "affinity_group" field was cleared in this mutation.
+func (m *AccountMutation) AffinityGroupCleared() bool {
+	_, ok := m.clearedFields[account.FieldAffinityGroup]
+	return ok
+}
+
+// ResetAffinityGroup resets all changes to the "affinity_group" field.
+func (m *AccountMutation) ResetAffinityGroup() {
+	m.affinity_group = nil
+	delete(m.clearedFields, account.FieldAffinityGroup)
+}
+
+// SetMaxLineSize sets the "max_line_size" field.
+func (m *AccountMutation) SetMaxLineSize(i int) {
+	m.max_line_size = &i
+	m.addmax_line_size = nil
+}
+
+// MaxLineSize returns the value of the "max_line_size" field in the mutation.
+func (m *AccountMutation) MaxLineSize() (r int, exists bool) {
+	v := m.max_line_size
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxLineSize returns the old "max_line_size" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldMaxLineSize(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxLineSize is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxLineSize requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxLineSize: %w", err)
+	}
+	return oldValue.MaxLineSize, nil
+}
+
+// AddMaxLineSize adds i to the "max_line_size" field.
+func (m *AccountMutation) AddMaxLineSize(i int) {
+	if m.addmax_line_size != nil {
+		*m.addmax_line_size += i
+	} else {
+		m.addmax_line_size = &i
+	}
+}
+
+// AddedMaxLineSize returns the value that was added to the "max_line_size" field in this mutation.
+func (m *AccountMutation) AddedMaxLineSize() (r int, exists bool) {
+	v := m.addmax_line_size
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxLineSize resets all changes to the "max_line_size" field.
+func (m *AccountMutation) ResetMaxLineSize() {
+	m.max_line_size = nil
+	m.addmax_line_size = nil
+}
+
 // SetRateMultiplier sets the "rate_multiplier" field.
 func (m *AccountMutation) SetRateMultiplier(f float64) {
 	m.rate_multiplier = &f
@@ -2686,6 +2802,216 @@ func (m *AccountMutation) ResetSessionWindowStatus() {
 	delete(m.clearedFields, account.FieldSessionWindowStatus)
 }
 
+// SetSessionWindowUtilization sets the "session_window_utilization" field.
+func (m *AccountMutation) SetSessionWindowUtilization(i int) {
+	m.session_window_utilization = &i
+	m.addsession_window_utilization = nil
+}
+
+// SessionWindowUtilization returns the value of the "session_window_utilization" field in the mutation.
+func (m *AccountMutation) SessionWindowUtilization() (r int, exists bool) {
+	v := m.session_window_utilization
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionWindowUtilization returns the old "session_window_utilization" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldSessionWindowUtilization(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionWindowUtilization is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionWindowUtilization requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionWindowUtilization: %w", err)
+	}
+	return oldValue.SessionWindowUtilization, nil
+}
+
+// AddSessionWindowUtilization adds i to the "session_window_utilization" field.
+func (m *AccountMutation) AddSessionWindowUtilization(i int) {
+	if m.addsession_window_utilization != nil {
+		*m.addsession_window_utilization += i
+	} else {
+		m.addsession_window_utilization = &i
+	}
+}
+
+// AddedSessionWindowUtilization returns the value that was added to the "session_window_utilization" field in this mutation.
+func (m *AccountMutation) AddedSessionWindowUtilization() (r int, exists bool) {
+	v := m.addsession_window_utilization
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearSessionWindowUtilization clears the value of the "session_window_utilization" field.
+func (m *AccountMutation) ClearSessionWindowUtilization() {
+	m.session_window_utilization = nil
+	m.addsession_window_utilization = nil
+	m.clearedFields[account.FieldSessionWindowUtilization] = struct{}{}
+}
+
+// SessionWindowUtilizationCleared returns if the "session_window_utilization" field was cleared in this mutation.
+func (m *AccountMutation) SessionWindowUtilizationCleared() bool {
+	_, ok := m.clearedFields[account.FieldSessionWindowUtilization]
+	return ok
+}
+
+// ResetSessionWindowUtilization resets all changes to the "session_window_utilization" field.
+func (m *AccountMutation) ResetSessionWindowUtilization() {
+	m.session_window_utilization = nil
+	m.addsession_window_utilization = nil
+	delete(m.clearedFields, account.FieldSessionWindowUtilization)
+}
+
+// SetQuietHoursStartMinute sets the "quiet_hours_start_minute" field.
+func (m *AccountMutation) SetQuietHoursStartMinute(i int) {
+	m.quiet_hours_start_minute = &i
+	m.addquiet_hours_start_minute = nil
+}
+
+// QuietHoursStartMinute returns the value of the "quiet_hours_start_minute" field in the mutation.
+func (m *AccountMutation) QuietHoursStartMinute() (r int, exists bool) {
+	v := m.quiet_hours_start_minute
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuietHoursStartMinute returns the old "quiet_hours_start_minute" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldQuietHoursStartMinute(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuietHoursStartMinute is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuietHoursStartMinute requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuietHoursStartMinute: %w", err)
+	}
+	return oldValue.QuietHoursStartMinute, nil
+}
+
+// AddQuietHoursStartMinute adds i to the "quiet_hours_start_minute" field.
+func (m *AccountMutation) AddQuietHoursStartMinute(i int) {
+	if m.addquiet_hours_start_minute != nil {
+		*m.addquiet_hours_start_minute += i
+	} else {
+		m.addquiet_hours_start_minute = &i
+	}
+}
+
+// AddedQuietHoursStartMinute returns the value that was added to the "quiet_hours_start_minute" field in this mutation.
+func (m *AccountMutation) AddedQuietHoursStartMinute() (r int, exists bool) {
+	v := m.addquiet_hours_start_minute
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearQuietHoursStartMinute clears the value of the "quiet_hours_start_minute" field.
+func (m *AccountMutation) ClearQuietHoursStartMinute() {
+	m.quiet_hours_start_minute = nil
+	m.addquiet_hours_start_minute = nil
+	m.clearedFields[account.FieldQuietHoursStartMinute] = struct{}{}
+}
+
+// QuietHoursStartMinuteCleared returns if the "quiet_hours_start_minute" field was cleared in this mutation.
+func (m *AccountMutation) QuietHoursStartMinuteCleared() bool {
+	_, ok := m.clearedFields[account.FieldQuietHoursStartMinute]
+	return ok
+}
+
+// ResetQuietHoursStartMinute resets all changes to the "quiet_hours_start_minute" field.
+func (m *AccountMutation) ResetQuietHoursStartMinute() {
+	m.quiet_hours_start_minute = nil
+	m.addquiet_hours_start_minute = nil
+	delete(m.clearedFields, account.FieldQuietHoursStartMinute)
+}
+
+// SetQuietHoursEndMinute sets the "quiet_hours_end_minute" field.
+func (m *AccountMutation) SetQuietHoursEndMinute(i int) {
+	m.quiet_hours_end_minute = &i
+	m.addquiet_hours_end_minute = nil
+}
+
+// QuietHoursEndMinute returns the value of the "quiet_hours_end_minute" field in the mutation.
+func (m *AccountMutation) QuietHoursEndMinute() (r int, exists bool) {
+	v := m.quiet_hours_end_minute
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuietHoursEndMinute returns the old "quiet_hours_end_minute" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldQuietHoursEndMinute(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuietHoursEndMinute is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuietHoursEndMinute requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuietHoursEndMinute: %w", err)
+	}
+	return oldValue.QuietHoursEndMinute, nil
+}
+
+// AddQuietHoursEndMinute adds i to the "quiet_hours_end_minute" field.
+func (m *AccountMutation) AddQuietHoursEndMinute(i int) {
+	if m.addquiet_hours_end_minute != nil {
+		*m.addquiet_hours_end_minute += i
+	} else {
+		m.addquiet_hours_end_minute = &i
+	}
+}
+
+// AddedQuietHoursEndMinute returns the value that was added to the "quiet_hours_end_minute" field in this mutation.
+func (m *AccountMutation) AddedQuietHoursEndMinute() (r int, exists bool) {
+	v := m.addquiet_hours_end_minute
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearQuietHoursEndMinute clears the value of the "quiet_hours_end_minute" field.
+func (m *AccountMutation) ClearQuietHoursEndMinute() {
+	m.quiet_hours_end_minute = nil
+	m.addquiet_hours_end_minute = nil
+	m.clearedFields[account.FieldQuietHoursEndMinute] = struct{}{}
+}
+
+// QuietHoursEndMinuteCleared returns if the "quiet_hours_end_minute" field was cleared in this mutation.
+func (m *AccountMutation) QuietHoursEndMinuteCleared() bool {
+	_, ok := m.clearedFields[account.FieldQuietHoursEndMinute]
+	return ok
+}
+
+// ResetQuietHoursEndMinute resets all changes to the "quiet_hours_end_minute" field.
+func (m *AccountMutation) ResetQuietHoursEndMinute() {
+	m.quiet_hours_end_minute = nil
+	m.addquiet_hours_end_minute = nil
+	delete(m.clearedFields, account.FieldQuietHoursEndMinute)
+}
+
 // AddGroupIDs adds the "groups" edge to the Group entity by ids.
 func (m *AccountMutation) AddGroupIDs(ids ...int64) {
 	if m.groups == nil {
@@ -2855,7 +3181,7 @@ func (m *AccountMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *AccountMutation) Fields() []string {
-	fields := make([]string, 0, 25)
+	fields := make([]string, 0, 30)
 	if m.created_at != nil {
 		fields = append(fields, account.FieldCreatedAt)
 	}
@@ -2892,6 +3218,12 @@ func (m *AccountMutation) Fields() []string {
 	if m.priority != nil {
 		fields = append(fields, account.FieldPriority)
 	}
+	if m.affinity_group != nil {
+		fields = append(fields, account.FieldAffinityGroup)
+	}
+	if m.max_line_size != nil {
+		fields = append(fields, account.FieldMaxLineSize)
+	}
 	if m.rate_multiplier != nil {
 		fields = append(fields, account.FieldRateMultiplier)
 	}
@@ -2931,6 +3263,15 @@ func (m *AccountMutation) Fields() []string {
 	if m.session_window_status != nil {
 		fields = append(fields, account.FieldSessionWindowStatus)
 	}
+	if m.session_window_utilization != nil {
+		fields = append(fields, account.FieldSessionWindowUtilization)
+	}
+	if m.quiet_hours_start_minute != nil {
+		fields = append(fields, account.FieldQuietHoursStartMinute)
+	}
+	if m.quiet_hours_end_minute != nil {
+		fields = append(fields, account.FieldQuietHoursEndMinute)
+	}
 	return fields
 }
 
@@ -2963,6 +3304,10 @@ func (m *AccountMutation) Field(name string) (ent.Value, bool) {
 		return m.Concurrency()
 	case account.FieldPriority:
 		return m.Priority()
+	case account.FieldAffinityGroup:
+		return m.AffinityGroup()
+	case account.FieldMaxLineSize:
+		return m.MaxLineSize()
 	case account.FieldRateMultiplier:
 		return m.RateMultiplier()
 	case account.FieldStatus:
@@ -2989,6 +3334,12 @@ func (m *AccountMutation) Field(name string) (ent.Value, bool) {
 		return m.SessionWindowEnd()
 	case account.FieldSessionWindowStatus:
 		return m.SessionWindowStatus()
+	case account.FieldSessionWindowUtilization:
+		return m.SessionWindowUtilization()
+	case account.FieldQuietHoursStartMinute:
+		return m.QuietHoursStartMinute()
+	case account.FieldQuietHoursEndMinute:
+		return m.QuietHoursEndMinute()
 	}
 	return nil, false
 }
@@ -3022,6 +3373,10 @@ func (m *AccountMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldConcurrency(ctx)
 	case account.FieldPriority:
 		return m.OldPriority(ctx)
+	case account.FieldAffinityGroup:
+		return m.OldAffinityGroup(ctx)
+	case account.FieldMaxLineSize:
+		return m.OldMaxLineSize(ctx)
 	case account.FieldRateMultiplier:
 		return m.OldRateMultiplier(ctx)
 	case account.FieldStatus:
@@ -3048,6 +3403,12 @@ func (m *AccountMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldSessionWindowEnd(ctx)
 	case account.FieldSessionWindowStatus:
 		return m.OldSessionWindowStatus(ctx)
+	case account.FieldSessionWindowUtilization:
+		return m.OldSessionWindowUtilization(ctx)
+	case account.FieldQuietHoursStartMinute:
+		return m.OldQuietHoursStartMinute(ctx)
+	case account.FieldQuietHoursEndMinute:
+		return m.OldQuietHoursEndMinute(ctx)
 	}
 	return nil, fmt.Errorf("unknown Account field %s", name)
 }
@@ -3141,6 +3502,20 @@ func (m *AccountMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPriority(v)
 		return nil
+	case account.FieldAffinityGroup:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAffinityGroup(v)
+		return nil
+	case account.FieldMaxLineSize:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxLineSize(v)
+		return nil
 	case account.FieldRateMultiplier:
 		v, ok := value.(float64)
 		if !ok {
@@ -3232,6 +3607,27 @@ func (m *AccountMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetSessionWindowStatus(v)
 		return nil
+	case account.FieldSessionWindowUtilization:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionWindowUtilization(v)
+		return nil
+	case account.FieldQuietHoursStartMinute:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuietHoursStartMinute(v)
+		return nil
+	case account.FieldQuietHoursEndMinute:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuietHoursEndMinute(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Account field %s", name)
 }
@@ -3246,9 +3642,21 @@ func (m *AccountMutation) AddedFields() []string {
 	if m.addpriority != nil {
 		fields = append(fields, account.FieldPriority)
 	}
+	if m.addmax_line_size != nil {
+		fields = append(fields, account.FieldMaxLineSize)
+	}
 	if m.addrate_multiplier != nil {
 		fields = append(fields, account.FieldRateMultiplier)
 	}
+	if m.addsession_window_utilization != nil {
+		fields = append(fields, account.FieldSessionWindowUtilization)
+	}
+	if m.addquiet_hours_start_minute != nil {
+		fields = append(fields, account.FieldQuietHoursStartMinute)
+	}
+	if m.addquiet_hours_end_minute != nil {
+		fields = append(fields, account.FieldQuietHoursEndMinute)
+	}
 	return fields
 }
 
@@ -3261,8 +3669,16 @@ func (m *AccountMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedConcurrency()
 	case account.FieldPriority:
 		return m.AddedPriority()
+	case account.FieldMaxLineSize:
+		return m.AddedMaxLineSize()
 	case account.FieldRateMultiplier:
 		return m.AddedRateMultiplier()
+	case account.FieldSessionWindowUtilization:
+		return m.AddedSessionWindowUtilization()
+	case account.FieldQuietHoursStartMinute:
+		return m.AddedQuietHoursStartMinute()
+	case account.FieldQuietHoursEndMinute:
+		return m.AddedQuietHoursEndMinute()
 	}
 	return nil, false
 }
@@ -3286,6 +3702,13 @@ func (m *AccountMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddPriority(v)
 		return nil
+	case account.FieldMaxLineSize:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxLineSize(v)
+		return nil
 	case account.FieldRateMultiplier:
 		v, ok := value.(float64)
 		if !ok {
@@ -3293,6 +3716,27 @@ func (m *AccountMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddRateMultiplier(v)
 		return nil
+	case account.FieldSessionWindowUtilization:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSessionWindowUtilization(v)
+		return nil
+	case account.FieldQuietHoursStartMinute:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQuietHoursStartMinute(v)
+		return nil
+	case account.FieldQuietHoursEndMinute:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQuietHoursEndMinute(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Account numeric field %s", name)
 }
@@ -3310,6 +3754,9 @@ func (m *AccountMutation) ClearedFields() []string {
 	if m.FieldCleared(account.FieldProxyID) {
 		fields = append(fields, account.FieldProxyID)
 	}
+	if m.FieldCleared(account.FieldAffinityGroup) {
+		fields = append(fields, account.FieldAffinityGroup)
+	}
 	if m.FieldCleared(account.FieldErrorMessage) {
 		fields = append(fields, account.FieldErrorMessage)
 	}
@@ -3337,6 +3784,15 @@ func (m *AccountMutation) ClearedFields() []string {
 	if m.FieldCleared(account.FieldSessionWindowStatus) {
 		fields = append(fields, account.FieldSessionWindowStatus)
 	}
+	if m.FieldCleared(account.FieldSessionWindowUtilization) {
+		fields = append(fields, account.FieldSessionWindowUtilization)
+	}
+	if m.FieldCleared(account.FieldQuietHoursStartMinute) {
+		fields = append(fields, account.FieldQuietHoursStartMinute)
+	}
+	if m.FieldCleared(account.FieldQuietHoursEndMinute) {
+		fields = append(fields, account.FieldQuietHoursEndMinute)
+	}
 	return fields
 }
 
@@ -3360,6 +3816,9 @@ func (m *AccountMutation) ClearField(name string) error {
 	case account.FieldProxyID:
 		m.ClearProxyID()
 		return nil
+	case account.FieldAffinityGroup:
+		m.ClearAffinityGroup()
+		return nil
 	case account.FieldErrorMessage:
 		m.ClearErrorMessage()
 		return nil
@@ -3387,6 +3846,15 @@ func (m *AccountMutation) ClearField(name string) error {
 	case account.FieldSessionWindowStatus:
 		m.ClearSessionWindowStatus()
 		return nil
+	case account.FieldSessionWindowUtilization:
+		m.ClearSessionWindowUtilization()
+		return nil
+	case account.FieldQuietHoursStartMinute:
+		m.ClearQuietHoursStartMinute()
+		return nil
+	case account.FieldQuietHoursEndMinute:
+		m.ClearQuietHoursEndMinute()
+		return nil
 	}
 	return fmt.Errorf("unknown Account nullable field %s", name)
 }
@@ -3431,6 +3899,12 @@ func (m *AccountMutation) ResetField(name string) error {
 	case account.FieldPriority:
 		m.ResetPriority()
 		return nil
+	case account.FieldAffinityGroup:
+		m.ResetAffinityGroup()
+		return nil
+	case account.FieldMaxLineSize:
+		m.ResetMaxLineSize()
+		return nil
 	case account.FieldRateMultiplier:
 		m.ResetRateMultiplier()
 		return nil
@@ -3470,6 +3944,15 @@ func (m *AccountMutation) ResetField(name string) error {
 	case account.FieldSessionWindowStatus:
 		m.ResetSessionWindowStatus()
 		return nil
+	case account.FieldSessionWindowUtilization:
+		m.ResetSessionWindowUtilization()
+		return nil
+	case account.FieldQuietHoursStartMinute:
+		m.ResetQuietHoursStartMinute()
+		return nil
+	case account.FieldQuietHoursEndMinute:
+		m.ResetQuietHoursEndMinute()
+		return nil
 	}
 	return fmt.Errorf("unknown Account field %s", name)
 }
@@ -3605,19 +4088,21 @@ func (m *AccountMutation) ResetEdge(name string) error {
 // AccountGroupMutation represents an operation that mutates the AccountGroup nodes in the graph.
 type AccountGroupMutation struct {
 	config
-	op             Op
-	typ            string
-	priority       *int
-	addpriority    *int
-	created_at     *time.Time
-	clearedFields  map[string]struct{}
-	account        *int64
-	clearedaccount bool
-	group          *int64
-	clearedgroup   bool
-	done           bool
-	oldValue       func(context.Context) (*AccountGroup, error)
-	predicates     []predicate.AccountGroup
+	op                Op
+	typ               string
+	priority          *int
+	addpriority       *int
+	reserved_slots    *int
+	addreserved_slots *int
+	created_at        *time.Time
+	clearedFields     map[string]struct{}
+	account           *int64
+	clearedaccount    bool
+	group             *int64
+	clearedgroup      bool
+	done              bool
+	oldValue          func(context.Context) (*AccountGroup, error)
+	predicates        []predicate.AccountGroup
 }
 
 var _ ent.Mutation = (*AccountGroupMutation)(nil)
@@ -3735,8 +4220,47 @@ func (m *AccountGroupMutation) ResetPriority() {
 	m.addpriority = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *AccountGroupMutation) SetCreatedAt(t time.Time) {
+// SetReservedSlots sets the "reserved_slots" field.
+func (m *AccountGroupMutation) SetReservedSlots(i int) {
+	m.reserved_slots = &i
+	m.addreserved_slots = nil
+}
+
+// ReservedSlots returns the value of the "reserved_slots" field in the mutation.
+func (m *AccountGroupMutation) ReservedSlots() (r int, exists bool) {
+	v := m.reserved_slots
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// AddReservedSlots adds i to the "reserved_slots" field.
+func (m *AccountGroupMutation) AddReservedSlots(i int) {
+	if m.addreserved_slots != nil {
+		*m.addreserved_slots += i
+	} else {
+		m.addreserved_slots = &i
+	}
+}
+
+// AddedReservedSlots returns the value that was added to the "reserved_slots" field in this mutation.
+func (m *AccountGroupMutation) AddedReservedSlots() (r int, exists bool) {
+	v := m.addreserved_slots
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetReservedSlots resets all changes to the "reserved_slots" field.
+func (m *AccountGroupMutation) ResetReservedSlots() {
+	m.reserved_slots = nil
+	m.addreserved_slots = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *AccountGroupMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
@@ -3842,7 +4366,7 @@ func (m *AccountGroupMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *AccountGroupMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+	fields := make([]string, 0, 5)
 	if m.account != nil {
 		fields = append(fields, accountgroup.FieldAccountID)
 	}
@@ -3852,6 +4376,9 @@ func (m *AccountGroupMutation) Fields() []string {
 	if m.priority != nil {
 		fields = append(fields, accountgroup.FieldPriority)
 	}
+	if m.reserved_slots != nil {
+		fields = append(fields, accountgroup.FieldReservedSlots)
+	}
 	if m.created_at != nil {
 		fields = append(fields, accountgroup.FieldCreatedAt)
 	}
@@ -3869,6 +4396,8 @@ func (m *AccountGroupMutation) Field(name string) (ent.Value, bool) {
 		return m.GroupID()
 	case accountgroup.FieldPriority:
 		return m.Priority()
+	case accountgroup.FieldReservedSlots:
+		return m.ReservedSlots()
 	case accountgroup.FieldCreatedAt:
 		return m.CreatedAt()
 	}
@@ -3908,6 +4437,13 @@ func (m *AccountGroupMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPriority(v)
 		return nil
+	case accountgroup.FieldReservedSlots:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReservedSlots(v)
+		return nil
 	case accountgroup.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -3926,6 +4462,9 @@ func (m *AccountGroupMutation) AddedFields() []string {
 	if m.addpriority != nil {
 		fields = append(fields, accountgroup.FieldPriority)
 	}
+	if m.addreserved_slots != nil {
+		fields = append(fields, accountgroup.FieldReservedSlots)
+	}
 	return fields
 }
 
@@ -3936,6 +4475,8 @@ func (m *AccountGroupMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
 	case accountgroup.FieldPriority:
 		return m.AddedPriority()
+	case accountgroup.FieldReservedSlots:
+		return m.AddedReservedSlots()
 	}
 	return nil, false
 }
@@ -3952,6 +4493,13 @@ func (m *AccountGroupMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddPriority(v)
 		return nil
+	case accountgroup.FieldReservedSlots:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddReservedSlots(v)
+		return nil
 	}
 	return fmt.Errorf("unknown AccountGroup numeric field %s", name)
 }
@@ -3988,6 +4536,9 @@ func (m *AccountGroupMutation) ResetField(name string) error {
 	case accountgroup.FieldPriority:
 		m.ResetPriority()
 		return nil
+	case accountgroup.FieldReservedSlots:
+		m.ResetReservedSlots()
+		return nil
 	case accountgroup.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -7089,6 +7640,7 @@ type GroupMutation struct {
 	status                                  *string
 	platform                                *string
 	subscription_type                       *string
+	currency                                *string
 	daily_limit_usd                         *float64
 	adddaily_limit_usd                      *float64
 	weekly_limit_usd                        *float64
@@ -7115,6 +7667,25 @@ type GroupMutation struct {
 	appendsupported_model_scopes            []string
 	sort_order                              *int
 	addsort_order                           *int
+	disable_metadata_rewrite                *bool
+	max_messages                            *int
+	addmax_messages                         *int
+	daily_request_limit                     *int
+	adddaily_request_limit                  *int
+	upstream_headers                        *map[string]string
+	subscription_overflow_policy            *string
+	intent_routing                          *map[string][]int64
+	intent_routing_enabled                  *bool
+	allowed_endpoints                       *[]string
+	appendallowed_endpoints                 []string
+	require_anthropic_version               *bool
+	max_output_tokens                       *int
+	addmax_output_tokens                    *int
+	mixed_scheduling_native_saturation_only *bool
+	window_cost_limit_usd                   *float64
+	addwindow_cost_limit_usd                *float64
+	window_cost_window_hours                *int
+	addwindow_cost_window_hours             *int
 	clearedFields                           map[string]struct{}
 	api_keys                                map[int64]struct{}
 	removedapi_keys                         map[int64]struct{}
@@ -7643,6 +8214,42 @@ func (m *GroupMutation) ResetSubscriptionType() {
 	m.subscription_type = nil
 }
 
+// SetCurrency sets the "currency" field.
+func (m *GroupMutation) SetCurrency(s string) {
+	m.currency = &s
+}
+
+// Currency returns the value of the "currency" field in the mutation.
+func (m *GroupMutation) Currency() (r string, exists bool) {
+	v := m.currency
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCurrency returns the old "currency" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldCurrency(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCurrency requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
+	}
+	return oldValue.Currency, nil
+}
+
+// ResetCurrency resets all changes to the "currency" field.
+func (m *GroupMutation) ResetCurrency() {
+	m.currency = nil
+}
+
 // SetDailyLimitUsd sets the "daily_limit_usd" field.
 func (m *GroupMutation) SetDailyLimitUsd(f float64) {
 	m.daily_limit_usd = &f
@@ -8523,1885 +9130,3664 @@ func (m *GroupMutation) ResetSortOrder() {
 	m.addsort_order = nil
 }
 
-// AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
-func (m *GroupMutation) AddAPIKeyIDs(ids ...int64) {
-	if m.api_keys == nil {
-		m.api_keys = make(map[int64]struct{})
+// SetDisableMetadataRewrite sets the "disable_metadata_rewrite" field.
+func (m *GroupMutation) SetDisableMetadataRewrite(b bool) {
+	m.disable_metadata_rewrite = &b
+}
+
+// DisableMetadataRewrite returns the value of the "disable_metadata_rewrite" field in the mutation.
+func (m *GroupMutation) DisableMetadataRewrite() (r bool, exists bool) {
+	v := m.disable_metadata_rewrite
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		m.api_keys[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldDisableMetadataRewrite returns the old "disable_metadata_rewrite" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDisableMetadataRewrite(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisableMetadataRewrite is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisableMetadataRewrite requires an ID field in the mutation")
 	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisableMetadataRewrite: %w", err)
+	}
+	return oldValue.DisableMetadataRewrite, nil
 }
 
-// ClearAPIKeys clears the "api_keys" edge to the APIKey entity.
-func (m *GroupMutation) ClearAPIKeys() {
-	m.clearedapi_keys = true
+// ResetDisableMetadataRewrite resets all changes to the "disable_metadata_rewrite" field.
+func (m *GroupMutation) ResetDisableMetadataRewrite() {
+	m.disable_metadata_rewrite = nil
 }
 
-// APIKeysCleared reports if the "api_keys" edge to the APIKey entity was cleared.
-func (m *GroupMutation) APIKeysCleared() bool {
-	return m.clearedapi_keys
+// SetMaxMessages sets the "max_messages" field.
+func (m *GroupMutation) SetMaxMessages(i int) {
+	m.max_messages = &i
+	m.addmax_messages = nil
 }
 
-// RemoveAPIKeyIDs removes the "api_keys" edge to the APIKey entity by IDs.
-func (m *GroupMutation) RemoveAPIKeyIDs(ids ...int64) {
-	if m.removedapi_keys == nil {
-		m.removedapi_keys = make(map[int64]struct{})
+// MaxMessages returns the value of the "max_messages" field in the mutation.
+func (m *GroupMutation) MaxMessages() (r int, exists bool) {
+	v := m.max_messages
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		delete(m.api_keys, ids[i])
-		m.removedapi_keys[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldMaxMessages returns the old "max_messages" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldMaxMessages(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxMessages is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxMessages requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxMessages: %w", err)
 	}
+	return oldValue.MaxMessages, nil
 }
 
-// RemovedAPIKeys returns the removed IDs of the "api_keys" edge to the APIKey entity.
-func (m *GroupMutation) RemovedAPIKeysIDs() (ids []int64) {
-	for id := range m.removedapi_keys {
-		ids = append(ids, id)
+// AddMaxMessages adds i to the "max_messages" field.
+func (m *GroupMutation) AddMaxMessages(i int) {
+	if m.addmax_messages != nil {
+		*m.addmax_messages += i
+	} else {
+		m.addmax_messages = &i
 	}
-	return
 }
 
-// APIKeysIDs returns the "api_keys" edge IDs in the mutation.
-func (m *GroupMutation) APIKeysIDs() (ids []int64) {
-	for id := range m.api_keys {
-		ids = append(ids, id)
+// AddedMaxMessages returns the value that was added to the "max_messages" field in this mutation.
+func (m *GroupMutation) AddedMaxMessages() (r int, exists bool) {
+	v := m.addmax_messages
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetAPIKeys resets all changes to the "api_keys" edge.
-func (m *GroupMutation) ResetAPIKeys() {
-	m.api_keys = nil
-	m.clearedapi_keys = false
-	m.removedapi_keys = nil
+// ClearMaxMessages clears the value of the "max_messages" field.
+func (m *GroupMutation) ClearMaxMessages() {
+	m.max_messages = nil
+	m.addmax_messages = nil
+	m.clearedFields[group.FieldMaxMessages] = struct{}{}
 }
 
-// AddRedeemCodeIDs adds the "redeem_codes" edge to the RedeemCode entity by ids.
-func (m *GroupMutation) AddRedeemCodeIDs(ids ...int64) {
-	if m.redeem_codes == nil {
-		m.redeem_codes = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.redeem_codes[ids[i]] = struct{}{}
-	}
+// MaxMessagesCleared returns if the "max_messages" field was cleared in this mutation.
+func (m *GroupMutation) MaxMessagesCleared() bool {
+	_, ok := m.clearedFields[group.FieldMaxMessages]
+	return ok
 }
 
-// ClearRedeemCodes clears the "redeem_codes" edge to the RedeemCode entity.
-func (m *GroupMutation) ClearRedeemCodes() {
-	m.clearedredeem_codes = true
+// ResetMaxMessages resets all changes to the "max_messages" field.
+func (m *GroupMutation) ResetMaxMessages() {
+	m.max_messages = nil
+	m.addmax_messages = nil
+	delete(m.clearedFields, group.FieldMaxMessages)
 }
 
-// RedeemCodesCleared reports if the "redeem_codes" edge to the RedeemCode entity was cleared.
-func (m *GroupMutation) RedeemCodesCleared() bool {
-	return m.clearedredeem_codes
+// SetDailyRequestLimit sets the "daily_request_limit" field.
+func (m *GroupMutation) SetDailyRequestLimit(i int) {
+	m.daily_request_limit = &i
+	m.adddaily_request_limit = nil
 }
 
-// RemoveRedeemCodeIDs removes the "redeem_codes" edge to the RedeemCode entity by IDs.
-func (m *GroupMutation) RemoveRedeemCodeIDs(ids ...int64) {
-	if m.removedredeem_codes == nil {
-		m.removedredeem_codes = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.redeem_codes, ids[i])
-		m.removedredeem_codes[ids[i]] = struct{}{}
+// DailyRequestLimit returns the value of the "daily_request_limit" field in the mutation.
+func (m *GroupMutation) DailyRequestLimit() (r int, exists bool) {
+	v := m.daily_request_limit
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedRedeemCodes returns the removed IDs of the "redeem_codes" edge to the RedeemCode entity.
-func (m *GroupMutation) RemovedRedeemCodesIDs() (ids []int64) {
-	for id := range m.removedredeem_codes {
-		ids = append(ids, id)
+// OldDailyRequestLimit returns the old "daily_request_limit" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDailyRequestLimit(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDailyRequestLimit is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDailyRequestLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDailyRequestLimit: %w", err)
+	}
+	return oldValue.DailyRequestLimit, nil
 }
 
-// RedeemCodesIDs returns the "redeem_codes" edge IDs in the mutation.
-func (m *GroupMutation) RedeemCodesIDs() (ids []int64) {
-	for id := range m.redeem_codes {
-		ids = append(ids, id)
+// AddDailyRequestLimit adds i to the "daily_request_limit" field.
+func (m *GroupMutation) AddDailyRequestLimit(i int) {
+	if m.adddaily_request_limit != nil {
+		*m.adddaily_request_limit += i
+	} else {
+		m.adddaily_request_limit = &i
 	}
-	return
 }
 
-// ResetRedeemCodes resets all changes to the "redeem_codes" edge.
-func (m *GroupMutation) ResetRedeemCodes() {
-	m.redeem_codes = nil
-	m.clearedredeem_codes = false
-	m.removedredeem_codes = nil
+// AddedDailyRequestLimit returns the value that was added to the "daily_request_limit" field in this mutation.
+func (m *GroupMutation) AddedDailyRequestLimit() (r int, exists bool) {
+	v := m.adddaily_request_limit
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// AddSubscriptionIDs adds the "subscriptions" edge to the UserSubscription entity by ids.
-func (m *GroupMutation) AddSubscriptionIDs(ids ...int64) {
-	if m.subscriptions == nil {
-		m.subscriptions = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.subscriptions[ids[i]] = struct{}{}
-	}
+// ClearDailyRequestLimit clears the value of the "daily_request_limit" field.
+func (m *GroupMutation) ClearDailyRequestLimit() {
+	m.daily_request_limit = nil
+	m.adddaily_request_limit = nil
+	m.clearedFields[group.FieldDailyRequestLimit] = struct{}{}
 }
 
-// ClearSubscriptions clears the "subscriptions" edge to the UserSubscription entity.
-func (m *GroupMutation) ClearSubscriptions() {
-	m.clearedsubscriptions = true
+// DailyRequestLimitCleared returns if the "daily_request_limit" field was cleared in this mutation.
+func (m *GroupMutation) DailyRequestLimitCleared() bool {
+	_, ok := m.clearedFields[group.FieldDailyRequestLimit]
+	return ok
 }
 
-// SubscriptionsCleared reports if the "subscriptions" edge to the UserSubscription entity was cleared.
-func (m *GroupMutation) SubscriptionsCleared() bool {
-	return m.clearedsubscriptions
+// ResetDailyRequestLimit resets all changes to the "daily_request_limit" field.
+func (m *GroupMutation) ResetDailyRequestLimit() {
+	m.daily_request_limit = nil
+	m.adddaily_request_limit = nil
+	delete(m.clearedFields, group.FieldDailyRequestLimit)
 }
 
-// RemoveSubscriptionIDs removes the "subscriptions" edge to the UserSubscription entity by IDs.
-func (m *GroupMutation) RemoveSubscriptionIDs(ids ...int64) {
-	if m.removedsubscriptions == nil {
-		m.removedsubscriptions = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.subscriptions, ids[i])
-		m.removedsubscriptions[ids[i]] = struct{}{}
-	}
+// SetUpstreamHeaders sets the "upstream_headers" field.
+func (m *GroupMutation) SetUpstreamHeaders(value map[string]string) {
+	m.upstream_headers = &value
 }
 
-// RemovedSubscriptions returns the removed IDs of the "subscriptions" edge to the UserSubscription entity.
-func (m *GroupMutation) RemovedSubscriptionsIDs() (ids []int64) {
-	for id := range m.removedsubscriptions {
-		ids = append(ids, id)
+// UpstreamHeaders returns the value of the "upstream_headers" field in the mutation.
+func (m *GroupMutation) UpstreamHeaders() (r map[string]string, exists bool) {
+	v := m.upstream_headers
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// SubscriptionsIDs returns the "subscriptions" edge IDs in the mutation.
-func (m *GroupMutation) SubscriptionsIDs() (ids []int64) {
-	for id := range m.subscriptions {
-		ids = append(ids, id)
+// OldUpstreamHeaders returns the old "upstream_headers" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldUpstreamHeaders(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpstreamHeaders is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpstreamHeaders requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpstreamHeaders: %w", err)
+	}
+	return oldValue.UpstreamHeaders, nil
 }
 
-// ResetSubscriptions resets all changes to the "subscriptions" edge.
-func (m *GroupMutation) ResetSubscriptions() {
-	m.subscriptions = nil
-	m.clearedsubscriptions = false
-	m.removedsubscriptions = nil
+// ClearUpstreamHeaders clears the value of the "upstream_headers" field.
+func (m *GroupMutation) ClearUpstreamHeaders() {
+	m.upstream_headers = nil
+	m.clearedFields[group.FieldUpstreamHeaders] = struct{}{}
 }
 
-// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
-func (m *GroupMutation) AddUsageLogIDs(ids ...int64) {
-	if m.usage_logs == nil {
-		m.usage_logs = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.usage_logs[ids[i]] = struct{}{}
-	}
+// UpstreamHeadersCleared returns if the "upstream_headers" field was cleared in this mutation.
+func (m *GroupMutation) UpstreamHeadersCleared() bool {
+	_, ok := m.clearedFields[group.FieldUpstreamHeaders]
+	return ok
 }
 
-// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
-func (m *GroupMutation) ClearUsageLogs() {
-	m.clearedusage_logs = true
+// ResetUpstreamHeaders resets all changes to the "upstream_headers" field.
+func (m *GroupMutation) ResetUpstreamHeaders() {
+	m.upstream_headers = nil
+	delete(m.clearedFields, group.FieldUpstreamHeaders)
 }
 
-// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
-func (m *GroupMutation) UsageLogsCleared() bool {
-	return m.clearedusage_logs
+// SetSubscriptionOverflowPolicy sets the "subscription_overflow_policy" field.
+func (m *GroupMutation) SetSubscriptionOverflowPolicy(s string) {
+	m.subscription_overflow_policy = &s
 }
 
-// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
-func (m *GroupMutation) RemoveUsageLogIDs(ids ...int64) {
-	if m.removedusage_logs == nil {
-		m.removedusage_logs = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.usage_logs, ids[i])
-		m.removedusage_logs[ids[i]] = struct{}{}
+// SubscriptionOverflowPolicy returns the value of the "subscription_overflow_policy" field in the mutation.
+func (m *GroupMutation) SubscriptionOverflowPolicy() (r string, exists bool) {
+	v := m.subscription_overflow_policy
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
-func (m *GroupMutation) RemovedUsageLogsIDs() (ids []int64) {
-	for id := range m.removedusage_logs {
-		ids = append(ids, id)
+// OldSubscriptionOverflowPolicy returns the old "subscription_overflow_policy" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldSubscriptionOverflowPolicy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSubscriptionOverflowPolicy is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSubscriptionOverflowPolicy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSubscriptionOverflowPolicy: %w", err)
+	}
+	return oldValue.SubscriptionOverflowPolicy, nil
 }
 
-// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
-func (m *GroupMutation) UsageLogsIDs() (ids []int64) {
-	for id := range m.usage_logs {
-		ids = append(ids, id)
-	}
-	return
+// ResetSubscriptionOverflowPolicy resets all changes to the "subscription_overflow_policy" field.
+func (m *GroupMutation) ResetSubscriptionOverflowPolicy() {
+	m.subscription_overflow_policy = nil
 }
 
-// ResetUsageLogs resets all changes to the "usage_logs" edge.
-func (m *GroupMutation) ResetUsageLogs() {
-	m.usage_logs = nil
-	m.clearedusage_logs = false
-	m.removedusage_logs = nil
+// SetIntentRouting sets the "intent_routing" field.
+func (m *GroupMutation) SetIntentRouting(value map[string][]int64) {
+	m.intent_routing = &value
 }
 
-// AddAccountIDs adds the "accounts" edge to the Account entity by ids.
-func (m *GroupMutation) AddAccountIDs(ids ...int64) {
-	if m.accounts == nil {
-		m.accounts = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.accounts[ids[i]] = struct{}{}
+// IntentRouting returns the value of the "intent_routing" field in the mutation.
+func (m *GroupMutation) IntentRouting() (r map[string][]int64, exists bool) {
+	v := m.intent_routing
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearAccounts clears the "accounts" edge to the Account entity.
-func (m *GroupMutation) ClearAccounts() {
-	m.clearedaccounts = true
+// OldIntentRouting returns the old "intent_routing" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldIntentRouting(ctx context.Context) (v map[string][]int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIntentRouting is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIntentRouting requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIntentRouting: %w", err)
+	}
+	return oldValue.IntentRouting, nil
 }
 
-// AccountsCleared reports if the "accounts" edge to the Account entity was cleared.
-func (m *GroupMutation) AccountsCleared() bool {
-	return m.clearedaccounts
+// ClearIntentRouting clears the value of the "intent_routing" field.
+func (m *GroupMutation) ClearIntentRouting() {
+	m.intent_routing = nil
+	m.clearedFields[group.FieldIntentRouting] = struct{}{}
 }
 
-// RemoveAccountIDs removes the "accounts" edge to the Account entity by IDs.
-func (m *GroupMutation) RemoveAccountIDs(ids ...int64) {
-	if m.removedaccounts == nil {
-		m.removedaccounts = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.accounts, ids[i])
-		m.removedaccounts[ids[i]] = struct{}{}
-	}
+// IntentRoutingCleared returns if the "intent_routing" field was cleared in this mutation.
+func (m *GroupMutation) IntentRoutingCleared() bool {
+	_, ok := m.clearedFields[group.FieldIntentRouting]
+	return ok
 }
 
-// RemovedAccounts returns the removed IDs of the "accounts" edge to the Account entity.
-func (m *GroupMutation) RemovedAccountsIDs() (ids []int64) {
-	for id := range m.removedaccounts {
-		ids = append(ids, id)
-	}
-	return
+// ResetIntentRouting resets all changes to the "intent_routing" field.
+func (m *GroupMutation) ResetIntentRouting() {
+	m.intent_routing = nil
+	delete(m.clearedFields, group.FieldIntentRouting)
 }
 
-// AccountsIDs returns the "accounts" edge IDs in the mutation.
-func (m *GroupMutation) AccountsIDs() (ids []int64) {
-	for id := range m.accounts {
-		ids = append(ids, id)
-	}
-	return
+// SetIntentRoutingEnabled sets the "intent_routing_enabled" field.
+func (m *GroupMutation) SetIntentRoutingEnabled(b bool) {
+	m.intent_routing_enabled = &b
 }
 
-// ResetAccounts resets all changes to the "accounts" edge.
-func (m *GroupMutation) ResetAccounts() {
-	m.accounts = nil
-	m.clearedaccounts = false
-	m.removedaccounts = nil
+// IntentRoutingEnabled returns the value of the "intent_routing_enabled" field in the mutation.
+func (m *GroupMutation) IntentRoutingEnabled() (r bool, exists bool) {
+	v := m.intent_routing_enabled
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// AddAllowedUserIDs adds the "allowed_users" edge to the User entity by ids.
-func (m *GroupMutation) AddAllowedUserIDs(ids ...int64) {
-	if m.allowed_users == nil {
-		m.allowed_users = make(map[int64]struct{})
+// OldIntentRoutingEnabled returns the old "intent_routing_enabled" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldIntentRoutingEnabled(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIntentRoutingEnabled is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		m.allowed_users[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIntentRoutingEnabled requires an ID field in the mutation")
 	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIntentRoutingEnabled: %w", err)
+	}
+	return oldValue.IntentRoutingEnabled, nil
 }
 
-// ClearAllowedUsers clears the "allowed_users" edge to the User entity.
-func (m *GroupMutation) ClearAllowedUsers() {
-	m.clearedallowed_users = true
+// ResetIntentRoutingEnabled resets all changes to the "intent_routing_enabled" field.
+func (m *GroupMutation) ResetIntentRoutingEnabled() {
+	m.intent_routing_enabled = nil
 }
 
-// AllowedUsersCleared reports if the "allowed_users" edge to the User entity was cleared.
-func (m *GroupMutation) AllowedUsersCleared() bool {
-	return m.clearedallowed_users
+// SetAllowedEndpoints sets the "allowed_endpoints" field.
+func (m *GroupMutation) SetAllowedEndpoints(s []string) {
+	m.allowed_endpoints = &s
+	m.appendallowed_endpoints = nil
 }
 
-// RemoveAllowedUserIDs removes the "allowed_users" edge to the User entity by IDs.
-func (m *GroupMutation) RemoveAllowedUserIDs(ids ...int64) {
-	if m.removedallowed_users == nil {
-		m.removedallowed_users = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.allowed_users, ids[i])
-		m.removedallowed_users[ids[i]] = struct{}{}
+// AllowedEndpoints returns the value of the "allowed_endpoints" field in the mutation.
+func (m *GroupMutation) AllowedEndpoints() (r []string, exists bool) {
+	v := m.allowed_endpoints
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedAllowedUsers returns the removed IDs of the "allowed_users" edge to the User entity.
-func (m *GroupMutation) RemovedAllowedUsersIDs() (ids []int64) {
-	for id := range m.removedallowed_users {
-		ids = append(ids, id)
+// OldAllowedEndpoints returns the old "allowed_endpoints" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldAllowedEndpoints(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAllowedEndpoints is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAllowedEndpoints requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAllowedEndpoints: %w", err)
+	}
+	return oldValue.AllowedEndpoints, nil
 }
 
-// AllowedUsersIDs returns the "allowed_users" edge IDs in the mutation.
-func (m *GroupMutation) AllowedUsersIDs() (ids []int64) {
-	for id := range m.allowed_users {
-		ids = append(ids, id)
+// AppendAllowedEndpoints adds s to the "allowed_endpoints" field.
+func (m *GroupMutation) AppendAllowedEndpoints(s []string) {
+	m.appendallowed_endpoints = append(m.appendallowed_endpoints, s...)
+}
+
+// AppendedAllowedEndpoints returns the list of values that were appended to the "allowed_endpoints" field in this mutation.
+func (m *GroupMutation) AppendedAllowedEndpoints() ([]string, bool) {
+	if len(m.appendallowed_endpoints) == 0 {
+		return nil, false
 	}
-	return
+	return m.appendallowed_endpoints, true
 }
 
-// ResetAllowedUsers resets all changes to the "allowed_users" edge.
-func (m *GroupMutation) ResetAllowedUsers() {
-	m.allowed_users = nil
-	m.clearedallowed_users = false
-	m.removedallowed_users = nil
+// ClearAllowedEndpoints clears the value of the "allowed_endpoints" field.
+func (m *GroupMutation) ClearAllowedEndpoints() {
+	m.allowed_endpoints = nil
+	m.appendallowed_endpoints = nil
+	m.clearedFields[group.FieldAllowedEndpoints] = struct{}{}
 }
 
-// Where appends a list predicates to the GroupMutation builder.
-func (m *GroupMutation) Where(ps ...predicate.Group) {
-	m.predicates = append(m.predicates, ps...)
+// AllowedEndpointsCleared returns if the "allowed_endpoints" field was cleared in this mutation.
+func (m *GroupMutation) AllowedEndpointsCleared() bool {
+	_, ok := m.clearedFields[group.FieldAllowedEndpoints]
+	return ok
 }
 
-// WhereP appends storage-level predicates to the GroupMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *GroupMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Group, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// ResetAllowedEndpoints resets all changes to the "allowed_endpoints" field.
+func (m *GroupMutation) ResetAllowedEndpoints() {
+	m.allowed_endpoints = nil
+	m.appendallowed_endpoints = nil
+	delete(m.clearedFields, group.FieldAllowedEndpoints)
+}
+
+// SetRequireAnthropicVersion sets the "require_anthropic_version" field.
+func (m *GroupMutation) SetRequireAnthropicVersion(b bool) {
+	m.require_anthropic_version = &b
+}
+
+// RequireAnthropicVersion returns the value of the "require_anthropic_version" field in the mutation.
+func (m *GroupMutation) RequireAnthropicVersion() (r bool, exists bool) {
+	v := m.require_anthropic_version
+	if v == nil {
+		return
 	}
-	m.Where(p...)
+	return *v, true
 }
 
-// Op returns the operation name.
-func (m *GroupMutation) Op() Op {
-	return m.op
+// OldRequireAnthropicVersion returns the old "require_anthropic_version" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldRequireAnthropicVersion(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequireAnthropicVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequireAnthropicVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequireAnthropicVersion: %w", err)
+	}
+	return oldValue.RequireAnthropicVersion, nil
 }
 
-// SetOp allows setting the mutation operation.
-func (m *GroupMutation) SetOp(op Op) {
-	m.op = op
+// ResetRequireAnthropicVersion resets all changes to the "require_anthropic_version" field.
+func (m *GroupMutation) ResetRequireAnthropicVersion() {
+	m.require_anthropic_version = nil
 }
 
-// Type returns the node type of this mutation (Group).
-func (m *GroupMutation) Type() string {
-	return m.typ
+// SetMaxOutputTokens sets the "max_output_tokens" field.
+func (m *GroupMutation) SetMaxOutputTokens(i int) {
+	m.max_output_tokens = &i
+	m.addmax_output_tokens = nil
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *GroupMutation) Fields() []string {
-	fields := make([]string, 0, 25)
-	if m.created_at != nil {
-		fields = append(fields, group.FieldCreatedAt)
+// MaxOutputTokens returns the value of the "max_output_tokens" field in the mutation.
+func (m *GroupMutation) MaxOutputTokens() (r int, exists bool) {
+	v := m.max_output_tokens
+	if v == nil {
+		return
 	}
-	if m.updated_at != nil {
-		fields = append(fields, group.FieldUpdatedAt)
+	return *v, true
+}
+
+// OldMaxOutputTokens returns the old "max_output_tokens" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldMaxOutputTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxOutputTokens is only allowed on UpdateOne operations")
 	}
-	if m.deleted_at != nil {
-		fields = append(fields, group.FieldDeletedAt)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxOutputTokens requires an ID field in the mutation")
 	}
-	if m.name != nil {
-		fields = append(fields, group.FieldName)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxOutputTokens: %w", err)
 	}
-	if m.description != nil {
-		fields = append(fields, group.FieldDescription)
+	return oldValue.MaxOutputTokens, nil
+}
+
+// AddMaxOutputTokens adds i to the "max_output_tokens" field.
+func (m *GroupMutation) AddMaxOutputTokens(i int) {
+	if m.addmax_output_tokens != nil {
+		*m.addmax_output_tokens += i
+	} else {
+		m.addmax_output_tokens = &i
 	}
-	if m.rate_multiplier != nil {
-		fields = append(fields, group.FieldRateMultiplier)
+}
+
+// AddedMaxOutputTokens returns the value that was added to the "max_output_tokens" field in this mutation.
+func (m *GroupMutation) AddedMaxOutputTokens() (r int, exists bool) {
+	v := m.addmax_output_tokens
+	if v == nil {
+		return
 	}
-	if m.is_exclusive != nil {
-		fields = append(fields, group.FieldIsExclusive)
+	return *v, true
+}
+
+// ClearMaxOutputTokens clears the value of the "max_output_tokens" field.
+func (m *GroupMutation) ClearMaxOutputTokens() {
+	m.max_output_tokens = nil
+	m.addmax_output_tokens = nil
+	m.clearedFields[group.FieldMaxOutputTokens] = struct{}{}
+}
+
+// MaxOutputTokensCleared returns if the "max_output_tokens" field was cleared in this mutation.
+func (m *GroupMutation) MaxOutputTokensCleared() bool {
+	_, ok := m.clearedFields[group.FieldMaxOutputTokens]
+	return ok
+}
+
+// ResetMaxOutputTokens resets all changes to the "max_output_tokens" field.
+func (m *GroupMutation) ResetMaxOutputTokens() {
+	m.max_output_tokens = nil
+	m.addmax_output_tokens = nil
+	delete(m.clearedFields, group.FieldMaxOutputTokens)
+}
+
+// SetMixedSchedulingNativeSaturationOnly sets the "mixed_scheduling_native_saturation_only" field.
+func (m *GroupMutation) SetMixedSchedulingNativeSaturationOnly(b bool) {
+	m.mixed_scheduling_native_saturation_only = &b
+}
+
+// MixedSchedulingNativeSaturationOnly returns the value of the "mixed_scheduling_native_saturation_only" field in the mutation.
+func (m *GroupMutation) MixedSchedulingNativeSaturationOnly() (r bool, exists bool) {
+	v := m.mixed_scheduling_native_saturation_only
+	if v == nil {
+		return
 	}
-	if m.status != nil {
-		fields = append(fields, group.FieldStatus)
+	return *v, true
+}
+
+// OldMixedSchedulingNativeSaturationOnly returns the old "mixed_scheduling_native_saturation_only" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldMixedSchedulingNativeSaturationOnly(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMixedSchedulingNativeSaturationOnly is only allowed on UpdateOne operations")
 	}
-	if m.platform != nil {
-		fields = append(fields, group.FieldPlatform)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMixedSchedulingNativeSaturationOnly requires an ID field in the mutation")
 	}
-	if m.subscription_type != nil {
-		fields = append(fields, group.FieldSubscriptionType)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMixedSchedulingNativeSaturationOnly: %w", err)
 	}
-	if m.daily_limit_usd != nil {
-		fields = append(fields, group.FieldDailyLimitUsd)
+	return oldValue.MixedSchedulingNativeSaturationOnly, nil
+}
+
+// ResetMixedSchedulingNativeSaturationOnly resets all changes to the "mixed_scheduling_native_saturation_only" field.
+func (m *GroupMutation) ResetMixedSchedulingNativeSaturationOnly() {
+	m.mixed_scheduling_native_saturation_only = nil
+}
+
+// SetWindowCostLimitUsd sets the "window_cost_limit_usd" field.
+func (m *GroupMutation) SetWindowCostLimitUsd(f float64) {
+	m.window_cost_limit_usd = &f
+	m.addwindow_cost_limit_usd = nil
+}
+
+// WindowCostLimitUsd returns the value of the "window_cost_limit_usd" field in the mutation.
+func (m *GroupMutation) WindowCostLimitUsd() (r float64, exists bool) {
+	v := m.window_cost_limit_usd
+	if v == nil {
+		return
 	}
-	if m.weekly_limit_usd != nil {
-		fields = append(fields, group.FieldWeeklyLimitUsd)
+	return *v, true
+}
+
+// OldWindowCostLimitUsd returns the old "window_cost_limit_usd" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldWindowCostLimitUsd(ctx context.Context) (v *float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldWindowCostLimitUsd is only allowed on UpdateOne operations")
 	}
-	if m.monthly_limit_usd != nil {
-		fields = append(fields, group.FieldMonthlyLimitUsd)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldWindowCostLimitUsd requires an ID field in the mutation")
 	}
-	if m.default_validity_days != nil {
-		fields = append(fields, group.FieldDefaultValidityDays)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldWindowCostLimitUsd: %w", err)
 	}
-	if m.image_price_1k != nil {
-		fields = append(fields, group.FieldImagePrice1k)
+	return oldValue.WindowCostLimitUsd, nil
+}
+
+// AddWindowCostLimitUsd adds f to the "window_cost_limit_usd" field.
+func (m *GroupMutation) AddWindowCostLimitUsd(f float64) {
+	if m.addwindow_cost_limit_usd != nil {
+		*m.addwindow_cost_limit_usd += f
+	} else {
+		m.addwindow_cost_limit_usd = &f
 	}
-	if m.image_price_2k != nil {
-		fields = append(fields, group.FieldImagePrice2k)
+}
+
+// AddedWindowCostLimitUsd returns the value that was added to the "window_cost_limit_usd" field in this mutation.
+func (m *GroupMutation) AddedWindowCostLimitUsd() (r float64, exists bool) {
+	v := m.addwindow_cost_limit_usd
+	if v == nil {
+		return
 	}
-	if m.image_price_4k != nil {
-		fields = append(fields, group.FieldImagePrice4k)
+	return *v, true
+}
+
+// ClearWindowCostLimitUsd clears the value of the "window_cost_limit_usd" field.
+func (m *GroupMutation) ClearWindowCostLimitUsd() {
+	m.window_cost_limit_usd = nil
+	m.addwindow_cost_limit_usd = nil
+	m.clearedFields[group.FieldWindowCostLimitUsd] = struct{}{}
+}
+
+// WindowCostLimitUsdCleared returns if the "window_cost_limit_usd" field was cleared in this mutation.
+func (m *GroupMutation) WindowCostLimitUsdCleared() bool {
+	_, ok := m.clearedFields[group.FieldWindowCostLimitUsd]
+	return ok
+}
+
+// ResetWindowCostLimitUsd resets all changes to the "window_cost_limit_usd" field.
+func (m *GroupMutation) ResetWindowCostLimitUsd() {
+	m.window_cost_limit_usd = nil
+	m.addwindow_cost_limit_usd = nil
+	delete(m.clearedFields, group.FieldWindowCostLimitUsd)
+}
+
+// SetWindowCostWindowHours sets the "window_cost_window_hours" field.
+func (m *GroupMutation) SetWindowCostWindowHours(i int) {
+	m.window_cost_window_hours = &i
+	m.addwindow_cost_window_hours = nil
+}
+
+// WindowCostWindowHours returns the value of the "window_cost_window_hours" field in the mutation.
+func (m *GroupMutation) WindowCostWindowHours() (r int, exists bool) {
+	v := m.window_cost_window_hours
+	if v == nil {
+		return
 	}
-	if m.claude_code_only != nil {
-		fields = append(fields, group.FieldClaudeCodeOnly)
+	return *v, true
+}
+
+// OldWindowCostWindowHours returns the old "window_cost_window_hours" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldWindowCostWindowHours(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldWindowCostWindowHours is only allowed on UpdateOne operations")
 	}
-	if m.fallback_group_id != nil {
-		fields = append(fields, group.FieldFallbackGroupID)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldWindowCostWindowHours requires an ID field in the mutation")
 	}
-	if m.fallback_group_id_on_invalid_request != nil {
-		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldWindowCostWindowHours: %w", err)
 	}
-	if m.model_routing != nil {
-		fields = append(fields, group.FieldModelRouting)
+	return oldValue.WindowCostWindowHours, nil
+}
+
+// AddWindowCostWindowHours adds i to the "window_cost_window_hours" field.
+func (m *GroupMutation) AddWindowCostWindowHours(i int) {
+	if m.addwindow_cost_window_hours != nil {
+		*m.addwindow_cost_window_hours += i
+	} else {
+		m.addwindow_cost_window_hours = &i
 	}
-	if m.model_routing_enabled != nil {
-		fields = append(fields, group.FieldModelRoutingEnabled)
+}
+
+// AddedWindowCostWindowHours returns the value that was added to the "window_cost_window_hours" field in this mutation.
+func (m *GroupMutation) AddedWindowCostWindowHours() (r int, exists bool) {
+	v := m.addwindow_cost_window_hours
+	if v == nil {
+		return
 	}
-	if m.mcp_xml_inject != nil {
-		fields = append(fields, group.FieldMcpXMLInject)
+	return *v, true
+}
+
+// ClearWindowCostWindowHours clears the value of the "window_cost_window_hours" field.
+func (m *GroupMutation) ClearWindowCostWindowHours() {
+	m.window_cost_window_hours = nil
+	m.addwindow_cost_window_hours = nil
+	m.clearedFields[group.FieldWindowCostWindowHours] = struct{}{}
+}
+
+// WindowCostWindowHoursCleared returns if the "window_cost_window_hours" field was cleared in this mutation.
+func (m *GroupMutation) WindowCostWindowHoursCleared() bool {
+	_, ok := m.clearedFields[group.FieldWindowCostWindowHours]
+	return ok
+}
+
+// ResetWindowCostWindowHours resets all changes to the "window_cost_window_hours" field.
+func (m *GroupMutation) ResetWindowCostWindowHours() {
+	m.window_cost_window_hours = nil
+	m.addwindow_cost_window_hours = nil
+	delete(m.clearedFields, group.FieldWindowCostWindowHours)
+}
+
+// AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
+func (m *GroupMutation) AddAPIKeyIDs(ids ...int64) {
+	if m.api_keys == nil {
+		m.api_keys = make(map[int64]struct{})
 	}
-	if m.supported_model_scopes != nil {
-		fields = append(fields, group.FieldSupportedModelScopes)
+	for i := range ids {
+		m.api_keys[ids[i]] = struct{}{}
 	}
-	if m.sort_order != nil {
-		fields = append(fields, group.FieldSortOrder)
+}
+
+// ClearAPIKeys clears the "api_keys" edge to the APIKey entity.
+func (m *GroupMutation) ClearAPIKeys() {
+	m.clearedapi_keys = true
+}
+
+// APIKeysCleared reports if the "api_keys" edge to the APIKey entity was cleared.
+func (m *GroupMutation) APIKeysCleared() bool {
+	return m.clearedapi_keys
+}
+
+// RemoveAPIKeyIDs removes the "api_keys" edge to the APIKey entity by IDs.
+func (m *GroupMutation) RemoveAPIKeyIDs(ids ...int64) {
+	if m.removedapi_keys == nil {
+		m.removedapi_keys = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.api_keys, ids[i])
+		m.removedapi_keys[ids[i]] = struct{}{}
 	}
-	return fields
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *GroupMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case group.FieldCreatedAt:
-		return m.CreatedAt()
-	case group.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case group.FieldDeletedAt:
-		return m.DeletedAt()
-	case group.FieldName:
-		return m.Name()
-	case group.FieldDescription:
-		return m.Description()
-	case group.FieldRateMultiplier:
-		return m.RateMultiplier()
-	case group.FieldIsExclusive:
-		return m.IsExclusive()
-	case group.FieldStatus:
-		return m.Status()
-	case group.FieldPlatform:
-		return m.Platform()
-	case group.FieldSubscriptionType:
-		return m.SubscriptionType()
-	case group.FieldDailyLimitUsd:
-		return m.DailyLimitUsd()
-	case group.FieldWeeklyLimitUsd:
-		return m.WeeklyLimitUsd()
-	case group.FieldMonthlyLimitUsd:
-		return m.MonthlyLimitUsd()
-	case group.FieldDefaultValidityDays:
-		return m.DefaultValidityDays()
-	case group.FieldImagePrice1k:
-		return m.ImagePrice1k()
-	case group.FieldImagePrice2k:
-		return m.ImagePrice2k()
-	case group.FieldImagePrice4k:
-		return m.ImagePrice4k()
-	case group.FieldClaudeCodeOnly:
-		return m.ClaudeCodeOnly()
-	case group.FieldFallbackGroupID:
-		return m.FallbackGroupID()
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		return m.FallbackGroupIDOnInvalidRequest()
-	case group.FieldModelRouting:
-		return m.ModelRouting()
-	case group.FieldModelRoutingEnabled:
-		return m.ModelRoutingEnabled()
-	case group.FieldMcpXMLInject:
-		return m.McpXMLInject()
-	case group.FieldSupportedModelScopes:
-		return m.SupportedModelScopes()
-	case group.FieldSortOrder:
-		return m.SortOrder()
+// RemovedAPIKeys returns the removed IDs of the "api_keys" edge to the APIKey entity.
+func (m *GroupMutation) RemovedAPIKeysIDs() (ids []int64) {
+	for id := range m.removedapi_keys {
+		ids = append(ids, id)
 	}
-	return nil, false
+	return
 }
 
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case group.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case group.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case group.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
-	case group.FieldName:
-		return m.OldName(ctx)
-	case group.FieldDescription:
-		return m.OldDescription(ctx)
-	case group.FieldRateMultiplier:
-		return m.OldRateMultiplier(ctx)
-	case group.FieldIsExclusive:
-		return m.OldIsExclusive(ctx)
-	case group.FieldStatus:
-		return m.OldStatus(ctx)
-	case group.FieldPlatform:
-		return m.OldPlatform(ctx)
-	case group.FieldSubscriptionType:
-		return m.OldSubscriptionType(ctx)
-	case group.FieldDailyLimitUsd:
-		return m.OldDailyLimitUsd(ctx)
-	case group.FieldWeeklyLimitUsd:
-		return m.OldWeeklyLimitUsd(ctx)
-	case group.FieldMonthlyLimitUsd:
-		return m.OldMonthlyLimitUsd(ctx)
-	case group.FieldDefaultValidityDays:
-		return m.OldDefaultValidityDays(ctx)
-	case group.FieldImagePrice1k:
-		return m.OldImagePrice1k(ctx)
-	case group.FieldImagePrice2k:
-		return m.OldImagePrice2k(ctx)
-	case group.FieldImagePrice4k:
-		return m.OldImagePrice4k(ctx)
-	case group.FieldClaudeCodeOnly:
-		return m.OldClaudeCodeOnly(ctx)
-	case group.FieldFallbackGroupID:
-		return m.OldFallbackGroupID(ctx)
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		return m.OldFallbackGroupIDOnInvalidRequest(ctx)
-	case group.FieldModelRouting:
-		return m.OldModelRouting(ctx)
-	case group.FieldModelRoutingEnabled:
-		return m.OldModelRoutingEnabled(ctx)
-	case group.FieldMcpXMLInject:
-		return m.OldMcpXMLInject(ctx)
-	case group.FieldSupportedModelScopes:
-		return m.OldSupportedModelScopes(ctx)
-	case group.FieldSortOrder:
-		return m.OldSortOrder(ctx)
+// APIKeysIDs returns the "api_keys" edge IDs in the mutation.
+func (m *GroupMutation) APIKeysIDs() (ids []int64) {
+	for id := range m.api_keys {
+		ids = append(ids, id)
 	}
-	return nil, fmt.Errorf("unknown Group field %s", name)
+	return
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *GroupMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case group.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
-	case group.FieldUpdatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUpdatedAt(v)
-		return nil
-	case group.FieldDeletedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDeletedAt(v)
-		return nil
-	case group.FieldName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetName(v)
-		return nil
-	case group.FieldDescription:
+// ResetAPIKeys resets all changes to the "api_keys" edge.
+func (m *GroupMutation) ResetAPIKeys() {
+	m.api_keys = nil
+	m.clearedapi_keys = false
+	m.removedapi_keys = nil
+}
+
+// AddRedeemCodeIDs adds the "redeem_codes" edge to the RedeemCode entity by ids.
+func (m *GroupMutation) AddRedeemCodeIDs(ids ...int64) {
+	if m.redeem_codes == nil {
+		m.redeem_codes = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.redeem_codes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearRedeemCodes clears the "redeem_codes" edge to the RedeemCode entity.
+func (m *GroupMutation) ClearRedeemCodes() {
+	m.clearedredeem_codes = true
+}
+
+// RedeemCodesCleared reports if the "redeem_codes" edge to the RedeemCode entity was cleared.
+func (m *GroupMutation) RedeemCodesCleared() bool {
+	return m.clearedredeem_codes
+}
+
+// RemoveRedeemCodeIDs removes the "redeem_codes" edge to the RedeemCode entity by IDs.
+func (m *GroupMutation) RemoveRedeemCodeIDs(ids ...int64) {
+	if m.removedredeem_codes == nil {
+		m.removedredeem_codes = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.redeem_codes, ids[i])
+		m.removedredeem_codes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedRedeemCodes returns the removed IDs of the "redeem_codes" edge to the RedeemCode entity.
+func (m *GroupMutation) RemovedRedeemCodesIDs() (ids []int64) {
+	for id := range m.removedredeem_codes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// RedeemCodesIDs returns the "redeem_codes" edge IDs in the mutation.
+func (m *GroupMutation) RedeemCodesIDs() (ids []int64) {
+	for id := range m.redeem_codes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetRedeemCodes resets all changes to the "redeem_codes" edge.
+func (m *GroupMutation) ResetRedeemCodes() {
+	m.redeem_codes = nil
+	m.clearedredeem_codes = false
+	m.removedredeem_codes = nil
+}
+
+// AddSubscriptionIDs adds the "subscriptions" edge to the UserSubscription entity by ids.
+func (m *GroupMutation) AddSubscriptionIDs(ids ...int64) {
+	if m.subscriptions == nil {
+		m.subscriptions = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.subscriptions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSubscriptions clears the "subscriptions" edge to the UserSubscription entity.
+func (m *GroupMutation) ClearSubscriptions() {
+	m.clearedsubscriptions = true
+}
+
+// SubscriptionsCleared reports if the "subscriptions" edge to the UserSubscription entity was cleared.
+func (m *GroupMutation) SubscriptionsCleared() bool {
+	return m.clearedsubscriptions
+}
+
+// RemoveSubscriptionIDs removes the "subscriptions" edge to the UserSubscription entity by IDs.
+func (m *GroupMutation) RemoveSubscriptionIDs(ids ...int64) {
+	if m.removedsubscriptions == nil {
+		m.removedsubscriptions = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.subscriptions, ids[i])
+		m.removedsubscriptions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSubscriptions returns the removed IDs of the "subscriptions" edge to the UserSubscription entity.
+func (m *GroupMutation) RemovedSubscriptionsIDs() (ids []int64) {
+	for id := range m.removedsubscriptions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SubscriptionsIDs returns the "subscriptions" edge IDs in the mutation.
+func (m *GroupMutation) SubscriptionsIDs() (ids []int64) {
+	for id := range m.subscriptions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSubscriptions resets all changes to the "subscriptions" edge.
+func (m *GroupMutation) ResetSubscriptions() {
+	m.subscriptions = nil
+	m.clearedsubscriptions = false
+	m.removedsubscriptions = nil
+}
+
+// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
+func (m *GroupMutation) AddUsageLogIDs(ids ...int64) {
+	if m.usage_logs == nil {
+		m.usage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.usage_logs[ids[i]] = struct{}{}
+	}
+}
+
+// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
+func (m *GroupMutation) ClearUsageLogs() {
+	m.clearedusage_logs = true
+}
+
+// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
+func (m *GroupMutation) UsageLogsCleared() bool {
+	return m.clearedusage_logs
+}
+
+// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
+func (m *GroupMutation) RemoveUsageLogIDs(ids ...int64) {
+	if m.removedusage_logs == nil {
+		m.removedusage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.usage_logs, ids[i])
+		m.removedusage_logs[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
+func (m *GroupMutation) RemovedUsageLogsIDs() (ids []int64) {
+	for id := range m.removedusage_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
+func (m *GroupMutation) UsageLogsIDs() (ids []int64) {
+	for id := range m.usage_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetUsageLogs resets all changes to the "usage_logs" edge.
+func (m *GroupMutation) ResetUsageLogs() {
+	m.usage_logs = nil
+	m.clearedusage_logs = false
+	m.removedusage_logs = nil
+}
+
+// AddAccountIDs adds the "accounts" edge to the Account entity by ids.
+func (m *GroupMutation) AddAccountIDs(ids ...int64) {
+	if m.accounts == nil {
+		m.accounts = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.accounts[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAccounts clears the "accounts" edge to the Account entity.
+func (m *GroupMutation) ClearAccounts() {
+	m.clearedaccounts = true
+}
+
+// AccountsCleared reports if the "accounts" edge to the Account entity was cleared.
+func (m *GroupMutation) AccountsCleared() bool {
+	return m.clearedaccounts
+}
+
+// RemoveAccountIDs removes the "accounts" edge to the Account entity by IDs.
+func (m *GroupMutation) RemoveAccountIDs(ids ...int64) {
+	if m.removedaccounts == nil {
+		m.removedaccounts = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.accounts, ids[i])
+		m.removedaccounts[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAccounts returns the removed IDs of the "accounts" edge to the Account entity.
+func (m *GroupMutation) RemovedAccountsIDs() (ids []int64) {
+	for id := range m.removedaccounts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// AccountsIDs returns the "accounts" edge IDs in the mutation.
+func (m *GroupMutation) AccountsIDs() (ids []int64) {
+	for id := range m.accounts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAccounts resets all changes to the "accounts" edge.
+func (m *GroupMutation) ResetAccounts() {
+	m.accounts = nil
+	m.clearedaccounts = false
+	m.removedaccounts = nil
+}
+
+// AddAllowedUserIDs adds the "allowed_users" edge to the User entity by ids.
+func (m *GroupMutation) AddAllowedUserIDs(ids ...int64) {
+	if m.allowed_users == nil {
+		m.allowed_users = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.allowed_users[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAllowedUsers clears the "allowed_users" edge to the User entity.
+func (m *GroupMutation) ClearAllowedUsers() {
+	m.clearedallowed_users = true
+}
+
+// AllowedUsersCleared reports if the "allowed_users" edge to the User entity was cleared.
+func (m *GroupMutation) AllowedUsersCleared() bool {
+	return m.clearedallowed_users
+}
+
+// RemoveAllowedUserIDs removes the "allowed_users" edge to the User entity by IDs.
+func (m *GroupMutation) RemoveAllowedUserIDs(ids ...int64) {
+	if m.removedallowed_users == nil {
+		m.removedallowed_users = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.allowed_users, ids[i])
+		m.removedallowed_users[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAllowedUsers returns the removed IDs of the "allowed_users" edge to the User entity.
+func (m *GroupMutation) RemovedAllowedUsersIDs() (ids []int64) {
+	for id := range m.removedallowed_users {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// AllowedUsersIDs returns the "allowed_users" edge IDs in the mutation.
+func (m *GroupMutation) AllowedUsersIDs() (ids []int64) {
+	for id := range m.allowed_users {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAllowedUsers resets all changes to the "allowed_users" edge.
+func (m *GroupMutation) ResetAllowedUsers() {
+	m.allowed_users = nil
+	m.clearedallowed_users = false
+	m.removedallowed_users = nil
+}
+
+// Where appends a list predicates to the GroupMutation builder.
+func (m *GroupMutation) Where(ps ...predicate.Group) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the GroupMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *GroupMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Group, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *GroupMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *GroupMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Group).
+func (m *GroupMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *GroupMutation) Fields() []string {
+	fields := make([]string, 0, 39)
+	if m.created_at != nil {
+		fields = append(fields, group.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, group.FieldUpdatedAt)
+	}
+	if m.deleted_at != nil {
+		fields = append(fields, group.FieldDeletedAt)
+	}
+	if m.name != nil {
+		fields = append(fields, group.FieldName)
+	}
+	if m.description != nil {
+		fields = append(fields, group.FieldDescription)
+	}
+	if m.rate_multiplier != nil {
+		fields = append(fields, group.FieldRateMultiplier)
+	}
+	if m.is_exclusive != nil {
+		fields = append(fields, group.FieldIsExclusive)
+	}
+	if m.status != nil {
+		fields = append(fields, group.FieldStatus)
+	}
+	if m.platform != nil {
+		fields = append(fields, group.FieldPlatform)
+	}
+	if m.subscription_type != nil {
+		fields = append(fields, group.FieldSubscriptionType)
+	}
+	if m.currency != nil {
+		fields = append(fields, group.FieldCurrency)
+	}
+	if m.daily_limit_usd != nil {
+		fields = append(fields, group.FieldDailyLimitUsd)
+	}
+	if m.weekly_limit_usd != nil {
+		fields = append(fields, group.FieldWeeklyLimitUsd)
+	}
+	if m.monthly_limit_usd != nil {
+		fields = append(fields, group.FieldMonthlyLimitUsd)
+	}
+	if m.default_validity_days != nil {
+		fields = append(fields, group.FieldDefaultValidityDays)
+	}
+	if m.image_price_1k != nil {
+		fields = append(fields, group.FieldImagePrice1k)
+	}
+	if m.image_price_2k != nil {
+		fields = append(fields, group.FieldImagePrice2k)
+	}
+	if m.image_price_4k != nil {
+		fields = append(fields, group.FieldImagePrice4k)
+	}
+	if m.claude_code_only != nil {
+		fields = append(fields, group.FieldClaudeCodeOnly)
+	}
+	if m.fallback_group_id != nil {
+		fields = append(fields, group.FieldFallbackGroupID)
+	}
+	if m.fallback_group_id_on_invalid_request != nil {
+		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	}
+	if m.model_routing != nil {
+		fields = append(fields, group.FieldModelRouting)
+	}
+	if m.model_routing_enabled != nil {
+		fields = append(fields, group.FieldModelRoutingEnabled)
+	}
+	if m.mcp_xml_inject != nil {
+		fields = append(fields, group.FieldMcpXMLInject)
+	}
+	if m.supported_model_scopes != nil {
+		fields = append(fields, group.FieldSupportedModelScopes)
+	}
+	if m.sort_order != nil {
+		fields = append(fields, group.FieldSortOrder)
+	}
+	if m.disable_metadata_rewrite != nil {
+		fields = append(fields, group.FieldDisableMetadataRewrite)
+	}
+	if m.max_messages != nil {
+		fields = append(fields, group.FieldMaxMessages)
+	}
+	if m.daily_request_limit != nil {
+		fields = append(fields, group.FieldDailyRequestLimit)
+	}
+	if m.upstream_headers != nil {
+		fields = append(fields, group.FieldUpstreamHeaders)
+	}
+	if m.subscription_overflow_policy != nil {
+		fields = append(fields, group.FieldSubscriptionOverflowPolicy)
+	}
+	if m.intent_routing != nil {
+		fields = append(fields, group.FieldIntentRouting)
+	}
+	if m.intent_routing_enabled != nil {
+		fields = append(fields, group.FieldIntentRoutingEnabled)
+	}
+	if m.allowed_endpoints != nil {
+		fields = append(fields, group.FieldAllowedEndpoints)
+	}
+	if m.require_anthropic_version != nil {
+		fields = append(fields, group.FieldRequireAnthropicVersion)
+	}
+	if m.max_output_tokens != nil {
+		fields = append(fields, group.FieldMaxOutputTokens)
+	}
+	if m.mixed_scheduling_native_saturation_only != nil {
+		fields = append(fields, group.FieldMixedSchedulingNativeSaturationOnly)
+	}
+	if m.window_cost_limit_usd != nil {
+		fields = append(fields, group.FieldWindowCostLimitUsd)
+	}
+	if m.window_cost_window_hours != nil {
+		fields = append(fields, group.FieldWindowCostWindowHours)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *GroupMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case group.FieldCreatedAt:
+		return m.CreatedAt()
+	case group.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case group.FieldDeletedAt:
+		return m.DeletedAt()
+	case group.FieldName:
+		return m.Name()
+	case group.FieldDescription:
+		return m.Description()
+	case group.FieldRateMultiplier:
+		return m.RateMultiplier()
+	case group.FieldIsExclusive:
+		return m.IsExclusive()
+	case group.FieldStatus:
+		return m.Status()
+	case group.FieldPlatform:
+		return m.Platform()
+	case group.FieldSubscriptionType:
+		return m.SubscriptionType()
+	case group.FieldCurrency:
+		return m.Currency()
+	case group.FieldDailyLimitUsd:
+		return m.DailyLimitUsd()
+	case group.FieldWeeklyLimitUsd:
+		return m.WeeklyLimitUsd()
+	case group.FieldMonthlyLimitUsd:
+		return m.MonthlyLimitUsd()
+	case group.FieldDefaultValidityDays:
+		return m.DefaultValidityDays()
+	case group.FieldImagePrice1k:
+		return m.ImagePrice1k()
+	case group.FieldImagePrice2k:
+		return m.ImagePrice2k()
+	case group.FieldImagePrice4k:
+		return m.ImagePrice4k()
+	case group.FieldClaudeCodeOnly:
+		return m.ClaudeCodeOnly()
+	case group.FieldFallbackGroupID:
+		return m.FallbackGroupID()
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		return m.FallbackGroupIDOnInvalidRequest()
+	case group.FieldModelRouting:
+		return m.ModelRouting()
+	case group.FieldModelRoutingEnabled:
+		return m.ModelRoutingEnabled()
+	case group.FieldMcpXMLInject:
+		return m.McpXMLInject()
+	case group.FieldSupportedModelScopes:
+		return m.SupportedModelScopes()
+	case group.FieldSortOrder:
+		return m.SortOrder()
+	case group.FieldDisableMetadataRewrite:
+		return m.DisableMetadataRewrite()
+	case group.FieldMaxMessages:
+		return m.MaxMessages()
+	case group.FieldDailyRequestLimit:
+		return m.DailyRequestLimit()
+	case group.FieldUpstreamHeaders:
+		return m.UpstreamHeaders()
+	case group.FieldSubscriptionOverflowPolicy:
+		return m.SubscriptionOverflowPolicy()
+	case group.FieldIntentRouting:
+		return m.IntentRouting()
+	case group.FieldIntentRoutingEnabled:
+		return m.IntentRoutingEnabled()
+	case group.FieldAllowedEndpoints:
+		return m.AllowedEndpoints()
+	case group.FieldRequireAnthropicVersion:
+		return m.RequireAnthropicVersion()
+	case group.FieldMaxOutputTokens:
+		return m.MaxOutputTokens()
+	case group.FieldMixedSchedulingNativeSaturationOnly:
+		return m.MixedSchedulingNativeSaturationOnly()
+	case group.FieldWindowCostLimitUsd:
+		return m.WindowCostLimitUsd()
+	case group.FieldWindowCostWindowHours:
+		return m.WindowCostWindowHours()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case group.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case group.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case group.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case group.FieldName:
+		return m.OldName(ctx)
+	case group.FieldDescription:
+		return m.OldDescription(ctx)
+	case group.FieldRateMultiplier:
+		return m.OldRateMultiplier(ctx)
+	case group.FieldIsExclusive:
+		return m.OldIsExclusive(ctx)
+	case group.FieldStatus:
+		return m.OldStatus(ctx)
+	case group.FieldPlatform:
+		return m.OldPlatform(ctx)
+	case group.FieldSubscriptionType:
+		return m.OldSubscriptionType(ctx)
+	case group.FieldCurrency:
+		return m.OldCurrency(ctx)
+	case group.FieldDailyLimitUsd:
+		return m.OldDailyLimitUsd(ctx)
+	case group.FieldWeeklyLimitUsd:
+		return m.OldWeeklyLimitUsd(ctx)
+	case group.FieldMonthlyLimitUsd:
+		return m.OldMonthlyLimitUsd(ctx)
+	case group.FieldDefaultValidityDays:
+		return m.OldDefaultValidityDays(ctx)
+	case group.FieldImagePrice1k:
+		return m.OldImagePrice1k(ctx)
+	case group.FieldImagePrice2k:
+		return m.OldImagePrice2k(ctx)
+	case group.FieldImagePrice4k:
+		return m.OldImagePrice4k(ctx)
+	case group.FieldClaudeCodeOnly:
+		return m.OldClaudeCodeOnly(ctx)
+	case group.FieldFallbackGroupID:
+		return m.OldFallbackGroupID(ctx)
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		return m.OldFallbackGroupIDOnInvalidRequest(ctx)
+	case group.FieldModelRouting:
+		return m.OldModelRouting(ctx)
+	case group.FieldModelRoutingEnabled:
+		return m.OldModelRoutingEnabled(ctx)
+	case group.FieldMcpXMLInject:
+		return m.OldMcpXMLInject(ctx)
+	case group.FieldSupportedModelScopes:
+		return m.OldSupportedModelScopes(ctx)
+	case group.FieldSortOrder:
+		return m.OldSortOrder(ctx)
+	case group.FieldDisableMetadataRewrite:
+		return m.OldDisableMetadataRewrite(ctx)
+	case group.FieldMaxMessages:
+		return m.OldMaxMessages(ctx)
+	case group.FieldDailyRequestLimit:
+		return m.OldDailyRequestLimit(ctx)
+	case group.FieldUpstreamHeaders:
+		return m.OldUpstreamHeaders(ctx)
+	case group.FieldSubscriptionOverflowPolicy:
+		return m.OldSubscriptionOverflowPolicy(ctx)
+	case group.FieldIntentRouting:
+		return m.OldIntentRouting(ctx)
+	case group.FieldIntentRoutingEnabled:
+		return m.OldIntentRoutingEnabled(ctx)
+	case group.FieldAllowedEndpoints:
+		return m.OldAllowedEndpoints(ctx)
+	case group.FieldRequireAnthropicVersion:
+		return m.OldRequireAnthropicVersion(ctx)
+	case group.FieldMaxOutputTokens:
+		return m.OldMaxOutputTokens(ctx)
+	case group.FieldMixedSchedulingNativeSaturationOnly:
+		return m.OldMixedSchedulingNativeSaturationOnly(ctx)
+	case group.FieldWindowCostLimitUsd:
+		return m.OldWindowCostLimitUsd(ctx)
+	case group.FieldWindowCostWindowHours:
+		return m.OldWindowCostWindowHours(ctx)
+	}
+	return nil, fmt.Errorf("unknown Group field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *GroupMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case group.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case group.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case group.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case group.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case group.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case group.FieldRateMultiplier:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateMultiplier(v)
+		return nil
+	case group.FieldIsExclusive:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIsExclusive(v)
+		return nil
+	case group.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case group.FieldPlatform:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPlatform(v)
+		return nil
+	case group.FieldSubscriptionType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSubscriptionType(v)
+		return nil
+	case group.FieldCurrency:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCurrency(v)
+		return nil
+	case group.FieldDailyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDailyLimitUsd(v)
+		return nil
+	case group.FieldWeeklyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetWeeklyLimitUsd(v)
+		return nil
+	case group.FieldMonthlyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMonthlyLimitUsd(v)
+		return nil
+	case group.FieldDefaultValidityDays:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDefaultValidityDays(v)
+		return nil
+	case group.FieldImagePrice1k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetImagePrice1k(v)
+		return nil
+	case group.FieldImagePrice2k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetImagePrice2k(v)
+		return nil
+	case group.FieldImagePrice4k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetImagePrice4k(v)
+		return nil
+	case group.FieldClaudeCodeOnly:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaudeCodeOnly(v)
+		return nil
+	case group.FieldFallbackGroupID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFallbackGroupID(v)
+		return nil
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFallbackGroupIDOnInvalidRequest(v)
+		return nil
+	case group.FieldModelRouting:
+		v, ok := value.(map[string][]int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModelRouting(v)
+		return nil
+	case group.FieldModelRoutingEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModelRoutingEnabled(v)
+		return nil
+	case group.FieldMcpXMLInject:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMcpXMLInject(v)
+		return nil
+	case group.FieldSupportedModelScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSupportedModelScopes(v)
+		return nil
+	case group.FieldSortOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSortOrder(v)
+		return nil
+	case group.FieldDisableMetadataRewrite:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisableMetadataRewrite(v)
+		return nil
+	case group.FieldMaxMessages:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxMessages(v)
+		return nil
+	case group.FieldDailyRequestLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDailyRequestLimit(v)
+		return nil
+	case group.FieldUpstreamHeaders:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpstreamHeaders(v)
+		return nil
+	case group.FieldSubscriptionOverflowPolicy:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDescription(v)
+		m.SetSubscriptionOverflowPolicy(v)
+		return nil
+	case group.FieldIntentRouting:
+		v, ok := value.(map[string][]int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIntentRouting(v)
+		return nil
+	case group.FieldIntentRoutingEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIntentRoutingEnabled(v)
+		return nil
+	case group.FieldAllowedEndpoints:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedEndpoints(v)
+		return nil
+	case group.FieldRequireAnthropicVersion:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequireAnthropicVersion(v)
+		return nil
+	case group.FieldMaxOutputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxOutputTokens(v)
+		return nil
+	case group.FieldMixedSchedulingNativeSaturationOnly:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMixedSchedulingNativeSaturationOnly(v)
+		return nil
+	case group.FieldWindowCostLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetWindowCostLimitUsd(v)
+		return nil
+	case group.FieldWindowCostWindowHours:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetWindowCostWindowHours(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Group field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *GroupMutation) AddedFields() []string {
+	var fields []string
+	if m.addrate_multiplier != nil {
+		fields = append(fields, group.FieldRateMultiplier)
+	}
+	if m.adddaily_limit_usd != nil {
+		fields = append(fields, group.FieldDailyLimitUsd)
+	}
+	if m.addweekly_limit_usd != nil {
+		fields = append(fields, group.FieldWeeklyLimitUsd)
+	}
+	if m.addmonthly_limit_usd != nil {
+		fields = append(fields, group.FieldMonthlyLimitUsd)
+	}
+	if m.adddefault_validity_days != nil {
+		fields = append(fields, group.FieldDefaultValidityDays)
+	}
+	if m.addimage_price_1k != nil {
+		fields = append(fields, group.FieldImagePrice1k)
+	}
+	if m.addimage_price_2k != nil {
+		fields = append(fields, group.FieldImagePrice2k)
+	}
+	if m.addimage_price_4k != nil {
+		fields = append(fields, group.FieldImagePrice4k)
+	}
+	if m.addfallback_group_id != nil {
+		fields = append(fields, group.FieldFallbackGroupID)
+	}
+	if m.addfallback_group_id_on_invalid_request != nil {
+		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	}
+	if m.addsort_order != nil {
+		fields = append(fields, group.FieldSortOrder)
+	}
+	if m.addmax_messages != nil {
+		fields = append(fields, group.FieldMaxMessages)
+	}
+	if m.adddaily_request_limit != nil {
+		fields = append(fields, group.FieldDailyRequestLimit)
+	}
+	if m.addmax_output_tokens != nil {
+		fields = append(fields, group.FieldMaxOutputTokens)
+	}
+	if m.addwindow_cost_limit_usd != nil {
+		fields = append(fields, group.FieldWindowCostLimitUsd)
+	}
+	if m.addwindow_cost_window_hours != nil {
+		fields = append(fields, group.FieldWindowCostWindowHours)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *GroupMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case group.FieldRateMultiplier:
+		return m.AddedRateMultiplier()
+	case group.FieldDailyLimitUsd:
+		return m.AddedDailyLimitUsd()
+	case group.FieldWeeklyLimitUsd:
+		return m.AddedWeeklyLimitUsd()
+	case group.FieldMonthlyLimitUsd:
+		return m.AddedMonthlyLimitUsd()
+	case group.FieldDefaultValidityDays:
+		return m.AddedDefaultValidityDays()
+	case group.FieldImagePrice1k:
+		return m.AddedImagePrice1k()
+	case group.FieldImagePrice2k:
+		return m.AddedImagePrice2k()
+	case group.FieldImagePrice4k:
+		return m.AddedImagePrice4k()
+	case group.FieldFallbackGroupID:
+		return m.AddedFallbackGroupID()
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		return m.AddedFallbackGroupIDOnInvalidRequest()
+	case group.FieldSortOrder:
+		return m.AddedSortOrder()
+	case group.FieldMaxMessages:
+		return m.AddedMaxMessages()
+	case group.FieldDailyRequestLimit:
+		return m.AddedDailyRequestLimit()
+	case group.FieldMaxOutputTokens:
+		return m.AddedMaxOutputTokens()
+	case group.FieldWindowCostLimitUsd:
+		return m.AddedWindowCostLimitUsd()
+	case group.FieldWindowCostWindowHours:
+		return m.AddedWindowCostWindowHours()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *GroupMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case group.FieldRateMultiplier:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRateMultiplier(v)
+		return nil
+	case group.FieldDailyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDailyLimitUsd(v)
+		return nil
+	case group.FieldWeeklyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddWeeklyLimitUsd(v)
+		return nil
+	case group.FieldMonthlyLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMonthlyLimitUsd(v)
+		return nil
+	case group.FieldDefaultValidityDays:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDefaultValidityDays(v)
+		return nil
+	case group.FieldImagePrice1k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddImagePrice1k(v)
+		return nil
+	case group.FieldImagePrice2k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddImagePrice2k(v)
+		return nil
+	case group.FieldImagePrice4k:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddImagePrice4k(v)
+		return nil
+	case group.FieldFallbackGroupID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddFallbackGroupID(v)
+		return nil
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddFallbackGroupIDOnInvalidRequest(v)
+		return nil
+	case group.FieldSortOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSortOrder(v)
+		return nil
+	case group.FieldMaxMessages:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxMessages(v)
+		return nil
+	case group.FieldDailyRequestLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDailyRequestLimit(v)
+		return nil
+	case group.FieldMaxOutputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxOutputTokens(v)
+		return nil
+	case group.FieldWindowCostLimitUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddWindowCostLimitUsd(v)
+		return nil
+	case group.FieldWindowCostWindowHours:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddWindowCostWindowHours(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Group numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *GroupMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(group.FieldDeletedAt) {
+		fields = append(fields, group.FieldDeletedAt)
+	}
+	if m.FieldCleared(group.FieldDescription) {
+		fields = append(fields, group.FieldDescription)
+	}
+	if m.FieldCleared(group.FieldDailyLimitUsd) {
+		fields = append(fields, group.FieldDailyLimitUsd)
+	}
+	if m.FieldCleared(group.FieldWeeklyLimitUsd) {
+		fields = append(fields, group.FieldWeeklyLimitUsd)
+	}
+	if m.FieldCleared(group.FieldMonthlyLimitUsd) {
+		fields = append(fields, group.FieldMonthlyLimitUsd)
+	}
+	if m.FieldCleared(group.FieldImagePrice1k) {
+		fields = append(fields, group.FieldImagePrice1k)
+	}
+	if m.FieldCleared(group.FieldImagePrice2k) {
+		fields = append(fields, group.FieldImagePrice2k)
+	}
+	if m.FieldCleared(group.FieldImagePrice4k) {
+		fields = append(fields, group.FieldImagePrice4k)
+	}
+	if m.FieldCleared(group.FieldFallbackGroupID) {
+		fields = append(fields, group.FieldFallbackGroupID)
+	}
+	if m.FieldCleared(group.FieldFallbackGroupIDOnInvalidRequest) {
+		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	}
+	if m.FieldCleared(group.FieldModelRouting) {
+		fields = append(fields, group.FieldModelRouting)
+	}
+	if m.FieldCleared(group.FieldMaxMessages) {
+		fields = append(fields, group.FieldMaxMessages)
+	}
+	if m.FieldCleared(group.FieldDailyRequestLimit) {
+		fields = append(fields, group.FieldDailyRequestLimit)
+	}
+	if m.FieldCleared(group.FieldUpstreamHeaders) {
+		fields = append(fields, group.FieldUpstreamHeaders)
+	}
+	if m.FieldCleared(group.FieldIntentRouting) {
+		fields = append(fields, group.FieldIntentRouting)
+	}
+	if m.FieldCleared(group.FieldAllowedEndpoints) {
+		fields = append(fields, group.FieldAllowedEndpoints)
+	}
+	if m.FieldCleared(group.FieldMaxOutputTokens) {
+		fields = append(fields, group.FieldMaxOutputTokens)
+	}
+	if m.FieldCleared(group.FieldWindowCostLimitUsd) {
+		fields = append(fields, group.FieldWindowCostLimitUsd)
+	}
+	if m.FieldCleared(group.FieldWindowCostWindowHours) {
+		fields = append(fields, group.FieldWindowCostWindowHours)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *GroupMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *GroupMutation) ClearField(name string) error {
+	switch name {
+	case group.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case group.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case group.FieldDailyLimitUsd:
+		m.ClearDailyLimitUsd()
+		return nil
+	case group.FieldWeeklyLimitUsd:
+		m.ClearWeeklyLimitUsd()
+		return nil
+	case group.FieldMonthlyLimitUsd:
+		m.ClearMonthlyLimitUsd()
+		return nil
+	case group.FieldImagePrice1k:
+		m.ClearImagePrice1k()
+		return nil
+	case group.FieldImagePrice2k:
+		m.ClearImagePrice2k()
+		return nil
+	case group.FieldImagePrice4k:
+		m.ClearImagePrice4k()
+		return nil
+	case group.FieldFallbackGroupID:
+		m.ClearFallbackGroupID()
+		return nil
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		m.ClearFallbackGroupIDOnInvalidRequest()
+		return nil
+	case group.FieldModelRouting:
+		m.ClearModelRouting()
+		return nil
+	case group.FieldMaxMessages:
+		m.ClearMaxMessages()
+		return nil
+	case group.FieldDailyRequestLimit:
+		m.ClearDailyRequestLimit()
+		return nil
+	case group.FieldUpstreamHeaders:
+		m.ClearUpstreamHeaders()
+		return nil
+	case group.FieldIntentRouting:
+		m.ClearIntentRouting()
+		return nil
+	case group.FieldAllowedEndpoints:
+		m.ClearAllowedEndpoints()
+		return nil
+	case group.FieldMaxOutputTokens:
+		m.ClearMaxOutputTokens()
+		return nil
+	case group.FieldWindowCostLimitUsd:
+		m.ClearWindowCostLimitUsd()
+		return nil
+	case group.FieldWindowCostWindowHours:
+		m.ClearWindowCostWindowHours()
+		return nil
+	}
+	return fmt.Errorf("unknown Group nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *GroupMutation) ResetField(name string) error {
+	switch name {
+	case group.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case group.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case group.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case group.FieldName:
+		m.ResetName()
+		return nil
+	case group.FieldDescription:
+		m.ResetDescription()
 		return nil
 	case group.FieldRateMultiplier:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRateMultiplier(v)
+		m.ResetRateMultiplier()
 		return nil
 	case group.FieldIsExclusive:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetIsExclusive(v)
+		m.ResetIsExclusive()
 		return nil
 	case group.FieldStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
+		m.ResetStatus()
 		return nil
 	case group.FieldPlatform:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPlatform(v)
+		m.ResetPlatform()
 		return nil
 	case group.FieldSubscriptionType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSubscriptionType(v)
+		m.ResetSubscriptionType()
+		return nil
+	case group.FieldCurrency:
+		m.ResetCurrency()
 		return nil
 	case group.FieldDailyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDailyLimitUsd(v)
+		m.ResetDailyLimitUsd()
 		return nil
 	case group.FieldWeeklyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetWeeklyLimitUsd(v)
+		m.ResetWeeklyLimitUsd()
 		return nil
 	case group.FieldMonthlyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetMonthlyLimitUsd(v)
+		m.ResetMonthlyLimitUsd()
 		return nil
 	case group.FieldDefaultValidityDays:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		m.ResetDefaultValidityDays()
+		return nil
+	case group.FieldImagePrice1k:
+		m.ResetImagePrice1k()
+		return nil
+	case group.FieldImagePrice2k:
+		m.ResetImagePrice2k()
+		return nil
+	case group.FieldImagePrice4k:
+		m.ResetImagePrice4k()
+		return nil
+	case group.FieldClaudeCodeOnly:
+		m.ResetClaudeCodeOnly()
+		return nil
+	case group.FieldFallbackGroupID:
+		m.ResetFallbackGroupID()
+		return nil
+	case group.FieldFallbackGroupIDOnInvalidRequest:
+		m.ResetFallbackGroupIDOnInvalidRequest()
+		return nil
+	case group.FieldModelRouting:
+		m.ResetModelRouting()
+		return nil
+	case group.FieldModelRoutingEnabled:
+		m.ResetModelRoutingEnabled()
+		return nil
+	case group.FieldMcpXMLInject:
+		m.ResetMcpXMLInject()
+		return nil
+	case group.FieldSupportedModelScopes:
+		m.ResetSupportedModelScopes()
+		return nil
+	case group.FieldSortOrder:
+		m.ResetSortOrder()
+		return nil
+	case group.FieldDisableMetadataRewrite:
+		m.ResetDisableMetadataRewrite()
+		return nil
+	case group.FieldMaxMessages:
+		m.ResetMaxMessages()
+		return nil
+	case group.FieldDailyRequestLimit:
+		m.ResetDailyRequestLimit()
+		return nil
+	case group.FieldUpstreamHeaders:
+		m.ResetUpstreamHeaders()
+		return nil
+	case group.FieldSubscriptionOverflowPolicy:
+		m.ResetSubscriptionOverflowPolicy()
+		return nil
+	case group.FieldIntentRouting:
+		m.ResetIntentRouting()
+		return nil
+	case group.FieldIntentRoutingEnabled:
+		m.ResetIntentRoutingEnabled()
+		return nil
+	case group.FieldAllowedEndpoints:
+		m.ResetAllowedEndpoints()
+		return nil
+	case group.FieldRequireAnthropicVersion:
+		m.ResetRequireAnthropicVersion()
+		return nil
+	case group.FieldMaxOutputTokens:
+		m.ResetMaxOutputTokens()
+		return nil
+	case group.FieldMixedSchedulingNativeSaturationOnly:
+		m.ResetMixedSchedulingNativeSaturationOnly()
+		return nil
+	case group.FieldWindowCostLimitUsd:
+		m.ResetWindowCostLimitUsd()
+		return nil
+	case group.FieldWindowCostWindowHours:
+		m.ResetWindowCostWindowHours()
+		return nil
+	}
+	return fmt.Errorf("unknown Group field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *GroupMutation) AddedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.api_keys != nil {
+		edges = append(edges, group.EdgeAPIKeys)
+	}
+	if m.redeem_codes != nil {
+		edges = append(edges, group.EdgeRedeemCodes)
+	}
+	if m.subscriptions != nil {
+		edges = append(edges, group.EdgeSubscriptions)
+	}
+	if m.usage_logs != nil {
+		edges = append(edges, group.EdgeUsageLogs)
+	}
+	if m.accounts != nil {
+		edges = append(edges, group.EdgeAccounts)
+	}
+	if m.allowed_users != nil {
+		edges = append(edges, group.EdgeAllowedUsers)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *GroupMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case group.EdgeAPIKeys:
+		ids := make([]ent.Value, 0, len(m.api_keys))
+		for id := range m.api_keys {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeRedeemCodes:
+		ids := make([]ent.Value, 0, len(m.redeem_codes))
+		for id := range m.redeem_codes {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeSubscriptions:
+		ids := make([]ent.Value, 0, len(m.subscriptions))
+		for id := range m.subscriptions {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.usage_logs))
+		for id := range m.usage_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeAccounts:
+		ids := make([]ent.Value, 0, len(m.accounts))
+		for id := range m.accounts {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeAllowedUsers:
+		ids := make([]ent.Value, 0, len(m.allowed_users))
+		for id := range m.allowed_users {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *GroupMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.removedapi_keys != nil {
+		edges = append(edges, group.EdgeAPIKeys)
+	}
+	if m.removedredeem_codes != nil {
+		edges = append(edges, group.EdgeRedeemCodes)
+	}
+	if m.removedsubscriptions != nil {
+		edges = append(edges, group.EdgeSubscriptions)
+	}
+	if m.removedusage_logs != nil {
+		edges = append(edges, group.EdgeUsageLogs)
+	}
+	if m.removedaccounts != nil {
+		edges = append(edges, group.EdgeAccounts)
+	}
+	if m.removedallowed_users != nil {
+		edges = append(edges, group.EdgeAllowedUsers)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *GroupMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case group.EdgeAPIKeys:
+		ids := make([]ent.Value, 0, len(m.removedapi_keys))
+		for id := range m.removedapi_keys {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeRedeemCodes:
+		ids := make([]ent.Value, 0, len(m.removedredeem_codes))
+		for id := range m.removedredeem_codes {
+			ids = append(ids, id)
+		}
+		return ids
+	case group.EdgeSubscriptions:
+		ids := make([]ent.Value, 0, len(m.removedsubscriptions))
+		for id := range m.removedsubscriptions {
+			ids = append(ids, id)
 		}
-		m.SetDefaultValidityDays(v)
-		return nil
-	case group.FieldImagePrice1k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		return ids
+	case group.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.removedusage_logs))
+		for id := range m.removedusage_logs {
+			ids = append(ids, id)
 		}
-		m.SetImagePrice1k(v)
-		return nil
-	case group.FieldImagePrice2k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		return ids
+	case group.EdgeAccounts:
+		ids := make([]ent.Value, 0, len(m.removedaccounts))
+		for id := range m.removedaccounts {
+			ids = append(ids, id)
 		}
-		m.SetImagePrice2k(v)
-		return nil
-	case group.FieldImagePrice4k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		return ids
+	case group.EdgeAllowedUsers:
+		ids := make([]ent.Value, 0, len(m.removedallowed_users))
+		for id := range m.removedallowed_users {
+			ids = append(ids, id)
 		}
-		m.SetImagePrice4k(v)
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *GroupMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.clearedapi_keys {
+		edges = append(edges, group.EdgeAPIKeys)
+	}
+	if m.clearedredeem_codes {
+		edges = append(edges, group.EdgeRedeemCodes)
+	}
+	if m.clearedsubscriptions {
+		edges = append(edges, group.EdgeSubscriptions)
+	}
+	if m.clearedusage_logs {
+		edges = append(edges, group.EdgeUsageLogs)
+	}
+	if m.clearedaccounts {
+		edges = append(edges, group.EdgeAccounts)
+	}
+	if m.clearedallowed_users {
+		edges = append(edges, group.EdgeAllowedUsers)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *GroupMutation) EdgeCleared(name string) bool {
+	switch name {
+	case group.EdgeAPIKeys:
+		return m.clearedapi_keys
+	case group.EdgeRedeemCodes:
+		return m.clearedredeem_codes
+	case group.EdgeSubscriptions:
+		return m.clearedsubscriptions
+	case group.EdgeUsageLogs:
+		return m.clearedusage_logs
+	case group.EdgeAccounts:
+		return m.clearedaccounts
+	case group.EdgeAllowedUsers:
+		return m.clearedallowed_users
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *GroupMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Group unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *GroupMutation) ResetEdge(name string) error {
+	switch name {
+	case group.EdgeAPIKeys:
+		m.ResetAPIKeys()
 		return nil
-	case group.FieldClaudeCodeOnly:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaudeCodeOnly(v)
+	case group.EdgeRedeemCodes:
+		m.ResetRedeemCodes()
 		return nil
-	case group.FieldFallbackGroupID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFallbackGroupID(v)
+	case group.EdgeSubscriptions:
+		m.ResetSubscriptions()
 		return nil
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFallbackGroupIDOnInvalidRequest(v)
+	case group.EdgeUsageLogs:
+		m.ResetUsageLogs()
 		return nil
-	case group.FieldModelRouting:
-		v, ok := value.(map[string][]int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetModelRouting(v)
+	case group.EdgeAccounts:
+		m.ResetAccounts()
 		return nil
-	case group.FieldModelRoutingEnabled:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetModelRoutingEnabled(v)
+	case group.EdgeAllowedUsers:
+		m.ResetAllowedUsers()
 		return nil
-	case group.FieldMcpXMLInject:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+	}
+	return fmt.Errorf("unknown Group edge %s", name)
+}
+
+// PromoCodeMutation represents an operation that mutates the PromoCode nodes in the graph.
+type PromoCodeMutation struct {
+	config
+	op                   Op
+	typ                  string
+	id                   *int64
+	code                 *string
+	bonus_amount         *float64
+	addbonus_amount      *float64
+	max_uses             *int
+	addmax_uses          *int
+	used_count           *int
+	addused_count        *int
+	status               *string
+	expires_at           *time.Time
+	notes                *string
+	created_at           *time.Time
+	updated_at           *time.Time
+	clearedFields        map[string]struct{}
+	usage_records        map[int64]struct{}
+	removedusage_records map[int64]struct{}
+	clearedusage_records bool
+	done                 bool
+	oldValue             func(context.Context) (*PromoCode, error)
+	predicates           []predicate.PromoCode
+}
+
+var _ ent.Mutation = (*PromoCodeMutation)(nil)
+
+// promocodeOption allows management of the mutation configuration using functional options.
+type promocodeOption func(*PromoCodeMutation)
+
+// newPromoCodeMutation creates new mutation for the PromoCode entity.
+func newPromoCodeMutation(c config, op Op, opts ...promocodeOption) *PromoCodeMutation {
+	m := &PromoCodeMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePromoCode,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPromoCodeID sets the ID field of the mutation.
+func withPromoCodeID(id int64) promocodeOption {
+	return func(m *PromoCodeMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PromoCode
+		)
+		m.oldValue = func(ctx context.Context) (*PromoCode, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PromoCode.Get(ctx, id)
+				}
+			})
+			return value, err
 		}
-		m.SetMcpXMLInject(v)
-		return nil
-	case group.FieldSupportedModelScopes:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		m.id = &id
+	}
+}
+
+// withPromoCode sets the old PromoCode of the mutation.
+func withPromoCode(node *PromoCode) promocodeOption {
+	return func(m *PromoCodeMutation) {
+		m.oldValue = func(context.Context) (*PromoCode, error) {
+			return node, nil
 		}
-		m.SetSupportedModelScopes(v)
-		return nil
-	case group.FieldSortOrder:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PromoCodeMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PromoCodeMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PromoCodeMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PromoCodeMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
 		}
-		m.SetSortOrder(v)
-		return nil
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PromoCode.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
-	return fmt.Errorf("unknown Group field %s", name)
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *GroupMutation) AddedFields() []string {
-	var fields []string
-	if m.addrate_multiplier != nil {
-		fields = append(fields, group.FieldRateMultiplier)
+// SetCode sets the "code" field.
+func (m *PromoCodeMutation) SetCode(s string) {
+	m.code = &s
+}
+
+// Code returns the value of the "code" field in the mutation.
+func (m *PromoCodeMutation) Code() (r string, exists bool) {
+	v := m.code
+	if v == nil {
+		return
 	}
-	if m.adddaily_limit_usd != nil {
-		fields = append(fields, group.FieldDailyLimitUsd)
+	return *v, true
+}
+
+// OldCode returns the old "code" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCode is only allowed on UpdateOne operations")
 	}
-	if m.addweekly_limit_usd != nil {
-		fields = append(fields, group.FieldWeeklyLimitUsd)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCode requires an ID field in the mutation")
 	}
-	if m.addmonthly_limit_usd != nil {
-		fields = append(fields, group.FieldMonthlyLimitUsd)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCode: %w", err)
 	}
-	if m.adddefault_validity_days != nil {
-		fields = append(fields, group.FieldDefaultValidityDays)
+	return oldValue.Code, nil
+}
+
+// ResetCode resets all changes to the "code" field.
+func (m *PromoCodeMutation) ResetCode() {
+	m.code = nil
+}
+
+// SetBonusAmount sets the "bonus_amount" field.
+func (m *PromoCodeMutation) SetBonusAmount(f float64) {
+	m.bonus_amount = &f
+	m.addbonus_amount = nil
+}
+
+// BonusAmount returns the value of the "bonus_amount" field in the mutation.
+func (m *PromoCodeMutation) BonusAmount() (r float64, exists bool) {
+	v := m.bonus_amount
+	if v == nil {
+		return
 	}
-	if m.addimage_price_1k != nil {
-		fields = append(fields, group.FieldImagePrice1k)
+	return *v, true
+}
+
+// OldBonusAmount returns the old "bonus_amount" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldBonusAmount(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBonusAmount is only allowed on UpdateOne operations")
 	}
-	if m.addimage_price_2k != nil {
-		fields = append(fields, group.FieldImagePrice2k)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBonusAmount requires an ID field in the mutation")
 	}
-	if m.addimage_price_4k != nil {
-		fields = append(fields, group.FieldImagePrice4k)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBonusAmount: %w", err)
 	}
-	if m.addfallback_group_id != nil {
-		fields = append(fields, group.FieldFallbackGroupID)
+	return oldValue.BonusAmount, nil
+}
+
+// AddBonusAmount adds f to the "bonus_amount" field.
+func (m *PromoCodeMutation) AddBonusAmount(f float64) {
+	if m.addbonus_amount != nil {
+		*m.addbonus_amount += f
+	} else {
+		m.addbonus_amount = &f
+	}
+}
+
+// AddedBonusAmount returns the value that was added to the "bonus_amount" field in this mutation.
+func (m *PromoCodeMutation) AddedBonusAmount() (r float64, exists bool) {
+	v := m.addbonus_amount
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetBonusAmount resets all changes to the "bonus_amount" field.
+func (m *PromoCodeMutation) ResetBonusAmount() {
+	m.bonus_amount = nil
+	m.addbonus_amount = nil
+}
+
+// SetMaxUses sets the "max_uses" field.
+func (m *PromoCodeMutation) SetMaxUses(i int) {
+	m.max_uses = &i
+	m.addmax_uses = nil
+}
+
+// MaxUses returns the value of the "max_uses" field in the mutation.
+func (m *PromoCodeMutation) MaxUses() (r int, exists bool) {
+	v := m.max_uses
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxUses returns the old "max_uses" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldMaxUses(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxUses is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxUses requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxUses: %w", err)
+	}
+	return oldValue.MaxUses, nil
+}
+
+// AddMaxUses adds i to the "max_uses" field.
+func (m *PromoCodeMutation) AddMaxUses(i int) {
+	if m.addmax_uses != nil {
+		*m.addmax_uses += i
+	} else {
+		m.addmax_uses = &i
+	}
+}
+
+// AddedMaxUses returns the value that was added to the "max_uses" field in this mutation.
+func (m *PromoCodeMutation) AddedMaxUses() (r int, exists bool) {
+	v := m.addmax_uses
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxUses resets all changes to the "max_uses" field.
+func (m *PromoCodeMutation) ResetMaxUses() {
+	m.max_uses = nil
+	m.addmax_uses = nil
+}
+
+// SetUsedCount sets the "used_count" field.
+func (m *PromoCodeMutation) SetUsedCount(i int) {
+	m.used_count = &i
+	m.addused_count = nil
+}
+
+// UsedCount returns the value of the "used_count" field in the mutation.
+func (m *PromoCodeMutation) UsedCount() (r int, exists bool) {
+	v := m.used_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsedCount returns the old "used_count" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldUsedCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsedCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsedCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsedCount: %w", err)
+	}
+	return oldValue.UsedCount, nil
+}
+
+// AddUsedCount adds i to the "used_count" field.
+func (m *PromoCodeMutation) AddUsedCount(i int) {
+	if m.addused_count != nil {
+		*m.addused_count += i
+	} else {
+		m.addused_count = &i
+	}
+}
+
+// AddedUsedCount returns the value that was added to the "used_count" field in this mutation.
+func (m *PromoCodeMutation) AddedUsedCount() (r int, exists bool) {
+	v := m.addused_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUsedCount resets all changes to the "used_count" field.
+func (m *PromoCodeMutation) ResetUsedCount() {
+	m.used_count = nil
+	m.addused_count = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *PromoCodeMutation) SetStatus(s string) {
+	m.status = &s
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *PromoCodeMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
-	if m.addfallback_group_id_on_invalid_request != nil {
-		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
-	if m.addsort_order != nil {
-		fields = append(fields, group.FieldSortOrder)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return fields
+	return oldValue.Status, nil
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *GroupMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case group.FieldRateMultiplier:
-		return m.AddedRateMultiplier()
-	case group.FieldDailyLimitUsd:
-		return m.AddedDailyLimitUsd()
-	case group.FieldWeeklyLimitUsd:
-		return m.AddedWeeklyLimitUsd()
-	case group.FieldMonthlyLimitUsd:
-		return m.AddedMonthlyLimitUsd()
-	case group.FieldDefaultValidityDays:
-		return m.AddedDefaultValidityDays()
-	case group.FieldImagePrice1k:
-		return m.AddedImagePrice1k()
-	case group.FieldImagePrice2k:
-		return m.AddedImagePrice2k()
-	case group.FieldImagePrice4k:
-		return m.AddedImagePrice4k()
-	case group.FieldFallbackGroupID:
-		return m.AddedFallbackGroupID()
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		return m.AddedFallbackGroupIDOnInvalidRequest()
-	case group.FieldSortOrder:
-		return m.AddedSortOrder()
-	}
-	return nil, false
+// ResetStatus resets all changes to the "status" field.
+func (m *PromoCodeMutation) ResetStatus() {
+	m.status = nil
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *GroupMutation) AddField(name string, value ent.Value) error {
-	switch name {
-	case group.FieldRateMultiplier:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddRateMultiplier(v)
-		return nil
-	case group.FieldDailyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddDailyLimitUsd(v)
-		return nil
-	case group.FieldWeeklyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddWeeklyLimitUsd(v)
-		return nil
-	case group.FieldMonthlyLimitUsd:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddMonthlyLimitUsd(v)
-		return nil
-	case group.FieldDefaultValidityDays:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddDefaultValidityDays(v)
-		return nil
-	case group.FieldImagePrice1k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddImagePrice1k(v)
-		return nil
-	case group.FieldImagePrice2k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddImagePrice2k(v)
-		return nil
-	case group.FieldImagePrice4k:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddImagePrice4k(v)
-		return nil
-	case group.FieldFallbackGroupID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddFallbackGroupID(v)
-		return nil
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddFallbackGroupIDOnInvalidRequest(v)
-		return nil
-	case group.FieldSortOrder:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddSortOrder(v)
-		return nil
+// SetExpiresAt sets the "expires_at" field.
+func (m *PromoCodeMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *PromoCodeMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown Group numeric field %s", name)
+	return *v, true
 }
 
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *GroupMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(group.FieldDeletedAt) {
-		fields = append(fields, group.FieldDeletedAt)
+// OldExpiresAt returns the old "expires_at" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
-	if m.FieldCleared(group.FieldDescription) {
-		fields = append(fields, group.FieldDescription)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
 	}
-	if m.FieldCleared(group.FieldDailyLimitUsd) {
-		fields = append(fields, group.FieldDailyLimitUsd)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
-	if m.FieldCleared(group.FieldWeeklyLimitUsd) {
-		fields = append(fields, group.FieldWeeklyLimitUsd)
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *PromoCodeMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[promocode.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *PromoCodeMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[promocode.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *PromoCodeMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, promocode.FieldExpiresAt)
+}
+
+// SetNotes sets the "notes" field.
+func (m *PromoCodeMutation) SetNotes(s string) {
+	m.notes = &s
+}
+
+// Notes returns the value of the "notes" field in the mutation.
+func (m *PromoCodeMutation) Notes() (r string, exists bool) {
+	v := m.notes
+	if v == nil {
+		return
 	}
-	if m.FieldCleared(group.FieldMonthlyLimitUsd) {
-		fields = append(fields, group.FieldMonthlyLimitUsd)
+	return *v, true
+}
+
+// OldNotes returns the old "notes" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldNotes(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
 	}
-	if m.FieldCleared(group.FieldImagePrice1k) {
-		fields = append(fields, group.FieldImagePrice1k)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNotes requires an ID field in the mutation")
 	}
-	if m.FieldCleared(group.FieldImagePrice2k) {
-		fields = append(fields, group.FieldImagePrice2k)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
 	}
-	if m.FieldCleared(group.FieldImagePrice4k) {
-		fields = append(fields, group.FieldImagePrice4k)
+	return oldValue.Notes, nil
+}
+
+// ClearNotes clears the value of the "notes" field.
+func (m *PromoCodeMutation) ClearNotes() {
+	m.notes = nil
+	m.clearedFields[promocode.FieldNotes] = struct{}{}
+}
+
+// NotesCleared returns if the "notes" field was cleared in this mutation.
+func (m *PromoCodeMutation) NotesCleared() bool {
+	_, ok := m.clearedFields[promocode.FieldNotes]
+	return ok
+}
+
+// ResetNotes resets all changes to the "notes" field.
+func (m *PromoCodeMutation) ResetNotes() {
+	m.notes = nil
+	delete(m.clearedFields, promocode.FieldNotes)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PromoCodeMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PromoCodeMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
-	if m.FieldCleared(group.FieldFallbackGroupID) {
-		fields = append(fields, group.FieldFallbackGroupID)
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
-	if m.FieldCleared(group.FieldFallbackGroupIDOnInvalidRequest) {
-		fields = append(fields, group.FieldFallbackGroupIDOnInvalidRequest)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
-	if m.FieldCleared(group.FieldModelRouting) {
-		fields = append(fields, group.FieldModelRouting)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return fields
+	return oldValue.CreatedAt, nil
 }
 
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *GroupMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
-	return ok
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PromoCodeMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *GroupMutation) ClearField(name string) error {
-	switch name {
-	case group.FieldDeletedAt:
-		m.ClearDeletedAt()
-		return nil
-	case group.FieldDescription:
-		m.ClearDescription()
-		return nil
-	case group.FieldDailyLimitUsd:
-		m.ClearDailyLimitUsd()
-		return nil
-	case group.FieldWeeklyLimitUsd:
-		m.ClearWeeklyLimitUsd()
-		return nil
-	case group.FieldMonthlyLimitUsd:
-		m.ClearMonthlyLimitUsd()
-		return nil
-	case group.FieldImagePrice1k:
-		m.ClearImagePrice1k()
-		return nil
-	case group.FieldImagePrice2k:
-		m.ClearImagePrice2k()
-		return nil
-	case group.FieldImagePrice4k:
-		m.ClearImagePrice4k()
-		return nil
-	case group.FieldFallbackGroupID:
-		m.ClearFallbackGroupID()
-		return nil
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		m.ClearFallbackGroupIDOnInvalidRequest()
-		return nil
-	case group.FieldModelRouting:
-		m.ClearModelRouting()
-		return nil
-	}
-	return fmt.Errorf("unknown Group nullable field %s", name)
+// SetUpdatedAt sets the "updated_at" field.
+func (m *PromoCodeMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *GroupMutation) ResetField(name string) error {
-	switch name {
-	case group.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case group.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case group.FieldDeletedAt:
-		m.ResetDeletedAt()
-		return nil
-	case group.FieldName:
-		m.ResetName()
-		return nil
-	case group.FieldDescription:
-		m.ResetDescription()
-		return nil
-	case group.FieldRateMultiplier:
-		m.ResetRateMultiplier()
-		return nil
-	case group.FieldIsExclusive:
-		m.ResetIsExclusive()
-		return nil
-	case group.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case group.FieldPlatform:
-		m.ResetPlatform()
-		return nil
-	case group.FieldSubscriptionType:
-		m.ResetSubscriptionType()
-		return nil
-	case group.FieldDailyLimitUsd:
-		m.ResetDailyLimitUsd()
-		return nil
-	case group.FieldWeeklyLimitUsd:
-		m.ResetWeeklyLimitUsd()
-		return nil
-	case group.FieldMonthlyLimitUsd:
-		m.ResetMonthlyLimitUsd()
-		return nil
-	case group.FieldDefaultValidityDays:
-		m.ResetDefaultValidityDays()
-		return nil
-	case group.FieldImagePrice1k:
-		m.ResetImagePrice1k()
-		return nil
-	case group.FieldImagePrice2k:
-		m.ResetImagePrice2k()
-		return nil
-	case group.FieldImagePrice4k:
-		m.ResetImagePrice4k()
-		return nil
-	case group.FieldClaudeCodeOnly:
-		m.ResetClaudeCodeOnly()
-		return nil
-	case group.FieldFallbackGroupID:
-		m.ResetFallbackGroupID()
-		return nil
-	case group.FieldFallbackGroupIDOnInvalidRequest:
-		m.ResetFallbackGroupIDOnInvalidRequest()
-		return nil
-	case group.FieldModelRouting:
-		m.ResetModelRouting()
-		return nil
-	case group.FieldModelRoutingEnabled:
-		m.ResetModelRoutingEnabled()
-		return nil
-	case group.FieldMcpXMLInject:
-		m.ResetMcpXMLInject()
-		return nil
-	case group.FieldSupportedModelScopes:
-		m.ResetSupportedModelScopes()
-		return nil
-	case group.FieldSortOrder:
-		m.ResetSortOrder()
-		return nil
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *PromoCodeMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown Group field %s", name)
+	return *v, true
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *GroupMutation) AddedEdges() []string {
-	edges := make([]string, 0, 6)
-	if m.api_keys != nil {
-		edges = append(edges, group.EdgeAPIKeys)
-	}
-	if m.redeem_codes != nil {
-		edges = append(edges, group.EdgeRedeemCodes)
-	}
-	if m.subscriptions != nil {
-		edges = append(edges, group.EdgeSubscriptions)
-	}
-	if m.usage_logs != nil {
-		edges = append(edges, group.EdgeUsageLogs)
+// OldUpdatedAt returns the old "updated_at" field's value of the PromoCode entity.
+// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromoCodeMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
-	if m.accounts != nil {
-		edges = append(edges, group.EdgeAccounts)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
-	if m.allowed_users != nil {
-		edges = append(edges, group.EdgeAllowedUsers)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return edges
+	return oldValue.UpdatedAt, nil
 }
 
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *GroupMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case group.EdgeAPIKeys:
-		ids := make([]ent.Value, 0, len(m.api_keys))
-		for id := range m.api_keys {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeRedeemCodes:
-		ids := make([]ent.Value, 0, len(m.redeem_codes))
-		for id := range m.redeem_codes {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeSubscriptions:
-		ids := make([]ent.Value, 0, len(m.subscriptions))
-		for id := range m.subscriptions {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.usage_logs))
-		for id := range m.usage_logs {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeAccounts:
-		ids := make([]ent.Value, 0, len(m.accounts))
-		for id := range m.accounts {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeAllowedUsers:
-		ids := make([]ent.Value, 0, len(m.allowed_users))
-		for id := range m.allowed_users {
-			ids = append(ids, id)
-		}
-		return ids
-	}
-	return nil
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *PromoCodeMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *GroupMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 6)
-	if m.removedapi_keys != nil {
-		edges = append(edges, group.EdgeAPIKeys)
+// AddUsageRecordIDs adds the "usage_records" edge to the PromoCodeUsage entity by ids.
+func (m *PromoCodeMutation) AddUsageRecordIDs(ids ...int64) {
+	if m.usage_records == nil {
+		m.usage_records = make(map[int64]struct{})
 	}
-	if m.removedredeem_codes != nil {
-		edges = append(edges, group.EdgeRedeemCodes)
+	for i := range ids {
+		m.usage_records[ids[i]] = struct{}{}
 	}
-	if m.removedsubscriptions != nil {
-		edges = append(edges, group.EdgeSubscriptions)
+}
+
+// ClearUsageRecords clears the "usage_records" edge to the PromoCodeUsage entity.
+func (m *PromoCodeMutation) ClearUsageRecords() {
+	m.clearedusage_records = true
+}
+
+// UsageRecordsCleared reports if the "usage_records" edge to the PromoCodeUsage entity was cleared.
+func (m *PromoCodeMutation) UsageRecordsCleared() bool {
+	return m.clearedusage_records
+}
+
+// RemoveUsageRecordIDs removes the "usage_records" edge to the PromoCodeUsage entity by IDs.
+func (m *PromoCodeMutation) RemoveUsageRecordIDs(ids ...int64) {
+	if m.removedusage_records == nil {
+		m.removedusage_records = make(map[int64]struct{})
 	}
-	if m.removedusage_logs != nil {
-		edges = append(edges, group.EdgeUsageLogs)
+	for i := range ids {
+		delete(m.usage_records, ids[i])
+		m.removedusage_records[ids[i]] = struct{}{}
 	}
-	if m.removedaccounts != nil {
-		edges = append(edges, group.EdgeAccounts)
+}
+
+// RemovedUsageRecords returns the removed IDs of the "usage_records" edge to the PromoCodeUsage entity.
+func (m *PromoCodeMutation) RemovedUsageRecordsIDs() (ids []int64) {
+	for id := range m.removedusage_records {
+		ids = append(ids, id)
 	}
-	if m.removedallowed_users != nil {
-		edges = append(edges, group.EdgeAllowedUsers)
+	return
+}
+
+// UsageRecordsIDs returns the "usage_records" edge IDs in the mutation.
+func (m *PromoCodeMutation) UsageRecordsIDs() (ids []int64) {
+	for id := range m.usage_records {
+		ids = append(ids, id)
 	}
-	return edges
+	return
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *GroupMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case group.EdgeAPIKeys:
-		ids := make([]ent.Value, 0, len(m.removedapi_keys))
-		for id := range m.removedapi_keys {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeRedeemCodes:
-		ids := make([]ent.Value, 0, len(m.removedredeem_codes))
-		for id := range m.removedredeem_codes {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeSubscriptions:
-		ids := make([]ent.Value, 0, len(m.removedsubscriptions))
-		for id := range m.removedsubscriptions {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.removedusage_logs))
-		for id := range m.removedusage_logs {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeAccounts:
-		ids := make([]ent.Value, 0, len(m.removedaccounts))
-		for id := range m.removedaccounts {
-			ids = append(ids, id)
-		}
-		return ids
-	case group.EdgeAllowedUsers:
-		ids := make([]ent.Value, 0, len(m.removedallowed_users))
-		for id := range m.removedallowed_users {
-			ids = append(ids, id)
-		}
-		return ids
+// ResetUsageRecords resets all changes to the "usage_records" edge.
+func (m *PromoCodeMutation) ResetUsageRecords() {
+	m.usage_records = nil
+	m.clearedusage_records = false
+	m.removedusage_records = nil
+}
+
+// Where appends a list predicates to the PromoCodeMutation builder.
+func (m *PromoCodeMutation) Where(ps ...predicate.PromoCode) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PromoCodeMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PromoCodeMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PromoCode, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return nil
+	m.Where(p...)
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *GroupMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 6)
-	if m.clearedapi_keys {
-		edges = append(edges, group.EdgeAPIKeys)
+// Op returns the operation name.
+func (m *PromoCodeMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PromoCodeMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PromoCode).
+func (m *PromoCodeMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PromoCodeMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.code != nil {
+		fields = append(fields, promocode.FieldCode)
 	}
-	if m.clearedredeem_codes {
-		edges = append(edges, group.EdgeRedeemCodes)
+	if m.bonus_amount != nil {
+		fields = append(fields, promocode.FieldBonusAmount)
 	}
-	if m.clearedsubscriptions {
-		edges = append(edges, group.EdgeSubscriptions)
+	if m.max_uses != nil {
+		fields = append(fields, promocode.FieldMaxUses)
 	}
-	if m.clearedusage_logs {
-		edges = append(edges, group.EdgeUsageLogs)
+	if m.used_count != nil {
+		fields = append(fields, promocode.FieldUsedCount)
 	}
-	if m.clearedaccounts {
-		edges = append(edges, group.EdgeAccounts)
+	if m.status != nil {
+		fields = append(fields, promocode.FieldStatus)
 	}
-	if m.clearedallowed_users {
-		edges = append(edges, group.EdgeAllowedUsers)
+	if m.expires_at != nil {
+		fields = append(fields, promocode.FieldExpiresAt)
 	}
-	return edges
+	if m.notes != nil {
+		fields = append(fields, promocode.FieldNotes)
+	}
+	if m.created_at != nil {
+		fields = append(fields, promocode.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, promocode.FieldUpdatedAt)
+	}
+	return fields
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *GroupMutation) EdgeCleared(name string) bool {
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PromoCodeMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case group.EdgeAPIKeys:
-		return m.clearedapi_keys
-	case group.EdgeRedeemCodes:
-		return m.clearedredeem_codes
-	case group.EdgeSubscriptions:
-		return m.clearedsubscriptions
-	case group.EdgeUsageLogs:
-		return m.clearedusage_logs
-	case group.EdgeAccounts:
-		return m.clearedaccounts
-	case group.EdgeAllowedUsers:
-		return m.clearedallowed_users
+	case promocode.FieldCode:
+		return m.Code()
+	case promocode.FieldBonusAmount:
+		return m.BonusAmount()
+	case promocode.FieldMaxUses:
+		return m.MaxUses()
+	case promocode.FieldUsedCount:
+		return m.UsedCount()
+	case promocode.FieldStatus:
+		return m.Status()
+	case promocode.FieldExpiresAt:
+		return m.ExpiresAt()
+	case promocode.FieldNotes:
+		return m.Notes()
+	case promocode.FieldCreatedAt:
+		return m.CreatedAt()
+	case promocode.FieldUpdatedAt:
+		return m.UpdatedAt()
 	}
-	return false
+	return nil, false
 }
 
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *GroupMutation) ClearEdge(name string) error {
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PromoCodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
+	case promocode.FieldCode:
+		return m.OldCode(ctx)
+	case promocode.FieldBonusAmount:
+		return m.OldBonusAmount(ctx)
+	case promocode.FieldMaxUses:
+		return m.OldMaxUses(ctx)
+	case promocode.FieldUsedCount:
+		return m.OldUsedCount(ctx)
+	case promocode.FieldStatus:
+		return m.OldStatus(ctx)
+	case promocode.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case promocode.FieldNotes:
+		return m.OldNotes(ctx)
+	case promocode.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case promocode.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
 	}
-	return fmt.Errorf("unknown Group unique edge %s", name)
+	return nil, fmt.Errorf("unknown PromoCode field %s", name)
 }
 
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *GroupMutation) ResetEdge(name string) error {
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PromoCodeMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case group.EdgeAPIKeys:
-		m.ResetAPIKeys()
+	case promocode.FieldCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCode(v)
 		return nil
-	case group.EdgeRedeemCodes:
-		m.ResetRedeemCodes()
+	case promocode.FieldBonusAmount:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBonusAmount(v)
+		return nil
+	case promocode.FieldMaxUses:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxUses(v)
+		return nil
+	case promocode.FieldUsedCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsedCount(v)
+		return nil
+	case promocode.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
 		return nil
-	case group.EdgeSubscriptions:
-		m.ResetSubscriptions()
+	case promocode.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
 		return nil
-	case group.EdgeUsageLogs:
-		m.ResetUsageLogs()
+	case promocode.FieldNotes:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNotes(v)
 		return nil
-	case group.EdgeAccounts:
-		m.ResetAccounts()
+	case promocode.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
 		return nil
-	case group.EdgeAllowedUsers:
-		m.ResetAllowedUsers()
+	case promocode.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Group edge %s", name)
-}
-
-// PromoCodeMutation represents an operation that mutates the PromoCode nodes in the graph.
-type PromoCodeMutation struct {
-	config
-	op                   Op
-	typ                  string
-	id                   *int64
-	code                 *string
-	bonus_amount         *float64
-	addbonus_amount      *float64
-	max_uses             *int
-	addmax_uses          *int
-	used_count           *int
-	addused_count        *int
-	status               *string
-	expires_at           *time.Time
-	notes                *string
-	created_at           *time.Time
-	updated_at           *time.Time
-	clearedFields        map[string]struct{}
-	usage_records        map[int64]struct{}
-	removedusage_records map[int64]struct{}
-	clearedusage_records bool
-	done                 bool
-	oldValue             func(context.Context) (*PromoCode, error)
-	predicates           []predicate.PromoCode
-}
-
-var _ ent.Mutation = (*PromoCodeMutation)(nil)
-
-// promocodeOption allows management of the mutation configuration using functional options.
-type promocodeOption func(*PromoCodeMutation)
-
-// newPromoCodeMutation creates new mutation for the PromoCode entity.
-func newPromoCodeMutation(c config, op Op, opts ...promocodeOption) *PromoCodeMutation {
-	m := &PromoCodeMutation{
-		config:        c,
-		op:            op,
-		typ:           TypePromoCode,
-		clearedFields: make(map[string]struct{}),
-	}
-	for _, opt := range opts {
-		opt(m)
-	}
-	return m
+	return fmt.Errorf("unknown PromoCode field %s", name)
 }
 
-// withPromoCodeID sets the ID field of the mutation.
-func withPromoCodeID(id int64) promocodeOption {
-	return func(m *PromoCodeMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *PromoCode
-		)
-		m.oldValue = func(ctx context.Context) (*PromoCode, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().PromoCode.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PromoCodeMutation) AddedFields() []string {
+	var fields []string
+	if m.addbonus_amount != nil {
+		fields = append(fields, promocode.FieldBonusAmount)
 	}
-}
-
-// withPromoCode sets the old PromoCode of the mutation.
-func withPromoCode(node *PromoCode) promocodeOption {
-	return func(m *PromoCodeMutation) {
-		m.oldValue = func(context.Context) (*PromoCode, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+	if m.addmax_uses != nil {
+		fields = append(fields, promocode.FieldMaxUses)
 	}
-}
-
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PromoCodeMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
-}
-
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m PromoCodeMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
+	if m.addused_count != nil {
+		fields = append(fields, promocode.FieldUsedCount)
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
+	return fields
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *PromoCodeMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
-		return
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PromoCodeMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case promocode.FieldBonusAmount:
+		return m.AddedBonusAmount()
+	case promocode.FieldMaxUses:
+		return m.AddedMaxUses()
+	case promocode.FieldUsedCount:
+		return m.AddedUsedCount()
 	}
-	return *m.id, true
+	return nil, false
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *PromoCodeMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PromoCodeMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case promocode.FieldBonusAmount:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().PromoCode.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
-}
-
-// SetCode sets the "code" field.
-func (m *PromoCodeMutation) SetCode(s string) {
-	m.code = &s
-}
-
-// Code returns the value of the "code" field in the mutation.
-func (m *PromoCodeMutation) Code() (r string, exists bool) {
-	v := m.code
-	if v == nil {
-		return
+		m.AddBonusAmount(v)
+		return nil
+	case promocode.FieldMaxUses:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxUses(v)
+		return nil
+	case promocode.FieldUsedCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsedCount(v)
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown PromoCode numeric field %s", name)
 }
 
-// OldCode returns the old "code" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldCode(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCode is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCode requires an ID field in the mutation")
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PromoCodeMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(promocode.FieldExpiresAt) {
+		fields = append(fields, promocode.FieldExpiresAt)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCode: %w", err)
+	if m.FieldCleared(promocode.FieldNotes) {
+		fields = append(fields, promocode.FieldNotes)
 	}
-	return oldValue.Code, nil
-}
-
-// ResetCode resets all changes to the "code" field.
-func (m *PromoCodeMutation) ResetCode() {
-	m.code = nil
+	return fields
 }
 
-// SetBonusAmount sets the "bonus_amount" field.
-func (m *PromoCodeMutation) SetBonusAmount(f float64) {
-	m.bonus_amount = &f
-	m.addbonus_amount = nil
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PromoCodeMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// BonusAmount returns the value of the "bonus_amount" field in the mutation.
-func (m *PromoCodeMutation) BonusAmount() (r float64, exists bool) {
-	v := m.bonus_amount
-	if v == nil {
-		return
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PromoCodeMutation) ClearField(name string) error {
+	switch name {
+	case promocode.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case promocode.FieldNotes:
+		m.ClearNotes()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown PromoCode nullable field %s", name)
 }
 
-// OldBonusAmount returns the old "bonus_amount" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldBonusAmount(ctx context.Context) (v float64, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldBonusAmount is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldBonusAmount requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldBonusAmount: %w", err)
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PromoCodeMutation) ResetField(name string) error {
+	switch name {
+	case promocode.FieldCode:
+		m.ResetCode()
+		return nil
+	case promocode.FieldBonusAmount:
+		m.ResetBonusAmount()
+		return nil
+	case promocode.FieldMaxUses:
+		m.ResetMaxUses()
+		return nil
+	case promocode.FieldUsedCount:
+		m.ResetUsedCount()
+		return nil
+	case promocode.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case promocode.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case promocode.FieldNotes:
+		m.ResetNotes()
+		return nil
+	case promocode.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case promocode.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
 	}
-	return oldValue.BonusAmount, nil
+	return fmt.Errorf("unknown PromoCode field %s", name)
 }
 
-// AddBonusAmount adds f to the "bonus_amount" field.
-func (m *PromoCodeMutation) AddBonusAmount(f float64) {
-	if m.addbonus_amount != nil {
-		*m.addbonus_amount += f
-	} else {
-		m.addbonus_amount = &f
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PromoCodeMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.usage_records != nil {
+		edges = append(edges, promocode.EdgeUsageRecords)
 	}
+	return edges
 }
 
-// AddedBonusAmount returns the value that was added to the "bonus_amount" field in this mutation.
-func (m *PromoCodeMutation) AddedBonusAmount() (r float64, exists bool) {
-	v := m.addbonus_amount
-	if v == nil {
-		return
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PromoCodeMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case promocode.EdgeUsageRecords:
+		ids := make([]ent.Value, 0, len(m.usage_records))
+		for id := range m.usage_records {
+			ids = append(ids, id)
+		}
+		return ids
 	}
-	return *v, true
+	return nil
 }
 
-// ResetBonusAmount resets all changes to the "bonus_amount" field.
-func (m *PromoCodeMutation) ResetBonusAmount() {
-	m.bonus_amount = nil
-	m.addbonus_amount = nil
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PromoCodeMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedusage_records != nil {
+		edges = append(edges, promocode.EdgeUsageRecords)
+	}
+	return edges
 }
 
-// SetMaxUses sets the "max_uses" field.
-func (m *PromoCodeMutation) SetMaxUses(i int) {
-	m.max_uses = &i
-	m.addmax_uses = nil
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PromoCodeMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case promocode.EdgeUsageRecords:
+		ids := make([]ent.Value, 0, len(m.removedusage_records))
+		for id := range m.removedusage_records {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
 }
 
-// MaxUses returns the value of the "max_uses" field in the mutation.
-func (m *PromoCodeMutation) MaxUses() (r int, exists bool) {
-	v := m.max_uses
-	if v == nil {
-		return
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PromoCodeMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedusage_records {
+		edges = append(edges, promocode.EdgeUsageRecords)
 	}
-	return *v, true
+	return edges
 }
 
-// OldMaxUses returns the old "max_uses" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldMaxUses(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMaxUses is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMaxUses requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMaxUses: %w", err)
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PromoCodeMutation) EdgeCleared(name string) bool {
+	switch name {
+	case promocode.EdgeUsageRecords:
+		return m.clearedusage_records
 	}
-	return oldValue.MaxUses, nil
+	return false
 }
 
-// AddMaxUses adds i to the "max_uses" field.
-func (m *PromoCodeMutation) AddMaxUses(i int) {
-	if m.addmax_uses != nil {
-		*m.addmax_uses += i
-	} else {
-		m.addmax_uses = &i
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PromoCodeMutation) ClearEdge(name string) error {
+	switch name {
 	}
+	return fmt.Errorf("unknown PromoCode unique edge %s", name)
 }
 
-// AddedMaxUses returns the value that was added to the "max_uses" field in this mutation.
-func (m *PromoCodeMutation) AddedMaxUses() (r int, exists bool) {
-	v := m.addmax_uses
-	if v == nil {
-		return
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PromoCodeMutation) ResetEdge(name string) error {
+	switch name {
+	case promocode.EdgeUsageRecords:
+		m.ResetUsageRecords()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown PromoCode edge %s", name)
 }
 
-// ResetMaxUses resets all changes to the "max_uses" field.
-func (m *PromoCodeMutation) ResetMaxUses() {
-	m.max_uses = nil
-	m.addmax_uses = nil
+// PromoCodeUsageMutation represents an operation that mutates the PromoCodeUsage nodes in the graph.
+type PromoCodeUsageMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *int64
+	bonus_amount      *float64
+	addbonus_amount   *float64
+	used_at           *time.Time
+	clearedFields     map[string]struct{}
+	promo_code        *int64
+	clearedpromo_code bool
+	user              *int64
+	cleareduser       bool
+	done              bool
+	oldValue          func(context.Context) (*PromoCodeUsage, error)
+	predicates        []predicate.PromoCodeUsage
 }
 
-// SetUsedCount sets the "used_count" field.
-func (m *PromoCodeMutation) SetUsedCount(i int) {
-	m.used_count = &i
-	m.addused_count = nil
-}
+var _ ent.Mutation = (*PromoCodeUsageMutation)(nil)
 
-// UsedCount returns the value of the "used_count" field in the mutation.
-func (m *PromoCodeMutation) UsedCount() (r int, exists bool) {
-	v := m.used_count
-	if v == nil {
-		return
+// promocodeusageOption allows management of the mutation configuration using functional options.
+type promocodeusageOption func(*PromoCodeUsageMutation)
+
+// newPromoCodeUsageMutation creates new mutation for the PromoCodeUsage entity.
+func newPromoCodeUsageMutation(c config, op Op, opts ...promocodeusageOption) *PromoCodeUsageMutation {
+	m := &PromoCodeUsageMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePromoCodeUsage,
+		clearedFields: make(map[string]struct{}),
 	}
-	return *v, true
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
-// OldUsedCount returns the old "used_count" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldUsedCount(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsedCount is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsedCount requires an ID field in the mutation")
+// withPromoCodeUsageID sets the ID field of the mutation.
+func withPromoCodeUsageID(id int64) promocodeusageOption {
+	return func(m *PromoCodeUsageMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PromoCodeUsage
+		)
+		m.oldValue = func(ctx context.Context) (*PromoCodeUsage, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PromoCodeUsage.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsedCount: %w", err)
+}
+
+// withPromoCodeUsage sets the old PromoCodeUsage of the mutation.
+func withPromoCodeUsage(node *PromoCodeUsage) promocodeusageOption {
+	return func(m *PromoCodeUsageMutation) {
+		m.oldValue = func(context.Context) (*PromoCodeUsage, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
-	return oldValue.UsedCount, nil
 }
 
-// AddUsedCount adds i to the "used_count" field.
-func (m *PromoCodeMutation) AddUsedCount(i int) {
-	if m.addused_count != nil {
-		*m.addused_count += i
-	} else {
-		m.addused_count = &i
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PromoCodeUsageMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PromoCodeUsageMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// AddedUsedCount returns the value that was added to the "used_count" field in this mutation.
-func (m *PromoCodeMutation) AddedUsedCount() (r int, exists bool) {
-	v := m.addused_count
-	if v == nil {
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PromoCodeUsageMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
 		return
 	}
-	return *v, true
+	return *m.id, true
 }
 
-// ResetUsedCount resets all changes to the "used_count" field.
-func (m *PromoCodeMutation) ResetUsedCount() {
-	m.used_count = nil
-	m.addused_count = nil
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PromoCodeUsageMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PromoCodeUsage.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetStatus sets the "status" field.
-func (m *PromoCodeMutation) SetStatus(s string) {
-	m.status = &s
+// SetPromoCodeID sets the "promo_code_id" field.
+func (m *PromoCodeUsageMutation) SetPromoCodeID(i int64) {
+	m.promo_code = &i
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *PromoCodeMutation) Status() (r string, exists bool) {
-	v := m.status
+// PromoCodeID returns the value of the "promo_code_id" field in the mutation.
+func (m *PromoCodeUsageMutation) PromoCodeID() (r int64, exists bool) {
+	v := m.promo_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// OldPromoCodeID returns the old "promo_code_id" field's value of the PromoCodeUsage entity.
+// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldStatus(ctx context.Context) (v string, err error) {
+func (m *PromoCodeUsageMutation) OldPromoCodeID(ctx context.Context) (v int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldPromoCodeID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldPromoCodeID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldPromoCodeID: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.PromoCodeID, nil
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *PromoCodeMutation) ResetStatus() {
-	m.status = nil
+// ResetPromoCodeID resets all changes to the "promo_code_id" field.
+func (m *PromoCodeUsageMutation) ResetPromoCodeID() {
+	m.promo_code = nil
 }
 
-// SetExpiresAt sets the "expires_at" field.
-func (m *PromoCodeMutation) SetExpiresAt(t time.Time) {
-	m.expires_at = &t
+// SetUserID sets the "user_id" field.
+func (m *PromoCodeUsageMutation) SetUserID(i int64) {
+	m.user = &i
 }
 
-// ExpiresAt returns the value of the "expires_at" field in the mutation.
-func (m *PromoCodeMutation) ExpiresAt() (r time.Time, exists bool) {
-	v := m.expires_at
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *PromoCodeUsageMutation) UserID() (r int64, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiresAt returns the old "expires_at" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the PromoCodeUsage entity.
+// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+func (m *PromoCodeUsageMutation) OldUserID(ctx context.Context) (v int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.ExpiresAt, nil
-}
-
-// ClearExpiresAt clears the value of the "expires_at" field.
-func (m *PromoCodeMutation) ClearExpiresAt() {
-	m.expires_at = nil
-	m.clearedFields[promocode.FieldExpiresAt] = struct{}{}
-}
-
-// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
-func (m *PromoCodeMutation) ExpiresAtCleared() bool {
-	_, ok := m.clearedFields[promocode.FieldExpiresAt]
-	return ok
+	return oldValue.UserID, nil
 }
 
-// ResetExpiresAt resets all changes to the "expires_at" field.
-func (m *PromoCodeMutation) ResetExpiresAt() {
-	m.expires_at = nil
-	delete(m.clearedFields, promocode.FieldExpiresAt)
+// ResetUserID resets all changes to the "user_id" field.
+func (m *PromoCodeUsageMutation) ResetUserID() {
+	m.user = nil
 }
 
-// SetNotes sets the "notes" field.
-func (m *PromoCodeMutation) SetNotes(s string) {
-	m.notes = &s
+// SetBonusAmount sets the "bonus_amount" field.
+func (m *PromoCodeUsageMutation) SetBonusAmount(f float64) {
+	m.bonus_amount = &f
+	m.addbonus_amount = nil
 }
 
-// Notes returns the value of the "notes" field in the mutation.
-func (m *PromoCodeMutation) Notes() (r string, exists bool) {
-	v := m.notes
+// BonusAmount returns the value of the "bonus_amount" field in the mutation.
+func (m *PromoCodeUsageMutation) BonusAmount() (r float64, exists bool) {
+	v := m.bonus_amount
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNotes returns the old "notes" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// OldBonusAmount returns the old "bonus_amount" field's value of the PromoCodeUsage entity.
+// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldNotes(ctx context.Context) (v *string, err error) {
+func (m *PromoCodeUsageMutation) OldBonusAmount(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
+		return v, errors.New("OldBonusAmount is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNotes requires an ID field in the mutation")
+		return v, errors.New("OldBonusAmount requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
+		return v, fmt.Errorf("querying old value for OldBonusAmount: %w", err)
 	}
-	return oldValue.Notes, nil
-}
-
-// ClearNotes clears the value of the "notes" field.
-func (m *PromoCodeMutation) ClearNotes() {
-	m.notes = nil
-	m.clearedFields[promocode.FieldNotes] = struct{}{}
-}
-
-// NotesCleared returns if the "notes" field was cleared in this mutation.
-func (m *PromoCodeMutation) NotesCleared() bool {
-	_, ok := m.clearedFields[promocode.FieldNotes]
-	return ok
-}
-
-// ResetNotes resets all changes to the "notes" field.
-func (m *PromoCodeMutation) ResetNotes() {
-	m.notes = nil
-	delete(m.clearedFields, promocode.FieldNotes)
+	return oldValue.BonusAmount, nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *PromoCodeMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// AddBonusAmount adds f to the "bonus_amount" field.
+func (m *PromoCodeUsageMutation) AddBonusAmount(f float64) {
+	if m.addbonus_amount != nil {
+		*m.addbonus_amount += f
+	} else {
+		m.addbonus_amount = &f
+	}
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *PromoCodeMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// AddedBonusAmount returns the value that was added to the "bonus_amount" field in this mutation.
+func (m *PromoCodeUsageMutation) AddedBonusAmount() (r float64, exists bool) {
+	v := m.addbonus_amount
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
-	}
-	return oldValue.CreatedAt, nil
-}
-
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *PromoCodeMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetBonusAmount resets all changes to the "bonus_amount" field.
+func (m *PromoCodeUsageMutation) ResetBonusAmount() {
+	m.bonus_amount = nil
+	m.addbonus_amount = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *PromoCodeMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetUsedAt sets the "used_at" field.
+func (m *PromoCodeUsageMutation) SetUsedAt(t time.Time) {
+	m.used_at = &t
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *PromoCodeMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// UsedAt returns the value of the "used_at" field in the mutation.
+func (m *PromoCodeUsageMutation) UsedAt() (r time.Time, exists bool) {
+	v := m.used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the PromoCode entity.
-// If the PromoCode object wasn't provided to the builder, the object is fetched from the database.
+// OldUsedAt returns the old "used_at" field's value of the PromoCodeUsage entity.
+// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *PromoCodeUsageMutation) OldUsedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldUsedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsedAt: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
-}
-
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *PromoCodeMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+	return oldValue.UsedAt, nil
 }
 
-// AddUsageRecordIDs adds the "usage_records" edge to the PromoCodeUsage entity by ids.
-func (m *PromoCodeMutation) AddUsageRecordIDs(ids ...int64) {
-	if m.usage_records == nil {
-		m.usage_records = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.usage_records[ids[i]] = struct{}{}
-	}
+// ResetUsedAt resets all changes to the "used_at" field.
+func (m *PromoCodeUsageMutation) ResetUsedAt() {
+	m.used_at = nil
 }
 
-// ClearUsageRecords clears the "usage_records" edge to the PromoCodeUsage entity.
-func (m *PromoCodeMutation) ClearUsageRecords() {
-	m.clearedusage_records = true
+// ClearPromoCode clears the "promo_code" edge to the PromoCode entity.
+func (m *PromoCodeUsageMutation) ClearPromoCode() {
+	m.clearedpromo_code = true
+	m.clearedFields[promocodeusage.FieldPromoCodeID] = struct{}{}
 }
 
-// UsageRecordsCleared reports if the "usage_records" edge to the PromoCodeUsage entity was cleared.
-func (m *PromoCodeMutation) UsageRecordsCleared() bool {
-	return m.clearedusage_records
+// PromoCodeCleared reports if the "promo_code" edge to the PromoCode entity was cleared.
+func (m *PromoCodeUsageMutation) PromoCodeCleared() bool {
+	return m.clearedpromo_code
 }
 
-// RemoveUsageRecordIDs removes the "usage_records" edge to the PromoCodeUsage entity by IDs.
-func (m *PromoCodeMutation) RemoveUsageRecordIDs(ids ...int64) {
-	if m.removedusage_records == nil {
-		m.removedusage_records = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.usage_records, ids[i])
-		m.removedusage_records[ids[i]] = struct{}{}
+// PromoCodeIDs returns the "promo_code" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PromoCodeID instead. It exists only for internal usage by the builders.
+func (m *PromoCodeUsageMutation) PromoCodeIDs() (ids []int64) {
+	if id := m.promo_code; id != nil {
+		ids = append(ids, *id)
 	}
+	return
 }
 
-// RemovedUsageRecords returns the removed IDs of the "usage_records" edge to the PromoCodeUsage entity.
-func (m *PromoCodeMutation) RemovedUsageRecordsIDs() (ids []int64) {
-	for id := range m.removedusage_records {
-		ids = append(ids, id)
-	}
-	return
+// ResetPromoCode resets all changes to the "promo_code" edge.
+func (m *PromoCodeUsageMutation) ResetPromoCode() {
+	m.promo_code = nil
+	m.clearedpromo_code = false
 }
 
-// UsageRecordsIDs returns the "usage_records" edge IDs in the mutation.
-func (m *PromoCodeMutation) UsageRecordsIDs() (ids []int64) {
-	for id := range m.usage_records {
-		ids = append(ids, id)
+// ClearUser clears the "user" edge to the User entity.
+func (m *PromoCodeUsageMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[promocodeusage.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *PromoCodeUsageMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *PromoCodeUsageMutation) UserIDs() (ids []int64) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetUsageRecords resets all changes to the "usage_records" edge.
-func (m *PromoCodeMutation) ResetUsageRecords() {
-	m.usage_records = nil
-	m.clearedusage_records = false
-	m.removedusage_records = nil
+// ResetUser resets all changes to the "user" edge.
+func (m *PromoCodeUsageMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the PromoCodeMutation builder.
-func (m *PromoCodeMutation) Where(ps ...predicate.PromoCode) {
+// Where appends a list predicates to the PromoCodeUsageMutation builder.
+func (m *PromoCodeUsageMutation) Where(ps ...predicate.PromoCodeUsage) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PromoCodeMutation builder. Using this method,
+// WhereP appends storage-level predicates to the PromoCodeUsageMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PromoCodeMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.PromoCode, len(ps))
+func (m *PromoCodeUsageMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PromoCodeUsage, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -10409,51 +12795,36 @@ func (m *PromoCodeMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PromoCodeMutation) Op() Op {
+func (m *PromoCodeUsageMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PromoCodeMutation) SetOp(op Op) {
+func (m *PromoCodeUsageMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (PromoCode).
-func (m *PromoCodeMutation) Type() string {
+// Type returns the node type of this mutation (PromoCodeUsage).
+func (m *PromoCodeUsageMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PromoCodeMutation) Fields() []string {
-	fields := make([]string, 0, 9)
-	if m.code != nil {
-		fields = append(fields, promocode.FieldCode)
-	}
-	if m.bonus_amount != nil {
-		fields = append(fields, promocode.FieldBonusAmount)
-	}
-	if m.max_uses != nil {
-		fields = append(fields, promocode.FieldMaxUses)
-	}
-	if m.used_count != nil {
-		fields = append(fields, promocode.FieldUsedCount)
-	}
-	if m.status != nil {
-		fields = append(fields, promocode.FieldStatus)
-	}
-	if m.expires_at != nil {
-		fields = append(fields, promocode.FieldExpiresAt)
+func (m *PromoCodeUsageMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.promo_code != nil {
+		fields = append(fields, promocodeusage.FieldPromoCodeID)
 	}
-	if m.notes != nil {
-		fields = append(fields, promocode.FieldNotes)
+	if m.user != nil {
+		fields = append(fields, promocodeusage.FieldUserID)
 	}
-	if m.created_at != nil {
-		fields = append(fields, promocode.FieldCreatedAt)
+	if m.bonus_amount != nil {
+		fields = append(fields, promocodeusage.FieldBonusAmount)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, promocode.FieldUpdatedAt)
+	if m.used_at != nil {
+		fields = append(fields, promocodeusage.FieldUsedAt)
 	}
 	return fields
 }
@@ -10461,26 +12832,16 @@ func (m *PromoCodeMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PromoCodeMutation) Field(name string) (ent.Value, bool) {
+func (m *PromoCodeUsageMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case promocode.FieldCode:
-		return m.Code()
-	case promocode.FieldBonusAmount:
+	case promocodeusage.FieldPromoCodeID:
+		return m.PromoCodeID()
+	case promocodeusage.FieldUserID:
+		return m.UserID()
+	case promocodeusage.FieldBonusAmount:
 		return m.BonusAmount()
-	case promocode.FieldMaxUses:
-		return m.MaxUses()
-	case promocode.FieldUsedCount:
-		return m.UsedCount()
-	case promocode.FieldStatus:
-		return m.Status()
-	case promocode.FieldExpiresAt:
-		return m.ExpiresAt()
-	case promocode.FieldNotes:
-		return m.Notes()
-	case promocode.FieldCreatedAt:
-		return m.CreatedAt()
-	case promocode.FieldUpdatedAt:
-		return m.UpdatedAt()
+	case promocodeusage.FieldUsedAt:
+		return m.UsedAt()
 	}
 	return nil, false
 }
@@ -10488,114 +12849,63 @@ func (m *PromoCodeMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PromoCodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *PromoCodeUsageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case promocode.FieldCode:
-		return m.OldCode(ctx)
-	case promocode.FieldBonusAmount:
+	case promocodeusage.FieldPromoCodeID:
+		return m.OldPromoCodeID(ctx)
+	case promocodeusage.FieldUserID:
+		return m.OldUserID(ctx)
+	case promocodeusage.FieldBonusAmount:
 		return m.OldBonusAmount(ctx)
-	case promocode.FieldMaxUses:
-		return m.OldMaxUses(ctx)
-	case promocode.FieldUsedCount:
-		return m.OldUsedCount(ctx)
-	case promocode.FieldStatus:
-		return m.OldStatus(ctx)
-	case promocode.FieldExpiresAt:
-		return m.OldExpiresAt(ctx)
-	case promocode.FieldNotes:
-		return m.OldNotes(ctx)
-	case promocode.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case promocode.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
+	case promocodeusage.FieldUsedAt:
+		return m.OldUsedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown PromoCode field %s", name)
+	return nil, fmt.Errorf("unknown PromoCodeUsage field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PromoCodeMutation) SetField(name string, value ent.Value) error {
+func (m *PromoCodeUsageMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case promocode.FieldCode:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCode(v)
-		return nil
-	case promocode.FieldBonusAmount:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetBonusAmount(v)
-		return nil
-	case promocode.FieldMaxUses:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetMaxUses(v)
-		return nil
-	case promocode.FieldUsedCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUsedCount(v)
-		return nil
-	case promocode.FieldStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
-		return nil
-	case promocode.FieldExpiresAt:
-		v, ok := value.(time.Time)
+	case promocodeusage.FieldPromoCodeID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetExpiresAt(v)
+		m.SetPromoCodeID(v)
 		return nil
-	case promocode.FieldNotes:
-		v, ok := value.(string)
+	case promocodeusage.FieldUserID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetNotes(v)
+		m.SetUserID(v)
 		return nil
-	case promocode.FieldCreatedAt:
-		v, ok := value.(time.Time)
+	case promocodeusage.FieldBonusAmount:
+		v, ok := value.(float64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetBonusAmount(v)
 		return nil
-	case promocode.FieldUpdatedAt:
+	case promocodeusage.FieldUsedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetUsedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCode field %s", name)
+	return fmt.Errorf("unknown PromoCodeUsage field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PromoCodeMutation) AddedFields() []string {
+func (m *PromoCodeUsageMutation) AddedFields() []string {
 	var fields []string
 	if m.addbonus_amount != nil {
-		fields = append(fields, promocode.FieldBonusAmount)
-	}
-	if m.addmax_uses != nil {
-		fields = append(fields, promocode.FieldMaxUses)
-	}
-	if m.addused_count != nil {
-		fields = append(fields, promocode.FieldUsedCount)
+		fields = append(fields, promocodeusage.FieldBonusAmount)
 	}
 	return fields
 }
@@ -10603,14 +12913,10 @@ func (m *PromoCodeMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PromoCodeMutation) AddedField(name string) (ent.Value, bool) {
+func (m *PromoCodeUsageMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case promocode.FieldBonusAmount:
-		return m.AddedBonusAmount()
-	case promocode.FieldMaxUses:
-		return m.AddedMaxUses()
-	case promocode.FieldUsedCount:
-		return m.AddedUsedCount()
+	case promocodeusage.FieldBonusAmount:
+		return m.AddedBonusAmount()
 	}
 	return nil, false
 }
@@ -10618,530 +12924,756 @@ func (m *PromoCodeMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PromoCodeMutation) AddField(name string, value ent.Value) error {
+func (m *PromoCodeUsageMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case promocode.FieldBonusAmount:
+	case promocodeusage.FieldBonusAmount:
 		v, ok := value.(float64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.AddBonusAmount(v)
 		return nil
-	case promocode.FieldMaxUses:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddMaxUses(v)
-		return nil
-	case promocode.FieldUsedCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddUsedCount(v)
-		return nil
 	}
-	return fmt.Errorf("unknown PromoCode numeric field %s", name)
+	return fmt.Errorf("unknown PromoCodeUsage numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PromoCodeMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(promocode.FieldExpiresAt) {
-		fields = append(fields, promocode.FieldExpiresAt)
-	}
-	if m.FieldCleared(promocode.FieldNotes) {
-		fields = append(fields, promocode.FieldNotes)
-	}
-	return fields
+func (m *PromoCodeUsageMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PromoCodeMutation) FieldCleared(name string) bool {
+func (m *PromoCodeUsageMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PromoCodeMutation) ClearField(name string) error {
-	switch name {
-	case promocode.FieldExpiresAt:
-		m.ClearExpiresAt()
-		return nil
-	case promocode.FieldNotes:
-		m.ClearNotes()
-		return nil
-	}
-	return fmt.Errorf("unknown PromoCode nullable field %s", name)
+func (m *PromoCodeUsageMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown PromoCodeUsage nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PromoCodeMutation) ResetField(name string) error {
+func (m *PromoCodeUsageMutation) ResetField(name string) error {
 	switch name {
-	case promocode.FieldCode:
-		m.ResetCode()
-		return nil
-	case promocode.FieldBonusAmount:
-		m.ResetBonusAmount()
+	case promocodeusage.FieldPromoCodeID:
+		m.ResetPromoCodeID()
 		return nil
-	case promocode.FieldMaxUses:
-		m.ResetMaxUses()
+	case promocodeusage.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case promocode.FieldUsedCount:
-		m.ResetUsedCount()
+	case promocodeusage.FieldBonusAmount:
+		m.ResetBonusAmount()
 		return nil
-	case promocode.FieldStatus:
-		m.ResetStatus()
+	case promocodeusage.FieldUsedAt:
+		m.ResetUsedAt()
 		return nil
-	case promocode.FieldExpiresAt:
-		m.ResetExpiresAt()
+	}
+	return fmt.Errorf("unknown PromoCodeUsage field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PromoCodeUsageMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.promo_code != nil {
+		edges = append(edges, promocodeusage.EdgePromoCode)
+	}
+	if m.user != nil {
+		edges = append(edges, promocodeusage.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PromoCodeUsageMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case promocodeusage.EdgePromoCode:
+		if id := m.promo_code; id != nil {
+			return []ent.Value{*id}
+		}
+	case promocodeusage.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PromoCodeUsageMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PromoCodeUsageMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PromoCodeUsageMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedpromo_code {
+		edges = append(edges, promocodeusage.EdgePromoCode)
+	}
+	if m.cleareduser {
+		edges = append(edges, promocodeusage.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PromoCodeUsageMutation) EdgeCleared(name string) bool {
+	switch name {
+	case promocodeusage.EdgePromoCode:
+		return m.clearedpromo_code
+	case promocodeusage.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PromoCodeUsageMutation) ClearEdge(name string) error {
+	switch name {
+	case promocodeusage.EdgePromoCode:
+		m.ClearPromoCode()
 		return nil
-	case promocode.FieldNotes:
-		m.ResetNotes()
+	case promocodeusage.EdgeUser:
+		m.ClearUser()
 		return nil
-	case promocode.FieldCreatedAt:
-		m.ResetCreatedAt()
+	}
+	return fmt.Errorf("unknown PromoCodeUsage unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PromoCodeUsageMutation) ResetEdge(name string) error {
+	switch name {
+	case promocodeusage.EdgePromoCode:
+		m.ResetPromoCode()
 		return nil
-	case promocode.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case promocodeusage.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCode field %s", name)
+	return fmt.Errorf("unknown PromoCodeUsage edge %s", name)
+}
+
+// ProxyMutation represents an operation that mutates the Proxy nodes in the graph.
+type ProxyMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *int64
+	created_at      *time.Time
+	updated_at      *time.Time
+	deleted_at      *time.Time
+	name            *string
+	protocol        *string
+	host            *string
+	port            *int
+	addport         *int
+	username        *string
+	password        *string
+	status          *string
+	clearedFields   map[string]struct{}
+	accounts        map[int64]struct{}
+	removedaccounts map[int64]struct{}
+	clearedaccounts bool
+	done            bool
+	oldValue        func(context.Context) (*Proxy, error)
+	predicates      []predicate.Proxy
+}
+
+var _ ent.Mutation = (*ProxyMutation)(nil)
+
+// proxyOption allows management of the mutation configuration using functional options.
+type proxyOption func(*ProxyMutation)
+
+// newProxyMutation creates new mutation for the Proxy entity.
+func newProxyMutation(c config, op Op, opts ...proxyOption) *ProxyMutation {
+	m := &ProxyMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeProxy,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withProxyID sets the ID field of the mutation.
+func withProxyID(id int64) proxyOption {
+	return func(m *ProxyMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Proxy
+		)
+		m.oldValue = func(ctx context.Context) (*Proxy, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Proxy.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withProxy sets the old Proxy of the mutation.
+func withProxy(node *Proxy) proxyOption {
+	return func(m *ProxyMutation) {
+		m.oldValue = func(context.Context) (*Proxy, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ProxyMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ProxyMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PromoCodeMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.usage_records != nil {
-		edges = append(edges, promocode.EdgeUsageRecords)
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ProxyMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
 	}
-	return edges
+	return *m.id, true
 }
 
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *PromoCodeMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case promocode.EdgeUsageRecords:
-		ids := make([]ent.Value, 0, len(m.usage_records))
-		for id := range m.usage_records {
-			ids = append(ids, id)
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ProxyMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
 		}
-		return ids
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Proxy.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
-	return nil
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PromoCodeMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.removedusage_records != nil {
-		edges = append(edges, promocode.EdgeUsageRecords)
+// SetCreatedAt sets the "created_at" field.
+func (m *ProxyMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ProxyMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
-	return edges
+	return *v, true
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *PromoCodeMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case promocode.EdgeUsageRecords:
-		ids := make([]ent.Value, 0, len(m.removedusage_records))
-		for id := range m.removedusage_records {
-			ids = append(ids, id)
-		}
-		return ids
+// OldCreatedAt returns the old "created_at" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
-	return nil
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PromoCodeMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedusage_records {
-		edges = append(edges, promocode.EdgeUsageRecords)
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ProxyMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ProxyMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ProxyMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
 	}
-	return edges
+	return *v, true
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *PromoCodeMutation) EdgeCleared(name string) bool {
-	switch name {
-	case promocode.EdgeUsageRecords:
-		return m.clearedusage_records
+// OldUpdatedAt returns the old "updated_at" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
-	return false
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
 }
 
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *PromoCodeMutation) ClearEdge(name string) error {
-	switch name {
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ProxyMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (m *ProxyMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
+}
+
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *ProxyMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown PromoCode unique edge %s", name)
+	return *v, true
 }
 
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *PromoCodeMutation) ResetEdge(name string) error {
-	switch name {
-	case promocode.EdgeUsageRecords:
-		m.ResetUsageRecords()
-		return nil
+// OldDeletedAt returns the old "deleted_at" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
-	return fmt.Errorf("unknown PromoCode edge %s", name)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
 }
 
-// PromoCodeUsageMutation represents an operation that mutates the PromoCodeUsage nodes in the graph.
-type PromoCodeUsageMutation struct {
-	config
-	op                Op
-	typ               string
-	id                *int64
-	bonus_amount      *float64
-	addbonus_amount   *float64
-	used_at           *time.Time
-	clearedFields     map[string]struct{}
-	promo_code        *int64
-	clearedpromo_code bool
-	user              *int64
-	cleareduser       bool
-	done              bool
-	oldValue          func(context.Context) (*PromoCodeUsage, error)
-	predicates        []predicate.PromoCodeUsage
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *ProxyMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[proxy.FieldDeletedAt] = struct{}{}
 }
 
-var _ ent.Mutation = (*PromoCodeUsageMutation)(nil)
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *ProxyMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[proxy.FieldDeletedAt]
+	return ok
+}
 
-// promocodeusageOption allows management of the mutation configuration using functional options.
-type promocodeusageOption func(*PromoCodeUsageMutation)
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *ProxyMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, proxy.FieldDeletedAt)
+}
 
-// newPromoCodeUsageMutation creates new mutation for the PromoCodeUsage entity.
-func newPromoCodeUsageMutation(c config, op Op, opts ...promocodeusageOption) *PromoCodeUsageMutation {
-	m := &PromoCodeUsageMutation{
-		config:        c,
-		op:            op,
-		typ:           TypePromoCodeUsage,
-		clearedFields: make(map[string]struct{}),
-	}
-	for _, opt := range opts {
-		opt(m)
+// SetName sets the "name" field.
+func (m *ProxyMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ProxyMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
 	}
-	return m
+	return *v, true
 }
 
-// withPromoCodeUsageID sets the ID field of the mutation.
-func withPromoCodeUsageID(id int64) promocodeusageOption {
-	return func(m *PromoCodeUsageMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *PromoCodeUsage
-		)
-		m.oldValue = func(ctx context.Context) (*PromoCodeUsage, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().PromoCodeUsage.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
+// OldName returns the old "name" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
+	return oldValue.Name, nil
 }
 
-// withPromoCodeUsage sets the old PromoCodeUsage of the mutation.
-func withPromoCodeUsage(node *PromoCodeUsage) promocodeusageOption {
-	return func(m *PromoCodeUsageMutation) {
-		m.oldValue = func(context.Context) (*PromoCodeUsage, error) {
-			return node, nil
-		}
-		m.id = &node.ID
-	}
+// ResetName resets all changes to the "name" field.
+func (m *ProxyMutation) ResetName() {
+	m.name = nil
 }
 
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PromoCodeUsageMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
+// SetProtocol sets the "protocol" field.
+func (m *ProxyMutation) SetProtocol(s string) {
+	m.protocol = &s
 }
 
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m PromoCodeUsageMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
+// Protocol returns the value of the "protocol" field in the mutation.
+func (m *ProxyMutation) Protocol() (r string, exists bool) {
+	v := m.protocol
+	if v == nil {
+		return
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
+	return *v, true
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *PromoCodeUsageMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
-		return
+// OldProtocol returns the old "protocol" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldProtocol(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProtocol is only allowed on UpdateOne operations")
 	}
-	return *m.id, true
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProtocol requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProtocol: %w", err)
+	}
+	return oldValue.Protocol, nil
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *PromoCodeUsageMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().PromoCodeUsage.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
+// ResetProtocol resets all changes to the "protocol" field.
+func (m *ProxyMutation) ResetProtocol() {
+	m.protocol = nil
 }
 
-// SetPromoCodeID sets the "promo_code_id" field.
-func (m *PromoCodeUsageMutation) SetPromoCodeID(i int64) {
-	m.promo_code = &i
+// SetHost sets the "host" field.
+func (m *ProxyMutation) SetHost(s string) {
+	m.host = &s
 }
 
-// PromoCodeID returns the value of the "promo_code_id" field in the mutation.
-func (m *PromoCodeUsageMutation) PromoCodeID() (r int64, exists bool) {
-	v := m.promo_code
+// Host returns the value of the "host" field in the mutation.
+func (m *ProxyMutation) Host() (r string, exists bool) {
+	v := m.host
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPromoCodeID returns the old "promo_code_id" field's value of the PromoCodeUsage entity.
-// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
+// OldHost returns the old "host" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeUsageMutation) OldPromoCodeID(ctx context.Context) (v int64, err error) {
+func (m *ProxyMutation) OldHost(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPromoCodeID is only allowed on UpdateOne operations")
+		return v, errors.New("OldHost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPromoCodeID requires an ID field in the mutation")
+		return v, errors.New("OldHost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPromoCodeID: %w", err)
+		return v, fmt.Errorf("querying old value for OldHost: %w", err)
 	}
-	return oldValue.PromoCodeID, nil
+	return oldValue.Host, nil
 }
 
-// ResetPromoCodeID resets all changes to the "promo_code_id" field.
-func (m *PromoCodeUsageMutation) ResetPromoCodeID() {
-	m.promo_code = nil
+// ResetHost resets all changes to the "host" field.
+func (m *ProxyMutation) ResetHost() {
+	m.host = nil
 }
 
-// SetUserID sets the "user_id" field.
-func (m *PromoCodeUsageMutation) SetUserID(i int64) {
-	m.user = &i
+// SetPort sets the "port" field.
+func (m *ProxyMutation) SetPort(i int) {
+	m.port = &i
+	m.addport = nil
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *PromoCodeUsageMutation) UserID() (r int64, exists bool) {
-	v := m.user
+// Port returns the value of the "port" field in the mutation.
+func (m *ProxyMutation) Port() (r int, exists bool) {
+	v := m.port
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the PromoCodeUsage entity.
-// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
+// OldPort returns the old "port" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeUsageMutation) OldUserID(ctx context.Context) (v int64, err error) {
+func (m *ProxyMutation) OldPort(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldPort is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldPort requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldPort: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.Port, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *PromoCodeUsageMutation) ResetUserID() {
-	m.user = nil
+// AddPort adds i to the "port" field.
+func (m *ProxyMutation) AddPort(i int) {
+	if m.addport != nil {
+		*m.addport += i
+	} else {
+		m.addport = &i
+	}
 }
 
-// SetBonusAmount sets the "bonus_amount" field.
-func (m *PromoCodeUsageMutation) SetBonusAmount(f float64) {
-	m.bonus_amount = &f
-	m.addbonus_amount = nil
+// AddedPort returns the value that was added to the "port" field in this mutation.
+func (m *ProxyMutation) AddedPort() (r int, exists bool) {
+	v := m.addport
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// BonusAmount returns the value of the "bonus_amount" field in the mutation.
-func (m *PromoCodeUsageMutation) BonusAmount() (r float64, exists bool) {
-	v := m.bonus_amount
+// ResetPort resets all changes to the "port" field.
+func (m *ProxyMutation) ResetPort() {
+	m.port = nil
+	m.addport = nil
+}
+
+// SetUsername sets the "username" field.
+func (m *ProxyMutation) SetUsername(s string) {
+	m.username = &s
+}
+
+// Username returns the value of the "username" field in the mutation.
+func (m *ProxyMutation) Username() (r string, exists bool) {
+	v := m.username
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldBonusAmount returns the old "bonus_amount" field's value of the PromoCodeUsage entity.
-// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
+// OldUsername returns the old "username" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeUsageMutation) OldBonusAmount(ctx context.Context) (v float64, err error) {
+func (m *ProxyMutation) OldUsername(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldBonusAmount is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldBonusAmount requires an ID field in the mutation")
+		return v, errors.New("OldUsername requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldBonusAmount: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
 	}
-	return oldValue.BonusAmount, nil
+	return oldValue.Username, nil
 }
 
-// AddBonusAmount adds f to the "bonus_amount" field.
-func (m *PromoCodeUsageMutation) AddBonusAmount(f float64) {
-	if m.addbonus_amount != nil {
-		*m.addbonus_amount += f
-	} else {
-		m.addbonus_amount = &f
-	}
+// ClearUsername clears the value of the "username" field.
+func (m *ProxyMutation) ClearUsername() {
+	m.username = nil
+	m.clearedFields[proxy.FieldUsername] = struct{}{}
 }
 
-// AddedBonusAmount returns the value that was added to the "bonus_amount" field in this mutation.
-func (m *PromoCodeUsageMutation) AddedBonusAmount() (r float64, exists bool) {
-	v := m.addbonus_amount
+// UsernameCleared returns if the "username" field was cleared in this mutation.
+func (m *ProxyMutation) UsernameCleared() bool {
+	_, ok := m.clearedFields[proxy.FieldUsername]
+	return ok
+}
+
+// ResetUsername resets all changes to the "username" field.
+func (m *ProxyMutation) ResetUsername() {
+	m.username = nil
+	delete(m.clearedFields, proxy.FieldUsername)
+}
+
+// SetPassword sets the "password" field.
+func (m *ProxyMutation) SetPassword(s string) {
+	m.password = &s
+}
+
+// Password returns the value of the "password" field in the mutation.
+func (m *ProxyMutation) Password() (r string, exists bool) {
+	v := m.password
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetBonusAmount resets all changes to the "bonus_amount" field.
-func (m *PromoCodeUsageMutation) ResetBonusAmount() {
-	m.bonus_amount = nil
-	m.addbonus_amount = nil
+// OldPassword returns the old "password" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProxyMutation) OldPassword(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPassword requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+	}
+	return oldValue.Password, nil
+}
+
+// ClearPassword clears the value of the "password" field.
+func (m *ProxyMutation) ClearPassword() {
+	m.password = nil
+	m.clearedFields[proxy.FieldPassword] = struct{}{}
+}
+
+// PasswordCleared returns if the "password" field was cleared in this mutation.
+func (m *ProxyMutation) PasswordCleared() bool {
+	_, ok := m.clearedFields[proxy.FieldPassword]
+	return ok
+}
+
+// ResetPassword resets all changes to the "password" field.
+func (m *ProxyMutation) ResetPassword() {
+	m.password = nil
+	delete(m.clearedFields, proxy.FieldPassword)
 }
 
-// SetUsedAt sets the "used_at" field.
-func (m *PromoCodeUsageMutation) SetUsedAt(t time.Time) {
-	m.used_at = &t
+// SetStatus sets the "status" field.
+func (m *ProxyMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// UsedAt returns the value of the "used_at" field in the mutation.
-func (m *PromoCodeUsageMutation) UsedAt() (r time.Time, exists bool) {
-	v := m.used_at
+// Status returns the value of the "status" field in the mutation.
+func (m *ProxyMutation) Status() (r string, exists bool) {
+	v := m.status
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUsedAt returns the old "used_at" field's value of the PromoCodeUsage entity.
-// If the PromoCodeUsage object wasn't provided to the builder, the object is fetched from the database.
+// OldStatus returns the old "status" field's value of the Proxy entity.
+// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PromoCodeUsageMutation) OldUsedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ProxyMutation) OldStatus(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsedAt requires an ID field in the mutation")
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return oldValue.UsedAt, nil
-}
-
-// ResetUsedAt resets all changes to the "used_at" field.
-func (m *PromoCodeUsageMutation) ResetUsedAt() {
-	m.used_at = nil
+	return oldValue.Status, nil
 }
 
-// ClearPromoCode clears the "promo_code" edge to the PromoCode entity.
-func (m *PromoCodeUsageMutation) ClearPromoCode() {
-	m.clearedpromo_code = true
-	m.clearedFields[promocodeusage.FieldPromoCodeID] = struct{}{}
+// ResetStatus resets all changes to the "status" field.
+func (m *ProxyMutation) ResetStatus() {
+	m.status = nil
 }
 
-// PromoCodeCleared reports if the "promo_code" edge to the PromoCode entity was cleared.
-func (m *PromoCodeUsageMutation) PromoCodeCleared() bool {
-	return m.clearedpromo_code
+// AddAccountIDs adds the "accounts" edge to the Account entity by ids.
+func (m *ProxyMutation) AddAccountIDs(ids ...int64) {
+	if m.accounts == nil {
+		m.accounts = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.accounts[ids[i]] = struct{}{}
+	}
 }
 
-// PromoCodeIDs returns the "promo_code" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// PromoCodeID instead. It exists only for internal usage by the builders.
-func (m *PromoCodeUsageMutation) PromoCodeIDs() (ids []int64) {
-	if id := m.promo_code; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// ClearAccounts clears the "accounts" edge to the Account entity.
+func (m *ProxyMutation) ClearAccounts() {
+	m.clearedaccounts = true
 }
 
-// ResetPromoCode resets all changes to the "promo_code" edge.
-func (m *PromoCodeUsageMutation) ResetPromoCode() {
-	m.promo_code = nil
-	m.clearedpromo_code = false
+// AccountsCleared reports if the "accounts" edge to the Account entity was cleared.
+func (m *ProxyMutation) AccountsCleared() bool {
+	return m.clearedaccounts
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *PromoCodeUsageMutation) ClearUser() {
-	m.cleareduser = true
-	m.clearedFields[promocodeusage.FieldUserID] = struct{}{}
+// RemoveAccountIDs removes the "accounts" edge to the Account entity by IDs.
+func (m *ProxyMutation) RemoveAccountIDs(ids ...int64) {
+	if m.removedaccounts == nil {
+		m.removedaccounts = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.accounts, ids[i])
+		m.removedaccounts[ids[i]] = struct{}{}
+	}
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *PromoCodeUsageMutation) UserCleared() bool {
-	return m.cleareduser
+// RemovedAccounts returns the removed IDs of the "accounts" edge to the Account entity.
+func (m *ProxyMutation) RemovedAccountsIDs() (ids []int64) {
+	for id := range m.removedaccounts {
+		ids = append(ids, id)
+	}
+	return
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *PromoCodeUsageMutation) UserIDs() (ids []int64) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// AccountsIDs returns the "accounts" edge IDs in the mutation.
+func (m *ProxyMutation) AccountsIDs() (ids []int64) {
+	for id := range m.accounts {
+		ids = append(ids, id)
 	}
 	return
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *PromoCodeUsageMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// ResetAccounts resets all changes to the "accounts" edge.
+func (m *ProxyMutation) ResetAccounts() {
+	m.accounts = nil
+	m.clearedaccounts = false
+	m.removedaccounts = nil
 }
 
-// Where appends a list predicates to the PromoCodeUsageMutation builder.
-func (m *PromoCodeUsageMutation) Where(ps ...predicate.PromoCodeUsage) {
+// Where appends a list predicates to the ProxyMutation builder.
+func (m *ProxyMutation) Where(ps ...predicate.Proxy) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PromoCodeUsageMutation builder. Using this method,
+// WhereP appends storage-level predicates to the ProxyMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PromoCodeUsageMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.PromoCodeUsage, len(ps))
+func (m *ProxyMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Proxy, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -11149,36 +13681,54 @@ func (m *PromoCodeUsageMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PromoCodeUsageMutation) Op() Op {
+func (m *ProxyMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PromoCodeUsageMutation) SetOp(op Op) {
+func (m *ProxyMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (PromoCodeUsage).
-func (m *PromoCodeUsageMutation) Type() string {
+// Type returns the node type of this mutation (Proxy).
+func (m *ProxyMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PromoCodeUsageMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.promo_code != nil {
-		fields = append(fields, promocodeusage.FieldPromoCodeID)
+func (m *ProxyMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.created_at != nil {
+		fields = append(fields, proxy.FieldCreatedAt)
 	}
-	if m.user != nil {
-		fields = append(fields, promocodeusage.FieldUserID)
+	if m.updated_at != nil {
+		fields = append(fields, proxy.FieldUpdatedAt)
 	}
-	if m.bonus_amount != nil {
-		fields = append(fields, promocodeusage.FieldBonusAmount)
+	if m.deleted_at != nil {
+		fields = append(fields, proxy.FieldDeletedAt)
 	}
-	if m.used_at != nil {
-		fields = append(fields, promocodeusage.FieldUsedAt)
+	if m.name != nil {
+		fields = append(fields, proxy.FieldName)
+	}
+	if m.protocol != nil {
+		fields = append(fields, proxy.FieldProtocol)
+	}
+	if m.host != nil {
+		fields = append(fields, proxy.FieldHost)
+	}
+	if m.port != nil {
+		fields = append(fields, proxy.FieldPort)
+	}
+	if m.username != nil {
+		fields = append(fields, proxy.FieldUsername)
+	}
+	if m.password != nil {
+		fields = append(fields, proxy.FieldPassword)
+	}
+	if m.status != nil {
+		fields = append(fields, proxy.FieldStatus)
 	}
 	return fields
 }
@@ -11186,16 +13736,28 @@ func (m *PromoCodeUsageMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PromoCodeUsageMutation) Field(name string) (ent.Value, bool) {
+func (m *ProxyMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case promocodeusage.FieldPromoCodeID:
-		return m.PromoCodeID()
-	case promocodeusage.FieldUserID:
-		return m.UserID()
-	case promocodeusage.FieldBonusAmount:
-		return m.BonusAmount()
-	case promocodeusage.FieldUsedAt:
-		return m.UsedAt()
+	case proxy.FieldCreatedAt:
+		return m.CreatedAt()
+	case proxy.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case proxy.FieldDeletedAt:
+		return m.DeletedAt()
+	case proxy.FieldName:
+		return m.Name()
+	case proxy.FieldProtocol:
+		return m.Protocol()
+	case proxy.FieldHost:
+		return m.Host()
+	case proxy.FieldPort:
+		return m.Port()
+	case proxy.FieldUsername:
+		return m.Username()
+	case proxy.FieldPassword:
+		return m.Password()
+	case proxy.FieldStatus:
+		return m.Status()
 	}
 	return nil, false
 }
@@ -11203,63 +13765,117 @@ func (m *PromoCodeUsageMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PromoCodeUsageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ProxyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case promocodeusage.FieldPromoCodeID:
-		return m.OldPromoCodeID(ctx)
-	case promocodeusage.FieldUserID:
-		return m.OldUserID(ctx)
-	case promocodeusage.FieldBonusAmount:
-		return m.OldBonusAmount(ctx)
-	case promocodeusage.FieldUsedAt:
-		return m.OldUsedAt(ctx)
+	case proxy.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case proxy.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case proxy.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case proxy.FieldName:
+		return m.OldName(ctx)
+	case proxy.FieldProtocol:
+		return m.OldProtocol(ctx)
+	case proxy.FieldHost:
+		return m.OldHost(ctx)
+	case proxy.FieldPort:
+		return m.OldPort(ctx)
+	case proxy.FieldUsername:
+		return m.OldUsername(ctx)
+	case proxy.FieldPassword:
+		return m.OldPassword(ctx)
+	case proxy.FieldStatus:
+		return m.OldStatus(ctx)
 	}
-	return nil, fmt.Errorf("unknown PromoCodeUsage field %s", name)
+	return nil, fmt.Errorf("unknown Proxy field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PromoCodeUsageMutation) SetField(name string, value ent.Value) error {
+func (m *ProxyMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case promocodeusage.FieldPromoCodeID:
-		v, ok := value.(int64)
+	case proxy.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case proxy.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case proxy.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case proxy.FieldName:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPromoCodeID(v)
+		m.SetName(v)
 		return nil
-	case promocodeusage.FieldUserID:
-		v, ok := value.(int64)
+	case proxy.FieldProtocol:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUserID(v)
+		m.SetProtocol(v)
 		return nil
-	case promocodeusage.FieldBonusAmount:
-		v, ok := value.(float64)
+	case proxy.FieldHost:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetBonusAmount(v)
+		m.SetHost(v)
 		return nil
-	case promocodeusage.FieldUsedAt:
-		v, ok := value.(time.Time)
+	case proxy.FieldPort:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUsedAt(v)
+		m.SetPort(v)
+		return nil
+	case proxy.FieldUsername:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsername(v)
+		return nil
+	case proxy.FieldPassword:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPassword(v)
+		return nil
+	case proxy.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCodeUsage field %s", name)
+	return fmt.Errorf("unknown Proxy field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PromoCodeUsageMutation) AddedFields() []string {
+func (m *ProxyMutation) AddedFields() []string {
 	var fields []string
-	if m.addbonus_amount != nil {
-		fields = append(fields, promocodeusage.FieldBonusAmount)
+	if m.addport != nil {
+		fields = append(fields, proxy.FieldPort)
 	}
 	return fields
 }
@@ -11267,10 +13883,10 @@ func (m *PromoCodeUsageMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PromoCodeUsageMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ProxyMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case promocodeusage.FieldBonusAmount:
-		return m.AddedBonusAmount()
+	case proxy.FieldPort:
+		return m.AddedPort()
 	}
 	return nil, false
 }
@@ -11278,187 +13894,218 @@ func (m *PromoCodeUsageMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PromoCodeUsageMutation) AddField(name string, value ent.Value) error {
+func (m *ProxyMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case promocodeusage.FieldBonusAmount:
-		v, ok := value.(float64)
+	case proxy.FieldPort:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddBonusAmount(v)
+		m.AddPort(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCodeUsage numeric field %s", name)
+	return fmt.Errorf("unknown Proxy numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PromoCodeUsageMutation) ClearedFields() []string {
-	return nil
+func (m *ProxyMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(proxy.FieldDeletedAt) {
+		fields = append(fields, proxy.FieldDeletedAt)
+	}
+	if m.FieldCleared(proxy.FieldUsername) {
+		fields = append(fields, proxy.FieldUsername)
+	}
+	if m.FieldCleared(proxy.FieldPassword) {
+		fields = append(fields, proxy.FieldPassword)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PromoCodeUsageMutation) FieldCleared(name string) bool {
+func (m *ProxyMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PromoCodeUsageMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown PromoCodeUsage nullable field %s", name)
+func (m *ProxyMutation) ClearField(name string) error {
+	switch name {
+	case proxy.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case proxy.FieldUsername:
+		m.ClearUsername()
+		return nil
+	case proxy.FieldPassword:
+		m.ClearPassword()
+		return nil
+	}
+	return fmt.Errorf("unknown Proxy nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PromoCodeUsageMutation) ResetField(name string) error {
+func (m *ProxyMutation) ResetField(name string) error {
 	switch name {
-	case promocodeusage.FieldPromoCodeID:
-		m.ResetPromoCodeID()
+	case proxy.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
-	case promocodeusage.FieldUserID:
-		m.ResetUserID()
+	case proxy.FieldUpdatedAt:
+		m.ResetUpdatedAt()
 		return nil
-	case promocodeusage.FieldBonusAmount:
-		m.ResetBonusAmount()
+	case proxy.FieldDeletedAt:
+		m.ResetDeletedAt()
 		return nil
-	case promocodeusage.FieldUsedAt:
-		m.ResetUsedAt()
+	case proxy.FieldName:
+		m.ResetName()
+		return nil
+	case proxy.FieldProtocol:
+		m.ResetProtocol()
+		return nil
+	case proxy.FieldHost:
+		m.ResetHost()
+		return nil
+	case proxy.FieldPort:
+		m.ResetPort()
+		return nil
+	case proxy.FieldUsername:
+		m.ResetUsername()
+		return nil
+	case proxy.FieldPassword:
+		m.ResetPassword()
+		return nil
+	case proxy.FieldStatus:
+		m.ResetStatus()
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCodeUsage field %s", name)
+	return fmt.Errorf("unknown Proxy field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PromoCodeUsageMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.promo_code != nil {
-		edges = append(edges, promocodeusage.EdgePromoCode)
-	}
-	if m.user != nil {
-		edges = append(edges, promocodeusage.EdgeUser)
+func (m *ProxyMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.accounts != nil {
+		edges = append(edges, proxy.EdgeAccounts)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PromoCodeUsageMutation) AddedIDs(name string) []ent.Value {
+func (m *ProxyMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case promocodeusage.EdgePromoCode:
-		if id := m.promo_code; id != nil {
-			return []ent.Value{*id}
-		}
-	case promocodeusage.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
+	case proxy.EdgeAccounts:
+		ids := make([]ent.Value, 0, len(m.accounts))
+		for id := range m.accounts {
+			ids = append(ids, id)
 		}
+		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PromoCodeUsageMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *ProxyMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedaccounts != nil {
+		edges = append(edges, proxy.EdgeAccounts)
+	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PromoCodeUsageMutation) RemovedIDs(name string) []ent.Value {
+func (m *ProxyMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case proxy.EdgeAccounts:
+		ids := make([]ent.Value, 0, len(m.removedaccounts))
+		for id := range m.removedaccounts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PromoCodeUsageMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedpromo_code {
-		edges = append(edges, promocodeusage.EdgePromoCode)
-	}
-	if m.cleareduser {
-		edges = append(edges, promocodeusage.EdgeUser)
+func (m *ProxyMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedaccounts {
+		edges = append(edges, proxy.EdgeAccounts)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PromoCodeUsageMutation) EdgeCleared(name string) bool {
+func (m *ProxyMutation) EdgeCleared(name string) bool {
 	switch name {
-	case promocodeusage.EdgePromoCode:
-		return m.clearedpromo_code
-	case promocodeusage.EdgeUser:
-		return m.cleareduser
+	case proxy.EdgeAccounts:
+		return m.clearedaccounts
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PromoCodeUsageMutation) ClearEdge(name string) error {
+func (m *ProxyMutation) ClearEdge(name string) error {
 	switch name {
-	case promocodeusage.EdgePromoCode:
-		m.ClearPromoCode()
-		return nil
-	case promocodeusage.EdgeUser:
-		m.ClearUser()
-		return nil
 	}
-	return fmt.Errorf("unknown PromoCodeUsage unique edge %s", name)
+	return fmt.Errorf("unknown Proxy unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PromoCodeUsageMutation) ResetEdge(name string) error {
+func (m *ProxyMutation) ResetEdge(name string) error {
 	switch name {
-	case promocodeusage.EdgePromoCode:
-		m.ResetPromoCode()
-		return nil
-	case promocodeusage.EdgeUser:
-		m.ResetUser()
+	case proxy.EdgeAccounts:
+		m.ResetAccounts()
 		return nil
 	}
-	return fmt.Errorf("unknown PromoCodeUsage edge %s", name)
+	return fmt.Errorf("unknown Proxy edge %s", name)
 }
 
-// ProxyMutation represents an operation that mutates the Proxy nodes in the graph.
-type ProxyMutation struct {
+// RedeemCodeMutation represents an operation that mutates the RedeemCode nodes in the graph.
+type RedeemCodeMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *int64
-	created_at      *time.Time
-	updated_at      *time.Time
-	deleted_at      *time.Time
-	name            *string
-	protocol        *string
-	host            *string
-	port            *int
-	addport         *int
-	username        *string
-	password        *string
-	status          *string
-	clearedFields   map[string]struct{}
-	accounts        map[int64]struct{}
-	removedaccounts map[int64]struct{}
-	clearedaccounts bool
-	done            bool
-	oldValue        func(context.Context) (*Proxy, error)
-	predicates      []predicate.Proxy
+	op               Op
+	typ              string
+	id               *int64
+	code             *string
+	_type            *string
+	value            *float64
+	addvalue         *float64
+	status           *string
+	used_at          *time.Time
+	notes            *string
+	created_at       *time.Time
+	validity_days    *int
+	addvalidity_days *int
+	clearedFields    map[string]struct{}
+	user             *int64
+	cleareduser      bool
+	group            *int64
+	clearedgroup     bool
+	done             bool
+	oldValue         func(context.Context) (*RedeemCode, error)
+	predicates       []predicate.RedeemCode
 }
 
-var _ ent.Mutation = (*ProxyMutation)(nil)
+var _ ent.Mutation = (*RedeemCodeMutation)(nil)
 
-// proxyOption allows management of the mutation configuration using functional options.
-type proxyOption func(*ProxyMutation)
+// redeemcodeOption allows management of the mutation configuration using functional options.
+type redeemcodeOption func(*RedeemCodeMutation)
 
-// newProxyMutation creates new mutation for the Proxy entity.
-func newProxyMutation(c config, op Op, opts ...proxyOption) *ProxyMutation {
-	m := &ProxyMutation{
+// newRedeemCodeMutation creates new mutation for the RedeemCode entity.
+func newRedeemCodeMutation(c config, op Op, opts ...redeemcodeOption) *RedeemCodeMutation {
+	m := &RedeemCodeMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeProxy,
+		typ:           TypeRedeemCode,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -11467,20 +14114,20 @@ func newProxyMutation(c config, op Op, opts ...proxyOption) *ProxyMutation {
 	return m
 }
 
-// withProxyID sets the ID field of the mutation.
-func withProxyID(id int64) proxyOption {
-	return func(m *ProxyMutation) {
+// withRedeemCodeID sets the ID field of the mutation.
+func withRedeemCodeID(id int64) redeemcodeOption {
+	return func(m *RedeemCodeMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Proxy
+			value *RedeemCode
 		)
-		m.oldValue = func(ctx context.Context) (*Proxy, error) {
+		m.oldValue = func(ctx context.Context) (*RedeemCode, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Proxy.Get(ctx, id)
+					value, err = m.Client().RedeemCode.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -11489,10 +14136,10 @@ func withProxyID(id int64) proxyOption {
 	}
 }
 
-// withProxy sets the old Proxy of the mutation.
-func withProxy(node *Proxy) proxyOption {
-	return func(m *ProxyMutation) {
-		m.oldValue = func(context.Context) (*Proxy, error) {
+// withRedeemCode sets the old RedeemCode of the mutation.
+func withRedeemCode(node *RedeemCode) redeemcodeOption {
+	return func(m *RedeemCodeMutation) {
+		m.oldValue = func(context.Context) (*RedeemCode, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -11501,7 +14148,7 @@ func withProxy(node *Proxy) proxyOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ProxyMutation) Client() *Client {
+func (m RedeemCodeMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -11509,7 +14156,7 @@ func (m ProxyMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ProxyMutation) Tx() (*Tx, error) {
+func (m RedeemCodeMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -11520,7 +14167,7 @@ func (m ProxyMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ProxyMutation) ID() (id int64, exists bool) {
+func (m *RedeemCodeMutation) ID() (id int64, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -11531,7 +14178,7 @@ func (m *ProxyMutation) ID() (id int64, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ProxyMutation) IDs(ctx context.Context) ([]int64, error) {
+func (m *RedeemCodeMutation) IDs(ctx context.Context) ([]int64, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -11540,1331 +14187,1086 @@ func (m *ProxyMutation) IDs(ctx context.Context) ([]int64, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Proxy.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().RedeemCode.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *ProxyMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
-}
-
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ProxyMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldCreatedAt returns the old "created_at" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
-	}
-	return oldValue.CreatedAt, nil
-}
-
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ProxyMutation) ResetCreatedAt() {
-	m.created_at = nil
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ProxyMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
-}
-
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ProxyMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldUpdatedAt returns the old "updated_at" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
-	}
-	return oldValue.UpdatedAt, nil
-}
-
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ProxyMutation) ResetUpdatedAt() {
-	m.updated_at = nil
-}
-
-// SetDeletedAt sets the "deleted_at" field.
-func (m *ProxyMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
-}
-
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *ProxyMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldDeletedAt returns the old "deleted_at" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
-	}
-	return oldValue.DeletedAt, nil
-}
-
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *ProxyMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[proxy.FieldDeletedAt] = struct{}{}
-}
-
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *ProxyMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[proxy.FieldDeletedAt]
-	return ok
-}
-
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *ProxyMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, proxy.FieldDeletedAt)
-}
-
-// SetName sets the "name" field.
-func (m *ProxyMutation) SetName(s string) {
-	m.name = &s
-}
-
-// Name returns the value of the "name" field in the mutation.
-func (m *ProxyMutation) Name() (r string, exists bool) {
-	v := m.name
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldName returns the old "name" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
-	}
-	return oldValue.Name, nil
-}
-
-// ResetName resets all changes to the "name" field.
-func (m *ProxyMutation) ResetName() {
-	m.name = nil
-}
-
-// SetProtocol sets the "protocol" field.
-func (m *ProxyMutation) SetProtocol(s string) {
-	m.protocol = &s
+// SetCode sets the "code" field.
+func (m *RedeemCodeMutation) SetCode(s string) {
+	m.code = &s
 }
 
-// Protocol returns the value of the "protocol" field in the mutation.
-func (m *ProxyMutation) Protocol() (r string, exists bool) {
-	v := m.protocol
+// Code returns the value of the "code" field in the mutation.
+func (m *RedeemCodeMutation) Code() (r string, exists bool) {
+	v := m.code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProtocol returns the old "protocol" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldCode returns the old "code" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldProtocol(ctx context.Context) (v string, err error) {
+func (m *RedeemCodeMutation) OldCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProtocol is only allowed on UpdateOne operations")
+		return v, errors.New("OldCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProtocol requires an ID field in the mutation")
+		return v, errors.New("OldCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProtocol: %w", err)
+		return v, fmt.Errorf("querying old value for OldCode: %w", err)
 	}
-	return oldValue.Protocol, nil
+	return oldValue.Code, nil
 }
 
-// ResetProtocol resets all changes to the "protocol" field.
-func (m *ProxyMutation) ResetProtocol() {
-	m.protocol = nil
+// ResetCode resets all changes to the "code" field.
+func (m *RedeemCodeMutation) ResetCode() {
+	m.code = nil
 }
 
-// SetHost sets the "host" field.
-func (m *ProxyMutation) SetHost(s string) {
-	m.host = &s
+// SetType sets the "type" field.
+func (m *RedeemCodeMutation) SetType(s string) {
+	m._type = &s
 }
 
-// Host returns the value of the "host" field in the mutation.
-func (m *ProxyMutation) Host() (r string, exists bool) {
-	v := m.host
+// GetType returns the value of the "type" field in the mutation.
+func (m *RedeemCodeMutation) GetType() (r string, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldHost returns the old "host" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldHost(ctx context.Context) (v string, err error) {
+func (m *RedeemCodeMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldHost is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldHost requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldHost: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.Host, nil
+	return oldValue.Type, nil
 }
 
-// ResetHost resets all changes to the "host" field.
-func (m *ProxyMutation) ResetHost() {
-	m.host = nil
+// ResetType resets all changes to the "type" field.
+func (m *RedeemCodeMutation) ResetType() {
+	m._type = nil
 }
 
-// SetPort sets the "port" field.
-func (m *ProxyMutation) SetPort(i int) {
-	m.port = &i
-	m.addport = nil
+// SetValue sets the "value" field.
+func (m *RedeemCodeMutation) SetValue(f float64) {
+	m.value = &f
+	m.addvalue = nil
 }
 
-// Port returns the value of the "port" field in the mutation.
-func (m *ProxyMutation) Port() (r int, exists bool) {
-	v := m.port
+// Value returns the value of the "value" field in the mutation.
+func (m *RedeemCodeMutation) Value() (r float64, exists bool) {
+	v := m.value
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPort returns the old "port" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldPort(ctx context.Context) (v int, err error) {
+func (m *RedeemCodeMutation) OldValue(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPort is only allowed on UpdateOne operations")
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPort requires an ID field in the mutation")
+		return v, errors.New("OldValue requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPort: %w", err)
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
 	}
-	return oldValue.Port, nil
+	return oldValue.Value, nil
 }
 
-// AddPort adds i to the "port" field.
-func (m *ProxyMutation) AddPort(i int) {
-	if m.addport != nil {
-		*m.addport += i
+// AddValue adds f to the "value" field.
+func (m *RedeemCodeMutation) AddValue(f float64) {
+	if m.addvalue != nil {
+		*m.addvalue += f
 	} else {
-		m.addport = &i
+		m.addvalue = &f
 	}
 }
 
-// AddedPort returns the value that was added to the "port" field in this mutation.
-func (m *ProxyMutation) AddedPort() (r int, exists bool) {
-	v := m.addport
+// AddedValue returns the value that was added to the "value" field in this mutation.
+func (m *RedeemCodeMutation) AddedValue() (r float64, exists bool) {
+	v := m.addvalue
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetPort resets all changes to the "port" field.
-func (m *ProxyMutation) ResetPort() {
-	m.port = nil
-	m.addport = nil
+// ResetValue resets all changes to the "value" field.
+func (m *RedeemCodeMutation) ResetValue() {
+	m.value = nil
+	m.addvalue = nil
 }
 
-// SetUsername sets the "username" field.
-func (m *ProxyMutation) SetUsername(s string) {
-	m.username = &s
+// SetStatus sets the "status" field.
+func (m *RedeemCodeMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// Username returns the value of the "username" field in the mutation.
-func (m *ProxyMutation) Username() (r string, exists bool) {
-	v := m.username
+// Status returns the value of the "status" field in the mutation.
+func (m *RedeemCodeMutation) Status() (r string, exists bool) {
+	v := m.status
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUsername returns the old "username" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldStatus returns the old "status" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldUsername(ctx context.Context) (v *string, err error) {
+func (m *RedeemCodeMutation) OldStatus(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsername requires an ID field in the mutation")
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return oldValue.Username, nil
-}
-
-// ClearUsername clears the value of the "username" field.
-func (m *ProxyMutation) ClearUsername() {
-	m.username = nil
-	m.clearedFields[proxy.FieldUsername] = struct{}{}
-}
-
-// UsernameCleared returns if the "username" field was cleared in this mutation.
-func (m *ProxyMutation) UsernameCleared() bool {
-	_, ok := m.clearedFields[proxy.FieldUsername]
-	return ok
+	return oldValue.Status, nil
 }
 
-// ResetUsername resets all changes to the "username" field.
-func (m *ProxyMutation) ResetUsername() {
-	m.username = nil
-	delete(m.clearedFields, proxy.FieldUsername)
+// ResetStatus resets all changes to the "status" field.
+func (m *RedeemCodeMutation) ResetStatus() {
+	m.status = nil
 }
 
-// SetPassword sets the "password" field.
-func (m *ProxyMutation) SetPassword(s string) {
-	m.password = &s
+// SetUsedBy sets the "used_by" field.
+func (m *RedeemCodeMutation) SetUsedBy(i int64) {
+	m.user = &i
 }
 
-// Password returns the value of the "password" field in the mutation.
-func (m *ProxyMutation) Password() (r string, exists bool) {
-	v := m.password
+// UsedBy returns the value of the "used_by" field in the mutation.
+func (m *RedeemCodeMutation) UsedBy() (r int64, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPassword returns the old "password" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldUsedBy returns the old "used_by" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldPassword(ctx context.Context) (v *string, err error) {
+func (m *RedeemCodeMutation) OldUsedBy(ctx context.Context) (v *int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsedBy is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPassword requires an ID field in the mutation")
+		return v, errors.New("OldUsedBy requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsedBy: %w", err)
 	}
-	return oldValue.Password, nil
+	return oldValue.UsedBy, nil
 }
 
-// ClearPassword clears the value of the "password" field.
-func (m *ProxyMutation) ClearPassword() {
-	m.password = nil
-	m.clearedFields[proxy.FieldPassword] = struct{}{}
+// ClearUsedBy clears the value of the "used_by" field.
+func (m *RedeemCodeMutation) ClearUsedBy() {
+	m.user = nil
+	m.clearedFields[redeemcode.FieldUsedBy] = struct{}{}
 }
 
-// PasswordCleared returns if the "password" field was cleared in this mutation.
-func (m *ProxyMutation) PasswordCleared() bool {
-	_, ok := m.clearedFields[proxy.FieldPassword]
+// UsedByCleared returns if the "used_by" field was cleared in this mutation.
+func (m *RedeemCodeMutation) UsedByCleared() bool {
+	_, ok := m.clearedFields[redeemcode.FieldUsedBy]
 	return ok
 }
 
-// ResetPassword resets all changes to the "password" field.
-func (m *ProxyMutation) ResetPassword() {
-	m.password = nil
-	delete(m.clearedFields, proxy.FieldPassword)
+// ResetUsedBy resets all changes to the "used_by" field.
+func (m *RedeemCodeMutation) ResetUsedBy() {
+	m.user = nil
+	delete(m.clearedFields, redeemcode.FieldUsedBy)
 }
 
-// SetStatus sets the "status" field.
-func (m *ProxyMutation) SetStatus(s string) {
-	m.status = &s
+// SetUsedAt sets the "used_at" field.
+func (m *RedeemCodeMutation) SetUsedAt(t time.Time) {
+	m.used_at = &t
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *ProxyMutation) Status() (r string, exists bool) {
-	v := m.status
+// UsedAt returns the value of the "used_at" field in the mutation.
+func (m *RedeemCodeMutation) UsedAt() (r time.Time, exists bool) {
+	v := m.used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the Proxy entity.
-// If the Proxy object wasn't provided to the builder, the object is fetched from the database.
+// OldUsedAt returns the old "used_at" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProxyMutation) OldStatus(ctx context.Context) (v string, err error) {
+func (m *RedeemCodeMutation) OldUsedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldUsedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsedAt: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.UsedAt, nil
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *ProxyMutation) ResetStatus() {
-	m.status = nil
+// ClearUsedAt clears the value of the "used_at" field.
+func (m *RedeemCodeMutation) ClearUsedAt() {
+	m.used_at = nil
+	m.clearedFields[redeemcode.FieldUsedAt] = struct{}{}
 }
 
-// AddAccountIDs adds the "accounts" edge to the Account entity by ids.
-func (m *ProxyMutation) AddAccountIDs(ids ...int64) {
-	if m.accounts == nil {
-		m.accounts = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.accounts[ids[i]] = struct{}{}
-	}
+// UsedAtCleared returns if the "used_at" field was cleared in this mutation.
+func (m *RedeemCodeMutation) UsedAtCleared() bool {
+	_, ok := m.clearedFields[redeemcode.FieldUsedAt]
+	return ok
 }
 
-// ClearAccounts clears the "accounts" edge to the Account entity.
-func (m *ProxyMutation) ClearAccounts() {
-	m.clearedaccounts = true
+// ResetUsedAt resets all changes to the "used_at" field.
+func (m *RedeemCodeMutation) ResetUsedAt() {
+	m.used_at = nil
+	delete(m.clearedFields, redeemcode.FieldUsedAt)
 }
 
-// AccountsCleared reports if the "accounts" edge to the Account entity was cleared.
-func (m *ProxyMutation) AccountsCleared() bool {
-	return m.clearedaccounts
+// SetNotes sets the "notes" field.
+func (m *RedeemCodeMutation) SetNotes(s string) {
+	m.notes = &s
 }
 
-// RemoveAccountIDs removes the "accounts" edge to the Account entity by IDs.
-func (m *ProxyMutation) RemoveAccountIDs(ids ...int64) {
-	if m.removedaccounts == nil {
-		m.removedaccounts = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.accounts, ids[i])
-		m.removedaccounts[ids[i]] = struct{}{}
+// Notes returns the value of the "notes" field in the mutation.
+func (m *RedeemCodeMutation) Notes() (r string, exists bool) {
+	v := m.notes
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedAccounts returns the removed IDs of the "accounts" edge to the Account entity.
-func (m *ProxyMutation) RemovedAccountsIDs() (ids []int64) {
-	for id := range m.removedaccounts {
-		ids = append(ids, id)
+// OldNotes returns the old "notes" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RedeemCodeMutation) OldNotes(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNotes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
+	}
+	return oldValue.Notes, nil
+}
+
+// ClearNotes clears the value of the "notes" field.
+func (m *RedeemCodeMutation) ClearNotes() {
+	m.notes = nil
+	m.clearedFields[redeemcode.FieldNotes] = struct{}{}
 }
 
-// AccountsIDs returns the "accounts" edge IDs in the mutation.
-func (m *ProxyMutation) AccountsIDs() (ids []int64) {
-	for id := range m.accounts {
-		ids = append(ids, id)
-	}
-	return
+// NotesCleared returns if the "notes" field was cleared in this mutation.
+func (m *RedeemCodeMutation) NotesCleared() bool {
+	_, ok := m.clearedFields[redeemcode.FieldNotes]
+	return ok
 }
 
-// ResetAccounts resets all changes to the "accounts" edge.
-func (m *ProxyMutation) ResetAccounts() {
-	m.accounts = nil
-	m.clearedaccounts = false
-	m.removedaccounts = nil
+// ResetNotes resets all changes to the "notes" field.
+func (m *RedeemCodeMutation) ResetNotes() {
+	m.notes = nil
+	delete(m.clearedFields, redeemcode.FieldNotes)
 }
 
-// Where appends a list predicates to the ProxyMutation builder.
-func (m *ProxyMutation) Where(ps ...predicate.Proxy) {
-	m.predicates = append(m.predicates, ps...)
+// SetCreatedAt sets the "created_at" field.
+func (m *RedeemCodeMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// WhereP appends storage-level predicates to the ProxyMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ProxyMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Proxy, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *RedeemCodeMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
-	m.Where(p...)
+	return *v, true
 }
 
-// Op returns the operation name.
-func (m *ProxyMutation) Op() Op {
-	return m.op
+// OldCreatedAt returns the old "created_at" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RedeemCodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
 }
 
-// SetOp allows setting the mutation operation.
-func (m *ProxyMutation) SetOp(op Op) {
-	m.op = op
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *RedeemCodeMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// Type returns the node type of this mutation (Proxy).
-func (m *ProxyMutation) Type() string {
-	return m.typ
+// SetGroupID sets the "group_id" field.
+func (m *RedeemCodeMutation) SetGroupID(i int64) {
+	m.group = &i
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *ProxyMutation) Fields() []string {
-	fields := make([]string, 0, 10)
-	if m.created_at != nil {
-		fields = append(fields, proxy.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, proxy.FieldUpdatedAt)
-	}
-	if m.deleted_at != nil {
-		fields = append(fields, proxy.FieldDeletedAt)
-	}
-	if m.name != nil {
-		fields = append(fields, proxy.FieldName)
-	}
-	if m.protocol != nil {
-		fields = append(fields, proxy.FieldProtocol)
-	}
-	if m.host != nil {
-		fields = append(fields, proxy.FieldHost)
-	}
-	if m.port != nil {
-		fields = append(fields, proxy.FieldPort)
-	}
-	if m.username != nil {
-		fields = append(fields, proxy.FieldUsername)
-	}
-	if m.password != nil {
-		fields = append(fields, proxy.FieldPassword)
-	}
-	if m.status != nil {
-		fields = append(fields, proxy.FieldStatus)
+// GroupID returns the value of the "group_id" field in the mutation.
+func (m *RedeemCodeMutation) GroupID() (r int64, exists bool) {
+	v := m.group
+	if v == nil {
+		return
 	}
-	return fields
+	return *v, true
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *ProxyMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case proxy.FieldCreatedAt:
-		return m.CreatedAt()
-	case proxy.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case proxy.FieldDeletedAt:
-		return m.DeletedAt()
-	case proxy.FieldName:
-		return m.Name()
-	case proxy.FieldProtocol:
-		return m.Protocol()
-	case proxy.FieldHost:
-		return m.Host()
-	case proxy.FieldPort:
-		return m.Port()
-	case proxy.FieldUsername:
-		return m.Username()
-	case proxy.FieldPassword:
-		return m.Password()
-	case proxy.FieldStatus:
-		return m.Status()
+// OldGroupID returns the old "group_id" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RedeemCodeMutation) OldGroupID(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGroupID is only allowed on UpdateOne operations")
 	}
-	return nil, false
-}
-
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *ProxyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case proxy.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case proxy.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case proxy.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
-	case proxy.FieldName:
-		return m.OldName(ctx)
-	case proxy.FieldProtocol:
-		return m.OldProtocol(ctx)
-	case proxy.FieldHost:
-		return m.OldHost(ctx)
-	case proxy.FieldPort:
-		return m.OldPort(ctx)
-	case proxy.FieldUsername:
-		return m.OldUsername(ctx)
-	case proxy.FieldPassword:
-		return m.OldPassword(ctx)
-	case proxy.FieldStatus:
-		return m.OldStatus(ctx)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGroupID requires an ID field in the mutation")
 	}
-	return nil, fmt.Errorf("unknown Proxy field %s", name)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGroupID: %w", err)
+	}
+	return oldValue.GroupID, nil
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *ProxyMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case proxy.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
-	case proxy.FieldUpdatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUpdatedAt(v)
-		return nil
-	case proxy.FieldDeletedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDeletedAt(v)
-		return nil
-	case proxy.FieldName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetName(v)
-		return nil
-	case proxy.FieldProtocol:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetProtocol(v)
-		return nil
-	case proxy.FieldHost:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetHost(v)
-		return nil
-	case proxy.FieldPort:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPort(v)
-		return nil
-	case proxy.FieldUsername:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUsername(v)
-		return nil
-	case proxy.FieldPassword:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPassword(v)
-		return nil
-	case proxy.FieldStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
-		return nil
-	}
-	return fmt.Errorf("unknown Proxy field %s", name)
+// ClearGroupID clears the value of the "group_id" field.
+func (m *RedeemCodeMutation) ClearGroupID() {
+	m.group = nil
+	m.clearedFields[redeemcode.FieldGroupID] = struct{}{}
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *ProxyMutation) AddedFields() []string {
-	var fields []string
-	if m.addport != nil {
-		fields = append(fields, proxy.FieldPort)
-	}
-	return fields
+// GroupIDCleared returns if the "group_id" field was cleared in this mutation.
+func (m *RedeemCodeMutation) GroupIDCleared() bool {
+	_, ok := m.clearedFields[redeemcode.FieldGroupID]
+	return ok
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *ProxyMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case proxy.FieldPort:
-		return m.AddedPort()
-	}
-	return nil, false
+// ResetGroupID resets all changes to the "group_id" field.
+func (m *RedeemCodeMutation) ResetGroupID() {
+	m.group = nil
+	delete(m.clearedFields, redeemcode.FieldGroupID)
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *ProxyMutation) AddField(name string, value ent.Value) error {
-	switch name {
-	case proxy.FieldPort:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddPort(v)
-		return nil
+// SetValidityDays sets the "validity_days" field.
+func (m *RedeemCodeMutation) SetValidityDays(i int) {
+	m.validity_days = &i
+	m.addvalidity_days = nil
+}
+
+// ValidityDays returns the value of the "validity_days" field in the mutation.
+func (m *RedeemCodeMutation) ValidityDays() (r int, exists bool) {
+	v := m.validity_days
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown Proxy numeric field %s", name)
+	return *v, true
 }
 
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *ProxyMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(proxy.FieldDeletedAt) {
-		fields = append(fields, proxy.FieldDeletedAt)
+// OldValidityDays returns the old "validity_days" field's value of the RedeemCode entity.
+// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RedeemCodeMutation) OldValidityDays(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValidityDays is only allowed on UpdateOne operations")
 	}
-	if m.FieldCleared(proxy.FieldUsername) {
-		fields = append(fields, proxy.FieldUsername)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValidityDays requires an ID field in the mutation")
 	}
-	if m.FieldCleared(proxy.FieldPassword) {
-		fields = append(fields, proxy.FieldPassword)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValidityDays: %w", err)
 	}
-	return fields
+	return oldValue.ValidityDays, nil
 }
 
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *ProxyMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
-	return ok
+// AddValidityDays adds i to the "validity_days" field.
+func (m *RedeemCodeMutation) AddValidityDays(i int) {
+	if m.addvalidity_days != nil {
+		*m.addvalidity_days += i
+	} else {
+		m.addvalidity_days = &i
+	}
 }
 
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *ProxyMutation) ClearField(name string) error {
-	switch name {
-	case proxy.FieldDeletedAt:
-		m.ClearDeletedAt()
-		return nil
-	case proxy.FieldUsername:
-		m.ClearUsername()
-		return nil
-	case proxy.FieldPassword:
-		m.ClearPassword()
-		return nil
+// AddedValidityDays returns the value that was added to the "validity_days" field in this mutation.
+func (m *RedeemCodeMutation) AddedValidityDays() (r int, exists bool) {
+	v := m.addvalidity_days
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown Proxy nullable field %s", name)
+	return *v, true
 }
 
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *ProxyMutation) ResetField(name string) error {
-	switch name {
-	case proxy.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case proxy.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case proxy.FieldDeletedAt:
-		m.ResetDeletedAt()
-		return nil
-	case proxy.FieldName:
-		m.ResetName()
-		return nil
-	case proxy.FieldProtocol:
-		m.ResetProtocol()
-		return nil
-	case proxy.FieldHost:
-		m.ResetHost()
-		return nil
-	case proxy.FieldPort:
-		m.ResetPort()
-		return nil
-	case proxy.FieldUsername:
-		m.ResetUsername()
-		return nil
-	case proxy.FieldPassword:
-		m.ResetPassword()
-		return nil
-	case proxy.FieldStatus:
-		m.ResetStatus()
-		return nil
-	}
-	return fmt.Errorf("unknown Proxy field %s", name)
+// ResetValidityDays resets all changes to the "validity_days" field.
+func (m *RedeemCodeMutation) ResetValidityDays() {
+	m.validity_days = nil
+	m.addvalidity_days = nil
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ProxyMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.accounts != nil {
-		edges = append(edges, proxy.EdgeAccounts)
-	}
-	return edges
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *RedeemCodeMutation) SetUserID(id int64) {
+	m.user = &id
 }
 
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *ProxyMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case proxy.EdgeAccounts:
-		ids := make([]ent.Value, 0, len(m.accounts))
-		for id := range m.accounts {
-			ids = append(ids, id)
-		}
-		return ids
-	}
-	return nil
+// ClearUser clears the "user" edge to the User entity.
+func (m *RedeemCodeMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[redeemcode.FieldUsedBy] = struct{}{}
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ProxyMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.removedaccounts != nil {
-		edges = append(edges, proxy.EdgeAccounts)
-	}
-	return edges
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *RedeemCodeMutation) UserCleared() bool {
+	return m.UsedByCleared() || m.cleareduser
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *ProxyMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case proxy.EdgeAccounts:
-		ids := make([]ent.Value, 0, len(m.removedaccounts))
-		for id := range m.removedaccounts {
-			ids = append(ids, id)
-		}
-		return ids
+// UserID returns the "user" edge ID in the mutation.
+func (m *RedeemCodeMutation) UserID() (id int64, exists bool) {
+	if m.user != nil {
+		return *m.user, true
 	}
-	return nil
+	return
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ProxyMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedaccounts {
-		edges = append(edges, proxy.EdgeAccounts)
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *RedeemCodeMutation) UserIDs() (ids []int64) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
-	return edges
+	return
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *ProxyMutation) EdgeCleared(name string) bool {
-	switch name {
-	case proxy.EdgeAccounts:
-		return m.clearedaccounts
-	}
-	return false
+// ResetUser resets all changes to the "user" edge.
+func (m *RedeemCodeMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *ProxyMutation) ClearEdge(name string) error {
-	switch name {
+// ClearGroup clears the "group" edge to the Group entity.
+func (m *RedeemCodeMutation) ClearGroup() {
+	m.clearedgroup = true
+	m.clearedFields[redeemcode.FieldGroupID] = struct{}{}
+}
+
+// GroupCleared reports if the "group" edge to the Group entity was cleared.
+func (m *RedeemCodeMutation) GroupCleared() bool {
+	return m.GroupIDCleared() || m.clearedgroup
+}
+
+// GroupIDs returns the "group" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// GroupID instead. It exists only for internal usage by the builders.
+func (m *RedeemCodeMutation) GroupIDs() (ids []int64) {
+	if id := m.group; id != nil {
+		ids = append(ids, *id)
 	}
-	return fmt.Errorf("unknown Proxy unique edge %s", name)
+	return
+}
+
+// ResetGroup resets all changes to the "group" edge.
+func (m *RedeemCodeMutation) ResetGroup() {
+	m.group = nil
+	m.clearedgroup = false
+}
+
+// Where appends a list predicates to the RedeemCodeMutation builder.
+func (m *RedeemCodeMutation) Where(ps ...predicate.RedeemCode) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *ProxyMutation) ResetEdge(name string) error {
-	switch name {
-	case proxy.EdgeAccounts:
-		m.ResetAccounts()
-		return nil
+// WhereP appends storage-level predicates to the RedeemCodeMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *RedeemCodeMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.RedeemCode, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return fmt.Errorf("unknown Proxy edge %s", name)
+	m.Where(p...)
 }
 
-// RedeemCodeMutation represents an operation that mutates the RedeemCode nodes in the graph.
-type RedeemCodeMutation struct {
-	config
-	op               Op
-	typ              string
-	id               *int64
-	code             *string
-	_type            *string
-	value            *float64
-	addvalue         *float64
-	status           *string
-	used_at          *time.Time
-	notes            *string
-	created_at       *time.Time
-	validity_days    *int
-	addvalidity_days *int
-	clearedFields    map[string]struct{}
-	user             *int64
-	cleareduser      bool
-	group            *int64
-	clearedgroup     bool
-	done             bool
-	oldValue         func(context.Context) (*RedeemCode, error)
-	predicates       []predicate.RedeemCode
+// Op returns the operation name.
+func (m *RedeemCodeMutation) Op() Op {
+	return m.op
 }
 
-var _ ent.Mutation = (*RedeemCodeMutation)(nil)
+// SetOp allows setting the mutation operation.
+func (m *RedeemCodeMutation) SetOp(op Op) {
+	m.op = op
+}
 
-// redeemcodeOption allows management of the mutation configuration using functional options.
-type redeemcodeOption func(*RedeemCodeMutation)
+// Type returns the node type of this mutation (RedeemCode).
+func (m *RedeemCodeMutation) Type() string {
+	return m.typ
+}
 
-// newRedeemCodeMutation creates new mutation for the RedeemCode entity.
-func newRedeemCodeMutation(c config, op Op, opts ...redeemcodeOption) *RedeemCodeMutation {
-	m := &RedeemCodeMutation{
-		config:        c,
-		op:            op,
-		typ:           TypeRedeemCode,
-		clearedFields: make(map[string]struct{}),
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *RedeemCodeMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.code != nil {
+		fields = append(fields, redeemcode.FieldCode)
 	}
-	for _, opt := range opts {
-		opt(m)
+	if m._type != nil {
+		fields = append(fields, redeemcode.FieldType)
 	}
-	return m
-}
-
-// withRedeemCodeID sets the ID field of the mutation.
-func withRedeemCodeID(id int64) redeemcodeOption {
-	return func(m *RedeemCodeMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *RedeemCode
-		)
-		m.oldValue = func(ctx context.Context) (*RedeemCode, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().RedeemCode.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
+	if m.value != nil {
+		fields = append(fields, redeemcode.FieldValue)
 	}
-}
-
-// withRedeemCode sets the old RedeemCode of the mutation.
-func withRedeemCode(node *RedeemCode) redeemcodeOption {
-	return func(m *RedeemCodeMutation) {
-		m.oldValue = func(context.Context) (*RedeemCode, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+	if m.status != nil {
+		fields = append(fields, redeemcode.FieldStatus)
 	}
+	if m.user != nil {
+		fields = append(fields, redeemcode.FieldUsedBy)
+	}
+	if m.used_at != nil {
+		fields = append(fields, redeemcode.FieldUsedAt)
+	}
+	if m.notes != nil {
+		fields = append(fields, redeemcode.FieldNotes)
+	}
+	if m.created_at != nil {
+		fields = append(fields, redeemcode.FieldCreatedAt)
+	}
+	if m.group != nil {
+		fields = append(fields, redeemcode.FieldGroupID)
+	}
+	if m.validity_days != nil {
+		fields = append(fields, redeemcode.FieldValidityDays)
+	}
+	return fields
 }
 
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m RedeemCodeMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
-}
-
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m RedeemCodeMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *RedeemCodeMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case redeemcode.FieldCode:
+		return m.Code()
+	case redeemcode.FieldType:
+		return m.GetType()
+	case redeemcode.FieldValue:
+		return m.Value()
+	case redeemcode.FieldStatus:
+		return m.Status()
+	case redeemcode.FieldUsedBy:
+		return m.UsedBy()
+	case redeemcode.FieldUsedAt:
+		return m.UsedAt()
+	case redeemcode.FieldNotes:
+		return m.Notes()
+	case redeemcode.FieldCreatedAt:
+		return m.CreatedAt()
+	case redeemcode.FieldGroupID:
+		return m.GroupID()
+	case redeemcode.FieldValidityDays:
+		return m.ValidityDays()
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
+	return nil, false
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *RedeemCodeMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
-		return
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *RedeemCodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case redeemcode.FieldCode:
+		return m.OldCode(ctx)
+	case redeemcode.FieldType:
+		return m.OldType(ctx)
+	case redeemcode.FieldValue:
+		return m.OldValue(ctx)
+	case redeemcode.FieldStatus:
+		return m.OldStatus(ctx)
+	case redeemcode.FieldUsedBy:
+		return m.OldUsedBy(ctx)
+	case redeemcode.FieldUsedAt:
+		return m.OldUsedAt(ctx)
+	case redeemcode.FieldNotes:
+		return m.OldNotes(ctx)
+	case redeemcode.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case redeemcode.FieldGroupID:
+		return m.OldGroupID(ctx)
+	case redeemcode.FieldValidityDays:
+		return m.OldValidityDays(ctx)
 	}
-	return *m.id, true
+	return nil, fmt.Errorf("unknown RedeemCode field %s", name)
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *RedeemCodeMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RedeemCodeMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case redeemcode.FieldCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCode(v)
+		return nil
+	case redeemcode.FieldType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case redeemcode.FieldValue:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValue(v)
+		return nil
+	case redeemcode.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case redeemcode.FieldUsedBy:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsedBy(v)
+		return nil
+	case redeemcode.FieldUsedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsedAt(v)
+		return nil
+	case redeemcode.FieldNotes:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().RedeemCode.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
-}
-
-// SetCode sets the "code" field.
-func (m *RedeemCodeMutation) SetCode(s string) {
-	m.code = &s
-}
-
-// Code returns the value of the "code" field in the mutation.
-func (m *RedeemCodeMutation) Code() (r string, exists bool) {
-	v := m.code
-	if v == nil {
-		return
+		m.SetNotes(v)
+		return nil
+	case redeemcode.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case redeemcode.FieldGroupID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGroupID(v)
+		return nil
+	case redeemcode.FieldValidityDays:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValidityDays(v)
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown RedeemCode field %s", name)
 }
 
-// OldCode returns the old "code" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldCode(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCode is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCode requires an ID field in the mutation")
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *RedeemCodeMutation) AddedFields() []string {
+	var fields []string
+	if m.addvalue != nil {
+		fields = append(fields, redeemcode.FieldValue)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCode: %w", err)
+	if m.addvalidity_days != nil {
+		fields = append(fields, redeemcode.FieldValidityDays)
 	}
-	return oldValue.Code, nil
-}
-
-// ResetCode resets all changes to the "code" field.
-func (m *RedeemCodeMutation) ResetCode() {
-	m.code = nil
-}
-
-// SetType sets the "type" field.
-func (m *RedeemCodeMutation) SetType(s string) {
-	m._type = &s
+	return fields
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *RedeemCodeMutation) GetType() (r string, exists bool) {
-	v := m._type
-	if v == nil {
-		return
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *RedeemCodeMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case redeemcode.FieldValue:
+		return m.AddedValue()
+	case redeemcode.FieldValidityDays:
+		return m.AddedValidityDays()
 	}
-	return *v, true
+	return nil, false
 }
 
-// OldType returns the old "type" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldType(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RedeemCodeMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case redeemcode.FieldValue:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddValue(v)
+		return nil
+	case redeemcode.FieldValidityDays:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddValidityDays(v)
+		return nil
 	}
-	return oldValue.Type, nil
-}
-
-// ResetType resets all changes to the "type" field.
-func (m *RedeemCodeMutation) ResetType() {
-	m._type = nil
-}
-
-// SetValue sets the "value" field.
-func (m *RedeemCodeMutation) SetValue(f float64) {
-	m.value = &f
-	m.addvalue = nil
+	return fmt.Errorf("unknown RedeemCode numeric field %s", name)
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *RedeemCodeMutation) Value() (r float64, exists bool) {
-	v := m.value
-	if v == nil {
-		return
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *RedeemCodeMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(redeemcode.FieldUsedBy) {
+		fields = append(fields, redeemcode.FieldUsedBy)
 	}
-	return *v, true
-}
-
-// OldValue returns the old "value" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldValue(ctx context.Context) (v float64, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+	if m.FieldCleared(redeemcode.FieldUsedAt) {
+		fields = append(fields, redeemcode.FieldUsedAt)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+	if m.FieldCleared(redeemcode.FieldNotes) {
+		fields = append(fields, redeemcode.FieldNotes)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+	if m.FieldCleared(redeemcode.FieldGroupID) {
+		fields = append(fields, redeemcode.FieldGroupID)
 	}
-	return oldValue.Value, nil
+	return fields
 }
 
-// AddValue adds f to the "value" field.
-func (m *RedeemCodeMutation) AddValue(f float64) {
-	if m.addvalue != nil {
-		*m.addvalue += f
-	} else {
-		m.addvalue = &f
-	}
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *RedeemCodeMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// AddedValue returns the value that was added to the "value" field in this mutation.
-func (m *RedeemCodeMutation) AddedValue() (r float64, exists bool) {
-	v := m.addvalue
-	if v == nil {
-		return
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *RedeemCodeMutation) ClearField(name string) error {
+	switch name {
+	case redeemcode.FieldUsedBy:
+		m.ClearUsedBy()
+		return nil
+	case redeemcode.FieldUsedAt:
+		m.ClearUsedAt()
+		return nil
+	case redeemcode.FieldNotes:
+		m.ClearNotes()
+		return nil
+	case redeemcode.FieldGroupID:
+		m.ClearGroupID()
+		return nil
 	}
-	return *v, true
-}
-
-// ResetValue resets all changes to the "value" field.
-func (m *RedeemCodeMutation) ResetValue() {
-	m.value = nil
-	m.addvalue = nil
-}
-
-// SetStatus sets the "status" field.
-func (m *RedeemCodeMutation) SetStatus(s string) {
-	m.status = &s
+	return fmt.Errorf("unknown RedeemCode nullable field %s", name)
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *RedeemCodeMutation) Status() (r string, exists bool) {
-	v := m.status
-	if v == nil {
-		return
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *RedeemCodeMutation) ResetField(name string) error {
+	switch name {
+	case redeemcode.FieldCode:
+		m.ResetCode()
+		return nil
+	case redeemcode.FieldType:
+		m.ResetType()
+		return nil
+	case redeemcode.FieldValue:
+		m.ResetValue()
+		return nil
+	case redeemcode.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case redeemcode.FieldUsedBy:
+		m.ResetUsedBy()
+		return nil
+	case redeemcode.FieldUsedAt:
+		m.ResetUsedAt()
+		return nil
+	case redeemcode.FieldNotes:
+		m.ResetNotes()
+		return nil
+	case redeemcode.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case redeemcode.FieldGroupID:
+		m.ResetGroupID()
+		return nil
+	case redeemcode.FieldValidityDays:
+		m.ResetValidityDays()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown RedeemCode field %s", name)
 }
 
-// OldStatus returns the old "status" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldStatus(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *RedeemCodeMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.user != nil {
+		edges = append(edges, redeemcode.EdgeUser)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	if m.group != nil {
+		edges = append(edges, redeemcode.EdgeGroup)
 	}
-	return oldValue.Status, nil
+	return edges
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *RedeemCodeMutation) ResetStatus() {
-	m.status = nil
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *RedeemCodeMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case redeemcode.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	case redeemcode.EdgeGroup:
+		if id := m.group; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
 }
 
-// SetUsedBy sets the "used_by" field.
-func (m *RedeemCodeMutation) SetUsedBy(i int64) {
-	m.user = &i
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *RedeemCodeMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	return edges
 }
 
-// UsedBy returns the value of the "used_by" field in the mutation.
-func (m *RedeemCodeMutation) UsedBy() (r int64, exists bool) {
-	v := m.user
-	if v == nil {
-		return
-	}
-	return *v, true
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *RedeemCodeMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// OldUsedBy returns the old "used_by" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldUsedBy(ctx context.Context) (v *int64, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsedBy is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsedBy requires an ID field in the mutation")
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *RedeemCodeMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.cleareduser {
+		edges = append(edges, redeemcode.EdgeUser)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsedBy: %w", err)
+	if m.clearedgroup {
+		edges = append(edges, redeemcode.EdgeGroup)
 	}
-	return oldValue.UsedBy, nil
-}
-
-// ClearUsedBy clears the value of the "used_by" field.
-func (m *RedeemCodeMutation) ClearUsedBy() {
-	m.user = nil
-	m.clearedFields[redeemcode.FieldUsedBy] = struct{}{}
-}
-
-// UsedByCleared returns if the "used_by" field was cleared in this mutation.
-func (m *RedeemCodeMutation) UsedByCleared() bool {
-	_, ok := m.clearedFields[redeemcode.FieldUsedBy]
-	return ok
-}
-
-// ResetUsedBy resets all changes to the "used_by" field.
-func (m *RedeemCodeMutation) ResetUsedBy() {
-	m.user = nil
-	delete(m.clearedFields, redeemcode.FieldUsedBy)
+	return edges
 }
 
-// SetUsedAt sets the "used_at" field.
-func (m *RedeemCodeMutation) SetUsedAt(t time.Time) {
-	m.used_at = &t
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *RedeemCodeMutation) EdgeCleared(name string) bool {
+	switch name {
+	case redeemcode.EdgeUser:
+		return m.cleareduser
+	case redeemcode.EdgeGroup:
+		return m.clearedgroup
+	}
+	return false
 }
 
-// UsedAt returns the value of the "used_at" field in the mutation.
-func (m *RedeemCodeMutation) UsedAt() (r time.Time, exists bool) {
-	v := m.used_at
-	if v == nil {
-		return
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *RedeemCodeMutation) ClearEdge(name string) error {
+	switch name {
+	case redeemcode.EdgeUser:
+		m.ClearUser()
+		return nil
+	case redeemcode.EdgeGroup:
+		m.ClearGroup()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown RedeemCode unique edge %s", name)
 }
 
-// OldUsedAt returns the old "used_at" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldUsedAt(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsedAt: %w", err)
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *RedeemCodeMutation) ResetEdge(name string) error {
+	switch name {
+	case redeemcode.EdgeUser:
+		m.ResetUser()
+		return nil
+	case redeemcode.EdgeGroup:
+		m.ResetGroup()
+		return nil
 	}
-	return oldValue.UsedAt, nil
+	return fmt.Errorf("unknown RedeemCode edge %s", name)
 }
 
-// ClearUsedAt clears the value of the "used_at" field.
-func (m *RedeemCodeMutation) ClearUsedAt() {
-	m.used_at = nil
-	m.clearedFields[redeemcode.FieldUsedAt] = struct{}{}
+// SessionBindingMutation represents an operation that mutates the SessionBinding nodes in the graph.
+type SessionBindingMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int64
+	created_at    *time.Time
+	updated_at    *time.Time
+	group_id      *int64
+	addgroup_id   *int64
+	session_hash  *string
+	account_id    *int64
+	addaccount_id *int64
+	expires_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*SessionBinding, error)
+	predicates    []predicate.SessionBinding
 }
 
-// UsedAtCleared returns if the "used_at" field was cleared in this mutation.
-func (m *RedeemCodeMutation) UsedAtCleared() bool {
-	_, ok := m.clearedFields[redeemcode.FieldUsedAt]
-	return ok
-}
+var _ ent.Mutation = (*SessionBindingMutation)(nil)
 
-// ResetUsedAt resets all changes to the "used_at" field.
-func (m *RedeemCodeMutation) ResetUsedAt() {
-	m.used_at = nil
-	delete(m.clearedFields, redeemcode.FieldUsedAt)
-}
+// sessionbindingOption allows management of the mutation configuration using functional options.
+type sessionbindingOption func(*SessionBindingMutation)
 
-// SetNotes sets the "notes" field.
-func (m *RedeemCodeMutation) SetNotes(s string) {
-	m.notes = &s
+// newSessionBindingMutation creates new mutation for the SessionBinding entity.
+func newSessionBindingMutation(c config, op Op, opts ...sessionbindingOption) *SessionBindingMutation {
+	m := &SessionBindingMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSessionBinding,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
-// Notes returns the value of the "notes" field in the mutation.
-func (m *RedeemCodeMutation) Notes() (r string, exists bool) {
-	v := m.notes
-	if v == nil {
-		return
+// withSessionBindingID sets the ID field of the mutation.
+func withSessionBindingID(id int64) sessionbindingOption {
+	return func(m *SessionBindingMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SessionBinding
+		)
+		m.oldValue = func(ctx context.Context) (*SessionBinding, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SessionBinding.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	return *v, true
 }
 
-// OldNotes returns the old "notes" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldNotes(ctx context.Context) (v *string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNotes requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
+// withSessionBinding sets the old SessionBinding of the mutation.
+func withSessionBinding(node *SessionBinding) sessionbindingOption {
+	return func(m *SessionBindingMutation) {
+		m.oldValue = func(context.Context) (*SessionBinding, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
-	return oldValue.Notes, nil
 }
 
-// ClearNotes clears the value of the "notes" field.
-func (m *RedeemCodeMutation) ClearNotes() {
-	m.notes = nil
-	m.clearedFields[redeemcode.FieldNotes] = struct{}{}
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SessionBindingMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// NotesCleared returns if the "notes" field was cleared in this mutation.
-func (m *RedeemCodeMutation) NotesCleared() bool {
-	_, ok := m.clearedFields[redeemcode.FieldNotes]
-	return ok
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SessionBindingMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// ResetNotes resets all changes to the "notes" field.
-func (m *RedeemCodeMutation) ResetNotes() {
-	m.notes = nil
-	delete(m.clearedFields, redeemcode.FieldNotes)
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionBindingMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionBindingMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SessionBinding.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *RedeemCodeMutation) SetCreatedAt(t time.Time) {
+func (m *SessionBindingMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *RedeemCodeMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *SessionBindingMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -12872,10 +15274,10 @@ func (m *RedeemCodeMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *SessionBindingMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -12890,28 +15292,65 @@ func (m *RedeemCodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *RedeemCodeMutation) ResetCreatedAt() {
+func (m *SessionBindingMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (m *SessionBindingMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *SessionBindingMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionBindingMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *SessionBindingMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
 // SetGroupID sets the "group_id" field.
-func (m *RedeemCodeMutation) SetGroupID(i int64) {
-	m.group = &i
+func (m *SessionBindingMutation) SetGroupID(i int64) {
+	m.group_id = &i
+	m.addgroup_id = nil
 }
 
 // GroupID returns the value of the "group_id" field in the mutation.
-func (m *RedeemCodeMutation) GroupID() (r int64, exists bool) {
-	v := m.group
+func (m *SessionBindingMutation) GroupID() (r int64, exists bool) {
+	v := m.group_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGroupID returns the old "group_id" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// OldGroupID returns the old "group_id" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldGroupID(ctx context.Context) (v *int64, err error) {
+func (m *SessionBindingMutation) OldGroupID(ctx context.Context) (v int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldGroupID is only allowed on UpdateOne operations")
 	}
@@ -12925,156 +15364,167 @@ func (m *RedeemCodeMutation) OldGroupID(ctx context.Context) (v *int64, err erro
 	return oldValue.GroupID, nil
 }
 
-// ClearGroupID clears the value of the "group_id" field.
-func (m *RedeemCodeMutation) ClearGroupID() {
-	m.group = nil
-	m.clearedFields[redeemcode.FieldGroupID] = struct{}{}
+// AddGroupID adds i to the "group_id" field.
+func (m *SessionBindingMutation) AddGroupID(i int64) {
+	if m.addgroup_id != nil {
+		*m.addgroup_id += i
+	} else {
+		m.addgroup_id = &i
+	}
 }
 
-// GroupIDCleared returns if the "group_id" field was cleared in this mutation.
-func (m *RedeemCodeMutation) GroupIDCleared() bool {
-	_, ok := m.clearedFields[redeemcode.FieldGroupID]
-	return ok
+// AddedGroupID returns the value that was added to the "group_id" field in this mutation.
+func (m *SessionBindingMutation) AddedGroupID() (r int64, exists bool) {
+	v := m.addgroup_id
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
 // ResetGroupID resets all changes to the "group_id" field.
-func (m *RedeemCodeMutation) ResetGroupID() {
-	m.group = nil
-	delete(m.clearedFields, redeemcode.FieldGroupID)
+func (m *SessionBindingMutation) ResetGroupID() {
+	m.group_id = nil
+	m.addgroup_id = nil
 }
 
-// SetValidityDays sets the "validity_days" field.
-func (m *RedeemCodeMutation) SetValidityDays(i int) {
-	m.validity_days = &i
-	m.addvalidity_days = nil
+// SetSessionHash sets the "session_hash" field.
+func (m *SessionBindingMutation) SetSessionHash(s string) {
+	m.session_hash = &s
 }
 
-// ValidityDays returns the value of the "validity_days" field in the mutation.
-func (m *RedeemCodeMutation) ValidityDays() (r int, exists bool) {
-	v := m.validity_days
+// SessionHash returns the value of the "session_hash" field in the mutation.
+func (m *SessionBindingMutation) SessionHash() (r string, exists bool) {
+	v := m.session_hash
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValidityDays returns the old "validity_days" field's value of the RedeemCode entity.
-// If the RedeemCode object wasn't provided to the builder, the object is fetched from the database.
+// OldSessionHash returns the old "session_hash" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RedeemCodeMutation) OldValidityDays(ctx context.Context) (v int, err error) {
+func (m *SessionBindingMutation) OldSessionHash(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValidityDays is only allowed on UpdateOne operations")
+		return v, errors.New("OldSessionHash is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValidityDays requires an ID field in the mutation")
+		return v, errors.New("OldSessionHash requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValidityDays: %w", err)
+		return v, fmt.Errorf("querying old value for OldSessionHash: %w", err)
 	}
-	return oldValue.ValidityDays, nil
+	return oldValue.SessionHash, nil
 }
 
-// AddValidityDays adds i to the "validity_days" field.
-func (m *RedeemCodeMutation) AddValidityDays(i int) {
-	if m.addvalidity_days != nil {
-		*m.addvalidity_days += i
-	} else {
-		m.addvalidity_days = &i
-	}
+// ResetSessionHash resets all changes to the "session_hash" field.
+func (m *SessionBindingMutation) ResetSessionHash() {
+	m.session_hash = nil
 }
 
-// AddedValidityDays returns the value that was added to the "validity_days" field in this mutation.
-func (m *RedeemCodeMutation) AddedValidityDays() (r int, exists bool) {
-	v := m.addvalidity_days
+// SetAccountID sets the "account_id" field.
+func (m *SessionBindingMutation) SetAccountID(i int64) {
+	m.account_id = &i
+	m.addaccount_id = nil
+}
+
+// AccountID returns the value of the "account_id" field in the mutation.
+func (m *SessionBindingMutation) AccountID() (r int64, exists bool) {
+	v := m.account_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetValidityDays resets all changes to the "validity_days" field.
-func (m *RedeemCodeMutation) ResetValidityDays() {
-	m.validity_days = nil
-	m.addvalidity_days = nil
-}
-
-// SetUserID sets the "user" edge to the User entity by id.
-func (m *RedeemCodeMutation) SetUserID(id int64) {
-	m.user = &id
-}
-
-// ClearUser clears the "user" edge to the User entity.
-func (m *RedeemCodeMutation) ClearUser() {
-	m.cleareduser = true
-	m.clearedFields[redeemcode.FieldUsedBy] = struct{}{}
-}
-
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *RedeemCodeMutation) UserCleared() bool {
-	return m.UsedByCleared() || m.cleareduser
+// OldAccountID returns the old "account_id" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionBindingMutation) OldAccountID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAccountID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAccountID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAccountID: %w", err)
+	}
+	return oldValue.AccountID, nil
 }
 
-// UserID returns the "user" edge ID in the mutation.
-func (m *RedeemCodeMutation) UserID() (id int64, exists bool) {
-	if m.user != nil {
-		return *m.user, true
+// AddAccountID adds i to the "account_id" field.
+func (m *SessionBindingMutation) AddAccountID(i int64) {
+	if m.addaccount_id != nil {
+		*m.addaccount_id += i
+	} else {
+		m.addaccount_id = &i
 	}
-	return
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *RedeemCodeMutation) UserIDs() (ids []int64) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// AddedAccountID returns the value that was added to the "account_id" field in this mutation.
+func (m *SessionBindingMutation) AddedAccountID() (r int64, exists bool) {
+	v := m.addaccount_id
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *RedeemCodeMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// ResetAccountID resets all changes to the "account_id" field.
+func (m *SessionBindingMutation) ResetAccountID() {
+	m.account_id = nil
+	m.addaccount_id = nil
 }
 
-// ClearGroup clears the "group" edge to the Group entity.
-func (m *RedeemCodeMutation) ClearGroup() {
-	m.clearedgroup = true
-	m.clearedFields[redeemcode.FieldGroupID] = struct{}{}
+// SetExpiresAt sets the "expires_at" field.
+func (m *SessionBindingMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// GroupCleared reports if the "group" edge to the Group entity was cleared.
-func (m *RedeemCodeMutation) GroupCleared() bool {
-	return m.GroupIDCleared() || m.clearedgroup
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *SessionBindingMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// GroupIDs returns the "group" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// GroupID instead. It exists only for internal usage by the builders.
-func (m *RedeemCodeMutation) GroupIDs() (ids []int64) {
-	if id := m.group; id != nil {
-		ids = append(ids, *id)
+// OldExpiresAt returns the old "expires_at" field's value of the SessionBinding entity.
+// If the SessionBinding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionBindingMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
 }
 
-// ResetGroup resets all changes to the "group" edge.
-func (m *RedeemCodeMutation) ResetGroup() {
-	m.group = nil
-	m.clearedgroup = false
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *SessionBindingMutation) ResetExpiresAt() {
+	m.expires_at = nil
 }
 
-// Where appends a list predicates to the RedeemCodeMutation builder.
-func (m *RedeemCodeMutation) Where(ps ...predicate.RedeemCode) {
+// Where appends a list predicates to the SessionBindingMutation builder.
+func (m *SessionBindingMutation) Where(ps ...predicate.SessionBinding) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the RedeemCodeMutation builder. Using this method,
+// WhereP appends storage-level predicates to the SessionBindingMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *RedeemCodeMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.RedeemCode, len(ps))
+func (m *SessionBindingMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SessionBinding, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -13082,54 +15532,42 @@ func (m *RedeemCodeMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *RedeemCodeMutation) Op() Op {
+func (m *SessionBindingMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *RedeemCodeMutation) SetOp(op Op) {
+func (m *SessionBindingMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (RedeemCode).
-func (m *RedeemCodeMutation) Type() string {
+// Type returns the node type of this mutation (SessionBinding).
+func (m *SessionBindingMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *RedeemCodeMutation) Fields() []string {
-	fields := make([]string, 0, 10)
-	if m.code != nil {
-		fields = append(fields, redeemcode.FieldCode)
-	}
-	if m._type != nil {
-		fields = append(fields, redeemcode.FieldType)
-	}
-	if m.value != nil {
-		fields = append(fields, redeemcode.FieldValue)
-	}
-	if m.status != nil {
-		fields = append(fields, redeemcode.FieldStatus)
-	}
-	if m.user != nil {
-		fields = append(fields, redeemcode.FieldUsedBy)
+func (m *SessionBindingMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.created_at != nil {
+		fields = append(fields, sessionbinding.FieldCreatedAt)
 	}
-	if m.used_at != nil {
-		fields = append(fields, redeemcode.FieldUsedAt)
+	if m.updated_at != nil {
+		fields = append(fields, sessionbinding.FieldUpdatedAt)
 	}
-	if m.notes != nil {
-		fields = append(fields, redeemcode.FieldNotes)
+	if m.group_id != nil {
+		fields = append(fields, sessionbinding.FieldGroupID)
 	}
-	if m.created_at != nil {
-		fields = append(fields, redeemcode.FieldCreatedAt)
+	if m.session_hash != nil {
+		fields = append(fields, sessionbinding.FieldSessionHash)
 	}
-	if m.group != nil {
-		fields = append(fields, redeemcode.FieldGroupID)
+	if m.account_id != nil {
+		fields = append(fields, sessionbinding.FieldAccountID)
 	}
-	if m.validity_days != nil {
-		fields = append(fields, redeemcode.FieldValidityDays)
+	if m.expires_at != nil {
+		fields = append(fields, sessionbinding.FieldExpiresAt)
 	}
 	return fields
 }
@@ -13137,28 +15575,20 @@ func (m *RedeemCodeMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *RedeemCodeMutation) Field(name string) (ent.Value, bool) {
+func (m *SessionBindingMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case redeemcode.FieldCode:
-		return m.Code()
-	case redeemcode.FieldType:
-		return m.GetType()
-	case redeemcode.FieldValue:
-		return m.Value()
-	case redeemcode.FieldStatus:
-		return m.Status()
-	case redeemcode.FieldUsedBy:
-		return m.UsedBy()
-	case redeemcode.FieldUsedAt:
-		return m.UsedAt()
-	case redeemcode.FieldNotes:
-		return m.Notes()
-	case redeemcode.FieldCreatedAt:
+	case sessionbinding.FieldCreatedAt:
 		return m.CreatedAt()
-	case redeemcode.FieldGroupID:
+	case sessionbinding.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case sessionbinding.FieldGroupID:
 		return m.GroupID()
-	case redeemcode.FieldValidityDays:
-		return m.ValidityDays()
+	case sessionbinding.FieldSessionHash:
+		return m.SessionHash()
+	case sessionbinding.FieldAccountID:
+		return m.AccountID()
+	case sessionbinding.FieldExpiresAt:
+		return m.ExpiresAt()
 	}
 	return nil, false
 }
@@ -13166,120 +15596,84 @@ func (m *RedeemCodeMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *RedeemCodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *SessionBindingMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case redeemcode.FieldCode:
-		return m.OldCode(ctx)
-	case redeemcode.FieldType:
-		return m.OldType(ctx)
-	case redeemcode.FieldValue:
-		return m.OldValue(ctx)
-	case redeemcode.FieldStatus:
-		return m.OldStatus(ctx)
-	case redeemcode.FieldUsedBy:
-		return m.OldUsedBy(ctx)
-	case redeemcode.FieldUsedAt:
-		return m.OldUsedAt(ctx)
-	case redeemcode.FieldNotes:
-		return m.OldNotes(ctx)
-	case redeemcode.FieldCreatedAt:
+	case sessionbinding.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case redeemcode.FieldGroupID:
+	case sessionbinding.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case sessionbinding.FieldGroupID:
 		return m.OldGroupID(ctx)
-	case redeemcode.FieldValidityDays:
-		return m.OldValidityDays(ctx)
+	case sessionbinding.FieldSessionHash:
+		return m.OldSessionHash(ctx)
+	case sessionbinding.FieldAccountID:
+		return m.OldAccountID(ctx)
+	case sessionbinding.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown RedeemCode field %s", name)
+	return nil, fmt.Errorf("unknown SessionBinding field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *RedeemCodeMutation) SetField(name string, value ent.Value) error {
+func (m *SessionBindingMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case redeemcode.FieldCode:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCode(v)
-		return nil
-	case redeemcode.FieldType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetType(v)
-		return nil
-	case redeemcode.FieldValue:
-		v, ok := value.(float64)
+	case sessionbinding.FieldCreatedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetCreatedAt(v)
 		return nil
-	case redeemcode.FieldStatus:
-		v, ok := value.(string)
+	case sessionbinding.FieldUpdatedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetStatus(v)
+		m.SetUpdatedAt(v)
 		return nil
-	case redeemcode.FieldUsedBy:
+	case sessionbinding.FieldGroupID:
 		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUsedBy(v)
-		return nil
-	case redeemcode.FieldUsedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUsedAt(v)
+		m.SetGroupID(v)
 		return nil
-	case redeemcode.FieldNotes:
+	case sessionbinding.FieldSessionHash:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetNotes(v)
-		return nil
-	case redeemcode.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
+		m.SetSessionHash(v)
 		return nil
-	case redeemcode.FieldGroupID:
+	case sessionbinding.FieldAccountID:
 		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetGroupID(v)
+		m.SetAccountID(v)
 		return nil
-	case redeemcode.FieldValidityDays:
-		v, ok := value.(int)
+	case sessionbinding.FieldExpiresAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValidityDays(v)
+		m.SetExpiresAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown RedeemCode field %s", name)
+	return fmt.Errorf("unknown SessionBinding field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *RedeemCodeMutation) AddedFields() []string {
+func (m *SessionBindingMutation) AddedFields() []string {
 	var fields []string
-	if m.addvalue != nil {
-		fields = append(fields, redeemcode.FieldValue)
+	if m.addgroup_id != nil {
+		fields = append(fields, sessionbinding.FieldGroupID)
 	}
-	if m.addvalidity_days != nil {
-		fields = append(fields, redeemcode.FieldValidityDays)
+	if m.addaccount_id != nil {
+		fields = append(fields, sessionbinding.FieldAccountID)
 	}
 	return fields
 }
@@ -13287,12 +15681,12 @@ func (m *RedeemCodeMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *RedeemCodeMutation) AddedField(name string) (ent.Value, bool) {
+func (m *SessionBindingMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case redeemcode.FieldValue:
-		return m.AddedValue()
-	case redeemcode.FieldValidityDays:
-		return m.AddedValidityDays()
+	case sessionbinding.FieldGroupID:
+		return m.AddedGroupID()
+	case sessionbinding.FieldAccountID:
+		return m.AddedAccountID()
 	}
 	return nil, false
 }
@@ -13300,200 +15694,117 @@ func (m *RedeemCodeMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *RedeemCodeMutation) AddField(name string, value ent.Value) error {
+func (m *SessionBindingMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case redeemcode.FieldValue:
-		v, ok := value.(float64)
+	case sessionbinding.FieldGroupID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddValue(v)
+		m.AddGroupID(v)
 		return nil
-	case redeemcode.FieldValidityDays:
-		v, ok := value.(int)
+	case sessionbinding.FieldAccountID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddValidityDays(v)
+		m.AddAccountID(v)
 		return nil
 	}
-	return fmt.Errorf("unknown RedeemCode numeric field %s", name)
+	return fmt.Errorf("unknown SessionBinding numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *RedeemCodeMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(redeemcode.FieldUsedBy) {
-		fields = append(fields, redeemcode.FieldUsedBy)
-	}
-	if m.FieldCleared(redeemcode.FieldUsedAt) {
-		fields = append(fields, redeemcode.FieldUsedAt)
-	}
-	if m.FieldCleared(redeemcode.FieldNotes) {
-		fields = append(fields, redeemcode.FieldNotes)
-	}
-	if m.FieldCleared(redeemcode.FieldGroupID) {
-		fields = append(fields, redeemcode.FieldGroupID)
-	}
-	return fields
+func (m *SessionBindingMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *RedeemCodeMutation) FieldCleared(name string) bool {
+func (m *SessionBindingMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *RedeemCodeMutation) ClearField(name string) error {
-	switch name {
-	case redeemcode.FieldUsedBy:
-		m.ClearUsedBy()
-		return nil
-	case redeemcode.FieldUsedAt:
-		m.ClearUsedAt()
-		return nil
-	case redeemcode.FieldNotes:
-		m.ClearNotes()
-		return nil
-	case redeemcode.FieldGroupID:
-		m.ClearGroupID()
-		return nil
-	}
-	return fmt.Errorf("unknown RedeemCode nullable field %s", name)
+func (m *SessionBindingMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown SessionBinding nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *RedeemCodeMutation) ResetField(name string) error {
+func (m *SessionBindingMutation) ResetField(name string) error {
 	switch name {
-	case redeemcode.FieldCode:
-		m.ResetCode()
-		return nil
-	case redeemcode.FieldType:
-		m.ResetType()
-		return nil
-	case redeemcode.FieldValue:
-		m.ResetValue()
-		return nil
-	case redeemcode.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case redeemcode.FieldUsedBy:
-		m.ResetUsedBy()
+	case sessionbinding.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
-	case redeemcode.FieldUsedAt:
-		m.ResetUsedAt()
+	case sessionbinding.FieldUpdatedAt:
+		m.ResetUpdatedAt()
 		return nil
-	case redeemcode.FieldNotes:
-		m.ResetNotes()
+	case sessionbinding.FieldGroupID:
+		m.ResetGroupID()
 		return nil
-	case redeemcode.FieldCreatedAt:
-		m.ResetCreatedAt()
+	case sessionbinding.FieldSessionHash:
+		m.ResetSessionHash()
 		return nil
-	case redeemcode.FieldGroupID:
-		m.ResetGroupID()
+	case sessionbinding.FieldAccountID:
+		m.ResetAccountID()
 		return nil
-	case redeemcode.FieldValidityDays:
-		m.ResetValidityDays()
+	case sessionbinding.FieldExpiresAt:
+		m.ResetExpiresAt()
 		return nil
 	}
-	return fmt.Errorf("unknown RedeemCode field %s", name)
+	return fmt.Errorf("unknown SessionBinding field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *RedeemCodeMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.user != nil {
-		edges = append(edges, redeemcode.EdgeUser)
-	}
-	if m.group != nil {
-		edges = append(edges, redeemcode.EdgeGroup)
-	}
+func (m *SessionBindingMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *RedeemCodeMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case redeemcode.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
-		}
-	case redeemcode.EdgeGroup:
-		if id := m.group; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *SessionBindingMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *RedeemCodeMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *SessionBindingMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *RedeemCodeMutation) RemovedIDs(name string) []ent.Value {
+func (m *SessionBindingMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *RedeemCodeMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.cleareduser {
-		edges = append(edges, redeemcode.EdgeUser)
-	}
-	if m.clearedgroup {
-		edges = append(edges, redeemcode.EdgeGroup)
-	}
+func (m *SessionBindingMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *RedeemCodeMutation) EdgeCleared(name string) bool {
-	switch name {
-	case redeemcode.EdgeUser:
-		return m.cleareduser
-	case redeemcode.EdgeGroup:
-		return m.clearedgroup
-	}
+func (m *SessionBindingMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *RedeemCodeMutation) ClearEdge(name string) error {
-	switch name {
-	case redeemcode.EdgeUser:
-		m.ClearUser()
-		return nil
-	case redeemcode.EdgeGroup:
-		m.ClearGroup()
-		return nil
-	}
-	return fmt.Errorf("unknown RedeemCode unique edge %s", name)
+func (m *SessionBindingMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown SessionBinding unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *RedeemCodeMutation) ResetEdge(name string) error {
-	switch name {
-	case redeemcode.EdgeUser:
-		m.ResetUser()
-		return nil
-	case redeemcode.EdgeGroup:
-		m.ResetGroup()
-		return nil
-	}
-	return fmt.Errorf("unknown RedeemCode edge %s", name)
+func (m *SessionBindingMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown SessionBinding edge %s", name)
 }
 
 // SettingMutation represents an operation that mutates the Setting nodes in the graph.
@@ -17974,6 +20285,7 @@ type UserMutation struct {
 	totp_secret_encrypted         *string
 	totp_enabled                  *bool
 	totp_enabled_at               *time.Time
+	model_mapping                 *map[string]string
 	clearedFields                 map[string]struct{}
 	api_keys                      map[int64]struct{}
 	removedapi_keys               map[int64]struct{}
@@ -18688,6 +21000,55 @@ func (m *UserMutation) ResetTotpEnabledAt() {
 	delete(m.clearedFields, user.FieldTotpEnabledAt)
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (m *UserMutation) SetModelMapping(value map[string]string) {
+	m.model_mapping = &value
+}
+
+// ModelMapping returns the value of the "model_mapping" field in the mutation.
+func (m *UserMutation) ModelMapping() (r map[string]string, exists bool) {
+	v := m.model_mapping
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldModelMapping returns the old "model_mapping" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldModelMapping(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModelMapping is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModelMapping requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModelMapping: %w", err)
+	}
+	return oldValue.ModelMapping, nil
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (m *UserMutation) ClearModelMapping() {
+	m.model_mapping = nil
+	m.clearedFields[user.FieldModelMapping] = struct{}{}
+}
+
+// ModelMappingCleared returns if the "model_mapping" field was cleared in this mutation.
+func (m *UserMutation) ModelMappingCleared() bool {
+	_, ok := m.clearedFields[user.FieldModelMapping]
+	return ok
+}
+
+// ResetModelMapping resets all changes to the "model_mapping" field.
+func (m *UserMutation) ResetModelMapping() {
+	m.model_mapping = nil
+	delete(m.clearedFields, user.FieldModelMapping)
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
 func (m *UserMutation) AddAPIKeyIDs(ids ...int64) {
 	if m.api_keys == nil {
@@ -19208,7 +21569,7 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 14)
+	fields := make([]string, 0, 15)
 	if m.created_at != nil {
 		fields = append(fields, user.FieldCreatedAt)
 	}
@@ -19251,6 +21612,9 @@ func (m *UserMutation) Fields() []string {
 	if m.totp_enabled_at != nil {
 		fields = append(fields, user.FieldTotpEnabledAt)
 	}
+	if m.model_mapping != nil {
+		fields = append(fields, user.FieldModelMapping)
+	}
 	return fields
 }
 
@@ -19287,6 +21651,8 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.TotpEnabled()
 	case user.FieldTotpEnabledAt:
 		return m.TotpEnabledAt()
+	case user.FieldModelMapping:
+		return m.ModelMapping()
 	}
 	return nil, false
 }
@@ -19324,6 +21690,8 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldTotpEnabled(ctx)
 	case user.FieldTotpEnabledAt:
 		return m.OldTotpEnabledAt(ctx)
+	case user.FieldModelMapping:
+		return m.OldModelMapping(ctx)
 	}
 	return nil, fmt.Errorf("unknown User field %s", name)
 }
@@ -19431,6 +21799,13 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetTotpEnabledAt(v)
 		return nil
+	case user.FieldModelMapping:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModelMapping(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -19497,6 +21872,9 @@ func (m *UserMutation) ClearedFields() []string {
 	if m.FieldCleared(user.FieldTotpEnabledAt) {
 		fields = append(fields, user.FieldTotpEnabledAt)
 	}
+	if m.FieldCleared(user.FieldModelMapping) {
+		fields = append(fields, user.FieldModelMapping)
+	}
 	return fields
 }
 
@@ -19520,6 +21898,9 @@ func (m *UserMutation) ClearField(name string) error {
 	case user.FieldTotpEnabledAt:
 		m.ClearTotpEnabledAt()
 		return nil
+	case user.FieldModelMapping:
+		m.ClearModelMapping()
+		return nil
 	}
 	return fmt.Errorf("unknown User nullable field %s", name)
 }
@@ -19570,6 +21951,9 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldTotpEnabledAt:
 		m.ResetTotpEnabledAt()
 		return nil
+	case user.FieldModelMapping:
+		m.ResetModelMapping()
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
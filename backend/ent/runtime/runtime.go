@@ -17,6 +17,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/proxy"
 	"github.com/Wei-Shaw/sub2api/ent/redeemcode"
 	"github.com/Wei-Shaw/sub2api/ent/schema"
+	"github.com/Wei-Shaw/sub2api/ent/sessionbinding"
 	"github.com/Wei-Shaw/sub2api/ent/setting"
 	"github.com/Wei-Shaw/sub2api/ent/usagecleanuptask"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
@@ -189,36 +190,86 @@ func init() {
 	accountDescPriority := accountFields[8].Descriptor()
 	// account.DefaultPriority holds the default value on creation for the priority field.
 	account.DefaultPriority = accountDescPriority.Default.(int)
+	// accountDescAffinityGroup is the schema descriptor for affinity_group field.
+	accountDescAffinityGroup := accountFields[9].Descriptor()
+	// account.DefaultAffinityGroup holds the default value on creation for the affinity_group field.
+	account.DefaultAffinityGroup = accountDescAffinityGroup.Default.(string)
+	// account.AffinityGroupValidator is a validator for the "affinity_group" field. It is called by the builders before save.
+	account.AffinityGroupValidator = accountDescAffinityGroup.Validators[0].(func(string) error)
+	// accountDescMaxLineSize is the schema descriptor for max_line_size field.
+	accountDescMaxLineSize := accountFields[10].Descriptor()
+	// account.DefaultMaxLineSize holds the default value on creation for the max_line_size field.
+	account.DefaultMaxLineSize = accountDescMaxLineSize.Default.(int)
 	// accountDescRateMultiplier is the schema descriptor for rate_multiplier field.
-	accountDescRateMultiplier := accountFields[9].Descriptor()
+	accountDescRateMultiplier := accountFields[11].Descriptor()
 	// account.DefaultRateMultiplier holds the default value on creation for the rate_multiplier field.
 	account.DefaultRateMultiplier = accountDescRateMultiplier.Default.(float64)
 	// accountDescStatus is the schema descriptor for status field.
-	accountDescStatus := accountFields[10].Descriptor()
+	accountDescStatus := accountFields[12].Descriptor()
 	// account.DefaultStatus holds the default value on creation for the status field.
 	account.DefaultStatus = accountDescStatus.Default.(string)
 	// account.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	account.StatusValidator = accountDescStatus.Validators[0].(func(string) error)
 	// accountDescAutoPauseOnExpired is the schema descriptor for auto_pause_on_expired field.
-	accountDescAutoPauseOnExpired := accountFields[14].Descriptor()
+	accountDescAutoPauseOnExpired := accountFields[16].Descriptor()
 	// account.DefaultAutoPauseOnExpired holds the default value on creation for the auto_pause_on_expired field.
 	account.DefaultAutoPauseOnExpired = accountDescAutoPauseOnExpired.Default.(bool)
 	// accountDescSchedulable is the schema descriptor for schedulable field.
-	accountDescSchedulable := accountFields[15].Descriptor()
+	accountDescSchedulable := accountFields[17].Descriptor()
 	// account.DefaultSchedulable holds the default value on creation for the schedulable field.
 	account.DefaultSchedulable = accountDescSchedulable.Default.(bool)
 	// accountDescSessionWindowStatus is the schema descriptor for session_window_status field.
-	accountDescSessionWindowStatus := accountFields[21].Descriptor()
+	accountDescSessionWindowStatus := accountFields[23].Descriptor()
 	// account.SessionWindowStatusValidator is a validator for the "session_window_status" field. It is called by the builders before save.
 	account.SessionWindowStatusValidator = accountDescSessionWindowStatus.Validators[0].(func(string) error)
+	// accountDescQuietHoursStartMinute is the schema descriptor for quiet_hours_start_minute field.
+	accountDescQuietHoursStartMinute := accountFields[25].Descriptor()
+	// account.QuietHoursStartMinuteValidator is a validator for the "quiet_hours_start_minute" field. It is called by the builders before save.
+	account.QuietHoursStartMinuteValidator = func() func(int) error {
+		validators := accountDescQuietHoursStartMinute.Validators
+		fns := [...]func(int) error{
+			validators[0].(func(int) error),
+			validators[1].(func(int) error),
+		}
+		return func(quiet_hours_start_minute int) error {
+			for _, fn := range fns {
+				if err := fn(quiet_hours_start_minute); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// accountDescQuietHoursEndMinute is the schema descriptor for quiet_hours_end_minute field.
+	accountDescQuietHoursEndMinute := accountFields[26].Descriptor()
+	// account.QuietHoursEndMinuteValidator is a validator for the "quiet_hours_end_minute" field. It is called by the builders before save.
+	account.QuietHoursEndMinuteValidator = func() func(int) error {
+		validators := accountDescQuietHoursEndMinute.Validators
+		fns := [...]func(int) error{
+			validators[0].(func(int) error),
+			validators[1].(func(int) error),
+		}
+		return func(quiet_hours_end_minute int) error {
+			for _, fn := range fns {
+				if err := fn(quiet_hours_end_minute); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
 	accountgroupFields := schema.AccountGroup{}.Fields()
 	_ = accountgroupFields
 	// accountgroupDescPriority is the schema descriptor for priority field.
 	accountgroupDescPriority := accountgroupFields[2].Descriptor()
 	// accountgroup.DefaultPriority holds the default value on creation for the priority field.
 	accountgroup.DefaultPriority = accountgroupDescPriority.Default.(int)
+	// accountgroupDescReservedSlots is the schema descriptor for reserved_slots field.
+	accountgroupDescReservedSlots := accountgroupFields[3].Descriptor()
+	// accountgroup.DefaultReservedSlots holds the default value on creation for the reserved_slots field.
+	accountgroup.DefaultReservedSlots = accountgroupDescReservedSlots.Default.(int)
 	// accountgroupDescCreatedAt is the schema descriptor for created_at field.
-	accountgroupDescCreatedAt := accountgroupFields[3].Descriptor()
+	accountgroupDescCreatedAt := accountgroupFields[4].Descriptor()
 	// accountgroup.DefaultCreatedAt holds the default value on creation for the created_at field.
 	accountgroup.DefaultCreatedAt = accountgroupDescCreatedAt.Default.(func() time.Time)
 	announcementFields := schema.Announcement{}.Fields()
@@ -393,30 +444,58 @@ func init() {
 	group.DefaultSubscriptionType = groupDescSubscriptionType.Default.(string)
 	// group.SubscriptionTypeValidator is a validator for the "subscription_type" field. It is called by the builders before save.
 	group.SubscriptionTypeValidator = groupDescSubscriptionType.Validators[0].(func(string) error)
+	// groupDescCurrency is the schema descriptor for currency field.
+	groupDescCurrency := groupFields[7].Descriptor()
+	// group.DefaultCurrency holds the default value on creation for the currency field.
+	group.DefaultCurrency = groupDescCurrency.Default.(string)
+	// group.CurrencyValidator is a validator for the "currency" field. It is called by the builders before save.
+	group.CurrencyValidator = groupDescCurrency.Validators[0].(func(string) error)
 	// groupDescDefaultValidityDays is the schema descriptor for default_validity_days field.
-	groupDescDefaultValidityDays := groupFields[10].Descriptor()
+	groupDescDefaultValidityDays := groupFields[11].Descriptor()
 	// group.DefaultDefaultValidityDays holds the default value on creation for the default_validity_days field.
 	group.DefaultDefaultValidityDays = groupDescDefaultValidityDays.Default.(int)
 	// groupDescClaudeCodeOnly is the schema descriptor for claude_code_only field.
-	groupDescClaudeCodeOnly := groupFields[14].Descriptor()
+	groupDescClaudeCodeOnly := groupFields[15].Descriptor()
 	// group.DefaultClaudeCodeOnly holds the default value on creation for the claude_code_only field.
 	group.DefaultClaudeCodeOnly = groupDescClaudeCodeOnly.Default.(bool)
 	// groupDescModelRoutingEnabled is the schema descriptor for model_routing_enabled field.
-	groupDescModelRoutingEnabled := groupFields[18].Descriptor()
+	groupDescModelRoutingEnabled := groupFields[19].Descriptor()
 	// group.DefaultModelRoutingEnabled holds the default value on creation for the model_routing_enabled field.
 	group.DefaultModelRoutingEnabled = groupDescModelRoutingEnabled.Default.(bool)
 	// groupDescMcpXMLInject is the schema descriptor for mcp_xml_inject field.
-	groupDescMcpXMLInject := groupFields[19].Descriptor()
+	groupDescMcpXMLInject := groupFields[20].Descriptor()
 	// group.DefaultMcpXMLInject holds the default value on creation for the mcp_xml_inject field.
 	group.DefaultMcpXMLInject = groupDescMcpXMLInject.Default.(bool)
 	// groupDescSupportedModelScopes is the schema descriptor for supported_model_scopes field.
-	groupDescSupportedModelScopes := groupFields[20].Descriptor()
+	groupDescSupportedModelScopes := groupFields[21].Descriptor()
 	// group.DefaultSupportedModelScopes holds the default value on creation for the supported_model_scopes field.
 	group.DefaultSupportedModelScopes = groupDescSupportedModelScopes.Default.([]string)
 	// groupDescSortOrder is the schema descriptor for sort_order field.
-	groupDescSortOrder := groupFields[21].Descriptor()
+	groupDescSortOrder := groupFields[22].Descriptor()
 	// group.DefaultSortOrder holds the default value on creation for the sort_order field.
 	group.DefaultSortOrder = groupDescSortOrder.Default.(int)
+	// groupDescDisableMetadataRewrite is the schema descriptor for disable_metadata_rewrite field.
+	groupDescDisableMetadataRewrite := groupFields[23].Descriptor()
+	// group.DefaultDisableMetadataRewrite holds the default value on creation for the disable_metadata_rewrite field.
+	group.DefaultDisableMetadataRewrite = groupDescDisableMetadataRewrite.Default.(bool)
+	// groupDescSubscriptionOverflowPolicy is the schema descriptor for subscription_overflow_policy field.
+	groupDescSubscriptionOverflowPolicy := groupFields[27].Descriptor()
+	// group.DefaultSubscriptionOverflowPolicy holds the default value on creation for the subscription_overflow_policy field.
+	group.DefaultSubscriptionOverflowPolicy = groupDescSubscriptionOverflowPolicy.Default.(string)
+	// group.SubscriptionOverflowPolicyValidator is a validator for the "subscription_overflow_policy" field. It is called by the builders before save.
+	group.SubscriptionOverflowPolicyValidator = groupDescSubscriptionOverflowPolicy.Validators[0].(func(string) error)
+	// groupDescIntentRoutingEnabled is the schema descriptor for intent_routing_enabled field.
+	groupDescIntentRoutingEnabled := groupFields[29].Descriptor()
+	// group.DefaultIntentRoutingEnabled holds the default value on creation for the intent_routing_enabled field.
+	group.DefaultIntentRoutingEnabled = groupDescIntentRoutingEnabled.Default.(bool)
+	// groupDescRequireAnthropicVersion is the schema descriptor for require_anthropic_version field.
+	groupDescRequireAnthropicVersion := groupFields[31].Descriptor()
+	// group.DefaultRequireAnthropicVersion holds the default value on creation for the require_anthropic_version field.
+	group.DefaultRequireAnthropicVersion = groupDescRequireAnthropicVersion.Default.(bool)
+	// groupDescMixedSchedulingNativeSaturationOnly is the schema descriptor for mixed_scheduling_native_saturation_only field.
+	groupDescMixedSchedulingNativeSaturationOnly := groupFields[33].Descriptor()
+	// group.DefaultMixedSchedulingNativeSaturationOnly holds the default value on creation for the mixed_scheduling_native_saturation_only field.
+	group.DefaultMixedSchedulingNativeSaturationOnly = groupDescMixedSchedulingNativeSaturationOnly.Default.(bool)
 	promocodeFields := schema.PromoCode{}.Fields()
 	_ = promocodeFields
 	// promocodeDescCode is the schema descriptor for code field.
@@ -602,6 +681,39 @@ func init() {
 	redeemcodeDescValidityDays := redeemcodeFields[9].Descriptor()
 	// redeemcode.DefaultValidityDays holds the default value on creation for the validity_days field.
 	redeemcode.DefaultValidityDays = redeemcodeDescValidityDays.Default.(int)
+	sessionbindingMixin := schema.SessionBinding{}.Mixin()
+	sessionbindingMixinFields0 := sessionbindingMixin[0].Fields()
+	_ = sessionbindingMixinFields0
+	sessionbindingFields := schema.SessionBinding{}.Fields()
+	_ = sessionbindingFields
+	// sessionbindingDescCreatedAt is the schema descriptor for created_at field.
+	sessionbindingDescCreatedAt := sessionbindingMixinFields0[0].Descriptor()
+	// sessionbinding.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sessionbinding.DefaultCreatedAt = sessionbindingDescCreatedAt.Default.(func() time.Time)
+	// sessionbindingDescUpdatedAt is the schema descriptor for updated_at field.
+	sessionbindingDescUpdatedAt := sessionbindingMixinFields0[1].Descriptor()
+	// sessionbinding.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	sessionbinding.DefaultUpdatedAt = sessionbindingDescUpdatedAt.Default.(func() time.Time)
+	// sessionbinding.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	sessionbinding.UpdateDefaultUpdatedAt = sessionbindingDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// sessionbindingDescSessionHash is the schema descriptor for session_hash field.
+	sessionbindingDescSessionHash := sessionbindingFields[1].Descriptor()
+	// sessionbinding.SessionHashValidator is a validator for the "session_hash" field. It is called by the builders before save.
+	sessionbinding.SessionHashValidator = func() func(string) error {
+		validators := sessionbindingDescSessionHash.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(session_hash string) error {
+			for _, fn := range fns {
+				if err := fn(session_hash); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
 	settingFields := schema.Setting{}.Fields()
 	_ = settingFields
 	// settingDescKey is the schema descriptor for key field.
@@ -102,6 +102,18 @@ func (Account) Fields() []ent.Field {
 		field.Int("priority").
 			Default(50),
 
+		// affinity_group: 账号亲和分组，用于多账号共享缓存/区域等场景
+		// 故障转移时优先选择同一亲和分组内的其他账号，再退化到其他账号；空字符串表示不属于任何亲和分组
+		field.String("affinity_group").
+			Optional().
+			MaxLen(100).
+			Default(""),
+
+		// max_line_size: 该账号流式响应单行缓冲区上限（字节），用于覆盖全局 Gateway.MaxLineSize
+		// 0 表示不覆盖，按全局配置/默认值处理；部分上游（图片、超大工具输出）需要更大的单账号缓冲区
+		field.Int("max_line_size").
+			Default(0),
+
 		// rate_multiplier: 账号计费倍率（>=0，允许 0 表示该账号计费为 0）
 		// 仅影响账号维度计费口径，不影响用户/API Key 扣费（分组倍率）
 		field.Float("rate_multiplier").
@@ -178,6 +190,24 @@ func (Account) Fields() []ent.Field {
 			Optional().
 			Nillable().
 			MaxLen(20),
+		// session_window_utilization: 当前 5h 窗口已使用百分比（0-100），来自上游
+		// anthropic-ratelimit-unified-5h-utilization 响应头，供管理端查看账号接近限流上限的程度
+		field.Int("session_window_utilization").
+			Optional().
+			Nillable(),
+		// quiet_hours_start_minute / quiet_hours_end_minute: 账号"静默时段"配置（UTC，
+		// 一天内分钟数 0-1439），用于在已知的运维窗口内抑制该账号健康状态变化的对外通知
+		// （禁用/限流），但不影响状态本身的流转。两者都为空表示未配置静默时段。
+		field.Int("quiet_hours_start_minute").
+			Optional().
+			Nillable().
+			Min(0).
+			Max(1439),
+		field.Int("quiet_hours_end_minute").
+			Optional().
+			Nillable().
+			Min(0).
+			Max(1439),
 	}
 }
 
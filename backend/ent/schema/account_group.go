@@ -32,6 +32,9 @@ func (AccountGroup) Fields() []ent.Field {
 		field.Int64("group_id"),
 		field.Int("priority").
 			Default(50),
+		field.Int("reserved_slots").
+			Default(0).
+			Comment("为该分组在此账号上预留的并发槽位数；预留槽位只能被该分组占用，超出部分（账号总并发 - 预留槽位）由绑定该账号的所有分组共享"),
 		field.Time("created_at").
 			Immutable().
 			Default(time.Now).
@@ -58,6 +58,10 @@ func (Group) Fields() []ent.Field {
 		field.String("subscription_type").
 			MaxLen(20).
 			Default(domain.SubscriptionTypeStandard),
+		field.String("currency").
+			MaxLen(10).
+			Default(domain.CurrencyUSD).
+			Comment("计费展示货币代码，仅影响前端展示（如预估费用、用量账单），内部计费计算始终以美元为基准单位"),
 		field.Float("daily_limit_usd").
 			Optional().
 			Nillable().
@@ -126,6 +130,80 @@ func (Group) Fields() []ent.Field {
 		field.Int("sort_order").
 			Default(0).
 			Comment("分组显示排序，数值越小越靠前"),
+
+		// OAuth metadata.user_id 重写开关 (added by migration 056)
+		field.Bool("disable_metadata_rewrite").
+			Default(false).
+			Comment("是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写，透传客户端原始 metadata"),
+
+		// 单次请求最大消息数限制 (added by migration 057)
+		field.Int("max_messages").
+			Optional().
+			Nillable().
+			Comment("单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；NULL 表示使用全局配置"),
+
+		// 分组每日请求次数限额
+		field.Int("daily_request_limit").
+			Optional().
+			Nillable().
+			Comment("分组每日请求次数上限，与 daily_limit_usd 的费用限额相互独立；NULL 或 <=0 表示不限制"),
+
+		// 分组级上游默认请求头 (added by migration 061)
+		field.JSON("upstream_headers", map[string]string{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("分组级上游默认请求头，在构建上游请求时应用于该分组下的 API-key 账号；认证类头部不受影响"),
+
+		// 订阅/余额兼有时的计费策略 (added by migration 062)
+		field.String("subscription_overflow_policy").
+			MaxLen(20).
+			Default(domain.SubscriptionOverflowPolicySubscriptionOnly).
+			Comment("订阅限额用尽后的计费策略：subscription_only 始终按订阅计费，fallback_balance 改为从余额扣费"),
+
+		// 请求 intent 路由配置 (added by migration 063)
+		field.JSON("intent_routing", map[string][]int64{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("Intent 路由配置：{\"intent\": [account_id1, account_id2], ...}，按 x-sub2api-intent 请求头精确匹配"),
+
+		// Intent 路由开关 (added by migration 064)
+		field.Bool("intent_routing_enabled").
+			Default(false).
+			Comment("是否启用 Intent 路由配置"),
+
+		// 允许的上游端点白名单 (added by migration 065)
+		field.JSON("allowed_endpoints", []string{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("允许的上游端点白名单：messages, count_tokens，为空表示不限制"),
+
+		// anthropic-version 请求头强制校验开关 (added by migration 067)
+		field.Bool("require_anthropic_version").
+			Default(false).
+			Comment("是否要求客户端必须携带 anthropic-version 请求头，缺失时拒绝请求而非默认填充"),
+
+		// 流式响应输出 token 硬上限 (added by migration 069)
+		field.Int("max_output_tokens").
+			Optional().
+			Nillable().
+			Comment("流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发；NULL 表示不限制"),
+
+		// 混合调度严格 fallback 开关 (added by migration 070)
+		field.Bool("mixed_scheduling_native_saturation_only").
+			Default(false).
+			Comment("混合调度下是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户"),
+
+		// 分组级窗口费用上限 (added by migration 073)
+		field.Float("window_cost_limit_usd").
+			Optional().
+			Nillable().
+			Comment("分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；NULL 或 <=0 表示不限制"),
+
+		// 分组级窗口费用滚动窗口时长 (added by migration 073)
+		field.Int("window_cost_window_hours").
+			Optional().
+			Nillable().
+			Comment("window_cost_limit_usd 对应的滚动窗口时长（小时）；NULL 或 <=0 时默认为 5 小时"),
 	}
 }
 
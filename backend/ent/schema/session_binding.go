@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+)
+
+// SessionBinding holds the schema definition for the SessionBinding entity.
+//
+// 粘性会话绑定的写穿持久化：GatewayCache 中的粘性会话绑定只存在于缓存（Redis/内存），
+// 缓存被清空后正在进行的 Claude Code 会话会中途切换账号，破坏 prompt caching。
+// 这张表在 gateway.scheduling.persist_sticky_sessions 开启时作为缓存未命中的兜底来源。
+type SessionBinding struct {
+	ent.Schema
+}
+
+func (SessionBinding) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "session_bindings"},
+	}
+}
+
+func (SessionBinding) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+func (SessionBinding) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("group_id").
+			Comment("所属分组ID，0 表示不分组的全局会话"),
+		field.String("session_hash").
+			MaxLen(128).
+			NotEmpty().
+			Comment("粘性会话哈希"),
+		field.Int64("account_id").
+			Comment("绑定的账号ID"),
+		field.Time("expires_at").
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}).
+			Comment("绑定过期时间，用于 TTL 清理"),
+	}
+}
+
+func (SessionBinding) Edges() []ent.Edge {
+	return nil
+}
+
+func (SessionBinding) Indexes() []ent.Index {
+	return []ent.Index{
+		// 每个分组下每个会话哈希只保留一条绑定记录
+		index.Fields("group_id", "session_hash").Unique(),
+		index.Fields("expires_at"),
+	}
+}
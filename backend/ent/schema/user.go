@@ -72,6 +72,12 @@ func (User) Fields() []ent.Field {
 		field.Time("totp_enabled_at").
 			Optional().
 			Nillable(),
+
+		// 用户级默认模型映射 (added by migration 066)
+		field.JSON("model_mapping", map[string]string{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("用户级默认模型映射：{\"requested_model\": \"target_model\"}，在分组/账号映射之前应用，支持通配符"),
 	}
 }
 
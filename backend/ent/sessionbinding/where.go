@@ -0,0 +1,365 @@
+// Code generated by ent, DO NOT EDIT.
+
+package sessionbinding
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// GroupID applies equality check predicate on the "group_id" field. It's identical to GroupIDEQ.
+func GroupID(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldGroupID, v))
+}
+
+// SessionHash applies equality check predicate on the "session_hash" field. It's identical to SessionHashEQ.
+func SessionHash(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldSessionHash, v))
+}
+
+// AccountID applies equality check predicate on the "account_id" field. It's identical to AccountIDEQ.
+func AccountID(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldAccountID, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// GroupIDEQ applies the EQ predicate on the "group_id" field.
+func GroupIDEQ(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldGroupID, v))
+}
+
+// GroupIDNEQ applies the NEQ predicate on the "group_id" field.
+func GroupIDNEQ(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldGroupID, v))
+}
+
+// GroupIDIn applies the In predicate on the "group_id" field.
+func GroupIDIn(vs ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldGroupID, vs...))
+}
+
+// GroupIDNotIn applies the NotIn predicate on the "group_id" field.
+func GroupIDNotIn(vs ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldGroupID, vs...))
+}
+
+// GroupIDGT applies the GT predicate on the "group_id" field.
+func GroupIDGT(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldGroupID, v))
+}
+
+// GroupIDGTE applies the GTE predicate on the "group_id" field.
+func GroupIDGTE(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldGroupID, v))
+}
+
+// GroupIDLT applies the LT predicate on the "group_id" field.
+func GroupIDLT(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldGroupID, v))
+}
+
+// GroupIDLTE applies the LTE predicate on the "group_id" field.
+func GroupIDLTE(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldGroupID, v))
+}
+
+// SessionHashEQ applies the EQ predicate on the "session_hash" field.
+func SessionHashEQ(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldSessionHash, v))
+}
+
+// SessionHashNEQ applies the NEQ predicate on the "session_hash" field.
+func SessionHashNEQ(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldSessionHash, v))
+}
+
+// SessionHashIn applies the In predicate on the "session_hash" field.
+func SessionHashIn(vs ...string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldSessionHash, vs...))
+}
+
+// SessionHashNotIn applies the NotIn predicate on the "session_hash" field.
+func SessionHashNotIn(vs ...string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldSessionHash, vs...))
+}
+
+// SessionHashGT applies the GT predicate on the "session_hash" field.
+func SessionHashGT(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldSessionHash, v))
+}
+
+// SessionHashGTE applies the GTE predicate on the "session_hash" field.
+func SessionHashGTE(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldSessionHash, v))
+}
+
+// SessionHashLT applies the LT predicate on the "session_hash" field.
+func SessionHashLT(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldSessionHash, v))
+}
+
+// SessionHashLTE applies the LTE predicate on the "session_hash" field.
+func SessionHashLTE(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldSessionHash, v))
+}
+
+// SessionHashContains applies the Contains predicate on the "session_hash" field.
+func SessionHashContains(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldContains(FieldSessionHash, v))
+}
+
+// SessionHashHasPrefix applies the HasPrefix predicate on the "session_hash" field.
+func SessionHashHasPrefix(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldHasPrefix(FieldSessionHash, v))
+}
+
+// SessionHashHasSuffix applies the HasSuffix predicate on the "session_hash" field.
+func SessionHashHasSuffix(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldHasSuffix(FieldSessionHash, v))
+}
+
+// SessionHashEqualFold applies the EqualFold predicate on the "session_hash" field.
+func SessionHashEqualFold(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEqualFold(FieldSessionHash, v))
+}
+
+// SessionHashContainsFold applies the ContainsFold predicate on the "session_hash" field.
+func SessionHashContainsFold(v string) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldContainsFold(FieldSessionHash, v))
+}
+
+// AccountIDEQ applies the EQ predicate on the "account_id" field.
+func AccountIDEQ(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldAccountID, v))
+}
+
+// AccountIDNEQ applies the NEQ predicate on the "account_id" field.
+func AccountIDNEQ(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldAccountID, v))
+}
+
+// AccountIDIn applies the In predicate on the "account_id" field.
+func AccountIDIn(vs ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldAccountID, vs...))
+}
+
+// AccountIDNotIn applies the NotIn predicate on the "account_id" field.
+func AccountIDNotIn(vs ...int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldAccountID, vs...))
+}
+
+// AccountIDGT applies the GT predicate on the "account_id" field.
+func AccountIDGT(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldAccountID, v))
+}
+
+// AccountIDGTE applies the GTE predicate on the "account_id" field.
+func AccountIDGTE(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldAccountID, v))
+}
+
+// AccountIDLT applies the LT predicate on the "account_id" field.
+func AccountIDLT(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldAccountID, v))
+}
+
+// AccountIDLTE applies the LTE predicate on the "account_id" field.
+func AccountIDLTE(v int64) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldAccountID, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SessionBinding) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SessionBinding) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SessionBinding) predicate.SessionBinding {
+	return predicate.SessionBinding(sql.NotPredicates(p))
+}
@@ -0,0 +1,767 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/sessionbinding"
+)
+
+// SessionBindingCreate is the builder for creating a SessionBinding entity.
+type SessionBindingCreate struct {
+	config
+	mutation *SessionBindingMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SessionBindingCreate) SetCreatedAt(v time.Time) *SessionBindingCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SessionBindingCreate) SetNillableCreatedAt(v *time.Time) *SessionBindingCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *SessionBindingCreate) SetUpdatedAt(v time.Time) *SessionBindingCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *SessionBindingCreate) SetNillableUpdatedAt(v *time.Time) *SessionBindingCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetGroupID sets the "group_id" field.
+func (_c *SessionBindingCreate) SetGroupID(v int64) *SessionBindingCreate {
+	_c.mutation.SetGroupID(v)
+	return _c
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (_c *SessionBindingCreate) SetSessionHash(v string) *SessionBindingCreate {
+	_c.mutation.SetSessionHash(v)
+	return _c
+}
+
+// SetAccountID sets the "account_id" field.
+func (_c *SessionBindingCreate) SetAccountID(v int64) *SessionBindingCreate {
+	_c.mutation.SetAccountID(v)
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *SessionBindingCreate) SetExpiresAt(v time.Time) *SessionBindingCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// Mutation returns the SessionBindingMutation object of the builder.
+func (_c *SessionBindingCreate) Mutation() *SessionBindingMutation {
+	return _c.mutation
+}
+
+// Save creates the SessionBinding in the database.
+func (_c *SessionBindingCreate) Save(ctx context.Context) (*SessionBinding, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SessionBindingCreate) SaveX(ctx context.Context) *SessionBinding {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionBindingCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionBindingCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SessionBindingCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := sessionbinding.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := sessionbinding.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SessionBindingCreate) check() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SessionBinding.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "SessionBinding.updated_at"`)}
+	}
+	if _, ok := _c.mutation.GroupID(); !ok {
+		return &ValidationError{Name: "group_id", err: errors.New(`ent: missing required field "SessionBinding.group_id"`)}
+	}
+	if _, ok := _c.mutation.SessionHash(); !ok {
+		return &ValidationError{Name: "session_hash", err: errors.New(`ent: missing required field "SessionBinding.session_hash"`)}
+	}
+	if v, ok := _c.mutation.SessionHash(); ok {
+		if err := sessionbinding.SessionHashValidator(v); err != nil {
+			return &ValidationError{Name: "session_hash", err: fmt.Errorf(`ent: validator failed for field "SessionBinding.session_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.AccountID(); !ok {
+		return &ValidationError{Name: "account_id", err: errors.New(`ent: missing required field "SessionBinding.account_id"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "SessionBinding.expires_at"`)}
+	}
+	return nil
+}
+
+func (_c *SessionBindingCreate) sqlSave(ctx context.Context) (*SessionBinding, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SessionBindingCreate) createSpec() (*SessionBinding, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SessionBinding{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(sessionbinding.Table, sqlgraph.NewFieldSpec(sessionbinding.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(sessionbinding.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(sessionbinding.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.GroupID(); ok {
+		_spec.SetField(sessionbinding.FieldGroupID, field.TypeInt64, value)
+		_node.GroupID = value
+	}
+	if value, ok := _c.mutation.SessionHash(); ok {
+		_spec.SetField(sessionbinding.FieldSessionHash, field.TypeString, value)
+		_node.SessionHash = value
+	}
+	if value, ok := _c.mutation.AccountID(); ok {
+		_spec.SetField(sessionbinding.FieldAccountID, field.TypeInt64, value)
+		_node.AccountID = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(sessionbinding.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.SessionBinding.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SessionBindingUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *SessionBindingCreate) OnConflict(opts ...sql.ConflictOption) *SessionBindingUpsertOne {
+	_c.conflict = opts
+	return &SessionBindingUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *SessionBindingCreate) OnConflictColumns(columns ...string) *SessionBindingUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &SessionBindingUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// SessionBindingUpsertOne is the builder for "upsert"-ing
+	//  one SessionBinding node.
+	SessionBindingUpsertOne struct {
+		create *SessionBindingCreate
+	}
+
+	// SessionBindingUpsert is the "OnConflict" setter.
+	SessionBindingUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SessionBindingUpsert) SetUpdatedAt(v time.Time) *SessionBindingUpsert {
+	u.Set(sessionbinding.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SessionBindingUpsert) UpdateUpdatedAt() *SessionBindingUpsert {
+	u.SetExcluded(sessionbinding.FieldUpdatedAt)
+	return u
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *SessionBindingUpsert) SetGroupID(v int64) *SessionBindingUpsert {
+	u.Set(sessionbinding.FieldGroupID, v)
+	return u
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *SessionBindingUpsert) UpdateGroupID() *SessionBindingUpsert {
+	u.SetExcluded(sessionbinding.FieldGroupID)
+	return u
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *SessionBindingUpsert) AddGroupID(v int64) *SessionBindingUpsert {
+	u.Add(sessionbinding.FieldGroupID, v)
+	return u
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (u *SessionBindingUpsert) SetSessionHash(v string) *SessionBindingUpsert {
+	u.Set(sessionbinding.FieldSessionHash, v)
+	return u
+}
+
+// UpdateSessionHash sets the "session_hash" field to the value that was provided on create.
+func (u *SessionBindingUpsert) UpdateSessionHash() *SessionBindingUpsert {
+	u.SetExcluded(sessionbinding.FieldSessionHash)
+	return u
+}
+
+// SetAccountID sets the "account_id" field.
+func (u *SessionBindingUpsert) SetAccountID(v int64) *SessionBindingUpsert {
+	u.Set(sessionbinding.FieldAccountID, v)
+	return u
+}
+
+// UpdateAccountID sets the "account_id" field to the value that was provided on create.
+func (u *SessionBindingUpsert) UpdateAccountID() *SessionBindingUpsert {
+	u.SetExcluded(sessionbinding.FieldAccountID)
+	return u
+}
+
+// AddAccountID adds v to the "account_id" field.
+func (u *SessionBindingUpsert) AddAccountID(v int64) *SessionBindingUpsert {
+	u.Add(sessionbinding.FieldAccountID, v)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *SessionBindingUpsert) SetExpiresAt(v time.Time) *SessionBindingUpsert {
+	u.Set(sessionbinding.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *SessionBindingUpsert) UpdateExpiresAt() *SessionBindingUpsert {
+	u.SetExcluded(sessionbinding.FieldExpiresAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SessionBindingUpsertOne) UpdateNewValues() *SessionBindingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(sessionbinding.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *SessionBindingUpsertOne) Ignore() *SessionBindingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SessionBindingUpsertOne) DoNothing() *SessionBindingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SessionBindingCreate.OnConflict
+// documentation for more info.
+func (u *SessionBindingUpsertOne) Update(set func(*SessionBindingUpsert)) *SessionBindingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SessionBindingUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SessionBindingUpsertOne) SetUpdatedAt(v time.Time) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SessionBindingUpsertOne) UpdateUpdatedAt() *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *SessionBindingUpsertOne) SetGroupID(v int64) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetGroupID(v)
+	})
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *SessionBindingUpsertOne) AddGroupID(v int64) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.AddGroupID(v)
+	})
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *SessionBindingUpsertOne) UpdateGroupID() *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateGroupID()
+	})
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (u *SessionBindingUpsertOne) SetSessionHash(v string) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetSessionHash(v)
+	})
+}
+
+// UpdateSessionHash sets the "session_hash" field to the value that was provided on create.
+func (u *SessionBindingUpsertOne) UpdateSessionHash() *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateSessionHash()
+	})
+}
+
+// SetAccountID sets the "account_id" field.
+func (u *SessionBindingUpsertOne) SetAccountID(v int64) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetAccountID(v)
+	})
+}
+
+// AddAccountID adds v to the "account_id" field.
+func (u *SessionBindingUpsertOne) AddAccountID(v int64) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.AddAccountID(v)
+	})
+}
+
+// UpdateAccountID sets the "account_id" field to the value that was provided on create.
+func (u *SessionBindingUpsertOne) UpdateAccountID() *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateAccountID()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *SessionBindingUpsertOne) SetExpiresAt(v time.Time) *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *SessionBindingUpsertOne) UpdateExpiresAt() *SessionBindingUpsertOne {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *SessionBindingUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SessionBindingCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SessionBindingUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *SessionBindingUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *SessionBindingUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// SessionBindingCreateBulk is the builder for creating many SessionBinding entities in bulk.
+type SessionBindingCreateBulk struct {
+	config
+	err      error
+	builders []*SessionBindingCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the SessionBinding entities in the database.
+func (_c *SessionBindingCreateBulk) Save(ctx context.Context) ([]*SessionBinding, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*SessionBinding, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SessionBindingMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SessionBindingCreateBulk) SaveX(ctx context.Context) []*SessionBinding {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionBindingCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionBindingCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.SessionBinding.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SessionBindingUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *SessionBindingCreateBulk) OnConflict(opts ...sql.ConflictOption) *SessionBindingUpsertBulk {
+	_c.conflict = opts
+	return &SessionBindingUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *SessionBindingCreateBulk) OnConflictColumns(columns ...string) *SessionBindingUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &SessionBindingUpsertBulk{
+		create: _c,
+	}
+}
+
+// SessionBindingUpsertBulk is the builder for "upsert"-ing
+// a bulk of SessionBinding nodes.
+type SessionBindingUpsertBulk struct {
+	create *SessionBindingCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SessionBindingUpsertBulk) UpdateNewValues() *SessionBindingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(sessionbinding.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.SessionBinding.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *SessionBindingUpsertBulk) Ignore() *SessionBindingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SessionBindingUpsertBulk) DoNothing() *SessionBindingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SessionBindingCreateBulk.OnConflict
+// documentation for more info.
+func (u *SessionBindingUpsertBulk) Update(set func(*SessionBindingUpsert)) *SessionBindingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SessionBindingUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SessionBindingUpsertBulk) SetUpdatedAt(v time.Time) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SessionBindingUpsertBulk) UpdateUpdatedAt() *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *SessionBindingUpsertBulk) SetGroupID(v int64) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetGroupID(v)
+	})
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *SessionBindingUpsertBulk) AddGroupID(v int64) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.AddGroupID(v)
+	})
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *SessionBindingUpsertBulk) UpdateGroupID() *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateGroupID()
+	})
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (u *SessionBindingUpsertBulk) SetSessionHash(v string) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetSessionHash(v)
+	})
+}
+
+// UpdateSessionHash sets the "session_hash" field to the value that was provided on create.
+func (u *SessionBindingUpsertBulk) UpdateSessionHash() *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateSessionHash()
+	})
+}
+
+// SetAccountID sets the "account_id" field.
+func (u *SessionBindingUpsertBulk) SetAccountID(v int64) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetAccountID(v)
+	})
+}
+
+// AddAccountID adds v to the "account_id" field.
+func (u *SessionBindingUpsertBulk) AddAccountID(v int64) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.AddAccountID(v)
+	})
+}
+
+// UpdateAccountID sets the "account_id" field to the value that was provided on create.
+func (u *SessionBindingUpsertBulk) UpdateAccountID() *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateAccountID()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *SessionBindingUpsertBulk) SetExpiresAt(v time.Time) *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *SessionBindingUpsertBulk) UpdateExpiresAt() *SessionBindingUpsertBulk {
+	return u.Update(func(s *SessionBindingUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *SessionBindingUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the SessionBindingCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SessionBindingCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SessionBindingUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,414 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+	"github.com/Wei-Shaw/sub2api/ent/sessionbinding"
+)
+
+// SessionBindingUpdate is the builder for updating SessionBinding entities.
+type SessionBindingUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SessionBindingMutation
+}
+
+// Where appends a list predicates to the SessionBindingUpdate builder.
+func (_u *SessionBindingUpdate) Where(ps ...predicate.SessionBinding) *SessionBindingUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *SessionBindingUpdate) SetUpdatedAt(v time.Time) *SessionBindingUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetGroupID sets the "group_id" field.
+func (_u *SessionBindingUpdate) SetGroupID(v int64) *SessionBindingUpdate {
+	_u.mutation.ResetGroupID()
+	_u.mutation.SetGroupID(v)
+	return _u
+}
+
+// SetNillableGroupID sets the "group_id" field if the given value is not nil.
+func (_u *SessionBindingUpdate) SetNillableGroupID(v *int64) *SessionBindingUpdate {
+	if v != nil {
+		_u.SetGroupID(*v)
+	}
+	return _u
+}
+
+// AddGroupID adds value to the "group_id" field.
+func (_u *SessionBindingUpdate) AddGroupID(v int64) *SessionBindingUpdate {
+	_u.mutation.AddGroupID(v)
+	return _u
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (_u *SessionBindingUpdate) SetSessionHash(v string) *SessionBindingUpdate {
+	_u.mutation.SetSessionHash(v)
+	return _u
+}
+
+// SetNillableSessionHash sets the "session_hash" field if the given value is not nil.
+func (_u *SessionBindingUpdate) SetNillableSessionHash(v *string) *SessionBindingUpdate {
+	if v != nil {
+		_u.SetSessionHash(*v)
+	}
+	return _u
+}
+
+// SetAccountID sets the "account_id" field.
+func (_u *SessionBindingUpdate) SetAccountID(v int64) *SessionBindingUpdate {
+	_u.mutation.ResetAccountID()
+	_u.mutation.SetAccountID(v)
+	return _u
+}
+
+// SetNillableAccountID sets the "account_id" field if the given value is not nil.
+func (_u *SessionBindingUpdate) SetNillableAccountID(v *int64) *SessionBindingUpdate {
+	if v != nil {
+		_u.SetAccountID(*v)
+	}
+	return _u
+}
+
+// AddAccountID adds value to the "account_id" field.
+func (_u *SessionBindingUpdate) AddAccountID(v int64) *SessionBindingUpdate {
+	_u.mutation.AddAccountID(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *SessionBindingUpdate) SetExpiresAt(v time.Time) *SessionBindingUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *SessionBindingUpdate) SetNillableExpiresAt(v *time.Time) *SessionBindingUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SessionBindingMutation object of the builder.
+func (_u *SessionBindingUpdate) Mutation() *SessionBindingMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SessionBindingUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionBindingUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SessionBindingUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionBindingUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *SessionBindingUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := sessionbinding.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionBindingUpdate) check() error {
+	if v, ok := _u.mutation.SessionHash(); ok {
+		if err := sessionbinding.SessionHashValidator(v); err != nil {
+			return &ValidationError{Name: "session_hash", err: fmt.Errorf(`ent: validator failed for field "SessionBinding.session_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionBindingUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sessionbinding.Table, sessionbinding.Columns, sqlgraph.NewFieldSpec(sessionbinding.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(sessionbinding.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.GroupID(); ok {
+		_spec.SetField(sessionbinding.FieldGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedGroupID(); ok {
+		_spec.AddField(sessionbinding.FieldGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.SessionHash(); ok {
+		_spec.SetField(sessionbinding.FieldSessionHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AccountID(); ok {
+		_spec.SetField(sessionbinding.FieldAccountID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedAccountID(); ok {
+		_spec.AddField(sessionbinding.FieldAccountID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(sessionbinding.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sessionbinding.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SessionBindingUpdateOne is the builder for updating a single SessionBinding entity.
+type SessionBindingUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SessionBindingMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *SessionBindingUpdateOne) SetUpdatedAt(v time.Time) *SessionBindingUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetGroupID sets the "group_id" field.
+func (_u *SessionBindingUpdateOne) SetGroupID(v int64) *SessionBindingUpdateOne {
+	_u.mutation.ResetGroupID()
+	_u.mutation.SetGroupID(v)
+	return _u
+}
+
+// SetNillableGroupID sets the "group_id" field if the given value is not nil.
+func (_u *SessionBindingUpdateOne) SetNillableGroupID(v *int64) *SessionBindingUpdateOne {
+	if v != nil {
+		_u.SetGroupID(*v)
+	}
+	return _u
+}
+
+// AddGroupID adds value to the "group_id" field.
+func (_u *SessionBindingUpdateOne) AddGroupID(v int64) *SessionBindingUpdateOne {
+	_u.mutation.AddGroupID(v)
+	return _u
+}
+
+// SetSessionHash sets the "session_hash" field.
+func (_u *SessionBindingUpdateOne) SetSessionHash(v string) *SessionBindingUpdateOne {
+	_u.mutation.SetSessionHash(v)
+	return _u
+}
+
+// SetNillableSessionHash sets the "session_hash" field if the given value is not nil.
+func (_u *SessionBindingUpdateOne) SetNillableSessionHash(v *string) *SessionBindingUpdateOne {
+	if v != nil {
+		_u.SetSessionHash(*v)
+	}
+	return _u
+}
+
+// SetAccountID sets the "account_id" field.
+func (_u *SessionBindingUpdateOne) SetAccountID(v int64) *SessionBindingUpdateOne {
+	_u.mutation.ResetAccountID()
+	_u.mutation.SetAccountID(v)
+	return _u
+}
+
+// SetNillableAccountID sets the "account_id" field if the given value is not nil.
+func (_u *SessionBindingUpdateOne) SetNillableAccountID(v *int64) *SessionBindingUpdateOne {
+	if v != nil {
+		_u.SetAccountID(*v)
+	}
+	return _u
+}
+
+// AddAccountID adds value to the "account_id" field.
+func (_u *SessionBindingUpdateOne) AddAccountID(v int64) *SessionBindingUpdateOne {
+	_u.mutation.AddAccountID(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *SessionBindingUpdateOne) SetExpiresAt(v time.Time) *SessionBindingUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *SessionBindingUpdateOne) SetNillableExpiresAt(v *time.Time) *SessionBindingUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SessionBindingMutation object of the builder.
+func (_u *SessionBindingUpdateOne) Mutation() *SessionBindingMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the SessionBindingUpdate builder.
+func (_u *SessionBindingUpdateOne) Where(ps ...predicate.SessionBinding) *SessionBindingUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SessionBindingUpdateOne) Select(field string, fields ...string) *SessionBindingUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated SessionBinding entity.
+func (_u *SessionBindingUpdateOne) Save(ctx context.Context) (*SessionBinding, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionBindingUpdateOne) SaveX(ctx context.Context) *SessionBinding {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SessionBindingUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionBindingUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *SessionBindingUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := sessionbinding.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionBindingUpdateOne) check() error {
+	if v, ok := _u.mutation.SessionHash(); ok {
+		if err := sessionbinding.SessionHashValidator(v); err != nil {
+			return &ValidationError{Name: "session_hash", err: fmt.Errorf(`ent: validator failed for field "SessionBinding.session_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionBindingUpdateOne) sqlSave(ctx context.Context) (_node *SessionBinding, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sessionbinding.Table, sessionbinding.Columns, sqlgraph.NewFieldSpec(sessionbinding.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SessionBinding.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, sessionbinding.FieldID)
+		for _, f := range fields {
+			if !sessionbinding.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != sessionbinding.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(sessionbinding.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.GroupID(); ok {
+		_spec.SetField(sessionbinding.FieldGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedGroupID(); ok {
+		_spec.AddField(sessionbinding.FieldGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.SessionHash(); ok {
+		_spec.SetField(sessionbinding.FieldSessionHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AccountID(); ok {
+		_spec.SetField(sessionbinding.FieldAccountID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedAccountID(); ok {
+		_spec.AddField(sessionbinding.FieldAccountID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(sessionbinding.FieldExpiresAt, field.TypeTime, value)
+	}
+	_node = &SessionBinding{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sessionbinding.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -860,6 +860,16 @@ func TotpEnabledAtNotNil() predicate.User {
 	return predicate.User(sql.FieldNotNull(FieldTotpEnabledAt))
 }
 
+// ModelMappingIsNil applies the IsNil predicate on the "model_mapping" field.
+func ModelMappingIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldModelMapping))
+}
+
+// ModelMappingNotNil applies the NotNil predicate on the "model_mapping" field.
+func ModelMappingNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldModelMapping))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
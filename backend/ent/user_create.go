@@ -210,6 +210,12 @@ func (_c *UserCreate) SetNillableTotpEnabledAt(v *time.Time) *UserCreate {
 	return _c
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (_c *UserCreate) SetModelMapping(v map[string]string) *UserCreate {
+	_c.mutation.SetModelMapping(v)
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *UserCreate) AddAPIKeyIDs(ids ...int64) *UserCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -570,6 +576,10 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldTotpEnabledAt, field.TypeTime, value)
 		_node.TotpEnabledAt = &value
 	}
+	if value, ok := _c.mutation.ModelMapping(); ok {
+		_spec.SetField(user.FieldModelMapping, field.TypeJSON, value)
+		_node.ModelMapping = value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -956,6 +966,24 @@ func (u *UserUpsert) ClearTotpEnabledAt() *UserUpsert {
 	return u
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (u *UserUpsert) SetModelMapping(v map[string]string) *UserUpsert {
+	u.Set(user.FieldModelMapping, v)
+	return u
+}
+
+// UpdateModelMapping sets the "model_mapping" field to the value that was provided on create.
+func (u *UserUpsert) UpdateModelMapping() *UserUpsert {
+	u.SetExcluded(user.FieldModelMapping)
+	return u
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (u *UserUpsert) ClearModelMapping() *UserUpsert {
+	u.SetNull(user.FieldModelMapping)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1218,6 +1246,27 @@ func (u *UserUpsertOne) ClearTotpEnabledAt() *UserUpsertOne {
 	})
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (u *UserUpsertOne) SetModelMapping(v map[string]string) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetModelMapping(v)
+	})
+}
+
+// UpdateModelMapping sets the "model_mapping" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateModelMapping() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateModelMapping()
+	})
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (u *UserUpsertOne) ClearModelMapping() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearModelMapping()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1646,6 +1695,27 @@ func (u *UserUpsertBulk) ClearTotpEnabledAt() *UserUpsertBulk {
 	})
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (u *UserUpsertBulk) SetModelMapping(v map[string]string) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetModelMapping(v)
+	})
+}
+
+// UpdateModelMapping sets the "model_mapping" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateModelMapping() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateModelMapping()
+	})
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (u *UserUpsertBulk) ClearModelMapping() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearModelMapping()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -242,6 +242,18 @@ func (_u *UserUpdate) ClearTotpEnabledAt() *UserUpdate {
 	return _u
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (_u *UserUpdate) SetModelMapping(v map[string]string) *UserUpdate {
+	_u.mutation.SetModelMapping(v)
+	return _u
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (_u *UserUpdate) ClearModelMapping() *UserUpdate {
+	_u.mutation.ClearModelMapping()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdate) AddAPIKeyIDs(ids ...int64) *UserUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -709,6 +721,12 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.TotpEnabledAtCleared() {
 		_spec.ClearField(user.FieldTotpEnabledAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.ModelMapping(); ok {
+		_spec.SetField(user.FieldModelMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.ModelMappingCleared() {
+		_spec.ClearField(user.FieldModelMapping, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1352,6 +1370,18 @@ func (_u *UserUpdateOne) ClearTotpEnabledAt() *UserUpdateOne {
 	return _u
 }
 
+// SetModelMapping sets the "model_mapping" field.
+func (_u *UserUpdateOne) SetModelMapping(v map[string]string) *UserUpdateOne {
+	_u.mutation.SetModelMapping(v)
+	return _u
+}
+
+// ClearModelMapping clears the value of the "model_mapping" field.
+func (_u *UserUpdateOne) ClearModelMapping() *UserUpdateOne {
+	_u.mutation.ClearModelMapping()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdateOne) AddAPIKeyIDs(ids ...int64) *UserUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -1849,6 +1879,12 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	if _u.mutation.TotpEnabledAtCleared() {
 		_spec.ClearField(user.FieldTotpEnabledAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.ModelMapping(); ok {
+		_spec.SetField(user.FieldModelMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.ModelMappingCleared() {
+		_spec.ClearField(user.FieldModelMapping, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
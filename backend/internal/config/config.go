@@ -38,36 +38,70 @@ const (
 )
 
 type Config struct {
-	Server       ServerConfig               `mapstructure:"server"`
-	CORS         CORSConfig                 `mapstructure:"cors"`
-	Security     SecurityConfig             `mapstructure:"security"`
-	Billing      BillingConfig              `mapstructure:"billing"`
-	Turnstile    TurnstileConfig            `mapstructure:"turnstile"`
-	Database     DatabaseConfig             `mapstructure:"database"`
-	Redis        RedisConfig                `mapstructure:"redis"`
-	Ops          OpsConfig                  `mapstructure:"ops"`
-	JWT          JWTConfig                  `mapstructure:"jwt"`
-	Totp         TotpConfig                 `mapstructure:"totp"`
-	LinuxDo      LinuxDoConnectConfig       `mapstructure:"linuxdo_connect"`
-	Default      DefaultConfig              `mapstructure:"default"`
-	RateLimit    RateLimitConfig            `mapstructure:"rate_limit"`
-	Pricing      PricingConfig              `mapstructure:"pricing"`
-	Gateway      GatewayConfig              `mapstructure:"gateway"`
-	APIKeyAuth   APIKeyAuthCacheConfig      `mapstructure:"api_key_auth_cache"`
-	Dashboard    DashboardCacheConfig       `mapstructure:"dashboard_cache"`
-	DashboardAgg DashboardAggregationConfig `mapstructure:"dashboard_aggregation"`
-	UsageCleanup UsageCleanupConfig         `mapstructure:"usage_cleanup"`
-	Concurrency  ConcurrencyConfig          `mapstructure:"concurrency"`
-	TokenRefresh TokenRefreshConfig         `mapstructure:"token_refresh"`
-	RunMode      string                     `mapstructure:"run_mode" yaml:"run_mode"`
-	Timezone     string                     `mapstructure:"timezone"` // e.g. "Asia/Shanghai", "UTC"
-	Gemini       GeminiConfig               `mapstructure:"gemini"`
-	Update       UpdateConfig               `mapstructure:"update"`
+	Server           ServerConfig               `mapstructure:"server"`
+	CORS             CORSConfig                 `mapstructure:"cors"`
+	Security         SecurityConfig             `mapstructure:"security"`
+	Billing          BillingConfig              `mapstructure:"billing"`
+	Turnstile        TurnstileConfig            `mapstructure:"turnstile"`
+	Database         DatabaseConfig             `mapstructure:"database"`
+	Redis            RedisConfig                `mapstructure:"redis"`
+	Ops              OpsConfig                  `mapstructure:"ops"`
+	JWT              JWTConfig                  `mapstructure:"jwt"`
+	Totp             TotpConfig                 `mapstructure:"totp"`
+	LinuxDo          LinuxDoConnectConfig       `mapstructure:"linuxdo_connect"`
+	Default          DefaultConfig              `mapstructure:"default"`
+	RateLimit        RateLimitConfig            `mapstructure:"rate_limit"`
+	Pricing          PricingConfig              `mapstructure:"pricing"`
+	Gateway          GatewayConfig              `mapstructure:"gateway"`
+	APIKeyAuth       APIKeyAuthCacheConfig      `mapstructure:"api_key_auth_cache"`
+	Dashboard        DashboardCacheConfig       `mapstructure:"dashboard_cache"`
+	DashboardAgg     DashboardAggregationConfig `mapstructure:"dashboard_aggregation"`
+	UsageCleanup     UsageCleanupConfig         `mapstructure:"usage_cleanup"`
+	Concurrency      ConcurrencyConfig          `mapstructure:"concurrency"`
+	TokenRefresh     TokenRefreshConfig         `mapstructure:"token_refresh"`
+	TokenHealthCheck TokenHealthCheckConfig     `mapstructure:"token_health_check"`
+	RunMode          string                     `mapstructure:"run_mode" yaml:"run_mode"`
+	Timezone         string                     `mapstructure:"timezone"` // e.g. "Asia/Shanghai", "UTC"
+	Gemini           GeminiConfig               `mapstructure:"gemini"`
+	Update           UpdateConfig               `mapstructure:"update"`
 }
 
 type GeminiConfig struct {
-	OAuth GeminiOAuthConfig `mapstructure:"oauth"`
-	Quota GeminiQuotaConfig `mapstructure:"quota"`
+	OAuth         GeminiOAuthConfig         `mapstructure:"oauth"`
+	Quota         GeminiQuotaConfig         `mapstructure:"quota"`
+	ImageFetch    GeminiImageFetchConfig    `mapstructure:"image_fetch"`
+	CachedContent GeminiCachedContentConfig `mapstructure:"cached_content"`
+	// PromptBlockMode 控制整个 prompt 被 Gemini 安全过滤器拦截（promptFeedback.blockReason，
+	// 此时 candidates 为空）时如何转换为 Claude 兼容响应：
+	// "text"(默认，转换为一条说明拦截原因的文本消息，避免客户端看到无任何提示的空回复)、
+	// "error"(返回 Claude 风格的 invalid_request_error 错误响应)、
+	// "ignore"(保留旧行为，返回一条空 content 的消息)。
+	PromptBlockMode string `mapstructure:"prompt_block_mode"`
+}
+
+// GeminiCachedContentConfig 控制 Gemini 原生 v1beta 透传请求是否复用显式缓存
+// （cachedContents）来承载稳定的 system instruction / 前缀上下文，降低重复大
+// 上下文场景下的计费与延迟。仅对 AI Studio 直连的 API Key 账号生效；关闭、
+// 创建失败或命中过期时均回退为完全内联的请求，不影响主流程。
+type GeminiCachedContentConfig struct {
+	// Enabled 是否启用显式缓存（默认关闭，opt-in）
+	Enabled bool `mapstructure:"enabled"`
+	// MinContentLength 稳定前缀（system instruction + 摘要链覆盖的内容）至少
+	// 达到该字符数才会尝试创建缓存，过短的前缀缓存收益小于创建开销
+	MinContentLength int `mapstructure:"min_content_length"`
+	// TTL 缓存资源的存活时间，同时用作上游 cachedContents 资源的 ttl 参数
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// GeminiImageFetchConfig 控制 Claude 兼容请求中 url 类型的 image source
+// 转换为 Gemini inlineData 时，是否允许网关主动拉取图片内容。
+type GeminiImageFetchConfig struct {
+	// Enabled 是否允许拉取 url 类型图片；关闭时降级为文本占位提示
+	Enabled bool `mapstructure:"enabled"`
+	// MaxBytes 单张图片允许拉取的最大字节数，超过则放弃转换并降级为文本提示
+	MaxBytes int64 `mapstructure:"max_bytes"`
+	// TimeoutSeconds 拉取单张图片的超时时间（秒）
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
 }
 
 type GeminiOAuthConfig struct {
@@ -128,6 +162,20 @@ type TokenRefreshConfig struct {
 	RetryBackoffSeconds int `mapstructure:"retry_backoff_seconds"`
 }
 
+// TokenHealthCheckConfig OAuth token健康检查配置
+// 与 TokenRefreshConfig 不同，本配置用于主动探测凭证是否已失效（而非临近过期），
+// 探测失败达到一定次数后将账号标记为 error 状态，避免用户请求命中失效账号。
+type TokenHealthCheckConfig struct {
+	// 是否启用健康检查
+	Enabled bool `mapstructure:"enabled"`
+	// 检查间隔（分钟）
+	CheckIntervalMinutes int `mapstructure:"check_interval_minutes"`
+	// 单次检查周期内的最大并发数，避免同时对大量账号发起刷新请求
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+	// 连续失败多少次后将账号标记为 error 状态（避免单次网络抖动误判）
+	MaxConsecutiveFailures int `mapstructure:"max_consecutive_failures"`
+}
+
 type PricingConfig struct {
 	// 价格数据远程URL（默认使用LiteLLM镜像）
 	RemoteURL string `mapstructure:"remote_url"`
@@ -177,10 +225,12 @@ type SecurityConfig struct {
 }
 
 type URLAllowlistConfig struct {
-	Enabled           bool     `mapstructure:"enabled"`
-	UpstreamHosts     []string `mapstructure:"upstream_hosts"`
-	PricingHosts      []string `mapstructure:"pricing_hosts"`
-	CRSHosts          []string `mapstructure:"crs_hosts"`
+	Enabled       bool     `mapstructure:"enabled"`
+	UpstreamHosts []string `mapstructure:"upstream_hosts"`
+	PricingHosts  []string `mapstructure:"pricing_hosts"`
+	CRSHosts      []string `mapstructure:"crs_hosts"`
+	// ImageHosts 拉取 Claude 消息中 url 类型图片时允许访问的主机白名单
+	ImageHosts        []string `mapstructure:"image_hosts"`
 	AllowPrivateHosts bool     `mapstructure:"allow_private_hosts"`
 	// 关闭 URL 白名单校验时，是否允许 http URL（默认只允许 https）
 	AllowInsecureHTTP bool `mapstructure:"allow_insecure_http"`
@@ -203,6 +253,10 @@ type ProxyProbeConfig struct {
 
 type BillingConfig struct {
 	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// MinimumBalanceUSD 发起请求所需的最低余额（USD）。余额模式下，请求在账号选择之前
+	// 会检查缓存余额是否不低于该值；0 表示不启用最低余额检查（仅要求余额 > 0，维持原行为）。
+	MinimumBalanceUSD float64 `mapstructure:"minimum_balance_usd"`
 }
 
 type CircuitBreakerConfig struct {
@@ -215,6 +269,21 @@ type CircuitBreakerConfig struct {
 type ConcurrencyConfig struct {
 	// PingInterval: 并发等待期间的 SSE ping 间隔（秒）
 	PingInterval int `mapstructure:"ping_interval"`
+
+	// RecoveryRampEnabled: 是否在账号从限流/过载恢复后启用并发爬坡
+	// 账号恢复瞬间直接放开满额并发容易再次触发上游限流，爬坡期间逐步放量更稳妥
+	RecoveryRampEnabled bool `mapstructure:"recovery_ramp_enabled"`
+	// RecoveryRampDurationSeconds: 爬坡持续时间（秒），恢复后此时长内并发逐步从初始比例升到满额
+	RecoveryRampDurationSeconds int `mapstructure:"recovery_ramp_duration_seconds"`
+	// RecoveryRampInitialPercent: 恢复瞬间的初始并发比例（1-100），爬坡起点
+	RecoveryRampInitialPercent int `mapstructure:"recovery_ramp_initial_percent"`
+
+	// AffinityBorrowEnabled: 是否允许账号在自身并发打满时，从同一亲和分组内空闲的
+	// 账号借用槽位，用于平滑突发流量
+	AffinityBorrowEnabled bool `mapstructure:"affinity_borrow_enabled"`
+	// AffinityBorrowMaxSlots: 单个账号同一时间最多可借用的槽位数，超过后即使分组内
+	// 仍有空闲账号也不再借用，避免单账号占满整个分组的容量
+	AffinityBorrowMaxSlots int `mapstructure:"affinity_borrow_max_slots"`
 }
 
 // GatewayConfig API网关相关配置
@@ -255,19 +324,48 @@ type GatewayConfig struct {
 
 	// StreamDataIntervalTimeout: 流数据间隔超时（秒），0表示禁用
 	StreamDataIntervalTimeout int `mapstructure:"stream_data_interval_timeout"`
+	// GracefulStreamTimeout: 流超时时是否优雅关闭连接——向客户端发送携带已收集 usage 的
+	// message_delta（stop_reason=max_tokens）+ message_stop，而不是 error 事件。
+	// 这样客户端已经渲染的部分内容会被视为一条完整消息，而不是被丢弃。默认关闭以保持旧行为。
+	GracefulStreamTimeout bool `mapstructure:"graceful_stream_timeout"`
 	// StreamKeepaliveInterval: 流式 keepalive 间隔（秒），0表示禁用
 	StreamKeepaliveInterval int `mapstructure:"stream_keepalive_interval"`
+	// InterimStreamUsage: 是否在每个 content_block_stop 事件后额外补发一条携带当前累计
+	// usage 的 message_delta 事件（stop_reason 置空，不影响客户端对最终停止原因的判断），
+	// 供需要实时更新 token 计数器的客户端使用。output_tokens 若上游尚未报告，按已生成
+	// 内容估算。默认关闭，不改变默认的流事件形状。
+	InterimStreamUsage bool `mapstructure:"interim_stream_usage"`
 	// MaxLineSize: 上游 SSE 单行最大字节数（0使用默认值）
 	MaxLineSize int `mapstructure:"max_line_size"`
 
+	// ClientWriteRetryDelayMs: 向客户端写入流式数据失败后，重试前的等待时间（毫秒），
+	// 0 表示禁用重试、首次写入失败即判定客户端断开
+	// 部分反向代理/网络环境下会出现一次性的瞬时写入错误，并非真实断开，
+	// 增加一次延迟重试可避免过早放弃客户端连接（仍会继续读取上游以保证计费准确）
+	ClientWriteRetryDelayMs int `mapstructure:"client_write_retry_delay_ms"`
+
 	// 是否记录上游错误响应体摘要（避免输出请求内容）
 	LogUpstreamErrorBody bool `mapstructure:"log_upstream_error_body"`
 	// 上游错误响应体记录最大字节数（超过会截断）
 	LogUpstreamErrorBodyMaxBytes int `mapstructure:"log_upstream_error_body_max_bytes"`
 
+	// 单次请求内（含重试/failover）累计记录的 OpsUpstreamErrorEvent 最大条数，超出后
+	// 仅保留最早与最近的若干条（中间用一条汇总事件替代），避免病态的大量切换账号场景下
+	// 把 gin context 撑爆。0 或未配置时使用默认值。
+	MaxOpsUpstreamErrorEvents int `mapstructure:"max_ops_upstream_error_events"`
+
 	// API-key 账号在客户端未提供 anthropic-beta 时，是否按需自动补齐（默认关闭以保持兼容）
 	InjectBetaForAPIKey bool `mapstructure:"inject_beta_for_apikey"`
 
+	// 合并后的 anthropic-beta header 最大长度（字符数），超出时按优先级从低到高丢弃，
+	// 必需的 beta（OAuth/thinking 等）始终保留；0 表示不限制
+	MaxAnthropicBetaHeaderLength int `mapstructure:"max_anthropic_beta_header_length"`
+
+	// OAuth 账号上需要从客户端透传的 anthropic-beta header 中剔除的 beta 列表
+	// （全局生效，用于屏蔽已知与 OAuth 账号不兼容、会导致上游报错的客户端 beta）；
+	// 剔除发生在 getBetaHeader 补齐 OAuth/claude-code 必需 beta 之前，因此必需的 beta 始终保留
+	OAuthBetaDenylist []string `mapstructure:"oauth_beta_denylist"`
+
 	// 是否允许对部分 400 错误触发 failover（默认关闭以避免改变语义）
 	FailoverOn400 bool `mapstructure:"failover_on_400"`
 
@@ -284,6 +382,124 @@ type GatewayConfig struct {
 
 	// TLSFingerprint: TLS指纹伪装配置
 	TLSFingerprint TLSFingerprintConfig `mapstructure:"tls_fingerprint"`
+
+	// AdmissionControl: 全局准入控制配置（在选择账号前拦截超额请求）
+	AdmissionControl GatewayAdmissionControlConfig `mapstructure:"admission_control"`
+
+	// MaxMessages: 单次请求允许的最大 messages 数量，0 表示不限制
+	// 可被分组的 max_messages 覆盖；用于避免超长上下文拖垮上游或浪费配额
+	MaxMessages int `mapstructure:"max_messages"`
+
+	// CountTokensCacheTTLSeconds: count_tokens 结果缓存的 TTL（秒），0 表示禁用缓存
+	// 相同模型+请求体的 count_tokens 请求可在 TTL 内复用缓存结果，避免重复转发到上游
+	CountTokensCacheTTLSeconds int `mapstructure:"count_tokens_cache_ttl_seconds"`
+
+	// IncludeModelInCountTokensResponse: 是否在 count_tokens 响应中附加解析后（映射/补全后，
+	// 对客户端而言即原始请求）的 model 字段，默认关闭以保持与 Anthropic 原生响应形状一致。
+	// 开启后覆盖 Anthropic/Antigravity 直通响应与 Gemini 估算 fallback 响应。
+	IncludeModelInCountTokensResponse bool `mapstructure:"include_model_in_count_tokens_response"`
+
+	// DecodeUpstreamGzipNonStreaming: 是否对非流式响应按 gzip magic bytes 探测并解压，
+	// 解压后同时去掉转发给客户端的 Content-Encoding 头，避免出现“响应头声明 gzip、
+	// 但实际字节已是明文（或反之）”的不一致。默认关闭以保持现有透传行为不变。
+	DecodeUpstreamGzipNonStreaming bool `mapstructure:"decode_upstream_gzip_non_streaming"`
+
+	// SelectionLogSampleRate: 选号高频 info 日志（如 [Forward] Using account）按 1/N 采样输出，
+	// <=1 表示每次都记录。仅影响高频 info 日志，错误/failover 日志始终记录，不受此项影响
+	SelectionLogSampleRate int `mapstructure:"selection_log_sample_rate"`
+
+	// ModelValidation: 已知模型名校验配置（默认关闭，避免新模型上线前被误拦截）
+	ModelValidation GatewayModelValidationConfig `mapstructure:"model_validation"`
+
+	// IntentRouting: x-sub2api-intent 请求头校验配置（默认关闭，避免未配置时误拦截请求）
+	IntentRouting GatewayIntentRoutingConfig `mapstructure:"intent_routing"`
+
+	// BillingTag: x-sub2api-tag 请求头校验配置（默认关闭，避免未配置时误拦截请求）
+	BillingTag GatewayBillingTagConfig `mapstructure:"billing_tag"`
+
+	// StreamTee: 流式响应调试抄送配置（默认关闭，避免额外磁盘开销）
+	StreamTee GatewayStreamTeeConfig `mapstructure:"stream_tee"`
+
+	// GenerateTraceHeadersWhenMissing: 客户端请求未携带 traceparent/b3 时，是否自动生成
+	// 一对供上游使用（默认关闭，避免与外部观测系统自身的采样/生成策略冲突）。
+	// 客户端已携带时始终原样透传，不受此项影响。
+	GenerateTraceHeadersWhenMissing bool `mapstructure:"generate_trace_headers_when_missing"`
+
+	// Retry: 同账号请求重试的退避参数配置
+	Retry GatewayRetryConfig `mapstructure:"retry"`
+}
+
+// GatewayRetryConfig 同账号请求重试的退避参数配置，对应 gateway_service.go 中
+// Forward 重试循环使用的指数退避常量。未配置（零值）的字段使用原有的默认值。
+type GatewayRetryConfig struct {
+	// MaxAttempts: 最大尝试次数（包含首次请求）
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// BaseDelay: 指数退避基础等待时间；第 N 次失败后的等待 = BaseDelay * 2^(N-1)，上限为 MaxDelay
+	BaseDelay time.Duration `mapstructure:"base_delay"`
+	// MaxDelay: 单次退避等待的上限
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+	// MaxElapsed: 最大重试耗时（包含请求本身耗时 + 退避等待时间），用于防止极端情况下
+	// goroutine 长时间堆积导致资源耗尽；必须不小于 BaseDelay
+	MaxElapsed time.Duration `mapstructure:"max_elapsed"`
+}
+
+// GatewayIntentRoutingConfig 请求 intent 路由的全局校验配置
+// 用于校验客户端携带的 x-sub2api-intent 请求头是否在允许的取值范围内；
+// 具体的 intent -> 账号子集映射由各分组的 Group.IntentRouting 配置
+type GatewayIntentRoutingConfig struct {
+	// Enabled: 是否启用 intent 校验（默认关闭，opt-in）；关闭时 x-sub2api-intent 请求头被忽略
+	Enabled bool `mapstructure:"enabled"`
+	// ValidIntents: 允许的 intent 取值列表；请求头携带了未在此列表中的 intent 时会被忽略
+	ValidIntents []string `mapstructure:"valid_intents"`
+}
+
+// GatewayBillingTagConfig 请求计费标签（x-sub2api-tag）的全局校验配置
+// 用于校验客户端携带的 x-sub2api-tag 请求头是否在允许的取值范围内；
+// 校验通过的标签会写入 UsageLog.Tag，供按项目/功能维度的成本报表聚合使用
+type GatewayBillingTagConfig struct {
+	// Enabled: 是否启用 tag 校验（默认关闭，opt-in）；关闭时任意非空 x-sub2api-tag 都会被接受
+	Enabled bool `mapstructure:"enabled"`
+	// ValidTags: 允许的 tag 取值列表；请求头携带了未在此列表中的 tag 时会被忽略
+	ValidTags []string `mapstructure:"valid_tags"`
+}
+
+// GatewayStreamTeeConfig 流式响应调试抄送（tee）配置
+// 用于排查个别请求的流式响应问题：在转发给客户端的同时，异步把上游原始 SSE
+// 原文额外写入本地调试文件。默认全局关闭，且即便全局启用，也必须单次请求携带
+// 匹配 Token 的请求头才会对该请求生效，避免误开导致磁盘堆积
+type GatewayStreamTeeConfig struct {
+	// Enabled: 是否全局启用流式抄送功能（默认关闭，opt-in）
+	Enabled bool `mapstructure:"enabled"`
+	// Header: 触发单次请求抄送的请求头名称
+	Header string `mapstructure:"header"`
+	// Token: 请求头取值必须与此 Token 完全匹配才会对该请求生效；为空时即使 Enabled 也不会抄送
+	Token string `mapstructure:"token"`
+	// Dir: 抄送文件写入目录，自动创建
+	Dir string `mapstructure:"dir"`
+}
+
+// GatewayModelValidationConfig 已知模型名校验配置
+// 用于在请求进入调度前拦截明显拼写错误的模型名，避免无意义地转发到上游后才收到 404/400
+type GatewayModelValidationConfig struct {
+	// Enabled: 是否启用已知模型名校验（默认关闭，opt-in，避免阻塞尚未录入注册表的新模型）
+	Enabled bool `mapstructure:"enabled"`
+	// KnownModels: 静态配置的已知模型名列表；为空时从当前可调度账户的 model_mapping 中动态推导
+	KnownModels []string `mapstructure:"known_models"`
+	// MaxSuggestions: 校验失败时在错误信息中返回的相近模型名建议数量上限
+	MaxSuggestions int `mapstructure:"max_suggestions"`
+}
+
+// GatewayAdmissionControlConfig 全局准入控制配置
+// 独立于按账号/用户的并发限制，用于在极端负载下保护整个进程
+type GatewayAdmissionControlConfig struct {
+	// Enabled: 是否启用全局准入控制（默认关闭）
+	Enabled bool `mapstructure:"enabled"`
+	// MaxInFlight: 全网关允许的最大同时处理请求数
+	MaxInFlight int `mapstructure:"max_in_flight"`
+	// MaxQueue: 超过 MaxInFlight 后允许排队等待的最大请求数，超出则直接拒绝
+	MaxQueue int `mapstructure:"max_queue"`
+	// QueueTimeout: 排队等待获取处理名额的最长时间，超时返回 503
+	QueueTimeout time.Duration `mapstructure:"queue_timeout"`
 }
 
 // TLSFingerprintConfig TLS指纹伪装配置
@@ -320,7 +536,10 @@ type GatewaySchedulingConfig struct {
 	FallbackWaitTimeout time.Duration `mapstructure:"fallback_wait_timeout"`
 	FallbackMaxWaiting  int           `mapstructure:"fallback_max_waiting"`
 
-	// 兜底层账户选择策略: "last_used"(按最后使用时间排序，默认) 或 "random"(随机)
+	// 兜底层账户选择策略: "last_used"(按最后使用时间排序，默认)、"random"(随机)、
+	// "cheapest"(同优先级内优先选择 BillingRateMultiplier 最低的账号) 或
+	// "weighted"(同优先级内按账号 Concurrency 加权随机选择，同时也用于负载感知路径
+	// 的同层最终选号)
 	FallbackSelectionMode string `mapstructure:"fallback_selection_mode"`
 
 	// 负载计算
@@ -351,8 +570,63 @@ type GatewaySchedulingConfig struct {
 	// 全量重建周期配置
 	// 全量重建周期（秒），0 表示禁用
 	FullRebuildIntervalSeconds int `mapstructure:"full_rebuild_interval_seconds"`
+
+	// 混合调度下，同优先级账户的平台偏好: "none"(默认，不区分)、"native_first"(优先原生平台)、"antigravity_first"(优先 antigravity)
+	MixedSchedulingPreference string `mapstructure:"mixed_scheduling_preference"`
+
+	// 单用户最大并发流式连接数，0 表示不限制
+	// 流式连接生命周期长，限制可避免单个用户打开过多连接耗尽并发槽位；超限立即返回 429，不排队等待
+	MaxConcurrentStreamsPerUser int `mapstructure:"max_concurrent_streams_per_user"`
+
+	// 候选账户为空时的短暂等待重试配置：账户可能在几秒内从临时不可调度状态恢复
+	// （限流解除、窗口费用重置等），短暂重试可避免因一次快照而直接失败
+	// 等待重试总时长，0 表示禁用重试（立即返回 "no available accounts"）
+	EmptyCandidatesRetryTimeout time.Duration `mapstructure:"empty_candidates_retry_timeout"`
+	// 两次重试之间的间隔
+	EmptyCandidatesRetryInterval time.Duration `mapstructure:"empty_candidates_retry_interval"`
+
+	// 账号因请求特征（anthropic-beta、thinking 等）触发 400 兼容性 failover 后，
+	// 在此时长内跳过该账号对携带同一特征的请求，0 表示禁用该标记（不跳过）
+	FeatureIncompatTTL time.Duration `mapstructure:"feature_incompat_ttl"`
+
+	// simple 模式下默认仍限定到指定分组的可调度账号，0 表示维持原行为（忽略分组，调度全平台账号）。
+	// 用于希望以 simple 模式部署但仍需隔离账号池的场景。
+	SimpleModeDefaultGroupID int64 `mapstructure:"simple_mode_default_group_id"`
+
+	// 账户选择公平性（可选，默认关闭）：单个用户短时间内占用同一优先级内的大量调度次数时，
+	// 在该优先级band内对其降权，让其它用户更容易选到同一批账号。见 accountSelectionFairness。
+	FairnessEnabled bool `mapstructure:"fairness_enabled"`
+	// 统计最近调度次数的滑动窗口时长
+	FairnessWindow time.Duration `mapstructure:"fairness_window"`
+	// 单用户在窗口内的调度次数占比超过该阈值时触发降权，取值范围 (0, 1]
+	FairnessMaxShare float64 `mapstructure:"fairness_max_share"`
+
+	// 账号“预热”模型判定窗口：账号在此时长内为某个声明为 warm 的模型（见 Account.GetWarmModels）
+	// 提供过服务时，调度会在可选账号中优先选择该账号，减少冷启动延迟敏感上游的切换开销。
+	// 0 或未配置时使用默认值（10 分钟）。
+	WarmModelWindow time.Duration `mapstructure:"warm_model_window"`
+
+	// 新账号 break-in 优先调度窗口：账号创建时间距今不超过此时长时，调度会在分层过滤的
+	// 优先级判定之前，临时将候选收窄为这些“新账号”（忽略其配置的 Priority），以便尽快
+	// 为其积累验证流量；窗口结束后该账号恢复按正常 Priority 参与调度。
+	// 0 或未配置时禁用该特性（默认），不影响既有调度行为。
+	NewAccountBoostWindow time.Duration `mapstructure:"new_account_boost_window"`
+
+	// 粘性会话绑定写穿持久化（可选，默认关闭）：开启后，GatewayCache 在 Redis 未命中时
+	// 会回落到 session_bindings 表，写入时同步写穿，使长时间运行的会话（如 Claude Code）
+	// 不会因缓存重启而中途切换账号、破坏 prompt caching。
+	PersistStickySessions bool `mapstructure:"persist_sticky_sessions"`
+	// session_bindings 表过期记录的清理周期，仅在 PersistStickySessions 开启时生效
+	SessionBindingCleanupInterval time.Duration `mapstructure:"session_binding_cleanup_interval"`
 }
 
+// Mixed scheduling platform preference values for GatewaySchedulingConfig.MixedSchedulingPreference.
+const (
+	MixedSchedulingPreferenceNone             = "none"
+	MixedSchedulingPreferenceNativeFirst      = "native_first"
+	MixedSchedulingPreferenceAntigravityFirst = "antigravity_first"
+)
+
 func (s *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
@@ -735,6 +1009,7 @@ func setDefaults() {
 		"raw.githubusercontent.com",
 	})
 	viper.SetDefault("security.url_allowlist.crs_hosts", []string{})
+	viper.SetDefault("security.url_allowlist.image_hosts", []string{})
 	viper.SetDefault("security.url_allowlist.allow_private_hosts", true)
 	viper.SetDefault("security.url_allowlist.allow_insecure_http", true)
 	viper.SetDefault("security.response_headers.enabled", false)
@@ -749,6 +1024,7 @@ func setDefaults() {
 	viper.SetDefault("billing.circuit_breaker.failure_threshold", 5)
 	viper.SetDefault("billing.circuit_breaker.reset_timeout_seconds", 30)
 	viper.SetDefault("billing.circuit_breaker.half_open_requests", 3)
+	viper.SetDefault("billing.minimum_balance_usd", 0)
 
 	// Turnstile
 	viper.SetDefault("turnstile.required", false)
@@ -878,12 +1154,21 @@ func setDefaults() {
 	viper.SetDefault("gateway.response_header_timeout", 600) // 600秒(10分钟)等待上游响应头，LLM高负载时可能排队较久
 	viper.SetDefault("gateway.log_upstream_error_body", true)
 	viper.SetDefault("gateway.log_upstream_error_body_max_bytes", 2048)
+	viper.SetDefault("gateway.max_ops_upstream_error_events", 20)
 	viper.SetDefault("gateway.inject_beta_for_apikey", false)
+	viper.SetDefault("gateway.max_anthropic_beta_header_length", 0)
+	viper.SetDefault("gateway.oauth_beta_denylist", []string{})
 	viper.SetDefault("gateway.failover_on_400", false)
 	viper.SetDefault("gateway.max_account_switches", 10)
 	viper.SetDefault("gateway.max_account_switches_gemini", 3)
 	viper.SetDefault("gateway.antigravity_fallback_cooldown_minutes", 1)
 	viper.SetDefault("gateway.max_body_size", int64(100*1024*1024))
+	viper.SetDefault("gateway.max_messages", 0)                               // 0表示不限制单次请求的 messages 数量
+	viper.SetDefault("gateway.count_tokens_cache_ttl_seconds", 0)             // 0表示禁用 count_tokens 结果缓存
+	viper.SetDefault("gateway.include_model_in_count_tokens_response", false) // 默认关闭，保持原生响应形状
+	viper.SetDefault("gateway.decode_upstream_gzip_non_streaming", false)     // 默认关闭，保持现有透传行为
+	viper.SetDefault("gateway.generate_trace_headers_when_missing", false)    // 默认关闭，避免与外部观测系统冲突
+	viper.SetDefault("gateway.selection_log_sample_rate", 1)                  // 1表示每次请求都记录选号日志，不采样
 	viper.SetDefault("gateway.connection_pool_isolation", ConnectionPoolIsolationAccountProxy)
 	// HTTP 上游连接池配置（针对 5000+ 并发用户优化）
 	viper.SetDefault("gateway.max_idle_conns", 240)           // 最大空闲连接总数（HTTP/2 场景默认）
@@ -894,8 +1179,19 @@ func setDefaults() {
 	viper.SetDefault("gateway.client_idle_ttl_seconds", 900)
 	viper.SetDefault("gateway.concurrency_slot_ttl_minutes", 30) // 并发槽位过期时间（支持超长请求）
 	viper.SetDefault("gateway.stream_data_interval_timeout", 180)
+	viper.SetDefault("gateway.graceful_stream_timeout", false)
 	viper.SetDefault("gateway.stream_keepalive_interval", 10)
+	viper.SetDefault("gateway.interim_stream_usage", false)
+	viper.SetDefault("gateway.stream_tee.enabled", false)
+	viper.SetDefault("gateway.stream_tee.header", "x-sub2api-debug-tee")
+	viper.SetDefault("gateway.stream_tee.token", "")
+	viper.SetDefault("gateway.stream_tee.dir", "./data/stream-tee")
 	viper.SetDefault("gateway.max_line_size", 40*1024*1024)
+	viper.SetDefault("gateway.client_write_retry_delay_ms", 20)
+	viper.SetDefault("gateway.retry.max_attempts", 5)
+	viper.SetDefault("gateway.retry.base_delay", 300*time.Millisecond)
+	viper.SetDefault("gateway.retry.max_delay", 3*time.Second)
+	viper.SetDefault("gateway.retry.max_elapsed", 10*time.Second)
 	viper.SetDefault("gateway.scheduling.sticky_session_max_waiting", 3)
 	viper.SetDefault("gateway.scheduling.sticky_session_wait_timeout", 120*time.Second)
 	viper.SetDefault("gateway.scheduling.fallback_wait_timeout", 30*time.Second)
@@ -912,9 +1208,36 @@ func setDefaults() {
 	viper.SetDefault("gateway.scheduling.outbox_lag_rebuild_failures", 3)
 	viper.SetDefault("gateway.scheduling.outbox_backlog_rebuild_rows", 10000)
 	viper.SetDefault("gateway.scheduling.full_rebuild_interval_seconds", 300)
+	viper.SetDefault("gateway.scheduling.mixed_scheduling_preference", MixedSchedulingPreferenceNone)
+	viper.SetDefault("gateway.scheduling.max_concurrent_streams_per_user", 0)
+	viper.SetDefault("gateway.scheduling.empty_candidates_retry_timeout", 0)
+	viper.SetDefault("gateway.scheduling.empty_candidates_retry_interval", 2*time.Second)
+	viper.SetDefault("gateway.scheduling.feature_incompat_ttl", 10*time.Minute)
+	viper.SetDefault("gateway.scheduling.simple_mode_default_group_id", 0)
+	viper.SetDefault("gateway.scheduling.fairness_enabled", false)
+	viper.SetDefault("gateway.scheduling.fairness_window", 5*time.Minute)
+	viper.SetDefault("gateway.scheduling.fairness_max_share", 0.7)
+	viper.SetDefault("gateway.scheduling.warm_model_window", 10*time.Minute)
+	viper.SetDefault("gateway.scheduling.new_account_boost_window", 0)
+	viper.SetDefault("gateway.scheduling.persist_sticky_sessions", false)
+	viper.SetDefault("gateway.scheduling.session_binding_cleanup_interval", 5*time.Minute)
+	viper.SetDefault("gateway.model_validation.enabled", false)
+	viper.SetDefault("gateway.model_validation.max_suggestions", 3)
+	viper.SetDefault("gateway.intent_routing.enabled", false)
+	viper.SetDefault("gateway.intent_routing.valid_intents", []string{})
 	// TLS指纹伪装配置（默认关闭，需要账号级别单独启用）
 	viper.SetDefault("gateway.tls_fingerprint.enabled", true)
+	// 全局准入控制（默认关闭，不影响现有按账号/用户的并发限制）
+	viper.SetDefault("gateway.admission_control.enabled", false)
+	viper.SetDefault("gateway.admission_control.max_in_flight", 1000)
+	viper.SetDefault("gateway.admission_control.max_queue", 200)
+	viper.SetDefault("gateway.admission_control.queue_timeout", 5*time.Second)
 	viper.SetDefault("concurrency.ping_interval", 10)
+	viper.SetDefault("concurrency.recovery_ramp_enabled", false)
+	viper.SetDefault("concurrency.recovery_ramp_duration_seconds", 120)
+	viper.SetDefault("concurrency.recovery_ramp_initial_percent", 25)
+	viper.SetDefault("concurrency.affinity_borrow_enabled", false)
+	viper.SetDefault("concurrency.affinity_borrow_max_slots", 2)
 
 	// TokenRefresh
 	viper.SetDefault("token_refresh.enabled", true)
@@ -923,6 +1246,12 @@ func setDefaults() {
 	viper.SetDefault("token_refresh.max_retries", 3)                   // 最多重试3次
 	viper.SetDefault("token_refresh.retry_backoff_seconds", 2)         // 重试退避基础2秒
 
+	// TokenHealthCheck
+	viper.SetDefault("token_health_check.enabled", true)
+	viper.SetDefault("token_health_check.check_interval_minutes", 30) // 每30分钟检查一次
+	viper.SetDefault("token_health_check.max_concurrency", 5)         // 最多5个账号并发检查
+	viper.SetDefault("token_health_check.max_consecutive_failures", 3)
+
 	// Gemini OAuth - configure via environment variables or config file
 	// GEMINI_OAUTH_CLIENT_ID and GEMINI_OAUTH_CLIENT_SECRET
 	// Default: uses Gemini CLI public credentials (set via environment)
@@ -930,6 +1259,13 @@ func setDefaults() {
 	viper.SetDefault("gemini.oauth.client_secret", "")
 	viper.SetDefault("gemini.oauth.scopes", "")
 	viper.SetDefault("gemini.quota.policy", "")
+	viper.SetDefault("gemini.image_fetch.enabled", false)
+	viper.SetDefault("gemini.image_fetch.max_bytes", int64(5*1024*1024)) // 5MB
+	viper.SetDefault("gemini.image_fetch.timeout_seconds", 10)
+	viper.SetDefault("gemini.cached_content.enabled", false)
+	viper.SetDefault("gemini.cached_content.min_content_length", 4096)
+	viper.SetDefault("gemini.cached_content.ttl", 10*time.Minute)
+	viper.SetDefault("gemini.prompt_block_mode", "text")
 }
 
 func (c *Config) Validate() error {
@@ -1027,6 +1363,17 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("billing.circuit_breaker.half_open_requests must be positive")
 		}
 	}
+	if c.TokenHealthCheck.Enabled {
+		if c.TokenHealthCheck.CheckIntervalMinutes <= 0 {
+			return fmt.Errorf("token_health_check.check_interval_minutes must be positive")
+		}
+		if c.TokenHealthCheck.MaxConcurrency <= 0 {
+			return fmt.Errorf("token_health_check.max_concurrency must be positive")
+		}
+		if c.TokenHealthCheck.MaxConsecutiveFailures <= 0 {
+			return fmt.Errorf("token_health_check.max_consecutive_failures must be positive")
+		}
+	}
 	if c.Database.MaxOpenConns <= 0 {
 		return fmt.Errorf("database.max_open_conns must be positive")
 	}
@@ -1214,6 +1561,24 @@ func (c *Config) Validate() error {
 	if c.Gateway.MaxLineSize != 0 && c.Gateway.MaxLineSize < 1024*1024 {
 		return fmt.Errorf("gateway.max_line_size must be at least 1MB")
 	}
+	if c.Gateway.ClientWriteRetryDelayMs < 0 {
+		return fmt.Errorf("gateway.client_write_retry_delay_ms must be non-negative")
+	}
+	if c.Gateway.Retry.MaxAttempts < 0 {
+		return fmt.Errorf("gateway.retry.max_attempts must be non-negative")
+	}
+	if c.Gateway.Retry.BaseDelay < 0 {
+		return fmt.Errorf("gateway.retry.base_delay must be non-negative")
+	}
+	if c.Gateway.Retry.MaxDelay < 0 {
+		return fmt.Errorf("gateway.retry.max_delay must be non-negative")
+	}
+	if c.Gateway.Retry.MaxElapsed < 0 {
+		return fmt.Errorf("gateway.retry.max_elapsed must be non-negative")
+	}
+	if c.Gateway.Retry.BaseDelay > 0 && c.Gateway.Retry.MaxElapsed > 0 && c.Gateway.Retry.MaxElapsed < c.Gateway.Retry.BaseDelay {
+		return fmt.Errorf("gateway.retry.max_elapsed must not be smaller than gateway.retry.base_delay")
+	}
 	if c.Gateway.Scheduling.StickySessionMaxWaiting <= 0 {
 		return fmt.Errorf("gateway.scheduling.sticky_session_max_waiting must be positive")
 	}
@@ -1226,9 +1591,57 @@ func (c *Config) Validate() error {
 	if c.Gateway.Scheduling.FallbackMaxWaiting <= 0 {
 		return fmt.Errorf("gateway.scheduling.fallback_max_waiting must be positive")
 	}
+	if c.Gateway.Scheduling.MaxConcurrentStreamsPerUser < 0 {
+		return fmt.Errorf("gateway.scheduling.max_concurrent_streams_per_user must be non-negative")
+	}
+	if c.Gateway.Scheduling.SimpleModeDefaultGroupID < 0 {
+		return fmt.Errorf("gateway.scheduling.simple_mode_default_group_id must be non-negative")
+	}
+	if c.Gateway.Scheduling.FairnessWindow < 0 {
+		return fmt.Errorf("gateway.scheduling.fairness_window must be non-negative")
+	}
+	if c.Gateway.Scheduling.FairnessMaxShare < 0 || c.Gateway.Scheduling.FairnessMaxShare > 1 {
+		return fmt.Errorf("gateway.scheduling.fairness_max_share must be between 0 and 1")
+	}
+	if c.Gateway.Scheduling.WarmModelWindow < 0 {
+		return fmt.Errorf("gateway.scheduling.warm_model_window must be non-negative")
+	}
+	if c.Gateway.Scheduling.NewAccountBoostWindow < 0 {
+		return fmt.Errorf("gateway.scheduling.new_account_boost_window must be non-negative")
+	}
+	if c.Gateway.Scheduling.EmptyCandidatesRetryTimeout < 0 {
+		return fmt.Errorf("gateway.scheduling.empty_candidates_retry_timeout must be non-negative")
+	}
+	if c.Gateway.Scheduling.EmptyCandidatesRetryTimeout > 0 && c.Gateway.Scheduling.EmptyCandidatesRetryInterval <= 0 {
+		return fmt.Errorf("gateway.scheduling.empty_candidates_retry_interval must be positive when empty_candidates_retry_timeout is set")
+	}
+	if c.Gateway.Scheduling.FeatureIncompatTTL < 0 {
+		return fmt.Errorf("gateway.scheduling.feature_incompat_ttl must be non-negative")
+	}
 	if c.Gateway.Scheduling.SlotCleanupInterval < 0 {
 		return fmt.Errorf("gateway.scheduling.slot_cleanup_interval must be non-negative")
 	}
+	if c.Gateway.Scheduling.SessionBindingCleanupInterval < 0 {
+		return fmt.Errorf("gateway.scheduling.session_binding_cleanup_interval must be non-negative")
+	}
+	if c.Gateway.Scheduling.PersistStickySessions && c.Gateway.Scheduling.SessionBindingCleanupInterval == 0 {
+		return fmt.Errorf("gateway.scheduling.session_binding_cleanup_interval must be positive when persist_sticky_sessions is enabled")
+	}
+	if c.Gateway.ModelValidation.MaxSuggestions < 0 {
+		return fmt.Errorf("gateway.model_validation.max_suggestions must be non-negative")
+	}
+	if c.Gateway.MaxAnthropicBetaHeaderLength < 0 {
+		return fmt.Errorf("gateway.max_anthropic_beta_header_length must be non-negative")
+	}
+	if c.Gemini.CachedContent.MinContentLength < 0 {
+		return fmt.Errorf("gemini.cached_content.min_content_length must be non-negative")
+	}
+	if c.Gemini.CachedContent.TTL < 0 {
+		return fmt.Errorf("gemini.cached_content.ttl must be non-negative")
+	}
+	if c.Gemini.CachedContent.Enabled && c.Gemini.CachedContent.TTL <= 0 {
+		return fmt.Errorf("gemini.cached_content.ttl must be positive when gemini.cached_content.enabled=true")
+	}
 	if c.Gateway.Scheduling.DbFallbackTimeoutSeconds < 0 {
 		return fmt.Errorf("gateway.scheduling.db_fallback_timeout_seconds must be non-negative")
 	}
@@ -1276,6 +1689,17 @@ func (c *Config) Validate() error {
 	if c.Concurrency.PingInterval < 5 || c.Concurrency.PingInterval > 30 {
 		return fmt.Errorf("concurrency.ping_interval must be between 5-30 seconds")
 	}
+	if c.Concurrency.RecoveryRampEnabled {
+		if c.Concurrency.RecoveryRampDurationSeconds <= 0 {
+			return fmt.Errorf("concurrency.recovery_ramp_duration_seconds must be positive")
+		}
+		if c.Concurrency.RecoveryRampInitialPercent <= 0 || c.Concurrency.RecoveryRampInitialPercent > 100 {
+			return fmt.Errorf("concurrency.recovery_ramp_initial_percent must be between 1-100")
+		}
+	}
+	if c.Concurrency.AffinityBorrowEnabled && c.Concurrency.AffinityBorrowMaxSlots <= 0 {
+		return fmt.Errorf("concurrency.affinity_borrow_max_slots must be positive")
+	}
 	return nil
 }
 
@@ -1350,6 +1774,29 @@ func GetServerAddress() string {
 	return fmt.Sprintf("%s:%d", host, port)
 }
 
+// GetSetupBindAddress returns the address the first-run setup wizard should bind to.
+// This allows operators to restrict the wizard to localhost (e.g. "127.0.0.1:8080")
+// even when the main server binds to "0.0.0.0", independent of GetServerAddress.
+// Priority: config.yaml "setup.bind_address" / env SETUP_BIND_ADDRESS > GetServerAddress().
+func GetSetupBindAddress() string {
+	v := viper.New()
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(".")
+	v.AddConfigPath("./config")
+	v.AddConfigPath("/etc/sub2api")
+
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	_ = v.ReadInConfig()
+
+	if addr := strings.TrimSpace(v.GetString("setup.bind_address")); addr != "" {
+		return addr
+	}
+	return GetServerAddress()
+}
+
 // ValidateAbsoluteHTTPURL 验证是否为有效的绝对 HTTP(S) URL
 func ValidateAbsoluteHTTPURL(raw string) error {
 	raw = strings.TrimSpace(raw)
@@ -54,6 +54,52 @@ func TestLoadDefaultSchedulingConfig(t *testing.T) {
 	if cfg.Gateway.Scheduling.SlotCleanupInterval != 30*time.Second {
 		t.Fatalf("SlotCleanupInterval = %v, want 30s", cfg.Gateway.Scheduling.SlotCleanupInterval)
 	}
+	if cfg.Gateway.Scheduling.PersistStickySessions {
+		t.Fatalf("PersistStickySessions = true, want false")
+	}
+	if cfg.Gateway.Scheduling.SessionBindingCleanupInterval != 5*time.Minute {
+		t.Fatalf("SessionBindingCleanupInterval = %v, want 5m", cfg.Gateway.Scheduling.SessionBindingCleanupInterval)
+	}
+}
+
+func TestValidate_PersistStickySessionsRequiresCleanupInterval(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	cfg.Gateway.Scheduling.PersistStickySessions = true
+	cfg.Gateway.Scheduling.SessionBindingCleanupInterval = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Validate() expected error when persist_sticky_sessions is enabled without a cleanup interval")
+	} else if !strings.Contains(err.Error(), "session_binding_cleanup_interval") {
+		t.Fatalf("Validate() error = %v, want message mentioning session_binding_cleanup_interval", err)
+	}
+}
+
+func TestLoadDefaultRetryConfig(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if cfg.Gateway.Retry.MaxAttempts != 5 {
+		t.Fatalf("Retry.MaxAttempts = %d, want 5", cfg.Gateway.Retry.MaxAttempts)
+	}
+	if cfg.Gateway.Retry.BaseDelay != 300*time.Millisecond {
+		t.Fatalf("Retry.BaseDelay = %v, want 300ms", cfg.Gateway.Retry.BaseDelay)
+	}
+	if cfg.Gateway.Retry.MaxDelay != 3*time.Second {
+		t.Fatalf("Retry.MaxDelay = %v, want 3s", cfg.Gateway.Retry.MaxDelay)
+	}
+	if cfg.Gateway.Retry.MaxElapsed != 10*time.Second {
+		t.Fatalf("Retry.MaxElapsed = %v, want 10s", cfg.Gateway.Retry.MaxElapsed)
+	}
 }
 
 func TestLoadSchedulingConfigFromEnv(t *testing.T) {
@@ -406,6 +452,27 @@ func TestGetServerAddressFromEnv(t *testing.T) {
 	}
 }
 
+func TestGetSetupBindAddressDefaultsToServerAddress(t *testing.T) {
+	t.Setenv("SERVER_HOST", "0.0.0.0")
+	t.Setenv("SERVER_PORT", "8080")
+
+	address := GetSetupBindAddress()
+	if address != "0.0.0.0:8080" {
+		t.Fatalf("GetSetupBindAddress() = %q, want %q", address, "0.0.0.0:8080")
+	}
+}
+
+func TestGetSetupBindAddressOverrideFromEnv(t *testing.T) {
+	t.Setenv("SERVER_HOST", "0.0.0.0")
+	t.Setenv("SERVER_PORT", "8080")
+	t.Setenv("SETUP_BIND_ADDRESS", "127.0.0.1:8080")
+
+	address := GetSetupBindAddress()
+	if address != "127.0.0.1:8080" {
+		t.Fatalf("GetSetupBindAddress() = %q, want override %q", address, "127.0.0.1:8080")
+	}
+}
+
 func TestValidateAbsoluteHTTPURL(t *testing.T) {
 	if err := ValidateAbsoluteHTTPURL("https://example.com/path"); err != nil {
 		t.Fatalf("ValidateAbsoluteHTTPURL valid url error: %v", err)
@@ -799,6 +866,24 @@ func TestValidateConfigErrors(t *testing.T) {
 			mutate:  func(c *Config) { c.Gateway.MaxLineSize = -1 },
 			wantErr: "gateway.max_line_size must be non-negative",
 		},
+		{
+			name:    "gateway retry max attempts negative",
+			mutate:  func(c *Config) { c.Gateway.Retry.MaxAttempts = -1 },
+			wantErr: "gateway.retry.max_attempts must be non-negative",
+		},
+		{
+			name:    "gateway retry base delay negative",
+			mutate:  func(c *Config) { c.Gateway.Retry.BaseDelay = -1 },
+			wantErr: "gateway.retry.base_delay must be non-negative",
+		},
+		{
+			name: "gateway retry max elapsed smaller than base delay",
+			mutate: func(c *Config) {
+				c.Gateway.Retry.BaseDelay = 10 * time.Second
+				c.Gateway.Retry.MaxElapsed = 1 * time.Second
+			},
+			wantErr: "gateway.retry.max_elapsed must not be smaller than gateway.retry.base_delay",
+		},
 		{
 			name:    "gateway scheduling sticky waiting",
 			mutate:  func(c *Config) { c.Gateway.Scheduling.StickySessionMaxWaiting = 0 },
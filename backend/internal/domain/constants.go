@@ -58,6 +58,13 @@ const (
 	SubscriptionTypeSubscription = "subscription" // 订阅模式（按限额控制）
 )
 
+// Group subscription overflow policy constants，用于控制同时拥有订阅与余额的用户
+// 在订阅限额被打满时的计费行为
+const (
+	SubscriptionOverflowPolicySubscriptionOnly = "subscription_only" // 始终按订阅计费，超出限额部分仍记为订阅用量
+	SubscriptionOverflowPolicyFallbackBalance  = "fallback_balance"  // 订阅限额用尽后，超出部分改为从余额扣费
+)
+
 // Subscription status constants
 const (
 	SubscriptionStatusActive    = "active"
@@ -65,6 +72,10 @@ const (
 	SubscriptionStatusSuspended = "suspended"
 )
 
+// CurrencyUSD 是分组计费展示货币的默认值。内部计费计算始终以美元为基准单位，
+// 该字段仅影响预估费用/用量账单等响应中展示给前端的货币标注。
+const CurrencyUSD = "USD"
+
 // DefaultAntigravityModelMapping 是 Antigravity 平台的默认模型映射
 // 当账号未配置 model_mapping 时使用此默认值
 // 与前端 useModelWhitelist.ts 中的 antigravityDefaultMappings 保持一致
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// AccountExport is the sanitized representation of an account returned by the
+// config export endpoint: only non-secret configuration, no credentials.
+type AccountExport struct {
+	ID                 int64             `json:"id"`
+	Name               string            `json:"name"`
+	Platform           string            `json:"platform"`
+	Type               string            `json:"type"`
+	GroupIDs           []int64           `json:"group_ids,omitempty"`
+	Priority           int               `json:"priority"`
+	ModelMapping       map[string]string `json:"model_mapping,omitempty"`
+	ProxyID            *int64            `json:"proxy_id,omitempty"`
+	Status             string            `json:"status"`
+	Schedulable        bool              `json:"schedulable"`
+	AutoPauseOnExpired bool              `json:"auto_pause_on_expired"`
+}
+
+// AccountExportPayload is the response body of the config export endpoint.
+type AccountExportPayload struct {
+	ExportedAt string          `json:"exported_at"`
+	Accounts   []AccountExport `json:"accounts"`
+}
+
+// Export returns all (or filtered) accounts' non-secret configuration —
+// platform, type, name, groups, priority, model mapping, proxy id and flags —
+// for backup purposes. Credentials are never included; restoring from a backup
+// means re-creating accounts with BatchCreate and supplying credentials again.
+func (h *AccountHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	selectedIDs, err := parseAccountIDs(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	accounts, err := h.resolveExportAccounts(ctx, selectedIDs, c)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items := make([]AccountExport, 0, len(accounts))
+	for i := range accounts {
+		acc := accounts[i]
+		items = append(items, AccountExport{
+			ID:                 acc.ID,
+			Name:               acc.Name,
+			Platform:           acc.Platform,
+			Type:               acc.Type,
+			GroupIDs:           acc.GroupIDs,
+			Priority:           acc.Priority,
+			ModelMapping:       acc.GetModelMapping(),
+			ProxyID:            acc.ProxyID,
+			Status:             acc.Status,
+			Schedulable:        acc.Schedulable,
+			AutoPauseOnExpired: acc.AutoPauseOnExpired,
+		})
+	}
+
+	response.Success(c, AccountExportPayload{
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Accounts:   items,
+	})
+}
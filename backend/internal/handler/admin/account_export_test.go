@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type exportResponse struct {
+	Code int                  `json:"code"`
+	Data AccountExportPayload `json:"data"`
+}
+
+func setupAccountExportRouter() (*gin.Engine, *stubAdminService) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	adminSvc := newStubAdminService()
+
+	h := NewAccountHandler(
+		adminSvc,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	router.GET("/api/v1/admin/accounts/export", h.Export)
+	return router, adminSvc
+}
+
+func TestAccountExportNeverIncludesCredentials(t *testing.T) {
+	router, adminSvc := setupAccountExportRouter()
+
+	proxyID := int64(11)
+	adminSvc.accounts = []service.Account{
+		{
+			ID:          21,
+			Name:        "account",
+			Platform:    service.PlatformOpenAI,
+			Type:        service.AccountTypeOAuth,
+			Credentials: map[string]any{"token": "super-secret", "model_mapping": map[string]any{"gpt-4": "gpt-4o"}},
+			ProxyID:     &proxyID,
+			Concurrency: 3,
+			Priority:    50,
+			Status:      service.StatusActive,
+			Schedulable: true,
+			GroupIDs:    []int64{1, 2},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/export", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Credentials must never appear anywhere in the response body, even the
+	// secret embedded inside the raw JSON.
+	require.NotContains(t, rec.Body.String(), "super-secret")
+	require.NotContains(t, rec.Body.String(), "credentials")
+
+	var resp exportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 0, resp.Code)
+	require.Len(t, resp.Data.Accounts, 1)
+
+	item := resp.Data.Accounts[0]
+	require.Equal(t, int64(21), item.ID)
+	require.Equal(t, "account", item.Name)
+	require.Equal(t, service.PlatformOpenAI, item.Platform)
+	require.Equal(t, service.AccountTypeOAuth, item.Type)
+	require.Equal(t, []int64{1, 2}, item.GroupIDs)
+	require.Equal(t, 50, item.Priority)
+	require.Equal(t, "gpt-4o", item.ModelMapping["gpt-4"])
+	require.Equal(t, &proxyID, item.ProxyID)
+	require.True(t, item.Schedulable)
+}
+
+func TestAccountExportFiltersByIDs(t *testing.T) {
+	router, _ := setupAccountExportRouter()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/export?ids=21,22", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp exportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Accounts, 2)
+	require.Equal(t, int64(21), resp.Data.Accounts[0].ID)
+	require.Equal(t, int64(22), resp.Data.Accounts[1].ID)
+}
@@ -91,7 +91,11 @@ type CreateAccountRequest struct {
 	ProxyID                 *int64         `json:"proxy_id"`
 	Concurrency             int            `json:"concurrency"`
 	Priority                int            `json:"priority"`
+	AffinityGroup           string         `json:"affinity_group" binding:"omitempty,max=100"`
+	MaxLineSize             int            `json:"max_line_size"`
 	RateMultiplier          *float64       `json:"rate_multiplier"`
+	QuietHoursStartMinute   *int           `json:"quiet_hours_start_minute"`
+	QuietHoursEndMinute     *int           `json:"quiet_hours_end_minute"`
 	GroupIDs                []int64        `json:"group_ids"`
 	ExpiresAt               *int64         `json:"expires_at"`
 	AutoPauseOnExpired      *bool          `json:"auto_pause_on_expired"`
@@ -101,20 +105,26 @@ type CreateAccountRequest struct {
 // UpdateAccountRequest represents update account request
 // 使用指针类型来区分"未提供"和"设置为0"
 type UpdateAccountRequest struct {
-	Name                    string         `json:"name"`
-	Notes                   *string        `json:"notes"`
-	Type                    string         `json:"type" binding:"omitempty,oneof=oauth setup-token apikey upstream"`
-	Credentials             map[string]any `json:"credentials"`
-	Extra                   map[string]any `json:"extra"`
-	ProxyID                 *int64         `json:"proxy_id"`
-	Concurrency             *int           `json:"concurrency"`
-	Priority                *int           `json:"priority"`
-	RateMultiplier          *float64       `json:"rate_multiplier"`
-	Status                  string         `json:"status" binding:"omitempty,oneof=active inactive"`
-	GroupIDs                *[]int64       `json:"group_ids"`
-	ExpiresAt               *int64         `json:"expires_at"`
-	AutoPauseOnExpired      *bool          `json:"auto_pause_on_expired"`
-	ConfirmMixedChannelRisk *bool          `json:"confirm_mixed_channel_risk"` // 用户确认混合渠道风险
+	Name           string         `json:"name"`
+	Notes          *string        `json:"notes"`
+	Type           string         `json:"type" binding:"omitempty,oneof=oauth setup-token apikey upstream"`
+	Credentials    map[string]any `json:"credentials"`
+	Extra          map[string]any `json:"extra"`
+	ProxyID        *int64         `json:"proxy_id"`
+	Concurrency    *int           `json:"concurrency"`
+	Priority       *int           `json:"priority"`
+	AffinityGroup  *string        `json:"affinity_group" binding:"omitempty"`
+	MaxLineSize    *int           `json:"max_line_size"`
+	RateMultiplier *float64       `json:"rate_multiplier"`
+	// QuietHoursStartMinute / QuietHoursEndMinute 静默时段（UTC，一天内分钟数 0-1439）；
+	// 两者都为负数表示清除静默时段配置
+	QuietHoursStartMinute   *int     `json:"quiet_hours_start_minute"`
+	QuietHoursEndMinute     *int     `json:"quiet_hours_end_minute"`
+	Status                  string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	GroupIDs                *[]int64 `json:"group_ids"`
+	ExpiresAt               *int64   `json:"expires_at"`
+	AutoPauseOnExpired      *bool    `json:"auto_pause_on_expired"`
+	ConfirmMixedChannelRisk *bool    `json:"confirm_mixed_channel_risk"` // 用户确认混合渠道风险
 }
 
 // BulkUpdateAccountsRequest represents the payload for bulk editing accounts
@@ -309,7 +319,11 @@ func (h *AccountHandler) Create(c *gin.Context) {
 		ProxyID:               req.ProxyID,
 		Concurrency:           req.Concurrency,
 		Priority:              req.Priority,
+		AffinityGroup:         req.AffinityGroup,
+		MaxLineSize:           req.MaxLineSize,
 		RateMultiplier:        req.RateMultiplier,
+		QuietHoursStartMinute: req.QuietHoursStartMinute,
+		QuietHoursEndMinute:   req.QuietHoursEndMinute,
 		GroupIDs:              req.GroupIDs,
 		ExpiresAt:             req.ExpiresAt,
 		AutoPauseOnExpired:    req.AutoPauseOnExpired,
@@ -370,9 +384,13 @@ func (h *AccountHandler) Update(c *gin.Context) {
 		Credentials:           req.Credentials,
 		Extra:                 req.Extra,
 		ProxyID:               req.ProxyID,
-		Concurrency:           req.Concurrency, // 指针类型，nil 表示未提供
-		Priority:              req.Priority,    // 指针类型，nil 表示未提供
+		Concurrency:           req.Concurrency,   // 指针类型，nil 表示未提供
+		Priority:              req.Priority,      // 指针类型，nil 表示未提供
+		AffinityGroup:         req.AffinityGroup, // 指针类型，nil 表示未提供
+		MaxLineSize:           req.MaxLineSize,   // 指针类型，nil 表示未提供
 		RateMultiplier:        req.RateMultiplier,
+		QuietHoursStartMinute: req.QuietHoursStartMinute,
+		QuietHoursEndMinute:   req.QuietHoursEndMinute,
 		Status:                req.Status,
 		GroupIDs:              req.GroupIDs,
 		ExpiresAt:             req.ExpiresAt,
@@ -694,6 +712,76 @@ func (h *AccountHandler) GetStats(c *gin.Context) {
 	response.Success(c, stats)
 }
 
+// GetThroughput handles getting the account's average output throughput (tokens/sec)
+// GET /api/v1/admin/accounts/:id/throughput
+func (h *AccountHandler) GetThroughput(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid account ID")
+		return
+	}
+
+	// Parse days parameter (default 30)
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 90 {
+			days = d
+		}
+	}
+
+	// Calculate time range
+	now := timezone.Now()
+	endTime := timezone.StartOfDay(now.AddDate(0, 0, 1))
+	startTime := timezone.StartOfDay(now.AddDate(0, 0, -days+1))
+
+	tokensPerSecond, err := h.accountUsageService.GetAccountTokensPerSecond(c.Request.Context(), accountID, startTime, endTime)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"account_id":        accountID,
+		"days":              days,
+		"tokens_per_second": tokensPerSecond,
+	})
+}
+
+// GetCacheHitRatio handles returning an account's prompt cache hit ratio over a time window
+// GET /api/v1/admin/accounts/:id/cache-hit-ratio
+func (h *AccountHandler) GetCacheHitRatio(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid account ID")
+		return
+	}
+
+	// Parse days parameter (default 30)
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 90 {
+			days = d
+		}
+	}
+
+	// Calculate time range
+	now := timezone.Now()
+	endTime := timezone.StartOfDay(now.AddDate(0, 0, 1))
+	startTime := timezone.StartOfDay(now.AddDate(0, 0, -days+1))
+
+	cacheHitRatio, err := h.accountUsageService.GetAccountCacheHitRatio(c.Request.Context(), accountID, startTime, endTime)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"account_id":      accountID,
+		"days":            days,
+		"cache_hit_ratio": cacheHitRatio,
+	})
+}
+
 // ClearError handles clearing account error
 // POST /api/v1/admin/accounts/:id/clear-error
 func (h *AccountHandler) ClearError(c *gin.Context) {
@@ -721,6 +809,63 @@ func (h *AccountHandler) ClearError(c *gin.Context) {
 	response.Success(c, dto.AccountFromService(account))
 }
 
+// RetryError 对一个 error 状态的账号立即发起一次连接校验（复用账号测试功能），
+// 成功则清除错误状态并重新启用，失败则保持 error 状态并记录新的错误信息。
+// POST /api/v1/admin/accounts/:id/retry-error
+func (h *AccountHandler) RetryError(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid account ID")
+		return
+	}
+
+	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if account.Status != service.StatusError {
+		response.BadRequest(c, "Account is not in error status")
+		return
+	}
+
+	var req TestAccountRequest
+	// Allow empty body, model_id is optional
+	_ = c.ShouldBindJSON(&req)
+
+	result := h.accountTestService.ValidateConnectionOnce(c.Request.Context(), accountID, req.ModelID)
+
+	if result.Success {
+		account, err = h.adminService.ClearAccountError(c.Request.Context(), accountID)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+		if h.tokenCacheInvalidator != nil && account.IsOAuth() {
+			if invalidateErr := h.tokenCacheInvalidator.InvalidateToken(c.Request.Context(), account); invalidateErr != nil {
+				// 缓存失效失败只记录日志，不影响主流程
+				_ = c.Error(invalidateErr)
+			}
+		}
+	} else {
+		if err := h.adminService.SetAccountError(c.Request.Context(), accountID, result.Error); err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+		account, err = h.adminService.GetAccount(c.Request.Context(), accountID)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	response.Success(c, gin.H{
+		"success": result.Success,
+		"error":   result.Error,
+		"account": dto.AccountFromService(account),
+	})
+}
+
 // BatchCreate handles batch creating accounts
 // POST /api/v1/admin/accounts/batch
 func (h *AccountHandler) BatchCreate(c *gin.Context) {
@@ -760,7 +905,11 @@ func (h *AccountHandler) BatchCreate(c *gin.Context) {
 			ProxyID:               item.ProxyID,
 			Concurrency:           item.Concurrency,
 			Priority:              item.Priority,
+			AffinityGroup:         item.AffinityGroup,
+			MaxLineSize:           item.MaxLineSize,
 			RateMultiplier:        item.RateMultiplier,
+			QuietHoursStartMinute: item.QuietHoursStartMinute,
+			QuietHoursEndMinute:   item.QuietHoursEndMinute,
 			GroupIDs:              item.GroupIDs,
 			ExpiresAt:             item.ExpiresAt,
 			AutoPauseOnExpired:    item.AutoPauseOnExpired,
@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// retryErrorAccountRepo serves a single fixed account for RetryError tests.
+type retryErrorAccountRepo struct {
+	service.AccountRepository
+	account *service.Account
+}
+
+func (r *retryErrorAccountRepo) GetByID(ctx context.Context, id int64) (*service.Account, error) {
+	return r.account, nil
+}
+
+// retryErrorUpstream returns a canned response for every upstream call, used
+// to simulate the connection test succeeding or failing.
+type retryErrorUpstream struct {
+	service.HTTPUpstream
+	statusCode int
+	err        error
+}
+
+func (u *retryErrorUpstream) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, enableTLSFingerprint bool) (*http.Response, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	return &http.Response{
+		StatusCode: u.statusCode,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func setupRetryErrorRouter(account *service.Account, upstream service.HTTPUpstream) (*gin.Engine, *stubAdminService) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	adminSvc := newStubAdminService()
+	adminSvc.accounts = append(adminSvc.accounts, *account)
+
+	accountTestService := service.NewAccountTestService(
+		&retryErrorAccountRepo{account: account},
+		nil,
+		nil,
+		upstream,
+		&config.Config{},
+	)
+
+	h := NewAccountHandler(
+		adminSvc,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		accountTestService,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	router.POST("/api/v1/admin/accounts/:id/retry-error", h.RetryError)
+	return router, adminSvc
+}
+
+func errorAccount() *service.Account {
+	return &service.Account{
+		ID:           1,
+		Name:         "errored-account",
+		Status:       service.StatusError,
+		ErrorMessage: "previous failure",
+		Platform:     service.PlatformAnthropic,
+		Type:         service.AccountTypeOAuth,
+		Credentials:  map[string]any{"access_token": "test-token"},
+	}
+}
+
+func TestAccountHandler_RetryError_SuccessReEnablesAccount(t *testing.T) {
+	router, adminSvc := setupRetryErrorRouter(errorAccount(), &retryErrorUpstream{statusCode: http.StatusOK})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/1/retry-error", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	updated, err := adminSvc.GetAccount(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, service.StatusActive, updated.Status)
+	require.Empty(t, updated.ErrorMessage)
+}
+
+func TestAccountHandler_RetryError_FailureKeepsAccountErrored(t *testing.T) {
+	router, adminSvc := setupRetryErrorRouter(errorAccount(), &retryErrorUpstream{statusCode: http.StatusUnauthorized})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/1/retry-error", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	updated, err := adminSvc.GetAccount(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, service.StatusError, updated.Status)
+	require.Contains(t, updated.ErrorMessage, fmt.Sprintf("%d", http.StatusUnauthorized))
+}
+
+func TestAccountHandler_RetryError_RejectsNonErrorAccount(t *testing.T) {
+	account := errorAccount()
+	account.Status = service.StatusActive
+	router, _ := setupRetryErrorRouter(account, &retryErrorUpstream{statusCode: http.StatusOK})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/1/retry-error", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
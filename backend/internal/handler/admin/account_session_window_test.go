@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type sessionWindowAccountResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		SessionWindowStatus      string `json:"session_window_status"`
+		SessionWindowUtilization *int   `json:"session_window_utilization"`
+	} `json:"data"`
+}
+
+func TestAccountGetByID_ExposesSessionWindowUtilization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	adminSvc := newStubAdminService()
+
+	utilization := 85
+	adminSvc.accounts = []service.Account{
+		{
+			ID:                       1,
+			Name:                     "account",
+			Status:                   service.StatusActive,
+			SessionWindowStatus:      "allowed_warning",
+			SessionWindowUtilization: &utilization,
+		},
+	}
+
+	h := NewAccountHandler(adminSvc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router.GET("/api/v1/admin/accounts/:id", h.GetByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/1", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp sessionWindowAccountResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(t, "allowed_warning", resp.Data.SessionWindowStatus)
+	require.NotNil(t, resp.Data.SessionWindowUtilization)
+	require.Equal(t, 85, *resp.Data.SessionWindowUtilization)
+}
+
+func TestAccountGetByID_SessionWindowUtilizationNilWhenUnknown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	adminSvc := newStubAdminService()
+
+	adminSvc.accounts = []service.Account{
+		{ID: 2, Name: "account", Status: service.StatusActive},
+	}
+
+	h := NewAccountHandler(adminSvc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router.GET("/api/v1/admin/accounts/:id", h.GetByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/2", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp sessionWindowAccountResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Nil(t, resp.Data.SessionWindowUtilization)
+}
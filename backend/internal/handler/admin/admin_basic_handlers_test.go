@@ -29,6 +29,8 @@ func setupAdminRouter() (*gin.Engine, *stubAdminService) {
 	router.POST("/api/v1/admin/users/:id/balance", userHandler.UpdateBalance)
 	router.GET("/api/v1/admin/users/:id/api-keys", userHandler.GetUserAPIKeys)
 	router.GET("/api/v1/admin/users/:id/usage", userHandler.GetUserUsage)
+	router.GET("/api/v1/admin/users/:id/sticky-sessions", userHandler.ListStickySessions)
+	router.DELETE("/api/v1/admin/users/:id/sticky-sessions", userHandler.RevokeStickySessions)
 
 	router.GET("/api/v1/admin/groups", groupHandler.List)
 	router.GET("/api/v1/admin/groups/all", groupHandler.GetAll)
@@ -38,6 +40,8 @@ func setupAdminRouter() (*gin.Engine, *stubAdminService) {
 	router.DELETE("/api/v1/admin/groups/:id", groupHandler.Delete)
 	router.GET("/api/v1/admin/groups/:id/stats", groupHandler.GetStats)
 	router.GET("/api/v1/admin/groups/:id/api-keys", groupHandler.GetGroupAPIKeys)
+	router.GET("/api/v1/admin/groups/:id/routing", groupHandler.GetRouting)
+	router.PUT("/api/v1/admin/groups/:id/routing", groupHandler.UpdateRouting)
 
 	router.GET("/api/v1/admin/proxies", proxyHandler.List)
 	router.GET("/api/v1/admin/proxies/all", proxyHandler.GetAll)
@@ -112,6 +116,39 @@ func TestUserHandlerEndpoints(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestUserHandlerStickySessions(t *testing.T) {
+	router, adminSvc := setupAdminRouter()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/1/sticky-sessions", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var listResp struct {
+		Data []struct {
+			SessionHash string `json:"session_hash"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listResp))
+	require.Len(t, listResp.Data, 1)
+	require.Equal(t, "session-hash-1", listResp.Data[0].SessionHash)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/1/sticky-sessions", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []int64{1}, adminSvc.revokedUserIDs)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/1/sticky-sessions", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	listResp.Data = nil
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listResp))
+	require.Empty(t, listResp.Data)
+}
+
 func TestGroupHandlerEndpoints(t *testing.T) {
 	router, _ := setupAdminRouter()
 
@@ -160,6 +197,54 @@ func TestGroupHandlerEndpoints(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestGroupHandlerRouting(t *testing.T) {
+	router, _ := setupAdminRouter()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/groups/2/routing", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Valid update: pattern references an account bound to the group (account ID 3).
+	body, _ := json.Marshal(map[string]any{
+		"model_routing":         map[string][]int64{"claude-opus-*": {3}},
+		"model_routing_enabled": true,
+	})
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/groups/2/routing", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/groups/2/routing", nil)
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "claude-opus-*")
+
+	// Invalid update: referenced account does not exist / is not bound to the group.
+	body, _ = json.Marshal(map[string]any{
+		"model_routing":         map[string][]int64{"claude-opus-*": {999}},
+		"model_routing_enabled": true,
+	})
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/groups/2/routing", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	require.NotEqual(t, http.StatusOK, rec.Code)
+
+	// Invalid update: wildcard is only supported at the end of a pattern.
+	body, _ = json.Marshal(map[string]any{
+		"model_routing":         map[string][]int64{"claude-*-sonnet": {3}},
+		"model_routing_enabled": true,
+	})
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/groups/2/routing", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	require.NotEqual(t, http.StatusOK, rec.Code)
+}
+
 func TestProxyHandlerEndpoints(t *testing.T) {
 	router, _ := setupAdminRouter()
 
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,9 @@ type stubAdminService struct {
 	updatedProxyIDs []int64
 	updatedProxies  []*service.UpdateProxyInput
 	testedProxyIDs  []int64
+	stickySessions  []service.StickySession
+	revokedUserIDs  []int64
+	groupRouting    map[int64]*service.GroupRoutingRules
 	mu              sync.Mutex
 }
 
@@ -87,6 +91,9 @@ func newStubAdminService() *stubAdminService {
 		proxies:     []service.Proxy{proxy},
 		proxyCounts: []service.ProxyWithAccountCount{{Proxy: proxy, AccountCount: 1}},
 		redeems:     []service.RedeemCode{redeem},
+		stickySessions: []service.StickySession{
+			{GroupID: group.ID, SessionHash: "session-hash-1", AccountID: account.ID},
+		},
 	}
 }
 
@@ -166,11 +173,58 @@ func (s *stubAdminService) GetGroupAPIKeys(ctx context.Context, groupID int64, p
 	return s.apiKeys, int64(len(s.apiKeys)), nil
 }
 
+func (s *stubAdminService) GetGroupRouting(ctx context.Context, groupID int64) (*service.GroupRoutingRules, error) {
+	if rules, ok := s.groupRouting[groupID]; ok {
+		return rules, nil
+	}
+	return &service.GroupRoutingRules{}, nil
+}
+
+func (s *stubAdminService) UpdateGroupRouting(ctx context.Context, groupID int64, input *service.UpdateGroupRoutingInput) (*service.GroupRoutingRules, error) {
+	boundAccountIDs := make(map[int64]struct{}, len(s.accounts))
+	for _, a := range s.accounts {
+		boundAccountIDs[a.ID] = struct{}{}
+	}
+	for pattern, accountIDs := range input.ModelRouting {
+		if strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("model routing pattern must not be empty")
+		}
+		if idx := strings.Index(pattern, "*"); idx >= 0 && idx != len(pattern)-1 {
+			return nil, fmt.Errorf("model routing pattern %q is invalid: '*' is only supported at the end", pattern)
+		}
+		if len(accountIDs) == 0 {
+			return nil, fmt.Errorf("model routing pattern %q must reference at least one account", pattern)
+		}
+		for _, accountID := range accountIDs {
+			if _, ok := boundAccountIDs[accountID]; !ok {
+				return nil, fmt.Errorf("model routing pattern %q references account %d which is not bound to this group", pattern, accountID)
+			}
+		}
+	}
+
+	rules := &service.GroupRoutingRules{ModelRouting: input.ModelRouting, ModelRoutingEnabled: input.ModelRoutingEnabled}
+	if s.groupRouting == nil {
+		s.groupRouting = map[int64]*service.GroupRoutingRules{}
+	}
+	s.groupRouting[groupID] = rules
+	return rules, nil
+}
+
+func (s *stubAdminService) ValidateGroupConfig(ctx context.Context, groupID int64) ([]service.GroupValidationIssue, error) {
+	return nil, nil
+}
+
 func (s *stubAdminService) ListAccounts(ctx context.Context, page, pageSize int, platform, accountType, status, search string, groupID int64) ([]service.Account, int64, error) {
 	return s.accounts, int64(len(s.accounts)), nil
 }
 
 func (s *stubAdminService) GetAccount(ctx context.Context, id int64) (*service.Account, error) {
+	for i := range s.accounts {
+		if s.accounts[i].ID == id {
+			account := s.accounts[i]
+			return &account, nil
+		}
+	}
 	account := service.Account{ID: id, Name: "account", Status: service.StatusActive}
 	return &account, nil
 }
@@ -207,11 +261,30 @@ func (s *stubAdminService) RefreshAccountCredentials(ctx context.Context, id int
 }
 
 func (s *stubAdminService) ClearAccountError(ctx context.Context, id int64) (*service.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.accounts {
+		if s.accounts[i].ID == id {
+			s.accounts[i].Status = service.StatusActive
+			s.accounts[i].ErrorMessage = ""
+			account := s.accounts[i]
+			return &account, nil
+		}
+	}
 	account := service.Account{ID: id, Name: "account", Status: service.StatusActive}
 	return &account, nil
 }
 
 func (s *stubAdminService) SetAccountError(ctx context.Context, id int64, errorMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.accounts {
+		if s.accounts[i].ID == id {
+			s.accounts[i].Status = service.StatusError
+			s.accounts[i].ErrorMessage = errorMsg
+			return nil
+		}
+	}
 	return nil
 }
 
@@ -361,5 +434,17 @@ func (s *stubAdminService) UpdateGroupSortOrders(ctx context.Context, updates []
 	return nil
 }
 
+func (s *stubAdminService) ListUserStickySessions(ctx context.Context, userID int64) ([]service.StickySession, error) {
+	return s.stickySessions, nil
+}
+
+func (s *stubAdminService) RevokeUserStickySessions(ctx context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokedUserIDs = append(s.revokedUserIDs, userID)
+	s.stickySessions = nil
+	return nil
+}
+
 // Ensure stub implements interface.
 var _ service.AdminService = (*stubAdminService)(nil)
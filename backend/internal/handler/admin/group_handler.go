@@ -25,15 +25,17 @@ func NewGroupHandler(adminService service.AdminService) *GroupHandler {
 
 // CreateGroupRequest represents create group request
 type CreateGroupRequest struct {
-	Name             string   `json:"name" binding:"required"`
-	Description      string   `json:"description"`
-	Platform         string   `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity"`
-	RateMultiplier   float64  `json:"rate_multiplier"`
-	IsExclusive      bool     `json:"is_exclusive"`
-	SubscriptionType string   `json:"subscription_type" binding:"omitempty,oneof=standard subscription"`
-	DailyLimitUSD    *float64 `json:"daily_limit_usd"`
-	WeeklyLimitUSD   *float64 `json:"weekly_limit_usd"`
-	MonthlyLimitUSD  *float64 `json:"monthly_limit_usd"`
+	Name             string  `json:"name" binding:"required"`
+	Description      string  `json:"description"`
+	Platform         string  `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity"`
+	RateMultiplier   float64 `json:"rate_multiplier"`
+	IsExclusive      bool    `json:"is_exclusive"`
+	SubscriptionType string  `json:"subscription_type" binding:"omitempty,oneof=standard subscription"`
+	// 计费展示货币代码（如 USD、CNY、EUR），仅影响前端展示，内部计费计算始终以美元为基准单位；留空则使用默认值 USD
+	Currency        string   `json:"currency" binding:"omitempty,len=3"`
+	DailyLimitUSD   *float64 `json:"daily_limit_usd"`
+	WeeklyLimitUSD  *float64 `json:"weekly_limit_usd"`
+	MonthlyLimitUSD *float64 `json:"monthly_limit_usd"`
 	// 图片生成计费配置（antigravity 和 gemini 平台使用，负数表示清除配置）
 	ImagePrice1K                    *float64 `json:"image_price_1k"`
 	ImagePrice2K                    *float64 `json:"image_price_2k"`
@@ -47,6 +49,31 @@ type CreateGroupRequest struct {
 	MCPXMLInject        *bool              `json:"mcp_xml_inject"`
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string `json:"supported_model_scopes"`
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写
+	DisableMetadataRewrite bool `json:"disable_metadata_rewrite"`
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages
+	MaxMessages *int `json:"max_messages"`
+	// 分组每日请求次数上限
+	DailyRequestLimit *int `json:"daily_request_limit"`
+	// 流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发
+	MaxOutputTokens *int `json:"max_output_tokens"`
+	// 分组级上游默认请求头，构建上游请求时应用于该分组下的 API-key 账号
+	UpstreamHeaders map[string]string `json:"upstream_headers"`
+	// Intent 路由配置（按 x-sub2api-intent 请求头精确匹配）
+	IntentRouting        map[string][]int64 `json:"intent_routing"`
+	IntentRoutingEnabled bool               `json:"intent_routing_enabled"`
+	// 订阅限额用尽后的计费策略（仅订阅模式分组生效）
+	SubscriptionOverflowPolicy string `json:"subscription_overflow_policy" binding:"omitempty,oneof=subscription_only fallback_balance"`
+	// 允许的上游端点白名单，为空表示不限制
+	AllowedEndpoints []string `json:"allowed_endpoints"`
+	// 是否要求客户端必须携带 anthropic-version 请求头
+	RequireAnthropicVersion bool `json:"require_anthropic_version"`
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly bool `json:"mixed_scheduling_native_saturation_only"`
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元），不设置或 <=0 表示不限制
+	WindowCostLimitUSD *float64 `json:"window_cost_limit_usd"`
+	// WindowCostLimitUSD 对应的滚动窗口时长（小时），不设置或 <=0 时默认为 5 小时
+	WindowCostWindowHours *int `json:"window_cost_window_hours"`
 	// 从指定分组复制账号（创建后自动绑定）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -60,9 +87,11 @@ type UpdateGroupRequest struct {
 	IsExclusive      *bool    `json:"is_exclusive"`
 	Status           string   `json:"status" binding:"omitempty,oneof=active inactive"`
 	SubscriptionType string   `json:"subscription_type" binding:"omitempty,oneof=standard subscription"`
-	DailyLimitUSD    *float64 `json:"daily_limit_usd"`
-	WeeklyLimitUSD   *float64 `json:"weekly_limit_usd"`
-	MonthlyLimitUSD  *float64 `json:"monthly_limit_usd"`
+	// 计费展示货币代码；空值表示不修改
+	Currency        string   `json:"currency" binding:"omitempty,len=3"`
+	DailyLimitUSD   *float64 `json:"daily_limit_usd"`
+	WeeklyLimitUSD  *float64 `json:"weekly_limit_usd"`
+	MonthlyLimitUSD *float64 `json:"monthly_limit_usd"`
 	// 图片生成计费配置（antigravity 和 gemini 平台使用，负数表示清除配置）
 	ImagePrice1K                    *float64 `json:"image_price_1k"`
 	ImagePrice2K                    *float64 `json:"image_price_2k"`
@@ -76,6 +105,31 @@ type UpdateGroupRequest struct {
 	MCPXMLInject        *bool              `json:"mcp_xml_inject"`
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes *[]string `json:"supported_model_scopes"`
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写
+	DisableMetadataRewrite *bool `json:"disable_metadata_rewrite"`
+	// 单次请求允许的最大 messages 数量；0 或负数表示清除覆盖（恢复使用全局配置）
+	MaxMessages *int `json:"max_messages"`
+	// 分组每日请求次数上限；0 或负数表示清除限制
+	DailyRequestLimit *int `json:"daily_request_limit"`
+	// 流式响应累计 output tokens 硬上限；0 或负数表示清除限制
+	MaxOutputTokens *int `json:"max_output_tokens"`
+	// 分组级上游默认请求头；传 null 表示不修改，传 {} 表示清除
+	UpstreamHeaders map[string]string `json:"upstream_headers"`
+	// Intent 路由配置；传 null 表示不修改，传 {} 表示清除
+	IntentRouting        map[string][]int64 `json:"intent_routing"`
+	IntentRoutingEnabled *bool              `json:"intent_routing_enabled"`
+	// 订阅限额用尽后的计费策略；空值表示不修改
+	SubscriptionOverflowPolicy string `json:"subscription_overflow_policy" binding:"omitempty,oneof=subscription_only fallback_balance"`
+	// 允许的上游端点白名单；传 null 表示不修改，传 [] 表示清除限制
+	AllowedEndpoints *[]string `json:"allowed_endpoints"`
+	// 是否要求客户端必须携带 anthropic-version 请求头
+	RequireAnthropicVersion *bool `json:"require_anthropic_version"`
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly *bool `json:"mixed_scheduling_native_saturation_only"`
+	// 分组窗口费用上限（美元）；0 或负数表示清除限制
+	WindowCostLimitUSD *float64 `json:"window_cost_limit_usd"`
+	// 分组窗口费用滚动窗口时长（小时）；0 或负数表示清除（恢复默认 5 小时）
+	WindowCostWindowHours *int `json:"window_cost_window_hours"`
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -167,26 +221,40 @@ func (h *GroupHandler) Create(c *gin.Context) {
 	}
 
 	group, err := h.adminService.CreateGroup(c.Request.Context(), &service.CreateGroupInput{
-		Name:                            req.Name,
-		Description:                     req.Description,
-		Platform:                        req.Platform,
-		RateMultiplier:                  req.RateMultiplier,
-		IsExclusive:                     req.IsExclusive,
-		SubscriptionType:                req.SubscriptionType,
-		DailyLimitUSD:                   req.DailyLimitUSD,
-		WeeklyLimitUSD:                  req.WeeklyLimitUSD,
-		MonthlyLimitUSD:                 req.MonthlyLimitUSD,
-		ImagePrice1K:                    req.ImagePrice1K,
-		ImagePrice2K:                    req.ImagePrice2K,
-		ImagePrice4K:                    req.ImagePrice4K,
-		ClaudeCodeOnly:                  req.ClaudeCodeOnly,
-		FallbackGroupID:                 req.FallbackGroupID,
-		FallbackGroupIDOnInvalidRequest: req.FallbackGroupIDOnInvalidRequest,
-		ModelRouting:                    req.ModelRouting,
-		ModelRoutingEnabled:             req.ModelRoutingEnabled,
-		MCPXMLInject:                    req.MCPXMLInject,
-		SupportedModelScopes:            req.SupportedModelScopes,
-		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
+		Name:                                req.Name,
+		Description:                         req.Description,
+		Platform:                            req.Platform,
+		RateMultiplier:                      req.RateMultiplier,
+		IsExclusive:                         req.IsExclusive,
+		SubscriptionType:                    req.SubscriptionType,
+		Currency:                            req.Currency,
+		DailyLimitUSD:                       req.DailyLimitUSD,
+		WeeklyLimitUSD:                      req.WeeklyLimitUSD,
+		MonthlyLimitUSD:                     req.MonthlyLimitUSD,
+		ImagePrice1K:                        req.ImagePrice1K,
+		ImagePrice2K:                        req.ImagePrice2K,
+		ImagePrice4K:                        req.ImagePrice4K,
+		ClaudeCodeOnly:                      req.ClaudeCodeOnly,
+		FallbackGroupID:                     req.FallbackGroupID,
+		FallbackGroupIDOnInvalidRequest:     req.FallbackGroupIDOnInvalidRequest,
+		ModelRouting:                        req.ModelRouting,
+		ModelRoutingEnabled:                 req.ModelRoutingEnabled,
+		MCPXMLInject:                        req.MCPXMLInject,
+		SupportedModelScopes:                req.SupportedModelScopes,
+		DisableMetadataRewrite:              req.DisableMetadataRewrite,
+		MaxMessages:                         req.MaxMessages,
+		DailyRequestLimit:                   req.DailyRequestLimit,
+		MaxOutputTokens:                     req.MaxOutputTokens,
+		UpstreamHeaders:                     req.UpstreamHeaders,
+		IntentRouting:                       req.IntentRouting,
+		IntentRoutingEnabled:                req.IntentRoutingEnabled,
+		SubscriptionOverflowPolicy:          req.SubscriptionOverflowPolicy,
+		AllowedEndpoints:                    req.AllowedEndpoints,
+		RequireAnthropicVersion:             req.RequireAnthropicVersion,
+		MixedSchedulingNativeSaturationOnly: req.MixedSchedulingNativeSaturationOnly,
+		WindowCostLimitUSD:                  req.WindowCostLimitUSD,
+		WindowCostWindowHours:               req.WindowCostWindowHours,
+		CopyAccountsFromGroupIDs:            req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -212,27 +280,41 @@ func (h *GroupHandler) Update(c *gin.Context) {
 	}
 
 	group, err := h.adminService.UpdateGroup(c.Request.Context(), groupID, &service.UpdateGroupInput{
-		Name:                            req.Name,
-		Description:                     req.Description,
-		Platform:                        req.Platform,
-		RateMultiplier:                  req.RateMultiplier,
-		IsExclusive:                     req.IsExclusive,
-		Status:                          req.Status,
-		SubscriptionType:                req.SubscriptionType,
-		DailyLimitUSD:                   req.DailyLimitUSD,
-		WeeklyLimitUSD:                  req.WeeklyLimitUSD,
-		MonthlyLimitUSD:                 req.MonthlyLimitUSD,
-		ImagePrice1K:                    req.ImagePrice1K,
-		ImagePrice2K:                    req.ImagePrice2K,
-		ImagePrice4K:                    req.ImagePrice4K,
-		ClaudeCodeOnly:                  req.ClaudeCodeOnly,
-		FallbackGroupID:                 req.FallbackGroupID,
-		FallbackGroupIDOnInvalidRequest: req.FallbackGroupIDOnInvalidRequest,
-		ModelRouting:                    req.ModelRouting,
-		ModelRoutingEnabled:             req.ModelRoutingEnabled,
-		MCPXMLInject:                    req.MCPXMLInject,
-		SupportedModelScopes:            req.SupportedModelScopes,
-		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
+		Name:                                req.Name,
+		Description:                         req.Description,
+		Platform:                            req.Platform,
+		RateMultiplier:                      req.RateMultiplier,
+		IsExclusive:                         req.IsExclusive,
+		Status:                              req.Status,
+		SubscriptionType:                    req.SubscriptionType,
+		Currency:                            req.Currency,
+		DailyLimitUSD:                       req.DailyLimitUSD,
+		WeeklyLimitUSD:                      req.WeeklyLimitUSD,
+		MonthlyLimitUSD:                     req.MonthlyLimitUSD,
+		ImagePrice1K:                        req.ImagePrice1K,
+		ImagePrice2K:                        req.ImagePrice2K,
+		ImagePrice4K:                        req.ImagePrice4K,
+		ClaudeCodeOnly:                      req.ClaudeCodeOnly,
+		FallbackGroupID:                     req.FallbackGroupID,
+		FallbackGroupIDOnInvalidRequest:     req.FallbackGroupIDOnInvalidRequest,
+		ModelRouting:                        req.ModelRouting,
+		ModelRoutingEnabled:                 req.ModelRoutingEnabled,
+		MCPXMLInject:                        req.MCPXMLInject,
+		SupportedModelScopes:                req.SupportedModelScopes,
+		DisableMetadataRewrite:              req.DisableMetadataRewrite,
+		MaxMessages:                         req.MaxMessages,
+		DailyRequestLimit:                   req.DailyRequestLimit,
+		MaxOutputTokens:                     req.MaxOutputTokens,
+		UpstreamHeaders:                     req.UpstreamHeaders,
+		IntentRouting:                       req.IntentRouting,
+		IntentRoutingEnabled:                req.IntentRoutingEnabled,
+		SubscriptionOverflowPolicy:          req.SubscriptionOverflowPolicy,
+		AllowedEndpoints:                    req.AllowedEndpoints,
+		RequireAnthropicVersion:             req.RequireAnthropicVersion,
+		MixedSchedulingNativeSaturationOnly: req.MixedSchedulingNativeSaturationOnly,
+		WindowCostLimitUSD:                  req.WindowCostLimitUSD,
+		WindowCostWindowHours:               req.WindowCostWindowHours,
+		CopyAccountsFromGroupIDs:            req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -303,6 +385,107 @@ func (h *GroupHandler) GetGroupAPIKeys(c *gin.Context) {
 	response.Paginated(c, outKeys, total, page, pageSize)
 }
 
+// GroupRoutingResponse represents a group's parsed model routing rules
+type GroupRoutingResponse struct {
+	ModelRouting        map[string][]int64 `json:"model_routing"`
+	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+}
+
+// UpdateGroupRoutingRequest represents the request to replace a group's model routing rules
+type UpdateGroupRoutingRequest struct {
+	ModelRouting        map[string][]int64 `json:"model_routing"`
+	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+}
+
+// GetRouting handles getting a group's model routing rules
+// GET /api/v1/admin/groups/:id/routing
+func (h *GroupHandler) GetRouting(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	rules, err := h.adminService.GetGroupRouting(c.Request.Context(), groupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, &GroupRoutingResponse{
+		ModelRouting:        rules.ModelRouting,
+		ModelRoutingEnabled: rules.ModelRoutingEnabled,
+	})
+}
+
+// UpdateRouting handles validating and saving a group's model routing rules
+// PUT /api/v1/admin/groups/:id/routing
+func (h *GroupHandler) UpdateRouting(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	var req UpdateGroupRoutingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	rules, err := h.adminService.UpdateGroupRouting(c.Request.Context(), groupID, &service.UpdateGroupRoutingInput{
+		ModelRouting:        req.ModelRouting,
+		ModelRoutingEnabled: req.ModelRoutingEnabled,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, &GroupRoutingResponse{
+		ModelRouting:        rules.ModelRouting,
+		ModelRoutingEnabled: rules.ModelRoutingEnabled,
+	})
+}
+
+// GroupValidationIssueResponse represents a single diagnostic from validating a group's configuration
+type GroupValidationIssueResponse struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// GroupValidationResponse represents the result of validating a group's configuration end-to-end
+type GroupValidationResponse struct {
+	Valid  bool                           `json:"valid"`
+	Issues []GroupValidationIssueResponse `json:"issues"`
+}
+
+// Validate handles validating a group's configuration end-to-end
+// POST /api/v1/admin/groups/:id/validate
+func (h *GroupHandler) Validate(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	issues, err := h.adminService.ValidateGroupConfig(c.Request.Context(), groupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	resp := &GroupValidationResponse{Valid: true, Issues: make([]GroupValidationIssueResponse, 0, len(issues))}
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			resp.Valid = false
+		}
+		resp.Issues = append(resp.Issues, GroupValidationIssueResponse{Severity: issue.Severity, Message: issue.Message})
+	}
+
+	response.Success(c, resp)
+}
+
 // UpdateSortOrderRequest represents the request to update group sort orders
 type UpdateSortOrderRequest struct {
 	Updates []struct {
@@ -63,6 +63,44 @@ func (h *OpsHandler) GetConcurrencyStats(c *gin.Context) {
 	response.Success(c, payload)
 }
 
+// GetPoolUtilizationSummary returns an aggregated account pool capacity view:
+// total/in-use slots, waiting count, and per-platform breakdown.
+// GET /api/v1/admin/ops/pool-utilization
+func (h *OpsHandler) GetPoolUtilizationSummary(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	if !h.opsService.IsRealtimeMonitoringEnabled(c.Request.Context()) {
+		response.Success(c, gin.H{
+			"enabled":   false,
+			"summary":   &service.PoolUtilizationSummary{Platform: map[string]*service.PlatformConcurrencyInfo{}},
+			"timestamp": time.Now().UTC(),
+		})
+		return
+	}
+
+	summary, collectedAt, err := h.opsService.GetPoolUtilizationSummary(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	payload := gin.H{
+		"enabled": true,
+		"summary": summary,
+	}
+	if collectedAt != nil {
+		payload["timestamp"] = collectedAt.UTC()
+	}
+	response.Success(c, payload)
+}
+
 // GetUserConcurrencyStats returns real-time concurrency usage for all active users.
 // GET /api/v1/admin/ops/user-concurrency
 func (h *OpsHandler) GetUserConcurrencyStats(c *gin.Context) {
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// poolUtilizationAccountRepo serves a fixed set of accounts across two platforms
+// for GetPoolUtilizationSummary tests.
+type poolUtilizationAccountRepo struct {
+	service.AccountRepository
+	accounts []service.Account
+}
+
+func (r *poolUtilizationAccountRepo) ListWithFilters(ctx context.Context, params pagination.PaginationParams, platform, accountType, status, search string, groupID int64) ([]service.Account, *pagination.PaginationResult, error) {
+	if params.Page > 1 {
+		return nil, &pagination.PaginationResult{Total: int64(len(r.accounts))}, nil
+	}
+	return r.accounts, &pagination.PaginationResult{Total: int64(len(r.accounts))}, nil
+}
+
+// poolUtilizationConcurrencyCache returns canned load info for GetPoolUtilizationSummary tests.
+type poolUtilizationConcurrencyCache struct {
+	service.ConcurrencyCache
+	load map[int64]*service.AccountLoadInfo
+}
+
+func (c *poolUtilizationConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []service.AccountWithConcurrency) (map[int64]*service.AccountLoadInfo, error) {
+	return c.load, nil
+}
+
+func TestGetPoolUtilizationSummary_AggregatesAcrossPlatforms(t *testing.T) {
+	accounts := []service.Account{
+		{ID: 1, Name: "anthropic-1", Platform: service.PlatformAnthropic, Concurrency: 10},
+		{ID: 2, Name: "anthropic-2", Platform: service.PlatformAnthropic, Concurrency: 10},
+		{ID: 3, Name: "openai-1", Platform: service.PlatformOpenAI, Concurrency: 5},
+	}
+	load := map[int64]*service.AccountLoadInfo{
+		1: {AccountID: 1, CurrentConcurrency: 4, WaitingCount: 1},
+		2: {AccountID: 2, CurrentConcurrency: 2, WaitingCount: 0},
+		3: {AccountID: 3, CurrentConcurrency: 5, WaitingCount: 3},
+	}
+
+	opsService := service.NewOpsService(
+		nil, nil, nil,
+		&poolUtilizationAccountRepo{accounts: accounts},
+		nil,
+		service.NewConcurrencyService(&poolUtilizationConcurrencyCache{load: load}),
+		nil, nil, nil, nil,
+	)
+	handler := NewOpsHandler(opsService)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/ops/pool-utilization", nil)
+
+	handler.GetPoolUtilizationSummary(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Data struct {
+			Enabled bool                           `json:"enabled"`
+			Summary service.PoolUtilizationSummary `json:"summary"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	require.True(t, body.Data.Enabled)
+	require.Equal(t, int64(25), body.Data.Summary.TotalSlots)
+	require.Equal(t, int64(11), body.Data.Summary.InUseSlots)
+	require.Equal(t, int64(4), body.Data.Summary.WaitingCount)
+	require.InDelta(t, 44.0, body.Data.Summary.LoadPercentage, 0.01)
+
+	anthropic, ok := body.Data.Summary.Platform[service.PlatformAnthropic]
+	require.True(t, ok)
+	require.Equal(t, int64(20), anthropic.MaxCapacity)
+	require.Equal(t, int64(6), anthropic.CurrentInUse)
+	require.Equal(t, int64(1), anthropic.WaitingInQueue)
+
+	openai, ok := body.Data.Summary.Platform[service.PlatformOpenAI]
+	require.True(t, ok)
+	require.Equal(t, int64(5), openai.MaxCapacity)
+	require.Equal(t, int64(5), openai.CurrentInUse)
+	require.Equal(t, int64(3), openai.WaitingInQueue)
+}
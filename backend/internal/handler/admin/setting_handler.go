@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"errors"
+	"io"
 	"log"
 	"strings"
 	"time"
@@ -20,15 +22,17 @@ type SettingHandler struct {
 	emailService     *service.EmailService
 	turnstileService *service.TurnstileService
 	opsService       *service.OpsService
+	authService      *service.AuthService
 }
 
 // NewSettingHandler 创建系统设置处理器
-func NewSettingHandler(settingService *service.SettingService, emailService *service.EmailService, turnstileService *service.TurnstileService, opsService *service.OpsService) *SettingHandler {
+func NewSettingHandler(settingService *service.SettingService, emailService *service.EmailService, turnstileService *service.TurnstileService, opsService *service.OpsService, authService *service.AuthService) *SettingHandler {
 	return &SettingHandler{
 		settingService:   settingService,
 		emailService:     emailService,
 		turnstileService: turnstileService,
 		opsService:       opsService,
+		authService:      authService,
 	}
 }
 
@@ -732,6 +736,34 @@ func (h *SettingHandler) DeleteAdminAPIKey(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Admin API key deleted"})
 }
 
+// RotateJWTSigningKeyRequest 轮换 JWT 签名密钥请求
+type RotateJWTSigningKeyRequest struct {
+	// GraceMinutes 旧密钥继续被接受的宽限期（分钟），<=0 时使用默认宽限期
+	GraceMinutes int `json:"grace_minutes"`
+}
+
+// RotateJWTSigningKey 轮换 JWT 签名密钥：新 token 立即使用新密钥签发，
+// 宽限期内旧密钥签发的 token 仍然有效，避免轮换瞬间让所有在线用户掉线
+// POST /api/v1/admin/settings/jwt-signing-key/rotate
+func (h *SettingHandler) RotateJWTSigningKey(c *gin.Context) {
+	var req RotateJWTSigningKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	graceWindow := time.Duration(req.GraceMinutes) * time.Minute
+	newKey, err := h.authService.RotateSigningKey(c.Request.Context(), graceWindow)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"key": newKey, // 新密钥只在轮换时返回一次，不会再次暴露
+	})
+}
+
 // GetStreamTimeoutSettings 获取流超时处理配置
 // GET /api/v1/admin/settings/stream-timeout
 func (h *SettingHandler) GetStreamTimeoutSettings(c *gin.Context) {
@@ -99,6 +99,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	}
 
 	model := c.Query("model")
+	tag := c.Query("tag")
 
 	var stream *bool
 	if streamStr := c.Query("stream"); streamStr != "" {
@@ -151,6 +152,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		AccountID:   accountID,
 		GroupID:     groupID,
 		Model:       model,
+		Tag:         tag,
 		Stream:      stream,
 		BillingType: billingType,
 		StartTime:   startTime,
@@ -212,6 +214,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	}
 
 	model := c.Query("model")
+	tag := c.Query("tag")
 
 	var stream *bool
 	if streamStr := c.Query("stream"); streamStr != "" {
@@ -277,6 +280,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 		AccountID:   accountID,
 		GroupID:     groupID,
 		Model:       model,
+		Tag:         tag,
 		Stream:      stream,
 		BillingType: billingType,
 		StartTime:   &startTime,
@@ -40,6 +40,8 @@ type CreateUserRequest struct {
 	Balance       float64 `json:"balance"`
 	Concurrency   int     `json:"concurrency"`
 	AllowedGroups []int64 `json:"allowed_groups"`
+	// ModelMapping 用户级默认模型映射，在分组/账号映射之前应用
+	ModelMapping map[string]string `json:"model_mapping"`
 }
 
 // UpdateUserRequest represents admin update user request
@@ -56,6 +58,8 @@ type UpdateUserRequest struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]*rate，nil 表示删除该分组的专属倍率
 	GroupRates map[int64]*float64 `json:"group_rates"`
+	// ModelMapping 用户级默认模型映射，非 nil 时整体替换，传入空 map 表示清除
+	ModelMapping map[string]string `json:"model_mapping"`
 }
 
 // UpdateBalanceRequest represents balance update request
@@ -180,6 +184,7 @@ func (h *UserHandler) Create(c *gin.Context) {
 		Balance:       req.Balance,
 		Concurrency:   req.Concurrency,
 		AllowedGroups: req.AllowedGroups,
+		ModelMapping:  req.ModelMapping,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -215,6 +220,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 		Status:        req.Status,
 		AllowedGroups: req.AllowedGroups,
 		GroupRates:    req.GroupRates,
+		ModelMapping:  req.ModelMapping,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -350,3 +356,42 @@ func (h *UserHandler) GetBalanceHistory(c *gin.Context) {
 		"total_recharged": totalRecharged,
 	})
 }
+
+// ListStickySessions handles listing a user's active sticky session bindings
+// GET /api/v1/admin/users/:id/sticky-sessions
+func (h *UserHandler) ListStickySessions(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	sessions, err := h.adminService.ListUserStickySessions(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.StickySession, 0, len(sessions))
+	for i := range sessions {
+		out = append(out, *dto.StickySessionFromService(&sessions[i]))
+	}
+	response.Success(c, out)
+}
+
+// RevokeStickySessions handles revoking all of a user's active sticky session bindings
+// DELETE /api/v1/admin/users/:id/sticky-sessions
+func (h *UserHandler) RevokeStickySessions(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.adminService.RevokeUserStickySessions(c.Request.Context(), userID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Sticky sessions revoked successfully"})
+}
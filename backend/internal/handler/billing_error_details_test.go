@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingErrorDetails_DailyRequestLimitExceededReturns429(t *testing.T) {
+	status, code, message := billingErrorDetails(service.ErrDailyRequestLimitExceeded)
+	require.Equal(t, http.StatusTooManyRequests, status)
+	require.Equal(t, "rate_limit_error", code)
+	require.NotEmpty(t, message)
+}
+
+func TestBillingErrorDetails_OtherBillingErrorsReturn403(t *testing.T) {
+	status, code, _ := billingErrorDetails(service.ErrSubscriptionInvalid)
+	require.Equal(t, http.StatusForbidden, status)
+	require.Equal(t, "billing_error", code)
+}
+
+func TestBillingErrorDetails_ServiceUnavailableReturns503(t *testing.T) {
+	status, code, _ := billingErrorDetails(service.ErrBillingServiceUnavailable)
+	require.Equal(t, http.StatusServiceUnavailable, status)
+	require.Equal(t, "billing_service_error", code)
+}
@@ -58,9 +58,21 @@ func UserFromServiceAdmin(u *service.User) *AdminUser {
 		return nil
 	}
 	return &AdminUser{
-		User:       *base,
-		Notes:      u.Notes,
-		GroupRates: u.GroupRates,
+		User:         *base,
+		Notes:        u.Notes,
+		GroupRates:   u.GroupRates,
+		ModelMapping: u.ModelMapping,
+	}
+}
+
+func StickySessionFromService(s *service.StickySession) *StickySession {
+	if s == nil {
+		return nil
+	}
+	return &StickySession{
+		GroupID:     s.GroupID,
+		SessionHash: s.SessionHash,
+		AccountID:   s.AccountID,
 	}
 }
 
@@ -109,13 +121,26 @@ func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
 		return nil
 	}
 	out := &AdminGroup{
-		Group:                groupFromServiceBase(g),
-		ModelRouting:         g.ModelRouting,
-		ModelRoutingEnabled:  g.ModelRoutingEnabled,
-		MCPXMLInject:         g.MCPXMLInject,
-		SupportedModelScopes: g.SupportedModelScopes,
-		AccountCount:         g.AccountCount,
-		SortOrder:            g.SortOrder,
+		Group:                               groupFromServiceBase(g),
+		ModelRouting:                        g.ModelRouting,
+		ModelRoutingEnabled:                 g.ModelRoutingEnabled,
+		MCPXMLInject:                        g.MCPXMLInject,
+		SupportedModelScopes:                g.SupportedModelScopes,
+		AccountCount:                        g.AccountCount,
+		SortOrder:                           g.SortOrder,
+		DisableMetadataRewrite:              g.DisableMetadataRewrite,
+		MaxMessages:                         g.MaxMessages,
+		DailyRequestLimit:                   g.DailyRequestLimit,
+		MaxOutputTokens:                     g.MaxOutputTokens,
+		UpstreamHeaders:                     g.UpstreamHeaders,
+		IntentRouting:                       g.IntentRouting,
+		IntentRoutingEnabled:                g.IntentRoutingEnabled,
+		SubscriptionOverflowPolicy:          g.SubscriptionOverflowPolicy,
+		AllowedEndpoints:                    g.AllowedEndpoints,
+		RequireAnthropicVersion:             g.RequireAnthropicVersion,
+		MixedSchedulingNativeSaturationOnly: g.MixedSchedulingNativeSaturationOnly,
+		WindowCostLimitUSD:                  g.WindowCostLimitUSD,
+		WindowCostWindowHours:               g.WindowCostWindowHours,
 	}
 	if len(g.AccountGroups) > 0 {
 		out.AccountGroups = make([]AccountGroup, 0, len(g.AccountGroups))
@@ -137,6 +162,7 @@ func groupFromServiceBase(g *service.Group) Group {
 		IsExclusive:      g.IsExclusive,
 		Status:           g.Status,
 		SubscriptionType: g.SubscriptionType,
+		Currency:         g.Currency,
 		DailyLimitUSD:    g.DailyLimitUSD,
 		WeeklyLimitUSD:   g.WeeklyLimitUSD,
 		MonthlyLimitUSD:  g.MonthlyLimitUSD,
@@ -157,34 +183,39 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		return nil
 	}
 	out := &Account{
-		ID:                      a.ID,
-		Name:                    a.Name,
-		Notes:                   a.Notes,
-		Platform:                a.Platform,
-		Type:                    a.Type,
-		Credentials:             a.Credentials,
-		Extra:                   a.Extra,
-		ProxyID:                 a.ProxyID,
-		Concurrency:             a.Concurrency,
-		Priority:                a.Priority,
-		RateMultiplier:          a.BillingRateMultiplier(),
-		Status:                  a.Status,
-		ErrorMessage:            a.ErrorMessage,
-		LastUsedAt:              a.LastUsedAt,
-		ExpiresAt:               timeToUnixSeconds(a.ExpiresAt),
-		AutoPauseOnExpired:      a.AutoPauseOnExpired,
-		CreatedAt:               a.CreatedAt,
-		UpdatedAt:               a.UpdatedAt,
-		Schedulable:             a.Schedulable,
-		RateLimitedAt:           a.RateLimitedAt,
-		RateLimitResetAt:        a.RateLimitResetAt,
-		OverloadUntil:           a.OverloadUntil,
-		TempUnschedulableUntil:  a.TempUnschedulableUntil,
-		TempUnschedulableReason: a.TempUnschedulableReason,
-		SessionWindowStart:      a.SessionWindowStart,
-		SessionWindowEnd:        a.SessionWindowEnd,
-		SessionWindowStatus:     a.SessionWindowStatus,
-		GroupIDs:                a.GroupIDs,
+		ID:                       a.ID,
+		Name:                     a.Name,
+		Notes:                    a.Notes,
+		Platform:                 a.Platform,
+		Type:                     a.Type,
+		Credentials:              a.Credentials,
+		Extra:                    a.Extra,
+		ProxyID:                  a.ProxyID,
+		Concurrency:              a.Concurrency,
+		Priority:                 a.Priority,
+		AffinityGroup:            a.AffinityGroup,
+		MaxLineSize:              a.MaxLineSize,
+		RateMultiplier:           a.BillingRateMultiplier(),
+		Status:                   a.Status,
+		ErrorMessage:             a.ErrorMessage,
+		LastUsedAt:               a.LastUsedAt,
+		ExpiresAt:                timeToUnixSeconds(a.ExpiresAt),
+		AutoPauseOnExpired:       a.AutoPauseOnExpired,
+		CreatedAt:                a.CreatedAt,
+		UpdatedAt:                a.UpdatedAt,
+		Schedulable:              a.Schedulable,
+		RateLimitedAt:            a.RateLimitedAt,
+		RateLimitResetAt:         a.RateLimitResetAt,
+		OverloadUntil:            a.OverloadUntil,
+		TempUnschedulableUntil:   a.TempUnschedulableUntil,
+		TempUnschedulableReason:  a.TempUnschedulableReason,
+		SessionWindowStart:       a.SessionWindowStart,
+		SessionWindowEnd:         a.SessionWindowEnd,
+		SessionWindowStatus:      a.SessionWindowStatus,
+		SessionWindowUtilization: a.SessionWindowUtilization,
+		QuietHoursStartMinute:    a.QuietHoursStartMinute,
+		QuietHoursEndMinute:      a.QuietHoursEndMinute,
+		GroupIDs:                 a.GroupIDs,
 	}
 
 	// 提取 5h 窗口费用控制和会话数量控制配置（仅 Anthropic OAuth/SetupToken 账号有效）
@@ -218,6 +249,11 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 			target := a.GetCacheTTLOverrideTarget()
 			out.CacheTTLOverrideTarget = &target
 		}
+		// 保留 system cache_control
+		if a.IsSystemCacheControlKept() {
+			enabled := true
+			out.KeepSystemCacheControl = &enabled
+		}
 	}
 
 	return out
@@ -383,6 +419,7 @@ func usageLogFromServiceUser(l *service.UsageLog) UsageLog {
 		RequestID:             l.RequestID,
 		Model:                 l.Model,
 		ReasoningEffort:       l.ReasoningEffort,
+		Tag:                   l.Tag,
 		GroupID:               l.GroupID,
 		SubscriptionID:        l.SubscriptionID,
 		InputTokens:           l.InputTokens,
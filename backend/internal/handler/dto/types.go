@@ -27,6 +27,16 @@ type AdminUser struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]rateMultiplier
 	GroupRates map[int64]float64 `json:"group_rates,omitempty"`
+	// ModelMapping 用户级默认模型映射，在分组/账号映射之前应用
+	ModelMapping map[string]string `json:"model_mapping,omitempty"`
+}
+
+// StickySession represents an active sticky session binding, shown to admins
+// for support/investigation purposes.
+type StickySession struct {
+	GroupID     int64  `json:"group_id"`
+	SessionHash string `json:"session_hash"`
+	AccountID   int64  `json:"account_id"`
 }
 
 type APIKey struct {
@@ -58,6 +68,7 @@ type Group struct {
 	Status         string  `json:"status"`
 
 	SubscriptionType string   `json:"subscription_type"`
+	Currency         string   `json:"currency"`
 	DailyLimitUSD    *float64 `json:"daily_limit_usd"`
 	WeeklyLimitUSD   *float64 `json:"weekly_limit_usd"`
 	MonthlyLimitUSD  *float64 `json:"monthly_limit_usd"`
@@ -96,6 +107,43 @@ type AdminGroup struct {
 
 	// 分组排序
 	SortOrder int `json:"sort_order"`
+
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写，透传客户端原始 metadata
+	DisableMetadataRewrite bool `json:"disable_metadata_rewrite"`
+
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；null 表示使用全局配置
+	MaxMessages *int `json:"max_messages"`
+
+	// 分组每日请求次数上限，与 daily_limit_usd 的费用限额相互独立；null 或 <=0 表示不限制
+	DailyRequestLimit *int `json:"daily_request_limit"`
+
+	// 流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发；null 表示不限制
+	MaxOutputTokens *int `json:"max_output_tokens"`
+
+	// 分组级上游默认请求头，构建上游请求时应用于该分组下的 API-key 账号；认证类头部不受影响
+	UpstreamHeaders map[string]string `json:"upstream_headers,omitempty"`
+
+	// Intent 路由配置（按 x-sub2api-intent 请求头精确匹配）
+	IntentRouting        map[string][]int64 `json:"intent_routing"`
+	IntentRoutingEnabled bool               `json:"intent_routing_enabled"`
+
+	// 订阅限额用尽后的计费策略（仅订阅模式分组生效）：subscription_only/fallback_balance
+	SubscriptionOverflowPolicy string `json:"subscription_overflow_policy"`
+
+	// 允许的上游端点白名单（messages/count_tokens），为空表示不限制
+	AllowedEndpoints []string `json:"allowed_endpoints"`
+
+	// 是否要求客户端必须携带 anthropic-version 请求头，缺失时拒绝请求而非默认填充
+	RequireAnthropicVersion bool `json:"require_anthropic_version"`
+
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly bool `json:"mixed_scheduling_native_saturation_only"`
+
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；null 或 <=0 表示不限制
+	WindowCostLimitUSD *float64 `json:"window_cost_limit_usd"`
+
+	// WindowCostLimitUSD 对应的滚动窗口时长（小时）；null 或 <=0 时默认为 5 小时
+	WindowCostWindowHours *int `json:"window_cost_window_hours"`
 }
 
 type Account struct {
@@ -109,6 +157,8 @@ type Account struct {
 	ProxyID            *int64         `json:"proxy_id"`
 	Concurrency        int            `json:"concurrency"`
 	Priority           int            `json:"priority"`
+	AffinityGroup      string         `json:"affinity_group"`
+	MaxLineSize        int            `json:"max_line_size"`
 	RateMultiplier     float64        `json:"rate_multiplier"`
 	Status             string         `json:"status"`
 	ErrorMessage       string         `json:"error_message"`
@@ -127,9 +177,15 @@ type Account struct {
 	TempUnschedulableUntil  *time.Time `json:"temp_unschedulable_until"`
 	TempUnschedulableReason string     `json:"temp_unschedulable_reason"`
 
-	SessionWindowStart  *time.Time `json:"session_window_start"`
-	SessionWindowEnd    *time.Time `json:"session_window_end"`
-	SessionWindowStatus string     `json:"session_window_status"`
+	SessionWindowStart       *time.Time `json:"session_window_start"`
+	SessionWindowEnd         *time.Time `json:"session_window_end"`
+	SessionWindowStatus      string     `json:"session_window_status"`
+	SessionWindowUtilization *int       `json:"session_window_utilization"`
+
+	// QuietHoursStartMinute / QuietHoursEndMinute 静默时段配置（UTC，一天内分钟数 0-1439）；
+	// 均为 nil 表示未配置
+	QuietHoursStartMinute *int `json:"quiet_hours_start_minute"`
+	QuietHoursEndMinute   *int `json:"quiet_hours_end_minute"`
 
 	// 5h窗口费用控制（仅 Anthropic OAuth/SetupToken 账号有效）
 	// 从 extra 字段提取，方便前端显示和编辑
@@ -155,6 +211,11 @@ type Account struct {
 	CacheTTLOverrideEnabled *bool   `json:"cache_ttl_override_enabled,omitempty"`
 	CacheTTLOverrideTarget  *string `json:"cache_ttl_override_target,omitempty"`
 
+	// 保留 system cache_control（仅 Anthropic OAuth/SetupToken 账号有效）
+	// 默认会在模拟 Claude Code 请求时剥离该字段，启用后跳过剥离
+	// 从 extra 字段提取，方便前端显示和编辑
+	KeepSystemCacheControl *bool `json:"keep_system_cache_control,omitempty"`
+
 	Proxy         *Proxy         `json:"proxy,omitempty"`
 	AccountGroups []AccountGroup `json:"account_groups,omitempty"`
 
@@ -246,6 +307,8 @@ type UsageLog struct {
 	// ReasoningEffort is the request's reasoning effort level (OpenAI Responses API).
 	// nil means not provided / not applicable.
 	ReasoningEffort *string `json:"reasoning_effort,omitempty"`
+	// Tag is the client-declared billing tag (x-sub2api-tag header). nil means not provided.
+	Tag *string `json:"tag,omitempty"`
 
 	GroupID        *int64 `json:"group_id"`
 	SubscriptionID *int64 `json:"subscription_id"`
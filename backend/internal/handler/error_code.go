@@ -0,0 +1,42 @@
+package handler
+
+// 标准化的机器可读错误码常量，随错误响应的 error.type 一并返回在 error.code 中，
+// 便于客户端在不解析人类可读的 message、也不依赖可能随措辞调整而变化的 error.type
+// 细分场景（如 "api_error" 既可能是内部错误也可能是无可用账号）时做稳定分支判断。
+const (
+	ErrCodeAuthenticationError  = "authentication_error"
+	ErrCodeInvalidRequest       = "invalid_request"
+	ErrCodeRateLimited          = "rate_limited"
+	ErrCodeOverloaded           = "overloaded"
+	ErrCodeSubscriptionRequired = "subscription_required"
+	ErrCodeUpstreamError        = "upstream_error"
+	ErrCodeAccountUnavailable   = "account_unavailable"
+	ErrCodeModelNotAllowed      = "model_not_allowed"
+	ErrCodeEndpointNotAllowed   = "endpoint_not_allowed"
+	ErrCodeInternalError        = "internal_error"
+	ErrCodeFeatureUnsupported   = "feature_unsupported"
+)
+
+// defaultErrorCode 根据现有的 Anthropic/OpenAI 风格 error.type 推断默认的标准化
+// error.code。个别比 error.type 需要更精细区分的场景（账号不可用、模型未放行等）
+// 由调用方通过 errorResponseWithCode/handleStreamingAwareErrorWithCode 显式指定。
+func defaultErrorCode(errType string) string {
+	switch errType {
+	case "authentication_error":
+		return ErrCodeAuthenticationError
+	case "invalid_request_error":
+		return ErrCodeInvalidRequest
+	case "rate_limit_error":
+		return ErrCodeRateLimited
+	case "overloaded_error":
+		return ErrCodeOverloaded
+	case "subscription_error":
+		return ErrCodeSubscriptionRequired
+	case "upstream_error":
+		return ErrCodeUpstreamError
+	case "api_error":
+		return ErrCodeInternalError
+	default:
+		return errType
+	}
+}
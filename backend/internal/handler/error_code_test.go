@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultErrorCode_MapsKnownAnthropicErrorTypes(t *testing.T) {
+	cases := []struct {
+		errType string
+		want    string
+	}{
+		{"authentication_error", ErrCodeAuthenticationError},
+		{"invalid_request_error", ErrCodeInvalidRequest},
+		{"rate_limit_error", ErrCodeRateLimited},
+		{"overloaded_error", ErrCodeOverloaded},
+		{"subscription_error", ErrCodeSubscriptionRequired},
+		{"upstream_error", ErrCodeUpstreamError},
+		{"api_error", ErrCodeInternalError},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, defaultErrorCode(tc.errType), "errType=%s", tc.errType)
+	}
+}
+
+func TestDefaultErrorCode_UnknownTypeFallsBackToItself(t *testing.T) {
+	require.Equal(t, "billing_error", defaultErrorCode("billing_error"))
+}
+
+func TestGatewayHandler_ErrorResponse_IncludesDefaultCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	h := &GatewayHandler{}
+	h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	errObj := body["error"].(map[string]any)
+	require.Equal(t, "rate_limit_error", errObj["type"])
+	require.Equal(t, ErrCodeRateLimited, errObj["code"])
+}
+
+func TestGatewayHandler_ErrorResponseWithCode_AccountUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	h := &GatewayHandler{}
+	h.errorResponseWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts")
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	errObj := body["error"].(map[string]any)
+	require.Equal(t, "api_error", errObj["type"])
+	require.Equal(t, ErrCodeAccountUnavailable, errObj["code"])
+}
+
+func TestGatewayHandler_HandleStreamingAwareErrorWithCode_ModelNotAllowedDuringStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	h := &GatewayHandler{}
+	h.handleStreamingAwareErrorWithCode(c, http.StatusBadRequest, "invalid_request_error", ErrCodeModelNotAllowed, `model "bogus" not found`, true)
+
+	require.Contains(t, rec.Body.String(), `"code":"`+ErrCodeModelNotAllowed+`"`)
+}
+
+func TestOpenAIGatewayHandler_ErrorResponse_IncludesDefaultCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	h := &OpenAIGatewayHandler{}
+	h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	errObj := body["error"].(map[string]any)
+	require.Equal(t, ErrCodeAuthenticationError, errObj["code"])
+}
+
+func TestOpenAIGatewayHandler_HandleStreamingAwareErrorWithCode_AccountUnavailableDuringStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	h := &OpenAIGatewayHandler{}
+	h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts", true)
+
+	require.Contains(t, rec.Body.String(), `"code":"`+ErrCodeAccountUnavailable+`"`)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendOpsUpstreamAttemptsHeader_AdminSeesAttemptedAccounts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	ctx.Set(string(middleware2.ContextKeyUserRole), service.RoleAdmin)
+	ctx.Set(service.OpsUpstreamErrorsKey, []*service.OpsUpstreamErrorEvent{
+		{AccountID: 1, Kind: "failover", UpstreamStatusCode: 429},
+		{AccountID: 2, Kind: "retry_exhausted", UpstreamStatusCode: 500},
+	})
+
+	appendOpsUpstreamAttemptsHeader(ctx)
+
+	require.Equal(t, "1:failover,2:retry_exhausted", rec.Header().Get(attemptsHeader))
+}
+
+func TestAppendOpsUpstreamAttemptsHeader_NonAdminGetsNoHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	ctx.Set(string(middleware2.ContextKeyUserRole), service.RoleUser)
+	ctx.Set(service.OpsUpstreamErrorsKey, []*service.OpsUpstreamErrorEvent{
+		{AccountID: 1, Kind: "failover", UpstreamStatusCode: 429},
+	})
+
+	appendOpsUpstreamAttemptsHeader(ctx)
+
+	require.Empty(t, rec.Header().Get(attemptsHeader))
+}
+
+func TestAppendOpsUpstreamAttemptsHeader_NoAttemptsRecordedSetsNoHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	ctx.Set(string(middleware2.ContextKeyUserRole), service.RoleAdmin)
+
+	appendOpsUpstreamAttemptsHeader(ctx)
+
+	require.Empty(t, rec.Header().Get(attemptsHeader))
+}
+
+func TestHandleFailoverExhaustedSimple_SetsAttemptsHeaderForAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+
+	ctx.Set(string(middleware2.ContextKeyUserRole), service.RoleAdmin)
+	ctx.Set(service.OpsUpstreamErrorsKey, []*service.OpsUpstreamErrorEvent{
+		{AccountID: 1, Kind: "failover", UpstreamStatusCode: 429},
+	})
+
+	h := &GatewayHandler{}
+	h.handleFailoverExhaustedSimple(ctx, 502, false)
+
+	require.Equal(t, "1:failover", rec.Header().Get(attemptsHeader))
+}
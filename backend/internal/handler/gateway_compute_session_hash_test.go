@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestComputeSessionHash_DebugHeaderExposesHashAndSource 验证携带
+// debugSessionHashHeader 时，响应中会附带本次请求实际算出的 session hash 及派生分支，
+// 便于排查线上粘性会话未按预期命中账号的问题。
+func TestComputeSessionHash_DebugHeaderExposesHashAndSource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set(debugSessionHashHeader, "true")
+
+	parsed := &service.ParsedRequest{
+		MetadataUserID: "session_123e4567-e89b-12d3-a456-426614174000",
+	}
+
+	hash := h.computeSessionHash(c, parsed)
+
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", hash)
+	require.Equal(t, hash, rec.Header().Get(sessionHashResultHeader))
+	require.Equal(t, string(service.SessionHashSourceMetadata), rec.Header().Get(sessionHashSourceHeader))
+}
+
+// TestComputeSessionHash_NoDebugHeaderOmitsDebugHeaders 验证未携带 debugSessionHashHeader
+// 时不会附带任何调试响应头，保持默认响应形状不变。
+func TestComputeSessionHash_NoDebugHeaderOmitsDebugHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	parsed := &service.ParsedRequest{
+		MetadataUserID: "session_123e4567-e89b-12d3-a456-426614174000",
+	}
+
+	hash := h.computeSessionHash(c, parsed)
+
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", hash)
+	require.Empty(t, rec.Header().Get(sessionHashResultHeader))
+	require.Empty(t, rec.Header().Get(sessionHashSourceHeader))
+}
+
+// TestComputeSessionHash_NoStickyHeaderReturnsEmptyHash 验证 noStickyHeader 显式跳过粘性会话时
+// 返回空 hash，即使同时携带了 debugSessionHashHeader。
+func TestComputeSessionHash_NoStickyHeaderReturnsEmptyHash(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set(noStickyHeader, "true")
+	c.Request.Header.Set(debugSessionHashHeader, "true")
+
+	parsed := &service.ParsedRequest{
+		MetadataUserID: "session_123e4567-e89b-12d3-a456-426614174000",
+	}
+
+	hash := h.computeSessionHash(c, parsed)
+
+	require.Empty(t, hash)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteEstimatedWaitHeader_SetsHeaderWhenEstimatePositive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	writeEstimatedWaitHeader(ctx, 1, &service.AccountWaitPlan{EstimatedWait: 1500 * time.Millisecond})
+
+	require.Equal(t, "1500", rec.Header().Get(estimatedWaitHeader))
+}
+
+func TestWriteEstimatedWaitHeader_NoHeaderWhenEstimateZero(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	writeEstimatedWaitHeader(ctx, 1, &service.AccountWaitPlan{})
+
+	require.Empty(t, rec.Header().Get(estimatedWaitHeader))
+}
+
+func TestWriteEstimatedWaitHeader_NilPlanIsNoOp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+
+	writeEstimatedWaitHeader(ctx, 1, nil)
+
+	require.Empty(t, rec.Header().Get(estimatedWaitHeader))
+}
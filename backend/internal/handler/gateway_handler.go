@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/json"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
 	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
@@ -39,6 +42,14 @@ type GatewayHandler struct {
 	concurrencyHelper         *ConcurrencyHelper
 	maxAccountSwitches        int
 	maxAccountSwitchesGemini  int
+	maxMessages               int
+	maxOpsUpstreamErrorEvents int
+	countTokensCache          service.CountTokensCache
+	countTokensCacheTTL       time.Duration
+	intentRoutingEnabled      bool
+	validIntents              []string
+	billingTagEnabled         bool
+	validBillingTags          []string
 }
 
 // NewGatewayHandler creates a new GatewayHandler
@@ -52,11 +63,20 @@ func NewGatewayHandler(
 	usageService *service.UsageService,
 	apiKeyService *service.APIKeyService,
 	errorPassthroughService *service.ErrorPassthroughService,
+	countTokensCache service.CountTokensCache,
 	cfg *config.Config,
 ) *GatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 10
 	maxAccountSwitchesGemini := 3
+	maxMessages := 0
+	maxConcurrentStreamsPerUser := 0
+	maxOpsUpstreamErrorEvents := 0
+	countTokensCacheTTL := time.Duration(0)
+	intentRoutingEnabled := false
+	var validIntents []string
+	billingTagEnabled := false
+	var validBillingTags []string
 	if cfg != nil {
 		pingInterval = time.Duration(cfg.Concurrency.PingInterval) * time.Second
 		if cfg.Gateway.MaxAccountSwitches > 0 {
@@ -65,6 +85,16 @@ func NewGatewayHandler(
 		if cfg.Gateway.MaxAccountSwitchesGemini > 0 {
 			maxAccountSwitchesGemini = cfg.Gateway.MaxAccountSwitchesGemini
 		}
+		maxMessages = cfg.Gateway.MaxMessages
+		maxConcurrentStreamsPerUser = cfg.Gateway.Scheduling.MaxConcurrentStreamsPerUser
+		maxOpsUpstreamErrorEvents = cfg.Gateway.MaxOpsUpstreamErrorEvents
+		if cfg.Gateway.CountTokensCacheTTLSeconds > 0 {
+			countTokensCacheTTL = time.Duration(cfg.Gateway.CountTokensCacheTTLSeconds) * time.Second
+		}
+		intentRoutingEnabled = cfg.Gateway.IntentRouting.Enabled
+		validIntents = cfg.Gateway.IntentRouting.ValidIntents
+		billingTagEnabled = cfg.Gateway.BillingTag.Enabled
+		validBillingTags = cfg.Gateway.BillingTag.ValidTags
 	}
 	return &GatewayHandler{
 		gatewayService:            gatewayService,
@@ -75,9 +105,17 @@ func NewGatewayHandler(
 		usageService:              usageService,
 		apiKeyService:             apiKeyService,
 		errorPassthroughService:   errorPassthroughService,
-		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatClaude, pingInterval),
+		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatClaude, pingInterval, maxConcurrentStreamsPerUser),
 		maxAccountSwitches:        maxAccountSwitches,
 		maxAccountSwitchesGemini:  maxAccountSwitchesGemini,
+		maxMessages:               maxMessages,
+		maxOpsUpstreamErrorEvents: maxOpsUpstreamErrorEvents,
+		countTokensCache:          countTokensCache,
+		countTokensCacheTTL:       countTokensCacheTTL,
+		intentRoutingEnabled:      intentRoutingEnabled,
+		validIntents:              validIntents,
+		billingTagEnabled:         billingTagEnabled,
+		validBillingTags:          validBillingTags,
 	}
 }
 
@@ -120,6 +158,8 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
 		return
 	}
+	// 应用用户级默认模型映射（在分组/账号映射之前），原始模型保留用于计费
+	h.gatewayService.ApplyUserModelMapping(parsedReq, apiKey.User)
 	reqModel := parsedReq.Model
 	reqStream := parsedReq.Stream
 
@@ -134,9 +174,29 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 	SetClaudeCodeClientContext(c, body)
 	isClaudeCodeClient := service.IsClaudeCodeClient(c.Request.Context())
 
+	applySelectionSeedHeader(c)
+	applyNoFailoverHeader(c)
+	h.applyIntentHeader(c)
+	h.applyBillingTagHeader(c)
+
+	// 在请求上下文中记录请求所属用户 ID，供账号选择阶段的公平性调度使用
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.RequestUserID, subject.UserID))
+
 	// 在请求上下文中记录 thinking 状态，供 Antigravity 最终模型 key 推导/模型维度限流使用
 	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.ThinkingEnabled, parsedReq.ThinkingEnabled))
 
+	// 在请求上下文中记录 anthropic-beta header，供账号选择匹配短暂 feature 不兼容标记使用
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.AnthropicBetaHeader, c.Request.Header.Get("anthropic-beta")))
+
+	// 在请求上下文中记录请求所需的账号能力（视觉输入/工具调用），供账号选择阶段按
+	// Account.SupportsVision / Account.SupportsTools 过滤不具备该能力的账号
+	if parsedReq.HasImageContent {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.RequiresVision, true))
+	}
+	if parsedReq.HasTools {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.RequiresTools, true))
+	}
+
 	setOpsRequestContext(c, reqModel, reqStream, body)
 
 	// 验证 model 必填
@@ -145,6 +205,34 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 校验单次请求 messages 数量上限（分组配置优先于全局配置），必须在 Forward 规范化请求体之前检查
+	if maxMessages := resolveMaxMessages(h.maxMessages, apiKey.Group); maxMessages > 0 && len(parsedReq.Messages) > maxMessages {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("messages count %d exceeds the maximum allowed (%d)", len(parsedReq.Messages), maxMessages))
+		return
+	}
+
+	// 校验分组是否允许访问 messages 端点
+	if !apiKey.Group.IsEndpointAllowed(service.GatewayEndpointMessages) {
+		h.errorResponseWithCode(c, http.StatusForbidden, "permission_error", ErrCodeEndpointNotAllowed, "this endpoint is not allowed for the current group")
+		return
+	}
+
+	// 分组要求客户端必须携带 anthropic-version 请求头时，缺失直接拒绝而非等到转发时默认填充
+	if apiKey.Group.RequiresAnthropicVersionHeader() && c.Request.Header.Get("anthropic-version") == "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "anthropic-version header is required")
+		return
+	}
+
+	// 已知模型名校验（opt-in，默认关闭）：尽早拒绝明显拼写错误的模型名，避免无意义地转发到上游后才收到 404/400
+	if validation := h.gatewayService.ValidateKnownModel(c.Request.Context(), apiKey.GroupID, reqModel); !validation.Known {
+		message := fmt.Sprintf("model %q not found", reqModel)
+		if len(validation.Suggestions) > 0 {
+			message += fmt.Sprintf(", did you mean: %s?", strings.Join(validation.Suggestions, ", "))
+		}
+		h.errorResponseWithCode(c, http.StatusBadRequest, "invalid_request_error", ErrCodeModelNotAllowed, message)
+		return
+	}
+
 	// Track if we've started streaming (for error handling)
 	streamStarted := false
 
@@ -152,6 +240,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 	if h.errorPassthroughService != nil {
 		service.BindErrorPassthroughService(c, h.errorPassthroughService)
 	}
+	service.BindOpsUpstreamMaxErrorEvents(c, h.maxOpsUpstreamErrorEvents)
 
 	// 获取订阅信息（可能为nil）- 提前获取用于后续检查
 	subscription, _ := middleware2.GetSubscriptionFromContext(c)
@@ -195,6 +284,19 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		defer userReleaseFunc()
 	}
 
+	// 1.5 流式请求的单用户并发连接数上限检查：超限立即返回 429，不排队等待
+	// （流式连接生命周期长，排队等待没有意义，应让客户端直接重试）
+	if reqStream {
+		streamReleaseFunc, err := h.concurrencyHelper.AcquireUserStreamSlot(c.Request.Context(), subject.UserID)
+		if err != nil {
+			log.Printf("User stream concurrency acquire failed: %v", err)
+			h.handleConcurrencyError(c, err, "user streams", streamStarted)
+			return
+		}
+		streamReleaseFunc = wrapReleaseOnDone(c.Request.Context(), streamReleaseFunc)
+		defer streamReleaseFunc()
+	}
+
 	// 2. 【新增】Wait后二次检查余额/订阅
 	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
 		log.Printf("Billing eligibility check failed after wait: %v", err)
@@ -209,7 +311,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		UserAgent: c.GetHeader("User-Agent"),
 		APIKeyID:  apiKey.ID,
 	}
-	sessionHash := h.gatewayService.GenerateSessionHash(parsedReq)
+	sessionHash := h.computeSessionHash(c, parsedReq)
 
 	// 获取平台：优先使用强制平台（/antigravity 路由，中间件已设置 request.Context），否则使用分组平台
 	platform := ""
@@ -250,7 +352,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, reqModel, failedAccountIDs, "") // Gemini 不使用会话限制
 			if err != nil {
 				if len(failedAccountIDs) == 0 {
-					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+					if errors.Is(err, service.ErrFeatureUnsupported) {
+						h.handleStreamingAwareErrorWithCode(c, http.StatusBadRequest, "invalid_request_error", ErrCodeFeatureUnsupported, err.Error(), streamStarted)
+						return
+					}
+					h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts: "+err.Error(), streamStarted)
 					return
 				}
 				// Antigravity 单账号退避重试：分组内没有其他可用账号时，
@@ -296,9 +402,10 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			accountReleaseFunc := selection.ReleaseFunc
 			if !selection.Acquired {
 				if selection.WaitPlan == nil {
-					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+					h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts", streamStarted)
 					return
 				}
+				writeEstimatedWaitHeader(c, account.ID, selection.WaitPlan)
 				accountWaitCounted := false
 				canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
 				if err != nil {
@@ -365,6 +472,12 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 						forceCacheBilling = true
 					}
 
+					// 诊断模式：x-sub2api-no-failover 要求直接返回首个账号的错误，不做任何重试/切换
+					if service.NoFailoverFromContext(c.Request.Context()) {
+						h.handleFailoverExhausted(c, failoverErr, service.PlatformGemini, streamStarted)
+						return
+					}
+
 					// 同账号重试：对 RetryableOnSameAccount 的临时性错误，先在同一账号上重试
 					if failoverErr.RetryableOnSameAccount && sameAccountRetryCount[account.ID] < maxSameAccountRetries {
 						sameAccountRetryCount[account.ID]++
@@ -382,7 +495,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					}
 
 					failedAccountIDs[account.ID] = struct{}{}
-					if switchCount >= maxAccountSwitches {
+					if failoverSwitchLimitReached(switchCount, maxAccountSwitches) {
 						h.handleFailoverExhausted(c, failoverErr, service.PlatformGemini, streamStarted)
 						return
 					}
@@ -455,7 +568,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), currentAPIKey.GroupID, sessionKey, reqModel, failedAccountIDs, parsedReq.MetadataUserID)
 			if err != nil {
 				if len(failedAccountIDs) == 0 {
-					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+					if errors.Is(err, service.ErrFeatureUnsupported) {
+						h.handleStreamingAwareErrorWithCode(c, http.StatusBadRequest, "invalid_request_error", ErrCodeFeatureUnsupported, err.Error(), streamStarted)
+						return
+					}
+					h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts: "+err.Error(), streamStarted)
 					return
 				}
 				// Antigravity 单账号退避重试：分组内没有其他可用账号时，
@@ -501,9 +618,10 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			accountReleaseFunc := selection.ReleaseFunc
 			if !selection.Acquired {
 				if selection.WaitPlan == nil {
-					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+					h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts", streamStarted)
 					return
 				}
+				writeEstimatedWaitHeader(c, account.ID, selection.WaitPlan)
 				accountWaitCounted := false
 				canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
 				if err != nil {
@@ -603,6 +721,12 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 						forceCacheBilling = true
 					}
 
+					// 诊断模式：x-sub2api-no-failover 要求直接返回首个账号的错误，不做任何重试/切换
+					if service.NoFailoverFromContext(c.Request.Context()) {
+						h.handleFailoverExhausted(c, failoverErr, account.Platform, streamStarted)
+						return
+					}
+
 					// 同账号重试：对 RetryableOnSameAccount 的临时性错误，先在同一账号上重试
 					if failoverErr.RetryableOnSameAccount && sameAccountRetryCount[account.ID] < maxSameAccountRetries {
 						sameAccountRetryCount[account.ID]++
@@ -620,7 +744,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					}
 
 					failedAccountIDs[account.ID] = struct{}{}
-					if switchCount >= maxAccountSwitches {
+					if failoverSwitchLimitReached(switchCount, maxAccountSwitches) {
 						h.handleFailoverExhausted(c, failoverErr, account.Platform, streamStarted)
 						return
 					}
@@ -952,7 +1076,64 @@ func sleepAntigravitySingleAccountBackoff(ctx context.Context, retryCount int) b
 	}
 }
 
+// attemptsHeader 携带本次请求切换过的账号及其失败原因，仅对管理员可见，
+// 便于排查"故障转移耗尽"类错误具体尝试过哪些账号。见 appendOpsUpstreamAttemptsHeader。
+const attemptsHeader = "x-sub2api-attempts"
+
+// estimatedWaitHeader 在请求进入账号排队等待时携带预计等待时长（毫秒），
+// 便于客户端/监控感知排队拥堵情况。见 writeEstimatedWaitHeader。
+const estimatedWaitHeader = "x-sub2api-estimated-wait-ms"
+
+// writeEstimatedWaitHeader 若等待计划中含有预计等待时长，写入响应头并记录日志，
+// 使长时间排队在客户端和服务端日志中都可见。
+func writeEstimatedWaitHeader(c *gin.Context, accountID int64, plan *service.AccountWaitPlan) {
+	if plan == nil || plan.EstimatedWait <= 0 {
+		return
+	}
+	c.Header(estimatedWaitHeader, strconv.FormatInt(plan.EstimatedWait.Milliseconds(), 10))
+	log.Printf("Account wait queue: account=%d estimated_wait=%s", accountID, plan.EstimatedWait)
+}
+
+// appendOpsUpstreamAttemptsHeader 若当前用户为管理员，将 OpsUpstreamErrorsKey 中累积的
+// 失败尝试（账号 ID + 失败原因）写入 attemptsHeader，用于在故障转移耗尽时排障。
+// 格式："<account_id>:<kind_or_status>" 以逗号分隔，单个账号的多次尝试都会列出。
+func appendOpsUpstreamAttemptsHeader(c *gin.Context) {
+	if c == nil {
+		return
+	}
+	role, ok := middleware2.GetUserRoleFromContext(c)
+	if !ok || role != service.RoleAdmin {
+		return
+	}
+	raw, ok := c.Get(service.OpsUpstreamErrorsKey)
+	if !ok {
+		return
+	}
+	events, ok := raw.([]*service.OpsUpstreamErrorEvent)
+	if !ok || len(events) == 0 {
+		return
+	}
+
+	attempts := make([]string, 0, len(events))
+	for _, ev := range events {
+		if ev == nil || ev.AccountID == 0 {
+			continue
+		}
+		reason := ev.Kind
+		if reason == "" {
+			reason = strconv.Itoa(ev.UpstreamStatusCode)
+		}
+		attempts = append(attempts, fmt.Sprintf("%d:%s", ev.AccountID, reason))
+	}
+	if len(attempts) == 0 {
+		return
+	}
+	c.Header(attemptsHeader, strings.Join(attempts, ","))
+}
+
 func (h *GatewayHandler) handleFailoverExhausted(c *gin.Context, failoverErr *service.UpstreamFailoverError, platform string, streamStarted bool) {
+	appendOpsUpstreamAttemptsHeader(c)
+
 	statusCode := failoverErr.StatusCode
 	responseBody := failoverErr.ResponseBody
 
@@ -987,6 +1168,8 @@ func (h *GatewayHandler) handleFailoverExhausted(c *gin.Context, failoverErr *se
 
 // handleFailoverExhaustedSimple 简化版本，用于没有响应体的情况
 func (h *GatewayHandler) handleFailoverExhaustedSimple(c *gin.Context, statusCode int, streamStarted bool) {
+	appendOpsUpstreamAttemptsHeader(c)
+
 	status, errType, errMsg := h.mapUpstreamError(statusCode)
 	h.handleStreamingAwareError(c, status, errType, errMsg, streamStarted)
 }
@@ -1010,6 +1193,12 @@ func (h *GatewayHandler) mapUpstreamError(statusCode int) (int, string, string)
 
 // handleStreamingAwareError handles errors that may occur after streaming has started
 func (h *GatewayHandler) handleStreamingAwareError(c *gin.Context, status int, errType, message string, streamStarted bool) {
+	h.handleStreamingAwareErrorWithCode(c, status, errType, defaultErrorCode(errType), message, streamStarted)
+}
+
+// handleStreamingAwareErrorWithCode 与 handleStreamingAwareError 相同，但允许调用方显式
+// 指定 error.code（用于 error.type 不足以区分的内部失败原因，例如账号不可用、模型未放行）
+func (h *GatewayHandler) handleStreamingAwareErrorWithCode(c *gin.Context, status int, errType, code, message string, streamStarted bool) {
 	if streamStarted {
 		// Stream already started, send error as SSE event then close
 		flusher, ok := c.Writer.(http.Flusher)
@@ -1019,6 +1208,7 @@ func (h *GatewayHandler) handleStreamingAwareError(c *gin.Context, status int, e
 				"type": "error",
 				"error": map[string]string{
 					"type":    errType,
+					"code":    code,
 					"message": message,
 				},
 			}
@@ -1037,20 +1227,86 @@ func (h *GatewayHandler) handleStreamingAwareError(c *gin.Context, status int, e
 	}
 
 	// Normal case: return JSON response with proper status code
-	h.errorResponse(c, status, errType, message)
+	h.errorResponseWithCode(c, status, errType, code, message)
 }
 
 // errorResponse 返回Claude API格式的错误响应
 func (h *GatewayHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	h.errorResponseWithCode(c, status, errType, defaultErrorCode(errType), message)
+}
+
+// errorResponseWithCode 与 errorResponse 相同，但允许调用方显式指定 error.code
+func (h *GatewayHandler) errorResponseWithCode(c *gin.Context, status int, errType, code, message string) {
 	c.JSON(status, gin.H{
 		"type": "error",
 		"error": gin.H{
 			"type":    errType,
+			"code":    code,
 			"message": message,
 		},
 	})
 }
 
+// Complete handles the legacy Anthropic text completions endpoint by converting the
+// request into an equivalent /v1/messages request, forwarding it through the normal
+// Messages flow (account selection/failover/billing unchanged), then converting the
+// response back into the legacy completion shape.
+// POST /v1/complete
+func (h *GatewayHandler) Complete(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+
+	var legacyReq CompleteRequest
+	if err := json.Unmarshal(body, &legacyReq); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+		return
+	}
+	if legacyReq.Model == "" || legacyReq.Prompt == "" || legacyReq.MaxTokensToSample <= 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "model, prompt and max_tokens_to_sample are required")
+		return
+	}
+
+	messagesBody, err := buildMessagesBodyFromLegacyComplete(&legacyReq)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(messagesBody))
+	c.Request.ContentLength = int64(len(messagesBody))
+
+	if legacyReq.Stream {
+		c.Writer = newLegacyCompleteStreamWriter(c.Writer, legacyReq.Model)
+		h.Messages(c)
+		return
+	}
+
+	capture := &legacyCompleteCaptureWriter{ResponseWriter: c.Writer}
+	c.Writer = capture
+	h.Messages(c)
+
+	// h.Messages 已经通过内嵌的 gin.ResponseWriter 写出了状态码/响应头，这里只需要把
+	// 被拦截的响应体转换后补写：非 200 时原样透传上游/错误响应体，200 时转换为旧版 completion 形状。
+	if capture.Status() != http.StatusOK || capture.buf.Len() == 0 {
+		_, _ = capture.ResponseWriter.Write(capture.buf.Bytes())
+		return
+	}
+	legacyResp := convertMessagesResponseToLegacyComplete(capture.buf.Bytes(), legacyReq.Model)
+	payload, err := json.Marshal(legacyResp)
+	if err != nil {
+		_, _ = capture.ResponseWriter.Write(capture.buf.Bytes())
+		return
+	}
+	_, _ = capture.ResponseWriter.Write(payload)
+}
+
 // CountTokens handles token counting endpoint
 // POST /v1/messages/count_tokens
 // 特点：校验订阅/余额，但不计算并发、不记录使用量
@@ -1087,6 +1343,11 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 	// 检查是否为 Claude Code 客户端，设置到 context 中
 	SetClaudeCodeClientContext(c, body)
 
+	applySelectionSeedHeader(c)
+	applyNoFailoverHeader(c)
+	h.applyIntentHeader(c)
+	h.applyBillingTagHeader(c)
+
 	setOpsRequestContext(c, "", false, body)
 
 	parsedReq, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
@@ -1103,6 +1364,21 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 		return
 	}
 
+	// 校验分组是否允许访问 count_tokens 端点
+	if !apiKey.Group.IsEndpointAllowed(service.GatewayEndpointCountTokens) {
+		h.errorResponseWithCode(c, http.StatusForbidden, "permission_error", ErrCodeEndpointNotAllowed, "this endpoint is not allowed for the current group")
+		return
+	}
+
+	// 分组要求客户端必须携带 anthropic-version 请求头时，缺失直接拒绝而非等到转发时默认填充
+	if apiKey.Group.RequiresAnthropicVersionHeader() && c.Request.Header.Get("anthropic-version") == "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "anthropic-version header is required")
+		return
+	}
+
+	// 应用用户级默认模型映射（在分组/账号映射之前），原始模型保留用于计费
+	h.gatewayService.ApplyUserModelMapping(parsedReq, apiKey.User)
+
 	setOpsRequestContext(c, parsedReq.Model, parsedReq.Stream, body)
 
 	// 获取订阅信息（可能为nil）
@@ -1116,30 +1392,100 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 		return
 	}
 
+	// 相同 模型+请求体 的 count_tokens 请求命中缓存时直接回放，省去一次上游转发
+	cacheEnabled := h.countTokensCache != nil && h.countTokensCacheTTL > 0
+	var cacheKey string
+	if cacheEnabled {
+		cacheKey = h.gatewayService.BuildCountTokensCacheKey(parsedReq.Model, body)
+		if entry, err := h.countTokensCache.GetCountTokensResult(c.Request.Context(), cacheKey); err == nil && entry != nil {
+			c.Data(entry.StatusCode, "application/json", entry.Body)
+			return
+		}
+	}
+
 	// 计算粘性会话 hash
 	parsedReq.SessionContext = &service.SessionContext{
 		ClientIP:  ip.GetClientIP(c),
 		UserAgent: c.GetHeader("User-Agent"),
 		APIKeyID:  apiKey.ID,
 	}
-	sessionHash := h.gatewayService.GenerateSessionHash(parsedReq)
+	sessionHash := h.computeSessionHash(c, parsedReq)
 
-	// 选择支持该模型的账号
-	account, err := h.gatewayService.SelectAccountForModel(c.Request.Context(), apiKey.GroupID, sessionHash, parsedReq.Model)
-	if err != nil {
-		h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error())
-		return
-	}
-	setOpsSelectedAccount(c, account.ID)
+	// 选择支持该模型的账号；上游返回可失败转移的错误时，在 maxAccountSwitches 次内切换账号重试
+	failedAccountIDs := make(map[int64]struct{})
+	switchCount := 0
+	for {
+		account, err := h.gatewayService.SelectAccountForModelWithExclusions(c.Request.Context(), apiKey.GroupID, sessionHash, parsedReq.Model, failedAccountIDs)
+		if err != nil {
+			h.errorResponseWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts: "+err.Error())
+			return
+		}
+		setOpsSelectedAccount(c, account.ID)
+
+		// 缓存开启时，临时接管 ResponseWriter 以捕获响应体用于写入缓存
+		var capture *countTokensCaptureWriter
+		if cacheEnabled {
+			capture = &countTokensCaptureWriter{ResponseWriter: c.Writer}
+			c.Writer = capture
+		}
+
+		// 转发请求（不记录使用量）
+		fwdErr := h.gatewayService.ForwardCountTokens(c.Request.Context(), c, account, parsedReq)
+		if capture != nil {
+			c.Writer = capture.ResponseWriter
+		}
+		if fwdErr != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(fwdErr, &failoverErr) {
+				// 诊断模式：x-sub2api-no-failover 要求直接返回首个账号的错误，不做任何切换
+				if service.NoFailoverFromContext(c.Request.Context()) {
+					h.errorResponseWithCode(c, failoverErr.StatusCode, "upstream_error", ErrCodeAccountUnavailable, "Upstream request failed after account failover")
+					return
+				}
+				failedAccountIDs[account.ID] = struct{}{}
+				if failoverSwitchLimitReached(switchCount, h.maxAccountSwitches) {
+					h.errorResponseWithCode(c, failoverErr.StatusCode, "upstream_error", ErrCodeAccountUnavailable, "Upstream request failed after account failover")
+					return
+				}
+				switchCount++
+				log.Printf("Account %d: count_tokens upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, h.maxAccountSwitches)
+				continue
+			}
+			log.Printf("Forward count_tokens request failed: %v", fwdErr)
+			// 错误响应已在 ForwardCountTokens 中处理
+			return
+		}
 
-	// 转发请求（不记录使用量）
-	if err := h.gatewayService.ForwardCountTokens(c.Request.Context(), c, account, parsedReq); err != nil {
-		log.Printf("Forward count_tokens request failed: %v", err)
-		// 错误响应已在 ForwardCountTokens 中处理
+		if capture != nil && capture.Status() == http.StatusOK && capture.buf.Len() > 0 {
+			entry := &service.CountTokensCacheEntry{
+				StatusCode: capture.Status(),
+				Body:       append([]byte(nil), capture.buf.Bytes()...),
+			}
+			if err := h.countTokensCache.SetCountTokensResult(c.Request.Context(), cacheKey, entry, h.countTokensCacheTTL); err != nil {
+				log.Printf("Failed to cache count_tokens result: %v", err)
+			}
+		}
 		return
 	}
 }
 
+// countTokensCaptureWriter 包裹 gin.ResponseWriter，旁路捕获写入的响应体用于缓存，
+// 不影响实际写给客户端的数据。
+type countTokensCaptureWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *countTokensCaptureWriter) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *countTokensCaptureWriter) WriteString(s string) (int, error) {
+	w.buf.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
 // InterceptType 表示请求拦截类型
 type InterceptType int
 
@@ -1155,6 +1501,118 @@ func isHaikuModel(model string) bool {
 	return strings.Contains(strings.ToLower(model), "haiku")
 }
 
+// selectionSeedHeader 携带选号随机种子，用于回放/压测场景下复现 fallback 阶段的账号选择顺序。
+// 仅当 service.SelectionSeedEnabled() 为 true 时生效。
+const selectionSeedHeader = "x-sub2api-selection-seed"
+
+// applySelectionSeedHeader 读取 selectionSeedHeader 并写入请求 context，供账号选择阶段使用。
+func applySelectionSeedHeader(c *gin.Context) {
+	if !service.SelectionSeedEnabled() {
+		return
+	}
+	raw := strings.TrimSpace(c.GetHeader(selectionSeedHeader))
+	if raw == "" {
+		return
+	}
+	seed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return
+	}
+	c.Request = c.Request.WithContext(service.WithSelectionSeed(c.Request.Context(), seed))
+}
+
+// noFailoverHeader 允许管理工具在诊断单个账号时禁用故障转移，让首个账号的上游错误
+// 直接返回给客户端，而不是被自动切换账号的重试逻辑掩盖。
+// 仅当 service.NoFailoverHeaderEnabled() 为 true 时生效。
+const noFailoverHeader = "x-sub2api-no-failover"
+
+// applyNoFailoverHeader 读取 noFailoverHeader 并写入请求 context，供失败转移循环使用。
+func applyNoFailoverHeader(c *gin.Context) {
+	if !service.NoFailoverHeaderEnabled() {
+		return
+	}
+	raw := strings.TrimSpace(c.GetHeader(noFailoverHeader))
+	if raw == "" {
+		return
+	}
+	disabled, err := strconv.ParseBool(raw)
+	if err != nil || !disabled {
+		return
+	}
+	c.Request = c.Request.WithContext(service.WithNoFailover(c.Request.Context()))
+}
+
+// intentHeader 携带客户端声明的请求意图（如 "coding"/"chat"），用于结合
+// Group.IntentRouting 限定候选账号子集，便于运营方按工作负载类型划分账号。
+const intentHeader = "x-sub2api-intent"
+
+// noStickyHeader 携带 "true" 时，本次请求跳过粘性会话层：既不读取已绑定账号，
+// 也不在选中账号后创建新的绑定。用于压测等明确不希望命中/建立粘性会话的场景。
+const noStickyHeader = "x-sub2api-no-sticky"
+
+// noStickyRequested 判断请求是否通过 noStickyHeader 显式要求跳过粘性会话。
+func noStickyRequested(c *gin.Context) bool {
+	return strings.EqualFold(strings.TrimSpace(c.GetHeader(noStickyHeader)), "true")
+}
+
+// debugSessionHashHeader 携带 "true" 时，在响应中附带本次请求计算出的粘性会话 hash
+// 及其派生分支（sessionHashResultHeader/sessionHashSourceHeader），用于排查线上
+// 粘性会话未按预期命中账号的问题，而不必像 admin 调试接口那样手动拼出请求体重放。
+const debugSessionHashHeader = "x-sub2api-debug-session-hash"
+
+// sessionHashResultHeader/sessionHashSourceHeader 见 debugSessionHashHeader。
+const (
+	sessionHashResultHeader = "x-sub2api-session-hash"
+	sessionHashSourceHeader = "x-sub2api-session-hash-source"
+)
+
+// computeSessionHash 计算本次请求的粘性会话 hash；noStickyHeader 显式跳过时返回空值。
+// 当客户端带上 debugSessionHashHeader 时，额外把 hash 与命中的派生分支写入响应头，
+// 供排查粘性会话未按预期命中账号的问题使用。
+func (h *GatewayHandler) computeSessionHash(c *gin.Context, parsedReq *service.ParsedRequest) string {
+	hash, source := h.gatewayService.GenerateSessionHashWithSource(parsedReq)
+	if noStickyRequested(c) {
+		hash = ""
+	}
+	if strings.EqualFold(strings.TrimSpace(c.GetHeader(debugSessionHashHeader)), "true") {
+		c.Header(sessionHashResultHeader, hash)
+		c.Header(sessionHashSourceHeader, string(source))
+	}
+	return hash
+}
+
+// applyIntentHeader 读取 intentHeader，校验通过后写入请求 context 供账号选择阶段使用。
+// 校验未通过（未知 intent）或 header 缺失时不写入，账号选择阶段按无限制处理。
+func (h *GatewayHandler) applyIntentHeader(c *gin.Context) {
+	raw := strings.TrimSpace(c.GetHeader(intentHeader))
+	if raw == "" {
+		return
+	}
+	if !service.IsValidIntent(h.intentRoutingEnabled, h.validIntents, raw) {
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), ctxkey.RequestIntent, raw)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// billingTagHeader 携带客户端声明的计费标签（如 "project-a"/"feature-x"），
+// 用于按项目/功能维度对网关流量的用量进行分类统计。
+const billingTagHeader = "x-sub2api-tag"
+
+// applyBillingTagHeader 读取 billingTagHeader，校验通过后写入请求 context，
+// 计费阶段据此写入 UsageLog.Tag。校验未通过（未知 tag）或 header 缺失时不写入。
+func (h *GatewayHandler) applyBillingTagHeader(c *gin.Context) {
+	raw := strings.TrimSpace(c.GetHeader(billingTagHeader))
+	if raw == "" {
+		return
+	}
+	if !service.IsValidBillingTag(h.billingTagEnabled, h.validBillingTags, raw) {
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), ctxkey.RequestBillingTag, raw)
+	c.Request = c.Request.WithContext(ctx)
+}
+
 // isMaxTokensOneHaikuRequest 检查是否为 max_tokens=1 + haiku 模型的探测请求
 // 这类请求用于 Claude Code 验证 API 连通性
 // 条件：max_tokens == 1 且 model 包含 "haiku" 且非流式请求
@@ -1162,6 +1620,21 @@ func isMaxTokensOneHaikuRequest(model string, maxTokens int, isStream bool) bool
 	return maxTokens == 1 && isHaikuModel(model) && !isStream
 }
 
+// resolveMaxMessages 计算单次请求允许的最大 messages 数量：分组覆盖优先于全局默认值
+func resolveMaxMessages(globalMax int, group *service.Group) int {
+	if group != nil && group.MaxMessages != nil {
+		return *group.MaxMessages
+	}
+	return globalMax
+}
+
+// failoverSwitchLimitReached 判断故障转移循环是否已达到本次客户端请求允许尝试的账号切换上限。
+// 超过该上限后不再切换账号，而是把最近一次上游错误返回给客户端，
+// 避免大规模上游故障时逐个尝试分组内所有账号。
+func failoverSwitchLimitReached(switchCount, maxAccountSwitches int) bool {
+	return switchCount >= maxAccountSwitches
+}
+
 // detectInterceptType 检测请求是否需要拦截，返回拦截类型
 // 参数说明：
 //   - body: 请求体字节
@@ -1400,5 +1873,100 @@ func billingErrorDetails(err error) (status int, code, message string) {
 	if msg == "" {
 		msg = err.Error()
 	}
+	if errors.Is(err, service.ErrDailyRequestLimitExceeded) {
+		return http.StatusTooManyRequests, "rate_limit_error", msg
+	}
 	return http.StatusForbidden, "billing_error", msg
 }
+
+// SimulateFailoverRequest 模拟故障转移请求参数
+type SimulateFailoverRequest struct {
+	StatusCode int    `json:"status_code" binding:"required"`
+	Body       string `json:"body"` // 上游原始响应体（JSON 字符串），用于识别 400 兼容性差异和自定义禁用规则
+}
+
+// SimulateFailover 模拟给定状态码/响应体会触发的故障转移与限流行为，不依赖、不修改任何账号状态，
+// 用于管理端验证故障转移配置是否符合预期。
+// POST /api/v1/admin/gateway/simulate-failover
+func (h *GatewayHandler) SimulateFailover(c *gin.Context) {
+	var req SimulateFailoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	result := h.gatewayService.SimulateFailover(req.StatusCode, []byte(req.Body))
+	response.Success(c, result)
+}
+
+// ReplayRequest 请求回放参数
+type ReplayRequest struct {
+	Body       string `json:"body" binding:"required"` // 原始请求体（JSON 字符串），与转发给上游的 /v1/messages 请求体一致
+	AccountIDA int64  `json:"account_id_a" binding:"required"`
+	AccountIDB int64  `json:"account_id_b" binding:"required"`
+}
+
+// Replay 将同一份请求体直接转发给两个指定账号并返回两侧响应，用于人工对比上游响应质量。
+// 仅支持非流式请求，不计入用户账单，也不经过正常的账号选择/故障转移流程。
+// POST /api/v1/admin/gateway/replay
+func (h *GatewayHandler) Replay(c *gin.Context) {
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	result, err := h.gatewayService.ReplayToAccounts(c.Request.Context(), []byte(req.Body), req.AccountIDA, req.AccountIDB)
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// GetSchedulingConfig 返回当前生效的调度配置（GatewaySchedulingConfig），用于运营方
+// 确认配置变更（含热加载）是否已生效，不做任何脱敏处理。
+// GET /api/v1/admin/gateway/config/scheduling
+func (h *GatewayHandler) GetSchedulingConfig(c *gin.Context) {
+	response.Success(c, h.gatewayService.SchedulingConfig())
+}
+
+// RebuildSchedulerSnapshot 立即强制重建调度快照，绕过 full_rebuild_interval_seconds 节流，
+// 用于批量变更账号后让新增/变更账号立即可调度，而不必等待下一次定时重建。
+// POST /api/v1/admin/gateway/scheduler/rebuild
+func (h *GatewayHandler) RebuildSchedulerSnapshot(c *gin.Context) {
+	if err := h.gatewayService.RebuildSchedulerSnapshot(); err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, gin.H{"rebuilt": true})
+}
+
+// DebugSessionHashRequest 粘性会话 hash 调试请求参数
+type DebugSessionHashRequest struct {
+	Body     string `json:"body" binding:"required"` // 原始请求体（JSON 字符串）
+	Protocol string `json:"protocol"`                // 为空时默认按 anthropic 协议解析
+}
+
+// DebugSessionHash 计算给定请求体的粘性会话 hash 并返回命中的派生分支（metadata/cacheable/fallback/none），
+// 用于排查线上粘性会话未按预期命中账号的问题，不计入用户账单，也不会发往上游。
+// POST /api/v1/admin/gateway/session-hash
+func (h *GatewayHandler) DebugSessionHash(c *gin.Context) {
+	var req DebugSessionHashRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	protocol := req.Protocol
+	if protocol == "" {
+		protocol = domain.PlatformAnthropic
+	}
+
+	result, err := h.gatewayService.DebugSessionHash([]byte(req.Body), protocol)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
@@ -85,20 +85,22 @@ func (e *ConcurrencyError) Error() string {
 
 // ConcurrencyHelper provides common concurrency slot management for gateway handlers
 type ConcurrencyHelper struct {
-	concurrencyService *service.ConcurrencyService
-	pingFormat         SSEPingFormat
-	pingInterval       time.Duration
+	concurrencyService          *service.ConcurrencyService
+	pingFormat                  SSEPingFormat
+	pingInterval                time.Duration
+	maxConcurrentStreamsPerUser int
 }
 
 // NewConcurrencyHelper creates a new ConcurrencyHelper
-func NewConcurrencyHelper(concurrencyService *service.ConcurrencyService, pingFormat SSEPingFormat, pingInterval time.Duration) *ConcurrencyHelper {
+func NewConcurrencyHelper(concurrencyService *service.ConcurrencyService, pingFormat SSEPingFormat, pingInterval time.Duration, maxConcurrentStreamsPerUser int) *ConcurrencyHelper {
 	if pingInterval <= 0 {
 		pingInterval = defaultPingInterval
 	}
 	return &ConcurrencyHelper{
-		concurrencyService: concurrencyService,
-		pingFormat:         pingFormat,
-		pingInterval:       pingInterval,
+		concurrencyService:          concurrencyService,
+		pingFormat:                  pingFormat,
+		pingInterval:                pingInterval,
+		maxConcurrentStreamsPerUser: maxConcurrentStreamsPerUser,
 	}
 }
 
@@ -173,6 +175,23 @@ func (h *ConcurrencyHelper) AcquireUserSlotWithWait(c *gin.Context, userID int64
 	return h.waitForSlotWithPing(c, "user", userID, maxConcurrency, isStream, streamStarted)
 }
 
+// AcquireUserStreamSlot acquires a streaming-connection slot for a user, counted
+// independently from the general concurrency slot. Unlike AcquireUserSlotWithWait,
+// this never waits: a user already at the streaming cap is rejected immediately
+// (the caller should map the returned *ConcurrencyError to a 429 response), since
+// streaming connections are long-lived and queuing them defeats the purpose of the cap.
+// A cap of 0 or less means unlimited.
+func (h *ConcurrencyHelper) AcquireUserStreamSlot(ctx context.Context, userID int64) (func(), error) {
+	result, err := h.concurrencyService.AcquireUserStreamSlot(ctx, userID, h.maxConcurrentStreamsPerUser)
+	if err != nil {
+		return nil, err
+	}
+	if result.Acquired {
+		return result.ReleaseFunc, nil
+	}
+	return nil, &ConcurrencyError{SlotType: "user streams", IsTimeout: false}
+}
+
 // AcquireAccountSlotWithWait acquires an account concurrency slot, waiting if necessary.
 // For streaming requests, sends ping events during the wait.
 // streamStarted is updated if streaming response has begun.
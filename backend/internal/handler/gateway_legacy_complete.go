@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// legacyHumanPrefix / legacyAssistantSuffix 是 Anthropic 旧版 text completions API
+// （POST /v1/complete）约定的 prompt 包裹格式：client 必须自行拼接
+// "\n\nHuman: {问题}\n\nAssistant:"，服务端只负责校验格式并提取中间的用户文本。
+const (
+	legacyHumanPrefix     = "\n\nHuman:"
+	legacyAssistantSuffix = "\n\nAssistant:"
+)
+
+// CompleteRequest 是旧版 /v1/complete 的请求体，字段命名遵循 Anthropic 已废弃的
+// text completions API（https://docs.anthropic.com/en/api/complete）。
+type CompleteRequest struct {
+	Model             string   `json:"model" binding:"required"`
+	Prompt            string   `json:"prompt" binding:"required"`
+	MaxTokensToSample int      `json:"max_tokens_to_sample" binding:"required"`
+	Stream            bool     `json:"stream"`
+	StopSequences     []string `json:"stop_sequences"`
+	Temperature       *float64 `json:"temperature"`
+	TopP              *float64 `json:"top_p"`
+	TopK              *int     `json:"top_k"`
+}
+
+// CompleteResponse 是旧版 /v1/complete 的非流式响应体，字段集合对齐旧版 API。
+type CompleteResponse struct {
+	Completion string `json:"completion"`
+	StopReason string `json:"stop_reason"`
+	Model      string `json:"model"`
+	Stop       string `json:"stop,omitempty"`
+	LogID      string `json:"log_id,omitempty"`
+	Truncated  bool   `json:"truncated"`
+}
+
+// extractLegacyPrompt 校验并提取旧版 prompt 中 "Human:" 与 "Assistant:" 之间的用户文本，
+// 旧版 API 要求 prompt 必须以 "\n\nHuman:" 开头、以 "\n\nAssistant:" 结尾。
+func extractLegacyPrompt(prompt string) (string, error) {
+	if !strings.HasPrefix(prompt, legacyHumanPrefix) {
+		return "", fmt.Errorf(`prompt must start with "%s"`, legacyHumanPrefix)
+	}
+	if !strings.HasSuffix(prompt, legacyAssistantSuffix) {
+		return "", fmt.Errorf(`prompt must end with "%s"`, legacyAssistantSuffix)
+	}
+	text := prompt[len(legacyHumanPrefix) : len(prompt)-len(legacyAssistantSuffix)]
+	return strings.TrimSpace(text), nil
+}
+
+// buildMessagesBodyFromLegacyComplete 将旧版 completion 请求转换为等价的 /v1/messages 请求体，
+// 供 GatewayHandler.Messages 的既有转发/账号选择/计费链路直接复用。
+func buildMessagesBodyFromLegacyComplete(req *CompleteRequest) ([]byte, error) {
+	userText, err := extractLegacyPrompt(req.Prompt)
+	if err != nil {
+		return nil, err
+	}
+	if userText == "" {
+		return nil, fmt.Errorf("prompt must contain a non-empty human turn")
+	}
+
+	body := []byte(`{}`)
+	body, err = sjson.SetBytes(body, "model", req.Model)
+	if err != nil {
+		return nil, err
+	}
+	body, err = sjson.SetBytes(body, "max_tokens", req.MaxTokensToSample)
+	if err != nil {
+		return nil, err
+	}
+	body, err = sjson.SetBytes(body, "stream", req.Stream)
+	if err != nil {
+		return nil, err
+	}
+	body, err = sjson.SetBytes(body, "messages.0.role", "user")
+	if err != nil {
+		return nil, err
+	}
+	body, err = sjson.SetBytes(body, "messages.0.content", userText)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.StopSequences) > 0 {
+		body, err = sjson.SetBytes(body, "stop_sequences", req.StopSequences)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Temperature != nil {
+		body, err = sjson.SetBytes(body, "temperature", *req.Temperature)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.TopP != nil {
+		body, err = sjson.SetBytes(body, "top_p", *req.TopP)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.TopK != nil {
+		body, err = sjson.SetBytes(body, "top_k", *req.TopK)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// convertMessagesStopReasonToLegacy 把 /v1/messages 的 stop_reason 映射为旧版 completion
+// 的取值集合（"stop_sequence" / "max_tokens" / "" ），未知值原样透传。
+func convertMessagesStopReasonToLegacy(stopReason string) string {
+	switch stopReason {
+	case "end_turn", "stop_sequence":
+		return "stop_sequence"
+	case "max_tokens":
+		return "max_tokens"
+	default:
+		return stopReason
+	}
+}
+
+// convertMessagesResponseToLegacyComplete 将一次非流式 /v1/messages 响应体转换为旧版
+// completion 响应，取第一个 text 内容块拼接为 completion 字段。
+func convertMessagesResponseToLegacyComplete(body []byte, model string) CompleteResponse {
+	var completion strings.Builder
+	for _, block := range gjson.GetBytes(body, "content").Array() {
+		if block.Get("type").String() == "text" {
+			completion.WriteString(block.Get("text").String())
+		}
+	}
+	respModel := gjson.GetBytes(body, "model").String()
+	if respModel == "" {
+		respModel = model
+	}
+	return CompleteResponse{
+		Completion: completion.String(),
+		StopReason: convertMessagesStopReasonToLegacy(gjson.GetBytes(body, "stop_reason").String()),
+		Model:      respModel,
+	}
+}
+
+// legacyCompleteCaptureWriter 包裹 gin.ResponseWriter，完全拦截非流式 /v1/messages
+// 响应体（不透传给客户端），供 Complete 转换为旧版 completion 形状后统一写出。
+type legacyCompleteCaptureWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *legacyCompleteCaptureWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *legacyCompleteCaptureWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// legacyCompleteStreamWriter 包裹 gin.ResponseWriter，把 /v1/messages 的 SSE 事件流
+// （content_block_delta / message_delta / message_stop）实时转换为旧版 completion
+// 的 SSE 事件流（每个事件一个增量 completion 文本块），供流式 /v1/complete 请求使用。
+type legacyCompleteStreamWriter struct {
+	gin.ResponseWriter
+	model string
+	buf   bytes.Buffer
+}
+
+func newLegacyCompleteStreamWriter(w gin.ResponseWriter, model string) *legacyCompleteStreamWriter {
+	return &legacyCompleteStreamWriter{ResponseWriter: w, model: model}
+}
+
+// Write 接收上游 SSE 原始字节，按 "\n\n" 切分完整事件后逐个转换并写出，
+// 不完整的尾部保留在 buf 中等待下一次 Write。
+func (w *legacyCompleteStreamWriter) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	for {
+		raw := w.buf.Bytes()
+		idx := bytes.Index(raw, []byte("\n\n"))
+		if idx < 0 {
+			break
+		}
+		event := raw[:idx]
+		w.buf.Next(idx + 2)
+		if err := w.writeLegacyEvent(event); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *legacyCompleteStreamWriter) writeLegacyEvent(event []byte) error {
+	eventName, data := parseSSEEventLine(event)
+	switch eventName {
+	case "content_block_delta":
+		text := gjson.GetBytes(data, "delta.text").String()
+		if text == "" {
+			return nil
+		}
+		return w.writeCompletionChunk(text, "")
+	case "message_delta":
+		stopReason := gjson.GetBytes(data, "delta.stop_reason").String()
+		if stopReason == "" {
+			return nil
+		}
+		return w.writeCompletionChunk("", convertMessagesStopReasonToLegacy(stopReason))
+	default:
+		return nil
+	}
+}
+
+func (w *legacyCompleteStreamWriter) writeCompletionChunk(completion, stopReason string) error {
+	chunk := CompleteResponse{
+		Completion: completion,
+		StopReason: stopReason,
+		Model:      w.model,
+	}
+	payload, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.ResponseWriter, "event: completion\ndata: %s\n\n", payload)
+	return err
+}
+
+// parseSSEEventLine 从一个完整的 SSE 事件块（不含结尾 "\n\n"）中解析出 event 名与 data 负载。
+func parseSSEEventLine(event []byte) (name string, data []byte) {
+	for _, line := range bytes.Split(event, []byte("\n")) {
+		switch {
+		case bytes.HasPrefix(line, []byte("event: ")):
+			name = string(bytes.TrimPrefix(line, []byte("event: ")))
+		case bytes.HasPrefix(line, []byte("data: ")):
+			data = bytes.TrimPrefix(line, []byte("data: "))
+		}
+	}
+	return name, data
+}
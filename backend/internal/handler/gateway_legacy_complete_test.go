@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLegacyPrompt_ValidWrapping(t *testing.T) {
+	text, err := extractLegacyPrompt("\n\nHuman: hello there\n\nAssistant:")
+	require.NoError(t, err)
+	require.Equal(t, "hello there", text)
+}
+
+func TestExtractLegacyPrompt_MissingHumanPrefix(t *testing.T) {
+	_, err := extractLegacyPrompt("hello there\n\nAssistant:")
+	require.Error(t, err)
+}
+
+func TestExtractLegacyPrompt_MissingAssistantSuffix(t *testing.T) {
+	_, err := extractLegacyPrompt("\n\nHuman: hello there")
+	require.Error(t, err)
+}
+
+func TestBuildMessagesBodyFromLegacyComplete_ConvertsPromptToUserMessage(t *testing.T) {
+	req := &CompleteRequest{
+		Model:             "claude-2",
+		Prompt:            "\n\nHuman: what is 2+2?\n\nAssistant:",
+		MaxTokensToSample: 100,
+		StopSequences:     []string{"\n\nHuman:"},
+	}
+
+	body, err := buildMessagesBodyFromLegacyComplete(req)
+	require.NoError(t, err)
+
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	require.NoError(t, err)
+	require.Equal(t, "claude-2", parsed.Model)
+	require.Equal(t, 100, parsed.MaxTokens)
+	require.Len(t, parsed.Messages, 1)
+}
+
+func TestBuildMessagesBodyFromLegacyComplete_RejectsMalformedPrompt(t *testing.T) {
+	req := &CompleteRequest{
+		Model:             "claude-2",
+		Prompt:            "what is 2+2?",
+		MaxTokensToSample: 100,
+	}
+
+	_, err := buildMessagesBodyFromLegacyComplete(req)
+	require.Error(t, err)
+}
+
+func TestConvertMessagesResponseToLegacyComplete_NonStreaming(t *testing.T) {
+	body := []byte(`{
+		"id": "msg_1",
+		"model": "claude-2",
+		"stop_reason": "end_turn",
+		"content": [{"type": "text", "text": "4"}]
+	}`)
+
+	resp := convertMessagesResponseToLegacyComplete(body, "claude-2")
+	require.Equal(t, "4", resp.Completion)
+	require.Equal(t, "stop_sequence", resp.StopReason)
+	require.Equal(t, "claude-2", resp.Model)
+}
+
+func TestLegacyCompleteStreamWriter_ConvertsContentBlockDeltas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newLegacyCompleteStreamWriter(c.Writer, "claude-2")
+	_, err := w.Write([]byte("event: content_block_delta\ndata: {\"delta\":{\"type\":\"text_delta\",\"text\":\"He\"}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: content_block_delta\ndata: {\"delta\":{\"type\":\"text_delta\",\"text\":\"llo\"}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: message_delta\ndata: {\"delta\":{\"stop_reason\":\"end_turn\"}}\n\n"))
+	require.NoError(t, err)
+
+	out := rec.Body.String()
+	require.Contains(t, out, `"completion":"He"`)
+	require.Contains(t, out, `"completion":"llo"`)
+	require.Contains(t, out, `"stop_reason":"stop_sequence"`)
+}
+
+func TestLegacyCompleteStreamWriter_SplitAcrossWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newLegacyCompleteStreamWriter(c.Writer, "claude-2")
+	_, err := w.Write([]byte("event: content_block_delta\ndata: {\"delta\":{\"type\":\"text_delta\","))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("\"text\":\"partial\"}}\n\n"))
+	require.NoError(t, err)
+
+	require.Contains(t, rec.Body.String(), `"completion":"partial"`)
+}
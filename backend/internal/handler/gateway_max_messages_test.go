@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveMaxMessages_GlobalDefaultWhenNoGroupOverride(t *testing.T) {
+	require.Equal(t, 100, resolveMaxMessages(100, nil))
+	require.Equal(t, 100, resolveMaxMessages(100, &service.Group{}))
+}
+
+func TestResolveMaxMessages_GroupOverrideTakesPrecedence(t *testing.T) {
+	override := 20
+	require.Equal(t, 20, resolveMaxMessages(100, &service.Group{MaxMessages: &override}))
+}
+
+func TestResolveMaxMessages_GroupOverrideCanDisableLimit(t *testing.T) {
+	unlimited := 0
+	require.Equal(t, 0, resolveMaxMessages(100, &service.Group{MaxMessages: &unlimited}))
+}
+
+func TestFailoverSwitchLimitReached_StopsAfterConfiguredAccountCount(t *testing.T) {
+	maxAccountSwitches := 3
+
+	for switchCount := 0; switchCount < maxAccountSwitches; switchCount++ {
+		require.False(t, failoverSwitchLimitReached(switchCount, maxAccountSwitches),
+			"should keep switching accounts while under the configured cap (switchCount=%d)", switchCount)
+	}
+	require.True(t, failoverSwitchLimitReached(maxAccountSwitches, maxAccountSwitches))
+	require.True(t, failoverSwitchLimitReached(maxAccountSwitches+1, maxAccountSwitches))
+}
+
+func TestFailoverSwitchLimitReached_ZeroLimitStopsImmediately(t *testing.T) {
+	require.True(t, failoverSwitchLimitReached(0, 0))
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyNoFailoverHeader_DisabledFeatureIgnoresHeader(t *testing.T) {
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "")
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+	ctx.Request.Header.Set(noFailoverHeader, "true")
+
+	applyNoFailoverHeader(ctx)
+
+	require.False(t, service.NoFailoverFromContext(ctx.Request.Context()))
+}
+
+func TestApplyNoFailoverHeader_EnabledFeatureSkipsFailover(t *testing.T) {
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "true")
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+	ctx.Request.Header.Set(noFailoverHeader, "true")
+
+	applyNoFailoverHeader(ctx)
+
+	require.True(t, service.NoFailoverFromContext(ctx.Request.Context()))
+}
+
+func TestApplyNoFailoverHeader_EnabledFeatureIgnoresFalseValue(t *testing.T) {
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "true")
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+	ctx.Request.Header.Set(noFailoverHeader, "false")
+
+	applyNoFailoverHeader(ctx)
+
+	require.False(t, service.NoFailoverFromContext(ctx.Request.Context()))
+}
+
+func TestApplyNoFailoverHeader_EnabledFeatureNoHeaderLeavesContextUnset(t *testing.T) {
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "true")
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+
+	applyNoFailoverHeader(ctx)
+
+	require.False(t, service.NoFailoverFromContext(ctx.Request.Context()))
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoStickyRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"true lowercase", "true", true},
+		{"true mixed case", "True", true},
+		{"false", "false", false},
+		{"empty", "", false},
+		{"whitespace around true", "  true  ", true},
+		{"other value", "yes", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+			if tc.header != "" {
+				c.Request.Header.Set(noStickyHeader, tc.header)
+			}
+			require.Equal(t, tc.want, noStickyRequested(c))
+		})
+	}
+}
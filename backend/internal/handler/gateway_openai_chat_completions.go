@@ -0,0 +1,711 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// ChatCompletionRequest 是 OpenAI 兼容的 POST /v1/chat/completions 请求体，
+// 仅覆盖转发到 Claude 所需的字段子集。
+type ChatCompletionRequest struct {
+	Model               string                  `json:"model" binding:"required"`
+	Messages            []ChatCompletionMessage `json:"messages" binding:"required"`
+	Tools               []ChatCompletionTool    `json:"tools,omitempty"`
+	ToolChoice          json.RawMessage         `json:"tool_choice,omitempty"`
+	Stream              bool                    `json:"stream"`
+	MaxTokens           *int                    `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int                    `json:"max_completion_tokens,omitempty"`
+	Temperature         *float64                `json:"temperature,omitempty"`
+	TopP                *float64                `json:"top_p,omitempty"`
+	Stop                json.RawMessage         `json:"stop,omitempty"`
+}
+
+// ChatCompletionMessage 对应 messages 数组中的一项；content 既可能是纯字符串，
+// 也可能是 OpenAI 的多模态内容块数组，因此用 json.RawMessage 延迟解析。
+type ChatCompletionMessage struct {
+	Role       string                   `json:"role"`
+	Content    json.RawMessage          `json:"content,omitempty"`
+	ToolCalls  []ChatCompletionToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string                   `json:"tool_call_id,omitempty"`
+}
+
+// ChatCompletionTool 对应 tools 数组中的一项（目前只支持 "function" 类型）。
+type ChatCompletionTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+// ChatCompletionFunctionCall 是 tool_calls 中 function 字段的形状，
+// arguments 按 OpenAI 约定是一段 JSON 文本而非结构化对象。
+type ChatCompletionFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatCompletionToolCall 同时用于请求中 assistant 消息的 tool_calls 字段，
+// 以及非流式响应 message.tool_calls 字段，两者形状一致。
+type ChatCompletionToolCall struct {
+	ID       string                     `json:"id"`
+	Type     string                     `json:"type"`
+	Function ChatCompletionFunctionCall `json:"function"`
+}
+
+// ChatCompletionResponse 是非流式 chat.completion 响应体。
+type ChatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   *ChatCompletionUsage   `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunk 是流式 chat.completion.chunk 响应体，Message/Delta 二选一使用。
+type ChatCompletionChunk struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   *ChatCompletionUsage   `json:"usage,omitempty"`
+}
+
+// ChatCompletionChoice 同时承载非流式的 message 与流式的 delta 两种形状。
+type ChatCompletionChoice struct {
+	Index        int                         `json:"index"`
+	Message      *ChatCompletionOutMessage   `json:"message,omitempty"`
+	Delta        *ChatCompletionDeltaMessage `json:"delta,omitempty"`
+	FinishReason *string                     `json:"finish_reason"`
+}
+
+// ChatCompletionOutMessage 是非流式响应 choices[].message 的形状。
+type ChatCompletionOutMessage struct {
+	Role      string                   `json:"role"`
+	Content   *string                  `json:"content"`
+	ToolCalls []ChatCompletionToolCall `json:"tool_calls,omitempty"`
+}
+
+// ChatCompletionDeltaMessage 是流式响应 choices[].delta 的形状，字段按需出现。
+type ChatCompletionDeltaMessage struct {
+	Role      string                        `json:"role,omitempty"`
+	Content   *string                       `json:"content,omitempty"`
+	ToolCalls []ChatCompletionToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// ChatCompletionToolCallDelta 是流式响应 delta.tool_calls 中的一项增量。
+type ChatCompletionToolCallDelta struct {
+	Index    int                              `json:"index"`
+	ID       string                           `json:"id,omitempty"`
+	Type     string                           `json:"type,omitempty"`
+	Function *ChatCompletionFunctionCallDelta `json:"function,omitempty"`
+}
+
+// ChatCompletionFunctionCallDelta 是 tool_calls 增量中 function 字段的形状。
+type ChatCompletionFunctionCallDelta struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ChatCompletionUsage 对应响应 usage 字段，由 Claude 的 input_tokens/output_tokens 映射而来。
+type ChatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// buildMessagesBodyFromChatCompletion 将 OpenAI chat completion 请求转换为等价的
+// /v1/messages 请求体，供 GatewayHandler.Messages 的既有转发/账号选择/计费链路直接复用。
+func buildMessagesBodyFromChatCompletion(req *ChatCompletionRequest) ([]byte, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages must not be empty")
+	}
+
+	maxTokens := 4096
+	if req.MaxCompletionTokens != nil {
+		maxTokens = *req.MaxCompletionTokens
+	}
+	if req.MaxTokens != nil {
+		maxTokens = *req.MaxTokens
+	}
+
+	out := map[string]any{
+		"model":      req.Model,
+		"max_tokens": maxTokens,
+		"stream":     req.Stream,
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if stopSequences := decodeChatCompletionStop(req.Stop); len(stopSequences) > 0 {
+		out["stop_sequences"] = stopSequences
+	}
+
+	var systemParts []string
+	claudeMessages := make([]any, 0, len(req.Messages))
+	for _, msg := range req.Messages {
+		role := msg.Role
+		if role != "system" && role != "developer" && role != "tool" && role != "assistant" {
+			role = "user"
+		}
+		switch role {
+		case "system", "developer":
+			if text := decodeChatCompletionContentText(msg.Content); text != "" {
+				systemParts = append(systemParts, text)
+			}
+		case "tool":
+			toolResult := map[string]any{
+				"type":        "tool_result",
+				"tool_use_id": msg.ToolCallID,
+				"content":     decodeChatCompletionContentText(msg.Content),
+			}
+			// 并行工具调用在 OpenAI 格式中表现为连续多条 tool 消息，Anthropic Messages API
+			// 要求角色交替出现，因此需要合并进同一条 user 消息的多个 tool_result 块。
+			if n := len(claudeMessages); n > 0 {
+				if prev, ok := claudeMessages[n-1].(map[string]any); ok && prev["role"] == "user" {
+					if content, ok := prev["content"].([]any); ok {
+						prev["content"] = append(content, toolResult)
+						continue
+					}
+				}
+			}
+			claudeMessages = append(claudeMessages, map[string]any{
+				"role":    "user",
+				"content": []any{toolResult},
+			})
+		case "assistant":
+			blocks := decodeChatCompletionContentBlocks(msg.Content)
+			for _, tc := range msg.ToolCalls {
+				input := map[string]any{}
+				if tc.Function.Arguments != "" {
+					_ = json.Unmarshal([]byte(tc.Function.Arguments), &input)
+				}
+				blocks = append(blocks, map[string]any{
+					"type":  "tool_use",
+					"id":    tc.ID,
+					"name":  tc.Function.Name,
+					"input": input,
+				})
+			}
+			if len(blocks) == 0 {
+				blocks = []any{map[string]any{"type": "text", "text": ""}}
+			}
+			claudeMessages = append(claudeMessages, map[string]any{"role": "assistant", "content": blocks})
+		default: // "user"
+			blocks := decodeChatCompletionContentBlocks(msg.Content)
+			if len(blocks) == 0 {
+				blocks = []any{map[string]any{"type": "text", "text": ""}}
+			}
+			claudeMessages = append(claudeMessages, map[string]any{"role": "user", "content": blocks})
+		}
+	}
+	if len(systemParts) > 0 {
+		out["system"] = strings.Join(systemParts, "\n\n")
+	}
+	out["messages"] = claudeMessages
+
+	if tools := convertChatCompletionToolsToClaudeTools(req.Tools); len(tools) > 0 {
+		out["tools"] = tools
+	}
+	if toolChoice := convertChatCompletionToolChoiceToClaude(req.ToolChoice); toolChoice != nil {
+		out["tool_choice"] = toolChoice
+	}
+
+	return json.Marshal(out)
+}
+
+// decodeChatCompletionStop 解析 stop 字段，兼容字符串与字符串数组两种写法。
+func decodeChatCompletionStop(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s == "" {
+			return nil
+		}
+		return []string{s}
+	}
+	var arr []string
+	if err := json.Unmarshal(raw, &arr); err == nil {
+		return arr
+	}
+	return nil
+}
+
+// decodeChatCompletionContentText 把 content 字段解析为纯文本，兼容字符串与内容块数组两种写法。
+func decodeChatCompletionContentText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var parts []map[string]any
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, p := range parts {
+		if t, _ := p["type"].(string); t == "text" {
+			if text, ok := p["text"].(string); ok {
+				sb.WriteString(text)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// decodeChatCompletionContentBlocks 把 content 字段解析为 Claude 内容块数组，
+// 兼容字符串与 OpenAI 多模态内容块数组（text/image_url）两种写法。
+func decodeChatCompletionContentBlocks(raw json.RawMessage) []any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s == "" {
+			return nil
+		}
+		return []any{map[string]any{"type": "text", "text": s}}
+	}
+	var parts []map[string]any
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return nil
+	}
+	blocks := make([]any, 0, len(parts))
+	for _, p := range parts {
+		switch t, _ := p["type"].(string); t {
+		case "text":
+			text, _ := p["text"].(string)
+			blocks = append(blocks, map[string]any{"type": "text", "text": text})
+		case "image_url":
+			imageURL, _ := p["image_url"].(map[string]any)
+			url, _ := imageURL["url"].(string)
+			if block := convertOpenAIImageURLToClaudeImage(url); block != nil {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	return blocks
+}
+
+// convertOpenAIImageURLToClaudeImage 把 OpenAI image_url（data URI 或普通 URL）转换为
+// Claude 的 image 内容块。
+func convertOpenAIImageURLToClaudeImage(url string) map[string]any {
+	if url == "" {
+		return nil
+	}
+	if !strings.HasPrefix(url, "data:") {
+		return map[string]any{
+			"type":   "image",
+			"source": map[string]any{"type": "url", "url": url},
+		}
+	}
+	header, data, _ := strings.Cut(url, ",")
+	mediaType := "image/png"
+	if _, rest, ok := strings.Cut(header, ":"); ok {
+		if mt, _, ok := strings.Cut(rest, ";"); ok {
+			mediaType = mt
+		}
+	}
+	return map[string]any{
+		"type": "image",
+		"source": map[string]any{
+			"type":       "base64",
+			"media_type": mediaType,
+			"data":       data,
+		},
+	}
+}
+
+// convertChatCompletionToolsToClaudeTools 把 OpenAI tools 数组转换为 Claude tools 数组。
+func convertChatCompletionToolsToClaudeTools(tools []ChatCompletionTool) []any {
+	if len(tools) == 0 {
+		return nil
+	}
+	out := make([]any, 0, len(tools))
+	for _, t := range tools {
+		if t.Type != "" && t.Type != "function" {
+			continue
+		}
+		schema := map[string]any{"type": "object"}
+		if len(t.Function.Parameters) > 0 {
+			var parsed map[string]any
+			if err := json.Unmarshal(t.Function.Parameters, &parsed); err == nil {
+				schema = parsed
+			}
+		}
+		out = append(out, map[string]any{
+			"name":         t.Function.Name,
+			"description":  t.Function.Description,
+			"input_schema": schema,
+		})
+	}
+	return out
+}
+
+// convertChatCompletionToolChoiceToClaude 把 OpenAI tool_choice 映射为 Claude tool_choice，
+// 无法识别的取值返回 nil（即不设置该字段）。
+func convertChatCompletionToolChoiceToClaude(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		switch s {
+		case "auto":
+			return map[string]any{"type": "auto"}
+		case "required":
+			return map[string]any{"type": "any"}
+		case "none":
+			return map[string]any{"type": "none"}
+		default:
+			return nil
+		}
+	}
+	var obj struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	if obj.Type == "function" && obj.Function.Name != "" {
+		return map[string]any{"type": "tool", "name": obj.Function.Name}
+	}
+	return nil
+}
+
+// mapClaudeStopReasonToOpenAIFinishReason 把 /v1/messages 的 stop_reason 映射为 OpenAI 的
+// finish_reason 取值集合，未知值一律归为 "stop"。
+func mapClaudeStopReasonToOpenAIFinishReason(stopReason string) string {
+	switch stopReason {
+	case "max_tokens":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "refusal":
+		return "content_filter"
+	default:
+		return "stop"
+	}
+}
+
+// chatCompletionIDFromClaudeID 由 Claude 消息 ID 派生 chatcmpl- 前缀的 ID，
+// 取不到时退化为基于时间戳生成。
+func chatCompletionIDFromClaudeID(claudeID string) string {
+	if claudeID == "" {
+		return fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
+	}
+	return "chatcmpl-" + strings.TrimPrefix(claudeID, "msg_")
+}
+
+// convertMessagesResponseToChatCompletion 将一次非流式 /v1/messages 响应体转换为
+// OpenAI chat.completion 响应，text 内容块拼接为 content，tool_use 内容块转换为 tool_calls。
+func convertMessagesResponseToChatCompletion(body []byte, reqModel string) *ChatCompletionResponse {
+	model := gjson.GetBytes(body, "model").String()
+	if model == "" {
+		model = reqModel
+	}
+
+	var contentText strings.Builder
+	var toolCalls []ChatCompletionToolCall
+	for _, block := range gjson.GetBytes(body, "content").Array() {
+		switch block.Get("type").String() {
+		case "text":
+			contentText.WriteString(block.Get("text").String())
+		case "tool_use":
+			args := block.Get("input").Raw
+			if args == "" {
+				args = "{}"
+			}
+			toolCalls = append(toolCalls, ChatCompletionToolCall{
+				ID:   block.Get("id").String(),
+				Type: "function",
+				Function: ChatCompletionFunctionCall{
+					Name:      block.Get("name").String(),
+					Arguments: args,
+				},
+			})
+		}
+	}
+
+	finishReason := mapClaudeStopReasonToOpenAIFinishReason(gjson.GetBytes(body, "stop_reason").String())
+
+	msg := &ChatCompletionOutMessage{Role: "assistant", ToolCalls: toolCalls}
+	if contentText.Len() > 0 || len(toolCalls) == 0 {
+		text := contentText.String()
+		msg.Content = &text
+	}
+
+	usage := &ChatCompletionUsage{
+		PromptTokens:     int(gjson.GetBytes(body, "usage.input_tokens").Int()),
+		CompletionTokens: int(gjson.GetBytes(body, "usage.output_tokens").Int()),
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+
+	return &ChatCompletionResponse{
+		ID:      chatCompletionIDFromClaudeID(gjson.GetBytes(body, "id").String()),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []ChatCompletionChoice{{
+			Index:        0,
+			Message:      msg,
+			FinishReason: &finishReason,
+		}},
+		Usage: usage,
+	}
+}
+
+// openaiChatStreamWriter 包裹 gin.ResponseWriter，把 /v1/messages 的 SSE 事件流
+// （message_start/content_block_start/content_block_delta/message_delta/message_stop）
+// 实时转换为 OpenAI chat.completion.chunk 的 SSE 事件流，供流式 /v1/chat/completions 请求使用。
+// 非 200 的错误响应体不是 SSE 形状，遇到时原样透传而不做转换。
+type openaiChatStreamWriter struct {
+	gin.ResponseWriter
+	buf           bytes.Buffer
+	model         string
+	id            string
+	created       int64
+	toolCallIndex map[int]int
+	nextToolCall  int
+	usage         *ChatCompletionUsage
+	finishReason  string
+	passthrough   bool
+	sentRole      bool
+}
+
+func newOpenAIChatStreamWriter(w gin.ResponseWriter, model string) *openaiChatStreamWriter {
+	return &openaiChatStreamWriter{
+		ResponseWriter: w,
+		model:          model,
+		toolCallIndex:  make(map[int]int),
+		finishReason:   "stop",
+	}
+}
+
+func (w *openaiChatStreamWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.buf.Len() == 0 && len(bytes.TrimSpace(b)) > 0 && bytes.TrimSpace(b)[0] == '{' {
+		w.passthrough = true
+		return w.ResponseWriter.Write(b)
+	}
+	w.buf.Write(b)
+	for {
+		raw := w.buf.Bytes()
+		idx := bytes.Index(raw, []byte("\n\n"))
+		if idx < 0 {
+			break
+		}
+		event := raw[:idx]
+		w.buf.Next(idx + 2)
+		if err := w.handleEvent(event); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *openaiChatStreamWriter) handleEvent(event []byte) error {
+	name, data := parseSSEEventLine(event)
+	switch name {
+	case "message_start":
+		w.id = chatCompletionIDFromClaudeID(gjson.GetBytes(data, "message.id").String())
+		w.created = time.Now().Unix()
+		if m := gjson.GetBytes(data, "message.model").String(); m != "" {
+			w.model = m
+		}
+		if in := gjson.GetBytes(data, "message.usage.input_tokens"); in.Exists() {
+			w.usage = &ChatCompletionUsage{PromptTokens: int(in.Int())}
+		}
+		return w.emitRoleChunk()
+	case "content_block_start":
+		if gjson.GetBytes(data, "content_block.type").String() != "tool_use" {
+			return nil
+		}
+		index := int(gjson.GetBytes(data, "index").Int())
+		toolIndex := w.nextToolCall
+		w.toolCallIndex[index] = toolIndex
+		w.nextToolCall++
+		return w.emitChunk(&ChatCompletionDeltaMessage{
+			ToolCalls: []ChatCompletionToolCallDelta{{
+				Index: toolIndex,
+				ID:    gjson.GetBytes(data, "content_block.id").String(),
+				Type:  "function",
+				Function: &ChatCompletionFunctionCallDelta{
+					Name: gjson.GetBytes(data, "content_block.name").String(),
+				},
+			}},
+		}, nil)
+	case "content_block_delta":
+		switch gjson.GetBytes(data, "delta.type").String() {
+		case "text_delta":
+			text := gjson.GetBytes(data, "delta.text").String()
+			if text == "" {
+				return nil
+			}
+			return w.emitChunk(&ChatCompletionDeltaMessage{Content: &text}, nil)
+		case "input_json_delta":
+			index := int(gjson.GetBytes(data, "index").Int())
+			toolIndex, ok := w.toolCallIndex[index]
+			if !ok {
+				return nil
+			}
+			partial := gjson.GetBytes(data, "delta.partial_json").String()
+			return w.emitChunk(&ChatCompletionDeltaMessage{
+				ToolCalls: []ChatCompletionToolCallDelta{{
+					Index:    toolIndex,
+					Function: &ChatCompletionFunctionCallDelta{Arguments: partial},
+				}},
+			}, nil)
+		default:
+			return nil
+		}
+	case "message_delta":
+		if stopReason := gjson.GetBytes(data, "delta.stop_reason").String(); stopReason != "" {
+			w.finishReason = mapClaudeStopReasonToOpenAIFinishReason(stopReason)
+		}
+		if out := gjson.GetBytes(data, "usage.output_tokens"); out.Exists() {
+			if w.usage == nil {
+				w.usage = &ChatCompletionUsage{}
+			}
+			w.usage.CompletionTokens = int(out.Int())
+		}
+		return nil
+	case "message_stop":
+		return w.emitFinal()
+	default:
+		return nil
+	}
+}
+
+func (w *openaiChatStreamWriter) emitRoleChunk() error {
+	if w.sentRole {
+		return nil
+	}
+	w.sentRole = true
+	empty := ""
+	return w.emitChunk(&ChatCompletionDeltaMessage{Role: "assistant", Content: &empty}, nil)
+}
+
+func (w *openaiChatStreamWriter) emitChunk(delta *ChatCompletionDeltaMessage, finishReason *string) error {
+	chunk := ChatCompletionChunk{
+		ID:      w.id,
+		Object:  "chat.completion.chunk",
+		Created: w.created,
+		Model:   w.model,
+		Choices: []ChatCompletionChoice{{Index: 0, Delta: delta, FinishReason: finishReason}},
+	}
+	return w.writeChunk(chunk)
+}
+
+func (w *openaiChatStreamWriter) emitFinal() error {
+	finishReason := w.finishReason
+	if err := w.emitChunk(&ChatCompletionDeltaMessage{}, &finishReason); err != nil {
+		return err
+	}
+	if w.usage != nil {
+		w.usage.TotalTokens = w.usage.PromptTokens + w.usage.CompletionTokens
+		usageChunk := ChatCompletionChunk{
+			ID:      w.id,
+			Object:  "chat.completion.chunk",
+			Created: w.created,
+			Model:   w.model,
+			Choices: []ChatCompletionChoice{},
+			Usage:   w.usage,
+		}
+		if err := w.writeChunk(usageChunk); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w.ResponseWriter, "data: [DONE]\n\n")
+	return err
+}
+
+func (w *openaiChatStreamWriter) writeChunk(chunk ChatCompletionChunk) error {
+	payload, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.ResponseWriter, "data: %s\n\n", payload)
+	return err
+}
+
+// ChatCompletions handles the OpenAI-compatible chat completions endpoint by converting
+// the request into an equivalent /v1/messages request, forwarding it through the normal
+// Messages flow (account selection/failover/billing unchanged), then converting the
+// response (JSON or SSE) back into the OpenAI chat.completion(.chunk) shape.
+// POST /v1/chat/completions
+func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+
+	var req ChatCompletionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+		return
+	}
+	if req.Model == "" || len(req.Messages) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "model and messages are required")
+		return
+	}
+
+	messagesBody, err := buildMessagesBodyFromChatCompletion(&req)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(messagesBody))
+	c.Request.ContentLength = int64(len(messagesBody))
+
+	if req.Stream {
+		c.Writer = newOpenAIChatStreamWriter(c.Writer, req.Model)
+		h.Messages(c)
+		return
+	}
+
+	capture := &legacyCompleteCaptureWriter{ResponseWriter: c.Writer}
+	c.Writer = capture
+	h.Messages(c)
+
+	if capture.Status() != http.StatusOK || capture.buf.Len() == 0 {
+		_, _ = capture.ResponseWriter.Write(capture.buf.Bytes())
+		return
+	}
+	chatResp := convertMessagesResponseToChatCompletion(capture.buf.Bytes(), req.Model)
+	payload, err := json.Marshal(chatResp)
+	if err != nil {
+		_, _ = capture.ResponseWriter.Write(capture.buf.Bytes())
+		return
+	}
+	_, _ = capture.ResponseWriter.Write(payload)
+}
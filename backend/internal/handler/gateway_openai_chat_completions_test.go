@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestBuildMessagesBodyFromChatCompletion_ConvertsSystemAndUserMessages(t *testing.T) {
+	req := &ChatCompletionRequest{
+		Model: "claude-3-5-sonnet",
+		Messages: []ChatCompletionMessage{
+			{Role: "system", Content: json.RawMessage(`"be concise"`)},
+			{Role: "user", Content: json.RawMessage(`"what is 2+2?"`)},
+		},
+	}
+
+	body, err := buildMessagesBodyFromChatCompletion(req)
+	require.NoError(t, err)
+
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	require.NoError(t, err)
+	require.Equal(t, "claude-3-5-sonnet", parsed.Model)
+	require.Equal(t, 4096, parsed.MaxTokens)
+	require.Len(t, parsed.Messages, 1)
+	require.Equal(t, "be concise", gjson.GetBytes(body, "system").String())
+}
+
+func TestBuildMessagesBodyFromChatCompletion_ConvertsToolCallsAndToolResults(t *testing.T) {
+	req := &ChatCompletionRequest{
+		Model:     "claude-3-5-sonnet",
+		MaxTokens: intPtr(200),
+		Messages: []ChatCompletionMessage{
+			{Role: "user", Content: json.RawMessage(`"what is the weather in sf?"`)},
+			{
+				Role: "assistant",
+				ToolCalls: []ChatCompletionToolCall{{
+					ID:   "call_1",
+					Type: "function",
+					Function: ChatCompletionFunctionCall{
+						Name:      "get_weather",
+						Arguments: `{"city":"sf"}`,
+					},
+				}},
+			},
+			{Role: "tool", ToolCallID: "call_1", Content: json.RawMessage(`"sunny"`)},
+		},
+		Tools: []ChatCompletionTool{{
+			Type: "function",
+			Function: struct {
+				Name        string          `json:"name"`
+				Description string          `json:"description,omitempty"`
+				Parameters  json.RawMessage `json:"parameters,omitempty"`
+			}{Name: "get_weather", Parameters: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`)},
+		}},
+	}
+
+	body, err := buildMessagesBodyFromChatCompletion(req)
+	require.NoError(t, err)
+
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	require.NoError(t, err)
+	require.Equal(t, 200, parsed.MaxTokens)
+	require.Len(t, parsed.Messages, 3)
+	require.True(t, parsed.HasTools)
+	require.Equal(t, "get_weather", gjson.GetBytes(body, "tools.0.name").String())
+}
+
+func TestBuildMessagesBodyFromChatCompletion_MergesParallelToolResultsIntoOneUserMessage(t *testing.T) {
+	req := &ChatCompletionRequest{
+		Model:     "claude-3-5-sonnet",
+		MaxTokens: intPtr(200),
+		Messages: []ChatCompletionMessage{
+			{Role: "user", Content: json.RawMessage(`"what is the weather in sf and nyc?"`)},
+			{
+				Role: "assistant",
+				ToolCalls: []ChatCompletionToolCall{
+					{ID: "call_1", Type: "function", Function: ChatCompletionFunctionCall{Name: "get_weather", Arguments: `{"city":"sf"}`}},
+					{ID: "call_2", Type: "function", Function: ChatCompletionFunctionCall{Name: "get_weather", Arguments: `{"city":"nyc"}`}},
+				},
+			},
+			{Role: "tool", ToolCallID: "call_1", Content: json.RawMessage(`"sunny"`)},
+			{Role: "tool", ToolCallID: "call_2", Content: json.RawMessage(`"cloudy"`)},
+		},
+	}
+
+	body, err := buildMessagesBodyFromChatCompletion(req)
+	require.NoError(t, err)
+
+	// 两条并行 tool 消息必须合并为同一条 user 消息（否则 Anthropic Messages API 会因角色
+	// 不交替而拒绝请求），且各自的 tool_result 块都要保留。
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	require.NoError(t, err)
+	require.Len(t, parsed.Messages, 3)
+	require.Equal(t, "user", gjson.GetBytes(body, "messages.2.role").String())
+	require.Len(t, gjson.GetBytes(body, "messages.2.content").Array(), 2)
+	require.Equal(t, "call_1", gjson.GetBytes(body, "messages.2.content.0.tool_use_id").String())
+	require.Equal(t, "sunny", gjson.GetBytes(body, "messages.2.content.0.content").String())
+	require.Equal(t, "call_2", gjson.GetBytes(body, "messages.2.content.1.tool_use_id").String())
+	require.Equal(t, "cloudy", gjson.GetBytes(body, "messages.2.content.1.content").String())
+}
+
+func TestBuildMessagesBodyFromChatCompletion_RejectsEmptyMessages(t *testing.T) {
+	_, err := buildMessagesBodyFromChatCompletion(&ChatCompletionRequest{Model: "claude-3-5-sonnet"})
+	require.Error(t, err)
+}
+
+func TestMapClaudeStopReasonToOpenAIFinishReason(t *testing.T) {
+	require.Equal(t, "stop", mapClaudeStopReasonToOpenAIFinishReason("end_turn"))
+	require.Equal(t, "stop", mapClaudeStopReasonToOpenAIFinishReason("stop_sequence"))
+	require.Equal(t, "length", mapClaudeStopReasonToOpenAIFinishReason("max_tokens"))
+	require.Equal(t, "tool_calls", mapClaudeStopReasonToOpenAIFinishReason("tool_use"))
+}
+
+func TestConvertMessagesResponseToChatCompletion_TextResponse(t *testing.T) {
+	body := []byte(`{
+		"id": "msg_abc123",
+		"model": "claude-3-5-sonnet",
+		"stop_reason": "end_turn",
+		"content": [{"type": "text", "text": "4"}],
+		"usage": {"input_tokens": 10, "output_tokens": 1}
+	}`)
+
+	resp := convertMessagesResponseToChatCompletion(body, "claude-3-5-sonnet")
+	require.Equal(t, "chat.completion", resp.Object)
+	require.Equal(t, "chatcmpl-abc123", resp.ID)
+	require.Len(t, resp.Choices, 1)
+	require.Equal(t, "stop", *resp.Choices[0].FinishReason)
+	require.Equal(t, "4", *resp.Choices[0].Message.Content)
+	require.Equal(t, 10, resp.Usage.PromptTokens)
+	require.Equal(t, 1, resp.Usage.CompletionTokens)
+	require.Equal(t, 11, resp.Usage.TotalTokens)
+}
+
+func TestConvertMessagesResponseToChatCompletion_ToolUse(t *testing.T) {
+	body := []byte(`{
+		"id": "msg_def456",
+		"model": "claude-3-5-sonnet",
+		"stop_reason": "tool_use",
+		"content": [{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "sf"}}],
+		"usage": {"input_tokens": 5, "output_tokens": 2}
+	}`)
+
+	resp := convertMessagesResponseToChatCompletion(body, "claude-3-5-sonnet")
+	require.Equal(t, "tool_calls", *resp.Choices[0].FinishReason)
+	require.Nil(t, resp.Choices[0].Message.Content)
+	require.Len(t, resp.Choices[0].Message.ToolCalls, 1)
+	require.Equal(t, "get_weather", resp.Choices[0].Message.ToolCalls[0].Function.Name)
+	require.JSONEq(t, `{"city":"sf"}`, resp.Choices[0].Message.ToolCalls[0].Function.Arguments)
+}
+
+func TestOpenAIChatStreamWriter_ConvertsTextDeltas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newOpenAIChatStreamWriter(c.Writer, "claude-3-5-sonnet")
+	_, err := w.Write([]byte("event: message_start\ndata: {\"message\":{\"id\":\"msg_xyz\",\"model\":\"claude-3-5-sonnet\",\"usage\":{\"input_tokens\":3}}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: content_block_delta\ndata: {\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"Hi\"}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: message_delta\ndata: {\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":1}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: message_stop\ndata: {}\n\n"))
+	require.NoError(t, err)
+
+	out := rec.Body.String()
+	require.Contains(t, out, `"chatcmpl-xyz"`)
+	require.Contains(t, out, `"content":"Hi"`)
+	require.Contains(t, out, `"finish_reason":"stop"`)
+	require.Contains(t, out, `"prompt_tokens":3`)
+	require.Contains(t, out, "data: [DONE]")
+}
+
+func TestOpenAIChatStreamWriter_ConvertsToolCallDeltas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newOpenAIChatStreamWriter(c.Writer, "claude-3-5-sonnet")
+	_, err := w.Write([]byte("event: message_start\ndata: {\"message\":{\"id\":\"msg_tool1\"}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: content_block_start\ndata: {\"index\":0,\"content_block\":{\"type\":\"tool_use\",\"id\":\"toolu_1\",\"name\":\"get_weather\"}}\n\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("event: content_block_delta\ndata: {\"index\":0,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"{\\\"city\\\"\"}}\n\n"))
+	require.NoError(t, err)
+
+	out := rec.Body.String()
+	require.Contains(t, out, `"id":"toolu_1"`)
+	require.Contains(t, out, `"name":"get_weather"`)
+	require.Contains(t, out, `"arguments":"{\"city\""`)
+}
+
+func TestOpenAIChatStreamWriter_PassesThroughNonSSEErrorBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newOpenAIChatStreamWriter(c.Writer, "claude-3-5-sonnet")
+	_, err := w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"bad request"}}`))
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{"error":{"type":"invalid_request_error","message":"bad request"}}`, rec.Body.String())
+}
+
+func intPtr(v int) *int { return &v }
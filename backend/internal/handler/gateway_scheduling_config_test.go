@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSchedulingConfig_ReturnsEffectiveConfig 验证返回值与加载的配置一致，不做脱敏处理。
+func TestGetSchedulingConfig_ReturnsEffectiveConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.Gateway.Scheduling.StickySessionMaxWaiting = 9
+	cfg.Gateway.Scheduling.StickySessionWaitTimeout = 12 * time.Second
+	cfg.Gateway.Scheduling.FallbackWaitTimeout = 34 * time.Second
+	cfg.Gateway.Scheduling.FallbackMaxWaiting = 56
+	cfg.Gateway.Scheduling.LoadBatchEnabled = false
+	cfg.Gateway.Scheduling.SlotCleanupInterval = 78 * time.Second
+	cfg.Gateway.Scheduling.SimpleModeDefaultGroupID = 90
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/gateway/config/scheduling", nil)
+
+	h.GetSchedulingConfig(c)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data config.GatewaySchedulingConfig `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, cfg.Gateway.Scheduling, body.Data)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDebugSessionHash_DefaultsToAnthropicProtocol 验证 protocol 留空时按 anthropic 协议解析，
+// 且返回的派生分支与请求内容一致。
+func TestDebugSessionHash_DefaultsToAnthropicProtocol(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	reqBody := `{"body":"{\"metadata\":{\"user_id\":\"session_123e4567-e89b-12d3-a456-426614174000\"},\"messages\":[{\"role\":\"user\",\"content\":\"hi\"}]}"}`
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/gateway/session-hash", strings.NewReader(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.DebugSessionHash(c)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data service.SessionHashDebugResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", resp.Data.SessionHash)
+	require.Equal(t, string(service.SessionHashSourceMetadata), resp.Data.Source)
+}
+
+// TestDebugSessionHash_MissingBodyReturnsBadRequest 验证缺少必填 body 字段时返回 400。
+func TestDebugSessionHash_MissingBodyReturnsBadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &GatewayHandler{gatewayService: service.NewGatewayService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/gateway/session-hash", strings.NewReader(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.DebugSessionHash(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -176,11 +177,21 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 
 	setOpsRequestContext(c, modelName, stream, body)
 
+	// 已知模型名校验（opt-in，默认关闭）：尽早拒绝明显拼写错误的模型名
+	if validation := h.gatewayService.ValidateKnownModel(c.Request.Context(), apiKey.GroupID, modelName); !validation.Known {
+		message := fmt.Sprintf("model %q not found", modelName)
+		if len(validation.Suggestions) > 0 {
+			message += fmt.Sprintf(", did you mean: %s?", strings.Join(validation.Suggestions, ", "))
+		}
+		googleError(c, http.StatusBadRequest, message)
+		return
+	}
+
 	// Get subscription (may be nil)
 	subscription, _ := middleware.GetSubscriptionFromContext(c)
 
 	// For Gemini native API, do not send Claude-style ping frames.
-	geminiConcurrency := NewConcurrencyHelper(h.concurrencyHelper.concurrencyService, SSEPingFormatNone, 0)
+	geminiConcurrency := NewConcurrencyHelper(h.concurrencyHelper.concurrencyService, SSEPingFormatNone, 0, h.concurrencyHelper.maxConcurrentStreamsPerUser)
 
 	// 0) wait queue check
 	maxWait := service.CalculateMaxWait(authSubject.Concurrency)
@@ -206,6 +217,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 	if h.errorPassthroughService != nil {
 		service.BindErrorPassthroughService(c, h.errorPassthroughService)
 	}
+	service.BindOpsUpstreamMaxErrorEvents(c, h.maxOpsUpstreamErrorEvents)
 	userReleaseFunc, err := geminiConcurrency.AcquireUserSlotWithWait(c, authSubject.UserID, authSubject.Concurrency, stream, &streamStarted)
 	if err != nil {
 		googleError(c, http.StatusTooManyRequests, err.Error())
@@ -221,6 +233,17 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		defer userReleaseFunc()
 	}
 
+	// 1.5) 流式请求的单用户并发连接数上限检查：超限立即返回 429，不排队等待
+	if stream {
+		streamReleaseFunc, err := geminiConcurrency.AcquireUserStreamSlot(c.Request.Context(), authSubject.UserID)
+		if err != nil {
+			googleError(c, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		streamReleaseFunc = wrapReleaseOnDone(c.Request.Context(), streamReleaseFunc)
+		defer streamReleaseFunc()
+	}
+
 	// 2) billing eligibility check (after wait)
 	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
 		status, _, message := billingErrorDetails(err)
@@ -243,6 +266,9 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		}
 		sessionHash = h.gatewayService.GenerateSessionHash(parsedReq)
 	}
+	if noStickyRequested(c) {
+		sessionHash = ""
+	}
 	sessionKey := sessionHash
 	if sessionHash != "" {
 		sessionKey = "gemini:" + sessionHash
@@ -385,6 +411,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 				googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts")
 				return
 			}
+			writeEstimatedWaitHeader(c, account.ID, selection.WaitPlan)
 			accountWaitCounted := false
 			canWait, err := geminiConcurrency.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
 			if err != nil {
@@ -432,6 +459,9 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		if switchCount > 0 {
 			requestCtx = context.WithValue(requestCtx, ctxkey.AccountSwitchCount, switchCount)
 		}
+		if sessionKey != "" {
+			requestCtx = context.WithValue(requestCtx, ctxkey.GeminiSessionKey, sessionKey)
+		}
 		if account.Platform == service.PlatformAntigravity && account.Type != service.AccountTypeAPIKey {
 			result, err = h.antigravityGatewayService.ForwardGemini(requestCtx, c, account, modelName, action, stream, body, hasBoundSession)
 		} else {
@@ -447,7 +477,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 				if needForceCacheBilling(hasBoundSession, failoverErr) {
 					forceCacheBilling = true
 				}
-				if switchCount >= maxAccountSwitches {
+				if failoverSwitchLimitReached(switchCount, maxAccountSwitches) {
 					lastFailoverErr = failoverErr
 					h.handleGeminiFailoverExhausted(c, lastFailoverErr)
 					return
@@ -41,6 +41,7 @@ type Handlers struct {
 	OpenAIGateway *OpenAIGatewayHandler
 	Setting       *SettingHandler
 	Totp          *TotpHandler
+	Health        *HealthHandler
 }
 
 // BuildInfo contains build-time information
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler 提供无需认证的健康检查与就绪探针
+type HealthHandler struct {
+	accountService    *service.AccountService
+	schedulerSnapshot *service.SchedulerSnapshotService
+}
+
+// NewHealthHandler 创建健康检查处理器
+func NewHealthHandler(accountService *service.AccountService, schedulerSnapshot *service.SchedulerSnapshotService) *HealthHandler {
+	return &HealthHandler{
+		accountService:    accountService,
+		schedulerSnapshot: schedulerSnapshot,
+	}
+}
+
+// schedulingPlatforms 就绪探针覆盖的平台列表
+var schedulingPlatforms = []string{service.PlatformAnthropic, service.PlatformGemini, service.PlatformAntigravity}
+
+// platformSchedulingStatus 单个平台的调度健康状况
+type platformSchedulingStatus struct {
+	Platform         string `json:"platform"`
+	SchedulableCount int    `json:"schedulable_count"`
+	RateLimitedCount int    `json:"rate_limited_count"`
+	CanServe         bool   `json:"can_serve"`
+}
+
+// GetSchedulingHealth 返回各平台可调度账号统计，用于 k8s 就绪探针
+// GET /healthz/scheduling
+func (h *HealthHandler) GetSchedulingHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	platforms := make([]platformSchedulingStatus, 0, len(schedulingPlatforms))
+	anySchedulable := false
+
+	for _, platform := range schedulingPlatforms {
+		status := h.platformStatus(ctx, platform)
+		if status.CanServe {
+			anySchedulable = true
+		}
+		platforms = append(platforms, status)
+	}
+
+	httpStatus := http.StatusOK
+	if !anySchedulable {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	c.JSON(httpStatus, gin.H{
+		"status":    map[bool]string{true: "ok", false: "unavailable"}[anySchedulable],
+		"platforms": platforms,
+	})
+}
+
+// platformStatus 统计单个平台的可调度账号数与限流账号数
+// 可调度账号数优先经由调度器快照获取（命中缓存则无需查库），限流账号数需要全量账号来判断，
+// 始终回落到数据库查询
+func (h *HealthHandler) platformStatus(ctx context.Context, platform string) platformSchedulingStatus {
+	status := platformSchedulingStatus{Platform: platform}
+
+	if schedulable, _, err := h.schedulerSnapshot.ListSchedulableAccounts(ctx, nil, platform, true); err == nil {
+		status.SchedulableCount = len(schedulable)
+	}
+
+	accounts, err := h.accountService.ListByPlatform(ctx, platform)
+	if err == nil {
+		for _, account := range accounts {
+			if account.IsRateLimited() {
+				status.RateLimitedCount++
+			}
+		}
+	}
+
+	status.CanServe = status.SchedulableCount > 0
+	return status
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
@@ -22,12 +23,15 @@ import (
 
 // OpenAIGatewayHandler handles OpenAI API gateway requests
 type OpenAIGatewayHandler struct {
-	gatewayService          *service.OpenAIGatewayService
-	billingCacheService     *service.BillingCacheService
-	apiKeyService           *service.APIKeyService
-	errorPassthroughService *service.ErrorPassthroughService
-	concurrencyHelper       *ConcurrencyHelper
-	maxAccountSwitches      int
+	gatewayService            *service.OpenAIGatewayService
+	billingCacheService       *service.BillingCacheService
+	apiKeyService             *service.APIKeyService
+	errorPassthroughService   *service.ErrorPassthroughService
+	concurrencyHelper         *ConcurrencyHelper
+	maxAccountSwitches        int
+	maxOpsUpstreamErrorEvents int
+	billingTagEnabled         bool
+	validBillingTags          []string
 }
 
 // NewOpenAIGatewayHandler creates a new OpenAIGatewayHandler
@@ -41,22 +45,47 @@ func NewOpenAIGatewayHandler(
 ) *OpenAIGatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 3
+	maxConcurrentStreamsPerUser := 0
+	maxOpsUpstreamErrorEvents := 0
+	billingTagEnabled := false
+	var validBillingTags []string
 	if cfg != nil {
 		pingInterval = time.Duration(cfg.Concurrency.PingInterval) * time.Second
 		if cfg.Gateway.MaxAccountSwitches > 0 {
 			maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
 		}
+		maxConcurrentStreamsPerUser = cfg.Gateway.Scheduling.MaxConcurrentStreamsPerUser
+		maxOpsUpstreamErrorEvents = cfg.Gateway.MaxOpsUpstreamErrorEvents
+		billingTagEnabled = cfg.Gateway.BillingTag.Enabled
+		validBillingTags = cfg.Gateway.BillingTag.ValidTags
 	}
 	return &OpenAIGatewayHandler{
-		gatewayService:          gatewayService,
-		billingCacheService:     billingCacheService,
-		apiKeyService:           apiKeyService,
-		errorPassthroughService: errorPassthroughService,
-		concurrencyHelper:       NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
-		maxAccountSwitches:      maxAccountSwitches,
+		gatewayService:            gatewayService,
+		billingCacheService:       billingCacheService,
+		apiKeyService:             apiKeyService,
+		errorPassthroughService:   errorPassthroughService,
+		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval, maxConcurrentStreamsPerUser),
+		maxAccountSwitches:        maxAccountSwitches,
+		maxOpsUpstreamErrorEvents: maxOpsUpstreamErrorEvents,
+		billingTagEnabled:         billingTagEnabled,
+		validBillingTags:          validBillingTags,
 	}
 }
 
+// applyBillingTagHeader 读取 billingTagHeader，校验通过后写入请求 context，
+// 计费阶段据此写入 UsageLog.Tag。校验未通过（未知 tag）或 header 缺失时不写入。
+func (h *OpenAIGatewayHandler) applyBillingTagHeader(c *gin.Context) {
+	raw := strings.TrimSpace(c.GetHeader(billingTagHeader))
+	if raw == "" {
+		return
+	}
+	if !service.IsValidBillingTag(h.billingTagEnabled, h.validBillingTags, raw) {
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), ctxkey.RequestBillingTag, raw)
+	c.Request = c.Request.WithContext(ctx)
+}
+
 // Responses handles OpenAI Responses API endpoint
 // POST /openai/v1/responses
 func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
@@ -108,6 +137,16 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 已知模型名校验（opt-in，默认关闭）：尽早拒绝明显拼写错误的模型名
+	if validation := h.gatewayService.ValidateKnownModel(c.Request.Context(), apiKey.GroupID, reqModel); !validation.Known {
+		message := fmt.Sprintf("model %q not found", reqModel)
+		if len(validation.Suggestions) > 0 {
+			message += fmt.Sprintf(", did you mean: %s?", strings.Join(validation.Suggestions, ", "))
+		}
+		h.errorResponseWithCode(c, http.StatusBadRequest, "invalid_request_error", ErrCodeModelNotAllowed, message)
+		return
+	}
+
 	userAgent := c.GetHeader("User-Agent")
 	if !openai.IsCodexCLIRequest(userAgent) {
 		existingInstructions, _ := reqBody["instructions"].(string)
@@ -125,6 +164,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 	}
 
 	setOpsRequestContext(c, reqModel, reqStream, body)
+	h.applyBillingTagHeader(c)
 
 	// 提前校验 function_call_output 是否具备可关联上下文，避免上游 400。
 	// 要求 previous_response_id，或 input 内存在带 call_id 的 tool_call/function_call，
@@ -153,6 +193,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 	if h.errorPassthroughService != nil {
 		service.BindErrorPassthroughService(c, h.errorPassthroughService)
 	}
+	service.BindOpsUpstreamMaxErrorEvents(c, h.maxOpsUpstreamErrorEvents)
 
 	// Get subscription info (may be nil)
 	subscription, _ := middleware2.GetSubscriptionFromContext(c)
@@ -195,6 +236,19 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		defer userReleaseFunc()
 	}
 
+	// 1.5 Streaming requests: enforce per-user max concurrent streams, rejecting
+	// immediately with 429 instead of waiting (streaming connections are long-lived).
+	if reqStream {
+		streamReleaseFunc, err := h.concurrencyHelper.AcquireUserStreamSlot(c.Request.Context(), subject.UserID)
+		if err != nil {
+			log.Printf("User stream concurrency acquire failed: %v", err)
+			h.handleConcurrencyError(c, err, "user streams", streamStarted)
+			return
+		}
+		streamReleaseFunc = wrapReleaseOnDone(c.Request.Context(), streamReleaseFunc)
+		defer streamReleaseFunc()
+	}
+
 	// 2. Re-check billing eligibility after wait
 	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
 		log.Printf("Billing eligibility check failed after wait: %v", err)
@@ -205,6 +259,9 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 
 	// Generate session hash (header first; fallback to prompt_cache_key)
 	sessionHash := h.gatewayService.GenerateSessionHash(c, reqBody)
+	if noStickyRequested(c) {
+		sessionHash = ""
+	}
 
 	maxAccountSwitches := h.maxAccountSwitches
 	switchCount := 0
@@ -218,7 +275,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		if err != nil {
 			log.Printf("[OpenAI Handler] SelectAccount failed: %v", err)
 			if len(failedAccountIDs) == 0 {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+				h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts: "+err.Error(), streamStarted)
 				return
 			}
 			if lastFailoverErr != nil {
@@ -236,9 +293,10 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		accountReleaseFunc := selection.ReleaseFunc
 		if !selection.Acquired {
 			if selection.WaitPlan == nil {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+				h.handleStreamingAwareErrorWithCode(c, http.StatusServiceUnavailable, "api_error", ErrCodeAccountUnavailable, "No available accounts", streamStarted)
 				return
 			}
+			writeEstimatedWaitHeader(c, account.ID, selection.WaitPlan)
 			accountWaitCounted := false
 			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
 			if err != nil {
@@ -291,7 +349,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 			if errors.As(err, &failoverErr) {
 				failedAccountIDs[account.ID] = struct{}{}
 				lastFailoverErr = failoverErr
-				if switchCount >= maxAccountSwitches {
+				if failoverSwitchLimitReached(switchCount, maxAccountSwitches) {
 					h.handleFailoverExhausted(c, failoverErr, streamStarted)
 					return
 				}
@@ -393,12 +451,30 @@ func (h *OpenAIGatewayHandler) mapUpstreamError(statusCode int) (int, string, st
 
 // handleStreamingAwareError handles errors that may occur after streaming has started
 func (h *OpenAIGatewayHandler) handleStreamingAwareError(c *gin.Context, status int, errType, message string, streamStarted bool) {
+	h.handleStreamingAwareErrorWithCode(c, status, errType, defaultErrorCode(errType), message, streamStarted)
+}
+
+// handleStreamingAwareErrorWithCode 与 handleStreamingAwareError 相同，但允许调用方显式
+// 指定 error.code（用于 error.type 不足以区分的内部失败原因，例如账号不可用、模型未放行）
+func (h *OpenAIGatewayHandler) handleStreamingAwareErrorWithCode(c *gin.Context, status int, errType, code, message string, streamStarted bool) {
 	if streamStarted {
 		// Stream already started, send error as SSE event then close
 		flusher, ok := c.Writer.(http.Flusher)
 		if ok {
 			// Send error event in OpenAI SSE format
-			errorEvent := fmt.Sprintf(`event: error`+"\n"+`data: {"error": {"type": "%s", "message": "%s"}}`+"\n\n", errType, message)
+			errorData := map[string]any{
+				"error": map[string]string{
+					"type":    errType,
+					"code":    code,
+					"message": message,
+				},
+			}
+			jsonBytes, err := json.Marshal(errorData)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			errorEvent := fmt.Sprintf("event: error\ndata: %s\n\n", string(jsonBytes))
 			if _, err := fmt.Fprint(c.Writer, errorEvent); err != nil {
 				_ = c.Error(err)
 			}
@@ -408,14 +484,20 @@ func (h *OpenAIGatewayHandler) handleStreamingAwareError(c *gin.Context, status
 	}
 
 	// Normal case: return JSON response with proper status code
-	h.errorResponse(c, status, errType, message)
+	h.errorResponseWithCode(c, status, errType, code, message)
 }
 
 // errorResponse returns OpenAI API format error response
 func (h *OpenAIGatewayHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	h.errorResponseWithCode(c, status, errType, defaultErrorCode(errType), message)
+}
+
+// errorResponseWithCode 与 errorResponse 相同，但允许调用方显式指定 error.code
+func (h *OpenAIGatewayHandler) errorResponseWithCode(c *gin.Context, status int, errType, code, message string) {
 	c.JSON(status, gin.H{
 		"error": gin.H{
 			"type":    errType,
+			"code":    code,
 			"message": message,
 		},
 	})
@@ -32,4 +32,45 @@ const (
 	// SingleAccountRetry 标识当前请求处于单账号 503 退避重试模式。
 	// 在此模式下，Service 层的模型限流预检查将等待限流过期而非直接切换账号。
 	SingleAccountRetry Key = "ctx_single_account_retry"
+
+	// SelectionSeed 用于为 fallback 随机选号（shuffleWithinPriority）播种，
+	// 由 x-sub2api-selection-seed 请求头设置，仅在该特性启用时生效，便于回放/负载测试复现选号结果。
+	SelectionSeed Key = "ctx_selection_seed"
+
+	// APIKeyID 当前请求所使用的 API Key ID，由 API Key 认证中间件设置，
+	// 用于将粘性会话绑定登记到按 API Key 维度的索引中（供管理端查询/吊销）。
+	APIKeyID Key = "ctx_api_key_id"
+
+	// AnthropicBetaHeader 记录客户端请求携带的 anthropic-beta header 原始值，
+	// 用于匹配账号的短暂 feature 不兼容标记（见 service.featureIncompatCache）。
+	AnthropicBetaHeader Key = "ctx_anthropic_beta_header"
+
+	// GeminiSessionKey 记录 Gemini 原生 v1beta 请求的粘性会话 key，
+	// 用于按会话维度复用显式缓存内容（见 service.geminiCachedContentCache）。
+	GeminiSessionKey Key = "ctx_gemini_session_key"
+
+	// RequiresVision 标识当前请求的 messages 中包含图片内容块，账号选择阶段据此
+	// 过滤不支持视觉输入的账号（见 Account.SupportsVision）。
+	RequiresVision Key = "ctx_requires_vision"
+
+	// RequiresTools 标识当前请求声明了 tools，账号选择阶段据此过滤不支持工具调用
+	// 的账号（见 Account.SupportsTools）。
+	RequiresTools Key = "ctx_requires_tools"
+
+	// RequestIntent 记录客户端通过 x-sub2api-intent 请求头声明的请求意图（如 "coding"/"chat"），
+	// 由 handler 在校验通过后写入，账号选择阶段据此结合 Group.IntentRouting 限定候选账号子集。
+	RequestIntent Key = "ctx_request_intent"
+
+	// RequestUserID 记录当前请求所属的用户 ID，由 handler 在认证后写入，
+	// 供账号选择阶段的公平性调度使用（见 service.accountSelectionFairness）。
+	RequestUserID Key = "ctx_request_user_id"
+
+	// RequestBillingTag 记录客户端通过 x-sub2api-tag 请求头声明的计费标签（如 "project-a"/"feature-x"），
+	// 由 handler 在校验通过后写入，计费阶段据此写入 UsageLog.Tag，供按项目/功能维度聚合报表使用。
+	RequestBillingTag Key = "ctx_request_billing_tag"
+
+	// NoFailover 标识当前请求通过 x-sub2api-no-failover 请求头要求禁用账号故障转移，
+	// 由 handler 在校验通过后写入，失败转移循环据此在首个账号出错时直接返回该账号的错误，
+	// 便于管理员诊断单个账号的问题而不被自动切换掩盖。
+	NoFailover Key = "ctx_no_failover"
 )
@@ -78,6 +78,41 @@ type ModelStat struct {
 	ActualCost   float64 `json:"actual_cost"` // 实际扣除
 }
 
+// AccountStat represents usage statistics for a single account, used to inspect
+// how traffic distributed across accounts over a time range (e.g. to validate
+// that priority/weight scheduling settings produce the intended distribution).
+type AccountStat struct {
+	AccountID    int64   `json:"account_id"`
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	Cost         float64 `json:"cost"`        // 标准计费
+	ActualCost   float64 `json:"actual_cost"` // 实际扣除
+}
+
+// CacheSavingsStats represents the cost savings produced by prompt caching over a
+// time range: how much cache_read tokens actually cost vs. what they would have
+// cost if billed as regular input tokens. Used to justify sticky-session/caching
+// configuration to admins.
+type CacheSavingsStats struct {
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CacheReadCost       float64 `json:"cache_read_cost"`       // 实际按缓存读取价格计费的金额
+	EquivalentInputCost float64 `json:"equivalent_input_cost"` // 若按常规 input token 计费的等价金额
+	EstimatedSavings    float64 `json:"estimated_savings"`     // EquivalentInputCost - CacheReadCost
+}
+
+// TagStat represents usage statistics for a single billing tag (x-sub2api-tag)
+type TagStat struct {
+	Tag          string  `json:"tag"`
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	Cost         float64 `json:"cost"`        // 标准计费
+	ActualCost   float64 `json:"actual_cost"` // 实际扣除
+}
+
 // UserUsageTrendPoint represents user usage trend data point
 type UserUsageTrendPoint struct {
 	Date       string  `json:"date"`
@@ -139,6 +174,7 @@ type UsageLogFilters struct {
 	AccountID   int64
 	GroupID     int64
 	Model       string
+	Tag         string
 	Stream      *bool
 	BillingType *int8
 	StartTime   *time.Time
@@ -81,6 +81,8 @@ func (r *accountRepository) Create(ctx context.Context, account *service.Account
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
+		SetAffinityGroup(account.AffinityGroup).
+		SetMaxLineSize(account.MaxLineSize).
 		SetStatus(account.Status).
 		SetErrorMessage(account.ErrorMessage).
 		SetSchedulable(account.Schedulable).
@@ -117,6 +119,15 @@ func (r *accountRepository) Create(ctx context.Context, account *service.Account
 	if account.SessionWindowStatus != "" {
 		builder.SetSessionWindowStatus(account.SessionWindowStatus)
 	}
+	if account.SessionWindowUtilization != nil {
+		builder.SetSessionWindowUtilization(*account.SessionWindowUtilization)
+	}
+	if account.QuietHoursStartMinute != nil {
+		builder.SetQuietHoursStartMinute(*account.QuietHoursStartMinute)
+	}
+	if account.QuietHoursEndMinute != nil {
+		builder.SetQuietHoursEndMinute(*account.QuietHoursEndMinute)
+	}
 
 	created, err := builder.Save(ctx)
 	if err != nil {
@@ -324,6 +335,8 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
+		SetAffinityGroup(account.AffinityGroup).
+		SetMaxLineSize(account.MaxLineSize).
 		SetStatus(account.Status).
 		SetErrorMessage(account.ErrorMessage).
 		SetSchedulable(account.Schedulable).
@@ -378,6 +391,21 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 	} else {
 		builder.ClearSessionWindowStatus()
 	}
+	if account.SessionWindowUtilization != nil {
+		builder.SetSessionWindowUtilization(*account.SessionWindowUtilization)
+	} else {
+		builder.ClearSessionWindowUtilization()
+	}
+	if account.QuietHoursStartMinute != nil {
+		builder.SetQuietHoursStartMinute(*account.QuietHoursStartMinute)
+	} else {
+		builder.ClearQuietHoursStartMinute()
+	}
+	if account.QuietHoursEndMinute != nil {
+		builder.SetQuietHoursEndMinute(*account.QuietHoursEndMinute)
+	} else {
+		builder.ClearQuietHoursEndMinute()
+	}
 	if account.Notes == nil {
 		builder.ClearNotes()
 	}
@@ -636,6 +664,27 @@ func (r *accountRepository) AddToGroup(ctx context.Context, accountID, groupID i
 	return nil
 }
 
+// SetGroupReservedSlots 设置某个分组在账号上的预留并发槽位数（account_groups.reserved_slots）。
+// 预留槽位只能被该分组占用，其余部分由绑定该账号的所有分组共享，
+// 详见 service.ConcurrencyService.AcquireAccountSlotForGroup。
+func (r *accountRepository) SetGroupReservedSlots(ctx context.Context, accountID, groupID int64, reservedSlots int) error {
+	_, err := r.client.AccountGroup.Update().
+		Where(
+			dbaccountgroup.AccountIDEQ(accountID),
+			dbaccountgroup.GroupIDEQ(groupID),
+		).
+		SetReservedSlots(reservedSlots).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	payload := buildSchedulerGroupPayload([]int64{groupID})
+	if err := enqueueSchedulerOutbox(ctx, r.sql, service.SchedulerOutboxEventAccountGroupsChanged, &accountID, nil, payload); err != nil {
+		log.Printf("[SchedulerOutbox] enqueue set group reserved slots failed: account=%d group=%d err=%v", accountID, groupID, err)
+	}
+	return nil
+}
+
 func (r *accountRepository) RemoveFromGroup(ctx context.Context, accountID, groupID int64) error {
 	_, err := r.client.AccountGroup.Delete().
 		Where(
@@ -997,7 +1046,7 @@ func (r *accountRepository) ClearModelRateLimits(ctx context.Context, id int64)
 	return nil
 }
 
-func (r *accountRepository) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string) error {
+func (r *accountRepository) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string, utilization *int) error {
 	builder := r.client.Account.Update().
 		Where(dbaccount.IDEQ(id)).
 		SetSessionWindowStatus(status)
@@ -1007,6 +1056,9 @@ func (r *accountRepository) UpdateSessionWindow(ctx context.Context, id int64, s
 	if end != nil {
 		builder.SetSessionWindowEnd(*end)
 	}
+	if utilization != nil {
+		builder.SetSessionWindowUtilization(*utilization)
+	}
 	_, err := builder.Save(ctx)
 	if err != nil {
 		return err
@@ -1428,11 +1480,12 @@ func (r *accountRepository) loadAccountGroups(ctx context.Context, accountIDs []
 	for _, ag := range entries {
 		groupSvc := groupEntityToService(ag.Edges.Group)
 		agSvc := service.AccountGroup{
-			AccountID: ag.AccountID,
-			GroupID:   ag.GroupID,
-			Priority:  ag.Priority,
-			CreatedAt: ag.CreatedAt,
-			Group:     groupSvc,
+			AccountID:     ag.AccountID,
+			GroupID:       ag.GroupID,
+			Priority:      ag.Priority,
+			ReservedSlots: ag.ReservedSlots,
+			CreatedAt:     ag.CreatedAt,
+			Group:         groupSvc,
 		}
 		accountGroupsByAccount[ag.AccountID] = append(accountGroupsByAccount[ag.AccountID], agSvc)
 		groupIDsByAccount[ag.AccountID] = append(groupIDsByAccount[ag.AccountID], ag.GroupID)
@@ -1500,31 +1553,36 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 	rateMultiplier := m.RateMultiplier
 
 	return &service.Account{
-		ID:                  m.ID,
-		Name:                m.Name,
-		Notes:               m.Notes,
-		Platform:            m.Platform,
-		Type:                m.Type,
-		Credentials:         copyJSONMap(m.Credentials),
-		Extra:               copyJSONMap(m.Extra),
-		ProxyID:             m.ProxyID,
-		Concurrency:         m.Concurrency,
-		Priority:            m.Priority,
-		RateMultiplier:      &rateMultiplier,
-		Status:              m.Status,
-		ErrorMessage:        derefString(m.ErrorMessage),
-		LastUsedAt:          m.LastUsedAt,
-		ExpiresAt:           m.ExpiresAt,
-		AutoPauseOnExpired:  m.AutoPauseOnExpired,
-		CreatedAt:           m.CreatedAt,
-		UpdatedAt:           m.UpdatedAt,
-		Schedulable:         m.Schedulable,
-		RateLimitedAt:       m.RateLimitedAt,
-		RateLimitResetAt:    m.RateLimitResetAt,
-		OverloadUntil:       m.OverloadUntil,
-		SessionWindowStart:  m.SessionWindowStart,
-		SessionWindowEnd:    m.SessionWindowEnd,
-		SessionWindowStatus: derefString(m.SessionWindowStatus),
+		ID:                       m.ID,
+		Name:                     m.Name,
+		Notes:                    m.Notes,
+		Platform:                 m.Platform,
+		Type:                     m.Type,
+		Credentials:              copyJSONMap(m.Credentials),
+		Extra:                    copyJSONMap(m.Extra),
+		ProxyID:                  m.ProxyID,
+		Concurrency:              m.Concurrency,
+		Priority:                 m.Priority,
+		AffinityGroup:            m.AffinityGroup,
+		MaxLineSize:              m.MaxLineSize,
+		RateMultiplier:           &rateMultiplier,
+		Status:                   m.Status,
+		ErrorMessage:             derefString(m.ErrorMessage),
+		LastUsedAt:               m.LastUsedAt,
+		ExpiresAt:                m.ExpiresAt,
+		AutoPauseOnExpired:       m.AutoPauseOnExpired,
+		CreatedAt:                m.CreatedAt,
+		UpdatedAt:                m.UpdatedAt,
+		Schedulable:              m.Schedulable,
+		RateLimitedAt:            m.RateLimitedAt,
+		RateLimitResetAt:         m.RateLimitResetAt,
+		OverloadUntil:            m.OverloadUntil,
+		SessionWindowStart:       m.SessionWindowStart,
+		SessionWindowEnd:         m.SessionWindowEnd,
+		SessionWindowStatus:      derefString(m.SessionWindowStatus),
+		SessionWindowUtilization: m.SessionWindowUtilization,
+		QuietHoursStartMinute:    m.QuietHoursStartMinute,
+		QuietHoursEndMinute:      m.QuietHoursEndMinute,
 	}
 }
 
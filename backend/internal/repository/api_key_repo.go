@@ -452,6 +452,7 @@ func userEntityToService(u *dbent.User) *service.User {
 		TotpSecretEncrypted: u.TotpSecretEncrypted,
 		TotpEnabled:         u.TotpEnabled,
 		TotpEnabledAt:       u.TotpEnabledAt,
+		ModelMapping:        u.ModelMapping,
 		CreatedAt:           u.CreatedAt,
 		UpdatedAt:           u.UpdatedAt,
 	}
@@ -462,32 +463,46 @@ func groupEntityToService(g *dbent.Group) *service.Group {
 		return nil
 	}
 	return &service.Group{
-		ID:                              g.ID,
-		Name:                            g.Name,
-		Description:                     derefString(g.Description),
-		Platform:                        g.Platform,
-		RateMultiplier:                  g.RateMultiplier,
-		IsExclusive:                     g.IsExclusive,
-		Status:                          g.Status,
-		Hydrated:                        true,
-		SubscriptionType:                g.SubscriptionType,
-		DailyLimitUSD:                   g.DailyLimitUsd,
-		WeeklyLimitUSD:                  g.WeeklyLimitUsd,
-		MonthlyLimitUSD:                 g.MonthlyLimitUsd,
-		ImagePrice1K:                    g.ImagePrice1k,
-		ImagePrice2K:                    g.ImagePrice2k,
-		ImagePrice4K:                    g.ImagePrice4k,
-		DefaultValidityDays:             g.DefaultValidityDays,
-		ClaudeCodeOnly:                  g.ClaudeCodeOnly,
-		FallbackGroupID:                 g.FallbackGroupID,
-		FallbackGroupIDOnInvalidRequest: g.FallbackGroupIDOnInvalidRequest,
-		ModelRouting:                    g.ModelRouting,
-		ModelRoutingEnabled:             g.ModelRoutingEnabled,
-		MCPXMLInject:                    g.McpXMLInject,
-		SupportedModelScopes:            g.SupportedModelScopes,
-		SortOrder:                       g.SortOrder,
-		CreatedAt:                       g.CreatedAt,
-		UpdatedAt:                       g.UpdatedAt,
+		ID:                                  g.ID,
+		Name:                                g.Name,
+		Description:                         derefString(g.Description),
+		Platform:                            g.Platform,
+		RateMultiplier:                      g.RateMultiplier,
+		IsExclusive:                         g.IsExclusive,
+		Status:                              g.Status,
+		Hydrated:                            true,
+		SubscriptionType:                    g.SubscriptionType,
+		Currency:                            g.Currency,
+		DailyLimitUSD:                       g.DailyLimitUsd,
+		WeeklyLimitUSD:                      g.WeeklyLimitUsd,
+		MonthlyLimitUSD:                     g.MonthlyLimitUsd,
+		ImagePrice1K:                        g.ImagePrice1k,
+		ImagePrice2K:                        g.ImagePrice2k,
+		ImagePrice4K:                        g.ImagePrice4k,
+		DefaultValidityDays:                 g.DefaultValidityDays,
+		ClaudeCodeOnly:                      g.ClaudeCodeOnly,
+		FallbackGroupID:                     g.FallbackGroupID,
+		FallbackGroupIDOnInvalidRequest:     g.FallbackGroupIDOnInvalidRequest,
+		ModelRouting:                        g.ModelRouting,
+		ModelRoutingEnabled:                 g.ModelRoutingEnabled,
+		MCPXMLInject:                        g.McpXMLInject,
+		SupportedModelScopes:                g.SupportedModelScopes,
+		SortOrder:                           g.SortOrder,
+		DisableMetadataRewrite:              g.DisableMetadataRewrite,
+		MaxMessages:                         g.MaxMessages,
+		DailyRequestLimit:                   g.DailyRequestLimit,
+		MaxOutputTokens:                     g.MaxOutputTokens,
+		UpstreamHeaders:                     g.UpstreamHeaders,
+		IntentRouting:                       g.IntentRouting,
+		IntentRoutingEnabled:                g.IntentRoutingEnabled,
+		SubscriptionOverflowPolicy:          g.SubscriptionOverflowPolicy,
+		AllowedEndpoints:                    g.AllowedEndpoints,
+		RequireAnthropicVersion:             g.RequireAnthropicVersion,
+		MixedSchedulingNativeSaturationOnly: g.MixedSchedulingNativeSaturationOnly,
+		WindowCostLimitUSD:                  g.WindowCostLimitUsd,
+		WindowCostWindowHours:               g.WindowCostWindowHours,
+		CreatedAt:                           g.CreatedAt,
+		UpdatedAt:                           g.UpdatedAt,
 	}
 }
 
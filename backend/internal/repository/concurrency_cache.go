@@ -27,10 +27,14 @@ const (
 	accountSlotKeyPrefix = "concurrency:account:"
 	// 格式: concurrency:user:{userID}
 	userSlotKeyPrefix = "concurrency:user:"
+	// 用户流式连接槽位（与普通并发槽位独立计数），格式: concurrency:user_stream:{userID}
+	userStreamSlotKeyPrefix = "concurrency:user_stream:"
 	// 等待队列计数器格式: concurrency:wait:{userID}
 	waitQueueKeyPrefix = "concurrency:wait:"
 	// 账号级等待队列计数器格式: wait:account:{accountID}
 	accountWaitKeyPrefix = "wait:account:"
+	// 亲和分组并发借用计数（有序集合，成员为 requestID），格式: concurrency:borrow:{accountID}
+	accountBorrowKeyPrefix = "concurrency:borrow:"
 
 	// 默认槽位过期时间（分钟），可通过配置覆盖
 	defaultSlotTTLMinutes = 15
@@ -76,6 +80,99 @@ var (
 		return 0
 	`)
 
+	// acquireBorrowedSlotScript 亲和分组并发借用：原子地检查出借账号是否有空闲槽位、
+	// 借用账号是否仍在借用上限内，两者皆满足时一并写入两个有序集合
+	// KEYS[1] = 出借账号槽位键 (concurrency:account:{lenderID})
+	// KEYS[2] = 借用账号的借用计数键 (concurrency:borrow:{borrowerID})
+	// ARGV[1] = lenderMaxConcurrency
+	// ARGV[2] = borrowCap
+	// ARGV[3] = TTL（秒）
+	// ARGV[4] = requestID
+	acquireBorrowedSlotScript = redis.NewScript(`
+		local lenderKey = KEYS[1]
+		local borrowKey = KEYS[2]
+		local lenderMaxConcurrency = tonumber(ARGV[1])
+		local borrowCap = tonumber(ARGV[2])
+		local ttl = tonumber(ARGV[3])
+		local requestID = ARGV[4]
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1])
+		local expireBefore = now - ttl
+
+		redis.call('ZREMRANGEBYSCORE', lenderKey, '-inf', expireBefore)
+		redis.call('ZREMRANGEBYSCORE', borrowKey, '-inf', expireBefore)
+
+		if redis.call('ZCARD', lenderKey) >= lenderMaxConcurrency then
+			return 0
+		end
+		if redis.call('ZCARD', borrowKey) >= borrowCap then
+			return 0
+		end
+
+		redis.call('ZADD', lenderKey, now, requestID)
+		redis.call('EXPIRE', lenderKey, ttl)
+		redis.call('ZADD', borrowKey, now, requestID)
+		redis.call('EXPIRE', borrowKey, ttl)
+		return 1
+	`)
+
+	// acquireReservedSlotScript 分组预留槽位的原子获取：账号为某分组预留了专属槽位时，该分组
+	// 在未用满自己的预留份额前，只需账号全局槽位未达硬上限（ARGV[1]）即可获取，不受其它分组
+	// 预留份额的影响；用满自己的预留份额后，超出部分与非预留分组一样，受共享上限
+	// （ARGV[3] = maxConcurrency - 其它分组预留槽位之和）约束。全局键与分组自身键在同一脚本内
+	// 原子更新，避免一个预留分组用满自身份额后，挤占另一个预留分组的保证容量。
+	// KEYS[1] = 账号全局槽位键 (concurrency:account:{accountID})
+	// KEYS[2] = 分组自身槽位键 (concurrency:account:{accountID}:group:{groupID})
+	// ARGV[1] = maxConcurrency（账号硬上限）
+	// ARGV[2] = reservedSlots（本分组的预留份额）
+	// ARGV[3] = effectiveMaxConcurrency（用满预留份额后，可使用的共享上限）
+	// ARGV[4] = TTL（秒）
+	// ARGV[5] = requestID
+	acquireReservedSlotScript = redis.NewScript(`
+		local globalKey = KEYS[1]
+		local groupKey = KEYS[2]
+		local maxConcurrency = tonumber(ARGV[1])
+		local reservedSlots = tonumber(ARGV[2])
+		local effectiveMaxConcurrency = tonumber(ARGV[3])
+		local ttl = tonumber(ARGV[4])
+		local requestID = ARGV[5]
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1])
+		local expireBefore = now - ttl
+
+		redis.call('ZREMRANGEBYSCORE', globalKey, '-inf', expireBefore)
+		redis.call('ZREMRANGEBYSCORE', groupKey, '-inf', expireBefore)
+
+		-- 支持重试场景刷新时间戳
+		local exists = redis.call('ZSCORE', globalKey, requestID)
+		if exists ~= false then
+			redis.call('ZADD', globalKey, now, requestID)
+			redis.call('EXPIRE', globalKey, ttl)
+			redis.call('ZADD', groupKey, now, requestID)
+			redis.call('EXPIRE', groupKey, ttl)
+			return 1
+		end
+
+		local ownCount = redis.call('ZCARD', groupKey)
+		local globalCount = redis.call('ZCARD', globalKey)
+		local limit = effectiveMaxConcurrency
+		if ownCount < reservedSlots then
+			limit = maxConcurrency
+		end
+
+		if globalCount < limit then
+			redis.call('ZADD', globalKey, now, requestID)
+			redis.call('EXPIRE', globalKey, ttl)
+			redis.call('ZADD', groupKey, now, requestID)
+			redis.call('EXPIRE', groupKey, ttl)
+			return 1
+		end
+
+		return 0
+	`)
+
 	// getCountScript 统计有序集合中的槽位数量并清理过期条目
 	// 使用 Redis TIME 命令获取服务器时间
 	// KEYS[1] = 有序集合键
@@ -286,6 +383,10 @@ func userSlotKey(userID int64) string {
 	return fmt.Sprintf("%s%d", userSlotKeyPrefix, userID)
 }
 
+func userStreamSlotKey(userID int64) string {
+	return fmt.Sprintf("%s%d", userStreamSlotKeyPrefix, userID)
+}
+
 func waitQueueKey(userID int64) string {
 	return fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
 }
@@ -294,6 +395,14 @@ func accountWaitKey(accountID int64) string {
 	return fmt.Sprintf("%s%d", accountWaitKeyPrefix, accountID)
 }
 
+func accountBorrowKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", accountBorrowKeyPrefix, accountID)
+}
+
+func accountGroupSlotKey(accountID int64, groupID int64) string {
+	return fmt.Sprintf("%s%d:group:%d", accountSlotKeyPrefix, accountID, groupID)
+}
+
 // Account slot operations
 
 func (c *concurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
@@ -321,6 +430,40 @@ func (c *concurrencyCache) GetAccountConcurrency(ctx context.Context, accountID
 	return result, nil
 }
 
+func (c *concurrencyCache) AcquireBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, lenderMaxConcurrency int, borrowerAccountID int64, borrowCap int, requestID string) (bool, error) {
+	keys := []string{accountSlotKey(lenderAccountID), accountBorrowKey(borrowerAccountID)}
+	result, err := acquireBorrowedSlotScript.Run(ctx, c.rdb, keys, lenderMaxConcurrency, borrowCap, c.slotTTLSeconds, requestID).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+func (c *concurrencyCache) ReleaseBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, borrowerAccountID int64, requestID string) error {
+	pipe := c.rdb.Pipeline()
+	pipe.ZRem(ctx, accountSlotKey(lenderAccountID), requestID)
+	pipe.ZRem(ctx, accountBorrowKey(borrowerAccountID), requestID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *concurrencyCache) AcquireReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, maxConcurrency int, reservedSlots int, effectiveMaxConcurrency int, requestID string) (bool, error) {
+	keys := []string{accountSlotKey(accountID), accountGroupSlotKey(accountID, groupID)}
+	result, err := acquireReservedSlotScript.Run(ctx, c.rdb, keys, maxConcurrency, reservedSlots, effectiveMaxConcurrency, c.slotTTLSeconds, requestID).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+func (c *concurrencyCache) ReleaseReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, requestID string) error {
+	pipe := c.rdb.Pipeline()
+	pipe.ZRem(ctx, accountSlotKey(accountID), requestID)
+	pipe.ZRem(ctx, accountGroupSlotKey(accountID, groupID), requestID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // User slot operations
 
 func (c *concurrencyCache) AcquireUserSlot(ctx context.Context, userID int64, maxConcurrency int, requestID string) (bool, error) {
@@ -348,6 +491,23 @@ func (c *concurrencyCache) GetUserConcurrency(ctx context.Context, userID int64)
 	return result, nil
 }
 
+// User stream slot operations (counted independently from the general user slot)
+
+func (c *concurrencyCache) AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int, requestID string) (bool, error) {
+	key := userStreamSlotKey(userID)
+	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取，确保多实例时钟一致
+	result, err := acquireScript.Run(ctx, c.rdb, []string{key}, maxConcurrentStreams, c.slotTTLSeconds, requestID).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+func (c *concurrencyCache) ReleaseUserStreamSlot(ctx context.Context, userID int64, requestID string) error {
+	key := userStreamSlotKey(userID)
+	return c.rdb.ZRem(ctx, key, requestID).Err()
+}
+
 // Wait queue operations
 
 func (c *concurrencyCache) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
@@ -468,8 +628,11 @@ func (c *concurrencyCache) GetUsersLoadBatch(ctx context.Context, users []servic
 	return loadMap, nil
 }
 
-func (c *concurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error {
+func (c *concurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) (int, error) {
 	key := accountSlotKey(accountID)
-	_, err := cleanupExpiredSlotsScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Result()
-	return err
+	reclaimed, err := cleanupExpiredSlotsScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Int()
+	if err != nil {
+		return 0, err
+	}
+	return reclaimed, nil
 }
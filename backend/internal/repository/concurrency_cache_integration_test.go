@@ -371,8 +371,9 @@ func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots() {
 	require.NoError(s.T(), err)
 
 	// Run cleanup
-	err = s.cache.CleanupExpiredAccountSlots(s.ctx, accountID)
+	reclaimed, err := s.cache.CleanupExpiredAccountSlots(s.ctx, accountID)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, reclaimed)
 
 	// Verify only 1 slot remains (req3)
 	cur, err = s.cache.GetAccountConcurrency(s.ctx, accountID)
@@ -398,8 +399,9 @@ func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots_NoExpired() {
 	require.True(s.T(), ok)
 
 	// Run cleanup (should not remove anything)
-	err = s.cache.CleanupExpiredAccountSlots(s.ctx, accountID)
+	reclaimed, err := s.cache.CleanupExpiredAccountSlots(s.ctx, accountID)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, reclaimed)
 
 	// Verify both slots still exist
 	cur, err := s.cache.GetAccountConcurrency(s.ctx, accountID)
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const countTokensCacheKeyPrefix = "count_tokens:result:"
+
+func countTokensCacheKey(key string) string {
+	return countTokensCacheKeyPrefix + key
+}
+
+type countTokensCache struct {
+	rdb *redis.Client
+}
+
+func NewCountTokensCache(rdb *redis.Client) service.CountTokensCache {
+	return &countTokensCache{rdb: rdb}
+}
+
+func (c *countTokensCache) GetCountTokensResult(ctx context.Context, key string) (*service.CountTokensCacheEntry, error) {
+	data, err := c.rdb.Get(ctx, countTokensCacheKey(key)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, service.ErrCountTokensCacheMiss
+		}
+		return nil, err
+	}
+
+	var entry service.CountTokensCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, service.ErrCountTokensCacheMiss
+	}
+	return &entry, nil
+}
+
+func (c *countTokensCache) SetCountTokensResult(ctx context.Context, key string, entry *service.CountTokensCacheEntry, ttl time.Duration) error {
+	if entry == nil {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, countTokensCacheKey(key), data, ttl).Err()
+}
@@ -0,0 +1,59 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CountTokensCacheSuite struct {
+	IntegrationRedisSuite
+	cache service.CountTokensCache
+}
+
+func (s *CountTokensCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewCountTokensCache(s.rdb)
+}
+
+func (s *CountTokensCacheSuite) TestGetCountTokensResult_Missing() {
+	_, err := s.cache.GetCountTokensResult(s.ctx, "nonexistent")
+	require.True(s.T(), errors.Is(err, service.ErrCountTokensCacheMiss), "expected ErrCountTokensCacheMiss for missing key")
+}
+
+func (s *CountTokensCacheSuite) TestSetAndGetCountTokensResult() {
+	key := "claude-3-5-sonnet:abc123"
+	entry := &service.CountTokensCacheEntry{StatusCode: 200, Body: []byte(`{"input_tokens":42}`)}
+
+	require.NoError(s.T(), s.cache.SetCountTokensResult(s.ctx, key, entry, 1*time.Minute), "SetCountTokensResult")
+
+	got, err := s.cache.GetCountTokensResult(s.ctx, key)
+	require.NoError(s.T(), err, "GetCountTokensResult")
+	require.Equal(s.T(), entry.StatusCode, got.StatusCode)
+	require.Equal(s.T(), entry.Body, got.Body)
+}
+
+func (s *CountTokensCacheSuite) TestCountTokensResult_TTLExpiry() {
+	key := "claude-3-5-sonnet:expiring"
+	entry := &service.CountTokensCacheEntry{StatusCode: 200, Body: []byte(`{"input_tokens":7}`)}
+
+	require.NoError(s.T(), s.cache.SetCountTokensResult(s.ctx, key, entry, 50*time.Millisecond), "SetCountTokensResult")
+
+	_, err := s.cache.GetCountTokensResult(s.ctx, key)
+	require.NoError(s.T(), err, "expected cache hit before TTL expiry")
+
+	time.Sleep(150 * time.Millisecond)
+
+	_, err = s.cache.GetCountTokensResult(s.ctx, key)
+	require.True(s.T(), errors.Is(err, service.ErrCountTokensCacheMiss), "expected ErrCountTokensCacheMiss after TTL expiry")
+}
+
+func TestCountTokensCacheSuite(t *testing.T) {
+	suite.Run(t, new(CountTokensCacheSuite))
+}
@@ -3,41 +3,143 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/sessionbinding"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/redis/go-redis/v9"
 )
 
-const stickySessionPrefix = "sticky_session:"
+const (
+	stickySessionPrefix = "sticky_session:"
+	// stickySessionAPIKeyIndexPrefix 按 API Key 维度索引粘性会话的 Redis Set key 前缀
+	// 格式: sticky_session_idx:apikey:{apiKeyID}，member 为 "{groupID}:{sessionHash}"
+	stickySessionAPIKeyIndexPrefix = "sticky_session_idx:apikey:"
+)
 
 type gatewayCache struct {
 	rdb *redis.Client
+
+	// entClient 非 nil 且 persistEnabled 为 true 时，GetSessionAccountID 在 Redis 未命中时
+	// 会回落到 session_bindings 表，SetSessionAccountID 会同步写穿，
+	// 使长时间运行的会话不因缓存重启而中途切换账号。由 gateway.scheduling.persist_sticky_sessions 控制。
+	entClient      *dbent.Client
+	persistEnabled bool
 }
 
 func NewGatewayCache(rdb *redis.Client) service.GatewayCache {
 	return &gatewayCache{rdb: rdb}
 }
 
+// NewPersistentGatewayCache 创建带 session_bindings 写穿持久化的粘性会话缓存。
+func NewPersistentGatewayCache(rdb *redis.Client, entClient *dbent.Client, persistEnabled bool) service.GatewayCache {
+	return &gatewayCache{rdb: rdb, entClient: entClient, persistEnabled: persistEnabled}
+}
+
 // buildSessionKey 构建 session key，包含 groupID 实现分组隔离
 // 格式: sticky_session:{groupID}:{sessionHash}
 func buildSessionKey(groupID int64, sessionHash string) string {
 	return fmt.Sprintf("%s%d:%s", stickySessionPrefix, groupID, sessionHash)
 }
 
+// buildAPIKeyIndexKey 构建按 API Key 索引粘性会话的 Redis Set key
+func buildAPIKeyIndexKey(apiKeyID int64) string {
+	return fmt.Sprintf("%s%d", stickySessionAPIKeyIndexPrefix, apiKeyID)
+}
+
+// encodeSessionMember / decodeSessionMember 在 "{groupID}:{sessionHash}" 与其字段间转换，
+// 作为 API Key 索引 Set 的 member 格式
+func encodeSessionMember(groupID int64, sessionHash string) string {
+	return fmt.Sprintf("%d:%s", groupID, sessionHash)
+}
+
+func decodeSessionMember(member string) (groupID int64, sessionHash string, ok bool) {
+	idx := strings.Index(member, ":")
+	if idx < 0 {
+		return 0, "", false
+	}
+	groupID, err := strconv.ParseInt(member[:idx], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return groupID, member[idx+1:], true
+}
+
 func (c *gatewayCache) GetSessionAccountID(ctx context.Context, groupID int64, sessionHash string) (int64, error) {
 	key := buildSessionKey(groupID, sessionHash)
-	return c.rdb.Get(ctx, key).Int64()
+	accountID, err := c.rdb.Get(ctx, key).Int64()
+	if err == nil {
+		return accountID, nil
+	}
+	if err != redis.Nil || !c.persistEnabled || c.entClient == nil {
+		return 0, err
+	}
+
+	binding, dbErr := c.entClient.SessionBinding.Query().
+		Where(
+			sessionbinding.GroupIDEQ(groupID),
+			sessionbinding.SessionHashEQ(sessionHash),
+			sessionbinding.ExpiresAtGT(time.Now()),
+		).
+		Only(ctx)
+	if dbErr != nil {
+		// 未命中持久化表，保留 Redis 的原始 miss 错误语义
+		return 0, err
+	}
+
+	// 回填 Redis，剩余 TTL 按数据库记录的过期时间计算
+	if ttl := time.Until(binding.ExpiresAt); ttl > 0 {
+		_ = c.rdb.Set(ctx, key, binding.AccountID, ttl).Err()
+	}
+	return binding.AccountID, nil
 }
 
 func (c *gatewayCache) SetSessionAccountID(ctx context.Context, groupID int64, sessionHash string, accountID int64, ttl time.Duration) error {
 	key := buildSessionKey(groupID, sessionHash)
-	return c.rdb.Set(ctx, key, accountID, ttl).Err()
+	if err := c.rdb.Set(ctx, key, accountID, ttl).Err(); err != nil {
+		return err
+	}
+
+	if !c.persistEnabled || c.entClient == nil {
+		return nil
+	}
+
+	err := c.entClient.SessionBinding.Create().
+		SetGroupID(groupID).
+		SetSessionHash(sessionHash).
+		SetAccountID(accountID).
+		SetExpiresAt(time.Now().Add(ttl)).
+		OnConflictColumns(sessionbinding.FieldGroupID, sessionbinding.FieldSessionHash).
+		UpdateNewValues().
+		Exec(ctx)
+	if err != nil {
+		// 写穿持久化失败不影响 Redis 已生效的绑定，仅记录错误交由调用方日志体系处理
+		return err
+	}
+	return nil
 }
 
 func (c *gatewayCache) RefreshSessionTTL(ctx context.Context, groupID int64, sessionHash string, ttl time.Duration) error {
 	key := buildSessionKey(groupID, sessionHash)
-	return c.rdb.Expire(ctx, key, ttl).Err()
+	if err := c.rdb.Expire(ctx, key, ttl).Err(); err != nil {
+		return err
+	}
+
+	if !c.persistEnabled || c.entClient == nil {
+		return nil
+	}
+
+	return c.entClient.SessionBinding.Update().
+		Where(
+			sessionbinding.GroupIDEQ(groupID),
+			sessionbinding.SessionHashEQ(sessionHash),
+		).
+		SetExpiresAt(time.Now().Add(ttl)).
+		Exec(ctx)
 }
 
 // DeleteSessionAccountID 删除粘性会话与账号的绑定关系。
@@ -49,5 +151,107 @@ func (c *gatewayCache) RefreshSessionTTL(ctx context.Context, groupID int64, ses
 // or unschedulable), allowing subsequent requests to select a new available account.
 func (c *gatewayCache) DeleteSessionAccountID(ctx context.Context, groupID int64, sessionHash string) error {
 	key := buildSessionKey(groupID, sessionHash)
+	if err := c.rdb.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+
+	if !c.persistEnabled || c.entClient == nil {
+		return nil
+	}
+
+	_, err := c.entClient.SessionBinding.Delete().
+		Where(
+			sessionbinding.GroupIDEQ(groupID),
+			sessionbinding.SessionHashEQ(sessionHash),
+		).
+		Exec(ctx)
+	return err
+}
+
+// IndexSessionForAPIKey 将粘性会话登记到按 API Key 索引的 Set 中，TTL 与会话绑定保持一致，
+// 使索引能随会话一同过期，无需单独清理。
+func (c *gatewayCache) IndexSessionForAPIKey(ctx context.Context, apiKeyID, groupID int64, sessionHash string, ttl time.Duration) error {
+	key := buildAPIKeyIndexKey(apiKeyID)
+	member := encodeSessionMember(groupID, sessionHash)
+	pipe := c.rdb.Pipeline()
+	pipe.SAdd(ctx, key, member)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListSessionsByAPIKey 列出某个 API Key 当前登记的粘性会话绑定。
+// 索引中已过期的成员（绑定已被 TTL 回收）会被顺带清理，不计入结果。
+func (c *gatewayCache) ListSessionsByAPIKey(ctx context.Context, apiKeyID int64) ([]service.StickySession, error) {
+	key := buildAPIKeyIndexKey(apiKeyID)
+	members, err := c.rdb.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]service.StickySession, 0, len(members))
+	for _, member := range members {
+		groupID, sessionHash, ok := decodeSessionMember(member)
+		if !ok {
+			continue
+		}
+		accountID, err := c.GetSessionAccountID(ctx, groupID, sessionHash)
+		if err != nil {
+			// 绑定已过期或不存在，顺带从索引中移除
+			_ = c.rdb.SRem(ctx, key, member).Err()
+			continue
+		}
+		sessions = append(sessions, service.StickySession{
+			GroupID:     groupID,
+			SessionHash: sessionHash,
+			AccountID:   accountID,
+		})
+	}
+	return sessions, nil
+}
+
+// DeleteSessionsByAPIKey 吊销某个 API Key 名下全部粘性会话绑定
+func (c *gatewayCache) DeleteSessionsByAPIKey(ctx context.Context, apiKeyID int64) error {
+	key := buildAPIKeyIndexKey(apiKeyID)
+	members, err := c.rdb.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		groupID, sessionHash, ok := decodeSessionMember(member)
+		if !ok {
+			continue
+		}
+		_ = c.DeleteSessionAccountID(ctx, groupID, sessionHash)
+	}
+
 	return c.rdb.Del(ctx, key).Err()
 }
+
+// StartSessionBindingCleanupWorker 启动后台任务，周期性清理 session_bindings 表中
+// 已过期的绑定记录。Redis 中的绑定依赖自身 TTL 过期，但持久化表没有 TTL 机制，
+// 需要定期删除，避免 session_hash 重复使用时与历史过期记录产生冲突判断上的歧义。
+func (c *gatewayCache) StartSessionBindingCleanupWorker(interval time.Duration) {
+	if c == nil || !c.persistEnabled || c.entClient == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			deleted, err := c.entClient.SessionBinding.Delete().
+				Where(sessionbinding.ExpiresAtLT(time.Now())).
+				Exec(cleanupCtx)
+			cancel()
+			if err != nil {
+				log.Printf("Warning: cleanup expired session bindings failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Cleaned up %d expired session binding(s)", deleted)
+			}
+		}
+	}()
+}
@@ -0,0 +1,87 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// GatewayCachePersistenceSuite exercises the optional session_bindings write-through,
+// combining a Redis client (for the cache layer under test) with a transactional Ent
+// client (for the persisted fallback table) so each test rolls back independently.
+type GatewayCachePersistenceSuite struct {
+	suite.Suite
+	ctx   context.Context
+	rdb   *redis.Client
+	tx    *dbent.Tx
+	cache *gatewayCache
+}
+
+func (s *GatewayCachePersistenceSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.rdb = testRedis(s.T())
+	s.tx = testEntTx(s.T())
+	s.cache = &gatewayCache{rdb: s.rdb, entClient: s.tx.Client(), persistEnabled: true}
+}
+
+func (s *GatewayCachePersistenceSuite) TestGetSessionAccountID_FallsBackToDBOnCacheMiss() {
+	groupID := int64(1)
+	sessionHash := "persisted-session"
+	accountID := int64(7)
+
+	require.NoError(s.T(), s.cache.SetSessionAccountID(s.ctx, groupID, sessionHash, accountID, time.Minute), "SetSessionAccountID")
+
+	// Simulate a cache flush/restart: the Redis key is gone, but the DB row survives.
+	require.NoError(s.T(), s.rdb.Del(s.ctx, buildSessionKey(groupID, sessionHash)).Err(), "Del redis key")
+
+	got, err := s.cache.GetSessionAccountID(s.ctx, groupID, sessionHash)
+	require.NoError(s.T(), err, "GetSessionAccountID should fall back to session_bindings")
+	require.Equal(s.T(), accountID, got)
+
+	// The fallback should have repopulated Redis so subsequent reads skip the DB.
+	repopulated, err := s.rdb.Get(s.ctx, buildSessionKey(groupID, sessionHash)).Int64()
+	require.NoError(s.T(), err, "Redis key should be repopulated after DB fallback")
+	require.Equal(s.T(), accountID, repopulated)
+}
+
+func (s *GatewayCachePersistenceSuite) TestGetSessionAccountID_MissingEverywhere() {
+	_, err := s.cache.GetSessionAccountID(s.ctx, 1, "nonexistent")
+	require.True(s.T(), errors.Is(err, redis.Nil), "expected redis.Nil when absent from both cache and DB")
+}
+
+func (s *GatewayCachePersistenceSuite) TestSetSessionAccountID_UpsertsExistingBinding() {
+	groupID := int64(2)
+	sessionHash := "rebinding-session"
+
+	require.NoError(s.T(), s.cache.SetSessionAccountID(s.ctx, groupID, sessionHash, 10, time.Minute), "initial SetSessionAccountID")
+	require.NoError(s.T(), s.cache.SetSessionAccountID(s.ctx, groupID, sessionHash, 11, time.Minute), "rebinding SetSessionAccountID")
+
+	require.NoError(s.T(), s.rdb.Del(s.ctx, buildSessionKey(groupID, sessionHash)).Err(), "Del redis key")
+
+	got, err := s.cache.GetSessionAccountID(s.ctx, groupID, sessionHash)
+	require.NoError(s.T(), err, "GetSessionAccountID after rebinding")
+	require.Equal(s.T(), int64(11), got, "DB fallback should reflect the latest binding, not a duplicate row")
+}
+
+func (s *GatewayCachePersistenceSuite) TestDeleteSessionAccountID_RemovesPersistedBinding() {
+	groupID := int64(3)
+	sessionHash := "deleted-session"
+
+	require.NoError(s.T(), s.cache.SetSessionAccountID(s.ctx, groupID, sessionHash, 99, time.Minute), "SetSessionAccountID")
+	require.NoError(s.T(), s.cache.DeleteSessionAccountID(s.ctx, groupID, sessionHash), "DeleteSessionAccountID")
+
+	_, err := s.cache.GetSessionAccountID(s.ctx, groupID, sessionHash)
+	require.True(s.T(), errors.Is(err, redis.Nil), "expected redis.Nil after delete removes both cache and DB rows")
+}
+
+func TestGatewayCachePersistenceSuite(t *testing.T) {
+	suite.Run(t, new(GatewayCachePersistenceSuite))
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type geminiImageFetcher struct {
+	httpClient *http.Client
+}
+
+// NewGeminiImageFetcher 创建 Gemini url 类型图片拉取客户端
+// proxyURL 为空时直连，支持 http/https/socks5/socks5h 协议
+// urlAllowlistEnabled/allowPrivateHosts 来自 Security.URLAllowlist 配置，开启时在连接层
+// 校验 DNS 解析后的真实 IP，防止攻击者通过 DNS rebinding 绕过 URL 形式上的校验访问内网地址
+func NewGeminiImageFetcher(proxyURL string, urlAllowlistEnabled, allowPrivateHosts bool) service.GeminiImageFetcher {
+	sharedClient, err := httpclient.GetClient(httpclient.Options{
+		Timeout:            30 * time.Second,
+		ProxyURL:           proxyURL,
+		ValidateResolvedIP: urlAllowlistEnabled,
+		AllowPrivateHosts:  allowPrivateHosts,
+	})
+	if err != nil {
+		sharedClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &geminiImageFetcher{
+		httpClient: sharedClient,
+	}
+}
+
+func (c *geminiImageFetcher) FetchImage(ctx context.Context, url string, maxBytes int64) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	if maxBytes > 0 && resp.ContentLength > maxBytes {
+		return nil, "", fmt.Errorf("image exceeds max size of %d bytes", maxBytes)
+	}
+
+	reader := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		reader = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, "", fmt.Errorf("image exceeds max size of %d bytes", maxBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	return data, contentType, nil
+}
@@ -41,6 +41,8 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 		SetIsExclusive(groupIn.IsExclusive).
 		SetStatus(groupIn.Status).
 		SetSubscriptionType(groupIn.SubscriptionType).
+		SetSubscriptionOverflowPolicy(groupIn.SubscriptionOverflowPolicy).
+		SetCurrency(groupIn.Currency).
 		SetNillableDailyLimitUsd(groupIn.DailyLimitUSD).
 		SetNillableWeeklyLimitUsd(groupIn.WeeklyLimitUSD).
 		SetNillableMonthlyLimitUsd(groupIn.MonthlyLimitUSD).
@@ -52,7 +54,16 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 		SetNillableFallbackGroupID(groupIn.FallbackGroupID).
 		SetNillableFallbackGroupIDOnInvalidRequest(groupIn.FallbackGroupIDOnInvalidRequest).
 		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
-		SetMcpXMLInject(groupIn.MCPXMLInject)
+		SetMcpXMLInject(groupIn.MCPXMLInject).
+		SetDisableMetadataRewrite(groupIn.DisableMetadataRewrite).
+		SetRequireAnthropicVersion(groupIn.RequireAnthropicVersion).
+		SetNillableMaxMessages(groupIn.MaxMessages).
+		SetNillableDailyRequestLimit(groupIn.DailyRequestLimit).
+		SetNillableMaxOutputTokens(groupIn.MaxOutputTokens).
+		SetMixedSchedulingNativeSaturationOnly(groupIn.MixedSchedulingNativeSaturationOnly).
+		SetNillableWindowCostLimitUsd(groupIn.WindowCostLimitUSD).
+		SetNillableWindowCostWindowHours(groupIn.WindowCostWindowHours).
+		SetIntentRoutingEnabled(groupIn.IntentRoutingEnabled)
 
 	// 设置模型路由配置
 	if groupIn.ModelRouting != nil {
@@ -62,6 +73,21 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 	// 设置支持的模型系列（始终设置，空数组表示不限制）
 	builder = builder.SetSupportedModelScopes(groupIn.SupportedModelScopes)
 
+	// 设置分组级上游默认请求头
+	if groupIn.UpstreamHeaders != nil {
+		builder = builder.SetUpstreamHeaders(groupIn.UpstreamHeaders)
+	}
+
+	// 设置 intent 路由配置
+	if groupIn.IntentRouting != nil {
+		builder = builder.SetIntentRouting(groupIn.IntentRouting)
+	}
+
+	// 设置允许的上游端点白名单
+	if groupIn.AllowedEndpoints != nil {
+		builder = builder.SetAllowedEndpoints(groupIn.AllowedEndpoints)
+	}
+
 	created, err := builder.Save(ctx)
 	if err == nil {
 		groupIn.ID = created.ID
@@ -104,6 +130,8 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		SetIsExclusive(groupIn.IsExclusive).
 		SetStatus(groupIn.Status).
 		SetSubscriptionType(groupIn.SubscriptionType).
+		SetSubscriptionOverflowPolicy(groupIn.SubscriptionOverflowPolicy).
+		SetCurrency(groupIn.Currency).
 		SetNillableDailyLimitUsd(groupIn.DailyLimitUSD).
 		SetNillableWeeklyLimitUsd(groupIn.WeeklyLimitUSD).
 		SetNillableMonthlyLimitUsd(groupIn.MonthlyLimitUSD).
@@ -113,7 +141,11 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		SetDefaultValidityDays(groupIn.DefaultValidityDays).
 		SetClaudeCodeOnly(groupIn.ClaudeCodeOnly).
 		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
-		SetMcpXMLInject(groupIn.MCPXMLInject)
+		SetMcpXMLInject(groupIn.MCPXMLInject).
+		SetDisableMetadataRewrite(groupIn.DisableMetadataRewrite).
+		SetRequireAnthropicVersion(groupIn.RequireAnthropicVersion).
+		SetMixedSchedulingNativeSaturationOnly(groupIn.MixedSchedulingNativeSaturationOnly).
+		SetIntentRoutingEnabled(groupIn.IntentRoutingEnabled)
 
 	// 处理 FallbackGroupID：nil 时清除，否则设置
 	if groupIn.FallbackGroupID != nil {
@@ -127,6 +159,36 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 	} else {
 		builder = builder.ClearFallbackGroupIDOnInvalidRequest()
 	}
+	// 处理 MaxMessages：nil 时清除（恢复为使用全局配置），否则设置
+	if groupIn.MaxMessages != nil {
+		builder = builder.SetMaxMessages(*groupIn.MaxMessages)
+	} else {
+		builder = builder.ClearMaxMessages()
+	}
+	// 处理 DailyRequestLimit：nil 时清除（恢复为不限制），否则设置
+	if groupIn.DailyRequestLimit != nil {
+		builder = builder.SetDailyRequestLimit(*groupIn.DailyRequestLimit)
+	} else {
+		builder = builder.ClearDailyRequestLimit()
+	}
+	// 处理 MaxOutputTokens：nil 时清除（恢复为不限制），否则设置
+	if groupIn.MaxOutputTokens != nil {
+		builder = builder.SetMaxOutputTokens(*groupIn.MaxOutputTokens)
+	} else {
+		builder = builder.ClearMaxOutputTokens()
+	}
+	// 处理 WindowCostLimitUSD：nil 时清除（恢复为不限制），否则设置
+	if groupIn.WindowCostLimitUSD != nil {
+		builder = builder.SetWindowCostLimitUsd(*groupIn.WindowCostLimitUSD)
+	} else {
+		builder = builder.ClearWindowCostLimitUsd()
+	}
+	// 处理 WindowCostWindowHours：nil 时清除（恢复为默认 5 小时），否则设置
+	if groupIn.WindowCostWindowHours != nil {
+		builder = builder.SetWindowCostWindowHours(*groupIn.WindowCostWindowHours)
+	} else {
+		builder = builder.ClearWindowCostWindowHours()
+	}
 
 	// 处理 ModelRouting：nil 时清除，否则设置
 	if groupIn.ModelRouting != nil {
@@ -138,6 +200,27 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 	// 处理 SupportedModelScopes（始终设置，空数组表示不限制）
 	builder = builder.SetSupportedModelScopes(groupIn.SupportedModelScopes)
 
+	// 处理 UpstreamHeaders：nil 时清除，否则设置
+	if groupIn.UpstreamHeaders != nil {
+		builder = builder.SetUpstreamHeaders(groupIn.UpstreamHeaders)
+	} else {
+		builder = builder.ClearUpstreamHeaders()
+	}
+
+	// 处理 IntentRouting：nil 时清除，否则设置
+	if groupIn.IntentRouting != nil {
+		builder = builder.SetIntentRouting(groupIn.IntentRouting)
+	} else {
+		builder = builder.ClearIntentRouting()
+	}
+
+	// 处理 AllowedEndpoints：nil 时清除（恢复为不限制），否则设置
+	if groupIn.AllowedEndpoints != nil {
+		builder = builder.SetAllowedEndpoints(groupIn.AllowedEndpoints)
+	} else {
+		builder = builder.ClearAllowedEndpoints()
+	}
+
 	updated, err := builder.Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, service.ErrGroupNotFound, service.ErrGroupExists)
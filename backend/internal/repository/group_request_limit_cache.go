@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// groupDailyRequestsKeyPrefix 分组每日请求计数键前缀
+// 格式: group_daily_requests:{groupID}:{YYYYMMDD}
+const groupDailyRequestsKeyPrefix = "group_daily_requests:"
+
+// groupDailyRequestsKey 生成分组每日请求计数的 Redis 键，按配置时区的自然日分桶
+func groupDailyRequestsKey(groupID int64, day time.Time) string {
+	return fmt.Sprintf("%s%d:%s", groupDailyRequestsKeyPrefix, groupID, day.Format("20060102"))
+}
+
+type groupRequestLimitCache struct {
+	rdb *redis.Client
+}
+
+func NewGroupRequestLimitCache(rdb *redis.Client) service.GroupRequestLimitCache {
+	return &groupRequestLimitCache{rdb: rdb}
+}
+
+// IncrementDailyRequestCount 将分组当日请求计数 +1，并为该键设置到当日结束的过期时间
+func (c *groupRequestLimitCache) IncrementDailyRequestCount(ctx context.Context, groupID int64) (int64, time.Time, error) {
+	now := timezone.Now()
+	resetAt := timezone.EndOfDay(now).Add(time.Second).Truncate(time.Second)
+	key := groupDailyRequestsKey(groupID, now)
+
+	pipe := c.rdb.Pipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, resetAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return incr.Val(), resetAt, nil
+}
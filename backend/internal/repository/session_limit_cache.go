@@ -29,6 +29,10 @@ const (
 	// 格式: window_cost:account:{accountID}
 	windowCostKeyPrefix = "window_cost:account:"
 
+	// 分组窗口费用缓存键前缀
+	// 格式: window_cost:group:{groupID}
+	groupWindowCostKeyPrefix = "window_cost:group:"
+
 	// 窗口费用缓存 TTL（30秒）
 	windowCostCacheTTL = 30 * time.Second
 )
@@ -185,6 +189,11 @@ func windowCostKey(accountID int64) string {
 	return fmt.Sprintf("%s%d", windowCostKeyPrefix, accountID)
 }
 
+// groupWindowCostKey 生成分组窗口费用缓存的 Redis 键
+func groupWindowCostKey(groupID int64) string {
+	return fmt.Sprintf("%s%d", groupWindowCostKeyPrefix, groupID)
+}
+
 // RegisterSession 注册会话活动
 func (c *sessionLimitCache) RegisterSession(ctx context.Context, accountID int64, sessionUUID string, maxSessions int, idleTimeout time.Duration) (bool, error) {
 	if sessionUUID == "" || maxSessions <= 0 {
@@ -342,3 +351,22 @@ func (c *sessionLimitCache) GetWindowCostBatch(ctx context.Context, accountIDs [
 
 	return results, nil
 }
+
+// GetGroupWindowCost 获取缓存的分组窗口费用
+func (c *sessionLimitCache) GetGroupWindowCost(ctx context.Context, groupID int64) (float64, bool, error) {
+	key := groupWindowCostKey(groupID)
+	val, err := c.rdb.Get(ctx, key).Float64()
+	if err == redis.Nil {
+		return 0, false, nil // 缓存未命中
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return val, true, nil
+}
+
+// SetGroupWindowCost 设置分组窗口费用缓存
+func (c *sessionLimitCache) SetGroupWindowCost(ctx context.Context, groupID int64, cost float64) error {
+	key := groupWindowCostKey(groupID)
+	return c.rdb.Set(ctx, key, cost, windowCostCacheTTL).Err()
+}
@@ -22,7 +22,7 @@ import (
 	"github.com/lib/pq"
 )
 
-const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, reasoning_effort, cache_ttl_overridden, created_at"
+const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, reasoning_effort, cache_ttl_overridden, tag, created_at"
 
 type usageLogRepository struct {
 	client *dbent.Client
@@ -116,6 +116,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 				image_size,
 				reasoning_effort,
 				cache_ttl_overridden,
+				tag,
 				created_at
 			) VALUES (
 				$1, $2, $3, $4, $5,
@@ -123,7 +124,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 				$8, $9, $10, $11,
 				$12, $13,
 				$14, $15, $16, $17, $18, $19,
-				$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
+				$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33
 			)
 			ON CONFLICT (request_id, api_key_id) DO NOTHING
 			RETURNING id, created_at
@@ -137,6 +138,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 	ipAddress := nullString(log.IPAddress)
 	imageSize := nullString(log.ImageSize)
 	reasoningEffort := nullString(log.ReasoningEffort)
+	tag := nullString(log.Tag)
 
 	var requestIDArg any
 	if requestID != "" {
@@ -175,6 +177,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 		imageSize,
 		reasoningEffort,
 		log.CacheTTLOverridden,
+		tag,
 		createdAt,
 	}
 	if err := scanSingleRow(ctx, sqlq, query, args, &log.ID, &log.CreatedAt); err != nil {
@@ -686,6 +689,45 @@ func (r *usageLogRepository) GetAccountStatsAggregated(ctx context.Context, acco
 	return &stats, nil
 }
 
+// GetAccountTokensPerSecond 按请求计算平均输出吞吐量（output_tokens / duration_ms），再取平均，
+// 用于容量规划时评估账号的流式生成速度。只统计 duration_ms、output_tokens 均大于 0 的请求，
+// 避免瞬时请求或无输出的请求拉低平均值。
+func (r *usageLogRepository) GetAccountTokensPerSecond(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(AVG(output_tokens::float8 / (duration_ms::float8 / 1000)), 0) as avg_tokens_per_second
+		FROM usage_logs
+		WHERE account_id = $1 AND created_at >= $2 AND created_at < $3
+			AND duration_ms > 0 AND output_tokens > 0
+	`
+
+	var avgTokensPerSecond float64
+	if err := scanSingleRow(ctx, r.sql, query, []any{accountID, startTime, endTime}, &avgTokensPerSecond); err != nil {
+		return 0, err
+	}
+	return avgTokensPerSecond, nil
+}
+
+// GetAccountCacheHitRatio 计算账号在时间范围内的 prompt cache 命中率：
+// cache_read_tokens / (cache_read_tokens + cache_creation_tokens)。
+// 命中率越高，说明该账号上的请求越多地复用了已有缓存，适合作为粘性会话的优先目标。
+// 窗口内没有任何缓存相关 token（既无命中也无创建）时返回 0。
+func (r *usageLogRepository) GetAccountCacheHitRatio(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(
+			SUM(cache_read_tokens)::float8 / NULLIF(SUM(cache_read_tokens + cache_creation_tokens), 0),
+			0
+		) as cache_hit_ratio
+		FROM usage_logs
+		WHERE account_id = $1 AND created_at >= $2 AND created_at < $3
+	`
+
+	var ratio float64
+	if err := scanSingleRow(ctx, r.sql, query, []any{accountID, startTime, endTime}, &ratio); err != nil {
+		return 0, err
+	}
+	return ratio, nil
+}
+
 // GetModelStatsAggregated 使用 SQL 聚合统计模型使用数据
 // 性能优化：数据库层聚合计算，避免应用层循环统计
 func (r *usageLogRepository) GetModelStatsAggregated(ctx context.Context, modelName string, startTime, endTime time.Time) (*usagestats.UsageStats, error) {
@@ -896,12 +938,45 @@ func (r *usageLogRepository) GetAccountWindowStats(ctx context.Context, accountI
 	return stats, nil
 }
 
+// GetGroupWindowStats 获取分组（其下所有账号聚合）在时间窗口内的统计
+func (r *usageLogRepository) GetGroupWindowStats(ctx context.Context, groupID int64, startTime time.Time) (*usagestats.AccountStats, error) {
+	query := `
+		SELECT
+			COUNT(*) as requests,
+			COALESCE(SUM(input_tokens + output_tokens + cache_creation_tokens + cache_read_tokens), 0) as tokens,
+			COALESCE(SUM(total_cost * COALESCE(account_rate_multiplier, 1)), 0) as cost,
+			COALESCE(SUM(total_cost), 0) as standard_cost,
+			COALESCE(SUM(actual_cost), 0) as user_cost
+		FROM usage_logs
+		WHERE group_id = $1 AND created_at >= $2
+	`
+
+	stats := &usagestats.AccountStats{}
+	if err := scanSingleRow(
+		ctx,
+		r.sql,
+		query,
+		[]any{groupID, startTime},
+		&stats.Requests,
+		&stats.Tokens,
+		&stats.Cost,
+		&stats.StandardCost,
+		&stats.UserCost,
+	); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // TrendDataPoint represents a single point in trend data
 type TrendDataPoint = usagestats.TrendDataPoint
 
 // ModelStat represents usage statistics for a single model
 type ModelStat = usagestats.ModelStat
 
+// TagStat represents usage statistics for a single billing tag
+type TagStat = usagestats.TagStat
+
 // UserUsageTrendPoint represents user usage trend data point
 type UserUsageTrendPoint = usagestats.UserUsageTrendPoint
 
@@ -1336,6 +1411,10 @@ func (r *usageLogRepository) ListWithFilters(ctx context.Context, params paginat
 		conditions = append(conditions, fmt.Sprintf("model = $%d", len(args)+1))
 		args = append(args, filters.Model)
 	}
+	if filters.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf("tag = $%d", len(args)+1))
+		args = append(args, filters.Tag)
+	}
 	if filters.Stream != nil {
 		conditions = append(conditions, fmt.Sprintf("stream = $%d", len(args)+1))
 		args = append(args, *filters.Stream)
@@ -1656,6 +1735,206 @@ func (r *usageLogRepository) GetModelStatsWithFilters(ctx context.Context, start
 	return results, nil
 }
 
+// GetTagStatsWithFilters returns billing-tag statistics with optional filters.
+// Logs without a tag (NULL) are excluded since they don't belong to any billing category.
+func (r *usageLogRepository) GetTagStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) (results []TagStat, err error) {
+	actualCostExpr := "COALESCE(SUM(actual_cost), 0) as actual_cost"
+	// 当仅按 account_id 聚合时，实际费用使用账号倍率（total_cost * account_rate_multiplier）。
+	if accountID > 0 && userID == 0 && apiKeyID == 0 {
+		actualCostExpr = "COALESCE(SUM(total_cost * COALESCE(account_rate_multiplier, 1)), 0) as actual_cost"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			tag,
+			COUNT(*) as requests,
+			COALESCE(SUM(input_tokens), 0) as input_tokens,
+			COALESCE(SUM(output_tokens), 0) as output_tokens,
+			COALESCE(SUM(input_tokens + output_tokens + cache_creation_tokens + cache_read_tokens), 0) as total_tokens,
+			COALESCE(SUM(total_cost), 0) as cost,
+			%s
+		FROM usage_logs
+		WHERE created_at >= $1 AND created_at < $2 AND tag IS NOT NULL
+	`, actualCostExpr)
+
+	args := []any{startTime, endTime}
+	if userID > 0 {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, userID)
+	}
+	if apiKeyID > 0 {
+		query += fmt.Sprintf(" AND api_key_id = $%d", len(args)+1)
+		args = append(args, apiKeyID)
+	}
+	if accountID > 0 {
+		query += fmt.Sprintf(" AND account_id = $%d", len(args)+1)
+		args = append(args, accountID)
+	}
+	if groupID > 0 {
+		query += fmt.Sprintf(" AND group_id = $%d", len(args)+1)
+		args = append(args, groupID)
+	}
+	if stream != nil {
+		query += fmt.Sprintf(" AND stream = $%d", len(args)+1)
+		args = append(args, *stream)
+	}
+	if billingType != nil {
+		query += fmt.Sprintf(" AND billing_type = $%d", len(args)+1)
+		args = append(args, int16(*billingType))
+	}
+	query += " GROUP BY tag ORDER BY total_tokens DESC"
+
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// 保持主错误优先；仅在无错误时回传 Close 失败。
+		// 同时清空返回值，避免误用不完整结果。
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+			results = nil
+		}
+	}()
+
+	results, err = scanTagStatsRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetAccountStatsWithFilters returns per-account usage statistics with optional filters,
+// used to inspect how traffic distributed across accounts over a time range (e.g. to
+// validate that priority/weight scheduling settings produce the intended distribution).
+func (r *usageLogRepository) GetAccountStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, groupID int64, stream *bool, billingType *int8) (results []usagestats.AccountStat, err error) {
+	query := `
+		SELECT
+			account_id,
+			COUNT(*) as requests,
+			COALESCE(SUM(input_tokens), 0) as input_tokens,
+			COALESCE(SUM(output_tokens), 0) as output_tokens,
+			COALESCE(SUM(input_tokens + output_tokens + cache_creation_tokens + cache_read_tokens), 0) as total_tokens,
+			COALESCE(SUM(total_cost), 0) as cost,
+			COALESCE(SUM(actual_cost), 0) as actual_cost
+		FROM usage_logs
+		WHERE created_at >= $1 AND created_at < $2 AND account_id IS NOT NULL
+	`
+
+	args := []any{startTime, endTime}
+	if userID > 0 {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, userID)
+	}
+	if apiKeyID > 0 {
+		query += fmt.Sprintf(" AND api_key_id = $%d", len(args)+1)
+		args = append(args, apiKeyID)
+	}
+	if groupID > 0 {
+		query += fmt.Sprintf(" AND group_id = $%d", len(args)+1)
+		args = append(args, groupID)
+	}
+	if stream != nil {
+		query += fmt.Sprintf(" AND stream = $%d", len(args)+1)
+		args = append(args, *stream)
+	}
+	if billingType != nil {
+		query += fmt.Sprintf(" AND billing_type = $%d", len(args)+1)
+		args = append(args, int16(*billingType))
+	}
+	query += " GROUP BY account_id ORDER BY total_tokens DESC"
+
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// 保持主错误优先；仅在无错误时回传 Close 失败。
+		// 同时清空返回值，避免误用不完整结果。
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+			results = nil
+		}
+	}()
+
+	results = make([]usagestats.AccountStat, 0)
+	for rows.Next() {
+		var row usagestats.AccountStat
+		if err := rows.Scan(
+			&row.AccountID,
+			&row.Requests,
+			&row.InputTokens,
+			&row.OutputTokens,
+			&row.TotalTokens,
+			&row.Cost,
+			&row.ActualCost,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetCacheSavingsStatsWithFilters computes how much prompt caching saved over a time
+// range: actual cache_read cost vs. the equivalent cost had those tokens been billed
+// as regular input tokens (derived per-row from that row's own input token price, so
+// it stays correct across rate multipliers and per-model pricing changes).
+func (r *usageLogRepository) GetCacheSavingsStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) (*usagestats.CacheSavingsStats, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(cache_read_tokens), 0) as cache_read_tokens,
+			COALESCE(SUM(cache_read_cost), 0) as cache_read_cost,
+			COALESCE(SUM(CASE WHEN input_tokens > 0 THEN cache_read_tokens * (input_cost / input_tokens) ELSE 0 END), 0) as equivalent_input_cost
+		FROM usage_logs
+		WHERE created_at >= $1 AND created_at < $2 AND cache_read_tokens > 0
+	`
+
+	args := []any{startTime, endTime}
+	if userID > 0 {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, userID)
+	}
+	if apiKeyID > 0 {
+		query += fmt.Sprintf(" AND api_key_id = $%d", len(args)+1)
+		args = append(args, apiKeyID)
+	}
+	if accountID > 0 {
+		query += fmt.Sprintf(" AND account_id = $%d", len(args)+1)
+		args = append(args, accountID)
+	}
+	if groupID > 0 {
+		query += fmt.Sprintf(" AND group_id = $%d", len(args)+1)
+		args = append(args, groupID)
+	}
+	if stream != nil {
+		query += fmt.Sprintf(" AND stream = $%d", len(args)+1)
+		args = append(args, *stream)
+	}
+	if billingType != nil {
+		query += fmt.Sprintf(" AND billing_type = $%d", len(args)+1)
+		args = append(args, int16(*billingType))
+	}
+
+	stats := &usagestats.CacheSavingsStats{}
+	if err := scanSingleRow(
+		ctx,
+		r.sql,
+		query,
+		args,
+		&stats.CacheReadTokens,
+		&stats.CacheReadCost,
+		&stats.EquivalentInputCost,
+	); err != nil {
+		return nil, err
+	}
+	stats.EstimatedSavings = stats.EquivalentInputCost - stats.CacheReadCost
+	return stats, nil
+}
+
 // GetGlobalStats gets usage statistics for all users within a time range
 func (r *usageLogRepository) GetGlobalStats(ctx context.Context, startTime, endTime time.Time) (*UsageStats, error) {
 	query := `
@@ -1716,6 +1995,10 @@ func (r *usageLogRepository) GetStatsWithFilters(ctx context.Context, filters Us
 		conditions = append(conditions, fmt.Sprintf("model = $%d", len(args)+1))
 		args = append(args, filters.Model)
 	}
+	if filters.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf("tag = $%d", len(args)+1))
+		args = append(args, filters.Tag)
+	}
 	if filters.Stream != nil {
 		conditions = append(conditions, fmt.Sprintf("stream = $%d", len(args)+1))
 		args = append(args, *filters.Stream)
@@ -2198,6 +2481,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		imageSize             sql.NullString
 		reasoningEffort       sql.NullString
 		cacheTTLOverridden    bool
+		tag                   sql.NullString
 		createdAt             time.Time
 	)
 
@@ -2234,6 +2518,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		&imageSize,
 		&reasoningEffort,
 		&cacheTTLOverridden,
+		&tag,
 		&createdAt,
 	); err != nil {
 		return nil, err
@@ -2297,6 +2582,9 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 	if reasoningEffort.Valid {
 		log.ReasoningEffort = &reasoningEffort.String
 	}
+	if tag.Valid {
+		log.Tag = &tag.String
+	}
 
 	return log, nil
 }
@@ -2348,6 +2636,29 @@ func scanModelStatsRows(rows *sql.Rows) ([]ModelStat, error) {
 	return results, nil
 }
 
+func scanTagStatsRows(rows *sql.Rows) ([]TagStat, error) {
+	results := make([]TagStat, 0)
+	for rows.Next() {
+		var row TagStat
+		if err := rows.Scan(
+			&row.Tag,
+			&row.Requests,
+			&row.InputTokens,
+			&row.OutputTokens,
+			&row.TotalTokens,
+			&row.Cost,
+			&row.ActualCost,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func buildWhere(conditions []string) string {
 	if len(conditions) == 0 {
 		return ""
@@ -97,6 +97,33 @@ func (s *UsageLogRepoSuite) TestGetByID() {
 	s.Require().Equal(10, got.InputTokens)
 }
 
+func (s *UsageLogRepoSuite) TestCreate_RoundTripsTag() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "tag-roundtrip@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-tag-roundtrip", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-tag-roundtrip"})
+
+	tag := "project-a"
+	log := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3",
+		InputTokens:  10,
+		OutputTokens: 20,
+		TotalCost:    0.5,
+		ActualCost:   0.4,
+		Tag:          &tag,
+	}
+	_, err := s.repo.Create(s.ctx, log)
+	s.Require().NoError(err)
+
+	got, err := s.repo.GetByID(s.ctx, log.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(got.Tag)
+	s.Require().Equal(tag, *got.Tag)
+}
+
 func (s *UsageLogRepoSuite) TestGetByID_NotFound() {
 	_, err := s.repo.GetByID(s.ctx, 999999)
 	s.Require().Error(err, "expected error for non-existent ID")
@@ -844,6 +871,51 @@ func (s *UsageLogRepoSuite) TestGetAccountWindowStats() {
 	s.Require().Equal(int64(70), stats.Tokens) // (10+20) + (15+25)
 }
 
+// --- GetGroupWindowStats ---
+
+func (s *UsageLogRepoSuite) createUsageLogForGroup(user *service.User, apiKey *service.APIKey, account *service.Account, groupID int64, inputTokens, outputTokens int, cost float64, createdAt time.Time) *service.UsageLog {
+	log := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		GroupID:      &groupID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3",
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		TotalCost:    cost,
+		ActualCost:   cost,
+		CreatedAt:    createdAt,
+	}
+	_, err := s.repo.Create(s.ctx, log)
+	s.Require().NoError(err)
+	return log
+}
+
+func (s *UsageLogRepoSuite) TestGetGroupWindowStats() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "groupwindowstats@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-groupwindowstats", Name: "k"})
+	accountA := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-groupwindowstats-a"})
+	accountB := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-groupwindowstats-b"})
+	group := mustCreateGroup(s.T(), s.client, &service.Group{Name: "group-windowstats"})
+	otherGroup := mustCreateGroup(s.T(), s.client, &service.Group{Name: "group-windowstats-other"})
+
+	now := time.Now()
+	windowStart := now.Add(-10 * time.Minute)
+
+	// 组内两个账号的用量应聚合到同一个分组统计
+	s.createUsageLogForGroup(user, apiKey, accountA, group.ID, 10, 20, 0.5, now.Add(-5*time.Minute))
+	s.createUsageLogForGroup(user, apiKey, accountB, group.ID, 15, 25, 0.6, now.Add(-3*time.Minute))
+	s.createUsageLogForGroup(user, apiKey, accountA, group.ID, 20, 30, 0.7, now.Add(-30*time.Minute))     // outside window
+	s.createUsageLogForGroup(user, apiKey, accountA, otherGroup.ID, 99, 99, 9.9, now.Add(-1*time.Minute)) // 其它分组，不应计入
+
+	stats, err := s.repo.GetGroupWindowStats(s.ctx, group.ID, windowStart)
+	s.Require().NoError(err, "GetGroupWindowStats")
+	s.Require().Equal(int64(2), stats.Requests)
+	s.Require().Equal(int64(70), stats.Tokens) // (10+20) + (15+25)
+	s.Require().InDelta(1.1, stats.StandardCost, 0.0001)
+}
+
 // --- GetUserUsageTrendByUserID ---
 
 func (s *UsageLogRepoSuite) TestGetUserUsageTrendByUserID() {
@@ -1032,6 +1104,234 @@ func (s *UsageLogRepoSuite) TestGetModelStatsWithFilters() {
 	s.Require().Len(stats, 2)
 }
 
+func (s *UsageLogRepoSuite) TestGetTagStatsWithFilters() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "tagfilters@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-tagfilters", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-tagfilters"})
+
+	base := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	tagA := "project-a"
+	tagB := "project-b"
+
+	log1 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-opus",
+		InputTokens:  100,
+		OutputTokens: 200,
+		TotalCost:    0.5,
+		ActualCost:   0.5,
+		Tag:          &tagA,
+		CreatedAt:    base,
+	}
+	_, err := s.repo.Create(s.ctx, log1)
+	s.Require().NoError(err)
+
+	log2 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-opus",
+		InputTokens:  50,
+		OutputTokens: 100,
+		TotalCost:    0.2,
+		ActualCost:   0.2,
+		Tag:          &tagA,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log2)
+	s.Require().NoError(err)
+
+	log3 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-sonnet",
+		InputTokens:  10,
+		OutputTokens: 20,
+		TotalCost:    0.1,
+		ActualCost:   0.1,
+		Tag:          &tagB,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log3)
+	s.Require().NoError(err)
+
+	// Untagged log should be excluded from aggregation
+	log4 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-sonnet",
+		InputTokens:  5,
+		OutputTokens: 5,
+		TotalCost:    0.05,
+		ActualCost:   0.05,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log4)
+	s.Require().NoError(err)
+
+	startTime := base.Add(-1 * time.Hour)
+	endTime := base.Add(2 * time.Hour)
+
+	stats, err := s.repo.GetTagStatsWithFilters(s.ctx, startTime, endTime, user.ID, 0, 0, 0, nil, nil)
+	s.Require().NoError(err, "GetTagStatsWithFilters user filter")
+	s.Require().Len(stats, 2)
+
+	byTag := make(map[string]usagestats.TagStat, len(stats))
+	for _, stat := range stats {
+		byTag[stat.Tag] = stat
+	}
+
+	statA, ok := byTag[tagA]
+	s.Require().True(ok, "expected stats for tagA")
+	s.Require().Equal(int64(2), statA.Requests)
+	s.Require().InEpsilon(0.7, statA.Cost, 0.0001)
+
+	statB, ok := byTag[tagB]
+	s.Require().True(ok, "expected stats for tagB")
+	s.Require().Equal(int64(1), statB.Requests)
+}
+
+func (s *UsageLogRepoSuite) TestGetAccountStatsWithFilters() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "accountstatfilters@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-accountstatfilters", Name: "k"})
+	accountA := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-distribution-a"})
+	accountB := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-distribution-b"})
+
+	base := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// Two requests routed to account A, one to account B — exercises the distribution
+	// an admin would check to validate priority/weight scheduling.
+	log1 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    accountA.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-opus",
+		InputTokens:  100,
+		OutputTokens: 200,
+		TotalCost:    0.5,
+		ActualCost:   0.5,
+		CreatedAt:    base,
+	}
+	_, err := s.repo.Create(s.ctx, log1)
+	s.Require().NoError(err)
+
+	log2 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    accountA.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-opus",
+		InputTokens:  50,
+		OutputTokens: 100,
+		TotalCost:    0.2,
+		ActualCost:   0.2,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log2)
+	s.Require().NoError(err)
+
+	log3 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    accountB.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-sonnet",
+		InputTokens:  10,
+		OutputTokens: 20,
+		TotalCost:    0.1,
+		ActualCost:   0.1,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log3)
+	s.Require().NoError(err)
+
+	startTime := base.Add(-1 * time.Hour)
+	endTime := base.Add(2 * time.Hour)
+
+	stats, err := s.repo.GetAccountStatsWithFilters(s.ctx, startTime, endTime, user.ID, 0, 0, nil, nil)
+	s.Require().NoError(err, "GetAccountStatsWithFilters user filter")
+	s.Require().Len(stats, 2)
+
+	byAccount := make(map[int64]usagestats.AccountStat, len(stats))
+	for _, stat := range stats {
+		byAccount[stat.AccountID] = stat
+	}
+
+	statA, ok := byAccount[accountA.ID]
+	s.Require().True(ok, "expected stats for accountA")
+	s.Require().Equal(int64(2), statA.Requests)
+	s.Require().InEpsilon(0.7, statA.Cost, 0.0001)
+
+	statB, ok := byAccount[accountB.ID]
+	s.Require().True(ok, "expected stats for accountB")
+	s.Require().Equal(int64(1), statB.Requests)
+}
+
+func (s *UsageLogRepoSuite) TestGetCacheSavingsStatsWithFilters() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "cachesavings@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-cachesavings", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-cachesavings"})
+
+	base := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// input_cost implies a unit price of $0.01/token (1.0 / 100); 80 cache_read tokens
+	// actually billed at $0.002 each ($0.16 total) would have cost $0.80 at that rate,
+	// so this row alone should produce $0.64 of savings.
+	log1 := &service.UsageLog{
+		UserID:          user.ID,
+		APIKeyID:        apiKey.ID,
+		AccountID:       account.ID,
+		RequestID:       uuid.New().String(),
+		Model:           "claude-3-opus",
+		InputTokens:     100,
+		OutputTokens:    50,
+		CacheReadTokens: 80,
+		InputCost:       1.0,
+		CacheReadCost:   0.16,
+		TotalCost:       1.16,
+		ActualCost:      1.16,
+		CreatedAt:       base,
+	}
+	_, err := s.repo.Create(s.ctx, log1)
+	s.Require().NoError(err)
+
+	// A second row with no cache reads should not contribute tokens/cost to the report.
+	log2 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		RequestID:    uuid.New().String(),
+		Model:        "claude-3-opus",
+		InputTokens:  10,
+		OutputTokens: 5,
+		InputCost:    0.1,
+		TotalCost:    0.1,
+		ActualCost:   0.1,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log2)
+	s.Require().NoError(err)
+
+	startTime := base.Add(-1 * time.Hour)
+	endTime := base.Add(2 * time.Hour)
+
+	stats, err := s.repo.GetCacheSavingsStatsWithFilters(s.ctx, startTime, endTime, user.ID, 0, 0, 0, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(80), stats.CacheReadTokens)
+	s.Require().InEpsilon(0.16, stats.CacheReadCost, 0.0001)
+	s.Require().InEpsilon(0.80, stats.EquivalentInputCost, 0.0001)
+	s.Require().InEpsilon(0.64, stats.EstimatedSavings, 0.0001)
+}
+
 // --- GetAccountUsageStats ---
 
 func (s *UsageLogRepoSuite) TestGetAccountUsageStats() {
@@ -1096,6 +1396,160 @@ func (s *UsageLogRepoSuite) TestGetAccountUsageStats_EmptyRange() {
 	s.Require().Equal(int64(0), resp.Summary.TotalRequests)
 }
 
+// --- GetAccountTokensPerSecond ---
+
+func (s *UsageLogRepoSuite) TestGetAccountTokensPerSecond() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "tokenspersec@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-tokenspersec", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-tokenspersec"})
+
+	base := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// 200 output_tokens / 2s = 100 tokens/sec
+	d1 := 2000
+	log1 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		Model:        "claude-3-opus",
+		InputTokens:  10,
+		OutputTokens: 200,
+		TotalCost:    0.5,
+		ActualCost:   0.4,
+		DurationMs:   &d1,
+		CreatedAt:    base.Add(1 * time.Hour),
+	}
+	_, err := s.repo.Create(s.ctx, log1)
+	s.Require().NoError(err)
+
+	// 100 output_tokens / 0.5s = 200 tokens/sec
+	d2 := 500
+	log2 := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		Model:        "claude-3-sonnet",
+		InputTokens:  5,
+		OutputTokens: 100,
+		TotalCost:    0.2,
+		ActualCost:   0.15,
+		DurationMs:   &d2,
+		CreatedAt:    base.Add(2 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log2)
+	s.Require().NoError(err)
+
+	// 零时长/零输出的请求不应拉低平均值
+	zero := 0
+	logZeroDuration := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		Model:        "claude-3-haiku",
+		InputTokens:  1,
+		OutputTokens: 1,
+		TotalCost:    0.01,
+		ActualCost:   0.01,
+		DurationMs:   &zero,
+		CreatedAt:    base.Add(3 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, logZeroDuration)
+	s.Require().NoError(err)
+
+	startTime := base
+	endTime := base.Add(24 * time.Hour)
+
+	avg, err := s.repo.GetAccountTokensPerSecond(s.ctx, account.ID, startTime, endTime)
+	s.Require().NoError(err, "GetAccountTokensPerSecond")
+	// (100 + 200) / 2 = 150
+	s.Require().InEpsilon(150.0, avg, 0.0001)
+}
+
+func (s *UsageLogRepoSuite) TestGetAccountTokensPerSecond_NoMatchingLogsReturnsZero() {
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-tokenspersec-empty"})
+
+	base := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	avg, err := s.repo.GetAccountTokensPerSecond(s.ctx, account.ID, base, base.Add(24*time.Hour))
+	s.Require().NoError(err, "GetAccountTokensPerSecond empty")
+	s.Require().Equal(0.0, avg)
+}
+
+// --- GetAccountCacheHitRatio ---
+
+func (s *UsageLogRepoSuite) TestGetAccountCacheHitRatio() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "cachehitratio@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-cachehitratio", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-cachehitratio"})
+
+	base := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// 80 cache_read, 20 cache_creation -> 命中率贡献 80
+	log1 := &service.UsageLog{
+		UserID:              user.ID,
+		APIKeyID:            apiKey.ID,
+		AccountID:           account.ID,
+		Model:               "claude-3-opus",
+		InputTokens:         10,
+		OutputTokens:        50,
+		CacheReadTokens:     80,
+		CacheCreationTokens: 20,
+		TotalCost:           0.5,
+		ActualCost:          0.4,
+		CreatedAt:           base.Add(1 * time.Hour),
+	}
+	_, err := s.repo.Create(s.ctx, log1)
+	s.Require().NoError(err)
+
+	// 20 cache_read, 80 cache_creation -> 命中率贡献 20
+	log2 := &service.UsageLog{
+		UserID:              user.ID,
+		APIKeyID:            apiKey.ID,
+		AccountID:           account.ID,
+		Model:               "claude-3-sonnet",
+		InputTokens:         5,
+		OutputTokens:        30,
+		CacheReadTokens:     20,
+		CacheCreationTokens: 80,
+		TotalCost:           0.2,
+		ActualCost:          0.15,
+		CreatedAt:           base.Add(2 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, log2)
+	s.Require().NoError(err)
+
+	// 没有任何缓存 token 的请求不应影响比率（分子分母都不变）
+	logNoCache := &service.UsageLog{
+		UserID:       user.ID,
+		APIKeyID:     apiKey.ID,
+		AccountID:    account.ID,
+		Model:        "claude-3-haiku",
+		InputTokens:  1,
+		OutputTokens: 1,
+		TotalCost:    0.01,
+		ActualCost:   0.01,
+		CreatedAt:    base.Add(3 * time.Hour),
+	}
+	_, err = s.repo.Create(s.ctx, logNoCache)
+	s.Require().NoError(err)
+
+	startTime := base
+	endTime := base.Add(24 * time.Hour)
+
+	ratio, err := s.repo.GetAccountCacheHitRatio(s.ctx, account.ID, startTime, endTime)
+	s.Require().NoError(err, "GetAccountCacheHitRatio")
+	// (80 + 20) / (80 + 20 + 20 + 80) = 100 / 200 = 0.5
+	s.Require().InEpsilon(0.5, ratio, 0.0001)
+}
+
+func (s *UsageLogRepoSuite) TestGetAccountCacheHitRatio_NoMatchingLogsReturnsZero() {
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-cachehitratio-empty"})
+
+	base := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	ratio, err := s.repo.GetAccountCacheHitRatio(s.ctx, account.ID, base, base.Add(24*time.Hour))
+	s.Require().NoError(err, "GetAccountCacheHitRatio empty")
+	s.Require().Equal(0.0, ratio)
+}
+
 // --- GetUserUsageTrend ---
 
 func (s *UsageLogRepoSuite) TestGetUserUsageTrend() {
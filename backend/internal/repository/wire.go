@@ -37,6 +37,13 @@ func ProvidePricingRemoteClient(cfg *config.Config) service.PricingRemoteClient
 	return NewPricingRemoteClient(cfg.Update.ProxyURL)
 }
 
+// ProvideGeminiImageFetcher 创建 Gemini url 类型图片拉取客户端
+// 复用全局代理设置，避免为单个功能单独引入代理配置项；同时复用 URL 允许列表的
+// 私有地址校验配置，防止 DNS rebinding 访问内网地址
+func ProvideGeminiImageFetcher(cfg *config.Config) service.GeminiImageFetcher {
+	return NewGeminiImageFetcher(cfg.Update.ProxyURL, cfg.Security.URLAllowlist.Enabled, cfg.Security.URLAllowlist.AllowPrivateHosts)
+}
+
 // ProvideSessionLimitCache 创建会话限制缓存
 // 用于 Anthropic OAuth/SetupToken 账号的并发会话数量控制
 func ProvideSessionLimitCache(rdb *redis.Client, cfg *config.Config) service.SessionLimitCache {
@@ -47,6 +54,21 @@ func ProvideSessionLimitCache(rdb *redis.Client, cfg *config.Config) service.Ses
 	return NewSessionLimitCache(rdb, defaultIdleTimeoutMinutes)
 }
 
+// ProvideGatewayCache 创建粘性会话缓存。
+// 当 gateway.scheduling.persist_sticky_sessions 开启时，额外启用 session_bindings
+// 表的写穿持久化，并启动过期绑定清理后台任务。
+func ProvideGatewayCache(rdb *redis.Client, entClient *ent.Client, cfg *config.Config) service.GatewayCache {
+	if cfg == nil || !cfg.Gateway.Scheduling.PersistStickySessions {
+		return NewGatewayCache(rdb)
+	}
+
+	cache := NewPersistentGatewayCache(rdb, entClient, true)
+	if pc, ok := cache.(*gatewayCache); ok {
+		pc.StartSessionBindingCleanupWorker(cfg.Gateway.Scheduling.SessionBindingCleanupInterval)
+	}
+	return cache
+}
+
 // ProviderSet is the Wire provider set for all repositories
 var ProviderSet = wire.NewSet(
 	NewUserRepository,
@@ -70,7 +92,7 @@ var ProviderSet = wire.NewSet(
 	NewErrorPassthroughRepository,
 
 	// Cache implementations
-	NewGatewayCache,
+	ProvideGatewayCache,
 	NewBillingCache,
 	NewAPIKeyCache,
 	NewTempUnschedCache,
@@ -86,9 +108,11 @@ var ProviderSet = wire.NewSet(
 	NewSchedulerCache,
 	NewSchedulerOutboxRepository,
 	NewProxyLatencyCache,
+	NewCountTokensCache,
 	NewTotpCache,
 	NewRefreshTokenCache,
 	NewErrorPassthroughCache,
+	NewGroupRequestLimitCache,
 
 	// Encryptors
 	NewAESEncryptor,
@@ -96,6 +120,7 @@ var ProviderSet = wire.NewSet(
 	// HTTP service ports (DI Strategy A: return interface directly)
 	NewTurnstileVerifier,
 	ProvidePricingRemoteClient,
+	ProvideGeminiImageFetcher,
 	ProvideGitHubReleaseClient,
 	NewProxyExitInfoProber,
 	NewClaudeUsageFetcher,
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// AdmissionController 全局准入控制器，独立于按账号/用户的并发限制，
+// 用于在极端负载下保护整个进程：超过 MaxInFlight 时请求进入队列等待，
+// 队列也满或等待超时则直接返回 503，避免请求在账号选择阶段无限堆积。
+type AdmissionController struct {
+	cfg   config.GatewayAdmissionControlConfig
+	slots chan struct{}
+	queue chan struct{}
+}
+
+// NewAdmissionController 创建全局准入控制器
+// cfg.Enabled 为 false 时返回的中间件不做任何拦截
+func NewAdmissionController(cfg config.GatewayAdmissionControlConfig) *AdmissionController {
+	ac := &AdmissionController{cfg: cfg}
+	if !cfg.Enabled {
+		return ac
+	}
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = 1
+	}
+	ac.slots = make(chan struct{}, maxInFlight)
+	if cfg.MaxQueue > 0 {
+		ac.queue = make(chan struct{}, cfg.MaxQueue)
+	}
+	return ac
+}
+
+// Middleware 返回准入控制中间件
+func (ac *AdmissionController) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ac == nil || !ac.cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		select {
+		case ac.slots <- struct{}{}:
+			defer func() { <-ac.slots }()
+			c.Next()
+			return
+		default:
+		}
+
+		// 运行中名额已满，尝试进入等待队列
+		if ac.queue != nil {
+			select {
+			case ac.queue <- struct{}{}:
+			default:
+				AbortWithError(c, 503, "ADMISSION_QUEUE_FULL", "Server is at capacity, please retry later")
+				return
+			}
+		} else {
+			AbortWithError(c, 503, "ADMISSION_LIMIT_EXCEEDED", "Server is at capacity, please retry later")
+			return
+		}
+
+		timeout := ac.cfg.QueueTimeout
+		if timeout <= 0 {
+			timeout = 5 * time.Second
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case ac.slots <- struct{}{}:
+			// 已经拿到运行名额，队列名额立即归还，避免在整个请求执行期间
+			// 继续占用队列容量（否则持续负载下 MaxQueue 会被逐渐耗尽）。
+			<-ac.queue
+			defer func() { <-ac.slots }()
+			c.Next()
+		case <-timer.C:
+			<-ac.queue
+			AbortWithError(c, 503, "ADMISSION_TIMEOUT", "Timed out waiting for available capacity")
+		case <-c.Request.Context().Done():
+			<-ac.queue
+			AbortWithError(c, 503, "ADMISSION_CLIENT_GONE", "Client disconnected while waiting for available capacity")
+		}
+	}
+}
@@ -0,0 +1,196 @@
+//go:build unit
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionController_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ac := NewAdmissionController(config.GatewayAdmissionControlConfig{Enabled: false})
+	router := gin.New()
+	router.Use(ac.Middleware())
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAdmissionController_RejectsAtGlobalCap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	ac := NewAdmissionController(config.GatewayAdmissionControlConfig{
+		Enabled:      true,
+		MaxInFlight:  1,
+		MaxQueue:     0,
+		QueueTimeout: 50 * time.Millisecond,
+	})
+	router := gin.New()
+	router.Use(ac.Middleware())
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		c.Status(http.StatusOK)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var firstCode int
+	go func() {
+		defer wg.Done()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		firstCode = rec.Code
+	}()
+
+	<-started
+
+	// The in-flight slot is held by the first request and there's no queue,
+	// so a second concurrent request must be rejected immediately.
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, http.StatusOK, firstCode)
+}
+
+func TestAdmissionController_QueueTimesOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	ac := NewAdmissionController(config.GatewayAdmissionControlConfig{
+		Enabled:      true,
+		MaxInFlight:  1,
+		MaxQueue:     1,
+		QueueTimeout: 20 * time.Millisecond,
+	})
+	router := gin.New()
+	router.Use(ac.Middleware())
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		c.Status(http.StatusOK)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+	<-started
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	close(release)
+	wg.Wait()
+}
+
+// TestAdmissionController_ReleasesQueueSlotOnceAdmitted verifies that a
+// request admitted into the running slots frees its queue slot immediately,
+// instead of holding it for the rest of the request's execution. With
+// MaxQueue=1, a second wave of queuing (request 3) while request 2 is still
+// running must succeed -- if the queue slot leaked for the whole request
+// lifetime, request 3 would be rejected with ADMISSION_QUEUE_FULL even
+// though the queue should be empty.
+func TestAdmissionController_ReleasesQueueSlotOnceAdmitted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release1 := make(chan struct{})
+	release2 := make(chan struct{})
+	started := make(chan int32, 3)
+	var n int32
+
+	ac := NewAdmissionController(config.GatewayAdmissionControlConfig{
+		Enabled:      true,
+		MaxInFlight:  1,
+		MaxQueue:     1,
+		QueueTimeout: 500 * time.Millisecond,
+	})
+	router := gin.New()
+	router.Use(ac.Middleware())
+	router.GET("/slow", func(c *gin.Context) {
+		id := atomic.AddInt32(&n, 1)
+		started <- id
+		switch id {
+		case 1:
+			<-release1
+		case 2:
+			<-release2
+		}
+		c.Status(http.StatusOK)
+	})
+
+	var wg sync.WaitGroup
+
+	// Request 1 takes the single in-flight slot.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+	require.Equal(t, int32(1), <-started)
+
+	// Request 2 fills the single queue slot while request 1 is still running.
+	var code2 int
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		code2 = rec.Code
+	}()
+	// Give request 2 time to reach the queue before request 1 finishes.
+	time.Sleep(50 * time.Millisecond)
+
+	// Finish request 1 so request 2 is admitted into the running slot.
+	close(release1)
+	require.Equal(t, int32(2), <-started)
+
+	// Request 2 is now running and its queue slot must already be released --
+	// a third request should be able to queue instead of being rejected.
+	var code3 int
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		code3 = rec.Code
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	close(release2)
+	wg.Wait()
+
+	require.Equal(t, http.StatusOK, code2)
+	require.Equal(t, http.StatusOK, code3)
+}
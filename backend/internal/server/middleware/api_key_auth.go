@@ -185,6 +185,7 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 		})
 		c.Set(string(ContextKeyUserRole), apiKey.User.Role)
 		setGroupContext(c, apiKey.Group)
+		setAPIKeyIDContext(c, apiKey.ID)
 
 		c.Next()
 	}
@@ -220,3 +221,13 @@ func setGroupContext(c *gin.Context, group *service.Group) {
 	ctx := context.WithValue(c.Request.Context(), ctxkey.Group, group)
 	c.Request = c.Request.WithContext(ctx)
 }
+
+// setAPIKeyIDContext 将当前请求使用的 API Key ID 写入 context，
+// 供网关服务层登记粘性会话的按 Key 索引使用。
+func setAPIKeyIDContext(c *gin.Context, apiKeyID int64) {
+	if apiKeyID <= 0 {
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), ctxkey.APIKeyID, apiKeyID)
+	c.Request = c.Request.WithContext(ctx)
+}
@@ -66,7 +66,7 @@ func registerRoutes(
 	redisClient *redis.Client,
 ) {
 	// 通用路由（健康检查、状态等）
-	routes.RegisterCommonRoutes(r)
+	routes.RegisterCommonRoutes(r, h)
 
 	// API v1
 	v1 := r.Group("/api/v1")
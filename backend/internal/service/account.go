@@ -3,6 +3,7 @@ package service
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,6 +23,10 @@ type Account struct {
 	ProxyID     *int64
 	Concurrency int
 	Priority    int
+	// AffinityGroup 账号亲和分组，故障转移时优先选择同一分组内的其他账号；空字符串表示不属于任何亲和分组
+	AffinityGroup string
+	// MaxLineSize 账号流式响应单行缓冲区上限（字节），覆盖全局 Gateway.MaxLineSize；0 表示不覆盖
+	MaxLineSize int
 	// RateMultiplier 账号计费倍率（>=0，允许 0 表示该账号计费为 0）。
 	// 使用指针用于兼容旧版本调度缓存（Redis）中缺字段的情况：nil 表示按 1.0 处理。
 	RateMultiplier     *float64
@@ -45,6 +50,14 @@ type Account struct {
 	SessionWindowStart  *time.Time
 	SessionWindowEnd    *time.Time
 	SessionWindowStatus string
+	// 当前 5h 窗口已使用百分比（0-100），来自上游 anthropic-ratelimit-unified-5h-utilization 响应头；nil 表示未知
+	SessionWindowUtilization *int
+
+	// QuietHoursStartMinute / QuietHoursEndMinute 账号"静默时段"配置（UTC，一天内分钟数 0-1439），
+	// 用于在已知的运维窗口内抑制该账号健康状态变化的对外通知（禁用/限流），但不影响状态本身的流转。
+	// 两者都为 nil 表示未配置静默时段，通知始终照常发送。
+	QuietHoursStartMinute *int
+	QuietHoursEndMinute   *int
 
 	Proxy         *Proxy
 	AccountGroups []AccountGroup
@@ -111,6 +124,25 @@ func (a *Account) IsOverloaded() bool {
 	return time.Now().Before(*a.OverloadUntil)
 }
 
+// IsWithinQuietHours 判断给定时间（按 UTC 取时分）是否落在账号配置的静默时段内，供健康
+// 通知发送前做抑制判断。未配置静默时段（两字段任一为 nil）时始终返回 false。
+// 起始分钟等于结束分钟视为全天静默；起始大于结束按跨零点处理（如 22:00-06:00）。
+func (a *Account) IsWithinQuietHours(now time.Time) bool {
+	if a == nil || a.QuietHoursStartMinute == nil || a.QuietHoursEndMinute == nil {
+		return false
+	}
+	start := *a.QuietHoursStartMinute
+	end := *a.QuietHoursEndMinute
+	if start == end {
+		return true
+	}
+	minute := now.UTC().Hour()*60 + now.UTC().Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
 func (a *Account) IsOAuth() bool {
 	return a.Type == AccountTypeOAuth || a.Type == AccountTypeSetupToken
 }
@@ -229,6 +261,20 @@ func (a *Account) GetCredentialAsInt64(key string) int64 {
 	return 0
 }
 
+// IsDebugLoggingEnabled 是否为该账号单独开启详细请求/响应调试日志（[ClaudeMimicDebug]/[Forward]），
+// 用于排查单个不稳定凭证而不必打开全局的 SUB2API_DEBUG_CLAUDE_MIMIC，避免生产环境日志量暴涨。
+func (a *Account) IsDebugLoggingEnabled() bool {
+	if a.Credentials == nil {
+		return false
+	}
+	raw, ok := a.Credentials["debug_logging_enabled"]
+	if !ok || raw == nil {
+		return false
+	}
+	enabled, ok := raw.(bool)
+	return ok && enabled
+}
+
 func (a *Account) IsTempUnschedulableEnabled() bool {
 	if a.Credentials == nil {
 		return false
@@ -279,6 +325,58 @@ func (a *Account) GetTempUnschedulableRules() []TempUnschedulableRule {
 	return rules
 }
 
+// IsSuccessRateCooldownEnabled 是否启用“成功率异常下降自动冷却”：即使没有命中
+// TempUnschedulableRules 中任何显式错误码规则（例如上游频繁返回空响应），也根据
+// 最近请求的成功率自动触发与临时不可调度相同的冷却。
+func (a *Account) IsSuccessRateCooldownEnabled() bool {
+	if a.Credentials == nil {
+		return false
+	}
+	raw, ok := a.Credentials["success_rate_cooldown_enabled"]
+	if !ok || raw == nil {
+		return false
+	}
+	enabled, ok := raw.(bool)
+	return ok && enabled
+}
+
+// SuccessRateCooldownThreshold 触发冷却的成功率下限（0-1，不含边界），未配置或非法时回退默认值。
+func (a *Account) SuccessRateCooldownThreshold() float64 {
+	const defaultThreshold = 0.5
+	if a.Credentials == nil {
+		return defaultThreshold
+	}
+	threshold, ok := a.Credentials["success_rate_cooldown_threshold"].(float64)
+	if !ok || threshold <= 0 || threshold >= 1 {
+		return defaultThreshold
+	}
+	return threshold
+}
+
+// SuccessRateCooldownMinSamples 触发冷却所需的最少样本数，避免样本过少时的误判，未配置或非法时回退默认值。
+func (a *Account) SuccessRateCooldownMinSamples() int {
+	const defaultMinSamples = 10
+	if a.Credentials == nil {
+		return defaultMinSamples
+	}
+	if n := parseTempUnschedInt(a.Credentials["success_rate_cooldown_min_samples"]); n > 0 {
+		return n
+	}
+	return defaultMinSamples
+}
+
+// SuccessRateCooldownMinutes 触发冷却后的临时不可调度时长（分钟），未配置或非法时回退默认值。
+func (a *Account) SuccessRateCooldownMinutes() int {
+	const defaultMinutes = 10
+	if a.Credentials == nil {
+		return defaultMinutes
+	}
+	if n := parseTempUnschedInt(a.Credentials["success_rate_cooldown_minutes"]); n > 0 {
+		return n
+	}
+	return defaultMinutes
+}
+
 func parseTempUnschedString(value any) string {
 	s, ok := value.(string)
 	if !ok {
@@ -531,6 +629,34 @@ func (a *Account) IsCustomErrorCodesEnabled() bool {
 	return false
 }
 
+// IsAnthropicVersionRequired 账号是否要求客户端必须携带 anthropic-version 请求头，
+// 缺失时应拒绝请求而非默认填充 2023-06-01
+func (a *Account) IsAnthropicVersionRequired() bool {
+	if a.Credentials == nil {
+		return false
+	}
+	if v, ok := a.Credentials["require_anthropic_version"]; ok {
+		if required, ok := v.(bool); ok {
+			return required
+		}
+	}
+	return false
+}
+
+// IsModelScopedRateLimitEnabled 账号是否按模型隔离限流：开启后，上游 429 仅标记触发请求所用的
+// 具体模型为限流，而不是将整个账号标记为限流，避免一个模型被限流时连带其他模型也被跳过调度
+func (a *Account) IsModelScopedRateLimitEnabled() bool {
+	if a.Credentials == nil {
+		return false
+	}
+	if v, ok := a.Credentials["model_scoped_rate_limit_enabled"]; ok {
+		if enabled, ok := v.(bool); ok {
+			return enabled
+		}
+	}
+	return false
+}
+
 func (a *Account) GetCustomErrorCodes() []int {
 	if a.Credentials == nil {
 		return nil
@@ -567,6 +693,49 @@ func (a *Account) ShouldHandleErrorCode(statusCode int) bool {
 	return false
 }
 
+// SupportsVision 账号是否支持图片/视觉输入。部分账号层级（如某些第三方中转渠道）
+// 不支持视觉输入，可通过 Credentials.supports_vision=false 显式声明；未配置时默认
+// 视为支持，避免存量账号因缺省字段被无故排除出调度候选。
+func (a *Account) SupportsVision() bool {
+	if a.Credentials == nil {
+		return true
+	}
+	if v, ok := a.Credentials["supports_vision"]; ok {
+		if supported, ok := v.(bool); ok {
+			return supported
+		}
+	}
+	return true
+}
+
+// SupportsTools 账号是否支持工具调用（tools/function calling）。可通过
+// Credentials.supports_tools=false 显式声明；未配置时默认视为支持。
+func (a *Account) SupportsTools() bool {
+	if a.Credentials == nil {
+		return true
+	}
+	if v, ok := a.Credentials["supports_tools"]; ok {
+		if supported, ok := v.(bool); ok {
+			return supported
+		}
+	}
+	return true
+}
+
+// SupportsExtendedThinking 账号是否支持 extended thinking。可通过
+// Credentials.supports_thinking=false 显式声明；未配置时默认视为支持。
+func (a *Account) SupportsExtendedThinking() bool {
+	if a.Credentials == nil {
+		return true
+	}
+	if v, ok := a.Credentials["supports_thinking"]; ok {
+		if supported, ok := v.(bool); ok {
+			return supported
+		}
+	}
+	return true
+}
+
 func (a *Account) IsInterceptWarmupEnabled() bool {
 	if a.Credentials == nil {
 		return false
@@ -752,6 +921,25 @@ func (a *Account) IsSessionIDMaskingEnabled() bool {
 	return false
 }
 
+// IsSystemCacheControlKept 检查是否保留 system 中的 cache_control
+// 仅适用于 Anthropic OAuth/SetupToken 类型账号
+// 默认会在模拟 Claude Code 请求时剥离 system 的 cache_control（部分上游不认可非官方客户端的缓存标记），
+// 启用后跳过剥离，适用于确认上游会尊重该字段并希望保留缓存命中率的账号
+func (a *Account) IsSystemCacheControlKept() bool {
+	if !a.IsAnthropicOAuthOrSetupToken() {
+		return false
+	}
+	if a.Extra == nil {
+		return false
+	}
+	if v, ok := a.Extra["keep_system_cache_control"]; ok {
+		if enabled, ok := v.(bool); ok {
+			return enabled
+		}
+	}
+	return false
+}
+
 // IsCacheTTLOverrideEnabled 检查是否启用缓存 TTL 强制替换
 // 仅适用于 Anthropic OAuth/SetupToken 类型账号
 // 启用后将所有 cache creation tokens 归入指定的 TTL 类型（5m 或 1h）
@@ -823,6 +1011,141 @@ func (a *Account) GetMaxSessions() int {
 	return 0
 }
 
+// GetAnthropicBetaBlacklist 获取该账号需要从 anthropic-beta header 中剔除的 beta 列表
+// 用于屏蔽与特定上游不兼容、会导致 400 错误的 beta 特性
+func (a *Account) GetAnthropicBetaBlacklist() []string {
+	if a.Extra == nil {
+		return nil
+	}
+	if v, ok := a.Extra["anthropic_beta_blacklist"]; ok {
+		return parseExtraStringList(v)
+	}
+	return nil
+}
+
+// GetWarmModels 返回该账号声明的“预热”模型列表。部分上游对冷启动（首次调用某模型）
+// 有明显延迟，标记为 warm 的模型在调度时会优先选择近期刚为该模型提供过服务的账号
+// （见 service.accountWarmth），以提升缓存命中率、减少冷启动延迟。
+func (a *Account) GetWarmModels() []string {
+	if a.Extra == nil {
+		return nil
+	}
+	if v, ok := a.Extra["warm_models"]; ok {
+		return parseExtraStringList(v)
+	}
+	return nil
+}
+
+// IsWarmModel 判断指定模型是否在该账号的预热模型列表中。
+func (a *Account) IsWarmModel(model string) bool {
+	for _, m := range a.GetWarmModels() {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyTransform 描述对上游请求体执行的单次声明式 JSON 转换，仅支持受限的 set/delete
+// 路径操作（按 gjson/sjson 路径语法），不支持任意代码执行。
+type BodyTransform struct {
+	Op    string `json:"op"`              // "set" 或 "delete"
+	Path  string `json:"path"`            // gjson/sjson 路径，如 "metadata.foo"
+	Value any    `json:"value,omitempty"` // op 为 "set" 时的目标值
+}
+
+// GetBodyTransforms 获取该账号的请求体转换规则，用于为个别上游适配固定字段改写
+// （如追加上游要求的固定字段）。仅对 API-key 类型账号生效。
+func (a *Account) GetBodyTransforms() []BodyTransform {
+	if a.Extra == nil {
+		return nil
+	}
+	raw, ok := a.Extra["body_transforms"]
+	if !ok {
+		return nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	transforms := make([]BodyTransform, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		op, _ := m["op"].(string)
+		path, _ := m["path"].(string)
+		if op == "" || path == "" {
+			continue
+		}
+		transforms = append(transforms, BodyTransform{Op: op, Path: path, Value: m["value"]})
+	}
+	return transforms
+}
+
+// ValidateBodyTransforms 校验账号 extra 中的 body_transforms 声明式转换配置，
+// 在保存账号配置时提前拒绝无效规则，避免转发请求时静默跳过。
+func ValidateBodyTransforms(extra map[string]any) error {
+	if extra == nil {
+		return nil
+	}
+	raw, ok := extra["body_transforms"]
+	if !ok {
+		return nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return fmt.Errorf("body_transforms must be an array")
+	}
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return fmt.Errorf("body_transforms[%d] must be an object", i)
+		}
+		op, _ := m["op"].(string)
+		switch op {
+		case "set":
+			if _, hasValue := m["value"]; !hasValue {
+				return fmt.Errorf("body_transforms[%d]: \"set\" requires a value", i)
+			}
+		case "delete":
+		default:
+			return fmt.Errorf("body_transforms[%d]: op must be \"set\" or \"delete\", got %q", i, op)
+		}
+		path, _ := m["path"].(string)
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("body_transforms[%d]: path is required", i)
+		}
+	}
+	return nil
+}
+
+// GetUpstreamHeaders 获取该账号的上游默认请求头覆盖，用于按账号粒度补充/覆盖
+// 分组级 UpstreamHeaders；仅对 API-key 类型账号生效，不影响认证类头部。
+func (a *Account) GetUpstreamHeaders() map[string]string {
+	if a.Extra == nil {
+		return nil
+	}
+	raw, ok := a.Extra["upstream_headers"]
+	if !ok {
+		return nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	headers := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		headers[k] = s
+	}
+	return headers
+}
+
 // GetSessionIdleTimeoutMinutes 获取会话空闲超时分钟数
 // 默认值为 5 分钟
 func (a *Account) GetSessionIdleTimeoutMinutes() int {
@@ -920,3 +1243,20 @@ func parseExtraInt(value any) int {
 	}
 	return 0
 }
+
+// parseExtraStringList 从 extra 字段解析字符串列表，兼容 []any 和 []string
+func parseExtraStringList(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, strings.TrimSpace(s))
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_GetBodyTransforms(t *testing.T) {
+	account := &Account{}
+	require.Nil(t, account.GetBodyTransforms())
+
+	account.Extra = map[string]any{
+		"body_transforms": []any{
+			map[string]any{"op": "set", "path": "metadata.vendor", "value": "acme"},
+			map[string]any{"op": "delete", "path": "metadata.user_id"},
+			map[string]any{"op": "unknown", "path": "ignored"},
+			map[string]any{"op": "set", "path": ""},
+		},
+	}
+
+	got := account.GetBodyTransforms()
+	require.Equal(t, []BodyTransform{
+		{Op: "set", Path: "metadata.vendor", Value: "acme"},
+		{Op: "delete", Path: "metadata.user_id"},
+		{Op: "unknown", Path: "ignored"},
+	}, got)
+}
+
+func TestApplyBodyTransforms_Set(t *testing.T) {
+	body := []byte(`{"model":"claude-3-5-sonnet","metadata":{}}`)
+	result := applyBodyTransforms(body, []BodyTransform{
+		{Op: "set", Path: "metadata.vendor", Value: "acme"},
+	})
+	require.JSONEq(t, `{"model":"claude-3-5-sonnet","metadata":{"vendor":"acme"}}`, string(result))
+}
+
+func TestApplyBodyTransforms_Delete(t *testing.T) {
+	body := []byte(`{"model":"claude-3-5-sonnet","metadata":{"user_id":"u1","vendor":"acme"}}`)
+	result := applyBodyTransforms(body, []BodyTransform{
+		{Op: "delete", Path: "metadata.user_id"},
+	})
+	require.JSONEq(t, `{"model":"claude-3-5-sonnet","metadata":{"vendor":"acme"}}`, string(result))
+}
+
+func TestApplyBodyTransforms_InvalidPathIsSkipped(t *testing.T) {
+	body := []byte(`{"model":"claude-3-5-sonnet"}`)
+	result := applyBodyTransforms(body, []BodyTransform{
+		{Op: "set", Path: "", Value: "acme"},
+	})
+	require.JSONEq(t, `{"model":"claude-3-5-sonnet"}`, string(result))
+}
+
+func TestValidateBodyTransforms(t *testing.T) {
+	require.NoError(t, ValidateBodyTransforms(nil))
+	require.NoError(t, ValidateBodyTransforms(map[string]any{}))
+
+	require.NoError(t, ValidateBodyTransforms(map[string]any{
+		"body_transforms": []any{
+			map[string]any{"op": "set", "path": "metadata.vendor", "value": "acme"},
+			map[string]any{"op": "delete", "path": "metadata.user_id"},
+		},
+	}))
+
+	require.Error(t, ValidateBodyTransforms(map[string]any{
+		"body_transforms": "not-an-array",
+	}))
+
+	require.Error(t, ValidateBodyTransforms(map[string]any{
+		"body_transforms": []any{
+			map[string]any{"op": "execute", "path": "metadata.vendor"},
+		},
+	}))
+
+	require.Error(t, ValidateBodyTransforms(map[string]any{
+		"body_transforms": []any{
+			map[string]any{"op": "set", "path": "metadata.vendor"},
+		},
+	}))
+
+	require.Error(t, ValidateBodyTransforms(map[string]any{
+		"body_transforms": []any{
+			map[string]any{"op": "delete", "path": ""},
+		},
+	}))
+}
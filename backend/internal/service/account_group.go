@@ -6,7 +6,10 @@ type AccountGroup struct {
 	AccountID int64
 	GroupID   int64
 	Priority  int
-	CreatedAt time.Time
+	// ReservedSlots 为该分组在此账号上预留的并发槽位数；预留槽位只能被该分组占用，
+	// 超出部分（账号总并发 - 预留槽位）由绑定该账号的所有分组共享。0 表示不预留。
+	ReservedSlots int
+	CreatedAt     time.Time
 
 	Account *Account
 	Group   *Group
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_IsWithinQuietHours_UnconfiguredAlwaysFalse(t *testing.T) {
+	a := Account{}
+	require.False(t, a.IsWithinQuietHours(time.Now()))
+}
+
+func TestAccount_IsWithinQuietHours_SuppressesWithinSameDayWindow(t *testing.T) {
+	a := Account{QuietHoursStartMinute: intPtr(9 * 60), QuietHoursEndMinute: intPtr(17 * 60)}
+
+	inside := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.True(t, a.IsWithinQuietHours(inside))
+}
+
+func TestAccount_IsWithinQuietHours_FiresOutsideSameDayWindow(t *testing.T) {
+	a := Account{QuietHoursStartMinute: intPtr(9 * 60), QuietHoursEndMinute: intPtr(17 * 60)}
+
+	outside := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	require.False(t, a.IsWithinQuietHours(outside))
+}
+
+func TestAccount_IsWithinQuietHours_SuppressesWithinOvernightWindow(t *testing.T) {
+	// 22:00 - 06:00 wraps past midnight.
+	a := Account{QuietHoursStartMinute: intPtr(22 * 60), QuietHoursEndMinute: intPtr(6 * 60)}
+
+	lateNight := time.Date(2026, 1, 1, 23, 30, 0, 0, time.UTC)
+	earlyMorning := time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC)
+	require.True(t, a.IsWithinQuietHours(lateNight))
+	require.True(t, a.IsWithinQuietHours(earlyMorning))
+}
+
+func TestAccount_IsWithinQuietHours_FiresOutsideOvernightWindow(t *testing.T) {
+	a := Account{QuietHoursStartMinute: intPtr(22 * 60), QuietHoursEndMinute: intPtr(6 * 60)}
+
+	midday := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.False(t, a.IsWithinQuietHours(midday))
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// accountSelectionFairness 在一个滑动时间窗口内跟踪各用户被选中账号的次数，用于判断单个
+// 用户是否在短时间内占用了不成比例的调度份额。仅在 Gateway.Scheduling.FairnessEnabled 开启
+// 时由 NewGatewayService 创建，默认关闭（字段为 nil，所有方法对 nil 接收者安全）。
+type accountSelectionFairness struct {
+	mu        sync.Mutex
+	window    time.Duration
+	maxShare  float64
+	minSample int
+	records   []fairnessRecord
+}
+
+// fairnessRecord 记录一次账号选中事件发生的用户与时间。
+type fairnessRecord struct {
+	userID int64
+	at     time.Time
+}
+
+// fairnessMinSample 样本量低于该值时不判定降权，避免低流量场景下个别请求被误判为“垄断”。
+const fairnessMinSample = 4
+
+// newAccountSelectionFairness 创建公平性跟踪器。window 为统计窗口，maxShare 为单用户在窗口内
+// 允许占用的最大份额（(0,1]），超过该阈值且样本量达到 fairnessMinSample 时判定需要降权。
+func newAccountSelectionFairness(window time.Duration, maxShare float64) *accountSelectionFairness {
+	return &accountSelectionFairness{
+		window:    window,
+		maxShare:  maxShare,
+		minSample: fairnessMinSample,
+	}
+}
+
+// recordSelection 记录一次为指定用户选中账号的事件。
+func (f *accountSelectionFairness) recordSelection(userID int64) {
+	if f == nil || userID <= 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	f.pruneLocked(now)
+	f.records = append(f.records, fairnessRecord{userID: userID, at: now})
+}
+
+// shouldDeprioritize 判断指定用户在当前窗口内的调度份额是否已超过阈值。
+func (f *accountSelectionFairness) shouldDeprioritize(userID int64) bool {
+	if f == nil || userID <= 0 {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruneLocked(time.Now())
+	if len(f.records) < f.minSample {
+		return false
+	}
+	var userCount int
+	for _, r := range f.records {
+		if r.userID == userID {
+			userCount++
+		}
+	}
+	return float64(userCount)/float64(len(f.records)) > f.maxShare
+}
+
+// pruneLocked 丢弃窗口之外的旧记录，调用方必须持有 f.mu。
+func (f *accountSelectionFairness) pruneLocked(now time.Time) {
+	cutoff := now.Add(-f.window)
+	i := 0
+	for i < len(f.records) && f.records[i].at.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		f.records = append([]fairnessRecord(nil), f.records[i:]...)
+	}
+}
+
+// filterFairCandidates 在公平性开启且 userID 近期份额已超过阈值时，从同一优先级/负载层的候选
+// 集合中排除负载感知算法本会选中的“最佳”候选（LRU 意义下最近最少使用的账号），把它让给份额
+// 更公平的其它用户，当前用户改用该层内次优的候选。候选只剩一个时不做任何排除，避免无账号可用。
+func filterFairCandidates(candidates []accountWithLoad, userID int64, fairness *accountSelectionFairness) []accountWithLoad {
+	if fairness == nil || len(candidates) <= 1 {
+		return candidates
+	}
+	if !fairness.shouldDeprioritize(userID) {
+		return candidates
+	}
+	best := selectByLRU(candidates, false)
+	if best == nil {
+		return candidates
+	}
+	filtered := make([]accountWithLoad, 0, len(candidates)-1)
+	skipped := false
+	for _, c := range candidates {
+		if !skipped && c.account.ID == best.account.ID {
+			skipped = true
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
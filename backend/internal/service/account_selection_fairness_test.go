@@ -0,0 +1,132 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountSelectionFairness_NilSafe(t *testing.T) {
+	var f *accountSelectionFairness
+	require.False(t, f.shouldDeprioritize(1))
+	require.NotPanics(t, func() { f.recordSelection(1) })
+}
+
+func TestAccountSelectionFairness_BelowMinSample_NeverDeprioritizes(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	for i := 0; i < fairnessMinSample-1; i++ {
+		f.recordSelection(1)
+	}
+	require.False(t, f.shouldDeprioritize(1), "sample size below fairnessMinSample must not trigger deprioritization")
+}
+
+func TestAccountSelectionFairness_HeavyUserExceedsShare(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	for i := 0; i < 8; i++ {
+		f.recordSelection(1) // user 1 dominates recent selections
+	}
+	f.recordSelection(2)
+
+	require.True(t, f.shouldDeprioritize(1))
+	require.False(t, f.shouldDeprioritize(2))
+}
+
+func TestAccountSelectionFairness_OldRecordsExpireOutsideWindow(t *testing.T) {
+	f := newAccountSelectionFairness(time.Millisecond, 0.5)
+	for i := 0; i < 8; i++ {
+		f.recordSelection(1)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	require.False(t, f.shouldDeprioritize(1), "records outside the window must be pruned before evaluating share")
+}
+
+func TestFilterFairCandidates_DisabledWhenFairnessNil(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{}},
+	}
+	result := filterFairCandidates(candidates, 1, nil)
+	require.Len(t, result, 2)
+}
+
+func TestFilterFairCandidates_DeprioritizesHeavyUserBestPick(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	for i := 0; i < 8; i++ {
+		f.recordSelection(1)
+	}
+
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now().Add(-time.Minute)
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, LastUsedAt: &older}, loadInfo: &AccountLoadInfo{}}, // would normally win LRU
+		{account: &Account{ID: 2, LastUsedAt: &newer}, loadInfo: &AccountLoadInfo{}},
+	}
+
+	result := filterFairCandidates(candidates, 1, f)
+	require.Len(t, result, 1)
+	require.Equal(t, int64(2), result[0].account.ID, "heavy user should be routed away from the LRU-preferred account")
+}
+
+func TestFilterFairCandidates_LightUserKeepsBestPick(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	for i := 0; i < 8; i++ {
+		f.recordSelection(1)
+	}
+
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now().Add(-time.Minute)
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, LastUsedAt: &older}, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2, LastUsedAt: &newer}, loadInfo: &AccountLoadInfo{}},
+	}
+
+	result := filterFairCandidates(candidates, 2, f)
+	require.Len(t, result, 2, "a user under the fair share threshold keeps the full candidate set")
+}
+
+func TestFilterFairCandidates_SingleCandidateNeverEmptied(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	for i := 0; i < 8; i++ {
+		f.recordSelection(1)
+	}
+
+	candidates := []accountWithLoad{{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{}}}
+	result := filterFairCandidates(candidates, 1, f)
+	require.Len(t, result, 1, "must never filter down to zero candidates")
+}
+
+// TestAccountSelectionFairness_FairerDistributionAcrossTwoUsers simulates a heavy user (user 1)
+// that fires far more requests than a light user (user 2) and asserts that, once user 1's recent
+// share crosses the configured threshold, they stop being routed to the single best (LRU) account
+// on every request — leaving it available for user 2 instead of being monopolized.
+func TestAccountSelectionFairness_FairerDistributionAcrossTwoUsers(t *testing.T) {
+	f := newAccountSelectionFairness(time.Minute, 0.5)
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now().Add(-time.Minute)
+	candidatesFor := func() []accountWithLoad {
+		return []accountWithLoad{
+			{account: &Account{ID: 1, LastUsedAt: &older}, loadInfo: &AccountLoadInfo{}}, // LRU-preferred
+			{account: &Account{ID: 2, LastUsedAt: &newer}, loadInfo: &AccountLoadInfo{}},
+		}
+	}
+
+	// User 1 fires far more requests than user 2 (10:1), as a single heavy user would.
+	for i := 0; i < 10; i++ {
+		selected := selectByLRU(filterFairCandidates(candidatesFor(), 1, f), false)
+		f.recordSelection(1)
+		require.NotNil(t, selected)
+	}
+
+	// Once user 1's share has crossed the threshold, a further request from user 1 is routed away
+	// from account 1 (the LRU-preferred account)...
+	userOneSelected := selectByLRU(filterFairCandidates(candidatesFor(), 1, f), false)
+	require.Equal(t, int64(2), userOneSelected.account.ID)
+
+	// ...while user 2, still under the threshold, keeps getting the best account.
+	userTwoSelected := selectByLRU(filterFairCandidates(candidatesFor(), 2, f), false)
+	require.Equal(t, int64(1), userTwoSelected.account.ID)
+}
@@ -0,0 +1,32 @@
+package service
+
+import "time"
+
+// filterNewAccountBoostCandidates 在候选集合中存在账号处于“新账号 break-in”窗口内
+// （CreatedAt 距今不超过 window）时，将候选收窄为这些账号，使其在本层过滤中越过既有账号
+// 的 Priority 优先被调度，以便尽快为新接入账号积累验证流量；window<=0（未配置/关闭）或不存在
+// 处于窗口内的账号时原样返回候选集合，不影响既有调度行为。
+func filterNewAccountBoostCandidates(candidates []accountWithLoad, window time.Duration) []accountWithLoad {
+	if window <= 0 || len(candidates) <= 1 {
+		return candidates
+	}
+	now := time.Now()
+	boosted := make([]accountWithLoad, 0, len(candidates))
+	for _, c := range candidates {
+		if isWithinNewAccountBoostWindow(c.account, now, window) {
+			boosted = append(boosted, c)
+		}
+	}
+	if len(boosted) == 0 {
+		return candidates
+	}
+	return boosted
+}
+
+// isWithinNewAccountBoostWindow 判断账号是否仍处于新账号 break-in 窗口内。
+func isWithinNewAccountBoostWindow(acc *Account, now time.Time, window time.Duration) bool {
+	if acc == nil || acc.CreatedAt.IsZero() || window <= 0 {
+		return false
+	}
+	return now.Sub(acc.CreatedAt) < window
+}
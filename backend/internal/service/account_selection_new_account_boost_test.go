@@ -0,0 +1,79 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterNewAccountBoostCandidates_DisabledWhenWindowZero(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, CreatedAt: time.Now()}},
+		{account: &Account{ID: 2, CreatedAt: time.Now().Add(-time.Hour)}},
+	}
+	result := filterNewAccountBoostCandidates(candidates, 0)
+	require.Len(t, result, 2)
+}
+
+func TestFilterNewAccountBoostCandidates_NarrowsToFreshAccountWithinWindow(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, CreatedAt: time.Now()}},                      // fresh
+		{account: &Account{ID: 2, CreatedAt: time.Now().Add(-24 * time.Hour)}}, // established
+	}
+	result := filterNewAccountBoostCandidates(candidates, time.Hour)
+	require.Len(t, result, 1)
+	require.Equal(t, int64(1), result[0].account.ID)
+}
+
+func TestFilterNewAccountBoostCandidates_NoFreshAccountReturnsAllCandidates(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, CreatedAt: time.Now().Add(-48 * time.Hour)}},
+		{account: &Account{ID: 2, CreatedAt: time.Now().Add(-24 * time.Hour)}},
+	}
+	result := filterNewAccountBoostCandidates(candidates, time.Hour)
+	require.Len(t, result, 2)
+}
+
+func TestFilterNewAccountBoostCandidates_AccountNormalizesAfterWindowExpires(t *testing.T) {
+	// Account was created just outside the boost window: it must no longer be
+	// singled out, and normal priority-based selection resumes.
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1, CreatedAt: time.Now().Add(-2 * time.Hour)}},
+		{account: &Account{ID: 2, CreatedAt: time.Now().Add(-24 * time.Hour)}},
+	}
+	result := filterNewAccountBoostCandidates(candidates, time.Hour)
+	require.Len(t, result, 2, "account outside the boost window must normalize back into the regular candidate pool")
+}
+
+// TestAccountSelectionNewAccountBoost_OverridesPriorityDuringWindow demonstrates the
+// full layered selection behavior described in the request: within the boost window a
+// freshly created account is preferred even over an established account with a
+// numerically better (lower) Priority, and once the window elapses priority-based
+// selection governs again.
+func TestAccountSelectionNewAccountBoost_OverridesPriorityDuringWindow(t *testing.T) {
+	fresh := accountWithLoad{
+		account:  &Account{ID: 1, Priority: 10, CreatedAt: time.Now()},
+		loadInfo: &AccountLoadInfo{LoadRate: 0},
+	}
+	established := accountWithLoad{
+		account:  &Account{ID: 2, Priority: 1, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)},
+		loadInfo: &AccountLoadInfo{LoadRate: 0},
+	}
+	candidates := []accountWithLoad{established, fresh}
+
+	// During the window: boosted candidates win before priority is even considered.
+	boosted := filterNewAccountBoostCandidates(candidates, time.Hour)
+	boosted = filterByMinPriority(boosted)
+	require.Len(t, boosted, 1)
+	require.Equal(t, int64(1), boosted[0].account.ID)
+
+	// Once the window is disabled (simulating it having elapsed for this account),
+	// normal Priority-based filtering selects the established, higher-priority account.
+	normal := filterNewAccountBoostCandidates(candidates, 0)
+	normal = filterByMinPriority(normal)
+	require.Len(t, normal, 1)
+	require.Equal(t, int64(2), normal[0].account.ID)
+}
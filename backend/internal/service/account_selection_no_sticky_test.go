@@ -0,0 +1,54 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectAccountWithLoadAwareness_EmptySessionHashSkipsStickyLookupAndBinding 验证
+// 调用方（如携带 x-sub2api-no-sticky 请求头的请求）传入空 sessionHash 时，既不会查询
+// 已有的粘性会话绑定，也不会在选中账号后创建新的绑定。
+func TestSelectAccountWithLoadAwareness_EmptySessionHashSkipsStickyLookupAndBinding(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 5},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+
+	// 预置一条以空字符串为 key 的绑定，证明即便存在，空 sessionHash 也不会触发查询命中它。
+	cache := &mockGatewayCacheForPlatform{
+		sessionBindings: map[string]int64{"": 999},
+	}
+
+	cfg := testConfig()
+	cfg.Gateway.Scheduling.LoadBatchEnabled = true
+
+	concurrencyCache := &mockConcurrencyCache{}
+
+	svc := &GatewayService{
+		accountRepo:        repo,
+		cache:              cache,
+		cfg:                cfg,
+		concurrencyService: NewConcurrencyService(concurrencyCache),
+	}
+
+	result, err := svc.SelectAccountWithLoadAwareness(ctx, nil, "", "claude-3-5-sonnet-20241022", nil, "")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.NotNil(t, result.Account)
+	require.Equal(t, int64(1), result.Account.ID, "空 sessionHash 应回退到负载感知选择，而不是命中预置的空 key 绑定")
+
+	_, stillPresent := cache.sessionBindings[""]
+	require.True(t, stillPresent)
+	require.Equal(t, int64(999), cache.sessionBindings[""], "空 sessionHash 不应覆盖或创建新的粘性会话绑定")
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// accountWarmth 在一个滑动时间窗口内跟踪各账号最近实际服务过的模型，用于账号选择时
+// 对声明了“预热”模型（见 Account.GetWarmModels）的账号做 tie-break 偏好：同等条件下
+// 优先选择近期刚为该模型提供过服务的账号，减少对冷启动延迟敏感上游的切换开销。
+// 与 accountSelectionFairness 一样常驻创建，默认不影响调度——只有账号显式声明了
+// warm_models 且近期确实服务过该模型时才会产生偏好。
+type accountWarmth struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	lastUse map[int64]map[string]time.Time // accountID -> model -> 最近一次服务该模型的时间
+}
+
+// newAccountWarmth 创建预热跟踪器，ttl 为“近期”的判定窗口。
+func newAccountWarmth(ttl time.Duration) *accountWarmth {
+	return &accountWarmth{
+		ttl:     ttl,
+		lastUse: make(map[int64]map[string]time.Time),
+	}
+}
+
+// recordUsage 记录账号刚为指定模型提供了一次服务。
+func (w *accountWarmth) recordUsage(accountID int64, model string) {
+	if w == nil || accountID <= 0 || model == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	models := w.lastUse[accountID]
+	if models == nil {
+		models = make(map[string]time.Time)
+		w.lastUse[accountID] = models
+	}
+	models[model] = time.Now()
+}
+
+// isRecentlyWarm 判断账号是否在窗口内服务过指定模型。
+func (w *accountWarmth) isRecentlyWarm(accountID int64, model string) bool {
+	if w == nil || accountID <= 0 || model == "" {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lastUsedAt, ok := w.lastUse[accountID][model]
+	if !ok {
+		return false
+	}
+	return time.Since(lastUsedAt) <= w.ttl
+}
+
+// filterWarmCandidates 在候选集合中存在账号同时满足「声明了该模型为 warm」与「近期确实
+// 服务过该模型」时，将候选收窄为这些账号，让 LRU 在其中做最终选择，从而让调度偏向近期
+// 服务过该模型的账号；不存在这样的账号时原样返回候选集合，不影响既有调度行为。
+func filterWarmCandidates(candidates []accountWithLoad, model string, warmth *accountWarmth) []accountWithLoad {
+	if warmth == nil || model == "" || len(candidates) <= 1 {
+		return candidates
+	}
+	warm := make([]accountWithLoad, 0, len(candidates))
+	for _, c := range candidates {
+		if c.account.IsWarmModel(model) && warmth.isRecentlyWarm(c.account.ID, model) {
+			warm = append(warm, c)
+		}
+	}
+	if len(warm) == 0 {
+		return candidates
+	}
+	return warm
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountWarmth_NilSafe(t *testing.T) {
+	var w *accountWarmth
+	require.False(t, w.isRecentlyWarm(1, "gpt-4"))
+	require.NotPanics(t, func() { w.recordUsage(1, "gpt-4") })
+}
+
+func TestAccountWarmth_RecordAndCheck(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+	require.False(t, w.isRecentlyWarm(1, "gpt-4"), "unused account/model pair must not be warm")
+
+	w.recordUsage(1, "gpt-4")
+	require.True(t, w.isRecentlyWarm(1, "gpt-4"))
+	require.False(t, w.isRecentlyWarm(1, "gpt-3.5"), "usage of one model must not warm another model on the same account")
+	require.False(t, w.isRecentlyWarm(2, "gpt-4"), "usage on one account must not warm another account")
+}
+
+func TestAccountWarmth_ExpiresOutsideWindow(t *testing.T) {
+	w := newAccountWarmth(time.Millisecond)
+	w.recordUsage(1, "gpt-4")
+	time.Sleep(5 * time.Millisecond)
+	require.False(t, w.isRecentlyWarm(1, "gpt-4"), "usage outside the window must no longer count as warm")
+}
+
+func TestGetWarmModels_ParsesExtraList(t *testing.T) {
+	a := &Account{Extra: map[string]any{"warm_models": []any{"gpt-4", "gpt-4-turbo"}}}
+	require.Equal(t, []string{"gpt-4", "gpt-4-turbo"}, a.GetWarmModels())
+	require.True(t, a.IsWarmModel("gpt-4"))
+	require.False(t, a.IsWarmModel("gpt-3.5"))
+}
+
+func TestGetWarmModels_NoExtraReturnsNil(t *testing.T) {
+	a := &Account{}
+	require.Nil(t, a.GetWarmModels())
+	require.False(t, a.IsWarmModel("gpt-4"))
+}
+
+func TestFilterWarmCandidates_NoWarmAccountsReturnsAllCandidates(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{}},
+	}
+	result := filterWarmCandidates(candidates, "gpt-4", w)
+	require.Len(t, result, 2)
+}
+
+func TestFilterWarmCandidates_NarrowsToRecentlyWarmAccount(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+	warmAccount := &Account{ID: 1, Extra: map[string]any{"warm_models": []any{"gpt-4"}}}
+	w.recordUsage(1, "gpt-4")
+
+	candidates := []accountWithLoad{
+		{account: warmAccount, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{}},
+	}
+	result := filterWarmCandidates(candidates, "gpt-4", w)
+	require.Len(t, result, 1)
+	require.Equal(t, int64(1), result[0].account.ID)
+}
+
+func TestFilterWarmCandidates_DeclaredButNotYetUsedDoesNotNarrow(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+	warmAccount := &Account{ID: 1, Extra: map[string]any{"warm_models": []any{"gpt-4"}}}
+
+	candidates := []accountWithLoad{
+		{account: warmAccount, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{}},
+	}
+	result := filterWarmCandidates(candidates, "gpt-4", w)
+	require.Len(t, result, 2, "a warm model declaration alone (never served yet) must not narrow candidates")
+}
+
+func TestFilterWarmCandidates_DisabledWhenWarmthNil(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{}},
+	}
+	result := filterWarmCandidates(candidates, "gpt-4", nil)
+	require.Len(t, result, 2)
+}
+
+func TestFilterWarmCandidates_SingleCandidateUnaffected(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+	candidates := []accountWithLoad{{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{}}}
+	result := filterWarmCandidates(candidates, "gpt-4", w)
+	require.Len(t, result, 1)
+}
+
+// TestAccountSelectionWarmth_PrefersRecentlyWarmAccountOnTie 模拟两个账号对同一模型都
+// 可调度、负载相同，但其中一个刚服务过该模型且声明其为 warm：调度应优先选中该账号，
+// 而不是 LRU 原本会选中的、最久未用的账号。
+func TestAccountSelectionWarmth_PrefersRecentlyWarmAccountOnTie(t *testing.T) {
+	w := newAccountWarmth(time.Minute)
+
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now().Add(-time.Minute)
+	warmAccount := &Account{ID: 2, LastUsedAt: &newer, Extra: map[string]any{"warm_models": []any{"gpt-4"}}}
+	lruPreferred := &Account{ID: 1, LastUsedAt: &older}
+
+	candidates := []accountWithLoad{
+		{account: lruPreferred, loadInfo: &AccountLoadInfo{}},
+		{account: warmAccount, loadInfo: &AccountLoadInfo{}},
+	}
+
+	// Without any warm usage yet, LRU picks the least-recently-used account.
+	require.Equal(t, int64(1), selectByLRU(filterWarmCandidates(candidates, "gpt-4", w), false).account.ID)
+
+	// Once the warm account has actually served the model, it is preferred for further requests.
+	w.recordUsage(2, "gpt-4")
+	selected := selectByLRU(filterWarmCandidates(candidates, "gpt-4", w), false)
+	require.Equal(t, int64(2), selected.account.ID, "recently-warm account should be preferred over the LRU pick")
+}
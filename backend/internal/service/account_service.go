@@ -60,7 +60,7 @@ type AccountRepository interface {
 	ClearRateLimit(ctx context.Context, id int64) error
 	ClearAntigravityQuotaScopes(ctx context.Context, id int64) error
 	ClearModelRateLimits(ctx context.Context, id int64) error
-	UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string) error
+	UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string, utilization *int) error
 	UpdateExtra(ctx context.Context, id int64, updates map[string]any) error
 	BulkUpdate(ctx context.Context, ids []int64, updates AccountBulkUpdate) (int64, error)
 }
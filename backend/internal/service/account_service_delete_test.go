@@ -175,7 +175,7 @@ func (s *accountRepoStub) ClearModelRateLimits(ctx context.Context, id int64) er
 	panic("unexpected ClearModelRateLimits call")
 }
 
-func (s *accountRepoStub) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string) error {
+func (s *accountRepoStub) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string, utilization *int) error {
 	panic("unexpected UpdateSessionWindow call")
 }
 
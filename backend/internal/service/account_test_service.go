@@ -12,6 +12,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 
@@ -166,6 +167,53 @@ func (s *AccountTestService) TestAccountConnection(c *gin.Context, accountID int
 	return s.testClaudeAccountConnection(c, account, modelID)
 }
 
+// RetryConnectionResult captures the outcome of a one-shot, non-streaming
+// connection validation (see ValidateConnectionOnce).
+type RetryConnectionResult struct {
+	Success bool
+	Error   string
+}
+
+// ValidateConnectionOnce 以与手动测试（TestAccountConnection）完全相同的方式对账号发起
+// 一次性连接校验，但在内存中捕获 SSE 事件流的最终结果而不是写入客户端响应，供错误账号
+// 的“立即重试”场景使用：校验成功返回 Success=true，失败返回 Success=false 及错误信息。
+func (s *AccountTestService) ValidateConnectionOnce(ctx context.Context, accountID int64, modelID string) *RetryConnectionResult {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/internal/account-test", nil).WithContext(ctx)
+
+	testErr := s.TestAccountConnection(c, accountID, modelID)
+	result := parseLastTestEvent(rec.Body.Bytes())
+	if testErr != nil && result.Error == "" {
+		result.Error = testErr.Error()
+	}
+	return result
+}
+
+// parseLastTestEvent 扫描 SSE 事件流，返回最后一次 test_complete/error 事件对应的结果。
+func parseLastTestEvent(body []byte) *RetryConnectionResult {
+	result := &RetryConnectionResult{}
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event TestEvent
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case "test_complete":
+			result.Success = event.Success
+			result.Error = ""
+		case "error":
+			result.Success = false
+			result.Error = event.Error
+		}
+	}
+	return result
+}
+
 // testClaudeAccountConnection tests an Anthropic Claude account's connection
 func (s *AccountTestService) testClaudeAccountConnection(c *gin.Context, account *Account, modelID string) error {
 	ctx := c.Request.Context()
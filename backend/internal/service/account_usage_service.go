@@ -29,11 +29,15 @@ type UsageLogRepository interface {
 
 	GetAccountWindowStats(ctx context.Context, accountID int64, startTime time.Time) (*usagestats.AccountStats, error)
 	GetAccountTodayStats(ctx context.Context, accountID int64) (*usagestats.AccountStats, error)
+	GetGroupWindowStats(ctx context.Context, groupID int64, startTime time.Time) (*usagestats.AccountStats, error)
 
 	// Admin dashboard stats
 	GetDashboardStats(ctx context.Context) (*usagestats.DashboardStats, error)
 	GetUsageTrendWithFilters(ctx context.Context, startTime, endTime time.Time, granularity string, userID, apiKeyID, accountID, groupID int64, model string, stream *bool, billingType *int8) ([]usagestats.TrendDataPoint, error)
 	GetModelStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) ([]usagestats.ModelStat, error)
+	GetTagStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) ([]usagestats.TagStat, error)
+	GetAccountStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, groupID int64, stream *bool, billingType *int8) ([]usagestats.AccountStat, error)
+	GetCacheSavingsStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) (*usagestats.CacheSavingsStats, error)
 	GetAPIKeyUsageTrend(ctx context.Context, startTime, endTime time.Time, granularity string, limit int) ([]usagestats.APIKeyUsageTrendPoint, error)
 	GetUserUsageTrend(ctx context.Context, startTime, endTime time.Time, granularity string, limit int) ([]usagestats.UserUsageTrendPoint, error)
 	GetBatchUserUsageStats(ctx context.Context, userIDs []int64) (map[int64]*usagestats.BatchUserUsageStats, error)
@@ -59,6 +63,14 @@ type UsageLogRepository interface {
 	GetAccountStatsAggregated(ctx context.Context, accountID int64, startTime, endTime time.Time) (*usagestats.UsageStats, error)
 	GetModelStatsAggregated(ctx context.Context, modelName string, startTime, endTime time.Time) (*usagestats.UsageStats, error)
 	GetDailyStatsAggregated(ctx context.Context, userID int64, startTime, endTime time.Time) ([]map[string]any, error)
+
+	// GetAccountTokensPerSecond 返回账号在时间范围内按请求计算的平均输出 tokens/秒
+	// （每条请求的 output_tokens / duration_ms 取平均），用于吞吐量容量规划。
+	GetAccountTokensPerSecond(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error)
+
+	// GetAccountCacheHitRatio 返回账号在时间范围内的 prompt cache 命中率
+	// （cache_read_tokens / (cache_read_tokens + cache_creation_tokens)）。
+	GetAccountCacheHitRatio(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error)
 }
 
 // apiUsageCache 缓存从 Anthropic API 获取的使用率数据（utilization, resets_at）
@@ -438,6 +450,24 @@ func (s *AccountUsageService) GetAccountUsageStats(ctx context.Context, accountI
 	return stats, nil
 }
 
+// GetAccountTokensPerSecond 返回账号在时间范围内的平均输出吞吐量（tokens/秒），供容量规划参考
+func (s *AccountUsageService) GetAccountTokensPerSecond(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error) {
+	tokensPerSecond, err := s.usageLogRepo.GetAccountTokensPerSecond(ctx, accountID, startTime, endTime)
+	if err != nil {
+		return 0, fmt.Errorf("get account tokens per second failed: %w", err)
+	}
+	return tokensPerSecond, nil
+}
+
+// GetAccountCacheHitRatio 返回账号在时间范围内的 prompt cache 命中率，供运营判断该账号是否适合作为粘性会话的优先目标
+func (s *AccountUsageService) GetAccountCacheHitRatio(ctx context.Context, accountID int64, startTime, endTime time.Time) (float64, error) {
+	ratio, err := s.usageLogRepo.GetAccountCacheHitRatio(ctx, accountID, startTime, endTime)
+	if err != nil {
+		return 0, fmt.Errorf("get account cache hit ratio failed: %w", err)
+	}
+	return ratio, nil
+}
+
 // fetchOAuthUsageRaw 从 Anthropic API 获取原始响应（不构建 UsageInfo）
 // 如果账号开启了 TLS 指纹，则使用 TLS 指纹伪装
 // 如果有缓存的 Fingerprint，则使用缓存的 User-Agent 等信息
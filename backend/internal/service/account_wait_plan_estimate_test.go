@@ -0,0 +1,75 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateWaitDuration(t *testing.T) {
+	t.Run("zero timeout yields zero estimate", func(t *testing.T) {
+		require.Equal(t, time.Duration(0), estimateWaitDuration(5, 2, 0))
+	})
+
+	t.Run("scales with queue position relative to concurrency", func(t *testing.T) {
+		got := estimateWaitDuration(1, 4, 10*time.Second)
+		require.Equal(t, 5*time.Second, got, "(1+1)/4 * 10s")
+	})
+
+	t.Run("caps at timeout", func(t *testing.T) {
+		got := estimateWaitDuration(100, 1, 10*time.Second)
+		require.Equal(t, 10*time.Second, got)
+	})
+
+	t.Run("non-positive concurrency treated as one slot", func(t *testing.T) {
+		got := estimateWaitDuration(0, 0, 10*time.Second)
+		require.Equal(t, 10*time.Second, got)
+	})
+}
+
+func TestSelectAccountWithLoadAwareness_SaturatedAccountWaitPlanHasEstimatedWait(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 2},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+
+	cache := &mockGatewayCacheForPlatform{
+		sessionBindings: map[string]int64{"sticky": 1},
+	}
+
+	cfg := testConfig()
+	cfg.Gateway.Scheduling.LoadBatchEnabled = true
+	cfg.Gateway.Scheduling.StickySessionMaxWaiting = 5
+	cfg.Gateway.Scheduling.StickySessionWaitTimeout = 10 * time.Second
+
+	concurrencyCache := &mockConcurrencyCache{
+		acquireResults: map[int64]bool{1: false},
+		waitCounts:     map[int64]int{1: 3},
+	}
+
+	svc := &GatewayService{
+		accountRepo:        repo,
+		cache:              cache,
+		cfg:                cfg,
+		concurrencyService: NewConcurrencyService(concurrencyCache),
+	}
+
+	result, err := svc.SelectAccountWithLoadAwareness(ctx, nil, "sticky", "claude-3-5-sonnet-20241022", nil, "")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.NotNil(t, result.WaitPlan)
+	require.Equal(t, int64(1), result.Account.ID)
+	require.Equal(t, estimateWaitDuration(3, 2, 10*time.Second), result.WaitPlan.EstimatedWait)
+	require.Positive(t, result.WaitPlan.EstimatedWait, "saturated account's wait plan should carry a non-zero estimate")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,10 @@ type AdminService interface {
 	// codeType is optional - pass empty string to return all types.
 	// Also returns totalRecharged (sum of all positive balance top-ups).
 	GetUserBalanceHistory(ctx context.Context, userID int64, page, pageSize int, codeType string) ([]RedeemCode, int64, float64, error)
+	// ListUserStickySessions returns the active sticky session bindings across all of a user's API keys.
+	ListUserStickySessions(ctx context.Context, userID int64) ([]StickySession, error)
+	// RevokeUserStickySessions revokes all active sticky session bindings across all of a user's API keys.
+	RevokeUserStickySessions(ctx context.Context, userID int64) error
 
 	// Group management
 	ListGroups(ctx context.Context, page, pageSize int, platform, status, search string, isExclusive *bool) ([]Group, int64, error)
@@ -37,6 +42,14 @@ type AdminService interface {
 	DeleteGroup(ctx context.Context, id int64) error
 	GetGroupAPIKeys(ctx context.Context, groupID int64, page, pageSize int) ([]APIKey, int64, error)
 	UpdateGroupSortOrders(ctx context.Context, updates []GroupSortOrderUpdate) error
+	// GetGroupRouting 返回分组已解析的模型路由规则
+	GetGroupRouting(ctx context.Context, groupID int64) (*GroupRoutingRules, error)
+	// UpdateGroupRouting 校验并保存分组的模型路由规则（通配符仅支持末尾 *，
+	// 规则引用的账号必须存在且已绑定到该分组）
+	UpdateGroupRouting(ctx context.Context, groupID int64, input *UpdateGroupRoutingInput) (*GroupRoutingRules, error)
+	// ValidateGroupConfig 对分组配置进行端到端一致性校验：可调度账号、路由规则账号引用、
+	// 降级分组链路是否成环、支持的模型系列/端点白名单取值是否合法，返回诊断列表供管理端主动发现误配置
+	ValidateGroupConfig(ctx context.Context, groupID int64) ([]GroupValidationIssue, error)
 
 	// Account management
 	ListAccounts(ctx context.Context, page, pageSize int, platform, accountType, status, search string, groupID int64) ([]Account, int64, error)
@@ -84,6 +97,8 @@ type CreateUserInput struct {
 	Balance       float64
 	Concurrency   int
 	AllowedGroups []int64
+	// ModelMapping 用户级默认模型映射，nil 表示不配置
+	ModelMapping map[string]string
 }
 
 type UpdateUserInput struct {
@@ -98,6 +113,8 @@ type UpdateUserInput struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]*rate，nil 表示删除该分组的专属倍率
 	GroupRates map[int64]*float64
+	// ModelMapping 用户级默认模型映射，非 nil 时整体替换，传入空 map 表示清除
+	ModelMapping map[string]string
 }
 
 type CreateGroupInput struct {
@@ -107,6 +124,7 @@ type CreateGroupInput struct {
 	RateMultiplier   float64
 	IsExclusive      bool
 	SubscriptionType string   // standard/subscription
+	Currency         string   // 计费展示货币代码，空值使用默认值 USD
 	DailyLimitUSD    *float64 // 日限额 (USD)
 	WeeklyLimitUSD   *float64 // 周限额 (USD)
 	MonthlyLimitUSD  *float64 // 月限额 (USD)
@@ -124,6 +142,31 @@ type CreateGroupInput struct {
 	MCPXMLInject        *bool
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写
+	DisableMetadataRewrite bool
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；nil 表示使用全局配置
+	MaxMessages *int
+	// 分组每日请求次数上限；nil 或 <=0 表示不限制
+	DailyRequestLimit *int
+	// 流式响应累计 output tokens 硬上限；nil 或 <=0 表示不限制
+	MaxOutputTokens *int
+	// 分组级上游默认请求头，构建上游请求时应用于该分组下的 API-key 账号
+	UpstreamHeaders map[string]string
+	// Intent 路由配置（按 x-sub2api-intent 请求头精确匹配）
+	IntentRouting        map[string][]int64
+	IntentRoutingEnabled bool
+	// 订阅限额用尽后的计费策略（仅订阅模式分组生效）：standard/subscription_only/fallback_balance，空值使用默认值
+	SubscriptionOverflowPolicy string
+	// 允许的上游端点白名单（见 service.GatewayEndpoint* 常量），为空表示不限制
+	AllowedEndpoints []string
+	// 是否要求客户端必须携带 anthropic-version 请求头
+	RequireAnthropicVersion bool
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly bool
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；nil 或 <=0 表示不限制
+	WindowCostLimitUSD *float64
+	// WindowCostLimitUSD 对应的滚动窗口时长（小时）；nil 或 <=0 时默认为 5 小时
+	WindowCostWindowHours *int
 	// 从指定分组复制账号（创建分组后在同一事务内绑定）
 	CopyAccountsFromGroupIDs []int64
 }
@@ -136,6 +179,7 @@ type UpdateGroupInput struct {
 	IsExclusive      *bool
 	Status           string
 	SubscriptionType string   // standard/subscription
+	Currency         string   // 计费展示货币代码；空字符串表示不修改
 	DailyLimitUSD    *float64 // 日限额 (USD)
 	WeeklyLimitUSD   *float64 // 周限额 (USD)
 	MonthlyLimitUSD  *float64 // 月限额 (USD)
@@ -153,24 +197,74 @@ type UpdateGroupInput struct {
 	MCPXMLInject        *bool
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes *[]string
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写
+	DisableMetadataRewrite *bool
+	// 单次请求允许的最大 messages 数量；0 或负数表示清除覆盖（恢复使用全局配置）
+	MaxMessages *int
+	// 分组每日请求次数上限；0 或负数表示清除限制
+	DailyRequestLimit *int
+	// 流式响应累计 output tokens 硬上限；0 或负数表示清除限制
+	MaxOutputTokens *int
+	// 分组级上游默认请求头；非 nil 时整体替换，传入空 map 表示清除
+	UpstreamHeaders map[string]string
+	// Intent 路由配置（按 x-sub2api-intent 请求头精确匹配）；非 nil 时整体替换，传入空 map 表示清除
+	IntentRouting        map[string][]int64
+	IntentRoutingEnabled *bool
+	// 订阅限额用尽后的计费策略；空字符串表示不修改
+	SubscriptionOverflowPolicy string
+	// 允许的上游端点白名单（见 service.GatewayEndpoint* 常量）；非 nil 时整体替换，传入空切片表示清除限制
+	AllowedEndpoints *[]string
+	// 是否要求客户端必须携带 anthropic-version 请求头
+	RequireAnthropicVersion *bool
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly *bool
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；0 或负数表示清除限制
+	WindowCostLimitUSD *float64
+	// WindowCostLimitUSD 对应的滚动窗口时长（小时）；0 或负数表示清除（恢复默认 5 小时）
+	WindowCostWindowHours *int
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64
 }
 
+// GroupRoutingRules 分组的模型路由规则
+type GroupRoutingRules struct {
+	ModelRouting        map[string][]int64
+	ModelRoutingEnabled bool
+}
+
+// UpdateGroupRoutingInput 更新分组模型路由规则的请求参数；ModelRouting 为全量替换
+type UpdateGroupRoutingInput struct {
+	ModelRouting        map[string][]int64
+	ModelRoutingEnabled bool
+}
+
+// GroupValidationIssue 描述分组配置端到端校验发现的一条诊断信息
+type GroupValidationIssue struct {
+	// Severity: "error"（明确的配置错误，可能导致请求失败）或 "warning"（潜在风险，不阻断使用）
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
 type CreateAccountInput struct {
-	Name               string
-	Notes              *string
-	Platform           string
-	Type               string
-	Credentials        map[string]any
-	Extra              map[string]any
-	ProxyID            *int64
-	Concurrency        int
-	Priority           int
-	RateMultiplier     *float64 // 账号计费倍率（>=0，允许 0）
-	GroupIDs           []int64
-	ExpiresAt          *int64
-	AutoPauseOnExpired *bool
+	Name           string
+	Notes          *string
+	Platform       string
+	Type           string
+	Credentials    map[string]any
+	Extra          map[string]any
+	ProxyID        *int64
+	Concurrency    int
+	Priority       int
+	AffinityGroup  string   // 账号亲和分组，故障转移时优先选择同一分组内的其他账号
+	MaxLineSize    int      // 账号流式响应单行缓冲区上限（字节），覆盖全局 Gateway.MaxLineSize；0 表示不覆盖
+	RateMultiplier *float64 // 账号计费倍率（>=0，允许 0）
+	// QuietHoursStartMinute / QuietHoursEndMinute 静默时段配置（UTC，一天内分钟数 0-1439）；
+	// 两者必须同时提供或同时不提供
+	QuietHoursStartMinute *int
+	QuietHoursEndMinute   *int
+	GroupIDs              []int64
+	ExpiresAt             *int64
+	AutoPauseOnExpired    *bool
 	// SkipDefaultGroupBind prevents auto-binding to platform default group when GroupIDs is empty.
 	SkipDefaultGroupBind bool
 	// SkipMixedChannelCheck skips the mixed channel risk check when binding groups.
@@ -179,15 +273,21 @@ type CreateAccountInput struct {
 }
 
 type UpdateAccountInput struct {
-	Name                  string
-	Notes                 *string
-	Type                  string // Account type: oauth, setup-token, apikey
-	Credentials           map[string]any
-	Extra                 map[string]any
-	ProxyID               *int64
-	Concurrency           *int     // 使用指针区分"未提供"和"设置为0"
-	Priority              *int     // 使用指针区分"未提供"和"设置为0"
-	RateMultiplier        *float64 // 账号计费倍率（>=0，允许 0）
+	Name           string
+	Notes          *string
+	Type           string // Account type: oauth, setup-token, apikey
+	Credentials    map[string]any
+	Extra          map[string]any
+	ProxyID        *int64
+	Concurrency    *int     // 使用指针区分"未提供"和"设置为0"
+	Priority       *int     // 使用指针区分"未提供"和"设置为0"
+	AffinityGroup  *string  // 账号亲和分组；使用指针区分"未提供"和"设置为空字符串"
+	MaxLineSize    *int     // 账号流式响应单行缓冲区上限（字节）；使用指针区分"未提供"和"设置为0"
+	RateMultiplier *float64 // 账号计费倍率（>=0，允许 0）
+	// QuietHoursStartMinute / QuietHoursEndMinute 静默时段配置（UTC，一天内分钟数 0-1439）；
+	// 两者都为 nil 表示不更新；两者都非 nil 表示设置；两者都为负数表示清除静默时段配置
+	QuietHoursStartMinute *int
+	QuietHoursEndMinute   *int
 	Status                string
 	GroupIDs              *[]int64
 	ExpiresAt             *int64
@@ -305,6 +405,7 @@ type adminServiceImpl struct {
 	proxyProber          ProxyExitInfoProber
 	proxyLatencyCache    ProxyLatencyCache
 	authCacheInvalidator APIKeyAuthCacheInvalidator
+	gatewayCache         GatewayCache
 }
 
 // NewAdminService creates a new AdminService
@@ -320,6 +421,7 @@ func NewAdminService(
 	proxyProber ProxyExitInfoProber,
 	proxyLatencyCache ProxyLatencyCache,
 	authCacheInvalidator APIKeyAuthCacheInvalidator,
+	gatewayCache GatewayCache,
 ) AdminService {
 	return &adminServiceImpl{
 		userRepo:             userRepo,
@@ -333,6 +435,7 @@ func NewAdminService(
 		proxyProber:          proxyProber,
 		proxyLatencyCache:    proxyLatencyCache,
 		authCacheInvalidator: authCacheInvalidator,
+		gatewayCache:         gatewayCache,
 	}
 }
 
@@ -384,6 +487,7 @@ func (s *adminServiceImpl) CreateUser(ctx context.Context, input *CreateUserInpu
 		Concurrency:   input.Concurrency,
 		Status:        StatusActive,
 		AllowedGroups: input.AllowedGroups,
+		ModelMapping:  input.ModelMapping,
 	}
 	if err := user.SetPassword(input.Password); err != nil {
 		return nil, err
@@ -437,6 +541,14 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 		user.AllowedGroups = *input.AllowedGroups
 	}
 
+	if input.ModelMapping != nil {
+		if len(input.ModelMapping) == 0 {
+			user.ModelMapping = nil
+		} else {
+			user.ModelMapping = input.ModelMapping
+		}
+	}
+
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
@@ -597,6 +709,66 @@ func (s *adminServiceImpl) GetUserBalanceHistory(ctx context.Context, userID int
 	return codes, result.Total, totalRecharged, nil
 }
 
+// userAPIKeyIDs returns the IDs of all API keys owned by a user (unpaginated).
+func (s *adminServiceImpl) userAPIKeyIDs(ctx context.Context, userID int64) ([]int64, error) {
+	count, err := s.apiKeyRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	keys, _, err := s.apiKeyRepo.ListByUserID(ctx, userID, pagination.PaginationParams{Page: 1, PageSize: int(count)})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(keys))
+	for i := range keys {
+		ids[i] = keys[i].ID
+	}
+	return ids, nil
+}
+
+// ListUserStickySessions returns the active sticky session bindings across all of a user's API keys.
+func (s *adminServiceImpl) ListUserStickySessions(ctx context.Context, userID int64) ([]StickySession, error) {
+	if s.gatewayCache == nil {
+		return nil, nil
+	}
+	apiKeyIDs, err := s.userAPIKeyIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]StickySession, 0)
+	for _, apiKeyID := range apiKeyIDs {
+		keySessions, err := s.gatewayCache.ListSessionsByAPIKey(ctx, apiKeyID)
+		if err != nil {
+			log.Printf("failed to list sticky sessions for api key %d: %v", apiKeyID, err)
+			continue
+		}
+		sessions = append(sessions, keySessions...)
+	}
+	return sessions, nil
+}
+
+// RevokeUserStickySessions revokes all active sticky session bindings across all of a user's API keys.
+func (s *adminServiceImpl) RevokeUserStickySessions(ctx context.Context, userID int64) error {
+	if s.gatewayCache == nil {
+		return nil
+	}
+	apiKeyIDs, err := s.userAPIKeyIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, apiKeyID := range apiKeyIDs {
+		if err := s.gatewayCache.DeleteSessionsByAPIKey(ctx, apiKeyID); err != nil {
+			log.Printf("failed to revoke sticky sessions for api key %d: %v", apiKeyID, err)
+		}
+	}
+	return nil
+}
+
 // Group management implementations
 func (s *adminServiceImpl) ListGroups(ctx context.Context, page, pageSize int, platform, status, search string, isExclusive *bool) ([]Group, int64, error) {
 	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
@@ -630,6 +802,16 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		subscriptionType = SubscriptionTypeStandard
 	}
 
+	subscriptionOverflowPolicy := input.SubscriptionOverflowPolicy
+	if subscriptionOverflowPolicy == "" {
+		subscriptionOverflowPolicy = SubscriptionOverflowPolicySubscriptionOnly
+	}
+
+	currency := input.Currency
+	if currency == "" {
+		currency = CurrencyUSD
+	}
+
 	// 限额字段：0 和 nil 都表示"无限制"
 	dailyLimit := normalizeLimit(input.DailyLimitUSD)
 	weeklyLimit := normalizeLimit(input.WeeklyLimitUSD)
@@ -696,25 +878,39 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 	}
 
 	group := &Group{
-		Name:                            input.Name,
-		Description:                     input.Description,
-		Platform:                        platform,
-		RateMultiplier:                  input.RateMultiplier,
-		IsExclusive:                     input.IsExclusive,
-		Status:                          StatusActive,
-		SubscriptionType:                subscriptionType,
-		DailyLimitUSD:                   dailyLimit,
-		WeeklyLimitUSD:                  weeklyLimit,
-		MonthlyLimitUSD:                 monthlyLimit,
-		ImagePrice1K:                    imagePrice1K,
-		ImagePrice2K:                    imagePrice2K,
-		ImagePrice4K:                    imagePrice4K,
-		ClaudeCodeOnly:                  input.ClaudeCodeOnly,
-		FallbackGroupID:                 input.FallbackGroupID,
-		FallbackGroupIDOnInvalidRequest: fallbackOnInvalidRequest,
-		ModelRouting:                    input.ModelRouting,
-		MCPXMLInject:                    mcpXMLInject,
-		SupportedModelScopes:            input.SupportedModelScopes,
+		Name:                                input.Name,
+		Description:                         input.Description,
+		Platform:                            platform,
+		RateMultiplier:                      input.RateMultiplier,
+		IsExclusive:                         input.IsExclusive,
+		Status:                              StatusActive,
+		SubscriptionType:                    subscriptionType,
+		Currency:                            currency,
+		DailyLimitUSD:                       dailyLimit,
+		WeeklyLimitUSD:                      weeklyLimit,
+		MonthlyLimitUSD:                     monthlyLimit,
+		ImagePrice1K:                        imagePrice1K,
+		ImagePrice2K:                        imagePrice2K,
+		ImagePrice4K:                        imagePrice4K,
+		ClaudeCodeOnly:                      input.ClaudeCodeOnly,
+		FallbackGroupID:                     input.FallbackGroupID,
+		FallbackGroupIDOnInvalidRequest:     fallbackOnInvalidRequest,
+		ModelRouting:                        input.ModelRouting,
+		MCPXMLInject:                        mcpXMLInject,
+		SupportedModelScopes:                input.SupportedModelScopes,
+		DisableMetadataRewrite:              input.DisableMetadataRewrite,
+		MaxMessages:                         input.MaxMessages,
+		DailyRequestLimit:                   normalizeIntLimit(input.DailyRequestLimit),
+		MaxOutputTokens:                     normalizeIntLimit(input.MaxOutputTokens),
+		UpstreamHeaders:                     input.UpstreamHeaders,
+		IntentRouting:                       input.IntentRouting,
+		IntentRoutingEnabled:                input.IntentRoutingEnabled,
+		SubscriptionOverflowPolicy:          subscriptionOverflowPolicy,
+		AllowedEndpoints:                    input.AllowedEndpoints,
+		RequireAnthropicVersion:             input.RequireAnthropicVersion,
+		MixedSchedulingNativeSaturationOnly: input.MixedSchedulingNativeSaturationOnly,
+		WindowCostLimitUSD:                  normalizeLimit(input.WindowCostLimitUSD),
+		WindowCostWindowHours:               normalizeIntLimit(input.WindowCostWindowHours),
 	}
 	if err := s.groupRepo.Create(ctx, group); err != nil {
 		return nil, err
@@ -739,6 +935,14 @@ func normalizeLimit(limit *float64) *float64 {
 	return limit
 }
 
+// normalizeIntLimit 将 0 或负数转换为 nil（表示无限制）
+func normalizeIntLimit(limit *int) *int {
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+	return limit
+}
+
 // normalizePrice 将负数转换为 nil（表示使用默认价格），0 保留（表示免费）
 func normalizePrice(price *float64) *float64 {
 	if price == nil || *price < 0 {
@@ -747,6 +951,26 @@ func normalizePrice(price *float64) *float64 {
 	return price
 }
 
+// normalizeQuietHours 校验并规范化账号静默时段输入：
+// - start 和 end 都为 nil 表示不修改
+// - start 和 end 都为负数表示清除已配置的静默时段
+// - 否则两者必须同时提供，且落在一天内分钟数范围 [0, 1439] 内
+func normalizeQuietHours(start, end *int) (changed bool, clear bool, err error) {
+	if start == nil && end == nil {
+		return false, false, nil
+	}
+	if start == nil || end == nil {
+		return false, false, errors.New("quiet_hours_start_minute and quiet_hours_end_minute must be provided together")
+	}
+	if *start < 0 && *end < 0 {
+		return true, true, nil
+	}
+	if *start < 0 || *end < 0 || *start > 1439 || *end > 1439 {
+		return false, false, errors.New("quiet_hours_start_minute and quiet_hours_end_minute must be between 0 and 1439")
+	}
+	return true, false, nil
+}
+
 // validateFallbackGroup 校验降级分组的有效性
 // currentGroupID: 当前分组 ID（新建时为 0）
 // fallbackGroupID: 降级分组 ID
@@ -845,6 +1069,12 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 	if input.SubscriptionType != "" {
 		group.SubscriptionType = input.SubscriptionType
 	}
+	if input.SubscriptionOverflowPolicy != "" {
+		group.SubscriptionOverflowPolicy = input.SubscriptionOverflowPolicy
+	}
+	if input.Currency != "" {
+		group.Currency = input.Currency
+	}
 	// 限额字段：0 和 nil 都表示"无限制"，正数表示具体限额
 	if input.DailyLimitUSD != nil {
 		group.DailyLimitUSD = normalizeLimit(input.DailyLimitUSD)
@@ -913,6 +1143,73 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 		group.SupportedModelScopes = *input.SupportedModelScopes
 	}
 
+	// 允许的上游端点白名单：传入空切片表示清除限制（不限制）
+	if input.AllowedEndpoints != nil {
+		group.AllowedEndpoints = *input.AllowedEndpoints
+	}
+
+	if input.DisableMetadataRewrite != nil {
+		group.DisableMetadataRewrite = *input.DisableMetadataRewrite
+	}
+
+	if input.RequireAnthropicVersion != nil {
+		group.RequireAnthropicVersion = *input.RequireAnthropicVersion
+	}
+
+	if input.MixedSchedulingNativeSaturationOnly != nil {
+		group.MixedSchedulingNativeSaturationOnly = *input.MixedSchedulingNativeSaturationOnly
+	}
+
+	// 单次请求最大 messages 数：0 或负数表示清除（恢复使用全局配置）
+	if input.MaxMessages != nil {
+		if *input.MaxMessages > 0 {
+			group.MaxMessages = input.MaxMessages
+		} else {
+			group.MaxMessages = nil
+		}
+	}
+
+	// 分组每日请求次数上限：0 或负数表示清除限制
+	if input.DailyRequestLimit != nil {
+		group.DailyRequestLimit = normalizeIntLimit(input.DailyRequestLimit)
+	}
+
+	// 流式响应累计 output tokens 硬上限：0 或负数表示清除限制
+	if input.MaxOutputTokens != nil {
+		group.MaxOutputTokens = normalizeIntLimit(input.MaxOutputTokens)
+	}
+
+	// 分组窗口费用上限：0 或负数表示清除限制
+	if input.WindowCostLimitUSD != nil {
+		group.WindowCostLimitUSD = normalizeLimit(input.WindowCostLimitUSD)
+	}
+
+	// 分组窗口费用滚动窗口时长：0 或负数表示清除（恢复默认 5 小时）
+	if input.WindowCostWindowHours != nil {
+		group.WindowCostWindowHours = normalizeIntLimit(input.WindowCostWindowHours)
+	}
+
+	// 分组级上游默认请求头：传入 nil 表示不修改，传入空 map 表示清除
+	if input.UpstreamHeaders != nil {
+		if len(input.UpstreamHeaders) == 0 {
+			group.UpstreamHeaders = nil
+		} else {
+			group.UpstreamHeaders = input.UpstreamHeaders
+		}
+	}
+
+	// Intent 路由配置：传入 nil 表示不修改，传入空 map 表示清除
+	if input.IntentRouting != nil {
+		if len(input.IntentRouting) == 0 {
+			group.IntentRouting = nil
+		} else {
+			group.IntentRouting = input.IntentRouting
+		}
+	}
+	if input.IntentRoutingEnabled != nil {
+		group.IntentRoutingEnabled = *input.IntentRoutingEnabled
+	}
+
 	if err := s.groupRepo.Update(ctx, group); err != nil {
 		return nil, err
 	}
@@ -1020,6 +1317,182 @@ func (s *adminServiceImpl) UpdateGroupSortOrders(ctx context.Context, updates []
 	return s.groupRepo.UpdateSortOrders(ctx, updates)
 }
 
+func (s *adminServiceImpl) GetGroupRouting(ctx context.Context, groupID int64) (*GroupRoutingRules, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupRoutingRules{
+		ModelRouting:        group.ModelRouting,
+		ModelRoutingEnabled: group.ModelRoutingEnabled,
+	}, nil
+}
+
+func (s *adminServiceImpl) UpdateGroupRouting(ctx context.Context, groupID int64, input *UpdateGroupRoutingInput) (*GroupRoutingRules, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateModelRouting(ctx, groupID, input.ModelRouting); err != nil {
+		return nil, err
+	}
+
+	group.ModelRouting = input.ModelRouting
+	group.ModelRoutingEnabled = input.ModelRoutingEnabled
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return &GroupRoutingRules{
+		ModelRouting:        group.ModelRouting,
+		ModelRoutingEnabled: group.ModelRoutingEnabled,
+	}, nil
+}
+
+// validateModelRouting 校验模型路由规则：通配符仅支持末尾 *（与 matchModelPattern
+// 的匹配能力保持一致），且规则引用的账号必须存在并已绑定到该分组。
+func (s *adminServiceImpl) validateModelRouting(ctx context.Context, groupID int64, routing map[string][]int64) error {
+	if len(routing) == 0 {
+		return nil
+	}
+
+	boundAccountIDs, err := s.groupRepo.GetAccountIDsByGroupIDs(ctx, []int64{groupID})
+	if err != nil {
+		return fmt.Errorf("failed to load group accounts: %w", err)
+	}
+	boundSet := make(map[int64]struct{}, len(boundAccountIDs))
+	for _, id := range boundAccountIDs {
+		boundSet[id] = struct{}{}
+	}
+
+	for pattern, accountIDs := range routing {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("model routing pattern must not be empty")
+		}
+		if idx := strings.Index(pattern, "*"); idx >= 0 && idx != len(pattern)-1 {
+			return fmt.Errorf("model routing pattern %q is invalid: '*' is only supported at the end", pattern)
+		}
+		if len(accountIDs) == 0 {
+			return fmt.Errorf("model routing pattern %q must reference at least one account", pattern)
+		}
+		for _, accountID := range accountIDs {
+			if _, ok := boundSet[accountID]; !ok {
+				return fmt.Errorf("model routing pattern %q references account %d which is not bound to this group", pattern, accountID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateGroupConfig 对分组配置进行端到端一致性校验，用于管理端主动发现误配置：
+//   - 分组是否存在可调度账号
+//   - 模型路由 / Intent 路由规则引用的账号是否已绑定到本分组
+//   - 降级分组链路（fallback_group_id / fallback_group_id_on_invalid_request）是否成环
+//   - 支持的模型系列 / 允许的上游端点白名单取值是否合法
+func (s *adminServiceImpl) ValidateGroupConfig(ctx context.Context, groupID int64) ([]GroupValidationIssue, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []GroupValidationIssue
+
+	boundAccountIDs, err := s.groupRepo.GetAccountIDsByGroupIDs(ctx, []int64{groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load group accounts: %w", err)
+	}
+	boundSet := make(map[int64]struct{}, len(boundAccountIDs))
+	for _, id := range boundAccountIDs {
+		boundSet[id] = struct{}{}
+	}
+
+	if len(boundAccountIDs) == 0 {
+		issues = append(issues, GroupValidationIssue{Severity: "warning", Message: "group has no bound accounts"})
+	} else {
+		accounts, err := s.accountRepo.GetByIDs(ctx, boundAccountIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load group accounts: %w", err)
+		}
+		hasSchedulable := false
+		for _, acc := range accounts {
+			if acc.IsSchedulable() {
+				hasSchedulable = true
+				break
+			}
+		}
+		if !hasSchedulable {
+			issues = append(issues, GroupValidationIssue{Severity: "warning", Message: "group has no schedulable accounts"})
+		}
+	}
+
+	for pattern, accountIDs := range group.ModelRouting {
+		for _, accountID := range accountIDs {
+			if _, ok := boundSet[accountID]; !ok {
+				issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("model routing pattern %q references account %d which is not bound to this group", pattern, accountID)})
+			}
+		}
+	}
+
+	for intent, accountIDs := range group.IntentRouting {
+		for _, accountID := range accountIDs {
+			if _, ok := boundSet[accountID]; !ok {
+				issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("intent routing %q references account %d which is not bound to this group", intent, accountID)})
+			}
+		}
+	}
+
+	for _, endpoint := range group.AllowedEndpoints {
+		if endpoint != GatewayEndpointMessages && endpoint != GatewayEndpointCountTokens {
+			issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("allowed_endpoints contains unknown endpoint %q", endpoint)})
+		}
+	}
+
+	validScopes := map[string]bool{"claude": true, "gemini_text": true, "gemini_image": true}
+	for _, scope := range group.SupportedModelScopes {
+		if !validScopes[scope] {
+			issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("supported_model_scopes contains unknown scope %q", scope)})
+		}
+	}
+
+	if path, hasCycle := s.detectFallbackCycle(ctx, group, func(g *Group) *int64 { return g.FallbackGroupID }); hasCycle {
+		issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("fallback_group_id chain forms a cycle: %s", path)})
+	}
+	if path, hasCycle := s.detectFallbackCycle(ctx, group, func(g *Group) *int64 { return g.FallbackGroupIDOnInvalidRequest }); hasCycle {
+		issues = append(issues, GroupValidationIssue{Severity: "error", Message: fmt.Sprintf("fallback_group_id_on_invalid_request chain forms a cycle: %s", path)})
+	}
+
+	return issues, nil
+}
+
+// detectFallbackCycle 沿 next 指定的降级分组字段遍历分组链路，检测是否存在环（包括自引用）。
+// 返回环上各分组 ID 依次相连构成的路径描述，便于定位问题。
+func (s *adminServiceImpl) detectFallbackCycle(ctx context.Context, start *Group, next func(*Group) *int64) (string, bool) {
+	visited := map[int64]bool{start.ID: true}
+	path := []string{strconv.FormatInt(start.ID, 10)}
+	current := start
+	for {
+		nextID := next(current)
+		if nextID == nil {
+			return "", false
+		}
+		path = append(path, strconv.FormatInt(*nextID, 10))
+		if visited[*nextID] {
+			return strings.Join(path, " -> "), true
+		}
+		visited[*nextID] = true
+
+		nextGroup, err := s.groupRepo.GetByIDLite(ctx, *nextID)
+		if err != nil {
+			// 降级分组指向了不存在的分组，属于另一类配置问题，这里不再继续遍历
+			return "", false
+		}
+		current = nextGroup
+	}
+}
+
 // Account management implementations
 func (s *adminServiceImpl) ListAccounts(ctx context.Context, page, pageSize int, platform, accountType, status, search string, groupID int64) ([]Account, int64, error) {
 	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
@@ -1071,18 +1544,24 @@ func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccou
 		}
 	}
 
+	if err := ValidateBodyTransforms(input.Extra); err != nil {
+		return nil, err
+	}
+
 	account := &Account{
-		Name:        input.Name,
-		Notes:       normalizeAccountNotes(input.Notes),
-		Platform:    input.Platform,
-		Type:        input.Type,
-		Credentials: input.Credentials,
-		Extra:       input.Extra,
-		ProxyID:     input.ProxyID,
-		Concurrency: input.Concurrency,
-		Priority:    input.Priority,
-		Status:      StatusActive,
-		Schedulable: true,
+		Name:          input.Name,
+		Notes:         normalizeAccountNotes(input.Notes),
+		Platform:      input.Platform,
+		Type:          input.Type,
+		Credentials:   input.Credentials,
+		Extra:         input.Extra,
+		ProxyID:       input.ProxyID,
+		Concurrency:   input.Concurrency,
+		Priority:      input.Priority,
+		AffinityGroup: input.AffinityGroup,
+		MaxLineSize:   input.MaxLineSize,
+		Status:        StatusActive,
+		Schedulable:   true,
 	}
 	if input.ExpiresAt != nil && *input.ExpiresAt > 0 {
 		expiresAt := time.Unix(*input.ExpiresAt, 0)
@@ -1099,6 +1578,12 @@ func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccou
 		}
 		account.RateMultiplier = input.RateMultiplier
 	}
+	if changed, _, err := normalizeQuietHours(input.QuietHoursStartMinute, input.QuietHoursEndMinute); err != nil {
+		return nil, err
+	} else if changed {
+		account.QuietHoursStartMinute = input.QuietHoursStartMinute
+		account.QuietHoursEndMinute = input.QuietHoursEndMinute
+	}
 	if err := s.accountRepo.Create(ctx, account); err != nil {
 		return nil, err
 	}
@@ -1132,6 +1617,9 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 		account.Credentials = input.Credentials
 	}
 	if len(input.Extra) > 0 {
+		if err := ValidateBodyTransforms(input.Extra); err != nil {
+			return nil, err
+		}
 		account.Extra = input.Extra
 	}
 	if input.ProxyID != nil {
@@ -1151,12 +1639,29 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 	if input.Priority != nil {
 		account.Priority = *input.Priority
 	}
+	// 只在指针非 nil 时更新 AffinityGroup（支持设置为空字符串以清除分组）
+	if input.AffinityGroup != nil {
+		account.AffinityGroup = *input.AffinityGroup
+	}
+	// 只在指针非 nil 时更新 MaxLineSize（支持设置为 0 以清除覆盖）
+	if input.MaxLineSize != nil {
+		account.MaxLineSize = *input.MaxLineSize
+	}
 	if input.RateMultiplier != nil {
 		if *input.RateMultiplier < 0 {
 			return nil, errors.New("rate_multiplier must be >= 0")
 		}
 		account.RateMultiplier = input.RateMultiplier
 	}
+	if changed, clear, err := normalizeQuietHours(input.QuietHoursStartMinute, input.QuietHoursEndMinute); err != nil {
+		return nil, err
+	} else if clear {
+		account.QuietHoursStartMinute = nil
+		account.QuietHoursEndMinute = nil
+	} else if changed {
+		account.QuietHoursStartMinute = input.QuietHoursStartMinute
+		account.QuietHoursEndMinute = input.QuietHoursEndMinute
+	}
 	if input.Status != "" {
 		account.Status = input.Status
 	}
@@ -177,6 +177,85 @@ func TestAdminService_CreateGroup_NilImagePricing(t *testing.T) {
 	require.Nil(t, repo.created.ImagePrice4K)
 }
 
+// TestAdminService_CreateGroup_DefaultsCurrencyToUSD 测试创建分组时未指定 Currency 使用默认值 USD
+func TestAdminService_CreateGroup_DefaultsCurrencyToUSD(t *testing.T) {
+	repo := &groupRepoStubForAdmin{}
+	svc := &adminServiceImpl{groupRepo: repo}
+
+	input := &CreateGroupInput{
+		Name:           "test-group",
+		Platform:       PlatformAnthropic,
+		RateMultiplier: 1.0,
+	}
+
+	group, err := svc.CreateGroup(context.Background(), input)
+	require.NoError(t, err)
+	require.NotNil(t, group)
+
+	require.NotNil(t, repo.created)
+	require.Equal(t, CurrencyUSD, repo.created.Currency)
+}
+
+// TestAdminService_CreateGroup_WithCurrency 测试创建分组时传入的 Currency 被透传
+func TestAdminService_CreateGroup_WithCurrency(t *testing.T) {
+	repo := &groupRepoStubForAdmin{}
+	svc := &adminServiceImpl{groupRepo: repo}
+
+	input := &CreateGroupInput{
+		Name:           "test-group",
+		Platform:       PlatformAnthropic,
+		RateMultiplier: 1.0,
+		Currency:       "CNY",
+	}
+
+	group, err := svc.CreateGroup(context.Background(), input)
+	require.NoError(t, err)
+	require.NotNil(t, group)
+
+	require.NotNil(t, repo.created)
+	require.Equal(t, "CNY", repo.created.Currency)
+}
+
+// TestAdminService_UpdateGroup_CurrencyEmptyKeepsUnchanged 测试更新分组时 Currency 为空字符串表示不修改
+func TestAdminService_UpdateGroup_CurrencyEmptyKeepsUnchanged(t *testing.T) {
+	existingGroup := &Group{
+		ID:       1,
+		Name:     "existing-group",
+		Platform: PlatformAnthropic,
+		Status:   StatusActive,
+		Currency: "EUR",
+	}
+	repo := &groupRepoStubForAdmin{getByID: existingGroup}
+	svc := &adminServiceImpl{groupRepo: repo}
+
+	group, err := svc.UpdateGroup(context.Background(), 1, &UpdateGroupInput{})
+	require.NoError(t, err)
+	require.NotNil(t, group)
+
+	require.NotNil(t, repo.updated)
+	require.Equal(t, "EUR", repo.updated.Currency)
+}
+
+// TestAdminService_UpdateGroup_WithCurrency 测试更新分组时 Currency 字段正确更新
+func TestAdminService_UpdateGroup_WithCurrency(t *testing.T) {
+	existingGroup := &Group{
+		ID:       1,
+		Name:     "existing-group",
+		Platform: PlatformAnthropic,
+		Status:   StatusActive,
+		Currency: CurrencyUSD,
+	}
+	repo := &groupRepoStubForAdmin{getByID: existingGroup}
+	svc := &adminServiceImpl{groupRepo: repo}
+
+	group, err := svc.UpdateGroup(context.Background(), 1, &UpdateGroupInput{Currency: "JPY"})
+	require.NoError(t, err)
+	require.NotNil(t, group)
+
+	require.NotNil(t, repo.updated)
+	require.Equal(t, "JPY", repo.updated.Currency)
+}
+
 // TestAdminService_UpdateGroup_WithImagePricing 测试更新分组时 ImagePrice 字段正确更新
 func TestAdminService_UpdateGroup_WithImagePricing(t *testing.T) {
 	existingGroup := &Group{
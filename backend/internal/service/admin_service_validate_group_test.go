@@ -0,0 +1,335 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+// groupRepoStubForValidate 用于测试 AdminService.ValidateGroupConfig 的 GroupRepository Stub
+type groupRepoStubForValidate struct {
+	groups          map[int64]*Group
+	boundAccountIDs []int64
+}
+
+func (s *groupRepoStubForValidate) Create(_ context.Context, _ *Group) error {
+	panic("unexpected Create call")
+}
+
+func (s *groupRepoStubForValidate) Update(_ context.Context, _ *Group) error {
+	panic("unexpected Update call")
+}
+
+func (s *groupRepoStubForValidate) GetByID(_ context.Context, id int64) (*Group, error) {
+	if g, ok := s.groups[id]; ok {
+		return g, nil
+	}
+	return nil, ErrGroupNotFound
+}
+
+func (s *groupRepoStubForValidate) GetByIDLite(ctx context.Context, id int64) (*Group, error) {
+	return s.GetByID(ctx, id)
+}
+
+func (s *groupRepoStubForValidate) Delete(_ context.Context, _ int64) error {
+	panic("unexpected Delete call")
+}
+
+func (s *groupRepoStubForValidate) DeleteCascade(_ context.Context, _ int64) ([]int64, error) {
+	panic("unexpected DeleteCascade call")
+}
+
+func (s *groupRepoStubForValidate) List(_ context.Context, _ pagination.PaginationParams) ([]Group, *pagination.PaginationResult, error) {
+	panic("unexpected List call")
+}
+
+func (s *groupRepoStubForValidate) ListWithFilters(_ context.Context, _ pagination.PaginationParams, _, _, _ string, _ *bool) ([]Group, *pagination.PaginationResult, error) {
+	panic("unexpected ListWithFilters call")
+}
+
+func (s *groupRepoStubForValidate) ListActive(_ context.Context) ([]Group, error) {
+	panic("unexpected ListActive call")
+}
+
+func (s *groupRepoStubForValidate) ListActiveByPlatform(_ context.Context, _ string) ([]Group, error) {
+	panic("unexpected ListActiveByPlatform call")
+}
+
+func (s *groupRepoStubForValidate) ExistsByName(_ context.Context, _ string) (bool, error) {
+	panic("unexpected ExistsByName call")
+}
+
+func (s *groupRepoStubForValidate) GetAccountCount(_ context.Context, _ int64) (int64, error) {
+	panic("unexpected GetAccountCount call")
+}
+
+func (s *groupRepoStubForValidate) DeleteAccountGroupsByGroupID(_ context.Context, _ int64) (int64, error) {
+	panic("unexpected DeleteAccountGroupsByGroupID call")
+}
+
+func (s *groupRepoStubForValidate) GetAccountIDsByGroupIDs(_ context.Context, _ []int64) ([]int64, error) {
+	return s.boundAccountIDs, nil
+}
+
+func (s *groupRepoStubForValidate) BindAccountsToGroup(_ context.Context, _ int64, _ []int64) error {
+	panic("unexpected BindAccountsToGroup call")
+}
+
+func (s *groupRepoStubForValidate) UpdateSortOrders(_ context.Context, _ []GroupSortOrderUpdate) error {
+	return nil
+}
+
+// accountRepoStubForValidate 用于测试 AdminService.ValidateGroupConfig 的 AccountRepository Stub
+type accountRepoStubForValidate struct {
+	accounts map[int64]*Account
+}
+
+func (s *accountRepoStubForValidate) Create(_ context.Context, _ *Account) error {
+	panic("unexpected Create call")
+}
+
+func (s *accountRepoStubForValidate) GetByID(_ context.Context, _ int64) (*Account, error) {
+	panic("unexpected GetByID call")
+}
+
+func (s *accountRepoStubForValidate) GetByIDs(_ context.Context, ids []int64) ([]*Account, error) {
+	out := make([]*Account, 0, len(ids))
+	for _, id := range ids {
+		if a, ok := s.accounts[id]; ok {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (s *accountRepoStubForValidate) ExistsByID(_ context.Context, _ int64) (bool, error) {
+	panic("unexpected ExistsByID call")
+}
+
+func (s *accountRepoStubForValidate) GetByCRSAccountID(_ context.Context, _ string) (*Account, error) {
+	panic("unexpected GetByCRSAccountID call")
+}
+
+func (s *accountRepoStubForValidate) ListCRSAccountIDs(_ context.Context) (map[string]int64, error) {
+	panic("unexpected ListCRSAccountIDs call")
+}
+
+func (s *accountRepoStubForValidate) Update(_ context.Context, _ *Account) error {
+	panic("unexpected Update call")
+}
+
+func (s *accountRepoStubForValidate) Delete(_ context.Context, _ int64) error {
+	panic("unexpected Delete call")
+}
+
+func (s *accountRepoStubForValidate) List(_ context.Context, _ pagination.PaginationParams) ([]Account, *pagination.PaginationResult, error) {
+	panic("unexpected List call")
+}
+
+func (s *accountRepoStubForValidate) ListWithFilters(_ context.Context, _ pagination.PaginationParams, _, _, _, _ string, _ int64) ([]Account, *pagination.PaginationResult, error) {
+	panic("unexpected ListWithFilters call")
+}
+
+func (s *accountRepoStubForValidate) ListByGroup(_ context.Context, _ int64) ([]Account, error) {
+	panic("unexpected ListByGroup call")
+}
+
+func (s *accountRepoStubForValidate) ListActive(_ context.Context) ([]Account, error) {
+	panic("unexpected ListActive call")
+}
+
+func (s *accountRepoStubForValidate) ListByPlatform(_ context.Context, _ string) ([]Account, error) {
+	panic("unexpected ListByPlatform call")
+}
+
+func (s *accountRepoStubForValidate) UpdateLastUsed(_ context.Context, _ int64) error {
+	panic("unexpected UpdateLastUsed call")
+}
+
+func (s *accountRepoStubForValidate) BatchUpdateLastUsed(_ context.Context, _ map[int64]time.Time) error {
+	panic("unexpected BatchUpdateLastUsed call")
+}
+
+func (s *accountRepoStubForValidate) SetError(_ context.Context, _ int64, _ string) error {
+	panic("unexpected SetError call")
+}
+
+func (s *accountRepoStubForValidate) ClearError(_ context.Context, _ int64) error {
+	panic("unexpected ClearError call")
+}
+
+func (s *accountRepoStubForValidate) SetSchedulable(_ context.Context, _ int64, _ bool) error {
+	panic("unexpected SetSchedulable call")
+}
+
+func (s *accountRepoStubForValidate) AutoPauseExpiredAccounts(_ context.Context, _ time.Time) (int64, error) {
+	panic("unexpected AutoPauseExpiredAccounts call")
+}
+
+func (s *accountRepoStubForValidate) BindGroups(_ context.Context, _ int64, _ []int64) error {
+	panic("unexpected BindGroups call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulable(_ context.Context) ([]Account, error) {
+	panic("unexpected ListSchedulable call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulableByGroupID(_ context.Context, _ int64) ([]Account, error) {
+	panic("unexpected ListSchedulableByGroupID call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulableByPlatform(_ context.Context, _ string) ([]Account, error) {
+	panic("unexpected ListSchedulableByPlatform call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulableByGroupIDAndPlatform(_ context.Context, _ int64, _ string) ([]Account, error) {
+	panic("unexpected ListSchedulableByGroupIDAndPlatform call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulableByPlatforms(_ context.Context, _ []string) ([]Account, error) {
+	panic("unexpected ListSchedulableByPlatforms call")
+}
+
+func (s *accountRepoStubForValidate) ListSchedulableByGroupIDAndPlatforms(_ context.Context, _ int64, _ []string) ([]Account, error) {
+	panic("unexpected ListSchedulableByGroupIDAndPlatforms call")
+}
+
+func (s *accountRepoStubForValidate) SetRateLimited(_ context.Context, _ int64, _ time.Time) error {
+	panic("unexpected SetRateLimited call")
+}
+
+func (s *accountRepoStubForValidate) SetModelRateLimit(_ context.Context, _ int64, _ string, _ time.Time) error {
+	panic("unexpected SetModelRateLimit call")
+}
+
+func (s *accountRepoStubForValidate) SetOverloaded(_ context.Context, _ int64, _ time.Time) error {
+	panic("unexpected SetOverloaded call")
+}
+
+func (s *accountRepoStubForValidate) SetTempUnschedulable(_ context.Context, _ int64, _ time.Time, _ string) error {
+	panic("unexpected SetTempUnschedulable call")
+}
+
+func (s *accountRepoStubForValidate) ClearTempUnschedulable(_ context.Context, _ int64) error {
+	panic("unexpected ClearTempUnschedulable call")
+}
+
+func (s *accountRepoStubForValidate) ClearRateLimit(_ context.Context, _ int64) error {
+	panic("unexpected ClearRateLimit call")
+}
+
+func (s *accountRepoStubForValidate) ClearAntigravityQuotaScopes(_ context.Context, _ int64) error {
+	panic("unexpected ClearAntigravityQuotaScopes call")
+}
+
+func (s *accountRepoStubForValidate) ClearModelRateLimits(_ context.Context, _ int64) error {
+	panic("unexpected ClearModelRateLimits call")
+}
+
+func (s *accountRepoStubForValidate) UpdateSessionWindow(_ context.Context, _ int64, _, _ *time.Time, _ string, _ *int) error {
+	panic("unexpected UpdateSessionWindow call")
+}
+
+func (s *accountRepoStubForValidate) UpdateExtra(_ context.Context, _ int64, _ map[string]any) error {
+	panic("unexpected UpdateExtra call")
+}
+
+func (s *accountRepoStubForValidate) BulkUpdate(_ context.Context, _ []int64, _ AccountBulkUpdate) (int64, error) {
+	panic("unexpected BulkUpdate call")
+}
+
+// TestAdminService_ValidateGroupConfig_DanglingRoutingAccount 验证模型路由引用了未绑定到本分组的账号时会报错
+func TestAdminService_ValidateGroupConfig_DanglingRoutingAccount(t *testing.T) {
+	groupID := int64(1)
+	group := &Group{
+		ID:       groupID,
+		Platform: PlatformAnthropic,
+		ModelRouting: map[string][]int64{
+			"claude-*": {99}, // 99 未绑定到本分组
+		},
+	}
+	groupRepo := &groupRepoStubForValidate{
+		groups:          map[int64]*Group{groupID: group},
+		boundAccountIDs: []int64{1},
+	}
+	accountRepo := &accountRepoStubForValidate{
+		accounts: map[int64]*Account{
+			1: {ID: 1, Status: StatusActive, Schedulable: true},
+		},
+	}
+	svc := &adminServiceImpl{groupRepo: groupRepo, accountRepo: accountRepo}
+
+	issues, err := svc.ValidateGroupConfig(context.Background(), groupID)
+	require.NoError(t, err)
+
+	found := false
+	for _, issue := range issues {
+		if issue.Severity == "error" && issue.Message == `model routing pattern "claude-*" references account 99 which is not bound to this group` {
+			found = true
+		}
+	}
+	require.True(t, found, "expected dangling routing account issue, got: %+v", issues)
+}
+
+// TestAdminService_ValidateGroupConfig_FallbackCycle 验证降级分组链路成环时会报错
+func TestAdminService_ValidateGroupConfig_FallbackCycle(t *testing.T) {
+	groupID := int64(1)
+	fallbackID := int64(2)
+	groupRepo := &groupRepoStubForValidate{
+		groups: map[int64]*Group{
+			groupID: {
+				ID:              groupID,
+				Platform:        PlatformAnthropic,
+				FallbackGroupID: &fallbackID,
+			},
+			fallbackID: {
+				ID:              fallbackID,
+				Platform:        PlatformAnthropic,
+				FallbackGroupID: &groupID,
+			},
+		},
+	}
+	svc := &adminServiceImpl{groupRepo: groupRepo, accountRepo: &accountRepoStubForValidate{}}
+
+	issues, err := svc.ValidateGroupConfig(context.Background(), groupID)
+	require.NoError(t, err)
+
+	found := false
+	for _, issue := range issues {
+		if issue.Severity == "error" && issue.Message == "fallback_group_id chain forms a cycle: 1 -> 2 -> 1" {
+			found = true
+		}
+	}
+	require.True(t, found, "expected fallback cycle issue, got: %+v", issues)
+}
+
+// TestAdminService_ValidateGroupConfig_NoIssuesForWellFormedGroup 验证配置正确的分组不产生任何诊断信息
+func TestAdminService_ValidateGroupConfig_NoIssuesForWellFormedGroup(t *testing.T) {
+	groupID := int64(1)
+	group := &Group{
+		ID:       groupID,
+		Platform: PlatformAnthropic,
+		ModelRouting: map[string][]int64{
+			"claude-*": {1},
+		},
+	}
+	groupRepo := &groupRepoStubForValidate{
+		groups:          map[int64]*Group{groupID: group},
+		boundAccountIDs: []int64{1},
+	}
+	accountRepo := &accountRepoStubForValidate{
+		accounts: map[int64]*Account{
+			1: {ID: 1, Status: StatusActive, Schedulable: true},
+		},
+	}
+	svc := &adminServiceImpl{groupRepo: groupRepo, accountRepo: accountRepo}
+
+	issues, err := svc.ValidateGroupConfig(context.Background(), groupID)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
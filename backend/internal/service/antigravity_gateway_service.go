@@ -2670,7 +2670,7 @@ func (s *AntigravityGatewayService) handleUpstreamError(
 	if s.rateLimitService == nil {
 		return nil
 	}
-	shouldDisable := s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, headers, body)
+	shouldDisable := s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, headers, body, requestedModel)
 	if shouldDisable {
 		log.Printf("%s status=%d marked_error", prefix, statusCode)
 	}
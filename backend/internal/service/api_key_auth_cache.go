@@ -23,11 +23,12 @@ type APIKeyAuthSnapshot struct {
 
 // APIKeyAuthUserSnapshot 用户快照
 type APIKeyAuthUserSnapshot struct {
-	ID          int64   `json:"id"`
-	Status      string  `json:"status"`
-	Role        string  `json:"role"`
-	Balance     float64 `json:"balance"`
-	Concurrency int     `json:"concurrency"`
+	ID           int64             `json:"id"`
+	Status       string            `json:"status"`
+	Role         string            `json:"role"`
+	Balance      float64           `json:"balance"`
+	Concurrency  int               `json:"concurrency"`
+	ModelMapping map[string]string `json:"model_mapping,omitempty"`
 }
 
 // APIKeyAuthGroupSnapshot 分组快照
@@ -54,8 +55,31 @@ type APIKeyAuthGroupSnapshot struct {
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
 	MCPXMLInject        bool               `json:"mcp_xml_inject"`
 
+	// Intent routing is used by gateway account selection alongside model routing,
+	// so it must also be part of the auth cache snapshot.
+	IntentRouting        map[string][]int64 `json:"intent_routing,omitempty"`
+	IntentRoutingEnabled bool               `json:"intent_routing_enabled"`
+
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string `json:"supported_model_scopes,omitempty"`
+
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写，透传客户端原始 metadata
+	DisableMetadataRewrite bool `json:"disable_metadata_rewrite"`
+
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；nil 表示使用全局配置
+	MaxMessages *int `json:"max_messages,omitempty"`
+
+	// 流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发；nil 表示不限制
+	MaxOutputTokens *int `json:"max_output_tokens,omitempty"`
+
+	// 允许的上游端点白名单，为空表示不限制
+	AllowedEndpoints []string `json:"allowed_endpoints,omitempty"`
+
+	// 是否要求客户端必须携带 anthropic-version 请求头，缺失时拒绝请求而非默认填充
+	RequireAnthropicVersion bool `json:"require_anthropic_version"`
+
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用 antigravity 账户（严格 fallback）
+	MixedSchedulingNativeSaturationOnly bool `json:"mixed_scheduling_native_saturation_only,omitempty"`
 }
 
 // APIKeyAuthCacheEntry 缓存条目，支持负缓存
@@ -217,34 +217,43 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 		QuotaUsed:   apiKey.QuotaUsed,
 		ExpiresAt:   apiKey.ExpiresAt,
 		User: APIKeyAuthUserSnapshot{
-			ID:          apiKey.User.ID,
-			Status:      apiKey.User.Status,
-			Role:        apiKey.User.Role,
-			Balance:     apiKey.User.Balance,
-			Concurrency: apiKey.User.Concurrency,
+			ID:           apiKey.User.ID,
+			Status:       apiKey.User.Status,
+			Role:         apiKey.User.Role,
+			Balance:      apiKey.User.Balance,
+			Concurrency:  apiKey.User.Concurrency,
+			ModelMapping: apiKey.User.ModelMapping,
 		},
 	}
 	if apiKey.Group != nil {
 		snapshot.Group = &APIKeyAuthGroupSnapshot{
-			ID:                              apiKey.Group.ID,
-			Name:                            apiKey.Group.Name,
-			Platform:                        apiKey.Group.Platform,
-			Status:                          apiKey.Group.Status,
-			SubscriptionType:                apiKey.Group.SubscriptionType,
-			RateMultiplier:                  apiKey.Group.RateMultiplier,
-			DailyLimitUSD:                   apiKey.Group.DailyLimitUSD,
-			WeeklyLimitUSD:                  apiKey.Group.WeeklyLimitUSD,
-			MonthlyLimitUSD:                 apiKey.Group.MonthlyLimitUSD,
-			ImagePrice1K:                    apiKey.Group.ImagePrice1K,
-			ImagePrice2K:                    apiKey.Group.ImagePrice2K,
-			ImagePrice4K:                    apiKey.Group.ImagePrice4K,
-			ClaudeCodeOnly:                  apiKey.Group.ClaudeCodeOnly,
-			FallbackGroupID:                 apiKey.Group.FallbackGroupID,
-			FallbackGroupIDOnInvalidRequest: apiKey.Group.FallbackGroupIDOnInvalidRequest,
-			ModelRouting:                    apiKey.Group.ModelRouting,
-			ModelRoutingEnabled:             apiKey.Group.ModelRoutingEnabled,
-			MCPXMLInject:                    apiKey.Group.MCPXMLInject,
-			SupportedModelScopes:            apiKey.Group.SupportedModelScopes,
+			ID:                                  apiKey.Group.ID,
+			Name:                                apiKey.Group.Name,
+			Platform:                            apiKey.Group.Platform,
+			Status:                              apiKey.Group.Status,
+			SubscriptionType:                    apiKey.Group.SubscriptionType,
+			RateMultiplier:                      apiKey.Group.RateMultiplier,
+			DailyLimitUSD:                       apiKey.Group.DailyLimitUSD,
+			WeeklyLimitUSD:                      apiKey.Group.WeeklyLimitUSD,
+			MonthlyLimitUSD:                     apiKey.Group.MonthlyLimitUSD,
+			ImagePrice1K:                        apiKey.Group.ImagePrice1K,
+			ImagePrice2K:                        apiKey.Group.ImagePrice2K,
+			ImagePrice4K:                        apiKey.Group.ImagePrice4K,
+			ClaudeCodeOnly:                      apiKey.Group.ClaudeCodeOnly,
+			FallbackGroupID:                     apiKey.Group.FallbackGroupID,
+			FallbackGroupIDOnInvalidRequest:     apiKey.Group.FallbackGroupIDOnInvalidRequest,
+			ModelRouting:                        apiKey.Group.ModelRouting,
+			ModelRoutingEnabled:                 apiKey.Group.ModelRoutingEnabled,
+			IntentRouting:                       apiKey.Group.IntentRouting,
+			IntentRoutingEnabled:                apiKey.Group.IntentRoutingEnabled,
+			MCPXMLInject:                        apiKey.Group.MCPXMLInject,
+			SupportedModelScopes:                apiKey.Group.SupportedModelScopes,
+			DisableMetadataRewrite:              apiKey.Group.DisableMetadataRewrite,
+			MaxMessages:                         apiKey.Group.MaxMessages,
+			MaxOutputTokens:                     apiKey.Group.MaxOutputTokens,
+			AllowedEndpoints:                    apiKey.Group.AllowedEndpoints,
+			RequireAnthropicVersion:             apiKey.Group.RequireAnthropicVersion,
+			MixedSchedulingNativeSaturationOnly: apiKey.Group.MixedSchedulingNativeSaturationOnly,
 		}
 	}
 	return snapshot
@@ -266,35 +275,44 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 		QuotaUsed:   snapshot.QuotaUsed,
 		ExpiresAt:   snapshot.ExpiresAt,
 		User: &User{
-			ID:          snapshot.User.ID,
-			Status:      snapshot.User.Status,
-			Role:        snapshot.User.Role,
-			Balance:     snapshot.User.Balance,
-			Concurrency: snapshot.User.Concurrency,
+			ID:           snapshot.User.ID,
+			Status:       snapshot.User.Status,
+			Role:         snapshot.User.Role,
+			Balance:      snapshot.User.Balance,
+			Concurrency:  snapshot.User.Concurrency,
+			ModelMapping: snapshot.User.ModelMapping,
 		},
 	}
 	if snapshot.Group != nil {
 		apiKey.Group = &Group{
-			ID:                              snapshot.Group.ID,
-			Name:                            snapshot.Group.Name,
-			Platform:                        snapshot.Group.Platform,
-			Status:                          snapshot.Group.Status,
-			Hydrated:                        true,
-			SubscriptionType:                snapshot.Group.SubscriptionType,
-			RateMultiplier:                  snapshot.Group.RateMultiplier,
-			DailyLimitUSD:                   snapshot.Group.DailyLimitUSD,
-			WeeklyLimitUSD:                  snapshot.Group.WeeklyLimitUSD,
-			MonthlyLimitUSD:                 snapshot.Group.MonthlyLimitUSD,
-			ImagePrice1K:                    snapshot.Group.ImagePrice1K,
-			ImagePrice2K:                    snapshot.Group.ImagePrice2K,
-			ImagePrice4K:                    snapshot.Group.ImagePrice4K,
-			ClaudeCodeOnly:                  snapshot.Group.ClaudeCodeOnly,
-			FallbackGroupID:                 snapshot.Group.FallbackGroupID,
-			FallbackGroupIDOnInvalidRequest: snapshot.Group.FallbackGroupIDOnInvalidRequest,
-			ModelRouting:                    snapshot.Group.ModelRouting,
-			ModelRoutingEnabled:             snapshot.Group.ModelRoutingEnabled,
-			MCPXMLInject:                    snapshot.Group.MCPXMLInject,
-			SupportedModelScopes:            snapshot.Group.SupportedModelScopes,
+			ID:                                  snapshot.Group.ID,
+			Name:                                snapshot.Group.Name,
+			Platform:                            snapshot.Group.Platform,
+			Status:                              snapshot.Group.Status,
+			Hydrated:                            true,
+			SubscriptionType:                    snapshot.Group.SubscriptionType,
+			RateMultiplier:                      snapshot.Group.RateMultiplier,
+			DailyLimitUSD:                       snapshot.Group.DailyLimitUSD,
+			WeeklyLimitUSD:                      snapshot.Group.WeeklyLimitUSD,
+			MonthlyLimitUSD:                     snapshot.Group.MonthlyLimitUSD,
+			ImagePrice1K:                        snapshot.Group.ImagePrice1K,
+			ImagePrice2K:                        snapshot.Group.ImagePrice2K,
+			ImagePrice4K:                        snapshot.Group.ImagePrice4K,
+			ClaudeCodeOnly:                      snapshot.Group.ClaudeCodeOnly,
+			FallbackGroupID:                     snapshot.Group.FallbackGroupID,
+			FallbackGroupIDOnInvalidRequest:     snapshot.Group.FallbackGroupIDOnInvalidRequest,
+			ModelRouting:                        snapshot.Group.ModelRouting,
+			ModelRoutingEnabled:                 snapshot.Group.ModelRoutingEnabled,
+			IntentRouting:                       snapshot.Group.IntentRouting,
+			IntentRoutingEnabled:                snapshot.Group.IntentRoutingEnabled,
+			MCPXMLInject:                        snapshot.Group.MCPXMLInject,
+			SupportedModelScopes:                snapshot.Group.SupportedModelScopes,
+			DisableMetadataRewrite:              snapshot.Group.DisableMetadataRewrite,
+			MaxMessages:                         snapshot.Group.MaxMessages,
+			MaxOutputTokens:                     snapshot.Group.MaxOutputTokens,
+			AllowedEndpoints:                    snapshot.Group.AllowedEndpoints,
+			RequireAnthropicVersion:             snapshot.Group.RequireAnthropicVersion,
+			MixedSchedulingNativeSaturationOnly: snapshot.Group.MixedSchedulingNativeSaturationOnly,
 		}
 	}
 	return apiKey
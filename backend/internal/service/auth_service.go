@@ -10,6 +10,7 @@ import (
 	"log"
 	"net/mail"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
@@ -45,6 +46,13 @@ const maxTokenLength = 8192
 // refreshTokenPrefix is the prefix for refresh tokens to distinguish them from access tokens.
 const refreshTokenPrefix = "rt_"
 
+// defaultJWTRotationGraceWindow 轮换 JWT 签名密钥时，旧密钥默认保留多久仍可用于校验。
+const defaultJWTRotationGraceWindow = 24 * time.Hour
+
+// jwtRotationStateRefreshInterval 从 settings 表拉取其它副本写入的轮换状态的节流间隔，
+// 避免每次签发/校验 token 都访问数据库。
+const jwtRotationStateRefreshInterval = 30 * time.Second
+
 // JWTClaims JWT载荷数据
 type JWTClaims struct {
 	UserID       int64  `json:"user_id"`
@@ -65,6 +73,17 @@ type AuthService struct {
 	turnstileService  *TurnstileService
 	emailQueueService *EmailQueueService
 	promoService      *PromoService
+
+	// jwtMu 保护以下 JWT 签名密钥轮换状态。主密钥初始值来自 cfg.JWT.Secret，
+	// 之后可通过 RotateSigningKey 在运行时轮换，旧密钥在宽限期内仍可用于校验 token。
+	// 轮换状态同时持久化到 settings 表（见 jwtRotationStateRefreshInterval），
+	// 使多副本部署下的其它实例也能感知到轮换，而不是只有执行轮换的那个实例生效。
+	jwtMu                sync.RWMutex
+	jwtPrimarySecret     string
+	jwtPreviousSecret    string
+	jwtPreviousExpiresAt time.Time
+	jwtRotatedAt         time.Time
+	jwtStateLoadedAt     time.Time
 }
 
 // NewAuthService 创建认证服务实例
@@ -89,6 +108,7 @@ func NewAuthService(
 		turnstileService:  turnstileService,
 		emailQueueService: emailQueueService,
 		promoService:      promoService,
+		jwtPrimarySecret:  cfg.JWT.Secret,
 	}
 }
 
@@ -593,6 +613,24 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		return nil, ErrTokenTooLarge
 	}
 
+	claims, err := s.validateTokenWithSecret(tokenString, s.currentSigningSecret())
+	if err == nil || errors.Is(err, ErrTokenExpired) {
+		return claims, err
+	}
+
+	// 签名校验失败时，尝试用宽限期内仍然有效的上一个签名密钥重新校验，
+	// 避免密钥轮换瞬间使所有已签发的 token 全部失效。
+	if previousSecret, ok := s.previousSigningSecret(); ok {
+		if prevClaims, prevErr := s.validateTokenWithSecret(tokenString, previousSecret); prevErr == nil || errors.Is(prevErr, ErrTokenExpired) {
+			return prevClaims, prevErr
+		}
+	}
+
+	return nil, err
+}
+
+// validateTokenWithSecret 使用指定的签名密钥校验 token，供主/旧密钥复用同一套校验逻辑。
+func (s *AuthService) validateTokenWithSecret(tokenString, secret string) (*JWTClaims, error) {
 	// 使用解析器并限制可接受的签名算法，防止算法混淆。
 	parser := jwt.NewParser(jwt.WithValidMethods([]string{
 		jwt.SigningMethodHS256.Name,
@@ -606,7 +644,7 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(s.cfg.JWT.Secret), nil
+		return []byte(secret), nil
 	})
 
 	if err != nil {
@@ -628,6 +666,106 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return nil, ErrInvalidToken
 }
 
+// currentSigningSecret 返回当前用于签发/校验 token 的主签名密钥。
+func (s *AuthService) currentSigningSecret() string {
+	s.refreshPersistedSigningStateIfStale()
+	s.jwtMu.RLock()
+	defer s.jwtMu.RUnlock()
+	return s.jwtPrimarySecret
+}
+
+// previousSigningSecret 返回宽限期内仍然有效的上一个签名密钥，用于密钥轮换期间的兼容校验。
+func (s *AuthService) previousSigningSecret() (string, bool) {
+	s.refreshPersistedSigningStateIfStale()
+	s.jwtMu.RLock()
+	defer s.jwtMu.RUnlock()
+	if s.jwtPreviousSecret == "" || time.Now().After(s.jwtPreviousExpiresAt) {
+		return "", false
+	}
+	return s.jwtPreviousSecret, true
+}
+
+// refreshPersistedSigningStateIfStale 定期从 settings 表拉取其它副本写入的轮换状态，
+// 使 RotateSigningKey 在一个副本上的执行结果最终对所有副本生效，而不是只在执行轮换的
+// 那个实例内存中生效（其它副本重启或等待下一次刷新前仍会沿用旧密钥）。
+// 节流到 jwtRotationStateRefreshInterval 一次，避免每次签发/校验 token 都访问数据库。
+func (s *AuthService) refreshPersistedSigningStateIfStale() {
+	if s.settingService == nil {
+		return
+	}
+
+	s.jwtMu.RLock()
+	stale := time.Since(s.jwtStateLoadedAt) >= jwtRotationStateRefreshInterval
+	s.jwtMu.RUnlock()
+	if !stale {
+		return
+	}
+
+	s.jwtMu.Lock()
+	if time.Since(s.jwtStateLoadedAt) < jwtRotationStateRefreshInterval {
+		s.jwtMu.Unlock()
+		return
+	}
+	s.jwtStateLoadedAt = time.Now()
+	s.jwtMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	state, err := s.settingService.GetJWTRotationState(ctx)
+	if err != nil || state == nil || state.PrimarySecret == "" {
+		return
+	}
+
+	s.jwtMu.Lock()
+	defer s.jwtMu.Unlock()
+	if state.RotatedAt.After(s.jwtRotatedAt) {
+		s.jwtPrimarySecret = state.PrimarySecret
+		s.jwtPreviousSecret = state.PreviousSecret
+		s.jwtPreviousExpiresAt = state.PreviousExpiresAt
+		s.jwtRotatedAt = state.RotatedAt
+	}
+}
+
+// RotateSigningKey 轮换 JWT 签名密钥：生成新的随机主密钥用于后续签发，
+// 原主密钥在 graceWindow（<=0 时使用默认宽限期）内仍可用于校验已签发的 token，
+// 使轮换对已登录用户透明。轮换结果会持久化到 settings 表，使其它副本能够感知到本次轮换。
+// 返回值为新密钥，仅在轮换时返回一次，不会再次暴露。
+func (s *AuthService) RotateSigningKey(ctx context.Context, graceWindow time.Duration) (string, error) {
+	if graceWindow <= 0 {
+		graceWindow = defaultJWTRotationGraceWindow
+	}
+
+	newSecret, err := randomHexString(32)
+	if err != nil {
+		return "", fmt.Errorf("generate signing key: %w", err)
+	}
+
+	now := time.Now()
+	s.jwtMu.Lock()
+	previousSecret := s.jwtPrimarySecret
+	previousExpiresAt := now.Add(graceWindow)
+	s.jwtPreviousSecret = previousSecret
+	s.jwtPreviousExpiresAt = previousExpiresAt
+	s.jwtPrimarySecret = newSecret
+	s.jwtRotatedAt = now
+	s.jwtStateLoadedAt = now
+	s.jwtMu.Unlock()
+
+	if s.settingService != nil {
+		state := &JWTRotationState{
+			PrimarySecret:     newSecret,
+			PreviousSecret:    previousSecret,
+			PreviousExpiresAt: previousExpiresAt,
+			RotatedAt:         now,
+		}
+		if err := s.settingService.SetJWTRotationState(ctx, state); err != nil {
+			log.Printf("[Auth] persist jwt rotation state failed: %v", err)
+		}
+	}
+
+	return newSecret, nil
+}
+
 func randomHexString(byteLength int) (string, error) {
 	if byteLength <= 0 {
 		byteLength = 16
@@ -669,7 +807,7 @@ func (s *AuthService) GenerateToken(user *User) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.cfg.JWT.Secret))
+	tokenString, err := token.SignedString([]byte(s.currentSigningSecret()))
 	if err != nil {
 		return "", fmt.Errorf("sign token: %w", err)
 	}
@@ -0,0 +1,162 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_RotateSigningKey_PreviousKeyValidDuringGrace(t *testing.T) {
+	svc := newAuthService(&userRepoStub{}, nil, nil)
+	user := &User{ID: 1, Email: "user@test.com", Role: "user"}
+
+	oldToken, err := svc.GenerateToken(user)
+	require.NoError(t, err)
+
+	newSecret, err := svc.RotateSigningKey(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, newSecret)
+	require.NotEqual(t, "test-secret", newSecret)
+
+	// token签发于旧密钥之下，宽限期内仍应校验通过
+	claims, err := svc.ValidateToken(oldToken)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+
+	// 新签发的 token 使用新密钥
+	newToken, err := svc.GenerateToken(user)
+	require.NoError(t, err)
+	require.NotEqual(t, oldToken, newToken)
+	claims, err = svc.ValidateToken(newToken)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+}
+
+func TestAuthService_RotateSigningKey_PreviousKeyRejectedAfterGrace(t *testing.T) {
+	svc := newAuthService(&userRepoStub{}, nil, nil)
+	user := &User{ID: 1, Email: "user@test.com", Role: "user"}
+
+	oldToken, err := svc.GenerateToken(user)
+	require.NoError(t, err)
+
+	_, err = svc.RotateSigningKey(context.Background(), time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = svc.ValidateToken(oldToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestAuthService_RotateSigningKey_DefaultGraceWindow(t *testing.T) {
+	svc := newAuthService(&userRepoStub{}, nil, nil)
+	user := &User{ID: 1, Email: "user@test.com", Role: "user"}
+
+	oldToken, err := svc.GenerateToken(user)
+	require.NoError(t, err)
+
+	_, err = svc.RotateSigningKey(context.Background(), 0)
+	require.NoError(t, err)
+
+	claims, err := svc.ValidateToken(oldToken)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+}
+
+// jwtSettingRepoFake is a minimal in-memory SettingRepository backing store,
+// shared between multiple AuthService instances to simulate settings persisted
+// in the database and visible across replicas.
+type jwtSettingRepoFake struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newJWTSettingRepoFake() *jwtSettingRepoFake {
+	return &jwtSettingRepoFake{values: map[string]string{}}
+}
+
+func (r *jwtSettingRepoFake) Get(ctx context.Context, key string) (*Setting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.values[key]
+	if !ok {
+		return nil, ErrSettingNotFound
+	}
+	return &Setting{Key: key, Value: value}, nil
+}
+
+func (r *jwtSettingRepoFake) GetValue(ctx context.Context, key string) (string, error) {
+	s, err := r.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return s.Value, nil
+}
+
+func (r *jwtSettingRepoFake) Set(ctx context.Context, key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+	return nil
+}
+
+func (r *jwtSettingRepoFake) GetMultiple(ctx context.Context, keys []string) (map[string]string, error) {
+	panic("unexpected GetMultiple call")
+}
+
+func (r *jwtSettingRepoFake) SetMultiple(ctx context.Context, settings map[string]string) error {
+	panic("unexpected SetMultiple call")
+}
+
+func (r *jwtSettingRepoFake) GetAll(ctx context.Context) (map[string]string, error) {
+	panic("unexpected GetAll call")
+}
+
+func (r *jwtSettingRepoFake) Delete(ctx context.Context, key string) error {
+	panic("unexpected Delete call")
+}
+
+// TestAuthService_RotateSigningKey_PersistsAcrossReplicas verifies that rotating the
+// signing key on one AuthService instance and waiting out the refresh throttle makes
+// a second instance backed by the same settings store pick up the new key -- simulating
+// two replicas behind the same database.
+func TestAuthService_RotateSigningKey_PersistsAcrossReplicas(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpireHour: 1}}
+	repo := newJWTSettingRepoFake()
+
+	replicaA := NewAuthService(&userRepoStub{}, nil, nil, cfg, NewSettingService(repo, cfg), nil, nil, nil, nil)
+	replicaB := NewAuthService(&userRepoStub{}, nil, nil, cfg, NewSettingService(repo, cfg), nil, nil, nil, nil)
+
+	user := &User{ID: 1, Email: "user@test.com", Role: "user"}
+
+	tokenBeforeRotation, err := replicaB.GenerateToken(user)
+	require.NoError(t, err)
+
+	_, err = replicaA.RotateSigningKey(context.Background(), time.Hour)
+	require.NoError(t, err)
+
+	// Force replicaB to treat its cached state as stale so it re-reads the settings store,
+	// instead of waiting out the real jwtRotationStateRefreshInterval.
+	replicaB.jwtStateLoadedAt = time.Time{}
+
+	tokenAfterRotation, err := replicaA.GenerateToken(user)
+	require.NoError(t, err)
+
+	// replicaB did not rotate itself, but should now validate tokens signed by replicaA's
+	// new key because it picked up the persisted rotation state.
+	claims, err := replicaB.ValidateToken(tokenAfterRotation)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+
+	// replicaB should also still accept its own pre-rotation token during the grace window,
+	// since it learned about the previous secret from the same persisted state.
+	claims, err = replicaB.ValidateToken(tokenBeforeRotation)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+}
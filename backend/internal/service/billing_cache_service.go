@@ -18,6 +18,7 @@ import (
 var (
 	ErrSubscriptionInvalid       = infraerrors.Forbidden("SUBSCRIPTION_INVALID", "subscription is invalid or expired")
 	ErrBillingServiceUnavailable = infraerrors.ServiceUnavailable("BILLING_SERVICE_ERROR", "Billing service temporarily unavailable. Please retry later.")
+	ErrDailyRequestLimitExceeded = infraerrors.TooManyRequests("DAILY_REQUEST_LIMIT_EXCEEDED", "daily request limit exceeded")
 )
 
 // subscriptionCacheData 订阅缓存数据结构（内部使用）
@@ -73,11 +74,12 @@ type cacheWriteTask struct {
 // BillingCacheService 计费缓存服务
 // 负责余额和订阅数据的缓存管理，提供高性能的计费资格检查
 type BillingCacheService struct {
-	cache          BillingCache
-	userRepo       UserRepository
-	subRepo        UserSubscriptionRepository
-	cfg            *config.Config
-	circuitBreaker *billingCircuitBreaker
+	cache                  BillingCache
+	userRepo               UserRepository
+	subRepo                UserSubscriptionRepository
+	cfg                    *config.Config
+	circuitBreaker         *billingCircuitBreaker
+	groupRequestLimitCache GroupRequestLimitCache
 
 	cacheWriteChan     chan cacheWriteTask
 	cacheWriteWg       sync.WaitGroup
@@ -90,12 +92,13 @@ type BillingCacheService struct {
 }
 
 // NewBillingCacheService 创建计费缓存服务
-func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, cfg *config.Config) *BillingCacheService {
+func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, cfg *config.Config, groupRequestLimitCache GroupRequestLimitCache) *BillingCacheService {
 	svc := &BillingCacheService{
-		cache:    cache,
-		userRepo: userRepo,
-		subRepo:  subRepo,
-		cfg:      cfg,
+		cache:                  cache,
+		userRepo:               userRepo,
+		subRepo:                subRepo,
+		cfg:                    cfg,
+		groupRequestLimitCache: groupRequestLimitCache,
 	}
 	svc.circuitBreaker = newBillingCircuitBreaker(cfg.Billing.CircuitBreaker)
 	svc.startCacheWriteWorkers()
@@ -457,6 +460,13 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 		return ErrBillingServiceUnavailable
 	}
 
+	// 分组每日请求次数限额，与计费模式（余额/订阅）无关，在账号选择之前检查
+	if group != nil && group.HasDailyRequestLimit() {
+		if err := s.checkDailyRequestLimit(ctx, group); err != nil {
+			return err
+		}
+	}
+
 	// 判断计费模式
 	isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
 
@@ -467,6 +477,27 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 	return s.checkBalanceEligibility(ctx, user.ID)
 }
 
+// checkDailyRequestLimit 检查分组当日请求次数是否超过 Group.DailyRequestLimit
+// 缓存不可用时按失败开放处理，避免因缓存故障拖垮网关
+func (s *BillingCacheService) checkDailyRequestLimit(ctx context.Context, group *Group) error {
+	if s.groupRequestLimitCache == nil {
+		return nil
+	}
+
+	count, resetAt, err := s.groupRequestLimitCache.IncrementDailyRequestCount(ctx, group.ID)
+	if err != nil {
+		log.Printf("ALERT: group daily request limit check failed for group %d: %v", group.ID, err)
+		return nil
+	}
+
+	if count > int64(*group.DailyRequestLimit) {
+		return infraerrors.Newf(int(ErrDailyRequestLimitExceeded.Code), ErrDailyRequestLimitExceeded.Reason,
+			"daily request limit exceeded, resets at %s", resetAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 // checkBalanceEligibility 检查余额模式资格
 func (s *BillingCacheService) checkBalanceEligibility(ctx context.Context, userID int64) error {
 	balance, err := s.GetUserBalance(ctx, userID)
@@ -485,6 +516,10 @@ func (s *BillingCacheService) checkBalanceEligibility(ctx context.Context, userI
 		return ErrInsufficientBalance
 	}
 
+	if floor := s.cfg.Billing.MinimumBalanceUSD; floor > 0 && balance < floor {
+		return ErrInsufficientBalance
+	}
+
 	return nil
 }
 
@@ -54,7 +54,7 @@ func (b *billingCacheWorkerStub) InvalidateSubscriptionCache(ctx context.Context
 
 func TestBillingCacheServiceQueueHighLoad(t *testing.T) {
 	cache := &billingCacheWorkerStub{}
-	svc := NewBillingCacheService(cache, nil, nil, &config.Config{})
+	svc := NewBillingCacheService(cache, nil, nil, &config.Config{}, nil)
 	t.Cleanup(svc.Stop)
 
 	start := time.Now()
@@ -73,3 +73,40 @@ func TestBillingCacheServiceQueueHighLoad(t *testing.T) {
 		return atomic.LoadInt64(&cache.subscriptionUpdates) > 0
 	}, 2*time.Second, 10*time.Millisecond)
 }
+
+type billingCacheFixedBalanceStub struct {
+	billingCacheWorkerStub
+	balance float64
+}
+
+func (b *billingCacheFixedBalanceStub) GetUserBalance(ctx context.Context, userID int64) (float64, error) {
+	return b.balance, nil
+}
+
+func TestCheckBalanceEligibility_BelowMinimumBalanceRejected(t *testing.T) {
+	cache := &billingCacheFixedBalanceStub{balance: 5}
+	cfg := &config.Config{Billing: config.BillingConfig{MinimumBalanceUSD: 10}}
+	svc := NewBillingCacheService(cache, nil, nil, cfg, nil)
+	t.Cleanup(svc.Stop)
+
+	err := svc.checkBalanceEligibility(context.Background(), 1)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+}
+
+func TestCheckBalanceEligibility_AboveMinimumBalanceAccepted(t *testing.T) {
+	cache := &billingCacheFixedBalanceStub{balance: 15}
+	cfg := &config.Config{Billing: config.BillingConfig{MinimumBalanceUSD: 10}}
+	svc := NewBillingCacheService(cache, nil, nil, cfg, nil)
+	t.Cleanup(svc.Stop)
+
+	require.NoError(t, svc.checkBalanceEligibility(context.Background(), 1))
+}
+
+func TestCheckBalanceEligibility_NoFloorConfiguredOnlyRequiresPositiveBalance(t *testing.T) {
+	cache := &billingCacheFixedBalanceStub{balance: 0.01}
+	cfg := &config.Config{}
+	svc := NewBillingCacheService(cache, nil, nil, cfg, nil)
+	t.Cleanup(svc.Stop)
+
+	require.NoError(t, svc.checkBalanceEligibility(context.Background(), 1))
+}
@@ -346,8 +346,15 @@ func (s *BillingService) IsModelSupported(model string) bool {
 		strings.Contains(modelLower, "haiku")
 }
 
-// GetEstimatedCost 估算费用（用于前端展示）
-func (s *BillingService) GetEstimatedCost(model string, estimatedInputTokens, estimatedOutputTokens int) (float64, error) {
+// EstimatedCost 是预估费用的展示结果。AmountUSD 始终是内部计费基准单位（美元）的金额，
+// Currency 仅用于标注前端应如何展示该金额，不参与计算。
+type EstimatedCost struct {
+	AmountUSD float64
+	Currency  string
+}
+
+// GetEstimatedCost 估算费用（用于前端展示）。currency 为空时回退到 CurrencyUSD。
+func (s *BillingService) GetEstimatedCost(model string, estimatedInputTokens, estimatedOutputTokens int, currency string) (*EstimatedCost, error) {
 	tokens := UsageTokens{
 		InputTokens:  estimatedInputTokens,
 		OutputTokens: estimatedOutputTokens,
@@ -355,10 +362,14 @@ func (s *BillingService) GetEstimatedCost(model string, estimatedInputTokens, es
 
 	breakdown, err := s.CalculateCostWithConfig(model, tokens)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	if currency == "" {
+		currency = CurrencyUSD
 	}
 
-	return breakdown.ActualCost, nil
+	return &EstimatedCost{AmountUSD: breakdown.ActualCost, Currency: currency}, nil
 }
 
 // GetPricingServiceStatus 获取价格服务状态
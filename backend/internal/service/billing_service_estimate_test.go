@@ -0,0 +1,30 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetEstimatedCost_EchoesConfiguredCurrency 测试预估费用结果携带调用方传入的货币代码，
+// 且不影响以美元为基准单位的金额计算。
+func TestGetEstimatedCost_EchoesConfiguredCurrency(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	estimate, err := svc.GetEstimatedCost("claude-3-5-haiku", 1000, 500, "CNY")
+	require.NoError(t, err)
+	require.Equal(t, "CNY", estimate.Currency)
+	require.Greater(t, estimate.AmountUSD, 0.0)
+}
+
+// TestGetEstimatedCost_DefaultsToUSD 测试未指定货币时回退到 CurrencyUSD。
+func TestGetEstimatedCost_DefaultsToUSD(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	estimate, err := svc.GetEstimatedCost("claude-3-5-haiku", 1000, 500, "")
+	require.NoError(t, err)
+	require.Equal(t, CurrencyUSD, estimate.Currency)
+}
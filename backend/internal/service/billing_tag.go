@@ -0,0 +1,25 @@
+package service
+
+// MaxBillingTagLength 是 usage_logs.tag 列（VARCHAR(64)）允许的最大长度。
+// 超出该长度的 tag 一律拒绝，避免写入 UsageLog 时因列宽超限而失败，
+// 导致该请求的用量记录被静默丢弃。
+const MaxBillingTagLength = 64
+
+// IsValidBillingTag checks requestedTag against the allowed-tags list configured via
+// Gateway.BillingTag. The feature is opt-in: when validationEnabled is false, any
+// non-empty tag up to MaxBillingTagLength bytes is accepted as-is so operators can
+// start tagging traffic before wiring up validation.
+func IsValidBillingTag(validationEnabled bool, validTags []string, requestedTag string) bool {
+	if requestedTag == "" || len(requestedTag) > MaxBillingTagLength {
+		return false
+	}
+	if !validationEnabled {
+		return true
+	}
+	for _, tag := range validTags {
+		if tag == requestedTag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+//go:build unit
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsValidBillingTag_DisabledAcceptsAny 校验未启用时任意非空 tag 都放行
+func TestIsValidBillingTag_DisabledAcceptsAny(t *testing.T) {
+	require.True(t, IsValidBillingTag(false, nil, "project-a"))
+	require.True(t, IsValidBillingTag(false, []string{"feature-x"}, "project-a"))
+}
+
+// TestIsValidBillingTag_EmptyAlwaysRejected 空 tag 始终不通过，无论是否启用校验
+func TestIsValidBillingTag_EmptyAlwaysRejected(t *testing.T) {
+	require.False(t, IsValidBillingTag(false, nil, ""))
+	require.False(t, IsValidBillingTag(true, []string{"project-a"}, ""))
+}
+
+// TestIsValidBillingTag_EnabledRejectsUnknown 启用校验后未在白名单中的 tag 被拒绝
+func TestIsValidBillingTag_EnabledRejectsUnknown(t *testing.T) {
+	require.False(t, IsValidBillingTag(true, []string{"project-a", "feature-x"}, "project-b"))
+	require.True(t, IsValidBillingTag(true, []string{"project-a", "feature-x"}, "feature-x"))
+}
+
+// TestIsValidBillingTag_OversizedTagAlwaysRejected usage_logs.tag 是 VARCHAR(64)，
+// 超长 tag 无论校验是否启用都应拒绝，避免写入 UsageLog 时超出列宽而失败
+func TestIsValidBillingTag_OversizedTagAlwaysRejected(t *testing.T) {
+	tooLong := strings.Repeat("a", MaxBillingTagLength+1)
+	require.False(t, IsValidBillingTag(false, nil, tooLong))
+	require.False(t, IsValidBillingTag(true, []string{tooLong}, tooLong))
+
+	exactLimit := strings.Repeat("a", MaxBillingTagLength)
+	require.True(t, IsValidBillingTag(false, nil, exactLimit))
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"log"
 	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
 )
 
 // ConcurrencyCache 定义并发控制的缓存接口
@@ -18,6 +20,18 @@ type ConcurrencyCache interface {
 	ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error
 	GetAccountConcurrency(ctx context.Context, accountID int64) (int, error)
 
+	// 亲和分组并发借用：账号自身槽位打满时，从同一亲和分组内的空闲账号借用一个槽位。
+	// 借用的槽位计入 lender 自身的并发槽位键（受 lenderMaxConcurrency 限制），同时计入
+	// borrower 的借用计数键（受 borrowCap 限制，避免单个账号借走整个分组的容量）。
+	AcquireBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, lenderMaxConcurrency int, borrowerAccountID int64, borrowCap int, requestID string) (bool, error)
+	ReleaseBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, borrowerAccountID int64, requestID string) error
+
+	// 分组预留槽位：账号为某分组预留了专属并发槽位时，该分组在全局槽位键之外，还在自身的
+	// 槽位键 concurrency:account:{accountID}:group:{groupID} 中单独计数，使其用满预留份额前
+	// 的获取只受账号硬上限约束，不会被其它预留分组挤占。
+	AcquireReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, maxConcurrency int, reservedSlots int, effectiveMaxConcurrency int, requestID string) (bool, error)
+	ReleaseReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, requestID string) error
+
 	// 账号等待队列（账号级）
 	IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error)
 	DecrementAccountWaitCount(ctx context.Context, accountID int64) error
@@ -33,12 +47,17 @@ type ConcurrencyCache interface {
 	IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error)
 	DecrementWaitCount(ctx context.Context, userID int64) error
 
+	// 用户流式连接槽位管理（与普通并发槽位独立计数）
+	// 键格式: concurrency:user_stream:{userID}（有序集合，成员为 requestID）
+	AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int, requestID string) (bool, error)
+	ReleaseUserStreamSlot(ctx context.Context, userID int64, requestID string) error
+
 	// 批量负载查询（只读）
 	GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error)
 	GetUsersLoadBatch(ctx context.Context, users []UserWithConcurrency) (map[int64]*UserLoadInfo, error)
 
-	// 清理过期槽位（后台任务）
-	CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error
+	// 清理过期槽位（后台任务），返回本次实际清理掉的槽位数量
+	CleanupExpiredAccountSlots(ctx context.Context, accountID int64) (int, error)
 }
 
 // generateRequestID generates a unique request ID for concurrency slot tracking
@@ -97,6 +116,61 @@ type UserLoadInfo struct {
 	LoadRate           int // 0-100+ (percent)
 }
 
+// effectiveAccountConcurrency 计算账号在并发爬坡期间的有效并发上限。
+// 账号从限流/过载中恢复后，若直接放开满额并发容易再次触发上游限流，
+// 因此在 RecoveryRampDurationSeconds 内将并发从 RecoveryRampInitialPercent 线性爬升到 100%。
+func effectiveAccountConcurrency(cfg *config.ConcurrencyConfig, account *Account) int {
+	maxConcurrency := account.Concurrency
+	if cfg == nil || !cfg.RecoveryRampEnabled || maxConcurrency <= 0 {
+		return maxConcurrency
+	}
+
+	recoveredAt := latestRecoveryTime(account)
+	if recoveredAt == nil {
+		return maxConcurrency
+	}
+
+	rampDuration := time.Duration(cfg.RecoveryRampDurationSeconds) * time.Second
+	elapsed := time.Since(*recoveredAt)
+	if elapsed < 0 || elapsed >= rampDuration {
+		return maxConcurrency
+	}
+
+	initialPercent := cfg.RecoveryRampInitialPercent
+	if initialPercent <= 0 || initialPercent > 100 {
+		initialPercent = 100
+	}
+
+	progress := float64(elapsed) / float64(rampDuration)
+	percent := float64(initialPercent) + (100-float64(initialPercent))*progress
+	ramped := int(float64(maxConcurrency) * percent / 100)
+	if ramped < 1 {
+		ramped = 1
+	}
+	if ramped > maxConcurrency {
+		ramped = maxConcurrency
+	}
+	return ramped
+}
+
+// latestRecoveryTime 返回账号最近一次从限流/过载状态恢复的时间点
+// （已经过去的 RateLimitResetAt/OverloadUntil 中较晚者），从未被限流/过载过则返回 nil。
+func latestRecoveryTime(account *Account) *time.Time {
+	now := time.Now()
+	var latest *time.Time
+	consider := func(t *time.Time) {
+		if t == nil || !t.Before(now) {
+			return // nil 或仍未到期（仍在限流/过载中）
+		}
+		if latest == nil || t.After(*latest) {
+			latest = t
+		}
+	}
+	consider(account.RateLimitResetAt)
+	consider(account.OverloadUntil)
+	return latest
+}
+
 // AcquireAccountSlot attempts to acquire a concurrency slot for an account.
 // If the account is at max concurrency, it waits until a slot is available or timeout.
 // Returns a release function that MUST be called when the request completes.
@@ -136,6 +210,134 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	}, nil
 }
 
+// ReservedGroupSlots 描述某个分组在账号上预留的专属并发槽位数（account_groups.reserved_slots）。
+type ReservedGroupSlots struct {
+	GroupID       int64
+	ReservedSlots int
+}
+
+// effectiveAccountConcurrencyForGroup 计算分组在账号上实际可用的并发上限：账号为某些分组
+// 预留了专属槽位时，这些槽位只能被对应分组占用，其它分组在该账号上最多只能使用
+// (maxConcurrency - 其它分组的预留槽位之和)；分组为自己预留的槽位不收窄，仍可使用账号全部
+// 并发（预留槽位 + 共享部分）。
+func effectiveAccountConcurrencyForGroup(maxConcurrency int, reservations []ReservedGroupSlots, requestingGroupID int64) int {
+	if maxConcurrency <= 0 || len(reservations) == 0 {
+		return maxConcurrency
+	}
+
+	reservedByOthers := 0
+	for _, r := range reservations {
+		if r.ReservedSlots <= 0 || r.GroupID == requestingGroupID {
+			continue
+		}
+		reservedByOthers += r.ReservedSlots
+	}
+	if reservedByOthers <= 0 {
+		return maxConcurrency
+	}
+
+	effective := maxConcurrency - reservedByOthers
+	if effective < 0 {
+		return 0
+	}
+	return effective
+}
+
+// ownReservedSlots 返回 requestingGroupID 在 reservations 中为自己预留的槽位数，
+// 未预留（或 requestingGroupID 为 0，即无分组上下文）时返回 0。
+func ownReservedSlots(reservations []ReservedGroupSlots, requestingGroupID int64) int {
+	if requestingGroupID == 0 {
+		return 0
+	}
+	for _, r := range reservations {
+		if r.GroupID == requestingGroupID {
+			return r.ReservedSlots
+		}
+	}
+	return 0
+}
+
+// AcquireAccountSlotForGroup 在 AcquireAccountSlot 基础上应用分组预留槽位限制：账号为某些
+// 分组预留了专属并发槽位时，请求所属分组（requestingGroupID）若不是预留方，则只能使用
+// (maxConcurrency - 其它分组预留槽位之和) 个槽位，确保预留方始终能在账号上获得保证的容量。
+//
+// 请求所属分组自身持有预留份额时，不能直接复用共享的账号级计数器去判断是否还有空位：
+// 多个预留分组共享同一个账号级槽位键时，一个分组用满自己的预留份额会把该计数器推高，
+// 导致另一个预留分组即使尚未触及自己的保证容量，也会被判定为"账号已满"而拒绝获取。
+// 因此持有预留份额的分组改为调用 AcquireReservedAccountSlot，在未用满自己的预留份额前
+// 只受账号硬上限约束；用满后再和非预留分组一样受共享上限约束。
+func (s *ConcurrencyService) AcquireAccountSlotForGroup(ctx context.Context, accountID int64, maxConcurrency int, reservations []ReservedGroupSlots, requestingGroupID int64) (*AcquireResult, error) {
+	effectiveMaxConcurrency := effectiveAccountConcurrencyForGroup(maxConcurrency, reservations, requestingGroupID)
+
+	reservedSlots := ownReservedSlots(reservations, requestingGroupID)
+	if reservedSlots <= 0 {
+		return s.AcquireAccountSlot(ctx, accountID, effectiveMaxConcurrency)
+	}
+
+	if maxConcurrency <= 0 {
+		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, nil
+	}
+
+	requestID := generateRequestID()
+	acquired, err := s.cache.AcquireReservedAccountSlot(ctx, accountID, requestingGroupID, maxConcurrency, reservedSlots, effectiveMaxConcurrency, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return &AcquireResult{Acquired: false, ReleaseFunc: nil}, nil
+	}
+
+	return &AcquireResult{
+		Acquired: true,
+		ReleaseFunc: func() {
+			bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.cache.ReleaseReservedAccountSlot(bgCtx, accountID, requestingGroupID, requestID); err != nil {
+				log.Printf("Warning: failed to release reserved account slot for account=%d group=%d (req=%s): %v", accountID, requestingGroupID, requestID, err)
+			}
+		},
+	}, nil
+}
+
+// AcquireAccountSlotWithAffinityBorrowing 先尝试为账号获取自身的并发槽位；若账号已达自身
+// 并发上限，则按顺序从 peers（同一亲和分组内的其它账号）中寻找尚有空闲容量的账号借用一个
+// 槽位，最多借用 borrowCap 个，超出后即使 peers 中仍有空闲账号也不再借用。借用的槽位计入
+// 出借账号自身的并发槽位，因此不会让出借账号超过其自身并发上限。
+func (s *ConcurrencyService) AcquireAccountSlotWithAffinityBorrowing(ctx context.Context, accountID int64, maxConcurrency int, peers []AccountWithConcurrency, borrowCap int) (*AcquireResult, error) {
+	result, err := s.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+	if err != nil || result.Acquired || borrowCap <= 0 || len(peers) == 0 {
+		return result, err
+	}
+
+	requestID := generateRequestID()
+	for _, peer := range peers {
+		if peer.MaxConcurrency <= 0 {
+			continue
+		}
+		lenderID := peer.ID
+		acquired, err := s.cache.AcquireBorrowedAccountSlot(ctx, lenderID, peer.MaxConcurrency, accountID, borrowCap, requestID)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			continue
+		}
+		return &AcquireResult{
+			Acquired: true,
+			ReleaseFunc: func() {
+				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := s.cache.ReleaseBorrowedAccountSlot(bgCtx, lenderID, accountID, requestID); err != nil {
+					log.Printf("Warning: failed to release borrowed account slot (lender=%d borrower=%d req=%s): %v", lenderID, accountID, requestID, err)
+				}
+			},
+		}, nil
+	}
+
+	// 分组内没有账号有空闲容量可借，返回账号自身未获取到槽位的原始结果（走等待计划）
+	return result, nil
+}
+
 // AcquireUserSlot attempts to acquire a concurrency slot for a user.
 // If the user is at max concurrency, it waits until a slot is available or timeout.
 // Returns a release function that MUST be called when the request completes.
@@ -175,6 +377,46 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 	}, nil
 }
 
+// AcquireUserStreamSlot attempts to acquire a streaming-connection slot for a user.
+// Unlike AcquireUserSlot, this never waits: streaming connections are long-lived, so a
+// user already at the cap is rejected immediately instead of being queued.
+// Counted independently from the general concurrency slot (AcquireUserSlot).
+func (s *ConcurrencyService) AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int) (*AcquireResult, error) {
+	// If maxConcurrentStreams is 0 or negative, no limit
+	if maxConcurrentStreams <= 0 {
+		return &AcquireResult{
+			Acquired:    true,
+			ReleaseFunc: func() {}, // no-op
+		}, nil
+	}
+
+	// Generate unique request ID for this slot
+	requestID := generateRequestID()
+
+	acquired, err := s.cache.AcquireUserStreamSlot(ctx, userID, maxConcurrentStreams, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if acquired {
+		return &AcquireResult{
+			Acquired: true,
+			ReleaseFunc: func() {
+				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := s.cache.ReleaseUserStreamSlot(bgCtx, userID, requestID); err != nil {
+					log.Printf("Warning: failed to release user stream slot for %d (req=%s): %v", userID, requestID, err)
+				}
+			},
+		}, nil
+	}
+
+	return &AcquireResult{
+		Acquired:    false,
+		ReleaseFunc: nil,
+	}, nil
+}
+
 // ============================================
 // Wait Queue Count Methods
 // ============================================
@@ -275,14 +517,19 @@ func (s *ConcurrencyService) GetUsersLoadBatch(ctx context.Context, users []User
 }
 
 // CleanupExpiredAccountSlots removes expired slots for one account (background task).
-func (s *ConcurrencyService) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error {
+// Returns the number of slots actually reclaimed, which is non-zero only when a
+// slot leaked (e.g. a missed ReleaseFunc on a panic path) and had to be reaped by TTL.
+func (s *ConcurrencyService) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) (int, error) {
 	if s.cache == nil {
-		return nil
+		return 0, nil
 	}
 	return s.cache.CleanupExpiredAccountSlots(ctx, accountID)
 }
 
-// StartSlotCleanupWorker starts a background cleanup worker for expired account slots.
+// StartSlotCleanupWorker starts a background watchdog that periodically reconciles
+// held concurrency slots against their TTL, reclaiming any that leaked (e.g. because
+// a ReleaseFunc was never called on a panic path) and logging when that happens so
+// leaks don't silently make an account look saturated forever.
 func (s *ConcurrencyService) StartSlotCleanupWorker(accountRepo AccountRepository, interval time.Duration) {
 	if s == nil || s.cache == nil || accountRepo == nil || interval <= 0 {
 		return
@@ -298,10 +545,14 @@ func (s *ConcurrencyService) StartSlotCleanupWorker(accountRepo AccountRepositor
 		}
 		for _, account := range accounts {
 			accountCtx, accountCancel := context.WithTimeout(context.Background(), 2*time.Second)
-			err := s.cache.CleanupExpiredAccountSlots(accountCtx, account.ID)
+			reclaimed, err := s.cache.CleanupExpiredAccountSlots(accountCtx, account.ID)
 			accountCancel()
 			if err != nil {
 				log.Printf("Warning: cleanup expired slots failed for account %d: %v", account.ID, err)
+				continue
+			}
+			if reclaimed > 0 {
+				log.Printf("Warning: reclaimed %d leaked concurrency slot(s) for account %d", reclaimed, account.ID)
 			}
 		}
 	}
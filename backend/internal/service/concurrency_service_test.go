@@ -0,0 +1,561 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConcurrencyCache is a minimal test double for ConcurrencyCache. Only
+// CleanupExpiredAccountSlots is exercised by these tests; every other method
+// is an unused no-op stub.
+type fakeConcurrencyCache struct {
+	mu                   sync.Mutex
+	cleanupCalls         []int64
+	reclaimedByAccountID map[int64]int
+}
+
+func (f *fakeConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) GetAccountConcurrency(ctx context.Context, accountID int64) (int, error) {
+	return 0, nil
+}
+func (f *fakeConcurrencyCache) AcquireBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, lenderMaxConcurrency int, borrowerAccountID int64, borrowCap int, requestID string) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) ReleaseBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, borrowerAccountID int64, requestID string) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) AcquireReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, maxConcurrency int, reservedSlots int, effectiveMaxConcurrency int, requestID string) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) ReleaseReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, requestID string) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) DecrementAccountWaitCount(ctx context.Context, accountID int64) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) GetAccountWaitingCount(ctx context.Context, accountID int64) (int, error) {
+	return 0, nil
+}
+func (f *fakeConcurrencyCache) AcquireUserSlot(ctx context.Context, userID int64, maxConcurrency int, requestID string) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) ReleaseUserSlot(ctx context.Context, userID int64, requestID string) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) GetUserConcurrency(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+func (f *fakeConcurrencyCache) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) DecrementWaitCount(ctx context.Context, userID int64) error {
+	return nil
+}
+func (f *fakeConcurrencyCache) AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int, requestID string) (bool, error) {
+	return true, nil
+}
+func (f *fakeConcurrencyCache) ReleaseUserStreamSlot(ctx context.Context, userID int64, requestID string) error {
+	return nil
+}
+
+// streamCapFakeCache embeds fakeConcurrencyCache (whose AcquireUserSlot always
+// grants the general slot) and additionally enforces a real per-user cap on
+// AcquireUserStreamSlot, so tests can verify the two slot types are tracked
+// independently.
+type streamCapFakeCache struct {
+	fakeConcurrencyCache
+	streamSlots map[int64]int
+}
+
+func (f *streamCapFakeCache) AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int, requestID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.streamSlots == nil {
+		f.streamSlots = map[int64]int{}
+	}
+	if f.streamSlots[userID] >= maxConcurrentStreams {
+		return false, nil
+	}
+	f.streamSlots[userID]++
+	return true, nil
+}
+
+func (f *streamCapFakeCache) ReleaseUserStreamSlot(ctx context.Context, userID int64, requestID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.streamSlots[userID]--
+	return nil
+}
+func (f *fakeConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error) {
+	return map[int64]*AccountLoadInfo{}, nil
+}
+func (f *fakeConcurrencyCache) GetUsersLoadBatch(ctx context.Context, users []UserWithConcurrency) (map[int64]*UserLoadInfo, error) {
+	return map[int64]*UserLoadInfo{}, nil
+}
+func (f *fakeConcurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleanupCalls = append(f.cleanupCalls, accountID)
+	return f.reclaimedByAccountID[accountID], nil
+}
+
+// schedulableAccountRepoStub only supports ListSchedulable; every other
+// AccountRepository method panics if called, since StartSlotCleanupWorker
+// should never touch them.
+type schedulableAccountRepoStub struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (s *schedulableAccountRepoStub) ListSchedulable(ctx context.Context) ([]Account, error) {
+	return s.accounts, nil
+}
+
+func TestConcurrencyService_CleanupExpiredAccountSlots_ReturnsReclaimedCount(t *testing.T) {
+	cache := &fakeConcurrencyCache{reclaimedByAccountID: map[int64]int{1: 2}}
+	svc := NewConcurrencyService(cache)
+
+	reclaimed, err := svc.CleanupExpiredAccountSlots(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, reclaimed)
+}
+
+func TestConcurrencyService_StartSlotCleanupWorker_ReclaimsLeakedSlot(t *testing.T) {
+	// Simulate a slot that leaked because its ReleaseFunc was never called
+	// (e.g. a panic path skipped the deferred release): the cache reports 1
+	// stale slot reclaimed for account 42 on the first cleanup pass.
+	cache := &fakeConcurrencyCache{reclaimedByAccountID: map[int64]int{42: 1}}
+	repo := &schedulableAccountRepoStub{accounts: []Account{{ID: 42}, {ID: 43}}}
+	svc := NewConcurrencyService(cache)
+
+	svc.StartSlotCleanupWorker(repo, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		seen := map[int64]bool{}
+		for _, id := range cache.cleanupCalls {
+			seen[id] = true
+		}
+		return seen[42] && seen[43]
+	}, time.Second, 5*time.Millisecond, "expected cleanup to run for both schedulable accounts")
+}
+
+func TestConcurrencyService_AcquireUserStreamSlot_RejectsAtCapButGeneralSlotStillWorks(t *testing.T) {
+	cache := &streamCapFakeCache{}
+	svc := NewConcurrencyService(cache)
+
+	first, err := svc.AcquireUserStreamSlot(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.True(t, first.Acquired)
+
+	// A second concurrent stream for the same user is at the cap and must be
+	// rejected immediately rather than queued.
+	second, err := svc.AcquireUserStreamSlot(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.False(t, second.Acquired)
+
+	// The general (non-streaming) concurrency slot is tracked independently,
+	// so it must still be acquirable for the same user.
+	general, err := svc.AcquireUserSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.True(t, general.Acquired)
+
+	first.ReleaseFunc()
+	third, err := svc.AcquireUserStreamSlot(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.True(t, third.Acquired, "slot should be available again after release")
+}
+
+// borrowFakeCache 模拟亲和借用场景：accountSaturated 标记哪些账号自身槽位已满，
+// lenderCapacity 记录每个出借账号当前剩余的空闲容量，每次成功借用后递减，释放后归还。
+type borrowFakeCache struct {
+	fakeConcurrencyCache
+	accountSaturated map[int64]bool
+	lenderCapacity   map[int64]int
+	borrowCalls      []int64
+	released         []int64
+}
+
+func (f *borrowFakeCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	if f.accountSaturated[accountID] {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (f *borrowFakeCache) AcquireBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, lenderMaxConcurrency int, borrowerAccountID int64, borrowCap int, requestID string) (bool, error) {
+	f.borrowCalls = append(f.borrowCalls, lenderAccountID)
+	if f.lenderCapacity[lenderAccountID] <= 0 {
+		return false, nil
+	}
+	f.lenderCapacity[lenderAccountID]--
+	return true, nil
+}
+
+func (f *borrowFakeCache) ReleaseBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, borrowerAccountID int64, requestID string) error {
+	f.released = append(f.released, lenderAccountID)
+	f.lenderCapacity[lenderAccountID]++
+	return nil
+}
+
+func TestConcurrencyService_AcquireAccountSlotWithAffinityBorrowing_SkipsBorrowingWhenOwnSlotAvailable(t *testing.T) {
+	cache := &borrowFakeCache{lenderCapacity: map[int64]int{2: 1}}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireAccountSlotWithAffinityBorrowing(context.Background(), 1, 5, []AccountWithConcurrency{{ID: 2, MaxConcurrency: 5}}, 2)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.Empty(t, cache.borrowCalls, "own slot was available, should not attempt to borrow")
+}
+
+func TestConcurrencyService_AcquireAccountSlotWithAffinityBorrowing_BorrowsFromIdlePeerWhenSaturated(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 1},
+	}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireAccountSlotWithAffinityBorrowing(context.Background(), 1, 5, []AccountWithConcurrency{{ID: 2, MaxConcurrency: 5}}, 2)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.Equal(t, []int64{2}, cache.borrowCalls)
+
+	result.ReleaseFunc()
+	require.Eventually(t, func() bool { return len(cache.released) == 1 }, time.Second, time.Millisecond, "release should credit the lender account")
+	require.Equal(t, []int64{2}, cache.released)
+}
+
+func TestConcurrencyService_AcquireAccountSlotWithAffinityBorrowing_SkipsSaturatedPeerTriesNext(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 0, 3: 1},
+	}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireAccountSlotWithAffinityBorrowing(context.Background(), 1, 5,
+		[]AccountWithConcurrency{{ID: 2, MaxConcurrency: 5}, {ID: 3, MaxConcurrency: 5}}, 2)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.Equal(t, []int64{2, 3}, cache.borrowCalls, "should try peer 2 first, then fall through to peer 3")
+}
+
+func TestConcurrencyService_AcquireAccountSlotWithAffinityBorrowing_NoIdlePeerReturnsUnacquired(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 0},
+	}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireAccountSlotWithAffinityBorrowing(context.Background(), 1, 5, []AccountWithConcurrency{{ID: 2, MaxConcurrency: 5}}, 2)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+}
+
+func TestConcurrencyService_AcquireAccountSlotWithAffinityBorrowing_ZeroBorrowCapDisablesBorrowing(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 1},
+	}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireAccountSlotWithAffinityBorrowing(context.Background(), 1, 5, []AccountWithConcurrency{{ID: 2, MaxConcurrency: 5}}, 0)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+	require.Empty(t, cache.borrowCalls)
+}
+
+func TestConcurrencyService_AcquireUserStreamSlot_ZeroCapIsUnlimited(t *testing.T) {
+	cache := &streamCapFakeCache{}
+	svc := NewConcurrencyService(cache)
+
+	result, err := svc.AcquireUserStreamSlot(context.Background(), 1, 0)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+}
+
+func TestEffectiveAccountConcurrency_DisabledReturnsFullConcurrency(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: false}
+	resetAt := time.Now().Add(-time.Second)
+	account := &Account{Concurrency: 10, RateLimitResetAt: &resetAt}
+
+	require.Equal(t, 10, effectiveAccountConcurrency(cfg, account))
+}
+
+func TestEffectiveAccountConcurrency_NoRecoveryTimestampReturnsFullConcurrency(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: true, RecoveryRampDurationSeconds: 120, RecoveryRampInitialPercent: 25}
+	account := &Account{Concurrency: 10}
+
+	require.Equal(t, 10, effectiveAccountConcurrency(cfg, account))
+}
+
+func TestEffectiveAccountConcurrency_StillRateLimitedReturnsFullConcurrency(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: true, RecoveryRampDurationSeconds: 120, RecoveryRampInitialPercent: 25}
+	resetAt := time.Now().Add(time.Minute) // 尚未恢复
+	account := &Account{Concurrency: 10, RateLimitResetAt: &resetAt}
+
+	require.Equal(t, 10, effectiveAccountConcurrency(cfg, account))
+}
+
+func TestEffectiveAccountConcurrency_ThrottledImmediatelyAfterRecovery(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: true, RecoveryRampDurationSeconds: 120, RecoveryRampInitialPercent: 25}
+	resetAt := time.Now().Add(-100 * time.Millisecond) // 刚刚恢复
+	account := &Account{Concurrency: 20, RateLimitResetAt: &resetAt}
+
+	got := effectiveAccountConcurrency(cfg, account)
+	require.Less(t, got, 20)
+	require.GreaterOrEqual(t, got, 1)
+}
+
+func TestEffectiveAccountConcurrency_ReturnsToFullAfterRampWindowElapses(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: true, RecoveryRampDurationSeconds: 120, RecoveryRampInitialPercent: 25}
+	resetAt := time.Now().Add(-200 * time.Second) // 爬坡窗口早已结束
+	account := &Account{Concurrency: 20, RateLimitResetAt: &resetAt}
+
+	require.Equal(t, 20, effectiveAccountConcurrency(cfg, account))
+}
+
+func TestEffectiveAccountConcurrency_UsesLatestOfRateLimitAndOverloadRecovery(t *testing.T) {
+	cfg := &config.ConcurrencyConfig{RecoveryRampEnabled: true, RecoveryRampDurationSeconds: 120, RecoveryRampInitialPercent: 25}
+	longRecovered := time.Now().Add(-200 * time.Second) // 已经走完爬坡
+	justRecovered := time.Now().Add(-100 * time.Millisecond)
+	account := &Account{Concurrency: 20, RateLimitResetAt: &longRecovered, OverloadUntil: &justRecovered}
+
+	got := effectiveAccountConcurrency(cfg, account)
+	require.Less(t, got, 20, "should ramp based on the most recent recovery (overload), not the older rate-limit recovery")
+}
+
+func TestEffectiveAccountConcurrencyForGroup_NoReservationsReturnsFullConcurrency(t *testing.T) {
+	require.Equal(t, 5, effectiveAccountConcurrencyForGroup(5, nil, 1))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_UnlimitedConcurrencyIsUnaffected(t *testing.T) {
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 2}}
+	require.Equal(t, 0, effectiveAccountConcurrencyForGroup(0, reservations, 2))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_RequestingGroupIgnoresOwnReservation(t *testing.T) {
+	// Group 1 reserved 2 of the account's 5 slots. Group 1 itself should still
+	// see the full 5 (its own reservation plus the shared remainder).
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 2}}
+	require.Equal(t, 5, effectiveAccountConcurrencyForGroup(5, reservations, 1))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_OtherGroupIsNarrowedByReservation(t *testing.T) {
+	// Group 1 reserved 2 of the account's 5 slots, so a different group (2)
+	// can only ever see 3 as the shared remainder.
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 2}}
+	require.Equal(t, 3, effectiveAccountConcurrencyForGroup(5, reservations, 2))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_SumsReservationsAcrossOtherGroups(t *testing.T) {
+	reservations := []ReservedGroupSlots{
+		{GroupID: 1, ReservedSlots: 2},
+		{GroupID: 2, ReservedSlots: 1},
+	}
+	require.Equal(t, 2, effectiveAccountConcurrencyForGroup(5, reservations, 3))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_ReservationExceedingMaxFloorsAtZero(t *testing.T) {
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 10}}
+	require.Equal(t, 0, effectiveAccountConcurrencyForGroup(5, reservations, 2))
+}
+
+func TestEffectiveAccountConcurrencyForGroup_ZeroAndNegativeReservationsAreIgnored(t *testing.T) {
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 0}, {GroupID: 2, ReservedSlots: -1}}
+	require.Equal(t, 5, effectiveAccountConcurrencyForGroup(5, reservations, 3))
+}
+
+// accountSlotCapFakeCache embeds fakeConcurrencyCache (whose AcquireAccountSlot
+// always grants the slot) and additionally enforces a real per-account cap, so
+// tests can verify that a narrowed maxConcurrency actually rejects excess
+// acquires once the shared pool is exhausted.
+type accountSlotCapFakeCache struct {
+	fakeConcurrencyCache
+	held map[int64]int
+}
+
+func (f *accountSlotCapFakeCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.held == nil {
+		f.held = map[int64]int{}
+	}
+	if f.held[accountID] >= maxConcurrency {
+		return false, nil
+	}
+	f.held[accountID]++
+	return true, nil
+}
+
+func (f *accountSlotCapFakeCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.held[accountID]--
+	return nil
+}
+
+func TestConcurrencyService_AcquireAccountSlotForGroup_NonReservedGroupCannotConsumeReservedSlots(t *testing.T) {
+	cache := &accountSlotCapFakeCache{}
+	svc := NewConcurrencyService(cache)
+	// Account has 5 total slots; group 1 has reserved 2 of them for itself.
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 2}}
+
+	// Group 2 (non-reserved) can only reach the shared remainder: 5-2=3.
+	var results []*AcquireResult
+	for i := 0; i < 3; i++ {
+		result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 5, reservations, 2)
+		require.NoError(t, err)
+		require.True(t, result.Acquired, "shared slot %d should be available", i)
+		results = append(results, result)
+	}
+
+	// The 4th acquire for the non-reserved group must be rejected even though
+	// group 1's reserved slots are sitting idle.
+	result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 5, reservations, 2)
+	require.NoError(t, err)
+	require.False(t, result.Acquired, "non-reserved group must not be able to consume reserved slots")
+
+	for _, r := range results {
+		r.ReleaseFunc()
+	}
+}
+
+func TestConcurrencyService_AcquireAccountSlotForGroup_ReservedGroupCanStillUseItsReservation(t *testing.T) {
+	cache := &accountSlotCapFakeCache{}
+	svc := NewConcurrencyService(cache)
+	reservations := []ReservedGroupSlots{{GroupID: 1, ReservedSlots: 2}}
+
+	// Exhaust the shared remainder (3) with the non-reserved group first.
+	for i := 0; i < 3; i++ {
+		result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 5, reservations, 2)
+		require.NoError(t, err)
+		require.True(t, result.Acquired)
+	}
+
+	// The reserved group is unaffected by other groups' usage and can still
+	// acquire up to the account's full concurrency.
+	result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 5, reservations, 1)
+	require.NoError(t, err)
+	require.True(t, result.Acquired, "reserved group should still be able to use its reserved slot")
+}
+
+// reservedSlotFakeCache mirrors the real Redis-backed accounting used by
+// AcquireReservedAccountSlot: a shared global counter per account plus an
+// independent counter per (account, group) pair, so tests can catch a
+// reserving group being starved by another reserving group's usage of the
+// shared global counter.
+type reservedSlotFakeCache struct {
+	fakeConcurrencyCache
+	mu          sync.Mutex
+	global      map[int64]int
+	byGroup     map[[2]int64]int
+	releaseCall int
+}
+
+func (f *reservedSlotFakeCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.global == nil {
+		f.global = map[int64]int{}
+	}
+	if f.global[accountID] >= maxConcurrency {
+		return false, nil
+	}
+	f.global[accountID]++
+	return true, nil
+}
+
+func (f *reservedSlotFakeCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.global[accountID]--
+	return nil
+}
+
+func (f *reservedSlotFakeCache) AcquireReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, maxConcurrency int, reservedSlots int, effectiveMaxConcurrency int, requestID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.global == nil {
+		f.global = map[int64]int{}
+	}
+	if f.byGroup == nil {
+		f.byGroup = map[[2]int64]int{}
+	}
+	key := [2]int64{accountID, groupID}
+
+	limit := effectiveMaxConcurrency
+	if f.byGroup[key] < reservedSlots {
+		limit = maxConcurrency
+	}
+	if f.global[accountID] >= limit {
+		return false, nil
+	}
+	f.global[accountID]++
+	f.byGroup[key]++
+	return true, nil
+}
+
+func (f *reservedSlotFakeCache) ReleaseReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, requestID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.releaseCall++
+	f.global[accountID]--
+	f.byGroup[[2]int64{accountID, groupID}]--
+	return nil
+}
+
+// TestConcurrencyService_AcquireAccountSlotForGroup_MultipleReservedGroupsDoNotStarveEachOther
+// reproduces the contention scenario between two groups that both hold a
+// reservation on the same account: group 1 filling its own 5-slot reservation
+// must not prevent group 2 from acquiring its own guaranteed 5 slots, even
+// though only a single account-wide counter backs the hard concurrency cap.
+func TestConcurrencyService_AcquireAccountSlotForGroup_MultipleReservedGroupsDoNotStarveEachOther(t *testing.T) {
+	cache := &reservedSlotFakeCache{}
+	svc := NewConcurrencyService(cache)
+	reservations := []ReservedGroupSlots{
+		{GroupID: 1, ReservedSlots: 5},
+		{GroupID: 2, ReservedSlots: 5},
+	}
+
+	// Group 1 fills its entire reservation.
+	var group1Results []*AcquireResult
+	for i := 0; i < 5; i++ {
+		result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 10, reservations, 1)
+		require.NoError(t, err)
+		require.True(t, result.Acquired, "group 1 slot %d within its own reservation should be available", i)
+		group1Results = append(group1Results, result)
+	}
+
+	// Group 2 must still be able to acquire its own guaranteed 5 slots even
+	// though the account-wide counter now reads 5/10 from group 1 alone.
+	for i := 0; i < 5; i++ {
+		result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 10, reservations, 2)
+		require.NoError(t, err)
+		require.True(t, result.Acquired, "group 2 slot %d within its own reservation must not be denied by group 1's usage", i)
+	}
+
+	// The account is now fully saturated (10/10); neither group can exceed it.
+	result, err := svc.AcquireAccountSlotForGroup(context.Background(), 1, 10, reservations, 1)
+	require.NoError(t, err)
+	require.False(t, result.Acquired, "account is at its hard concurrency cap")
+
+	for _, r := range group1Results {
+		r.ReleaseFunc()
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCountTokensCacheMiss 标记 count_tokens 结果缓存未命中。
+var ErrCountTokensCacheMiss = errors.New("count_tokens 缓存未命中")
+
+// CountTokensCacheEntry 缓存的 count_tokens 响应，命中时原样回放给客户端。
+type CountTokensCacheEntry struct {
+	StatusCode int    `json:"status_code"`
+	Body       []byte `json:"body"`
+}
+
+// CountTokensCache 定义 count_tokens 结果的短期缓存接口。
+// 相同模型+请求体的重复请求可在 TTL 内直接复用缓存结果，避免反复转发到上游。
+type CountTokensCache interface {
+	GetCountTokensResult(ctx context.Context, key string) (*CountTokensCacheEntry, error)
+	SetCountTokensResult(ctx context.Context, key string, entry *CountTokensCacheEntry, ttl time.Duration) error
+}
@@ -140,6 +140,36 @@ func (s *DashboardService) GetModelStatsWithFilters(ctx context.Context, startTi
 	return stats, nil
 }
 
+func (s *DashboardService) GetTagStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) ([]usagestats.TagStat, error) {
+	stats, err := s.usageRepo.GetTagStatsWithFilters(ctx, startTime, endTime, userID, apiKeyID, accountID, groupID, stream, billingType)
+	if err != nil {
+		return nil, fmt.Errorf("get tag stats with filters: %w", err)
+	}
+	return stats, nil
+}
+
+// GetAccountStatsWithFilters returns per-account usage statistics (counts, tokens, cost)
+// for a time range, so admins can validate that priority/weight scheduling settings
+// produce the intended account selection distribution.
+func (s *DashboardService) GetAccountStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, groupID int64, stream *bool, billingType *int8) ([]usagestats.AccountStat, error) {
+	stats, err := s.usageRepo.GetAccountStatsWithFilters(ctx, startTime, endTime, userID, apiKeyID, groupID, stream, billingType)
+	if err != nil {
+		return nil, fmt.Errorf("get account stats with filters: %w", err)
+	}
+	return stats, nil
+}
+
+// GetCacheSavingsStatsWithFilters returns how much prompt caching saved over a time
+// range (actual cache_read cost vs. the equivalent cost billed as regular input
+// tokens), so admins can justify sticky-session/caching configuration.
+func (s *DashboardService) GetCacheSavingsStatsWithFilters(ctx context.Context, startTime, endTime time.Time, userID, apiKeyID, accountID, groupID int64, stream *bool, billingType *int8) (*usagestats.CacheSavingsStats, error) {
+	stats, err := s.usageRepo.GetCacheSavingsStatsWithFilters(ctx, startTime, endTime, userID, apiKeyID, accountID, groupID, stream, billingType)
+	if err != nil {
+		return nil, fmt.Errorf("get cache savings stats with filters: %w", err)
+	}
+	return stats, nil
+}
+
 func (s *DashboardService) getCachedDashboardStats(ctx context.Context) (*usagestats.DashboardStats, bool, error) {
 	data, err := s.cache.GetDashboardStats(ctx)
 	if err != nil {
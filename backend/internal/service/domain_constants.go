@@ -60,6 +60,12 @@ const (
 	SubscriptionTypeSubscription = domain.SubscriptionTypeSubscription // 订阅模式（按限额控制）
 )
 
+// Group subscription overflow policy constants
+const (
+	SubscriptionOverflowPolicySubscriptionOnly = domain.SubscriptionOverflowPolicySubscriptionOnly
+	SubscriptionOverflowPolicyFallbackBalance  = domain.SubscriptionOverflowPolicyFallbackBalance
+)
+
 // Subscription status constants
 const (
 	SubscriptionStatusActive    = domain.SubscriptionStatusActive
@@ -70,6 +76,9 @@ const (
 // LinuxDoConnectSyntheticEmailDomain 是 LinuxDo Connect 用户的合成邮箱后缀（RFC 保留域名）。
 const LinuxDoConnectSyntheticEmailDomain = "@linuxdo-connect.invalid"
 
+// CurrencyUSD 是分组计费展示货币的默认值。
+const CurrencyUSD = domain.CurrencyUSD
+
 // Setting keys
 const (
 	// 注册设置
@@ -96,6 +105,10 @@ const (
 	// TOTP 双因素认证设置
 	SettingKeyTotpEnabled = "totp_enabled" // 是否启用 TOTP 2FA 功能
 
+	// JWT 签名密钥轮换状态：持久化后多副本部署下所有实例都能感知到轮换，
+	// 而不是只有执行轮换的那个实例在内存中生效。
+	SettingKeyJWTRotationState = "jwt_rotation_state"
+
 	// LinuxDo Connect OAuth 登录设置
 	SettingKeyLinuxDoConnectEnabled      = "linuxdo_connect_enabled"
 	SettingKeyLinuxDoConnectClientID     = "linuxdo_connect_client_id"
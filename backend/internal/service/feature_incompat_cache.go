@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// featureIncompatKey 标识某个账号对某个请求特征（如 anthropic-beta、thinking）的短暂不兼容状态。
+type featureIncompatKey struct {
+	accountID int64
+	feature   string
+}
+
+// featureIncompatCache 记录账号对特定请求特征的短暂不兼容标记（进程内缓存，重启后自动清空）。
+// 当上游对携带某特征的请求返回的 400 被判定为兼容性问题时（见 classifyFeatureIncompat），
+// 该账号在 TTL 内对携带相同特征的请求被跳过调度，但仍可继续服务不涉及该特征的请求。
+type featureIncompatCache struct {
+	mu      sync.RWMutex
+	expires map[featureIncompatKey]time.Time
+}
+
+func newFeatureIncompatCache() *featureIncompatCache {
+	return &featureIncompatCache{expires: make(map[featureIncompatKey]time.Time)}
+}
+
+// mark 记录 accountID 在 ttl 内对 feature 不兼容。feature 为空或 ttl 非正时不记录。
+func (c *featureIncompatCache) mark(accountID int64, feature string, ttl time.Duration) {
+	if c == nil || feature == "" || ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires[featureIncompatKey{accountID, feature}] = time.Now().Add(ttl)
+}
+
+// isMarked 判断 accountID 当前是否因 feature 被标记为短暂不兼容。
+func (c *featureIncompatCache) isMarked(accountID int64, feature string) bool {
+	if c == nil || feature == "" {
+		return false
+	}
+	key := featureIncompatKey{accountID, feature}
+	c.mu.RLock()
+	until, ok := c.expires[key]
+	c.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		c.mu.Lock()
+		delete(c.expires, key)
+		c.mu.Unlock()
+		return false
+	}
+	return true
+}
+
+// classifyFeatureIncompat 识别 400 错误消息中与请求特征相关的兼容性问题，
+// 返回触发 failover 的特征名（用于短暂标记账号），无法识别时返回空字符串。
+func classifyFeatureIncompat(msg string) string {
+	switch {
+	case strings.Contains(msg, "anthropic-beta") || strings.Contains(msg, "beta feature") || strings.Contains(msg, "requires beta"):
+		return "beta"
+	case strings.Contains(msg, "thinking") || strings.Contains(msg, "thought_signature") || strings.Contains(msg, "signature"):
+		return "thinking"
+	case strings.Contains(msg, "tool_use") || strings.Contains(msg, "tool_result") || strings.Contains(msg, "tools"):
+		return "tools"
+	default:
+		return ""
+	}
+}
+
+// isAccountFeatureIncompatible 判断 accountID 是否因 features 中的任一特征被短暂标记为不兼容。
+func isAccountFeatureIncompatible(cache *featureIncompatCache, accountID int64, features []string) bool {
+	for _, feature := range features {
+		if cache.isMarked(accountID, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestIncompatFeatures 返回当前请求涉及的特征集合，用于匹配 featureIncompatCache 的标记。
+func requestIncompatFeatures(ctx context.Context) []string {
+	var features []string
+	if enabled, ok := ctx.Value(ctxkey.ThinkingEnabled).(bool); ok && enabled {
+		features = append(features, "thinking")
+	}
+	if beta, ok := ctx.Value(ctxkey.AnthropicBetaHeader).(string); ok && strings.TrimSpace(beta) != "" {
+		features = append(features, "beta")
+	}
+	return features
+}
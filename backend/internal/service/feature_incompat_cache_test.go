@@ -0,0 +1,65 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyFeatureIncompat(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{"beta header missing", "the request requires the anthropic-beta header", "beta"},
+		{"beta feature", "this is a beta feature not enabled for your account", "beta"},
+		{"thinking blocks", "thinking or redacted_thinking blocks in the latest assistant message cannot be modified", "thinking"},
+		{"tool use", "messages.1.content.0.tool_use: unexpected tool_use block", "tools"},
+		{"unrelated", "invalid api key", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, classifyFeatureIncompat(tt.msg))
+		})
+	}
+}
+
+func TestFeatureIncompatCache_MarkAndExpire(t *testing.T) {
+	cache := newFeatureIncompatCache()
+
+	require.False(t, cache.isMarked(1, "beta"), "unmarked account should not be flagged")
+
+	cache.mark(1, "beta", 20*time.Millisecond)
+	require.True(t, cache.isMarked(1, "beta"))
+	require.False(t, cache.isMarked(1, "thinking"), "marking one feature must not affect another")
+	require.False(t, cache.isMarked(2, "beta"), "marking one account must not affect another")
+
+	time.Sleep(40 * time.Millisecond)
+	require.False(t, cache.isMarked(1, "beta"), "mark should expire after its ttl")
+}
+
+func TestFeatureIncompatCache_MarkNoopOnZeroTTLOrEmptyFeature(t *testing.T) {
+	cache := newFeatureIncompatCache()
+	cache.mark(1, "", time.Minute)
+	cache.mark(1, "beta", 0)
+	require.False(t, cache.isMarked(1, "beta"))
+}
+
+func TestRequestIncompatFeatures(t *testing.T) {
+	ctx := context.Background()
+	require.Empty(t, requestIncompatFeatures(ctx))
+
+	thinkingCtx := context.WithValue(ctx, ctxkey.ThinkingEnabled, true)
+	require.Equal(t, []string{"thinking"}, requestIncompatFeatures(thinkingCtx))
+
+	betaCtx := context.WithValue(ctx, ctxkey.AnthropicBetaHeader, "interleaved-thinking-2025-05-14")
+	require.Equal(t, []string{"beta"}, requestIncompatFeatures(betaCtx))
+
+	require.False(t, isAccountFeatureIncompatible(nil, 1, []string{"beta"}), "nil cache must fail open")
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"testing"
 
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,7 +87,79 @@ func TestMergeAnthropicBetaDropping_Context1M(t *testing.T) {
 	incoming := "context-1m-2025-08-07,foo-beta,oauth-2025-04-20"
 	drop := map[string]struct{}{"context-1m-2025-08-07": {}}
 
-	got := mergeAnthropicBetaDropping(required, incoming, drop)
+	got := mergeAnthropicBetaDropping(required, incoming, drop, 0)
 	require.Equal(t, "oauth-2025-04-20,interleaved-thinking-2025-05-14,foo-beta", got)
 	require.NotContains(t, got, "context-1m-2025-08-07")
 }
+
+func TestMergeAnthropicBetaDropping_CapKeepsRequiredDropsExcess(t *testing.T) {
+	required := []string{"oauth-2025-04-20", "interleaved-thinking-2025-05-14"}
+	incoming := "foo-beta,bar-beta,baz-beta"
+
+	// Cap just enough for the required betas plus "foo-beta"; the rest are
+	// lower priority (merged in later) and must be dropped first.
+	maxLen := len("oauth-2025-04-20,interleaved-thinking-2025-05-14,foo-beta")
+	got := mergeAnthropicBetaDropping(required, incoming, nil, maxLen)
+
+	require.Equal(t, "oauth-2025-04-20,interleaved-thinking-2025-05-14,foo-beta", got)
+	require.NotContains(t, got, "bar-beta")
+	require.NotContains(t, got, "baz-beta")
+}
+
+func TestMergeAnthropicBetaDropping_CapNeverDropsRequired(t *testing.T) {
+	required := []string{"oauth-2025-04-20", "interleaved-thinking-2025-05-14"}
+
+	// Cap far too small even for the required betas alone: they must survive anyway.
+	got := mergeAnthropicBetaDropping(required, "foo-beta", nil, 5)
+	require.Equal(t, "oauth-2025-04-20,interleaved-thinking-2025-05-14", got)
+}
+
+func TestMergeAnthropicBetaDropping_NoCap(t *testing.T) {
+	required := []string{"oauth-2025-04-20"}
+	got := mergeAnthropicBetaDropping(required, "foo-beta,bar-beta", nil, 0)
+	require.Equal(t, "oauth-2025-04-20,foo-beta,bar-beta", got)
+}
+
+func TestDropAnthropicBetas(t *testing.T) {
+	header := "oauth-2025-04-20,interleaved-thinking-2025-05-14,foo-beta"
+
+	got := dropAnthropicBetas(header, []string{"foo-beta"})
+	require.Equal(t, "oauth-2025-04-20,interleaved-thinking-2025-05-14", got)
+
+	// Required betas are only removed when explicitly blacklisted.
+	got = dropAnthropicBetas(header, []string{"bar-beta"})
+	require.Equal(t, header, got)
+
+	require.Equal(t, "", dropAnthropicBetas("", []string{"foo-beta"}))
+	require.Equal(t, header, dropAnthropicBetas(header, nil))
+}
+
+func TestGetBetaHeader_OAuthDenylistRemovesConflictingBetaButKeepsOAuth(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{
+		Gateway: config.GatewayConfig{
+			OAuthBetaDenylist: []string{"foo-beta"},
+		},
+	}}
+
+	got := svc.getBetaHeader("claude-sonnet-4", "claude-code-20250219,foo-beta")
+	require.Contains(t, got, "oauth-2025-04-20")
+	require.NotContains(t, got, "foo-beta")
+}
+
+func TestGetBetaHeader_NoDenylistConfiguredKeepsClientBetas(t *testing.T) {
+	svc := &GatewayService{}
+
+	got := svc.getBetaHeader("claude-sonnet-4", "claude-code-20250219,foo-beta")
+	require.Contains(t, got, "oauth-2025-04-20")
+	require.Contains(t, got, "foo-beta")
+}
+
+func TestAccount_GetAnthropicBetaBlacklist(t *testing.T) {
+	account := &Account{}
+	require.Nil(t, account.GetAnthropicBetaBlacklist())
+
+	account.Extra = map[string]any{
+		"anthropic_beta_blacklist": []any{"context-1m-2025-08-07", "foo-beta"},
+	}
+	require.Equal(t, []string{"context-1m-2025-08-07", "foo-beta"}, account.GetAnthropicBetaBlacklist())
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountCacheControlBlocks_IncludesTools(t *testing.T) {
+	body := []byte(`{
+		"system": [{"type":"text","text":"sys","cache_control":{"type":"ephemeral"}}],
+		"tools": [
+			{"name":"a","cache_control":{"type":"ephemeral"}},
+			{"name":"b","cache_control":{"type":"ephemeral"}}
+		],
+		"messages": [{"role":"user","content":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}}]}]
+	}`)
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(body, &data))
+	require.Equal(t, 4, countCacheControlBlocks(data))
+}
+
+func TestEnforceCacheControlLimit_TrimsToolCacheControlWhenOverLimit(t *testing.T) {
+	body := []byte(`{
+		"system": [{"type":"text","text":"sys","cache_control":{"type":"ephemeral"}}],
+		"tools": [
+			{"name":"a","cache_control":{"type":"ephemeral"}},
+			{"name":"b","cache_control":{"type":"ephemeral"}},
+			{"name":"c","cache_control":{"type":"ephemeral"}},
+			{"name":"d","cache_control":{"type":"ephemeral"}}
+		],
+		"messages": [{"role":"user","content":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}}]}]
+	}`)
+
+	result := enforceCacheControlLimit(body)
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(result, &data))
+	require.Equal(t, maxCacheControlBlocks, countCacheControlBlocks(data))
+
+	// messages 的 cache_control 优先级最低，应该先被移除
+	messages := data["messages"].([]any)
+	content := messages[0].(map[string]any)["content"].([]any)
+	_, hasMessageCacheControl := content[0].(map[string]any)["cache_control"]
+	require.False(t, hasMessageCacheControl)
+
+	// system 的 cache_control 受保护，最后才会被移除，此处应仍然存在
+	system := data["system"].([]any)
+	_, hasSystemCacheControl := system[0].(map[string]any)["cache_control"]
+	require.True(t, hasSystemCacheControl)
+
+	// messages 移除后仍超限，应继续从 tools 中移除（本例移除 1 个，剩 3 个）
+	tools := data["tools"].([]any)
+	toolsWithCacheControl := 0
+	for _, item := range tools {
+		if _, has := item.(map[string]any)["cache_control"]; has {
+			toolsWithCacheControl++
+		}
+	}
+	require.Equal(t, 3, toolsWithCacheControl)
+}
+
+func TestRemoveCacheControlFromTools(t *testing.T) {
+	data := map[string]any{
+		"tools": []any{
+			map[string]any{"name": "a", "cache_control": map[string]any{"type": "ephemeral"}},
+			map[string]any{"name": "b", "cache_control": map[string]any{"type": "ephemeral"}},
+		},
+	}
+
+	require.True(t, removeCacheControlFromTools(data))
+	tools := data["tools"].([]any)
+	_, hasSecond := tools[1].(map[string]any)["cache_control"]
+	require.False(t, hasSecond, "should remove from the tail first")
+
+	require.True(t, removeCacheControlFromTools(data))
+	require.False(t, removeCacheControlFromTools(data), "no more cache_control left to remove")
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_IsDebugLoggingEnabled(t *testing.T) {
+	account := &Account{}
+	require.False(t, account.IsDebugLoggingEnabled())
+
+	account.Credentials = map[string]any{"debug_logging_enabled": true}
+	require.True(t, account.IsDebugLoggingEnabled())
+
+	account.Credentials = map[string]any{"debug_logging_enabled": false}
+	require.False(t, account.IsDebugLoggingEnabled())
+}
+
+func TestShouldLogClaudeMimicDebug_FlaggedAccountLogsEvenWithEnvDisabled(t *testing.T) {
+	t.Setenv("SUB2API_DEBUG_CLAUDE_MIMIC", "")
+
+	s := &GatewayService{}
+	flagged := &Account{Credentials: map[string]any{"debug_logging_enabled": true}}
+	unflagged := &Account{}
+
+	require.True(t, s.shouldLogClaudeMimicDebug(flagged))
+	require.False(t, s.shouldLogClaudeMimicDebug(unflagged))
+}
+
+func TestShouldLogClaudeMimicDebug_EnvVarAppliesToAllAccounts(t *testing.T) {
+	t.Setenv("SUB2API_DEBUG_CLAUDE_MIMIC", "true")
+
+	s := &GatewayService{}
+	require.True(t, s.shouldLogClaudeMimicDebug(&Account{}))
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateFailover_MatchesShouldFailoverUpstreamError(t *testing.T) {
+	svc := &GatewayService{}
+	statusCodes := []int{200, 400, 401, 403, 404, 429, 500, 502, 529}
+
+	for _, code := range statusCodes {
+		want := svc.shouldFailoverUpstreamError(code)
+		got := svc.SimulateFailover(code, nil)
+		if want {
+			require.True(t, got.ShouldFailover, "status %d", code)
+			require.Equal(t, "upstream_error", got.FailoverReason, "status %d", code)
+		} else if code != http.StatusBadRequest {
+			require.False(t, got.ShouldFailover, "status %d", code)
+			require.Empty(t, got.FailoverReason, "status %d", code)
+		}
+	}
+}
+
+func TestSimulateFailover_MatchesShouldFailoverOn400(t *testing.T) {
+	svc := &GatewayService{}
+	bodies := [][]byte{
+		[]byte(`{"error":{"message":"thinking is not supported for this model"}}`),
+		[]byte(`{"error":{"message":"some completely unrecognized 400 error"}}`),
+		[]byte(`{}`),
+	}
+
+	for _, body := range bodies {
+		wantFailover, wantFeature := svc.shouldFailoverOn400(body)
+		got := svc.SimulateFailover(http.StatusBadRequest, body)
+		require.Equal(t, wantFailover, got.ShouldFailover, "body %s", body)
+		if wantFailover {
+			require.Equal(t, "400_compat", got.FailoverReason, "body %s", body)
+			require.Equal(t, wantFeature, got.Feature400Incompat, "body %s", body)
+		} else {
+			require.Empty(t, got.FailoverReason, "body %s", body)
+			require.Empty(t, got.Feature400Incompat, "body %s", body)
+		}
+	}
+}
+
+func TestClassifyRateLimitAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		respBody       []byte
+		wantAction     string
+		wantWouldDisab bool
+	}{
+		{"401 unauthorized", 401, nil, "auth_error", true},
+		{"402 payment required", 402, nil, "auth_error", true},
+		{"403 forbidden", 403, nil, "auth_error", true},
+		{"429 rate limited", 429, nil, "rate_limited", false},
+		{"529 overloaded", 529, nil, "overloaded", false},
+		{"500 logged only", 500, nil, "logged_only", false},
+		{"503 logged only", 503, nil, "logged_only", false},
+		{"200 ok", 200, nil, "none", false},
+		{"400 without org disabled", 400, []byte(`{"error":{"message":"bad request"}}`), "none", false},
+		{"400 with org disabled", 400, []byte(`{"error":{"message":"Organization has been disabled."}}`), "auth_error", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, wouldDisable := classifyRateLimitAction(tt.statusCode, tt.respBody)
+			require.Equal(t, tt.wantAction, action)
+			require.Equal(t, tt.wantWouldDisab, wouldDisable)
+		})
+	}
+}
+
+func TestSimulateFailover_IncludesRateLimitAction(t *testing.T) {
+	svc := &GatewayService{}
+	got := svc.SimulateFailover(429, nil)
+	require.Equal(t, "rate_limited", got.RateLimitAction)
+	require.False(t, got.WouldDisableAccount)
+}
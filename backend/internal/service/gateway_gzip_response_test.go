@@ -0,0 +1,122 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipCompress(t *testing.T, plain string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(plain))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDecodeGzipBody_ValidGzip(t *testing.T) {
+	plain := `{"hello":"world"}`
+	decoded, ok := decodeGzipBody(gzipCompress(t, plain))
+	require.True(t, ok)
+	require.Equal(t, plain, string(decoded))
+}
+
+func TestDecodeGzipBody_PlainJSONIsUntouched(t *testing.T) {
+	plain := []byte(`{"hello":"world"}`)
+	decoded, ok := decodeGzipBody(plain)
+	require.False(t, ok)
+	require.Equal(t, plain, decoded)
+}
+
+func TestDecodeGzipBody_TruncatedGzipFallsBackToOriginal(t *testing.T) {
+	truncated := gzipCompress(t, `{"hello":"world"}`)[:4]
+	decoded, ok := decodeGzipBody(truncated)
+	require.False(t, ok)
+	require.Equal(t, truncated, decoded)
+}
+
+// TestHandleNonStreamingResponse_GzipUpstream_DisabledByDefault_PassesRawBytes 验证默认
+// 关闭时维持现有透传行为：gzip 字节与 Content-Encoding 头原样转发，billing 解析会因为
+// body 不是合法 JSON 而失败（与修复前行为一致，证明该开关确实是关闭状态）。
+func TestHandleNonStreamingResponse_GzipUpstream_DisabledByDefault_PassesRawBytes(t *testing.T) {
+	plain := `{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"hi"}],"model":"claude-3-5-sonnet-20241022","stop_reason":"end_turn","usage":{"input_tokens":10,"output_tokens":5}}`
+	gzipped := gzipCompress(t, plain)
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {"gzip"}},
+		Body:       io.NopCloser(bytes.NewReader(gzipped)),
+	}
+
+	svc := &GatewayService{cfg: &config.Config{}}
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	_, err := svc.handleNonStreamingResponse(c.Request.Context(), resp, c, &Account{}, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022")
+	require.Error(t, err, "without the flag, gzip bytes are not decoded and fail JSON parsing, matching pre-fix behavior")
+}
+
+// TestHandleNonStreamingResponse_GzipUpstream_EnabledDecodesAndFixesHeaders 验证开启
+// DecodeUpstreamGzipNonStreaming 后，gzip 编码的非流式响应能被正确解压用于计费解析，
+// 且转发给客户端的响应已去掉 Content-Encoding: gzip（字节已是明文，避免客户端重复解压）。
+func TestHandleNonStreamingResponse_GzipUpstream_EnabledDecodesAndFixesHeaders(t *testing.T) {
+	plain := `{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"hi"}],"model":"claude-3-5-sonnet-20241022","stop_reason":"end_turn","usage":{"input_tokens":10,"output_tokens":5}}`
+	gzipped := gzipCompress(t, plain)
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {"gzip"}},
+		Body:       io.NopCloser(bytes.NewReader(gzipped)),
+	}
+
+	cfg := &config.Config{}
+	cfg.Gateway.DecodeUpstreamGzipNonStreaming = true
+	svc := &GatewayService{cfg: cfg}
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	usage, err := svc.handleNonStreamingResponse(c.Request.Context(), resp, c, &Account{}, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022")
+	require.NoError(t, err)
+	require.Equal(t, 5, usage.OutputTokens)
+	require.Empty(t, writer.Header().Get("Content-Encoding"), "decoded plain bytes must not be labeled as gzip")
+
+	var gotBody map[string]any
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &gotBody))
+	require.Equal(t, "hi", gotBody["content"].([]any)[0].(map[string]any)["text"])
+}
+
+// TestHandleNonStreamingResponse_NonGzipUpstream_EnabledFlagIsNoOp 验证开启该开关不会
+// 影响非 gzip 的普通响应。
+func TestHandleNonStreamingResponse_NonGzipUpstream_EnabledFlagIsNoOp(t *testing.T) {
+	plain := `{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"hi"}],"model":"claude-3-5-sonnet-20241022","stop_reason":"end_turn","usage":{"input_tokens":10,"output_tokens":5}}`
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(plain))),
+	}
+
+	cfg := &config.Config{}
+	cfg.Gateway.DecodeUpstreamGzipNonStreaming = true
+	svc := &GatewayService{cfg: cfg}
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	usage, err := svc.handleNonStreamingResponse(c.Request.Context(), resp, c, &Account{}, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022")
+	require.NoError(t, err)
+	require.Equal(t, 5, usage.OutputTokens)
+	require.JSONEq(t, plain, writer.Body.String())
+}
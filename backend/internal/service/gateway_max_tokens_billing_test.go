@@ -0,0 +1,81 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleNonStreamingResponse_TruncatedResponse_UsesActualOutputTokens 验证
+// max_tokens 截断的响应（stop_reason=max_tokens）按上游实际返回的 usage.output_tokens
+// 计费，而不是按请求中声明的 max_tokens。
+func TestHandleNonStreamingResponse_TruncatedResponse_UsesActualOutputTokens(t *testing.T) {
+	body := `{
+		"id": "msg_1",
+		"type": "message",
+		"role": "assistant",
+		"content": [{"type": "text", "text": "partial output before truncation"}],
+		"model": "claude-3-5-sonnet-20241022",
+		"stop_reason": "max_tokens",
+		"usage": {"input_tokens": 50, "output_tokens": 17}
+	}`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	svc := &GatewayService{cfg: &config.Config{}}
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	usage, err := svc.handleNonStreamingResponse(c.Request.Context(), resp, c, &Account{}, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022")
+	require.NoError(t, err)
+	require.Equal(t, 17, usage.OutputTokens, "billing must use the actual usage.output_tokens, not any requested max_tokens")
+}
+
+// TestHandleNonStreamingResponse_MissingUsage_EstimatesConservatively 验证上游完全
+// 不返回 usage.output_tokens 时，按已生成内容的文本长度保守估算，避免按 0 漏计费。
+func TestHandleNonStreamingResponse_MissingUsage_EstimatesConservatively(t *testing.T) {
+	body := `{
+		"id": "msg_2",
+		"type": "message",
+		"role": "assistant",
+		"content": [{"type": "text", "text": "this response was truncated by max_tokens and the upstream never reported usage at all"}],
+		"model": "claude-3-5-sonnet-20241022",
+		"stop_reason": "max_tokens"
+	}`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	svc := &GatewayService{cfg: &config.Config{}}
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	usage, err := svc.handleNonStreamingResponse(c.Request.Context(), resp, c, &Account{}, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022")
+	require.NoError(t, err)
+	require.Greater(t, usage.OutputTokens, 0, "missing usage must fall back to a conservative non-zero estimate")
+}
+
+// TestEstimateOutputTokensFromContentBody_EmptyContent 空 content 或无文本块时估算为 0
+func TestEstimateOutputTokensFromContentBody_EmptyContent(t *testing.T) {
+	require.Equal(t, 0, estimateOutputTokensFromContentBody([]byte(`{"content": []}`)))
+	require.Equal(t, 0, estimateOutputTokensFromContentBody([]byte(`{"content": [{"type": "tool_use"}]}`)))
+}
+
+// TestEstimateOutputTokensFromContentBody_TextAndThinking 对 text 与 thinking 块都应计入估算
+func TestEstimateOutputTokensFromContentBody_TextAndThinking(t *testing.T) {
+	body := `{"content": [{"type": "thinking", "thinking": "reasoning about it"}, {"type": "text", "text": "final answer"}]}`
+	require.Greater(t, estimateOutputTokensFromContentBody([]byte(body)), 0)
+}
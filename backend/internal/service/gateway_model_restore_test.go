@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreOriginalModelInErrorBody_RewritesMappedModelName(t *testing.T) {
+	s := &GatewayService{}
+	body := []byte(`{"type":"error","error":{"type":"invalid_request_error","message":"model: claude-3-5-sonnet-20241022 is not supported"}}`)
+
+	got := s.restoreOriginalModelInErrorBody(body, "claude-3-5-sonnet-20241022", "claude-3-5-sonnet-latest")
+
+	require.Contains(t, string(got), "claude-3-5-sonnet-latest")
+	require.NotContains(t, string(got), "claude-3-5-sonnet-20241022")
+}
+
+func TestRestoreOriginalModelInErrorBody_NoMappingLeavesBodyUntouched(t *testing.T) {
+	s := &GatewayService{}
+	body := []byte(`{"error":{"message":"model claude-3-5-sonnet-latest is overloaded"}}`)
+
+	got := s.restoreOriginalModelInErrorBody(body, "claude-3-5-sonnet-latest", "claude-3-5-sonnet-latest")
+
+	require.Equal(t, body, got)
+}
+
+func TestRestoreOriginalModelInErrorBody_EmptyModelsLeaveBodyUntouched(t *testing.T) {
+	s := &GatewayService{}
+	body := []byte(`{"error":{"message":"boom"}}`)
+
+	require.Equal(t, body, s.restoreOriginalModelInErrorBody(body, "", "claude-3-5-sonnet-latest"))
+	require.Equal(t, body, s.restoreOriginalModelInErrorBody(body, "claude-3-5-sonnet-latest", ""))
+}
@@ -166,7 +166,7 @@ func (m *mockAccountRepoForPlatform) ClearAntigravityQuotaScopes(ctx context.Con
 func (m *mockAccountRepoForPlatform) ClearModelRateLimits(ctx context.Context, id int64) error {
 	return nil
 }
-func (m *mockAccountRepoForPlatform) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string) error {
+func (m *mockAccountRepoForPlatform) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string, utilization *int) error {
 	return nil
 }
 func (m *mockAccountRepoForPlatform) UpdateExtra(ctx context.Context, id int64, updates map[string]any) error {
@@ -216,6 +216,18 @@ func (m *mockGatewayCacheForPlatform) DeleteSessionAccountID(ctx context.Context
 	return nil
 }
 
+func (m *mockGatewayCacheForPlatform) IndexSessionForAPIKey(ctx context.Context, apiKeyID, groupID int64, sessionHash string, ttl time.Duration) error {
+	return nil
+}
+
+func (m *mockGatewayCacheForPlatform) ListSessionsByAPIKey(ctx context.Context, apiKeyID int64) ([]StickySession, error) {
+	return nil, nil
+}
+
+func (m *mockGatewayCacheForPlatform) DeleteSessionsByAPIKey(ctx context.Context, apiKeyID int64) error {
+	return nil
+}
+
 type mockGroupRepoForGateway struct {
 	groups           map[int64]*Group
 	getByIDCalls     int
@@ -453,6 +465,71 @@ func TestGatewayService_SelectAccountForModelWithPlatform_AllExcluded(t *testing
 	require.Nil(t, acc)
 }
 
+// TestGatewayService_SelectAccountForModelWithPlatform_AffinityGroupFailover 测试故障转移时优先选择同一亲和分组的账户，
+// 即使分组外的账户优先级更高
+func TestGatewayService_SelectAccountForModelWithPlatform_AffinityGroupFailover(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, AffinityGroup: "region-a", Status: StatusActive, Schedulable: true},
+			{ID: 2, Platform: PlatformAnthropic, Priority: 1, AffinityGroup: "region-a", Status: StatusActive, Schedulable: true},
+			{ID: 3, Platform: PlatformAnthropic, Priority: 1, AffinityGroup: "region-b", Status: StatusActive, Schedulable: true},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+
+	cache := &mockGatewayCacheForPlatform{}
+
+	svc := &GatewayService{
+		accountRepo: repo,
+		cache:       cache,
+		cfg:         testConfig(),
+	}
+
+	// 账户 1 已失败（被排除），应优先在同一亲和分组 "region-a" 内的账户 2 中重试，
+	// 而不是分组外优先级相同的账户 3
+	excludedIDs := map[int64]struct{}{1: {}}
+	acc, err := svc.selectAccountForModelWithPlatform(ctx, nil, "", "claude-3-5-sonnet-20241022", excludedIDs, PlatformAnthropic)
+	require.NoError(t, err)
+	require.NotNil(t, acc)
+	require.Equal(t, int64(2), acc.ID, "故障转移时应优先选择同一亲和分组内的账户")
+}
+
+// TestGatewayService_SelectAccountForModelWithPlatform_AffinityGroupFallsBackWhenEmpty 测试亲和分组内无其他可用账户时退化为正常选择
+func TestGatewayService_SelectAccountForModelWithPlatform_AffinityGroupFallsBackWhenEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, AffinityGroup: "region-a", Status: StatusActive, Schedulable: true},
+			{ID: 2, Platform: PlatformAnthropic, Priority: 1, AffinityGroup: "region-b", Status: StatusActive, Schedulable: true},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+
+	cache := &mockGatewayCacheForPlatform{}
+
+	svc := &GatewayService{
+		accountRepo: repo,
+		cache:       cache,
+		cfg:         testConfig(),
+	}
+
+	// 账户 1 (region-a) 已失败，region-a 内没有其他账户，应退化为选择 region-b 的账户 2
+	excludedIDs := map[int64]struct{}{1: {}}
+	acc, err := svc.selectAccountForModelWithPlatform(ctx, nil, "", "claude-3-5-sonnet-20241022", excludedIDs, PlatformAnthropic)
+	require.NoError(t, err)
+	require.NotNil(t, acc)
+	require.Equal(t, int64(2), acc.ID, "亲和分组内无可用账户时应退化为其他账户")
+}
+
 // TestGatewayService_SelectAccountForModelWithPlatform_Schedulability 测试账户可调度性检查
 func TestGatewayService_SelectAccountForModelWithPlatform_Schedulability(t *testing.T) {
 	ctx := context.Background()
@@ -1699,6 +1776,136 @@ func TestGatewayService_selectAccountWithMixedScheduling(t *testing.T) {
 	})
 }
 
+// TestGatewayService_selectAccountWithMixedScheduling_PlatformPreference 测试 mixed_scheduling_preference
+// 配置在同优先级、均未使用过的原生账户与 antigravity 账户之间的平台偏好生效情况
+func TestGatewayService_selectAccountWithMixedScheduling_PlatformPreference(t *testing.T) {
+	ctx := context.Background()
+
+	newSvc := func(accounts []Account, preference string) *GatewayService {
+		repo := &mockAccountRepoForPlatform{
+			accounts:     accounts,
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+		cfg := testConfig()
+		cfg.Gateway.Scheduling.MixedSchedulingPreference = preference
+		return &GatewayService{
+			accountRepo: repo,
+			cache:       &mockGatewayCacheForPlatform{},
+			cfg:         cfg,
+		}
+	}
+
+	t.Run("none-保留先遍历到的账户", func(t *testing.T) {
+		svc := newSvc([]Account{
+			{ID: 1, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+			{ID: 2, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true},
+		}, config.MixedSchedulingPreferenceNone)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, nil, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(1), acc.ID, "无偏好时不应改变先遍历到的账户")
+	})
+
+	t.Run("native_first-优先原生平台账户", func(t *testing.T) {
+		svc := newSvc([]Account{
+			{ID: 1, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+			{ID: 2, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true},
+		}, config.MixedSchedulingPreferenceNativeFirst)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, nil, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(2), acc.ID, "native_first 偏好应改为选择原生平台账户")
+	})
+
+	t.Run("antigravity_first-优先antigravity账户", func(t *testing.T) {
+		svc := newSvc([]Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true},
+			{ID: 2, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+		}, config.MixedSchedulingPreferenceAntigravityFirst)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, nil, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(2), acc.ID, "antigravity_first 偏好应改为选择 antigravity 账户")
+	})
+}
+
+// TestGatewayService_selectAccountWithMixedScheduling_NativeSaturationOnly 测试分组开启
+// mixed_scheduling_native_saturation_only 后，antigravity 账户仅在原生平台账户全部不可调度
+// 时才会被纳入候选（严格 fallback），而不是与原生账户一起参与优先级排序。
+func TestGatewayService_selectAccountWithMixedScheduling_NativeSaturationOnly(t *testing.T) {
+	groupID := int64(50)
+
+	newSvc := func(accounts []Account, nativeSaturationOnly bool) (*GatewayService, context.Context) {
+		repo := &mockAccountRepoForPlatform{
+			accounts:     accounts,
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+		group := &Group{
+			ID:                                  groupID,
+			Platform:                            PlatformAnthropic,
+			Status:                              StatusActive,
+			Hydrated:                            true,
+			MixedSchedulingNativeSaturationOnly: nativeSaturationOnly,
+		}
+		groupRepo := &mockGroupRepoForGateway{
+			groups: map[int64]*Group{groupID: group},
+		}
+		svc := &GatewayService{
+			accountRepo: repo,
+			groupRepo:   groupRepo,
+			cache:       &mockGatewayCacheForPlatform{},
+			cfg:         testConfig(),
+		}
+		ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+		return svc, ctx
+	}
+
+	t.Run("未开启时antigravity与原生账户一起参与优先级排序", func(t *testing.T) {
+		svc, ctx := newSvc([]Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 2, Status: StatusActive, Schedulable: true},
+			{ID: 2, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+		}, false)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, &groupID, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(2), acc.ID, "未开启严格fallback时应按优先级选择antigravity账户")
+	})
+
+	t.Run("开启后原生账户可用时不应选择antigravity账户", func(t *testing.T) {
+		svc, ctx := newSvc([]Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 2, Status: StatusActive, Schedulable: true},
+			{ID: 2, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+		}, true)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, &groupID, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(1), acc.ID, "开启严格fallback时，原生账户未饱和前不应选择antigravity账户")
+	})
+
+	t.Run("开启后原生账户全部不可调度才回退到antigravity账户", func(t *testing.T) {
+		svc, ctx := newSvc([]Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: false},
+			{ID: 2, Platform: PlatformAntigravity, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"mixed_scheduling": true}},
+		}, true)
+
+		acc, err := svc.selectAccountWithMixedScheduling(ctx, &groupID, "", "claude-sonnet-4-5", nil, PlatformAnthropic)
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(2), acc.ID, "原生账户全部不可调度后应回退到antigravity账户")
+	})
+}
+
 // TestAccount_IsMixedSchedulingEnabled 测试混合调度开关检查
 func TestAccount_IsMixedSchedulingEnabled(t *testing.T) {
 	tests := []struct {
@@ -1788,6 +1995,9 @@ type mockConcurrencyCache struct {
 	loadMap             map[int64]*AccountLoadInfo
 	waitCounts          map[int64]int
 	skipDefaultLoad     bool
+	borrowResults       map[int64]bool // keyed by lenderAccountID
+	borrowCalls         []int64        // lenderAccountID of each AcquireBorrowedAccountSlot call, in order
+	borrowReleased      []int64        // lenderAccountID of each ReleaseBorrowedAccountSlot call, in order
 }
 
 func (m *mockConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
@@ -1808,6 +2018,35 @@ func (m *mockConcurrencyCache) GetAccountConcurrency(ctx context.Context, accoun
 	return 0, nil
 }
 
+func (m *mockConcurrencyCache) AcquireBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, lenderMaxConcurrency int, borrowerAccountID int64, borrowCap int, requestID string) (bool, error) {
+	m.borrowCalls = append(m.borrowCalls, lenderAccountID)
+	if m.borrowResults != nil {
+		if result, ok := m.borrowResults[lenderAccountID]; ok {
+			return result, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *mockConcurrencyCache) ReleaseBorrowedAccountSlot(ctx context.Context, lenderAccountID int64, borrowerAccountID int64, requestID string) error {
+	m.borrowReleased = append(m.borrowReleased, lenderAccountID)
+	return nil
+}
+
+func (m *mockConcurrencyCache) AcquireReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, maxConcurrency int, reservedSlots int, effectiveMaxConcurrency int, requestID string) (bool, error) {
+	m.acquireAccountCalls++
+	if m.acquireResults != nil {
+		if result, ok := m.acquireResults[accountID]; ok {
+			return result, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *mockConcurrencyCache) ReleaseReservedAccountSlot(ctx context.Context, accountID int64, groupID int64, requestID string) error {
+	return nil
+}
+
 func (m *mockConcurrencyCache) IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error) {
 	return true, nil
 }
@@ -1845,6 +2084,14 @@ func (m *mockConcurrencyCache) DecrementWaitCount(ctx context.Context, userID in
 	return nil
 }
 
+func (m *mockConcurrencyCache) AcquireUserStreamSlot(ctx context.Context, userID int64, maxConcurrentStreams int, requestID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockConcurrencyCache) ReleaseUserStreamSlot(ctx context.Context, userID int64, requestID string) error {
+	return nil
+}
+
 func (m *mockConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error) {
 	m.loadBatchCalls++
 	if m.loadBatchErr != nil {
@@ -1876,8 +2123,8 @@ func (m *mockConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, account
 	return result, nil
 }
 
-func (m *mockConcurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error {
-	return nil
+func (m *mockConcurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) (int, error) {
+	return 0, nil
 }
 
 func (m *mockConcurrencyCache) GetUsersLoadBatch(ctx context.Context, users []UserWithConcurrency) (map[int64]*UserLoadInfo, error) {
@@ -2183,6 +2430,114 @@ func TestGatewayService_SelectAccountWithLoadAwareness(t *testing.T) {
 		require.Contains(t, err.Error(), "no available accounts")
 	})
 
+	t.Run("候选账户为空-等待后恢复可被选中", func(t *testing.T) {
+		now := time.Now()
+		overloadUntil := now.Add(30 * time.Millisecond)
+
+		repo := &mockAccountRepoForPlatform{
+			accounts: []Account{
+				{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 5, OverloadUntil: &overloadUntil},
+			},
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+
+		cache := &mockGatewayCacheForPlatform{}
+		cfg := testConfig()
+		cfg.Gateway.Scheduling.LoadBatchEnabled = true
+		cfg.Gateway.Scheduling.EmptyCandidatesRetryTimeout = 200 * time.Millisecond
+		cfg.Gateway.Scheduling.EmptyCandidatesRetryInterval = 20 * time.Millisecond
+
+		concurrencyCache := &mockConcurrencyCache{}
+
+		svc := &GatewayService{
+			accountRepo:        repo,
+			cache:              cache,
+			cfg:                cfg,
+			concurrencyService: NewConcurrencyService(concurrencyCache),
+		}
+
+		result, err := svc.SelectAccountWithLoadAwareness(ctx, nil, "", "claude-3-5-sonnet-20241022", nil, "")
+		require.NoError(t, err, "过载窗口应在等待期内结束，账号重新可选")
+		require.NotNil(t, result)
+		require.NotNil(t, result.Account)
+		require.Equal(t, int64(1), result.Account.ID)
+	})
+
+	t.Run("候选账户为空-未配置重试时立即返回错误", func(t *testing.T) {
+		now := time.Now()
+		overloadUntil := now.Add(10 * time.Minute)
+
+		repo := &mockAccountRepoForPlatform{
+			accounts: []Account{
+				{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 5, OverloadUntil: &overloadUntil},
+			},
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+
+		cache := &mockGatewayCacheForPlatform{}
+		cfg := testConfig()
+		cfg.Gateway.Scheduling.LoadBatchEnabled = true
+
+		concurrencyCache := &mockConcurrencyCache{}
+
+		svc := &GatewayService{
+			accountRepo:        repo,
+			cache:              cache,
+			cfg:                cfg,
+			concurrencyService: NewConcurrencyService(concurrencyCache),
+		}
+
+		result, err := svc.SelectAccountWithLoadAwareness(ctx, nil, "", "claude-3-5-sonnet-20241022", nil, "")
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "no available accounts")
+	})
+
+	t.Run("beta不兼容标记-跳过beta请求但不影响普通请求", func(t *testing.T) {
+		repo := &mockAccountRepoForPlatform{
+			accounts: []Account{
+				{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 5},
+				{ID: 2, Platform: PlatformAnthropic, Priority: 2, Status: StatusActive, Schedulable: true, Concurrency: 5},
+			},
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+
+		cache := &mockGatewayCacheForPlatform{}
+		cfg := testConfig()
+		cfg.Gateway.Scheduling.LoadBatchEnabled = true
+
+		concurrencyCache := &mockConcurrencyCache{}
+
+		svc := &GatewayService{
+			accountRepo:        repo,
+			cache:              cache,
+			cfg:                cfg,
+			concurrencyService: NewConcurrencyService(concurrencyCache),
+			featureIncompat:    newFeatureIncompatCache(),
+		}
+		svc.featureIncompat.mark(1, "beta", time.Minute)
+
+		betaCtx := context.WithValue(ctx, ctxkey.AnthropicBetaHeader, "some-beta-flag")
+		result, err := svc.SelectAccountWithLoadAwareness(betaCtx, nil, "", "claude-3-5-sonnet-20241022", nil, "")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, int64(2), result.Account.ID, "标记为 beta 不兼容的账号应被跳过")
+
+		result, err = svc.SelectAccountWithLoadAwareness(ctx, nil, "", "claude-3-5-sonnet-20241022", nil, "")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, int64(1), result.Account.ID, "非 beta 请求不受标记影响，应按优先级选择")
+	})
+
 	t.Run("过滤不可调度账号-限流账号被跳过", func(t *testing.T) {
 		now := time.Now()
 		resetAt := now.Add(10 * time.Minute)
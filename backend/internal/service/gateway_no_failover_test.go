@@ -0,0 +1,31 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoFailoverHeaderEnabled(t *testing.T) {
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "")
+	require.False(t, NoFailoverHeaderEnabled())
+
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "true")
+	require.True(t, NoFailoverHeaderEnabled())
+
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "  ON ")
+	require.True(t, NoFailoverHeaderEnabled())
+
+	t.Setenv("SUB2API_ENABLE_NO_FAILOVER_HEADER", "0")
+	require.False(t, NoFailoverHeaderEnabled())
+}
+
+func TestWithNoFailover_RoundTrip(t *testing.T) {
+	require.False(t, NoFailoverFromContext(context.Background()))
+
+	ctx := WithNoFailover(context.Background())
+	require.True(t, NoFailoverFromContext(ctx))
+}
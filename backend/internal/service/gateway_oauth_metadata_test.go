@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,3 +62,19 @@ func TestBuildOAuthMetadataUserID_UsesAccountUUIDWhenPresent(t *testing.T) {
 	re := regexp.MustCompile(`^user_clientid123_account_acc-uuid_session_[a-f0-9-]{36}$`)
 	require.True(t, re.MatchString(got), "unexpected user_id format: %s", got)
 }
+
+func TestMetadataRewriteDisabled(t *testing.T) {
+	t.Run("no group in context", func(t *testing.T) {
+		require.False(t, metadataRewriteDisabled(context.Background()))
+	})
+
+	t.Run("group with rewrite enabled", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{ID: 1, DisableMetadataRewrite: false})
+		require.False(t, metadataRewriteDisabled(ctx))
+	})
+
+	t.Run("group with rewrite disabled", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{ID: 1, DisableMetadataRewrite: true})
+		require.True(t, metadataRewriteDisabled(ctx))
+	})
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/tidwall/gjson"
+)
+
+// ReplayAccountResult 单个账号的回放结果
+type ReplayAccountResult struct {
+	AccountID   int64  `json:"account_id"`
+	AccountName string `json:"account_name"`
+	StatusCode  int    `json:"status_code"`
+	Body        string `json:"body"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ReplayResult 两个账号回放结果的并排对比
+type ReplayResult struct {
+	A ReplayAccountResult `json:"a"`
+	B ReplayAccountResult `json:"b"`
+}
+
+// ReplayToAccounts 将同一份请求体直接转发给两个指定账号，用于人工对比上游响应质量。
+// 仅支持非流式请求，不计入用户账单，也不参与正常的账号选择/故障转移流程。
+func (s *GatewayService) ReplayToAccounts(ctx context.Context, body []byte, accountIDA, accountIDB int64) (*ReplayResult, error) {
+	resultA := s.replayToAccount(ctx, body, accountIDA)
+	resultB := s.replayToAccount(ctx, body, accountIDB)
+	return &ReplayResult{A: resultA, B: resultB}, nil
+}
+
+func (s *GatewayService) replayToAccount(ctx context.Context, body []byte, accountID int64) ReplayAccountResult {
+	result := ReplayAccountResult{AccountID: accountID}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		result.Error = fmt.Sprintf("load account: %v", err)
+		return result
+	}
+	result.AccountName = account.Name
+
+	token, tokenType, err := s.GetAccessToken(ctx, account)
+	if err != nil {
+		result.Error = fmt.Sprintf("get access token: %v", err)
+		return result
+	}
+
+	modelID := gjson.GetBytes(body, "model").String()
+	upstreamReq, err := s.buildUpstreamRequest(ctx, nil, account, body, token, tokenType, modelID, false, false)
+	if err != nil {
+		result.Error = fmt.Sprintf("build upstream request: %v", err)
+		return result
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	resp, err := s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, account.IsTLSFingerprintEnabled())
+	if err != nil {
+		result.Error = fmt.Sprintf("upstream request failed: %v", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = fmt.Sprintf("read upstream response: %v", err)
+		return result
+	}
+
+	result.StatusCode = resp.StatusCode
+	result.Body = string(respBody)
+	return result
+}
@@ -0,0 +1,90 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// replayUpstreamStub returns a distinct canned response per accountID.
+type replayUpstreamStub struct {
+	responses map[int64]string
+}
+
+func (u *replayUpstreamStub) Do(req *http.Request, proxyURL string, accountID int64, accountConcurrency int) (*http.Response, error) {
+	body := u.responses[accountID]
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func (u *replayUpstreamStub) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, enableTLSFingerprint bool) (*http.Response, error) {
+	return u.Do(req, proxyURL, accountID, accountConcurrency)
+}
+
+func TestReplayToAccounts_ReturnsBothUpstreamResponsesSideBySide(t *testing.T) {
+	accountA := &Account{ID: 1, Name: "account-a", Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "key-a"}}
+	accountB := &Account{ID: 2, Name: "account-b", Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "key-b"}}
+
+	repo := &mockAccountRepoForGemini{
+		accountsByID: map[int64]*Account{
+			1: accountA,
+			2: accountB,
+		},
+	}
+	upstream := &replayUpstreamStub{
+		responses: map[int64]string{
+			1: `{"content":[{"type":"text","text":"response from A"}]}`,
+			2: `{"content":[{"type":"text","text":"response from B"}]}`,
+		},
+	}
+
+	svc := &GatewayService{accountRepo: repo, httpUpstream: upstream, cfg: &config.Config{}}
+
+	result, err := svc.ReplayToAccounts(context.Background(), []byte(`{"model":"claude-sonnet-4-5","messages":[]}`), 1, 2)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), result.A.AccountID)
+	require.Equal(t, "account-a", result.A.AccountName)
+	require.Equal(t, http.StatusOK, result.A.StatusCode)
+	require.Contains(t, result.A.Body, "response from A")
+	require.Empty(t, result.A.Error)
+
+	require.Equal(t, int64(2), result.B.AccountID)
+	require.Equal(t, "account-b", result.B.AccountName)
+	require.Equal(t, http.StatusOK, result.B.StatusCode)
+	require.Contains(t, result.B.Body, "response from B")
+	require.Empty(t, result.B.Error)
+}
+
+func TestReplayToAccounts_UnknownAccountReportsErrorWithoutFailingOtherSide(t *testing.T) {
+	accountA := &Account{ID: 1, Name: "account-a", Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "key-a"}}
+
+	repo := &mockAccountRepoForGemini{
+		accountsByID: map[int64]*Account{
+			1: accountA,
+		},
+	}
+	upstream := &replayUpstreamStub{
+		responses: map[int64]string{
+			1: `{"content":[{"type":"text","text":"response from A"}]}`,
+		},
+	}
+
+	svc := &GatewayService{accountRepo: repo, httpUpstream: upstream, cfg: &config.Config{}}
+
+	result, err := svc.ReplayToAccounts(context.Background(), []byte(`{"model":"claude-sonnet-4-5","messages":[]}`), 1, 999)
+	require.NoError(t, err)
+
+	require.Contains(t, result.A.Body, "response from A")
+	require.NotEmpty(t, result.B.Error)
+}
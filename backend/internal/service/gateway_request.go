@@ -42,6 +42,9 @@ type ParsedRequest struct {
 	ThinkingEnabled bool            // 是否开启 thinking（部分平台会影响最终模型名）
 	MaxTokens       int             // max_tokens 值（用于探测请求拦截）
 	SessionContext  *SessionContext // 可选：请求上下文区分因子（nil 时行为不变）
+	HasTools        bool            // 是否声明了 tools（用于按账号能力矩阵过滤不支持工具调用的账号）
+	HasImageContent bool            // messages 中是否包含图片内容块（用于按账号能力矩阵过滤不支持视觉输入的账号）
+	OriginalModel   string          // 应用用户级模型映射前的原始模型名（为空表示未应用映射，Model 即为原始模型）
 }
 
 // ParseGatewayRequest 解析网关请求体并返回结构化结果。
@@ -115,9 +118,39 @@ func ParseGatewayRequest(body []byte, protocol string) (*ParsedRequest, error) {
 		}
 	}
 
+	if tools, ok := req["tools"].([]any); ok {
+		parsed.HasTools = len(tools) > 0
+	}
+	parsed.HasImageContent = messagesContainImage(parsed.Messages)
+
 	return parsed, nil
 }
 
+// messagesContainImage 检测 messages 数组中是否包含图片内容块
+// （Anthropic "image" / OpenAI "image_url" 内容块类型）。
+func messagesContainImage(messages []any) bool {
+	for _, msg := range messages {
+		msgMap, ok := msg.(map[string]any)
+		if !ok {
+			continue
+		}
+		content, ok := msgMap["content"].([]any)
+		if !ok {
+			continue
+		}
+		for _, part := range content {
+			partMap, ok := part.(map[string]any)
+			if !ok {
+				continue
+			}
+			if partType, _ := partMap["type"].(string); partType == "image" || partType == "image_url" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // parseIntegralNumber 将 JSON 解码后的数字安全转换为 int。
 // 仅接受“整数值”的输入，小数/NaN/Inf/越界值都会返回 false。
 func parseIntegralNumber(raw any) (int, bool) {
@@ -0,0 +1,63 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayService_retryConfig_NilConfigUsesDefaults(t *testing.T) {
+	s := &GatewayService{}
+
+	maxAttempts, baseDelay, maxDelay, maxElapsed := s.retryConfig()
+
+	require.Equal(t, defaultMaxRetryAttempts, maxAttempts)
+	require.Equal(t, defaultRetryBaseDelay, baseDelay)
+	require.Equal(t, defaultRetryMaxDelay, maxDelay)
+	require.Equal(t, defaultMaxRetryElapsed, maxElapsed)
+}
+
+func TestGatewayService_retryConfig_ZeroValueFallsBackToDefaults(t *testing.T) {
+	s := &GatewayService{cfg: &config.Config{}}
+
+	maxAttempts, baseDelay, maxDelay, maxElapsed := s.retryConfig()
+
+	require.Equal(t, defaultMaxRetryAttempts, maxAttempts)
+	require.Equal(t, defaultRetryBaseDelay, baseDelay)
+	require.Equal(t, defaultRetryMaxDelay, maxDelay)
+	require.Equal(t, defaultMaxRetryElapsed, maxElapsed)
+}
+
+func TestGatewayService_retryConfig_UsesConfiguredValues(t *testing.T) {
+	s := &GatewayService{cfg: &config.Config{
+		Gateway: config.GatewayConfig{
+			Retry: config.GatewayRetryConfig{
+				MaxAttempts: 2,
+				BaseDelay:   50 * time.Millisecond,
+				MaxDelay:    200 * time.Millisecond,
+				MaxElapsed:  500 * time.Millisecond,
+			},
+		},
+	}}
+
+	maxAttempts, baseDelay, maxDelay, maxElapsed := s.retryConfig()
+
+	require.Equal(t, 2, maxAttempts)
+	require.Equal(t, 50*time.Millisecond, baseDelay)
+	require.Equal(t, 200*time.Millisecond, maxDelay)
+	require.Equal(t, 500*time.Millisecond, maxElapsed)
+}
+
+func TestRetryBackoffDelay_ExponentialWithCap(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := 300 * time.Millisecond
+
+	require.Equal(t, base, retryBackoffDelay(0, base, max))
+	require.Equal(t, base, retryBackoffDelay(1, base, max))
+	require.Equal(t, 200*time.Millisecond, retryBackoffDelay(2, base, max))
+	require.Equal(t, max, retryBackoffDelay(3, base, max))
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
@@ -11,6 +12,7 @@ import (
 	"io"
 	"log"
 	"log/slog"
+	"math"
 	mathrand "math/rand"
 	"net/http"
 	"os"
@@ -35,10 +37,11 @@ import (
 )
 
 const (
-	claudeAPIURL            = "https://api.anthropic.com/v1/messages?beta=true"
-	claudeAPICountTokensURL = "https://api.anthropic.com/v1/messages/count_tokens?beta=true"
-	stickySessionTTL        = time.Hour // 粘性会话TTL
-	defaultMaxLineSize      = 40 * 1024 * 1024
+	claudeAPIURL                 = "https://api.anthropic.com/v1/messages?beta=true"
+	claudeAPICountTokensURL      = "https://api.anthropic.com/v1/messages/count_tokens?beta=true"
+	stickySessionTTL             = time.Hour // 粘性会话TTL
+	defaultMaxLineSize           = 40 * 1024 * 1024
+	defaultClientWriteRetryDelay = 20 * time.Millisecond
 	// Canonical Claude Code banner. Keep it EXACT (no trailing whitespace/newlines)
 	// to match real Claude CLI traffic as closely as possible. When we need a visual
 	// separator between system blocks, we add "\n\n" at concatenation time.
@@ -83,6 +86,117 @@ func (s *GatewayService) debugClaudeMimicEnabled() bool {
 	return v == "1" || v == "true" || v == "yes" || v == "on"
 }
 
+// shouldLogClaudeMimicDebug 是否打印 [ClaudeMimicDebug] 详情：全局环境变量开启，
+// 或该账号单独开启了 DebugLogging（见 Account.IsDebugLoggingEnabled），两者任一满足即可。
+func (s *GatewayService) shouldLogClaudeMimicDebug(account *Account) bool {
+	return s.debugClaudeMimicEnabled() || (account != nil && account.IsDebugLoggingEnabled())
+}
+
+// SelectionSeedEnabled 判断是否启用 x-sub2api-selection-seed 请求头。
+// 启用后，该头携带的种子会用于 fallback 阶段的随机选号（shuffleWithinPriority），
+// 使同一候选集合在回放/压测场景下产生确定性的选号顺序。
+func SelectionSeedEnabled() bool {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv("SUB2API_ENABLE_SELECTION_SEED")))
+	return v == "1" || v == "true" || v == "yes" || v == "on"
+}
+
+// WithSelectionSeed 将选号随机种子写入 context，供 sortCandidatesForFallback 读取。
+func WithSelectionSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, ctxkey.SelectionSeed, seed)
+}
+
+// selectionSeedFromContext 从 context 中读取选号随机种子
+func selectionSeedFromContext(ctx context.Context) (int64, bool) {
+	seed, ok := ctx.Value(ctxkey.SelectionSeed).(int64)
+	return seed, ok
+}
+
+// NoFailoverHeaderEnabled 判断是否启用 x-sub2api-no-failover 请求头。
+// 启用后，管理员可通过该头禁用当前请求的账号故障转移，在首个账号出错时
+// 直接返回该账号的错误，而不是自动切换到下一个候选账号，便于诊断单个账号的问题。
+func NoFailoverHeaderEnabled() bool {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv("SUB2API_ENABLE_NO_FAILOVER_HEADER")))
+	return v == "1" || v == "true" || v == "yes" || v == "on"
+}
+
+// WithNoFailover 将禁用故障转移的标记写入 context，供 handler 的失败转移循环读取。
+func WithNoFailover(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxkey.NoFailover, true)
+}
+
+// NoFailoverFromContext 从 context 中读取是否禁用故障转移的标记。
+func NoFailoverFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxkey.NoFailover).(bool)
+	return v
+}
+
+// metadataRewriteDisabled 判断当前请求所属分组是否关闭了 OAuth metadata.user_id 重写。
+// Group 由 API Key 认证中间件写入 context，贯穿整个请求生命周期。
+func metadataRewriteDisabled(ctx context.Context) bool {
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	return ok && group != nil && group.DisableMetadataRewrite
+}
+
+// maxOutputTokensFromContext 读取当前请求所属分组配置的流式响应输出 token 硬上限。
+// Group 由 API Key 认证中间件写入 context，贯穿整个请求生命周期；返回 0 表示不限制。
+func maxOutputTokensFromContext(ctx context.Context) int {
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	if !ok || group == nil || group.MaxOutputTokens == nil || *group.MaxOutputTokens <= 0 {
+		return 0
+	}
+	return *group.MaxOutputTokens
+}
+
+// mixedSchedulingNativeSaturationOnly 判断当前请求所属分组是否要求混合调度严格 fallback：
+// 仅当原生平台账户全部饱和/不可用时才使用启用了混合调度的 antigravity 账户。
+// Group 由 API Key 认证中间件写入 context，贯穿整个请求生命周期。
+func mixedSchedulingNativeSaturationOnly(ctx context.Context) bool {
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	return ok && group != nil && group.MixedSchedulingNativeSaturationOnly
+}
+
+// upstreamProtectedHeaders 不允许被分组/账号级上游默认请求头覆盖的头部，
+// 避免自定义配置破坏认证或请求体解析。
+var upstreamProtectedHeaders = map[string]bool{
+	"authorization":  true,
+	"x-api-key":      true,
+	"content-type":   true,
+	"content-length": true,
+	"host":           true,
+}
+
+// groupUpstreamHeadersFromContext 读取当前请求所属分组配置的上游默认请求头。
+// Group 由 API Key 认证中间件写入 context，贯穿整个请求生命周期。
+func groupUpstreamHeadersFromContext(ctx context.Context) map[string]string {
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	if !ok || group == nil {
+		return nil
+	}
+	return group.UpstreamHeaders
+}
+
+// applyUpstreamHeaders 将分组级 / 账号级配置的上游默认请求头应用到上游请求，
+// 账号级配置覆盖同名的分组级配置；认证类及 content-type/content-length/host 头部始终不受影响。
+func applyUpstreamHeaders(req *http.Request, groupHeaders, accountHeaders map[string]string) {
+	apply := func(headers map[string]string) {
+		for key, value := range headers {
+			if upstreamProtectedHeaders[strings.ToLower(key)] {
+				continue
+			}
+			req.Header.Set(key, value)
+		}
+	}
+	apply(groupHeaders)
+	apply(accountHeaders)
+}
+
+// apiKeyIDFromContext 从 context 中读取当前请求所使用的 API Key ID，
+// 由 API Key 认证中间件写入，用于登记粘性会话的按 Key 索引。
+func apiKeyIDFromContext(ctx context.Context) (int64, bool) {
+	apiKeyID, ok := ctx.Value(ctxkey.APIKeyID).(int64)
+	return apiKeyID, ok && apiKeyID > 0
+}
+
 func shortSessionHash(sessionHash string) string {
 	if sessionHash == "" {
 		return ""
@@ -252,6 +366,18 @@ var systemBlockFilterPrefixes = []string{
 // ErrClaudeCodeOnly 表示分组仅允许 Claude Code 客户端访问
 var ErrClaudeCodeOnly = errors.New("this group only allows Claude Code clients")
 
+// ErrAnthropicVersionRequired 表示账号要求客户端必须携带 anthropic-version 请求头，
+// 但客户端请求中缺失该头，因此拒绝请求而非默认填充 2023-06-01
+var ErrAnthropicVersionRequired = errors.New("anthropic-version header is required")
+
+// ErrFeatureUnsupported 表示分组内所有可调度账号都不支持请求所需的能力（如 extended
+// thinking、tool use），账号选择阶段据此提前拒绝，而不是转发到上游后才收到 400。
+var ErrFeatureUnsupported = errors.New("no available accounts support the requested feature")
+
+// ErrGroupWindowCostExceeded 表示分组内所有账号在当前滚动窗口内的聚合标准费用已超出
+// Group.WindowCostLimitUSD 配置的上限，新会话的账号选择据此提前拒绝。
+var ErrGroupWindowCostExceeded = errors.New("group window cost limit exceeded")
+
 // allowedHeaders 白名单headers（参考CRS项目）
 var allowedHeaders = map[string]bool{
 	"accept":                                    true,
@@ -272,6 +398,9 @@ var allowedHeaders = map[string]bool{
 	"sec-fetch-mode":                            true,
 	"user-agent":                                true,
 	"content-type":                              true,
+	"traceparent":                               true,
+	"tracestate":                                true,
+	"b3":                                        true,
 }
 
 // GatewayCache 定义网关服务的缓存操作接口。
@@ -292,6 +421,27 @@ type GatewayCache interface {
 	// DeleteSessionAccountID 删除粘性会话绑定，用于账号不可用时主动清理
 	// Delete sticky session binding, used to proactively clean up when account becomes unavailable
 	DeleteSessionAccountID(ctx context.Context, groupID int64, sessionHash string) error
+
+	// IndexSessionForAPIKey 将粘性会话登记到所属 API Key 的索引中，
+	// 使其可以按 API Key/用户维度被列出和吊销，供客服/管理后台使用。
+	// Index a sticky session under its owning API key so it can later be
+	// listed and revoked per API key / user (support & admin use cases).
+	IndexSessionForAPIKey(ctx context.Context, apiKeyID, groupID int64, sessionHash string, ttl time.Duration) error
+	// ListSessionsByAPIKey 列出某个 API Key 当前登记的粘性会话绑定
+	// List the sticky session bindings currently indexed under an API key
+	ListSessionsByAPIKey(ctx context.Context, apiKeyID int64) ([]StickySession, error)
+	// DeleteSessionsByAPIKey 吊销某个 API Key 名下全部粘性会话绑定
+	// Revoke all sticky session bindings indexed under an API key
+	DeleteSessionsByAPIKey(ctx context.Context, apiKeyID int64) error
+}
+
+// StickySession 表示一个粘性会话与账号的绑定关系，用于管理端查询和吊销。
+// StickySession represents a sticky session-to-account binding, used when
+// listing or revoking sessions from the admin side.
+type StickySession struct {
+	GroupID     int64
+	SessionHash string
+	AccountID   int64
 }
 
 // derefGroupID safely dereferences *int64 to int64, returning 0 if nil
@@ -302,6 +452,18 @@ func derefGroupID(groupID *int64) int64 {
 	return *groupID
 }
 
+// setSessionAccountID 写入粘性会话绑定，并尽力将其登记到按 API Key 的索引中，
+// 以便管理端后续可以按用户查询/吊销会话（登记失败不影响主流程）。
+func (s *GatewayService) setSessionAccountID(ctx context.Context, groupID int64, sessionHash string, accountID int64, ttl time.Duration) error {
+	if err := s.cache.SetSessionAccountID(ctx, groupID, sessionHash, accountID, ttl); err != nil {
+		return err
+	}
+	if apiKeyID, ok := apiKeyIDFromContext(ctx); ok {
+		_ = s.cache.IndexSessionForAPIKey(ctx, apiKeyID, groupID, sessionHash, ttl)
+	}
+	return nil
+}
+
 // shouldClearStickySession 检查账号是否处于不可调度状态，需要清理粘性会话绑定。
 // 当账号状态为错误、禁用、不可调度、处于临时不可调度期间，
 // 或请求的模型处于限流状态时，返回 true。
@@ -334,6 +496,48 @@ type AccountWaitPlan struct {
 	MaxConcurrency int
 	Timeout        time.Duration
 	MaxWaiting     int
+	// EstimatedWait 基于当前排队数与账号并发上限估算的预计等待时长，用于向客户端/日志提示队列拥堵情况。
+	// 仅由 GatewayService 的负载感知选择路径填充，其他平台的选择路径留空（零值）。
+	EstimatedWait time.Duration
+}
+
+// estimateWaitDuration 基于当前排队数（含本次请求）与账号并发槽位数，
+// 按比例估算本次请求需要等待多久才能轮到空闲槽位。
+// 以 timeout（等待计划允许的最长等待时间）作为一个"并发槽位全部轮转一次"的粗略基准，
+// 排队位置相对并发数的比例越大，预估等待时间越长，但不会超过 timeout 本身。
+func estimateWaitDuration(waitingCount, maxConcurrency int, timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	queuePosition := waitingCount + 1
+	estimated := timeout * time.Duration(queuePosition) / time.Duration(maxConcurrency)
+	if estimated > timeout {
+		estimated = timeout
+	}
+	return estimated
+}
+
+// newAccountWaitPlan 构造一个等待计划，并附带基于 waitingCount 估算的预计等待时长。
+func newAccountWaitPlan(accountID int64, maxConcurrency int, timeout time.Duration, maxWaiting int, waitingCount int) *AccountWaitPlan {
+	return &AccountWaitPlan{
+		AccountID:      accountID,
+		MaxConcurrency: maxConcurrency,
+		Timeout:        timeout,
+		MaxWaiting:     maxWaiting,
+		EstimatedWait:  estimateWaitDuration(waitingCount, maxConcurrency, timeout),
+	}
+}
+
+// currentWaitingCount 返回账号当前的等待队列长度；并发服务不可用时返回 0。
+func (s *GatewayService) currentWaitingCount(ctx context.Context, accountID int64) int {
+	if s.concurrencyService == nil {
+		return 0
+	}
+	count, _ := s.concurrencyService.GetAccountWaitingCount(ctx, accountID)
+	return count
 }
 
 type AccountSelectionResult struct {
@@ -415,7 +619,12 @@ type GatewayService struct {
 	deferredService     *DeferredService
 	concurrencyService  *ConcurrencyService
 	claudeTokenProvider *ClaudeTokenProvider
-	sessionLimitCache   SessionLimitCache // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+	sessionLimitCache   SessionLimitCache         // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+	selectionLogCounter atomic.Uint64             // 高频选号日志的采样计数器，见 shouldLogSelection
+	featureIncompat     *featureIncompatCache     // 账号级短暂 feature 不兼容标记，见 classifyFeatureIncompat
+	successRateTracker  *successRateTracker       // 账号级成功率滑动窗口，见 recordAccountOutcomeAndMaybeCooldown
+	fairness            *accountSelectionFairness // 账户选择公平性跟踪器，nil 表示未开启，见 accountSelectionFairness
+	warmth              *accountWarmth            // 账户预热模型跟踪器，见 accountWarmth
 }
 
 // NewGatewayService creates a new GatewayService
@@ -460,26 +669,64 @@ func NewGatewayService(
 		deferredService:     deferredService,
 		claudeTokenProvider: claudeTokenProvider,
 		sessionLimitCache:   sessionLimitCache,
+		featureIncompat:     newFeatureIncompatCache(),
+		successRateTracker:  newSuccessRateTracker(),
+		fairness:            newFairnessTrackerFromConfig(cfg),
+		warmth:              newAccountWarmth(warmModelWindow(cfg)),
+	}
+}
+
+// newFairnessTrackerFromConfig 根据配置创建账户选择公平性跟踪器，未开启该特性时返回 nil。
+func newFairnessTrackerFromConfig(cfg *config.Config) *accountSelectionFairness {
+	if cfg == nil || !cfg.Gateway.Scheduling.FairnessEnabled {
+		return nil
+	}
+	return newAccountSelectionFairness(cfg.Gateway.Scheduling.FairnessWindow, cfg.Gateway.Scheduling.FairnessMaxShare)
+}
+
+// warmModelWindow 返回预热模型“近期”判定窗口，未配置时使用默认值。该跟踪器始终创建
+// （不像 fairness 需要显式开启），因为只有账号通过 warm_models 显式声明后才会生效。
+func warmModelWindow(cfg *config.Config) time.Duration {
+	if cfg != nil && cfg.Gateway.Scheduling.WarmModelWindow > 0 {
+		return cfg.Gateway.Scheduling.WarmModelWindow
 	}
+	return 10 * time.Minute
 }
 
+// SessionHashSource 标识 GenerateSessionHash 命中的派生分支，用于排查粘性会话不生效的原因。
+type SessionHashSource string
+
+const (
+	SessionHashSourceMetadata  SessionHashSource = "metadata"  // metadata.user_id 中的 session_xxx
+	SessionHashSourceCacheable SessionHashSource = "cacheable" // cache_control: ephemeral 内容
+	SessionHashSourceFallback  SessionHashSource = "fallback"  // session 上下文 + system + 全部消息摘要
+	SessionHashSourceNone      SessionHashSource = "none"      // 没有任何可用于计算 hash 的内容
+)
+
 // GenerateSessionHash 从预解析请求计算粘性会话 hash
 func (s *GatewayService) GenerateSessionHash(parsed *ParsedRequest) string {
+	hash, _ := s.GenerateSessionHashWithSource(parsed)
+	return hash
+}
+
+// GenerateSessionHashWithSource 与 GenerateSessionHash 行为一致，额外返回命中的派生分支
+// （SessionHashSource），供调试端点排查粘性会话为何没有命中预期账号。
+func (s *GatewayService) GenerateSessionHashWithSource(parsed *ParsedRequest) (string, SessionHashSource) {
 	if parsed == nil {
-		return ""
+		return "", SessionHashSourceNone
 	}
 
 	// 1. 最高优先级：从 metadata.user_id 提取 session_xxx
 	if parsed.MetadataUserID != "" {
 		if match := sessionIDRegex.FindStringSubmatch(parsed.MetadataUserID); len(match) > 1 {
-			return match[1]
+			return match[1], SessionHashSourceMetadata
 		}
 	}
 
 	// 2. 提取带 cache_control: {type: "ephemeral"} 的内容
 	cacheableContent := s.extractCacheableContent(parsed)
 	if cacheableContent != "" {
-		return s.hashContent(cacheableContent)
+		return s.hashContent(cacheableContent), SessionHashSourceCacheable
 	}
 
 	// 3. 最后 fallback: 使用 session上下文 + system + 所有消息的完整摘要串
@@ -519,10 +766,10 @@ func (s *GatewayService) GenerateSessionHash(parsed *ParsedRequest) string {
 		}
 	}
 	if combined.Len() > 0 {
-		return s.hashContent(combined.String())
+		return s.hashContent(combined.String()), SessionHashSourceFallback
 	}
 
-	return ""
+	return "", SessionHashSourceNone
 }
 
 // BindStickySession sets session -> account binding with standard TTL.
@@ -530,7 +777,7 @@ func (s *GatewayService) BindStickySession(ctx context.Context, groupID *int64,
 	if sessionHash == "" || accountID <= 0 || s.cache == nil {
 		return nil
 	}
-	return s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, accountID, stickySessionTTL)
+	return s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, accountID, stickySessionTTL)
 }
 
 // GetCachedSessionAccountID retrieves the account ID bound to a sticky session.
@@ -667,6 +914,52 @@ func (s *GatewayService) hashContent(content string) string {
 	return strconv.FormatUint(h, 36)
 }
 
+// applyBodyTransforms 对请求体应用账号级声明式的 set/delete JSON 路径转换。
+// 仅支持受限的 sjson.Set/Delete 操作，不执行任意代码；单条规则失败时跳过并记录日志，不中断请求。
+func applyBodyTransforms(body []byte, transforms []BodyTransform) []byte {
+	for _, t := range transforms {
+		switch t.Op {
+		case "set":
+			result, err := sjson.SetBytes(body, t.Path, t.Value)
+			if err != nil {
+				log.Printf("Failed to apply body transform (set %q): %v", t.Path, err)
+				continue
+			}
+			body = result
+		case "delete":
+			result, err := sjson.DeleteBytes(body, t.Path)
+			if err != nil {
+				log.Printf("Failed to apply body transform (delete %q): %v", t.Path, err)
+				continue
+			}
+			body = result
+		}
+	}
+	return body
+}
+
+// BuildCountTokensCacheKey 基于 model 和原始请求体计算 count_tokens 结果缓存 key。
+// model 作为前缀拼接，确保不同模型的请求即便请求体相同也不会互相命中缓存。
+func (s *GatewayService) BuildCountTokensCacheKey(model string, body []byte) string {
+	digest := xxhash.Sum64(body)
+	return model + ":" + strconv.FormatUint(digest, 36)
+}
+
+// shouldLogSelection 判断本次高频选号日志（如 [Forward] Using account）是否应该输出。
+// 按 gateway.selection_log_sample_rate 做 1/N 采样，<=1 表示每次都记录；
+// 错误/failover 日志不经过该采样，始终记录。
+func (s *GatewayService) shouldLogSelection() bool {
+	rate := 1
+	if s.cfg != nil && s.cfg.Gateway.SelectionLogSampleRate > 1 {
+		rate = s.cfg.Gateway.SelectionLogSampleRate
+	}
+	if rate <= 1 {
+		return true
+	}
+	n := s.selectionLogCounter.Add(1)
+	return n%uint64(rate) == 0
+}
+
 // replaceModelInBody 替换请求体中的model字段
 // 使用 json.RawMessage 保留其他字段的原始字节，避免 thinking 块等内容被修改
 func (s *GatewayService) replaceModelInBody(body []byte, newModel string) []byte {
@@ -687,6 +980,22 @@ func (s *GatewayService) replaceModelInBody(body []byte, newModel string) []byte
 	return newBody
 }
 
+// ApplyUserModelMapping 在分组/账号映射之前应用用户级默认模型映射。
+// 命中映射时会改写 parsed.Model 与 parsed.Body，并将原始模型记录到
+// parsed.OriginalModel，供 Forward 计费时还原为用户实际请求的模型。
+func (s *GatewayService) ApplyUserModelMapping(parsed *ParsedRequest, user *User) {
+	if parsed == nil || user == nil {
+		return
+	}
+	mapped := user.GetMappedModel(parsed.Model)
+	if mapped == "" || mapped == parsed.Model {
+		return
+	}
+	parsed.OriginalModel = parsed.Model
+	parsed.Model = mapped
+	parsed.Body = s.replaceModelInBody(parsed.Body, mapped)
+}
+
 type claudeOAuthNormalizeOptions struct {
 	injectMetadata          bool
 	metadataUserID          string
@@ -874,6 +1183,35 @@ func generateSessionUUID(seed string) string {
 		bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:16])
 }
 
+// generateTraceParent 生成一个符合 W3C Trace Context 规范的 traceparent 值
+// （version-trace_id-parent_id-trace_flags），trace_flags 固定为 01（sampled）。
+func generateTraceParent() (traceparent string, traceID string, spanID string) {
+	traceID = strings.ReplaceAll(uuid.NewString(), "-", "")
+	spanID = strings.ReplaceAll(uuid.NewString(), "-", "")[:16]
+	return fmt.Sprintf("00-%s-%s-01", traceID, spanID), traceID, spanID
+}
+
+// generateB3Header 生成单头格式的 B3 追踪头（trace_id-span_id-sampled），
+// 复用 traceparent 的 trace_id/span_id 以便在两种格式间保持可关联。
+func generateB3Header(traceID, spanID string) string {
+	return fmt.Sprintf("%s-%s-1", traceID, spanID)
+}
+
+// ensureUpstreamTraceHeaders 确保转发给上游的请求携带分布式追踪头：客户端已携带
+// traceparent/b3 时原样透传（由白名单 header 拷贝完成，这里不做处理）；客户端未携带时，
+// 若 GenerateTraceHeadersWhenMissing 开启，则生成一对新的 traceparent/b3 供上游使用。
+func (s *GatewayService) ensureUpstreamTraceHeaders(req *http.Request) {
+	if s.cfg == nil || !s.cfg.Gateway.GenerateTraceHeadersWhenMissing {
+		return
+	}
+	if req.Header.Get("traceparent") != "" || req.Header.Get("b3") != "" {
+		return
+	}
+	traceparent, traceID, spanID := generateTraceParent()
+	req.Header.Set("traceparent", traceparent)
+	req.Header.Set("b3", generateB3Header(traceID, spanID))
+}
+
 // SelectAccount 选择账号（粘性会话+优先级）
 func (s *GatewayService) SelectAccount(ctx context.Context, groupID *int64, sessionHash string) (*Account, error) {
 	return s.SelectAccountForModel(ctx, groupID, sessionHash, "")
@@ -899,6 +1237,16 @@ func (s *GatewayService) SelectAccountForModelWithExclusions(ctx context.Context
 		groupID = resolvedGroupID
 		ctx = s.withGroupContext(ctx, group)
 		platform = group.Platform
+
+		var groupSticky bool
+		if sessionHash != "" && s.cache != nil {
+			if accID, err := s.cache.GetSessionAccountID(ctx, derefGroupID(groupID), sessionHash); err == nil && accID > 0 {
+				groupSticky = true
+			}
+		}
+		if !s.isGroupSchedulableForWindowCost(ctx, group, groupSticky) {
+			return nil, ErrGroupWindowCostExceeded
+		}
 	} else {
 		// 无分组时只使用原生 anthropic 平台
 		platform = PlatformAnthropic
@@ -945,6 +1293,10 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 	}
 	ctx = s.withGroupContext(ctx, group)
 
+	if !s.isGroupSchedulableForWindowCost(ctx, group, stickyAccountID > 0) {
+		return nil, ErrGroupWindowCostExceeded
+	}
+
 	if s.debugModelRoutingEnabled() && requestedModel != "" {
 		groupPlatform := ""
 		if group != nil {
@@ -967,7 +1319,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				return nil, err
 			}
 
-			result, err := s.tryAcquireAccountSlot(ctx, account.ID, account.Concurrency)
+			result, err := s.tryAcquireAccountSlot(ctx, account, nil, groupID)
 			if err == nil && result.Acquired {
 				// 获取槽位后检查会话限制（使用 sessionHash 作为会话标识符）
 				if !s.checkAndRegisterSession(ctx, account, sessionHash) {
@@ -992,24 +1344,14 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				waitingCount, _ := s.concurrencyService.GetAccountWaitingCount(ctx, account.ID)
 				if waitingCount < cfg.StickySessionMaxWaiting {
 					return &AccountSelectionResult{
-						Account: account,
-						WaitPlan: &AccountWaitPlan{
-							AccountID:      account.ID,
-							MaxConcurrency: account.Concurrency,
-							Timeout:        cfg.StickySessionWaitTimeout,
-							MaxWaiting:     cfg.StickySessionMaxWaiting,
-						},
+						Account:  account,
+						WaitPlan: newAccountWaitPlan(account.ID, account.Concurrency, cfg.StickySessionWaitTimeout, cfg.StickySessionMaxWaiting, waitingCount),
 					}, nil
 				}
 			}
 			return &AccountSelectionResult{
-				Account: account,
-				WaitPlan: &AccountWaitPlan{
-					AccountID:      account.ID,
-					MaxConcurrency: account.Concurrency,
-					Timeout:        cfg.FallbackWaitTimeout,
-					MaxWaiting:     cfg.FallbackMaxWaiting,
-				},
+				Account:  account,
+				WaitPlan: newAccountWaitPlan(account.ID, account.Concurrency, cfg.FallbackWaitTimeout, cfg.FallbackMaxWaiting, s.currentWaitingCount(ctx, account.ID)),
 			}, nil
 		}
 	}
@@ -1027,9 +1369,8 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 	if err != nil {
 		return nil, err
 	}
-	if len(accounts) == 0 {
-		return nil, errors.New("no available accounts")
-	}
+	// 注意：此处不因 accounts 为空而立即返回错误——下面 Layer 2 的候选账户
+	// 为空时会按 EmptyCandidatesRetryTimeout 配置统一处理等待重试或失败。
 
 	isExcluded := func(accountID int64) bool {
 		if excludedIDs == nil {
@@ -1130,7 +1471,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 							(requestedModel == "" || s.isModelSupportedByAccountWithContext(ctx, stickyAccount, requestedModel)) &&
 							stickyAccount.IsSchedulableForModelWithContext(ctx, requestedModel) &&
 							s.isAccountSchedulableForWindowCost(ctx, stickyAccount, true) { // 粘性会话窗口费用检查
-							result, err := s.tryAcquireAccountSlot(ctx, stickyAccountID, stickyAccount.Concurrency)
+							result, err := s.tryAcquireAccountSlot(ctx, stickyAccount, accounts, groupID)
 							if err == nil && result.Acquired {
 								// 会话数量限制检查
 								if !s.checkAndRegisterSession(ctx, stickyAccount, sessionHash) {
@@ -1155,13 +1496,8 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 									// 会话限制已满，继续到负载感知选择
 								} else {
 									return &AccountSelectionResult{
-										Account: stickyAccount,
-										WaitPlan: &AccountWaitPlan{
-											AccountID:      stickyAccountID,
-											MaxConcurrency: stickyAccount.Concurrency,
-											Timeout:        cfg.StickySessionWaitTimeout,
-											MaxWaiting:     cfg.StickySessionMaxWaiting,
-										},
+										Account:  stickyAccount,
+										WaitPlan: newAccountWaitPlan(stickyAccountID, stickyAccount.Concurrency, cfg.StickySessionWaitTimeout, cfg.StickySessionMaxWaiting, waitingCount),
 									}, nil
 								}
 							}
@@ -1220,7 +1556,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 
 				// 4. 尝试获取槽位
 				for _, item := range routingAvailable {
-					result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
+					result, err := s.tryAcquireAccountSlot(ctx, item.account, accounts, groupID)
 					if err == nil && result.Acquired {
 						// 会话数量限制检查
 						if !s.checkAndRegisterSession(ctx, item.account, sessionHash) {
@@ -1228,7 +1564,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 							continue
 						}
 						if sessionHash != "" && s.cache != nil {
-							_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.account.ID, stickySessionTTL)
+							_ = s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.account.ID, stickySessionTTL)
 						}
 						if s.debugModelRoutingEnabled() {
 							log.Printf("[ModelRoutingDebug] routed select: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.account.ID)
@@ -1251,13 +1587,8 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 						log.Printf("[ModelRoutingDebug] routed wait: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.account.ID)
 					}
 					return &AccountSelectionResult{
-						Account: item.account,
-						WaitPlan: &AccountWaitPlan{
-							AccountID:      item.account.ID,
-							MaxConcurrency: item.account.Concurrency,
-							Timeout:        cfg.StickySessionWaitTimeout,
-							MaxWaiting:     cfg.StickySessionMaxWaiting,
-						},
+						Account:  item.account,
+						WaitPlan: newAccountWaitPlan(item.account.ID, item.account.Concurrency, cfg.StickySessionWaitTimeout, cfg.StickySessionMaxWaiting, item.loadInfo.WaitingCount),
 					}, nil
 				}
 				// 所有路由账号会话限制都已满，继续到 Layer 2 回退
@@ -1284,7 +1615,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 					(requestedModel == "" || s.isModelSupportedByAccountWithContext(ctx, account, requestedModel)) &&
 					account.IsSchedulableForModelWithContext(ctx, requestedModel) &&
 					s.isAccountSchedulableForWindowCost(ctx, account, true) { // 粘性会话窗口费用检查
-					result, err := s.tryAcquireAccountSlot(ctx, accountID, account.Concurrency)
+					result, err := s.tryAcquireAccountSlot(ctx, account, accounts, groupID)
 					if err == nil && result.Acquired {
 						// 会话数量限制检查
 						// Session count limit check
@@ -1308,13 +1639,8 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 							// Session limit full, continue to Layer 2
 						} else {
 							return &AccountSelectionResult{
-								Account: account,
-								WaitPlan: &AccountWaitPlan{
-									AccountID:      accountID,
-									MaxConcurrency: account.Concurrency,
-									Timeout:        cfg.StickySessionWaitTimeout,
-									MaxWaiting:     cfg.StickySessionMaxWaiting,
-								},
+								Account:  account,
+								WaitPlan: newAccountWaitPlan(accountID, account.Concurrency, cfg.StickySessionWaitTimeout, cfg.StickySessionMaxWaiting, waitingCount),
 							}, nil
 						}
 					}
@@ -1324,35 +1650,31 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 	}
 
 	// ============ Layer 2: 负载感知选择 ============
-	candidates := make([]*Account, 0, len(accounts))
-	for i := range accounts {
-		acc := &accounts[i]
-		if isExcluded(acc.ID) {
-			continue
-		}
-		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
-		// re-check schedulability here so recently rate-limited/overloaded accounts
-		// are not selected again before the bucket is rebuilt.
-		if !acc.IsSchedulable() {
-			continue
-		}
-		if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
-			continue
-		}
-		if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
-			continue
-		}
-		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
-			continue
-		}
-		// 窗口费用检查（非粘性会话路径）
-		if !s.isAccountSchedulableForWindowCost(ctx, acc, false) {
-			continue
+	candidates, featureMismatch := s.loadAwareCandidates(ctx, accounts, isExcluded, platform, useMixed, requestedModel)
+
+	// 候选账户为空时短暂等待重试：账户可能在几秒内从临时不可调度状态恢复
+	// （限流解除、过载窗口结束等）。每次重试都重新查询可调度账户列表，
+	// 因为调度快照是周期性重建的，仅复用内存中的旧列表无法感知恢复。
+	if len(candidates) == 0 && cfg.EmptyCandidatesRetryTimeout > 0 {
+		deadline := time.Now().Add(cfg.EmptyCandidatesRetryTimeout)
+		for len(candidates) == 0 && time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(cfg.EmptyCandidatesRetryInterval):
+			}
+			accounts, useMixed, err = s.listSchedulableAccounts(ctx, groupID, platform, hasForcePlatform)
+			if err != nil {
+				return nil, err
+			}
+			candidates, featureMismatch = s.loadAwareCandidates(ctx, accounts, isExcluded, platform, useMixed, requestedModel)
 		}
-		candidates = append(candidates, acc)
 	}
 
 	if len(candidates) == 0 {
+		if featureMismatch {
+			return nil, ErrFeatureUnsupported
+		}
 		return nil, errors.New("no available accounts")
 	}
 
@@ -1366,7 +1688,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 
 	loadMap, err := s.concurrencyService.GetAccountsLoadBatch(ctx, accountLoads)
 	if err != nil {
-		if result, ok := s.tryAcquireByLegacyOrder(ctx, candidates, groupID, sessionHash, preferOAuth); ok {
+		if result, ok := s.tryAcquireByLegacyOrder(ctx, candidates, accounts, groupID, sessionHash, preferOAuth); ok {
 			return result, nil
 		}
 	} else {
@@ -1384,27 +1706,45 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			}
 		}
 
-		// 分层过滤选择：优先级 → 负载率 → LRU
+		// 分层过滤选择：亲和分组（故障转移时）→ 优先级 → 负载率 → 公平性 → LRU
+		affinityGroups := failoverAffinityGroups(accounts, excludedIDs)
+		requestUserID, _ := ctx.Value(ctxkey.RequestUserID).(int64)
 		for len(available) > 0 {
+			// 0. 故障转移时优先选择同一亲和分组内的账号
+			candidates := filterByAffinityGroup(available, affinityGroups)
+			// 0.5 新账号 break-in：窗口内存在新账号时，越过 Priority 优先在其中调度，
+			// 以便尽快为其积累验证流量
+			candidates = filterNewAccountBoostCandidates(candidates, cfg.NewAccountBoostWindow)
 			// 1. 取优先级最小的集合
-			candidates := filterByMinPriority(available)
+			candidates = filterByMinPriority(candidates)
 			// 2. 取负载率最低的集合
 			candidates = filterByMinLoadRate(candidates)
-			// 3. LRU 选择最久未用的账号
-			selected := selectByLRU(candidates, preferOAuth)
+			// 2.5 公平性：当前用户近期份额过高时，让出本层内的最佳候选给其它用户
+			candidates = filterFairCandidates(candidates, requestUserID, s.fairness)
+			// 2.6 预热偏好：存在近期服务过该模型的 warm 账号时，优先在其中做 LRU 选择
+			candidates = filterWarmCandidates(candidates, requestedModel, s.warmth)
+			// 3. 按配置选择最终账号：默认 LRU 选择最久未用的账号，"weighted" 模式下按 Concurrency 加权随机选择
+			var selected *accountWithLoad
+			if cfg.FallbackSelectionMode == "weighted" {
+				selected = selectByWeight(candidates)
+			} else {
+				selected = selectByLRU(candidates, preferOAuth)
+			}
 			if selected == nil {
 				break
 			}
 
-			result, err := s.tryAcquireAccountSlot(ctx, selected.account.ID, selected.account.Concurrency)
+			result, err := s.tryAcquireAccountSlot(ctx, selected.account, accounts, groupID)
 			if err == nil && result.Acquired {
 				// 会话数量限制检查
 				if !s.checkAndRegisterSession(ctx, selected.account, sessionHash) {
 					result.ReleaseFunc() // 释放槽位，继续尝试下一个账号
 				} else {
 					if sessionHash != "" && s.cache != nil {
-						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.account.ID, stickySessionTTL)
+						_ = s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.account.ID, stickySessionTTL)
 					}
+					s.fairness.recordSelection(requestUserID)
+					s.warmth.recordUsage(selected.account.ID, requestedModel)
 					return &AccountSelectionResult{
 						Account:     selected.account,
 						Acquired:    true,
@@ -1426,31 +1766,26 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 	}
 
 	// ============ Layer 3: 兜底排队 ============
-	s.sortCandidatesForFallback(candidates, preferOAuth, cfg.FallbackSelectionMode)
+	s.sortCandidatesForFallback(ctx, candidates, preferOAuth, cfg.FallbackSelectionMode)
 	for _, acc := range candidates {
 		// 会话数量限制检查（等待计划也需要占用会话配额）
 		if !s.checkAndRegisterSession(ctx, acc, sessionHash) {
 			continue // 会话限制已满，尝试下一个账号
 		}
 		return &AccountSelectionResult{
-			Account: acc,
-			WaitPlan: &AccountWaitPlan{
-				AccountID:      acc.ID,
-				MaxConcurrency: acc.Concurrency,
-				Timeout:        cfg.FallbackWaitTimeout,
-				MaxWaiting:     cfg.FallbackMaxWaiting,
-			},
+			Account:  acc,
+			WaitPlan: newAccountWaitPlan(acc.ID, acc.Concurrency, cfg.FallbackWaitTimeout, cfg.FallbackMaxWaiting, s.currentWaitingCount(ctx, acc.ID)),
 		}, nil
 	}
 	return nil, errors.New("no available accounts")
 }
 
-func (s *GatewayService) tryAcquireByLegacyOrder(ctx context.Context, candidates []*Account, groupID *int64, sessionHash string, preferOAuth bool) (*AccountSelectionResult, bool) {
+func (s *GatewayService) tryAcquireByLegacyOrder(ctx context.Context, candidates []*Account, accounts []Account, groupID *int64, sessionHash string, preferOAuth bool) (*AccountSelectionResult, bool) {
 	ordered := append([]*Account(nil), candidates...)
 	sortAccountsByPriorityAndLastUsed(ordered, preferOAuth)
 
 	for _, acc := range ordered {
-		result, err := s.tryAcquireAccountSlot(ctx, acc.ID, acc.Concurrency)
+		result, err := s.tryAcquireAccountSlot(ctx, acc, accounts, groupID)
 		if err == nil && result.Acquired {
 			// 会话数量限制检查
 			if !s.checkAndRegisterSession(ctx, acc, sessionHash) {
@@ -1458,7 +1793,7 @@ func (s *GatewayService) tryAcquireByLegacyOrder(ctx context.Context, candidates
 				continue
 			}
 			if sessionHash != "" && s.cache != nil {
-				_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, acc.ID, stickySessionTTL)
+				_ = s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, acc.ID, stickySessionTTL)
 			}
 			return &AccountSelectionResult{
 				Account:     acc,
@@ -1485,6 +1820,20 @@ func (s *GatewayService) schedulingConfig() config.GatewaySchedulingConfig {
 	}
 }
 
+// SchedulingConfig 返回当前生效的调度配置，供管理端查询接口使用。
+func (s *GatewayService) SchedulingConfig() config.GatewaySchedulingConfig {
+	return s.schedulingConfig()
+}
+
+// RebuildSchedulerSnapshot 立即强制重建调度快照，绕过节流周期，供管理端在批量变更账号
+// 后手动触发，使新增/变更账号立即可被 ListSchedulableAccounts 调度。
+func (s *GatewayService) RebuildSchedulerSnapshot() error {
+	if s.schedulerSnapshot == nil {
+		return ErrSchedulerCacheNotReady
+	}
+	return s.schedulerSnapshot.TriggerFullRebuild()
+}
+
 func (s *GatewayService) withGroupContext(ctx context.Context, group *Group) context.Context {
 	if !IsGroupContextValid(group) {
 		return ctx
@@ -1677,7 +2026,11 @@ func (s *GatewayService) listSchedulableAccounts(ctx context.Context, groupID *i
 	var accounts []Account
 	var err error
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
-		accounts, err = s.accountRepo.ListSchedulableByPlatform(ctx, platform)
+		if simpleGroupID := s.cfg.Gateway.Scheduling.SimpleModeDefaultGroupID; simpleGroupID > 0 {
+			accounts, err = s.accountRepo.ListSchedulableByGroupIDAndPlatform(ctx, simpleGroupID, platform)
+		} else {
+			accounts, err = s.accountRepo.ListSchedulableByPlatform(ctx, platform)
+		}
 	} else if groupID != nil {
 		accounts, err = s.accountRepo.ListSchedulableByGroupIDAndPlatform(ctx, *groupID, platform)
 		// 分组内无账号则返回空列表，由上层处理错误，不再回退到全平台查询
@@ -1748,11 +2101,135 @@ func (s *GatewayService) isAccountInGroup(account *Account, groupID *int64) bool
 	return false
 }
 
-func (s *GatewayService) tryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int) (*AcquireResult, error) {
+// accountSatisfiesRequiredCapabilities 检查账号是否具备当前请求所需的能力
+// （视觉输入/工具调用/extended thinking），需求通过 ctxkey.RequiresVision /
+// ctxkey.RequiresTools / ctxkey.ThinkingEnabled 传递，由 handler 在解析请求体后
+// 按需设置，未设置时不做任何限制。
+func accountSatisfiesRequiredCapabilities(ctx context.Context, account *Account) bool {
+	if requiresVision, ok := ctx.Value(ctxkey.RequiresVision).(bool); ok && requiresVision {
+		if !account.SupportsVision() {
+			return false
+		}
+	}
+	if requiresTools, ok := ctx.Value(ctxkey.RequiresTools).(bool); ok && requiresTools {
+		if !account.SupportsTools() {
+			return false
+		}
+	}
+	if thinkingEnabled, ok := ctx.Value(ctxkey.ThinkingEnabled).(bool); ok && thinkingEnabled {
+		if !account.SupportsExtendedThinking() {
+			return false
+		}
+	}
+	return true
+}
+
+// loadAwareCandidates 过滤出 Layer 2 负载感知选择可用的候选账户。
+// 被提取为独立方法是为了支持候选账户为空时的等待重试：每次重试都需要
+// 用相同的规则重新评估（时间类不可调度窗口可能已自然过期）。
+// 返回值 featureMismatch 标识是否存在账号仅因不满足 accountSatisfiesRequiredCapabilities
+// （视觉/工具调用/extended thinking）而被排除，用于区分"无可用账号"与"无账号支持所需特性"。
+func (s *GatewayService) loadAwareCandidates(ctx context.Context, accounts []Account, isExcluded func(int64) bool, platform string, useMixed bool, requestedModel string) (candidates []*Account, featureMismatch bool) {
+	candidates = make([]*Account, 0, len(accounts))
+	incompatFeatures := requestIncompatFeatures(ctx)
+	// intent 路由限定的账号子集（未声明 intent 或分组未配置时为 nil，不做限制）。
+	intentAccountIDs := intentAccountIDsFromContext(ctx)
+	for i := range accounts {
+		acc := &accounts[i]
+		if isExcluded(acc.ID) {
+			continue
+		}
+		if intentAccountIDs != nil && !containsInt64(intentAccountIDs, acc.ID) {
+			continue
+		}
+		if isAccountFeatureIncompatible(s.featureIncompat, acc.ID, incompatFeatures) {
+			continue
+		}
+		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
+		// re-check schedulability here so recently rate-limited/overloaded accounts
+		// are not selected again before the bucket is rebuilt.
+		if !acc.IsSchedulable() {
+			continue
+		}
+		if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
+			continue
+		}
+		if !accountSatisfiesRequiredCapabilities(ctx, acc) {
+			featureMismatch = true
+			continue
+		}
+		if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
+			continue
+		}
+		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
+			continue
+		}
+		// 窗口费用检查（非粘性会话路径）
+		if !s.isAccountSchedulableForWindowCost(ctx, acc, false) {
+			continue
+		}
+		candidates = append(candidates, acc)
+	}
+	return candidates, featureMismatch
+}
+
+// tryAcquireAccountSlot 尝试为账号获取并发槽位。peers 为同批候选账号列表，用于在账号自身
+// 并发打满且启用了亲和借用（concurrency.affinity_borrow_enabled）时，从同一亲和分组内的空闲
+// 账号借用槽位；peers 为空时（如旧版非负载批量路径）退化为普通的单账号槽位获取。groupID 为
+// 发起本次请求的分组，用于应用账号上的分组预留槽位（account_groups.reserved_slots）。
+func (s *GatewayService) tryAcquireAccountSlot(ctx context.Context, account *Account, peers []Account, groupID *int64) (*AcquireResult, error) {
 	if s.concurrencyService == nil {
 		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, nil
 	}
-	return s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+	maxConcurrency := account.Concurrency
+	if s.cfg != nil {
+		maxConcurrency = effectiveAccountConcurrency(&s.cfg.Concurrency, account)
+	}
+	reservations := accountGroupReservations(account)
+	if s.cfg != nil && s.cfg.Concurrency.AffinityBorrowEnabled {
+		if borrowPeers := s.affinityBorrowPeers(account, peers); len(borrowPeers) > 0 {
+			maxConcurrency = effectiveAccountConcurrencyForGroup(maxConcurrency, reservations, derefGroupID(groupID))
+			return s.concurrencyService.AcquireAccountSlotWithAffinityBorrowing(ctx, account.ID, maxConcurrency, borrowPeers, s.cfg.Concurrency.AffinityBorrowMaxSlots)
+		}
+	}
+	return s.concurrencyService.AcquireAccountSlotForGroup(ctx, account.ID, maxConcurrency, reservations, derefGroupID(groupID))
+}
+
+// accountGroupReservations 将账号已加载的分组绑定（AccountGroups）转换为预留槽位列表，
+// 供 AcquireAccountSlotForGroup 计算分组间的预留/共享并发上限。
+func accountGroupReservations(account *Account) []ReservedGroupSlots {
+	if account == nil || len(account.AccountGroups) == 0 {
+		return nil
+	}
+	reservations := make([]ReservedGroupSlots, 0, len(account.AccountGroups))
+	for _, ag := range account.AccountGroups {
+		if ag.ReservedSlots <= 0 {
+			continue
+		}
+		reservations = append(reservations, ReservedGroupSlots{GroupID: ag.GroupID, ReservedSlots: ag.ReservedSlots})
+	}
+	return reservations
+}
+
+// affinityBorrowPeers 返回与 account 同一亲和分组内的其它账号及其有效并发上限，
+// 供并发借用时查找空闲账号；account 未设置亲和分组时不存在借用对象。
+func (s *GatewayService) affinityBorrowPeers(account *Account, accounts []Account) []AccountWithConcurrency {
+	if account.AffinityGroup == "" || len(accounts) == 0 {
+		return nil
+	}
+	peers := make([]AccountWithConcurrency, 0, len(accounts))
+	for i := range accounts {
+		peer := &accounts[i]
+		if peer.ID == account.ID || peer.AffinityGroup != account.AffinityGroup {
+			continue
+		}
+		maxConcurrency := peer.Concurrency
+		if s.cfg != nil {
+			maxConcurrency = effectiveAccountConcurrency(&s.cfg.Concurrency, peer)
+		}
+		peers = append(peers, AccountWithConcurrency{ID: peer.ID, MaxConcurrency: maxConcurrency})
+	}
+	return peers
 }
 
 // isAccountSchedulableForWindowCost 检查账号是否可根据窗口费用进行调度
@@ -1812,23 +2289,67 @@ checkSchedulability:
 	return true
 }
 
-// checkAndRegisterSession 检查并注册会话，用于会话数量限制
-// 仅适用于 Anthropic OAuth/SetupToken 账号
-// sessionID: 会话标识符（使用粘性会话的 hash）
-// 返回 true 表示允许（在限制内或会话已存在），false 表示拒绝（超出限制且是新会话）
-func (s *GatewayService) checkAndRegisterSession(ctx context.Context, account *Account, sessionID string) bool {
-	// 只检查 Anthropic OAuth/SetupToken 账号
-	if !account.IsAnthropicOAuthOrSetupToken() {
-		return true
-	}
-
-	maxSessions := account.GetMaxSessions()
-	if maxSessions <= 0 || sessionID == "" {
-		return true // 未启用会话限制或无会话ID
+// isGroupSchedulableForWindowCost 检查分组是否可根据窗口费用进行调度
+// 聚合分组内所有账号在滚动窗口内的标准费用，与 Group.WindowCostLimitUSD 比较
+// isSticky 为 true 时表示该请求已绑定到分组内某个账号（粘性会话），超限时仍允许继续使用
+// 返回 true 表示可调度，false 表示不可调度
+func (s *GatewayService) isGroupSchedulableForWindowCost(ctx context.Context, group *Group, isSticky bool) bool {
+	if group == nil || !group.HasWindowCostLimit() {
+		return true // 未启用分组窗口费用限制
 	}
 
-	if s.sessionLimitCache == nil {
-		return true // 缓存不可用时允许通过
+	// 尝试从缓存获取窗口费用
+	var currentCost float64
+	if s.sessionLimitCache != nil {
+		if cost, hit, err := s.sessionLimitCache.GetGroupWindowCost(ctx, group.ID); err == nil && hit {
+			currentCost = cost
+			goto checkGroupSchedulability
+		}
+	}
+
+	// 缓存未命中，从数据库查询
+	{
+		startTime := time.Now().Add(-group.WindowCostWindowDuration())
+
+		stats, err := s.usageLogRepo.GetGroupWindowStats(ctx, group.ID, startTime)
+		if err != nil {
+			// 失败开放：查询失败时允许调度
+			return true
+		}
+
+		currentCost = stats.StandardCost
+
+		// 设置缓存（忽略错误）
+		if s.sessionLimitCache != nil {
+			_ = s.sessionLimitCache.SetGroupWindowCost(ctx, group.ID, currentCost)
+		}
+	}
+
+checkGroupSchedulability:
+	if currentCost < *group.WindowCostLimitUSD {
+		return true
+	}
+	// 已超出分组窗口费用上限：粘性会话继续允许使用已绑定账号，新会话拒绝
+	return isSticky
+}
+
+// checkAndRegisterSession 检查并注册会话，用于会话数量限制
+// 仅适用于 Anthropic OAuth/SetupToken 账号
+// sessionID: 会话标识符（使用粘性会话的 hash）
+// 返回 true 表示允许（在限制内或会话已存在），false 表示拒绝（超出限制且是新会话）
+func (s *GatewayService) checkAndRegisterSession(ctx context.Context, account *Account, sessionID string) bool {
+	// 只检查 Anthropic OAuth/SetupToken 账号
+	if !account.IsAnthropicOAuthOrSetupToken() {
+		return true
+	}
+
+	maxSessions := account.GetMaxSessions()
+	if maxSessions <= 0 || sessionID == "" {
+		return true // 未启用会话限制或无会话ID
+	}
+
+	if s.sessionLimitCache == nil {
+		return true // 缓存不可用时允许通过
 	}
 
 	idleTimeout := time.Duration(account.GetSessionIdleTimeoutMinutes()) * time.Minute
@@ -1888,6 +2409,24 @@ func filterByMinLoadRate(accounts []accountWithLoad) []accountWithLoad {
 	return result
 }
 
+// filterByAffinityGroup 在故障转移时，将候选集合缩小到与失败账号同一亲和分组的账号；
+// 若该分组内没有可用账号，则退化为返回原始候选集合（不限制亲和分组）
+func filterByAffinityGroup(accounts []accountWithLoad, affinityGroups map[string]struct{}) []accountWithLoad {
+	if len(affinityGroups) == 0 {
+		return accounts
+	}
+	preferred := make([]accountWithLoad, 0, len(accounts))
+	for _, acc := range accounts {
+		if _, ok := affinityGroups[acc.account.AffinityGroup]; ok {
+			preferred = append(preferred, acc)
+		}
+	}
+	if len(preferred) == 0 {
+		return accounts
+	}
+	return preferred
+}
+
 // selectByLRU 从集合中选择最久未用的账号
 // 如果有多个账号具有相同的最小 LastUsedAt，则随机选择一个
 func selectByLRU(accounts []accountWithLoad, preferOAuth bool) *accountWithLoad {
@@ -1948,6 +2487,42 @@ func selectByLRU(accounts []accountWithLoad, preferOAuth bool) *accountWithLoad
 	return &accounts[selectedIdx]
 }
 
+// failoverAffinityGroups 在发生故障转移（excludedIDs 非空）时，从候选账号列表中找出已被排除账号所属的
+// 亲和分组集合。后续选择会优先在这些分组内寻找替代账号，再退化到其他账号。
+func failoverAffinityGroups(accounts []Account, excludedIDs map[int64]struct{}) map[string]struct{} {
+	if len(excludedIDs) == 0 {
+		return nil
+	}
+	var groups map[string]struct{}
+	for i := range accounts {
+		if accounts[i].AffinityGroup == "" {
+			continue
+		}
+		if _, excluded := excludedIDs[accounts[i].ID]; !excluded {
+			continue
+		}
+		if groups == nil {
+			groups = make(map[string]struct{})
+		}
+		groups[accounts[i].AffinityGroup] = struct{}{}
+	}
+	return groups
+}
+
+// preferAffinityGroup 判断候选账号 acc 是否应优先于当前已选账号 selected：当两者是否属于目标亲和分组
+// 不一致时，属于目标分组的一方胜出；decided 为 false 表示两者在亲和分组维度上无法区分，应继续按其他规则比较。
+func preferAffinityGroup(affinityGroups map[string]struct{}, acc, selected *Account) (prefer bool, decided bool) {
+	if len(affinityGroups) == 0 {
+		return false, false
+	}
+	_, accIn := affinityGroups[acc.AffinityGroup]
+	_, selectedIn := affinityGroups[selected.AffinityGroup]
+	if accIn == selectedIn {
+		return false, false
+	}
+	return accIn, true
+}
+
 func sortAccountsByPriorityAndLastUsed(accounts []*Account, preferOAuth bool) {
 	sort.SliceStable(accounts, func(i, j int) bool {
 		a, b := accounts[i], accounts[j]
@@ -1971,6 +2546,66 @@ func sortAccountsByPriorityAndLastUsed(accounts []*Account, preferOAuth bool) {
 	shuffleWithinPriorityAndLastUsed(accounts)
 }
 
+// sortAccountsByPriorityAndCheapest 按 (Priority, BillingRateMultiplier, LastUsedAt) 排序，
+// 同优先级内优先选择计费倍率最低（即实际成本最低）的账号，倍率相同时按最后使用时间兜底。
+func sortAccountsByPriorityAndCheapest(accounts []*Account, preferOAuth bool) {
+	sort.SliceStable(accounts, func(i, j int) bool {
+		a, b := accounts[i], accounts[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if am, bm := a.BillingRateMultiplier(), b.BillingRateMultiplier(); am != bm {
+			return am < bm
+		}
+		switch {
+		case a.LastUsedAt == nil && b.LastUsedAt != nil:
+			return true
+		case a.LastUsedAt != nil && b.LastUsedAt == nil:
+			return false
+		case a.LastUsedAt == nil && b.LastUsedAt == nil:
+			if preferOAuth && a.Type != b.Type {
+				return a.Type == AccountTypeOAuth
+			}
+			return false
+		default:
+			return a.LastUsedAt.Before(*b.LastUsedAt)
+		}
+	})
+	shuffleWithinPriorityAndCheapest(accounts)
+}
+
+// shuffleWithinPriorityAndCheapest 对按 (Priority, BillingRateMultiplier, LastUsedAt) 排序后的切片，
+// 组内随机打乱，避免并发请求读取同一快照时确定性排序导致所有请求命中相同账号。
+func shuffleWithinPriorityAndCheapest(accounts []*Account) {
+	if len(accounts) <= 1 {
+		return
+	}
+	i := 0
+	for i < len(accounts) {
+		j := i + 1
+		for j < len(accounts) && sameAccountGroupCheapest(accounts[i], accounts[j]) {
+			j++
+		}
+		if j-i > 1 {
+			mathrand.Shuffle(j-i, func(a, b int) {
+				accounts[i+a], accounts[i+b] = accounts[i+b], accounts[i+a]
+			})
+		}
+		i = j
+	}
+}
+
+// sameAccountGroupCheapest 判断两个 Account 是否属于同一排序组（Priority + BillingRateMultiplier + LastUsedAt）
+func sameAccountGroupCheapest(a, b *Account) bool {
+	if a.Priority != b.Priority {
+		return false
+	}
+	if a.BillingRateMultiplier() != b.BillingRateMultiplier() {
+		return false
+	}
+	return sameLastUsedAt(a.LastUsedAt, b.LastUsedAt)
+}
+
 // shuffleWithinSortGroups 对排序后的 accountWithLoad 切片，按 (Priority, LoadRate, LastUsedAt) 分组后组内随机打乱。
 // 防止并发请求读取同一快照时，确定性排序导致所有请求命中相同账号。
 func shuffleWithinSortGroups(accounts []accountWithLoad) {
@@ -2044,18 +2679,120 @@ func sameLastUsedAt(a, b *time.Time) bool {
 }
 
 // sortCandidatesForFallback 根据配置选择排序策略
-// mode: "last_used"(按最后使用时间) 或 "random"(随机)
-func (s *GatewayService) sortCandidatesForFallback(accounts []*Account, preferOAuth bool, mode string) {
-	if mode == "random" {
+// mode: "last_used"(按最后使用时间)、"random"(随机)、"cheapest"(同优先级内优先选择计费倍率最低的账号)
+// 或 "weighted"(同优先级内按账号 Concurrency 加权随机排序)
+func (s *GatewayService) sortCandidatesForFallback(ctx context.Context, accounts []*Account, preferOAuth bool, mode string) {
+	switch mode {
+	case "random":
 		// 先按优先级排序，然后在同优先级内随机打乱
 		sortAccountsByPriorityOnly(accounts, preferOAuth)
-		shuffleWithinPriority(accounts)
-	} else {
+		if seed, ok := selectionSeedFromContext(ctx); ok {
+			shuffleWithinPriorityWithRand(accounts, mathrand.New(mathrand.NewSource(seed)))
+		} else {
+			shuffleWithinPriority(accounts)
+		}
+	case "cheapest":
+		sortAccountsByPriorityAndCheapest(accounts, preferOAuth)
+	case "weighted":
+		// 先按优先级排序，然后在同优先级内按权重加权打乱
+		sortAccountsByPriorityOnly(accounts, preferOAuth)
+		if seed, ok := selectionSeedFromContext(ctx); ok {
+			weightedShuffleWithinPriorityWithRand(accounts, mathrand.New(mathrand.NewSource(seed)))
+		} else {
+			weightedShuffleWithinPriority(accounts)
+		}
+	default:
 		// 默认按最后使用时间排序
 		sortAccountsByPriorityAndLastUsed(accounts, preferOAuth)
 	}
 }
 
+// accountSelectionWeight 返回账号在加权选择中的权重，取其 Concurrency（即 MaxConcurrency）；
+// Concurrency <= 0 表示不限并发，此时退化为权重 1，避免其在加权抽样中占据不成比例的份额。
+func accountSelectionWeight(account *Account) float64 {
+	if account.Concurrency > 0 {
+		return float64(account.Concurrency)
+	}
+	return 1
+}
+
+// weightedShuffleWithinPriority 在同优先级内按 accountSelectionWeight 加权随机排序
+func weightedShuffleWithinPriority(accounts []*Account) {
+	weightedShuffleWithinPriorityWithRand(accounts, mathrand.New(mathrand.NewSource(time.Now().UnixNano())))
+}
+
+// weightedShuffleWithinPriorityWithRand 使用 Efraimidis-Spirakis 加权抽样算法，在同优先级内
+// 按权重对账号排序：为每个账号生成 key = u^(1/weight)（u 为 (0,1) 均匀随机数），按 key 降序排列
+// 即为一次不放回的加权随机排列，权重越高的账号越可能排在前面。
+// 允许调用方传入带固定种子的随机源，使同一候选集合产生可复现的打乱结果（见 WithSelectionSeed）。
+func weightedShuffleWithinPriorityWithRand(accounts []*Account, r *mathrand.Rand) {
+	if len(accounts) <= 1 {
+		return
+	}
+	keys := make([]float64, len(accounts))
+	start := 0
+	for start < len(accounts) {
+		end := start + 1
+		for end < len(accounts) && accounts[end].Priority == accounts[start].Priority {
+			end++
+		}
+		if end-start > 1 {
+			for i := start; i < end; i++ {
+				u := r.Float64()
+				if u <= 0 {
+					u = math.SmallestNonzeroFloat64
+				}
+				keys[i] = math.Pow(u, 1/accountSelectionWeight(accounts[i]))
+			}
+			group := accounts[start:end]
+			groupKeys := keys[start:end]
+			sort.Sort(sort.Reverse(weightedGroupSorter{accounts: group, keys: groupKeys}))
+		}
+		start = end
+	}
+}
+
+// weightedGroupSorter 配合 sort.Sort 按 keys 对 accounts 做同步排序
+type weightedGroupSorter struct {
+	accounts []*Account
+	keys     []float64
+}
+
+func (s weightedGroupSorter) Len() int { return len(s.accounts) }
+func (s weightedGroupSorter) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
+}
+func (s weightedGroupSorter) Swap(i, j int) {
+	s.accounts[i], s.accounts[j] = s.accounts[j], s.accounts[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// selectByWeight 按 accountSelectionWeight 加权随机从集合中选择一个账号
+func selectByWeight(accounts []accountWithLoad) *accountWithLoad {
+	if len(accounts) == 0 {
+		return nil
+	}
+	if len(accounts) == 1 {
+		return &accounts[0]
+	}
+	total := 0.0
+	for _, acc := range accounts {
+		total += accountSelectionWeight(acc.account)
+	}
+	if total <= 0 {
+		return &accounts[mathrand.Intn(len(accounts))]
+	}
+	target := mathrand.Float64() * total
+	cumulative := 0.0
+	for i := range accounts {
+		cumulative += accountSelectionWeight(accounts[i].account)
+		if target < cumulative {
+			return &accounts[i]
+		}
+	}
+	return &accounts[len(accounts)-1]
+}
+
 // sortAccountsByPriorityOnly 仅按优先级排序
 func sortAccountsByPriorityOnly(accounts []*Account, preferOAuth bool) {
 	sort.SliceStable(accounts, func(i, j int) bool {
@@ -2072,10 +2809,15 @@ func sortAccountsByPriorityOnly(accounts []*Account, preferOAuth bool) {
 
 // shuffleWithinPriority 在同优先级内随机打乱顺序
 func shuffleWithinPriority(accounts []*Account) {
+	shuffleWithinPriorityWithRand(accounts, mathrand.New(mathrand.NewSource(time.Now().UnixNano())))
+}
+
+// shuffleWithinPriorityWithRand 在同优先级内使用指定随机源打乱顺序。
+// 允许调用方传入带固定种子的随机源，使同一候选集合产生可复现的打乱结果（见 WithSelectionSeed）。
+func shuffleWithinPriorityWithRand(accounts []*Account, r *mathrand.Rand) {
 	if len(accounts) <= 1 {
 		return
 	}
-	r := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
 	start := 0
 	for start < len(accounts) {
 		priority := accounts[start].Priority
@@ -2151,6 +2893,8 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			}
 		}
 
+		affinityGroups := failoverAffinityGroups(accounts, excludedIDs)
+
 		var selected *Account
 		for i := range accounts {
 			acc := &accounts[i]
@@ -2175,6 +2919,12 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 				selected = acc
 				continue
 			}
+			if prefer, decided := preferAffinityGroup(affinityGroups, acc, selected); decided {
+				if prefer {
+					selected = acc
+				}
+				continue
+			}
 			if acc.Priority < selected.Priority {
 				selected = acc
 			} else if acc.Priority == selected.Priority {
@@ -2197,7 +2947,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 
 		if selected != nil {
 			if sessionHash != "" && s.cache != nil {
-				if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
+				if err := s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
 					log.Printf("set session account failed: session=%s account_id=%d err=%v", sessionHash, selected.ID, err)
 				}
 			}
@@ -2242,7 +2992,8 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		}
 	}
 
-	// 3. 按优先级+最久未用选择（考虑模型支持）
+	// 3. 按优先级+最久未用选择（考虑模型支持）；故障转移时优先选择同一亲和分组内的账号
+	affinityGroups := failoverAffinityGroups(accounts, excludedIDs)
 	var selected *Account
 	for i := range accounts {
 		acc := &accounts[i]
@@ -2264,6 +3015,12 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			selected = acc
 			continue
 		}
+		if prefer, decided := preferAffinityGroup(affinityGroups, acc, selected); decided {
+			if prefer {
+				selected = acc
+			}
+			continue
+		}
 		if acc.Priority < selected.Priority {
 			selected = acc
 		} else if acc.Priority == selected.Priority {
@@ -2293,7 +3050,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 
 	// 4. 建立粘性绑定
 	if sessionHash != "" && s.cache != nil {
-		if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
+		if err := s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
 			log.Printf("set session account failed: session=%s account_id=%d err=%v", sessionHash, selected.ID, err)
 		}
 	}
@@ -2301,10 +3058,95 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 	return selected, nil
 }
 
+// preferMixedSchedulingAccount 在混合调度下，判断优先级和最后使用时间都相同的两个候选账户中，
+// 是否应根据 gateway.scheduling.mixed_scheduling_preference 配置优先选择 candidate 而非 current。
+// 仅当两者平台不同（一个是原生平台，一个是启用了混合调度的 antigravity）时才会生效。
+func preferMixedSchedulingAccount(preference string, candidate, current *Account) bool {
+	if candidate.Platform == current.Platform {
+		return false
+	}
+	switch preference {
+	case config.MixedSchedulingPreferenceNativeFirst:
+		return current.Platform == PlatformAntigravity
+	case config.MixedSchedulingPreferenceAntigravityFirst:
+		return candidate.Platform == PlatformAntigravity
+	default:
+		return false
+	}
+}
+
+// pickMixedSchedulingCandidate 在候选账号集合中按优先级+最久未用选择一个账号，支持混合调度。
+// routingSet 非 nil 时仅考虑其中的账号 ID（用于模型路由场景）；allowAntigravity 为 false 时
+// 跳过所有 antigravity 账号，用于实现严格 fallback（原生账号全部饱和后才纳入 antigravity）。
+func (s *GatewayService) pickMixedSchedulingCandidate(ctx context.Context, accounts []Account, excludedIDs map[int64]struct{}, requestedModel string, preferOAuth bool, mixedSchedulingPreference string, affinityGroups map[string]struct{}, allowAntigravity bool, routingSet map[int64]struct{}) *Account {
+	var selected *Account
+	for i := range accounts {
+		acc := &accounts[i]
+		if routingSet != nil {
+			if _, ok := routingSet[acc.ID]; !ok {
+				continue
+			}
+		}
+		if _, excluded := excludedIDs[acc.ID]; excluded {
+			continue
+		}
+		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
+		// avoid selecting accounts that were recently rate-limited/overloaded.
+		if !acc.IsSchedulable() {
+			continue
+		}
+		// 过滤：原生平台直接通过，antigravity 需要启用混合调度，且在严格 fallback 模式下
+		// 仅当原生账号全部不可用时才会被第二轮调用以 allowAntigravity=true 纳入考虑。
+		if acc.Platform == PlatformAntigravity {
+			if !acc.IsMixedSchedulingEnabled() || !allowAntigravity {
+				continue
+			}
+		}
+		if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
+			continue
+		}
+		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
+			continue
+		}
+		if selected == nil {
+			selected = acc
+			continue
+		}
+		if prefer, decided := preferAffinityGroup(affinityGroups, acc, selected); decided {
+			if prefer {
+				selected = acc
+			}
+			continue
+		}
+		if acc.Priority < selected.Priority {
+			selected = acc
+		} else if acc.Priority == selected.Priority {
+			switch {
+			case acc.LastUsedAt == nil && selected.LastUsedAt != nil:
+				selected = acc
+			case acc.LastUsedAt != nil && selected.LastUsedAt == nil:
+				// keep selected (never used is preferred)
+			case acc.LastUsedAt == nil && selected.LastUsedAt == nil:
+				if preferOAuth && acc.Platform == PlatformGemini && selected.Platform == PlatformGemini && acc.Type != selected.Type && acc.Type == AccountTypeOAuth {
+					selected = acc
+				} else if preferMixedSchedulingAccount(mixedSchedulingPreference, acc, selected) {
+					selected = acc
+				}
+			default:
+				if acc.LastUsedAt.Before(*selected.LastUsedAt) {
+					selected = acc
+				}
+			}
+		}
+	}
+	return selected
+}
+
 // selectAccountWithMixedScheduling 选择账户（支持混合调度）
 // 查询原生平台账户 + 启用 mixed_scheduling 的 antigravity 账户
 func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, groupID *int64, sessionHash string, requestedModel string, excludedIDs map[int64]struct{}, nativePlatform string) (*Account, error) {
 	preferOAuth := nativePlatform == PlatformGemini
+	mixedSchedulingPreference := s.schedulingConfig().MixedSchedulingPreference
 	routingAccountIDs := s.routingAccountIDsForRequest(ctx, groupID, requestedModel, nativePlatform)
 
 	var accounts []Account
@@ -2356,57 +3198,17 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			}
 		}
 
-		var selected *Account
-		for i := range accounts {
-			acc := &accounts[i]
-			if _, ok := routingSet[acc.ID]; !ok {
-				continue
-			}
-			if _, excluded := excludedIDs[acc.ID]; excluded {
-				continue
-			}
-			// Scheduler snapshots can be temporarily stale; re-check schedulability here to
-			// avoid selecting accounts that were recently rate-limited/overloaded.
-			if !acc.IsSchedulable() {
-				continue
-			}
-			// 过滤：原生平台直接通过，antigravity 需要启用混合调度
-			if acc.Platform == PlatformAntigravity && !acc.IsMixedSchedulingEnabled() {
-				continue
-			}
-			if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
-				continue
-			}
-			if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
-				continue
-			}
-			if selected == nil {
-				selected = acc
-				continue
-			}
-			if acc.Priority < selected.Priority {
-				selected = acc
-			} else if acc.Priority == selected.Priority {
-				switch {
-				case acc.LastUsedAt == nil && selected.LastUsedAt != nil:
-					selected = acc
-				case acc.LastUsedAt != nil && selected.LastUsedAt == nil:
-					// keep selected (never used is preferred)
-				case acc.LastUsedAt == nil && selected.LastUsedAt == nil:
-					if preferOAuth && acc.Platform == PlatformGemini && selected.Platform == PlatformGemini && acc.Type != selected.Type && acc.Type == AccountTypeOAuth {
-						selected = acc
-					}
-				default:
-					if acc.LastUsedAt.Before(*selected.LastUsedAt) {
-						selected = acc
-					}
-				}
-			}
+		affinityGroups := failoverAffinityGroups(accounts, excludedIDs)
+		nativeSaturationOnly := mixedSchedulingNativeSaturationOnly(ctx)
+
+		selected := s.pickMixedSchedulingCandidate(ctx, accounts, excludedIDs, requestedModel, preferOAuth, mixedSchedulingPreference, affinityGroups, !nativeSaturationOnly, routingSet)
+		if selected == nil && nativeSaturationOnly {
+			selected = s.pickMixedSchedulingCandidate(ctx, accounts, excludedIDs, requestedModel, preferOAuth, mixedSchedulingPreference, affinityGroups, true, routingSet)
 		}
 
 		if selected != nil {
 			if sessionHash != "" && s.cache != nil {
-				if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
+				if err := s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
 					log.Printf("set session account failed: session=%s account_id=%d err=%v", sessionHash, selected.ID, err)
 				}
 			}
@@ -2449,50 +3251,14 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		}
 	}
 
-	// 3. 按优先级+最久未用选择（考虑模型支持和混合调度）
-	var selected *Account
-	for i := range accounts {
-		acc := &accounts[i]
-		if _, excluded := excludedIDs[acc.ID]; excluded {
-			continue
-		}
-		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
-		// avoid selecting accounts that were recently rate-limited/overloaded.
-		if !acc.IsSchedulable() {
-			continue
-		}
-		// 过滤：原生平台直接通过，antigravity 需要启用混合调度
-		if acc.Platform == PlatformAntigravity && !acc.IsMixedSchedulingEnabled() {
-			continue
-		}
-		if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
-			continue
-		}
-		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
-			continue
-		}
-		if selected == nil {
-			selected = acc
-			continue
-		}
-		if acc.Priority < selected.Priority {
-			selected = acc
-		} else if acc.Priority == selected.Priority {
-			switch {
-			case acc.LastUsedAt == nil && selected.LastUsedAt != nil:
-				selected = acc
-			case acc.LastUsedAt != nil && selected.LastUsedAt == nil:
-				// keep selected (never used is preferred)
-			case acc.LastUsedAt == nil && selected.LastUsedAt == nil:
-				if preferOAuth && acc.Platform == PlatformGemini && selected.Platform == PlatformGemini && acc.Type != selected.Type && acc.Type == AccountTypeOAuth {
-					selected = acc
-				}
-			default:
-				if acc.LastUsedAt.Before(*selected.LastUsedAt) {
-					selected = acc
-				}
-			}
-		}
+	// 3. 按优先级+最久未用选择（考虑模型支持和混合调度）；故障转移时优先选择同一亲和分组内的账号
+	affinityGroups := failoverAffinityGroups(accounts, excludedIDs)
+	nativeSaturationOnly := mixedSchedulingNativeSaturationOnly(ctx)
+
+	selected := s.pickMixedSchedulingCandidate(ctx, accounts, excludedIDs, requestedModel, preferOAuth, mixedSchedulingPreference, affinityGroups, !nativeSaturationOnly, nil)
+	if selected == nil && nativeSaturationOnly {
+		// 严格 fallback：原生账号全部不可用时，再纳入启用了混合调度的 antigravity 账号。
+		selected = s.pickMixedSchedulingCandidate(ctx, accounts, excludedIDs, requestedModel, preferOAuth, mixedSchedulingPreference, affinityGroups, true, nil)
 	}
 
 	if selected == nil {
@@ -2504,7 +3270,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 
 	// 4. 建立粘性绑定
 	if sessionHash != "" && s.cache != nil {
-		if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
+		if err := s.setSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
 			log.Printf("set session account failed: session=%s account_id=%d err=%v", sessionHash, selected.ID, err)
 		}
 	}
@@ -2593,20 +3359,42 @@ func (s *GatewayService) getOAuthToken(ctx context.Context, account *Account) (s
 	return accessToken, "oauth", nil
 }
 
-// 重试相关常量
+// 重试相关默认值，当 gateway.retry 未配置（零值）时使用。
 const (
 	// 最大尝试次数（包含首次请求）。过多重试会导致请求堆积与资源耗尽。
-	maxRetryAttempts = 5
+	defaultMaxRetryAttempts = 5
 
-	// 指数退避：第 N 次失败后的等待 = retryBaseDelay * 2^(N-1)，并且上限为 retryMaxDelay。
-	retryBaseDelay = 300 * time.Millisecond
-	retryMaxDelay  = 3 * time.Second
+	// 指数退避：第 N 次失败后的等待 = baseDelay * 2^(N-1)，并且上限为 maxDelay。
+	defaultRetryBaseDelay = 300 * time.Millisecond
+	defaultRetryMaxDelay  = 3 * time.Second
 
 	// 最大重试耗时（包含请求本身耗时 + 退避等待时间）。
 	// 用于防止极端情况下 goroutine 长时间堆积导致资源耗尽。
-	maxRetryElapsed = 10 * time.Second
+	defaultMaxRetryElapsed = 10 * time.Second
 )
 
+// retryConfig 返回当前生效的重试退避参数，取自 gateway.retry 配置；
+// 未配置（零值）的字段回落到原有的硬编码默认值。
+func (s *GatewayService) retryConfig() (maxAttempts int, baseDelay, maxDelay, maxElapsed time.Duration) {
+	maxAttempts, baseDelay, maxDelay, maxElapsed = defaultMaxRetryAttempts, defaultRetryBaseDelay, defaultRetryMaxDelay, defaultMaxRetryElapsed
+	if s.cfg == nil {
+		return
+	}
+	if s.cfg.Gateway.Retry.MaxAttempts > 0 {
+		maxAttempts = s.cfg.Gateway.Retry.MaxAttempts
+	}
+	if s.cfg.Gateway.Retry.BaseDelay > 0 {
+		baseDelay = s.cfg.Gateway.Retry.BaseDelay
+	}
+	if s.cfg.Gateway.Retry.MaxDelay > 0 {
+		maxDelay = s.cfg.Gateway.Retry.MaxDelay
+	}
+	if s.cfg.Gateway.Retry.MaxElapsed > 0 {
+		maxElapsed = s.cfg.Gateway.Retry.MaxElapsed
+	}
+	return
+}
+
 func (s *GatewayService) shouldRetryUpstreamError(account *Account, statusCode int) bool {
 	// OAuth/Setup Token 账号：仅 403 重试
 	if account.IsOAuth() {
@@ -2627,14 +3415,14 @@ func (s *GatewayService) shouldFailoverUpstreamError(statusCode int) bool {
 	}
 }
 
-func retryBackoffDelay(attempt int) time.Duration {
+func retryBackoffDelay(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
 	// attempt 从 1 开始，表示第 attempt 次请求刚失败，需要等待后进行第 attempt+1 次请求。
 	if attempt <= 0 {
-		return retryBaseDelay
+		return baseDelay
 	}
-	delay := retryBaseDelay * time.Duration(1<<(attempt-1))
-	if delay > retryMaxDelay {
-		return retryMaxDelay
+	delay := baseDelay * time.Duration(1<<(attempt-1))
+	if delay > maxDelay {
+		return maxDelay
 	}
 	return delay
 }
@@ -2843,12 +3631,16 @@ func enforceCacheControlLimit(body []byte) []byte {
 		return body
 	}
 
-	// 超限：优先从 messages 中移除，再从 system 中移除
+	// 超限：优先从 messages 中移除，再从 tools 中移除，最后从 system 中移除
 	for count > maxCacheControlBlocks {
 		if removeCacheControlFromMessages(data) {
 			count--
 			continue
 		}
+		if removeCacheControlFromTools(data) {
+			count--
+			continue
+		}
 		if removeCacheControlFromSystem(data) {
 			count--
 			continue
@@ -2863,7 +3655,7 @@ func enforceCacheControlLimit(body []byte) []byte {
 	return result
 }
 
-// countCacheControlBlocks 统计 system 和 messages 中的 cache_control 块数量
+// countCacheControlBlocks 统计 system、tools 和 messages 中的 cache_control 块数量
 // 注意：thinking 块不支持 cache_control，统计时跳过
 func countCacheControlBlocks(data map[string]any) int {
 	count := 0
@@ -2883,6 +3675,17 @@ func countCacheControlBlocks(data map[string]any) int {
 		}
 	}
 
+	// 统计 tools 中的块（Anthropic 支持对工具定义设置 cache_control）
+	if tools, ok := data["tools"].([]any); ok {
+		for _, item := range tools {
+			if m, ok := item.(map[string]any); ok {
+				if _, has := m["cache_control"]; has {
+					count++
+				}
+			}
+		}
+	}
+
 	// 统计 messages 中的块
 	if messages, ok := data["messages"].([]any); ok {
 		for _, msg := range messages {
@@ -2941,6 +3744,25 @@ func removeCacheControlFromMessages(data map[string]any) bool {
 	return false
 }
 
+// removeCacheControlFromTools 从 tools 中移除一个 cache_control（从尾部开始）
+// 返回 true 表示成功移除，false 表示没有可移除的
+func removeCacheControlFromTools(data map[string]any) bool {
+	tools, ok := data["tools"].([]any)
+	if !ok {
+		return false
+	}
+
+	for i := len(tools) - 1; i >= 0; i-- {
+		if m, ok := tools[i].(map[string]any); ok {
+			if _, has := m["cache_control"]; has {
+				delete(m, "cache_control")
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // removeCacheControlFromSystem 从 system 中移除一个 cache_control（从尾部开始，保护注入的 prompt）
 // 返回 true 表示成功移除，false 表示没有可移除的
 // 注意：跳过 thinking 块（它不支持 cache_control）
@@ -3015,6 +3837,10 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	reqModel := parsed.Model
 	reqStream := parsed.Stream
 	originalModel := reqModel
+	if parsed.OriginalModel != "" {
+		// 用户级模型映射已在 Handler 层改写了 parsed.Model，此处还原为用户实际请求的模型用于计费
+		originalModel = parsed.OriginalModel
+	}
 
 	isClaudeCode := isClaudeCodeRequest(ctx, c, parsed)
 	shouldMimicClaudeCode := account.IsOAuth() && !isClaudeCode
@@ -3027,8 +3853,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			body = injectClaudeCodePrompt(body, parsed.System)
 		}
 
-		normalizeOpts := claudeOAuthNormalizeOptions{stripSystemCacheControl: true}
-		if s.identityService != nil {
+		normalizeOpts := claudeOAuthNormalizeOptions{stripSystemCacheControl: !account.IsSystemCacheControlKept()}
+		if s.identityService != nil && !metadataRewriteDisabled(ctx) {
 			fp, err := s.identityService.GetOrCreateFingerprint(ctx, account.ID, c.Request.Header)
 			if err == nil && fp != nil {
 				if metadataUserID := s.buildOAuthMetadataUserID(parsed, account, fp); metadataUserID != "" {
@@ -3087,19 +3913,32 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		proxyURL = account.Proxy.URL()
 	}
 
-	// 调试日志：记录即将转发的账号信息
-	log.Printf("[Forward] Using account: ID=%d Name=%s Platform=%s Type=%s TLSFingerprint=%v Proxy=%s",
-		account.ID, account.Name, account.Platform, account.Type, account.IsTLSFingerprintEnabled(), proxyURL)
+	// 调试日志：记录即将转发的账号信息（高频日志，按配置采样，避免生产环境日志刷屏）
+	// 账号开启 DebugLogging 时强制打印，便于单独排查某个不稳定凭证
+	if s.shouldLogSelection() || account.IsDebugLoggingEnabled() {
+		log.Printf("[Forward] Using account: ID=%d Name=%s Platform=%s Type=%s TLSFingerprint=%v Proxy=%s",
+			account.ID, account.Name, account.Platform, account.Type, account.IsTLSFingerprintEnabled(), proxyURL)
+	}
 
 	// 重试循环
+	maxAttempts, retryBaseDelay, retryMaxDelay, maxRetryElapsed := s.retryConfig()
 	var resp *http.Response
 	retryStart := time.Now()
-	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		// 构建上游请求（每次重试需要重新构建，因为请求体需要重新读取）
 		// Capture upstream request body for ops retry of this attempt.
 		c.Set(OpsUpstreamRequestBodyKey, string(body))
 		upstreamReq, err := s.buildUpstreamRequest(ctx, c, account, body, token, tokenType, reqModel, reqStream, shouldMimicClaudeCode)
 		if err != nil {
+			if errors.Is(err, ErrAnthropicVersionRequired) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"type": "error",
+					"error": gin.H{
+						"type":    "invalid_request_error",
+						"message": "anthropic-version header is required",
+					},
+				})
+			}
 			return nil, err
 		}
 
@@ -3260,13 +4099,13 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 
 		// 检查是否需要通用重试（排除400，因为400已经在上面特殊处理过了）
 		if resp.StatusCode >= 400 && resp.StatusCode != 400 && s.shouldRetryUpstreamError(account, resp.StatusCode) {
-			if attempt < maxRetryAttempts {
+			if attempt < maxAttempts {
 				elapsed := time.Since(retryStart)
 				if elapsed >= maxRetryElapsed {
 					break
 				}
 
-				delay := retryBackoffDelay(attempt)
+				delay := retryBackoffDelay(attempt, retryBaseDelay, retryMaxDelay)
 				remaining := maxRetryElapsed - elapsed
 				if delay > remaining {
 					delay = remaining
@@ -3293,7 +4132,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 					}(),
 				})
 				log.Printf("Account %d: upstream error %d, retry %d/%d after %v (elapsed=%v/%v)",
-					account.ID, resp.StatusCode, attempt, maxRetryAttempts, delay, elapsed, maxRetryElapsed)
+					account.ID, resp.StatusCode, attempt, maxAttempts, delay, elapsed, maxRetryElapsed)
 				if err := sleepWithContext(ctx, delay); err != nil {
 					return nil, err
 				}
@@ -3324,12 +4163,13 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 			_ = resp.Body.Close()
 			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			respBody = s.restoreOriginalModelInErrorBody(respBody, reqModel, originalModel)
 
 			// 调试日志：打印重试耗尽后的错误响应
 			log.Printf("[Forward] Upstream error (retry exhausted, failover): Account=%d(%s) Status=%d RequestID=%s Body=%s",
 				account.ID, account.Name, resp.StatusCode, resp.Header.Get("x-request-id"), truncateString(string(respBody), 1000))
 
-			s.handleRetryExhaustedSideEffects(ctx, resp, account)
+			s.handleRetryExhaustedSideEffects(ctx, resp, account, reqModel)
 			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
 				Platform:           account.Platform,
 				AccountID:          account.ID,
@@ -3347,7 +4187,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			})
 			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: respBody}
 		}
-		return s.handleRetryExhaustedError(ctx, resp, c, account)
+		return s.handleRetryExhaustedError(ctx, resp, c, account, originalModel, reqModel)
 	}
 
 	// 处理可切换账号的错误
@@ -3355,12 +4195,13 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 		_ = resp.Body.Close()
 		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		respBody = s.restoreOriginalModelInErrorBody(respBody, reqModel, originalModel)
 
 		// 调试日志：打印上游错误响应
 		log.Printf("[Forward] Upstream error (failover): Account=%d(%s) Status=%d RequestID=%s Body=%s",
 			account.ID, account.Name, resp.StatusCode, resp.Header.Get("x-request-id"), truncateString(string(respBody), 1000))
 
-		s.handleFailoverSideEffects(ctx, resp, account)
+		s.handleFailoverSideEffects(ctx, resp, account, reqModel)
 		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
 			Platform:           account.Platform,
 			AccountID:          account.ID,
@@ -3383,12 +4224,14 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 			if readErr != nil {
 				// ReadAll failed, fall back to normal error handling without consuming the stream
-				return s.handleErrorResponse(ctx, resp, c, account)
+				return s.handleErrorResponse(ctx, resp, c, account, originalModel, reqModel)
 			}
 			_ = resp.Body.Close()
 			resp.Body = io.NopCloser(bytes.NewReader(respBody))
 
-			if s.shouldFailoverOn400(respBody) {
+			if shouldFailover, feature := s.shouldFailoverOn400(respBody); shouldFailover {
+				respBody = s.restoreOriginalModelInErrorBody(respBody, reqModel, originalModel)
+				s.featureIncompat.mark(account.ID, feature, s.cfg.Gateway.Scheduling.FeatureIncompatTTL)
 				upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 				upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 				upstreamDetail := ""
@@ -3419,11 +4262,11 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 				} else {
 					log.Printf("Account %d: 400 error, attempting failover", account.ID)
 				}
-				s.handleFailoverSideEffects(ctx, resp, account)
+				s.handleFailoverSideEffects(ctx, resp, account, reqModel)
 				return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: respBody}
 			}
 		}
-		return s.handleErrorResponse(ctx, resp, c, account)
+		return s.handleErrorResponse(ctx, resp, c, account, originalModel, reqModel)
 	}
 
 	// 处理正常响应
@@ -3433,9 +4276,11 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	if reqStream {
 		streamResult, err := s.handleStreamingResponse(ctx, resp, c, account, startTime, originalModel, reqModel, shouldMimicClaudeCode)
 		if err != nil {
-			if err.Error() == "have error in stream" {
+			var midStreamErr *midStreamUpstreamError
+			if errors.As(err, &midStreamErr) {
 				return nil, &UpstreamFailoverError{
-					StatusCode: 403,
+					StatusCode:   inferStatusFromUpstreamErrorBody(midStreamErr.body),
+					ResponseBody: s.restoreOriginalModelInErrorBody(midStreamErr.body, reqModel, originalModel),
 				}
 			}
 			return nil, err
@@ -3450,6 +4295,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		}
 	}
 
+	recordAccountOutcomeAndMaybeCooldown(ctx, s.successRateTracker, s.accountRepo, account, true)
+
 	return &ForwardResult{
 		RequestID:        resp.Header.Get("x-request-id"),
 		Usage:            *usage,
@@ -3494,7 +4341,7 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 			// 2. 重写metadata.user_id（需要指纹中的ClientID和账号的account_uuid）
 			// 如果启用了会话ID伪装，会在重写后替换 session 部分为固定值
 			accountUUID := account.GetExtraString("account_uuid")
-			if accountUUID != "" && fp.ClientID != "" {
+			if accountUUID != "" && fp.ClientID != "" && !metadataRewriteDisabled(ctx) {
 				if newBody, err := s.identityService.RewriteUserIDWithMasking(ctx, body, account, accountUUID, fp.ClientID); err == nil && len(newBody) > 0 {
 					body = newBody
 				}
@@ -3502,6 +4349,14 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 		}
 	}
 
+	// API-key 账号：应用声明式的请求体转换规则（仅受限的 set/delete JSON 路径操作，
+	// 用于适配个别上游要求的固定字段改写），在签发上游请求前应用
+	if account.Type == AccountTypeAPIKey {
+		if transforms := account.GetBodyTransforms(); len(transforms) > 0 {
+			body = applyBodyTransforms(body, transforms)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
@@ -3523,6 +4378,7 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 			}
 		}
 	}
+	s.ensureUpstreamTraceHeaders(req)
 
 	// OAuth账号：应用缓存的指纹到请求头（覆盖白名单透传的头）
 	if fingerprint != nil {
@@ -3534,6 +4390,9 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 		req.Header.Set("content-type", "application/json")
 	}
 	if req.Header.Get("anthropic-version") == "" {
+		if account.IsAnthropicVersionRequired() {
+			return nil, ErrAnthropicVersionRequired
+		}
 		req.Header.Set("anthropic-version", "2023-06-01")
 	}
 	if tokenType == "oauth" {
@@ -3554,7 +4413,7 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 			// Also drop claude-code beta if a downstream client added it.
 			requiredBetas := []string{claude.BetaOAuth, claude.BetaInterleavedThinking}
 			drop := map[string]struct{}{claude.BetaClaudeCode: {}, claude.BetaContext1M: {}}
-			req.Header.Set("anthropic-beta", mergeAnthropicBetaDropping(requiredBetas, incomingBeta, drop))
+			req.Header.Set("anthropic-beta", mergeAnthropicBetaDropping(requiredBetas, incomingBeta, drop, s.maxAnthropicBetaHeaderLength()))
 		} else {
 			// Claude Code 客户端：尽量透传原始 header，仅补齐 oauth beta
 			clientBetaHeader := req.Header.Get("anthropic-beta")
@@ -3569,12 +4428,30 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 		}
 	}
 
+	// 账号级 beta 黑名单：部分上游对特定 beta 特性返回 400，
+	// 允许运营方按账号屏蔽这些 beta，在合并/补齐之后统一剔除
+	if blacklist := account.GetAnthropicBetaBlacklist(); len(blacklist) > 0 {
+		if current := req.Header.Get("anthropic-beta"); current != "" {
+			req.Header.Set("anthropic-beta", dropAnthropicBetas(current, blacklist))
+		}
+	}
+
+	// API-key 账号：应用分组级 / 账号级上游默认请求头（账号级覆盖同名分组级配置），
+	// 认证类头部及 content-type/content-length/host 始终不受影响
+	if account.Type == AccountTypeAPIKey {
+		groupHeaders := groupUpstreamHeadersFromContext(ctx)
+		accountHeaders := account.GetUpstreamHeaders()
+		if len(groupHeaders) > 0 || len(accountHeaders) > 0 {
+			applyUpstreamHeaders(req, groupHeaders, accountHeaders)
+		}
+	}
+
 	// Always capture a compact fingerprint line for later error diagnostics.
 	// We only print it when needed (or when the explicit debug flag is enabled).
 	if c != nil && tokenType == "oauth" {
 		c.Set(claudeMimicDebugInfoKey, buildClaudeMimicDebugLine(req, body, account, tokenType, mimicClaudeCode))
 	}
-	if s.debugClaudeMimicEnabled() {
+	if s.shouldLogClaudeMimicDebug(account) {
 		logClaudeMimicDebug(req, body, account, tokenType, mimicClaudeCode)
 	}
 
@@ -3584,6 +4461,12 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 // getBetaHeader 处理anthropic-beta header
 // 对于OAuth账号，需要确保包含oauth-2025-04-20
 func (s *GatewayService) getBetaHeader(modelID string, clientBetaHeader string) string {
+	// 先剔除与 OAuth 账号冲突的客户端 beta（见 cfg.Gateway.OAuthBetaDenylist），
+	// 再走下面的必需 beta 补齐逻辑，确保剔除不会影响 oauth/claude-code 等必需项
+	if s.cfg != nil && len(s.cfg.Gateway.OAuthBetaDenylist) > 0 {
+		clientBetaHeader = dropAnthropicBetas(clientBetaHeader, s.cfg.Gateway.OAuthBetaDenylist)
+	}
+
 	// 如果客户端传了anthropic-beta
 	if clientBetaHeader != "" {
 		// 已包含oauth beta则直接返回
@@ -3690,21 +4573,89 @@ func mergeAnthropicBeta(required []string, incoming string) string {
 	for _, p := range strings.Split(incoming, ",") {
 		add(p)
 	}
-	return strings.Join(out, ",")
+	return strings.Join(out, ",")
+}
+
+// mergeAnthropicBetaDropping merges required betas with the incoming header, removes any
+// token in drop, and caps the result at maxLen characters (0 = unlimited), dropping
+// lowest-priority (i.e. incoming, not required) betas from the end until it fits.
+func mergeAnthropicBetaDropping(required []string, incoming string, drop map[string]struct{}, maxLen int) string {
+	merged := mergeAnthropicBeta(required, incoming)
+	if merged == "" {
+		return merged
+	}
+	out := make([]string, 0, 8)
+	for _, p := range strings.Split(merged, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if _, ok := drop[p]; ok {
+			continue
+		}
+		out = append(out, p)
+	}
+	return capAnthropicBetaHeader(out, required, maxLen)
+}
+
+// capAnthropicBetaHeader 在合并后的 beta 列表超过 maxLen 字符（含逗号分隔符）时，
+// 从末尾（优先级最低，即最后合并进来的 incoming beta）开始丢弃非必需的 beta，
+// 直到总长度不超过 maxLen。required 中的 beta 始终保留，即使单独已超出 maxLen。
+func capAnthropicBetaHeader(betas []string, required []string, maxLen int) string {
+	if maxLen <= 0 || len(betas) == 0 {
+		return strings.Join(betas, ",")
+	}
+	requiredSet := make(map[string]struct{}, len(required))
+	for _, r := range required {
+		requiredSet[strings.TrimSpace(r)] = struct{}{}
+	}
+	for anthropicBetaHeaderLen(betas) > maxLen {
+		dropIndex := -1
+		for i := len(betas) - 1; i >= 0; i-- {
+			if _, ok := requiredSet[betas[i]]; !ok {
+				dropIndex = i
+				break
+			}
+		}
+		if dropIndex < 0 {
+			// 剩余全部是必需 beta，即使超长也必须保留
+			break
+		}
+		betas = append(betas[:dropIndex], betas[dropIndex+1:]...)
+	}
+	return strings.Join(betas, ",")
+}
+
+// anthropicBetaHeaderLen 计算按逗号拼接后的 header 字符串长度，避免每次都实际拼接。
+func anthropicBetaHeaderLen(betas []string) int {
+	if len(betas) == 0 {
+		return 0
+	}
+	total := len(betas) - 1 // commas
+	for _, b := range betas {
+		total += len(b)
+	}
+	return total
+}
+
+// maxAnthropicBetaHeaderLength 返回配置的 anthropic-beta header 最大长度，未配置时不限制。
+func (s *GatewayService) maxAnthropicBetaHeaderLength() int {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Gateway.MaxAnthropicBetaHeaderLength
 }
 
-func mergeAnthropicBetaDropping(required []string, incoming string, drop map[string]struct{}) string {
-	merged := mergeAnthropicBeta(required, incoming)
-	if merged == "" || len(drop) == 0 {
-		return merged
+// stripBetaToken removes a single beta token from a comma-separated header value.
+// It short-circuits when the token is not present to avoid unnecessary allocations.
+func stripBetaToken(header, token string) string {
+	if !strings.Contains(header, token) {
+		return header
 	}
 	out := make([]string, 0, 8)
-	for _, p := range strings.Split(merged, ",") {
+	for _, p := range strings.Split(header, ",") {
 		p = strings.TrimSpace(p)
-		if p == "" {
-			continue
-		}
-		if _, ok := drop[p]; ok {
+		if p == "" || p == token {
 			continue
 		}
 		out = append(out, p)
@@ -3712,16 +4663,24 @@ func mergeAnthropicBetaDropping(required []string, incoming string, drop map[str
 	return strings.Join(out, ",")
 }
 
-// stripBetaToken removes a single beta token from a comma-separated header value.
-// It short-circuits when the token is not present to avoid unnecessary allocations.
-func stripBetaToken(header, token string) string {
-	if !strings.Contains(header, token) {
+// dropAnthropicBetas removes any beta token present in blacklist from a
+// comma-separated anthropic-beta header value, used to apply an account-level
+// deny list on top of whatever merging/defaulting already produced the header.
+func dropAnthropicBetas(header string, blacklist []string) string {
+	if header == "" || len(blacklist) == 0 {
 		return header
 	}
+	drop := make(map[string]struct{}, len(blacklist))
+	for _, b := range blacklist {
+		drop[strings.TrimSpace(b)] = struct{}{}
+	}
 	out := make([]string, 0, 8)
 	for _, p := range strings.Split(header, ",") {
 		p = strings.TrimSpace(p)
-		if p == "" || p == token {
+		if p == "" {
+			continue
+		}
+		if _, ok := drop[p]; ok {
 			continue
 		}
 		out = append(out, p)
@@ -3808,31 +4767,109 @@ func (s *GatewayService) isThinkingBlockSignatureError(respBody []byte) bool {
 	return false
 }
 
-func (s *GatewayService) shouldFailoverOn400(respBody []byte) bool {
-	// 只对“可能是兼容性差异导致”的 400 允许切换，避免无意义重试。
-	// 默认保守：无法识别则不切换。
+// shouldFailoverOn400 判断该 400 错误是否“可能是兼容性差异导致”，允许切换账号，避免无意义重试。
+// 默认保守：无法识别则不切换。第二个返回值是触发切换的请求特征（见 classifyFeatureIncompat），
+// 供调用方短暂标记该账号对同一特征不兼容；无法识别时为空字符串。
+func (s *GatewayService) shouldFailoverOn400(respBody []byte) (bool, string) {
 	msg := strings.ToLower(strings.TrimSpace(extractUpstreamErrorMessage(respBody)))
 	if msg == "" {
-		return false
+		return false, ""
 	}
+	feature := classifyFeatureIncompat(msg)
+	return feature != "", feature
+}
 
-	// 缺少/错误的 beta header：换账号/链路可能成功（尤其是混合调度时）。
-	// 更精确匹配 beta 相关的兼容性问题，避免误触发切换。
-	if strings.Contains(msg, "anthropic-beta") ||
-		strings.Contains(msg, "beta feature") ||
-		strings.Contains(msg, "requires beta") {
-		return true
-	}
+// FailoverSimulationResult 描述针对某个上游状态码/响应体，故障转移与限流策略会如何处理，
+// 用于在不持有真实账号、不产生任何副作用的情况下验证配置是否符合预期。
+type FailoverSimulationResult struct {
+	StatusCode int `json:"status_code"`
 
-	// thinking/tool streaming 等兼容性约束（常见于中间转换链路）
-	if strings.Contains(msg, "thinking") || strings.Contains(msg, "thought_signature") || strings.Contains(msg, "signature") {
-		return true
+	// 是否会触发账号切换，以及触发原因："upstream_error"（命中 shouldFailoverUpstreamError）
+	// 或 "400_compat"（400 错误被识别为兼容性差异，见 shouldFailoverOn400）
+	ShouldFailover bool   `json:"should_failover"`
+	FailoverReason string `json:"failover_reason,omitempty"`
+	// 仅 FailoverReason 为 "400_compat" 时有意义：触发切换的请求特征，见 classifyFeatureIncompat
+	Feature400Incompat string `json:"feature_400_incompat,omitempty"`
+
+	// RateLimitService.HandleUpstreamError 对该状态码会采取的处理分类，见 classifyRateLimitAction
+	RateLimitAction     string `json:"rate_limit_action"`
+	WouldDisableAccount bool   `json:"would_disable_account"`
+}
+
+// SimulateFailover 预测给定状态码/响应体会触发的故障转移与限流行为，不依赖、不修改任何账号状态，
+// 用于管理端验证故障转移配置（如自定义错误码、400 兼容性识别规则）是否符合预期。
+func (s *GatewayService) SimulateFailover(statusCode int, respBody []byte) FailoverSimulationResult {
+	result := FailoverSimulationResult{StatusCode: statusCode}
+
+	if s.shouldFailoverUpstreamError(statusCode) {
+		result.ShouldFailover = true
+		result.FailoverReason = "upstream_error"
+	} else if statusCode == http.StatusBadRequest {
+		if ok, feature := s.shouldFailoverOn400(respBody); ok {
+			result.ShouldFailover = true
+			result.FailoverReason = "400_compat"
+			result.Feature400Incompat = feature
+		}
 	}
-	if strings.Contains(msg, "tool_use") || strings.Contains(msg, "tool_result") || strings.Contains(msg, "tools") {
-		return true
+
+	result.RateLimitAction, result.WouldDisableAccount = classifyRateLimitAction(statusCode, respBody)
+	return result
+}
+
+// classifyRateLimitAction 镜像 RateLimitService.HandleUpstreamError 按状态码分类处理的逻辑，
+// 在没有真实账号的情况下预测该状态码会落入哪个分支；不涉及账号自定义错误码/临时不可调度等
+// 依赖具体账号配置的规则，这些规则只能在真实调用中生效。
+func classifyRateLimitAction(statusCode int, respBody []byte) (action string, wouldDisable bool) {
+	msg := strings.ToLower(strings.TrimSpace(extractUpstreamErrorMessage(respBody)))
+	switch statusCode {
+	case 400:
+		if strings.Contains(msg, "organization has been disabled") {
+			return "auth_error", true
+		}
+		return "none", false
+	case 401, 402, 403:
+		return "auth_error", true
+	case 429:
+		return "rate_limited", false
+	case 529:
+		return "overloaded", false
+	default:
+		if statusCode >= 500 {
+			return "logged_only", false
+		}
+		return "none", false
 	}
+}
 
-	return false
+// midStreamUpstreamError 表示上游在流式响应中途发送了 event: error，
+// body 保留其原始错误体，供上层构造携带真实错误类型/消息的 Anthropic 错误信封。
+type midStreamUpstreamError struct {
+	body []byte
+}
+
+func (e *midStreamUpstreamError) Error() string {
+	return "have error in stream"
+}
+
+// inferStatusFromUpstreamErrorBody 根据上游错误体中的 error.type 推断等价的 HTTP 状态码，
+// 用于流中途错误场景（此时没有真实的 HTTP 状态码可用），与 error.type 的常见取值保持一致。
+func inferStatusFromUpstreamErrorBody(body []byte) int {
+	switch gjson.GetBytes(body, "error.type").String() {
+	case "overloaded_error":
+		return 529
+	case "rate_limit_error":
+		return http.StatusTooManyRequests
+	case "authentication_error":
+		return http.StatusUnauthorized
+	case "permission_error":
+		return http.StatusForbidden
+	case "not_found_error":
+		return http.StatusNotFound
+	case "invalid_request_error":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 // ExtractUpstreamErrorMessage 从上游响应体中提取错误消息
@@ -3858,8 +4895,9 @@ func extractUpstreamErrorMessage(body []byte) string {
 	return gjson.GetBytes(body, "message").String()
 }
 
-func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account) (*ForwardResult, error) {
+func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, originalModel, mappedModel string) (*ForwardResult, error) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+	body = s.restoreOriginalModelInErrorBody(body, mappedModel, originalModel)
 
 	// 调试日志：打印上游错误响应
 	log.Printf("[Forward] Upstream error (non-retryable): Account=%d(%s) Status=%d RequestID=%s Body=%s",
@@ -3905,7 +4943,7 @@ func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Res
 	// 处理上游错误，标记账号状态
 	shouldDisable := false
 	if s.rateLimitService != nil {
-		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body, mappedModel)
 	}
 	if shouldDisable {
 		return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: body}
@@ -4007,35 +5045,39 @@ func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Res
 	return nil, fmt.Errorf("upstream error: %d message=%s", resp.StatusCode, upstreamMsg)
 }
 
-func (s *GatewayService) handleRetryExhaustedSideEffects(ctx context.Context, resp *http.Response, account *Account) {
+func (s *GatewayService) handleRetryExhaustedSideEffects(ctx context.Context, resp *http.Response, account *Account, requestedModel string) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	statusCode := resp.StatusCode
+	maxAttempts, _, _, _ := s.retryConfig()
 
 	// OAuth/Setup Token 账号的 403：标记账号异常
 	if account.IsOAuth() && statusCode == 403 {
-		s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, resp.Header, body)
-		log.Printf("Account %d: marked as error after %d retries for status %d", account.ID, maxRetryAttempts, statusCode)
+		s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, resp.Header, body, requestedModel)
+		log.Printf("Account %d: marked as error after %d retries for status %d", account.ID, maxAttempts, statusCode)
 	} else {
 		// API Key 未配置错误码：不标记账号状态
-		log.Printf("Account %d: upstream error %d after %d retries (not marking account)", account.ID, statusCode, maxRetryAttempts)
+		log.Printf("Account %d: upstream error %d after %d retries (not marking account)", account.ID, statusCode, maxAttempts)
 	}
+	recordAccountOutcomeAndMaybeCooldown(ctx, s.successRateTracker, s.accountRepo, account, false)
 }
 
-func (s *GatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account) {
+func (s *GatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account, requestedModel string) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
-	s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+	s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body, requestedModel)
+	recordAccountOutcomeAndMaybeCooldown(ctx, s.successRateTracker, s.accountRepo, account, false)
 }
 
 // handleRetryExhaustedError 处理重试耗尽后的错误
 // OAuth 403：标记账号异常
 // API Key 未配置错误码：仅返回错误，不标记账号
-func (s *GatewayService) handleRetryExhaustedError(ctx context.Context, resp *http.Response, c *gin.Context, account *Account) (*ForwardResult, error) {
+func (s *GatewayService) handleRetryExhaustedError(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, originalModel, mappedModel string) (*ForwardResult, error) {
 	// Capture upstream error body before side-effects consume the stream.
 	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	_ = resp.Body.Close()
 	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	respBody = s.restoreOriginalModelInErrorBody(respBody, mappedModel, originalModel)
 
-	s.handleRetryExhaustedSideEffects(ctx, resp, account)
+	s.handleRetryExhaustedSideEffects(ctx, resp, account, mappedModel)
 
 	upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 	upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
@@ -4131,6 +5173,25 @@ type streamingResult struct {
 	clientDisconnect bool // 客户端是否在流式传输过程中断开
 }
 
+// writeStreamBlock 向客户端写入一个流式数据块。部分反向代理/网络环境下会出现一次性的
+// 瞬时写入错误，并非真实断开，因此首次写入失败时按配置延迟后重试一次；
+// 仅当重试也失败（或重试被禁用）时才判定为客户端断开。
+func (s *GatewayService) writeStreamBlock(w io.Writer, block string) bool {
+	if _, err := fmt.Fprint(w, block); err == nil {
+		return true
+	}
+	delay := defaultClientWriteRetryDelay
+	if s.cfg != nil {
+		delay = time.Duration(s.cfg.Gateway.ClientWriteRetryDelayMs) * time.Millisecond
+	}
+	if delay <= 0 {
+		return false
+	}
+	time.Sleep(delay)
+	_, err := fmt.Fprint(w, block)
+	return err == nil
+}
+
 func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, startTime time.Time, originalModel, mappedModel string, mimicClaudeCode bool) (*streamingResult, error) {
 	// 更新5h窗口状态
 	s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
@@ -4156,7 +5217,24 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 		return nil, errors.New("streaming not supported")
 	}
 
+	// 调试抄送：仅当全局启用且本次请求携带的 Token 匹配时才生效，异步写入，不影响客户端转发路径
+	var tee *streamTee
+	if s.cfg != nil && s.cfg.Gateway.StreamTee.Enabled && s.cfg.Gateway.StreamTee.Token != "" &&
+		c.GetHeader(s.cfg.Gateway.StreamTee.Header) == s.cfg.Gateway.StreamTee.Token {
+		tee = newStreamTee(s.cfg.Gateway.StreamTee.Dir, resp.Header.Get("x-request-id"))
+	}
+	if tee != nil {
+		defer tee.close()
+	}
+
 	usage := &ClaudeUsage{}
+	usageSeen := &sseUsageSeen{}
+	// 流结束（包括超时/客户端断开等提前返回路径）时做一次兜底校正：
+	// 以 message_start/message_delta 中观察到的最完整字段为准，
+	// 并记录差异日志，避免 GLM 等只在 message_delta 带完整 usage 的上游漏计费。
+	defer func() {
+		reconcileStreamUsage(usage, usageSeen, account.ID, originalModel)
+	}()
 	var firstTokenMs *int
 	scanner := bufio.NewScanner(resp.Body)
 	// 设置更大的buffer以处理长行
@@ -4164,6 +5242,10 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 	if s.cfg != nil && s.cfg.Gateway.MaxLineSize > 0 {
 		maxLineSize = s.cfg.Gateway.MaxLineSize
 	}
+	// 账号级覆盖优先于全局配置，用于应对个别上游（图片、超大工具输出）返回的超长行
+	if account.MaxLineSize > 0 {
+		maxLineSize = account.MaxLineSize
+	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
 	type scanEvent struct {
@@ -4223,9 +5305,47 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 		flusher.Flush()
 	}
 
+	// 流超时时优雅关闭：发送携带已收集 usage 的 message_delta（stop_reason=max_tokens）+
+	// message_stop，而不是 error 事件，使客户端已渲染的部分内容成为一条完整消息。
+	sendGracefulTimeoutStop := func() {
+		if errorEventSent {
+			return
+		}
+		errorEventSent = true
+		deltaEvent := map[string]any{
+			"type": "message_delta",
+			"delta": map[string]any{
+				"stop_reason":   "max_tokens",
+				"stop_sequence": nil,
+			},
+			"usage": usage,
+		}
+		if jsonBytes, err := json.Marshal(deltaEvent); err == nil {
+			_, _ = fmt.Fprintf(w, "event: message_delta\ndata: %s\n\n", jsonBytes)
+		}
+		_, _ = fmt.Fprint(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+		flusher.Flush()
+	}
+
 	needModelReplace := originalModel != mappedModel
 	clientDisconnected := false // 客户端断开标志，断开后继续读取上游以获取完整usage
 
+	// 分组级流式输出 token 硬上限：即使客户端请求的 max_tokens 更高，累计 output tokens
+	// 超出该上限后也提前终止上游转发（按已产生的用量计费），防止误配置客户端导致失控生成。
+	maxOutputTokens := maxOutputTokensFromContext(ctx)
+
+	// sawDeltaUsage 标记是否已经看到过携带 usage 字段的 message_delta 事件。
+	// 部分上游只在流结束时才给出完整 usage（甚至完全不在 message_delta 中携带），
+	// 但有些客户端专门从每个 message_delta.usage.output_tokens 里增量读取用量；
+	// 如果整条流都没有出现过这样的事件，在 message_stop 前补发一条携带累计 usage 的 message_delta。
+	sawDeltaUsage := false
+
+	// interimStreamUsage 开启时，在每个 content_block_stop 后补发一条携带目前为止累计
+	// usage 的 message_delta，供客户端实时更新 token 计数器。output_tokens 若上游尚未
+	// 报告，按已输出的 text/thinking 内容估算；默认关闭，不改变默认的流事件形状。
+	interimStreamUsage := s.cfg != nil && s.cfg.Gateway.InterimStreamUsage
+	var interimTextAccum strings.Builder
+
 	pendingEventLines := make([]string, 0, 4)
 
 	processSSEEvent := func(lines []string) ([]string, string, error) {
@@ -4290,6 +5410,17 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 		if eventType == "message_delta" {
 			if u, ok := event["usage"].(map[string]any); ok {
 				reconcileCachedTokens(u)
+				sawDeltaUsage = true
+			}
+		}
+
+		if interimStreamUsage && eventType == "content_block_delta" {
+			if delta, ok := event["delta"].(map[string]any); ok {
+				if text, ok := delta["text"].(string); ok {
+					interimTextAccum.WriteString(text)
+				} else if thinking, ok := delta["thinking"].(string); ok {
+					interimTextAccum.WriteString(thinking)
+				}
 			}
 		}
 
@@ -4334,6 +5465,30 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 			block = "event: " + eventName + "\n"
 		}
 		block += "data: " + string(newData) + "\n\n"
+
+		if interimStreamUsage && eventType == "content_block_stop" {
+			if interimBytes, err := buildInterimUsageDelta(*usage, interimTextAccum.String()); err == nil {
+				interimBlock := "event: message_delta\ndata: " + string(interimBytes) + "\n\n"
+				return []string{interimBlock, block}, string(newData), nil
+			}
+		}
+
+		if eventType == "message_stop" && !sawDeltaUsage {
+			sawDeltaUsage = true
+			syntheticDelta := map[string]any{
+				"type": "message_delta",
+				"delta": map[string]any{
+					"stop_reason":   "end_turn",
+					"stop_sequence": nil,
+				},
+				"usage": usage,
+			}
+			if syntheticBytes, err := json.Marshal(syntheticDelta); err == nil {
+				syntheticBlock := "event: message_delta\ndata: " + string(syntheticBytes) + "\n\n"
+				return []string{syntheticBlock, block}, string(newData), nil
+			}
+		}
+
 		return []string{block}, string(newData), nil
 	}
 
@@ -4365,6 +5520,7 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 				return &streamingResult{usage: usage, firstTokenMs: firstTokenMs}, fmt.Errorf("stream read error: %w", ev.err)
 			}
 			line := ev.line
+			tee.write(line + "\n")
 			trimmed := strings.TrimSpace(line)
 
 			if trimmed == "" {
@@ -4378,12 +5534,15 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 					if clientDisconnected {
 						return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
 					}
-					return nil, err
+					// 上游在流中途发送了 event: error（data 为其原始错误体），
+					// 保留该错误体，以便上层据此构造携带真实错误类型/消息的 Anthropic 错误信封，
+					// 而不是丢弃具体错误信息、笼统地当成 403 处理。
+					return nil, &midStreamUpstreamError{body: []byte(data)}
 				}
 
 				for _, block := range outputBlocks {
 					if !clientDisconnected {
-						if _, werr := fmt.Fprint(w, block); werr != nil {
+						if !s.writeStreamBlock(w, block) {
 							clientDisconnected = true
 							log.Printf("Client disconnected during streaming, continuing to drain upstream for billing")
 							break
@@ -4395,9 +5554,15 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 							ms := int(time.Since(startTime).Milliseconds())
 							firstTokenMs = &ms
 						}
-						s.parseSSEUsage(data, usage)
+						s.parseSSEUsage(data, usage, usageSeen)
 					}
 				}
+
+				if maxOutputTokens > 0 && usage.OutputTokens >= maxOutputTokens {
+					log.Printf("Group output token cap reached: account=%d model=%s cap=%d output_tokens=%d", account.ID, originalModel, maxOutputTokens, usage.OutputTokens)
+					sendGracefulTimeoutStop()
+					return &streamingResult{usage: usage, firstTokenMs: firstTokenMs}, nil
+				}
 				continue
 			}
 
@@ -4418,6 +5583,10 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 			if s.rateLimitService != nil {
 				s.rateLimitService.HandleStreamTimeout(ctx, account, originalModel)
 			}
+			if s.cfg != nil && s.cfg.Gateway.GracefulStreamTimeout {
+				sendGracefulTimeoutStop()
+				return &streamingResult{usage: usage, firstTokenMs: firstTokenMs}, nil
+			}
 			sendErrorEvent("stream_timeout")
 			return &streamingResult{usage: usage, firstTokenMs: firstTokenMs}, fmt.Errorf("stream data interval timeout")
 		}
@@ -4425,7 +5594,14 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 
 }
 
-func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
+// sseUsageSeen 记录流式响应中 message_start/message_delta 各自携带的最后一份 usage 快照，
+// 供流结束时的 reconcileStreamUsage 做兜底校正（部分上游如 GLM 只在 message_delta 中给出完整 usage）。
+type sseUsageSeen struct {
+	start *ClaudeUsage
+	delta *ClaudeUsage
+}
+
+func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage, seen *sseUsageSeen) {
 	// 解析message_start获取input tokens（标准Claude API格式）
 	var msgStart struct {
 		Type    string `json:"type"`
@@ -4445,6 +5621,11 @@ func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
 			usage.CacheCreation5mTokens = int(cc5m.Int())
 			usage.CacheCreation1hTokens = int(cc1h.Int())
 		}
+
+		if seen != nil {
+			snapshot := *usage
+			seen.start = &snapshot
+		}
 	}
 
 	// 解析message_delta获取tokens（兼容GLM等把所有usage放在delta中的API）
@@ -4481,6 +5662,47 @@ func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
 			usage.CacheCreation5mTokens = int(cc5m.Int())
 			usage.CacheCreation1hTokens = int(cc1h.Int())
 		}
+
+		if seen != nil {
+			snapshot := *usage
+			seen.delta = &snapshot
+		}
+	}
+}
+
+// reconcileStreamUsage 在流结束时做最终校正：对每个字段取 message_start/message_delta
+// 快照与当前已合并 usage 之间的最大值，防止任一事件中出现的 0 值覆盖掉另一方已给出的数据；
+// 当校正前后出现差异时记录日志，便于追踪计费准确性问题。
+func reconcileStreamUsage(usage *ClaudeUsage, seen *sseUsageSeen, accountID int64, model string) {
+	if usage == nil || seen == nil {
+		return
+	}
+	before := *usage
+	maxField := func(v int, others ...int) int {
+		for _, o := range others {
+			if o > v {
+				v = o
+			}
+		}
+		return v
+	}
+	var startVals, deltaVals ClaudeUsage
+	if seen.start != nil {
+		startVals = *seen.start
+	}
+	if seen.delta != nil {
+		deltaVals = *seen.delta
+	}
+
+	usage.InputTokens = maxField(usage.InputTokens, startVals.InputTokens, deltaVals.InputTokens)
+	usage.OutputTokens = maxField(usage.OutputTokens, startVals.OutputTokens, deltaVals.OutputTokens)
+	usage.CacheCreationInputTokens = maxField(usage.CacheCreationInputTokens, startVals.CacheCreationInputTokens, deltaVals.CacheCreationInputTokens)
+	usage.CacheReadInputTokens = maxField(usage.CacheReadInputTokens, startVals.CacheReadInputTokens, deltaVals.CacheReadInputTokens)
+	usage.CacheCreation5mTokens = maxField(usage.CacheCreation5mTokens, startVals.CacheCreation5mTokens, deltaVals.CacheCreation5mTokens)
+	usage.CacheCreation1hTokens = maxField(usage.CacheCreation1hTokens, startVals.CacheCreation1hTokens, deltaVals.CacheCreation1hTokens)
+
+	if *usage != before {
+		log.Printf("Stream usage reconciliation corrected final usage: account=%d model=%s before=%+v after=%+v", accountID, model, before, *usage)
 	}
 }
 
@@ -4540,11 +5762,33 @@ func (s *GatewayService) handleNonStreamingResponse(ctx context.Context, resp *h
 	// 更新5h窗口状态
 	s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
 
-	body, err := io.ReadAll(resp.Body)
+	// 部分上游（例如某些 Anthropic 兼容网关）即使客户端请求 stream=false，仍然会返回
+	// text/event-stream 响应。此时需要把 SSE 流聚合为一条完整的非流式 JSON 消息，
+	// 而不是把 SSE 原样透传给期望 JSON 响应体的客户端。
+	wasEventStream := isEventStreamContentType(resp.Header.Get("Content-Type"))
+
+	var body []byte
+	var err error
+	decodedGzip := false
+	if wasEventStream {
+		body, err = aggregateAnthropicSSEToJSON(resp.Body)
+	} else {
+		body, err = io.ReadAll(resp.Body)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// 部分上游（或其前置代理）会在 Content-Encoding: gzip 的同时返回已经被 Transport
+	// 透明解压的明文 body，也有个别场景会保留原始 gzip 字节。按 magic bytes 而非仅凭
+	// header 判断，避免漏判；仅在显式开启时才生效，默认保持原有透传行为不变。
+	if !wasEventStream && s.cfg != nil && s.cfg.Gateway.DecodeUpstreamGzipNonStreaming {
+		if decoded, ok := decodeGzipBody(body); ok {
+			body = decoded
+			decodedGzip = true
+		}
+	}
+
 	// 解析usage
 	var response struct {
 		Usage ClaudeUsage `json:"usage"`
@@ -4572,6 +5816,15 @@ func (s *GatewayService) handleNonStreamingResponse(ctx context.Context, resp *h
 		}
 	}
 
+	// 极少数上游（尤其是被截断为 max_tokens 的响应）可能完全不返回 usage.output_tokens。
+	// 此时按已生成内容的文本长度保守估算，避免 0 输出 token 导致漏计费；
+	// 该估算只用于计费，不回写响应体，客户端仍看到上游原始 usage 字段。
+	if response.Usage.OutputTokens <= 0 {
+		if estimated := estimateOutputTokensFromContentBody(body); estimated > 0 {
+			response.Usage.OutputTokens = estimated
+		}
+	}
+
 	// Cache TTL Override: 重写 non-streaming 响应中的 cache_creation 分类
 	if account.IsCacheTTLOverrideEnabled() {
 		overrideTarget := account.GetCacheTTLOverrideTarget()
@@ -4592,13 +5845,22 @@ func (s *GatewayService) handleNonStreamingResponse(ctx context.Context, resp *h
 	}
 
 	responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+	if decodedGzip {
+		// body 已被解压为明文，若继续透传 Content-Encoding: gzip，客户端会按 gzip 字节
+		// 再解一次压，导致收到损坏数据；拿到的既是明文字节就必须去掉该 header
+		c.Writer.Header().Del("Content-Encoding")
+	}
 
 	contentType := "application/json"
-	if s.cfg != nil && !s.cfg.Security.ResponseHeaders.Enabled {
+	if s.cfg != nil && !s.cfg.Security.ResponseHeaders.Enabled && !wasEventStream {
 		if upstreamType := resp.Header.Get("Content-Type"); upstreamType != "" {
 			contentType = upstreamType
 		}
 	}
+	if wasEventStream {
+		// 已被聚合为 JSON，强制覆盖从上游透传过来的 text/event-stream 头
+		c.Writer.Header().Set("Content-Type", contentType)
+	}
 
 	// 写入响应
 	c.Data(resp.StatusCode, contentType, body)
@@ -4606,6 +5868,199 @@ func (s *GatewayService) handleNonStreamingResponse(ctx context.Context, resp *h
 	return &response.Usage, nil
 }
 
+// isEventStreamContentType 判断响应的 Content-Type 是否为 SSE（text/event-stream）
+func isEventStreamContentType(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "text/event-stream")
+}
+
+// decodeGzipBody 按 gzip magic bytes（而非仅依赖 Content-Encoding header，因为部分上游/
+// 代理会在已被 Transport 透明解压后仍残留该 header）判断 body 是否为 gzip 压缩数据，
+// 是则解压返回；不是或解压失败则原样返回 body 与 false。
+func decodeGzipBody(body []byte) ([]byte, bool) {
+	if len(body) < 2 || body[0] != 0x1f || body[1] != 0x8b {
+		return body, false
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body, false
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return body, false
+	}
+	return decoded, true
+}
+
+// estimateOutputTokensFromContentBody 在上游完全没有返回 usage.output_tokens 时
+// （常见于 max_tokens 截断响应），按 content 数组中 text/thinking 块的文本长度
+// 保守估算输出 token 数，避免按 0 计费漏算费用。
+func estimateOutputTokensFromContentBody(body []byte) int {
+	total := 0
+	for _, block := range gjson.GetBytes(body, "content").Array() {
+		if text := block.Get("text"); text.Exists() {
+			total += estimateTokensForText(text.String())
+		}
+		if thinking := block.Get("thinking"); thinking.Exists() {
+			total += estimateTokensForText(thinking.String())
+		}
+	}
+	return total
+}
+
+// buildInterimUsageDelta 构造一条携带当前累计 usage 的 message_delta 事件 JSON，
+// 在启用 gateway.interim_stream_usage 时于每个 content_block_stop 后补发给客户端，
+// 供其实时更新 token 计数器。output_tokens 取已报告的 usage 与按 accumulatedText
+// （截至目前已输出的 text/thinking 内容）估算值中的较大者，避免上游尚未报告时显示为 0。
+// delta 字段留空（不含 stop_reason），避免客户端误判流已经结束。
+func buildInterimUsageDelta(usage ClaudeUsage, accumulatedText string) ([]byte, error) {
+	if textEstimate := estimateTokensForText(accumulatedText); textEstimate > usage.OutputTokens {
+		usage.OutputTokens = textEstimate
+	}
+	return json.Marshal(map[string]any{
+		"type":  "message_delta",
+		"delta": map[string]any{},
+		"usage": usage,
+	})
+}
+
+// aggregateAnthropicSSEToJSON 将 Anthropic 格式的 SSE 事件流聚合为完整的非流式
+// Messages API JSON 响应体，用于兼容那些对 stream=false 请求仍返回 SSE 的上游。
+func aggregateAnthropicSSEToJSON(r io.Reader) ([]byte, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), defaultMaxLineSize)
+
+	var message map[string]any
+	var contentBlocks []map[string]any
+
+	applyEvent := func(eventType string, data []byte) {
+		switch eventType {
+		case "message_start":
+			var payload struct {
+				Message map[string]any `json:"message"`
+			}
+			if json.Unmarshal(data, &payload) == nil && payload.Message != nil {
+				message = payload.Message
+			}
+		case "content_block_start":
+			var payload struct {
+				Index        int            `json:"index"`
+				ContentBlock map[string]any `json:"content_block"`
+			}
+			if json.Unmarshal(data, &payload) == nil {
+				for len(contentBlocks) <= payload.Index {
+					contentBlocks = append(contentBlocks, map[string]any{})
+				}
+				contentBlocks[payload.Index] = payload.ContentBlock
+			}
+		case "content_block_delta":
+			var payload struct {
+				Index int            `json:"index"`
+				Delta map[string]any `json:"delta"`
+			}
+			if json.Unmarshal(data, &payload) == nil && payload.Index >= 0 && payload.Index < len(contentBlocks) {
+				applyContentBlockDelta(contentBlocks[payload.Index], payload.Delta)
+			}
+		case "message_delta":
+			var payload struct {
+				Delta map[string]any `json:"delta"`
+				Usage map[string]any `json:"usage"`
+			}
+			if json.Unmarshal(data, &payload) == nil && message != nil {
+				for k, v := range payload.Delta {
+					message[k] = v
+				}
+				if payload.Usage != nil {
+					if existing, ok := message["usage"].(map[string]any); ok {
+						for k, v := range payload.Usage {
+							existing[k] = v
+						}
+					} else {
+						message["usage"] = payload.Usage
+					}
+				}
+			}
+		}
+	}
+
+	var currentEvent string
+	var dataLines []string
+	flush := func() {
+		if currentEvent != "" && len(dataLines) > 0 {
+			applyEvent(currentEvent, []byte(strings.Join(dataLines, "\n")))
+		}
+		currentEvent = ""
+		dataLines = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			flush()
+			currentEvent = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		case line == "":
+			flush()
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if message == nil {
+		return nil, fmt.Errorf("aggregate sse: no message_start event found")
+	}
+
+	message["content"] = finalizeContentBlocks(contentBlocks)
+	return json.Marshal(message)
+}
+
+// applyContentBlockDelta 将单个 content_block_delta 的增量合并进正在累积的内容块
+func applyContentBlockDelta(block map[string]any, delta map[string]any) {
+	deltaType, _ := delta["type"].(string)
+	switch deltaType {
+	case "text_delta":
+		text, _ := block["text"].(string)
+		block["text"] = text + stringField(delta, "text")
+	case "input_json_delta":
+		partial, _ := block["_partial_json"].(string)
+		block["_partial_json"] = partial + stringField(delta, "partial_json")
+	case "thinking_delta":
+		thinking, _ := block["thinking"].(string)
+		block["thinking"] = thinking + stringField(delta, "thinking")
+	case "signature_delta":
+		block["signature"] = stringField(delta, "signature")
+	}
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// finalizeContentBlocks 将累积的 tool_use 输入 JSON 片段解析为最终的 input 对象
+func finalizeContentBlocks(blocks []map[string]any) []map[string]any {
+	result := make([]map[string]any, 0, len(blocks))
+	for _, block := range blocks {
+		if partial, ok := block["_partial_json"]; ok {
+			delete(block, "_partial_json")
+			var input any
+			if s, _ := partial.(string); s != "" {
+				_ = json.Unmarshal([]byte(s), &input)
+			}
+			if input == nil {
+				input = map[string]any{}
+			}
+			block["input"] = input
+		}
+		result = append(result, block)
+	}
+	return result
+}
+
 // replaceModelInResponseBody 替换响应体中的model字段
 func (s *GatewayService) replaceModelInResponseBody(body []byte, fromModel, toModel string) []byte {
 	var resp map[string]any
@@ -4627,6 +6082,20 @@ func (s *GatewayService) replaceModelInResponseBody(body []byte, fromModel, toMo
 	return newBody
 }
 
+// restoreOriginalModelInErrorBody 将上游错误响应体中出现的映射后模型名替换回客户端原始请求的模型名，
+// 确保 failover 耗尽后透传给客户端/写入日志的错误信息里看到的是自己传入的模型，而不是账号内部映射的结果。
+// 错误响应体不一定是规整的 JSON（可能是纯文本或嵌套错误消息），因此这里做原始字节替换而非
+// 像 replaceModelInResponseBody 那样只替换顶层 model 字段。
+func (s *GatewayService) restoreOriginalModelInErrorBody(body []byte, mappedModel, originalModel string) []byte {
+	if mappedModel == "" || originalModel == "" || mappedModel == originalModel || len(body) == 0 {
+		return body
+	}
+	if !bytes.Contains(body, []byte(mappedModel)) {
+		return body
+	}
+	return bytes.ReplaceAll(body, []byte(mappedModel), []byte(originalModel))
+}
+
 // RecordUsageInput 记录使用量的输入参数
 type RecordUsageInput struct {
 	Result            *ForwardResult
@@ -4645,6 +6114,17 @@ type APIKeyQuotaUpdater interface {
 	UpdateQuotaUsed(ctx context.Context, apiKeyID int64, cost float64) error
 }
 
+// subscriptionOverflowsToBalance 判断本次请求的费用是否会打满订阅的日/周/月限额。
+// 仅当分组配置为 SubscriptionOverflowPolicyFallbackBalance 时才生效；
+// 返回 true 表示本次请求应改为从用户余额扣费，而不是计入订阅用量。
+func subscriptionOverflowsToBalance(group *Group, subscription *UserSubscription, additionalCost float64) bool {
+	if group == nil || subscription == nil || !group.FallsBackToBalanceOnOverflow() {
+		return false
+	}
+	daily, weekly, monthly := subscription.CheckAllLimits(group, additionalCost)
+	return !daily || !weekly || !monthly
+}
+
 // RecordUsage 记录使用量并扣费（或更新订阅用量）
 func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInput) error {
 	result := input.Result
@@ -4716,6 +6196,10 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 
 	// 判断计费方式：订阅模式 vs 余额模式
 	isSubscriptionBilling := subscription != nil && apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
+	if isSubscriptionBilling && subscriptionOverflowsToBalance(apiKey.Group, subscription, cost.TotalCost) {
+		// 本次请求将打满订阅限额，且分组配置为用尽后回退余额：改为余额计费
+		isSubscriptionBilling = false
+	}
 	billingType := BillingTypeBalance
 	if isSubscriptionBilling {
 		billingType = BillingTypeSubscription
@@ -4776,6 +6260,11 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		usageLog.SubscriptionID = &subscription.ID
 	}
 
+	// 添加计费标签（x-sub2api-tag）
+	if tag, ok := ctx.Value(ctxkey.RequestBillingTag).(string); ok && tag != "" {
+		usageLog.Tag = &tag
+	}
+
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if err != nil {
 		log.Printf("Create usage log failed: %v", err)
@@ -4969,6 +6458,11 @@ func (s *GatewayService) RecordUsageWithLongContext(ctx context.Context, input *
 		usageLog.SubscriptionID = &subscription.ID
 	}
 
+	// 添加计费标签（x-sub2api-tag）
+	if tag, ok := ctx.Value(ctxkey.RequestBillingTag).(string); ok && tag != "" {
+		usageLog.Tag = &tag
+	}
+
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if err != nil {
 		log.Printf("Create usage log failed: %v", err)
@@ -5030,13 +6524,17 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 	shouldMimicClaudeCode := account.IsOAuth() && !isClaudeCode
 
 	if shouldMimicClaudeCode {
-		normalizeOpts := claudeOAuthNormalizeOptions{stripSystemCacheControl: true}
+		normalizeOpts := claudeOAuthNormalizeOptions{stripSystemCacheControl: !account.IsSystemCacheControlKept()}
 		body, reqModel = normalizeClaudeOAuthRequestBody(body, reqModel, normalizeOpts)
 	}
 
 	// Antigravity 账户不支持 count_tokens 转发，直接返回空值
 	if account.Platform == PlatformAntigravity {
-		c.JSON(http.StatusOK, gin.H{"input_tokens": 0})
+		resp := gin.H{"input_tokens": 0}
+		if s.cfg != nil && s.cfg.Gateway.IncludeModelInCountTokensResponse {
+			resp["model"] = reqModel
+		}
+		c.JSON(http.StatusOK, resp)
 		return nil
 	}
 
@@ -5076,7 +6574,11 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 	// 构建上游请求
 	upstreamReq, err := s.buildCountTokensRequest(ctx, c, account, body, token, tokenType, reqModel, shouldMimicClaudeCode)
 	if err != nil {
-		s.countTokensError(c, http.StatusInternalServerError, "api_error", "Failed to build request")
+		if errors.Is(err, ErrAnthropicVersionRequired) {
+			s.countTokensError(c, http.StatusBadRequest, "invalid_request_error", "anthropic-version header is required")
+		} else {
+			s.countTokensError(c, http.StatusInternalServerError, "api_error", "Failed to build request")
+		}
 		return err
 	}
 
@@ -5122,10 +6624,59 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 		}
 	}
 
+	// 对 5xx/429 等临时性错误做有限重试（退避策略与 Forward 一致），避免瞬时抖动直接失败
+	maxAttempts, retryBaseDelay, retryMaxDelay, maxRetryElapsed := s.retryConfig()
+	retryStart := time.Now()
+	for attempt := 1; resp.StatusCode >= 400 && resp.StatusCode != 400 && s.shouldRetryUpstreamError(account, resp.StatusCode) && attempt < maxAttempts; attempt++ {
+		elapsed := time.Since(retryStart)
+		if elapsed >= maxRetryElapsed {
+			break
+		}
+		delay := retryBackoffDelay(attempt, retryBaseDelay, retryMaxDelay)
+		if remaining := maxRetryElapsed - elapsed; delay > remaining {
+			delay = remaining
+		}
+		if delay <= 0 {
+			break
+		}
+
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: resp.StatusCode,
+			UpstreamRequestID:  resp.Header.Get("x-request-id"),
+			Kind:               "retry",
+			Message:            extractUpstreamErrorMessage(respBody),
+		})
+		log.Printf("Account %d: count_tokens upstream error %d, retry %d/%d after %v (elapsed=%v/%v)",
+			account.ID, resp.StatusCode, attempt, maxAttempts, delay, elapsed, maxRetryElapsed)
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+
+		retryReq, buildErr := s.buildCountTokensRequest(ctx, c, account, body, token, tokenType, reqModel, shouldMimicClaudeCode)
+		if buildErr != nil {
+			break
+		}
+		retryResp, retryErr := s.httpUpstream.DoWithTLS(retryReq, proxyURL, account.ID, account.Concurrency, account.IsTLSFingerprintEnabled())
+		if retryErr != nil {
+			setOpsUpstreamError(c, 0, sanitizeUpstreamErrorMessage(retryErr.Error()), "")
+			break
+		}
+		resp = retryResp
+		respBody, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			s.countTokensError(c, http.StatusBadGateway, "upstream_error", "Failed to read response")
+			return err
+		}
+	}
+
 	// 处理错误响应
 	if resp.StatusCode >= 400 {
 		// 标记账号状态（429/529等）
-		s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, respBody)
+		s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, respBody, reqModel)
 
 		upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 		upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
@@ -5151,6 +6702,20 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 			)
 		}
 
+		// 重试耗尽后，失败转移类状态码交由调用方切换账号重试；不在此处写响应
+		if s.shouldRetryUpstreamError(account, resp.StatusCode) && s.shouldFailoverUpstreamError(resp.StatusCode) {
+			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+				Platform:           account.Platform,
+				AccountID:          account.ID,
+				AccountName:        account.Name,
+				UpstreamStatusCode: resp.StatusCode,
+				UpstreamRequestID:  resp.Header.Get("x-request-id"),
+				Kind:               "retry_exhausted_failover",
+				Message:            upstreamMsg,
+			})
+			return &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: respBody}
+		}
+
 		// 返回简化的错误响应
 		errMsg := "Upstream request failed"
 		switch resp.StatusCode {
@@ -5166,7 +6731,12 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 		return fmt.Errorf("upstream error: %d message=%s", resp.StatusCode, upstreamMsg)
 	}
 
-	// 透传成功响应
+	// 透传成功响应，按需附加解析后（映射/补全后）的 model 字段
+	if s.cfg != nil && s.cfg.Gateway.IncludeModelInCountTokensResponse && reqModel != "" {
+		if withModel, err := sjson.SetBytes(respBody, "model", reqModel); err == nil {
+			respBody = withModel
+		}
+	}
 	c.Data(resp.StatusCode, "application/json", respBody)
 	return nil
 }
@@ -5197,7 +6767,7 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 		fp, err := s.identityService.GetOrCreateFingerprint(ctx, account.ID, clientHeaders)
 		if err == nil {
 			accountUUID := account.GetExtraString("account_uuid")
-			if accountUUID != "" && fp.ClientID != "" {
+			if accountUUID != "" && fp.ClientID != "" && !metadataRewriteDisabled(ctx) {
 				if newBody, err := s.identityService.RewriteUserIDWithMasking(ctx, body, account, accountUUID, fp.ClientID); err == nil && len(newBody) > 0 {
 					body = newBody
 				}
@@ -5226,6 +6796,7 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 			}
 		}
 	}
+	s.ensureUpstreamTraceHeaders(req)
 
 	// OAuth 账号：应用指纹到请求头
 	if account.IsOAuth() && s.identityService != nil {
@@ -5240,6 +6811,9 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 		req.Header.Set("content-type", "application/json")
 	}
 	if req.Header.Get("anthropic-version") == "" {
+		if account.IsAnthropicVersionRequired() {
+			return nil, ErrAnthropicVersionRequired
+		}
 		req.Header.Set("anthropic-version", "2023-06-01")
 	}
 	if tokenType == "oauth" {
@@ -5254,7 +6828,7 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 			incomingBeta := req.Header.Get("anthropic-beta")
 			requiredBetas := []string{claude.BetaClaudeCode, claude.BetaOAuth, claude.BetaInterleavedThinking, claude.BetaTokenCounting}
 			drop := map[string]struct{}{claude.BetaContext1M: {}}
-			req.Header.Set("anthropic-beta", mergeAnthropicBetaDropping(requiredBetas, incomingBeta, drop))
+			req.Header.Set("anthropic-beta", mergeAnthropicBetaDropping(requiredBetas, incomingBeta, drop, s.maxAnthropicBetaHeaderLength()))
 		} else {
 			clientBetaHeader := req.Header.Get("anthropic-beta")
 			if clientBetaHeader == "" {
@@ -5276,10 +6850,17 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 		}
 	}
 
+	// 账号级 beta 黑名单：在合并/补齐之后统一剔除
+	if blacklist := account.GetAnthropicBetaBlacklist(); len(blacklist) > 0 {
+		if current := req.Header.Get("anthropic-beta"); current != "" {
+			req.Header.Set("anthropic-beta", dropAnthropicBetas(current, blacklist))
+		}
+	}
+
 	if c != nil && tokenType == "oauth" {
 		c.Set(claudeMimicDebugInfoKey, buildClaudeMimicDebugLine(req, body, account, tokenType, mimicClaudeCode))
 	}
-	if s.debugClaudeMimicEnabled() {
+	if s.shouldLogClaudeMimicDebug(account) {
 		logClaudeMimicDebug(req, body, account, tokenType, mimicClaudeCode)
 	}
 
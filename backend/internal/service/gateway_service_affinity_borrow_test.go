@@ -0,0 +1,131 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayService_affinityBorrowPeers_NoAffinityGroupReturnsNil(t *testing.T) {
+	svc := &GatewayService{}
+	account := &Account{ID: 1}
+	accounts := []Account{{ID: 1}, {ID: 2, AffinityGroup: "us-east"}}
+
+	require.Nil(t, svc.affinityBorrowPeers(account, accounts))
+}
+
+func TestGatewayService_affinityBorrowPeers_ExcludesSelfAndOtherGroups(t *testing.T) {
+	svc := &GatewayService{}
+	account := &Account{ID: 1, AffinityGroup: "us-east", Concurrency: 5}
+	accounts := []Account{
+		{ID: 1, AffinityGroup: "us-east", Concurrency: 5},
+		{ID: 2, AffinityGroup: "us-east", Concurrency: 3},
+		{ID: 3, AffinityGroup: "eu-west", Concurrency: 10},
+	}
+
+	peers := svc.affinityBorrowPeers(account, accounts)
+
+	require.Len(t, peers, 1)
+	require.Equal(t, int64(2), peers[0].ID)
+	require.Equal(t, 3, peers[0].MaxConcurrency)
+}
+
+func TestGatewayService_tryAcquireAccountSlot_BorrowsFromAffinityPeerWhenSaturatedAndEnabled(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 1},
+	}
+	cfg := testConfig()
+	cfg.Concurrency.AffinityBorrowEnabled = true
+	cfg.Concurrency.AffinityBorrowMaxSlots = 2
+
+	svc := &GatewayService{
+		cfg:                cfg,
+		concurrencyService: NewConcurrencyService(cache),
+	}
+
+	account := &Account{ID: 1, AffinityGroup: "us-east", Concurrency: 5}
+	accounts := []Account{*account, {ID: 2, AffinityGroup: "us-east", Concurrency: 5}}
+
+	result, err := svc.tryAcquireAccountSlot(context.Background(), account, accounts, nil)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.Equal(t, []int64{2}, cache.borrowCalls)
+}
+
+func TestGatewayService_tryAcquireAccountSlot_DoesNotBorrowWhenDisabled(t *testing.T) {
+	cache := &borrowFakeCache{
+		accountSaturated: map[int64]bool{1: true},
+		lenderCapacity:   map[int64]int{2: 1},
+	}
+	cfg := testConfig() // AffinityBorrowEnabled defaults to false
+
+	svc := &GatewayService{
+		cfg:                cfg,
+		concurrencyService: NewConcurrencyService(cache),
+	}
+
+	account := &Account{ID: 1, AffinityGroup: "us-east", Concurrency: 5}
+	accounts := []Account{*account, {ID: 2, AffinityGroup: "us-east", Concurrency: 5}}
+
+	result, err := svc.tryAcquireAccountSlot(context.Background(), account, accounts, nil)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+	require.Empty(t, cache.borrowCalls)
+}
+
+func TestGatewayService_accountGroupReservations_IgnoresUnreservedGroups(t *testing.T) {
+	account := &Account{
+		ID: 1,
+		AccountGroups: []AccountGroup{
+			{GroupID: 1, ReservedSlots: 0},
+			{GroupID: 2, ReservedSlots: 3},
+		},
+	}
+
+	reservations := accountGroupReservations(account)
+
+	require.Equal(t, []ReservedGroupSlots{{GroupID: 2, ReservedSlots: 3}}, reservations)
+}
+
+func TestGatewayService_accountGroupReservations_NilAccountOrNoGroupsReturnsNil(t *testing.T) {
+	require.Nil(t, accountGroupReservations(nil))
+	require.Nil(t, accountGroupReservations(&Account{ID: 1}))
+}
+
+func TestGatewayService_tryAcquireAccountSlot_NonReservedGroupCannotConsumeReservedSlots(t *testing.T) {
+	cache := &accountSlotCapFakeCache{}
+	svc := &GatewayService{
+		cfg:                testConfig(),
+		concurrencyService: NewConcurrencyService(cache),
+	}
+
+	// Account has 5 total slots; group 1 has reserved 2 of them for itself.
+	account := &Account{
+		ID:          1,
+		Concurrency: 5,
+		AccountGroups: []AccountGroup{
+			{GroupID: 1, ReservedSlots: 2},
+		},
+	}
+	otherGroupID := int64(2)
+
+	var results []*AcquireResult
+	for i := 0; i < 3; i++ {
+		result, err := svc.tryAcquireAccountSlot(context.Background(), account, []Account{*account}, &otherGroupID)
+		require.NoError(t, err)
+		require.True(t, result.Acquired, "shared slot %d should be available", i)
+		results = append(results, result)
+	}
+
+	result, err := svc.tryAcquireAccountSlot(context.Background(), account, []Account{*account}, &otherGroupID)
+	require.NoError(t, err)
+	require.False(t, result.Acquired, "non-reserved group must not be able to consume reserved slots")
+
+	for _, r := range results {
+		r.ReleaseFunc()
+	}
+}
@@ -0,0 +1,126 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_SupportsVision_DefaultsToTrueWhenUnset(t *testing.T) {
+	require.True(t, (&Account{}).SupportsVision())
+	require.True(t, (&Account{Credentials: map[string]any{}}).SupportsVision())
+	require.True(t, (&Account{Credentials: map[string]any{"supports_vision": "not-a-bool"}}).SupportsVision())
+}
+
+func TestAccount_SupportsVision_HonorsExplicitFalse(t *testing.T) {
+	account := &Account{Credentials: map[string]any{"supports_vision": false}}
+	require.False(t, account.SupportsVision())
+}
+
+func TestAccount_SupportsTools_DefaultsToTrueWhenUnset(t *testing.T) {
+	require.True(t, (&Account{}).SupportsTools())
+	require.True(t, (&Account{Credentials: map[string]any{}}).SupportsTools())
+}
+
+func TestAccount_SupportsTools_HonorsExplicitFalse(t *testing.T) {
+	account := &Account{Credentials: map[string]any{"supports_tools": false}}
+	require.False(t, account.SupportsTools())
+}
+
+func TestAccountSatisfiesRequiredCapabilities_NoRequirementsAllowsAnyAccount(t *testing.T) {
+	account := &Account{Credentials: map[string]any{"supports_vision": false, "supports_tools": false}}
+	require.True(t, accountSatisfiesRequiredCapabilities(context.Background(), account))
+}
+
+func TestAccountSatisfiesRequiredCapabilities_RequiresVisionRejectsNonVisionAccount(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxkey.RequiresVision, true)
+
+	nonVisionAccount := &Account{Credentials: map[string]any{"supports_vision": false}}
+	require.False(t, accountSatisfiesRequiredCapabilities(ctx, nonVisionAccount))
+
+	visionAccount := &Account{Credentials: map[string]any{"supports_vision": true}}
+	require.True(t, accountSatisfiesRequiredCapabilities(ctx, visionAccount))
+
+	defaultAccount := &Account{}
+	require.True(t, accountSatisfiesRequiredCapabilities(ctx, defaultAccount))
+}
+
+func TestAccountSatisfiesRequiredCapabilities_RequiresToolsRejectsNonToolsAccount(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxkey.RequiresTools, true)
+
+	nonToolsAccount := &Account{Credentials: map[string]any{"supports_tools": false}}
+	require.False(t, accountSatisfiesRequiredCapabilities(ctx, nonToolsAccount))
+
+	toolsAccount := &Account{Credentials: map[string]any{"supports_tools": true}}
+	require.True(t, accountSatisfiesRequiredCapabilities(ctx, toolsAccount))
+}
+
+func TestGatewayService_loadAwareCandidates_FiltersOutNonVisionAccountsWhenVisionRequired(t *testing.T) {
+	svc := &GatewayService{}
+	ctx := context.WithValue(context.Background(), ctxkey.RequiresVision, true)
+
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true},
+		{ID: 2, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true, Credentials: map[string]any{"supports_vision": false}},
+	}
+
+	candidates, featureMismatch := svc.loadAwareCandidates(ctx, accounts, func(int64) bool { return false }, PlatformAnthropic, false, "")
+
+	require.Len(t, candidates, 1)
+	require.Equal(t, int64(1), candidates[0].ID)
+	require.True(t, featureMismatch)
+}
+
+func TestAccount_SupportsExtendedThinking_DefaultsToTrueWhenUnset(t *testing.T) {
+	require.True(t, (&Account{}).SupportsExtendedThinking())
+	require.True(t, (&Account{Credentials: map[string]any{}}).SupportsExtendedThinking())
+}
+
+func TestAccount_SupportsExtendedThinking_HonorsExplicitFalse(t *testing.T) {
+	account := &Account{Credentials: map[string]any{"supports_thinking": false}}
+	require.False(t, account.SupportsExtendedThinking())
+}
+
+func TestAccountSatisfiesRequiredCapabilities_ThinkingRejectsNonThinkingAccount(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxkey.ThinkingEnabled, true)
+
+	nonThinkingAccount := &Account{Credentials: map[string]any{"supports_thinking": false}}
+	require.False(t, accountSatisfiesRequiredCapabilities(ctx, nonThinkingAccount))
+
+	thinkingAccount := &Account{Credentials: map[string]any{"supports_thinking": true}}
+	require.True(t, accountSatisfiesRequiredCapabilities(ctx, thinkingAccount))
+
+	defaultAccount := &Account{}
+	require.True(t, accountSatisfiesRequiredCapabilities(ctx, defaultAccount))
+}
+
+func TestGatewayService_SelectAccountWithLoadAwareness_ReturnsFeatureUnsupportedForThinkingRequest(t *testing.T) {
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Credentials: map[string]any{"supports_thinking": false}},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+
+	cfg := testConfig()
+	cfg.Gateway.Scheduling.LoadBatchEnabled = true
+
+	svc := &GatewayService{
+		accountRepo:        repo,
+		cache:              &mockGatewayCacheForPlatform{},
+		cfg:                cfg,
+		concurrencyService: NewConcurrencyService(&mockConcurrencyCache{}),
+	}
+
+	ctx := context.WithValue(context.Background(), ctxkey.ThinkingEnabled, true)
+
+	_, err := svc.SelectAccountWithLoadAwareness(ctx, nil, "", "claude-sonnet-4-5", nil, "")
+	require.ErrorIs(t, err, ErrFeatureUnsupported)
+}
@@ -0,0 +1,37 @@
+//go:build unit
+
+package service
+
+import "testing"
+
+func TestBuildCountTokensCacheKey_SameModelAndBodyProducesSameKey(t *testing.T) {
+	s := &GatewayService{}
+	body := []byte(`{"model":"claude-3-5-sonnet","messages":[{"role":"user","content":"hi"}]}`)
+
+	key1 := s.BuildCountTokensCacheKey("claude-3-5-sonnet", body)
+	key2 := s.BuildCountTokensCacheKey("claude-3-5-sonnet", body)
+	if key1 != key2 {
+		t.Fatalf("expected identical keys for identical input, got %q and %q", key1, key2)
+	}
+}
+
+func TestBuildCountTokensCacheKey_DifferentModelProducesDifferentKey(t *testing.T) {
+	s := &GatewayService{}
+	body := []byte(`{"messages":[{"role":"user","content":"hi"}]}`)
+
+	keyA := s.BuildCountTokensCacheKey("claude-3-5-sonnet", body)
+	keyB := s.BuildCountTokensCacheKey("claude-3-opus", body)
+	if keyA == keyB {
+		t.Fatalf("expected different keys for different models, got identical key %q", keyA)
+	}
+}
+
+func TestBuildCountTokensCacheKey_DifferentBodyProducesDifferentKey(t *testing.T) {
+	s := &GatewayService{}
+
+	keyA := s.BuildCountTokensCacheKey("claude-3-5-sonnet", []byte(`{"messages":[{"role":"user","content":"hi"}]}`))
+	keyB := s.BuildCountTokensCacheKey("claude-3-5-sonnet", []byte(`{"messages":[{"role":"user","content":"bye"}]}`))
+	if keyA == keyB {
+		t.Fatalf("expected different keys for different request bodies, got identical key %q", keyA)
+	}
+}
@@ -0,0 +1,185 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// countTokensSequenceUpstream 依次返回预设的响应，用于模拟瞬时上游错误后恢复的场景。
+type countTokensSequenceUpstream struct {
+	responses []countTokensUpstreamResponse
+	calls     int
+}
+
+type countTokensUpstreamResponse struct {
+	statusCode int
+	body       string
+}
+
+func (u *countTokensSequenceUpstream) Do(req *http.Request, proxyURL string, accountID int64, accountConcurrency int) (*http.Response, error) {
+	idx := u.calls
+	if idx >= len(u.responses) {
+		idx = len(u.responses) - 1
+	}
+	u.calls++
+	resp := u.responses[idx]
+	return &http.Response{
+		StatusCode: resp.statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(resp.body)),
+	}, nil
+}
+
+func (u *countTokensSequenceUpstream) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, enableTLSFingerprint bool) (*http.Response, error) {
+	return u.Do(req, proxyURL, accountID, accountConcurrency)
+}
+
+// TestForwardCountTokens_Antigravity_IncludesModelWhenEnabled 验证开启
+// IncludeModelInCountTokensResponse 后，Antigravity 账号的 count_tokens 空响应
+// 也会附加解析后的 model 字段。
+func TestForwardCountTokens_Antigravity_IncludesModelWhenEnabled(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	svc.cfg.Gateway.IncludeModelInCountTokensResponse = true
+
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/antigravity/v1/messages/count_tokens", nil)
+
+	account := &Account{Platform: PlatformAntigravity}
+	parsed := &ParsedRequest{Model: "claude-3-5-sonnet-20241022", Body: []byte(`{}`)}
+
+	err := svc.ForwardCountTokens(context.Background(), c, account, parsed)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &got))
+	require.Equal(t, "claude-3-5-sonnet-20241022", got["model"])
+}
+
+// TestForwardCountTokens_Antigravity_OmitsModelWhenDisabled 验证默认关闭时
+// Antigravity count_tokens 响应形状保持不变（无 model 字段）。
+func TestForwardCountTokens_Antigravity_OmitsModelWhenDisabled(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/antigravity/v1/messages/count_tokens", nil)
+
+	account := &Account{Platform: PlatformAntigravity}
+	parsed := &ParsedRequest{Model: "claude-3-5-sonnet-20241022", Body: []byte(`{}`)}
+
+	err := svc.ForwardCountTokens(context.Background(), c, account, parsed)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &got))
+	_, hasModel := got["model"]
+	require.False(t, hasModel, "model field must not appear unless explicitly enabled")
+}
+
+func TestGeminiCountTokensEstimateResponse_IncludesModelWhenEnabled(t *testing.T) {
+	svc := &GeminiMessagesCompatService{cfg: &config.Config{}}
+	svc.cfg.Gateway.IncludeModelInCountTokensResponse = true
+
+	resp := svc.geminiCountTokensEstimateResponse(42, "gemini-2.5-pro")
+	require.Equal(t, 42, resp["totalTokens"])
+	require.Equal(t, "gemini-2.5-pro", resp["model"])
+}
+
+func TestGeminiCountTokensEstimateResponse_OmitsModelWhenDisabled(t *testing.T) {
+	svc := &GeminiMessagesCompatService{cfg: &config.Config{}}
+
+	resp := svc.geminiCountTokensEstimateResponse(42, "gemini-2.5-pro")
+	require.Equal(t, 42, resp["totalTokens"])
+	_, hasModel := resp["model"]
+	require.False(t, hasModel, "model field must not appear unless explicitly enabled")
+}
+
+// TestForwardCountTokens_RetriesTransient503ThenSucceeds 验证 count_tokens 在遇到
+// 瞬时 503 时会按退避策略重试，并在随后的请求成功后正常返回响应，而不是直接失败。
+func TestForwardCountTokens_RetriesTransient503ThenSucceeds(t *testing.T) {
+	upstream := &countTokensSequenceUpstream{responses: []countTokensUpstreamResponse{
+		{statusCode: http.StatusServiceUnavailable, body: `{"error":{"type":"overloaded_error","message":"temporarily unavailable"}}`},
+		{statusCode: http.StatusOK, body: `{"input_tokens":12}`},
+	}}
+	svc := &GatewayService{
+		cfg:              &config.Config{},
+		httpUpstream:     upstream,
+		rateLimitService: &RateLimitService{},
+	}
+
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages/count_tokens", nil)
+
+	account := &Account{
+		Type:     AccountTypeAPIKey,
+		Platform: PlatformAnthropic,
+		Credentials: map[string]any{
+			"api_key": "sk-test",
+			// 自定义错误码仅覆盖 400，503 不在列表中——走通用的退避重试路径而非直接失败转移。
+			"custom_error_codes_enabled": true,
+			"custom_error_codes":         []any{float64(400)},
+		},
+	}
+	parsed := &ParsedRequest{Model: "claude-3-5-sonnet-20241022", Body: []byte(`{"model":"claude-3-5-sonnet-20241022"}`)}
+
+	err := svc.ForwardCountTokens(context.Background(), c, account, parsed)
+	require.NoError(t, err)
+	require.Equal(t, 2, upstream.calls)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &got))
+	require.Equal(t, float64(12), got["input_tokens"])
+}
+
+// TestForwardCountTokens_RetryExhaustedReturnsFailoverError 验证重试次数耗尽后，
+// count_tokens 对可失败转移的状态码返回 UpstreamFailoverError 交由调用方切换账号，
+// 而不是直接向客户端写入错误响应。
+func TestForwardCountTokens_RetryExhaustedReturnsFailoverError(t *testing.T) {
+	responses := make([]countTokensUpstreamResponse, 0, defaultMaxRetryAttempts)
+	for i := 0; i < defaultMaxRetryAttempts; i++ {
+		responses = append(responses, countTokensUpstreamResponse{statusCode: http.StatusServiceUnavailable, body: `{"error":{"type":"overloaded_error","message":"still down"}}`})
+	}
+	upstream := &countTokensSequenceUpstream{responses: responses}
+	svc := &GatewayService{
+		cfg:              &config.Config{},
+		httpUpstream:     upstream,
+		rateLimitService: &RateLimitService{},
+	}
+
+	writer := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(writer)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages/count_tokens", nil)
+
+	account := &Account{
+		Type:     AccountTypeAPIKey,
+		Platform: PlatformAnthropic,
+		Credentials: map[string]any{
+			"api_key":                    "sk-test",
+			"custom_error_codes_enabled": true,
+			"custom_error_codes":         []any{float64(400)},
+		},
+	}
+	parsed := &ParsedRequest{Model: "claude-3-5-sonnet-20241022", Body: []byte(`{"model":"claude-3-5-sonnet-20241022"}`)}
+
+	err := svc.ForwardCountTokens(context.Background(), c, account, parsed)
+	require.Error(t, err)
+	var failoverErr *UpstreamFailoverError
+	require.ErrorAs(t, err, &failoverErr)
+	require.Equal(t, http.StatusServiceUnavailable, failoverErr.StatusCode)
+	require.Equal(t, defaultMaxRetryAttempts, upstream.calls)
+	require.Zero(t, writer.Body.Len(), "no response should be written to the client on failover")
+}
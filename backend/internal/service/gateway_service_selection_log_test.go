@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldLogSelection_DefaultLogsEveryRequest(t *testing.T) {
+	s := &GatewayService{}
+	for i := 0; i < 5; i++ {
+		require.True(t, s.shouldLogSelection())
+	}
+}
+
+func TestShouldLogSelection_SamplesRoughlyOneInN(t *testing.T) {
+	const rate = 10
+	const iterations = 10000
+	s := &GatewayService{cfg: &config.Config{Gateway: config.GatewayConfig{SelectionLogSampleRate: rate}}}
+
+	logged := 0
+	for i := 0; i < iterations; i++ {
+		if s.shouldLogSelection() {
+			logged++
+		}
+	}
+
+	require.Equal(t, iterations/rate, logged)
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAccountRepoForSimpleMode 记录 listSchedulableAccounts 实际调用的是
+// 全平台查询还是按分组查询，用于验证 simple 模式下默认分组配置生效。
+type recordingAccountRepoForSimpleMode struct {
+	mockAccountRepoForPlatform
+	platformCalls []string
+	groupCalls    []int64
+}
+
+func (m *recordingAccountRepoForSimpleMode) ListSchedulableByPlatform(ctx context.Context, platform string) ([]Account, error) {
+	m.platformCalls = append(m.platformCalls, platform)
+	return m.accounts, nil
+}
+
+func (m *recordingAccountRepoForSimpleMode) ListSchedulableByGroupIDAndPlatform(ctx context.Context, groupID int64, platform string) ([]Account, error) {
+	m.groupCalls = append(m.groupCalls, groupID)
+	return m.accounts, nil
+}
+
+// TestListSchedulableAccounts_SimpleMode_IgnoresGroupByDefault 默认行为：simple 模式下忽略分组，全平台查询
+func TestListSchedulableAccounts_SimpleMode_IgnoresGroupByDefault(t *testing.T) {
+	repo := &recordingAccountRepoForSimpleMode{
+		mockAccountRepoForPlatform: mockAccountRepoForPlatform{
+			accounts: []Account{{ID: 1, Platform: PlatformOpenAI, Status: StatusActive, Schedulable: true}},
+		},
+	}
+	svc := &GatewayService{
+		accountRepo: repo,
+		cfg:         &config.Config{RunMode: config.RunModeSimple},
+	}
+
+	groupID := int64(7)
+	accounts, _, err := svc.listSchedulableAccounts(context.Background(), &groupID, PlatformOpenAI, false)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, []string{PlatformOpenAI}, repo.platformCalls)
+	require.Empty(t, repo.groupCalls, "simple mode without a configured default group must not scope by group")
+}
+
+// TestListSchedulableAccounts_SimpleMode_RespectsConfiguredDefaultGroup simple 模式下配置了默认分组时，
+// 即使调用方未传入分组，也应限定到该默认分组查询账号
+func TestListSchedulableAccounts_SimpleMode_RespectsConfiguredDefaultGroup(t *testing.T) {
+	repo := &recordingAccountRepoForSimpleMode{
+		mockAccountRepoForPlatform: mockAccountRepoForPlatform{
+			accounts: []Account{{ID: 1, Platform: PlatformOpenAI, Status: StatusActive, Schedulable: true}},
+		},
+	}
+	cfg := &config.Config{RunMode: config.RunModeSimple}
+	cfg.Gateway.Scheduling.SimpleModeDefaultGroupID = 42
+	svc := &GatewayService{accountRepo: repo, cfg: cfg}
+
+	accounts, _, err := svc.listSchedulableAccounts(context.Background(), nil, PlatformOpenAI, false)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, []int64{42}, repo.groupCalls)
+	require.Empty(t, repo.platformCalls, "configured default group must scope the simple-mode lookup by group")
+}
+
+// TestOpenAIGatewayService_ListSchedulableAccounts_SimpleMode_RespectsConfiguredDefaultGroup
+// 验证 OpenAIGatewayService 的 DB 回退路径同样遵循 simple_mode_default_group_id 配置
+func TestOpenAIGatewayService_ListSchedulableAccounts_SimpleMode_RespectsConfiguredDefaultGroup(t *testing.T) {
+	repo := &recordingAccountRepoForSimpleMode{
+		mockAccountRepoForPlatform: mockAccountRepoForPlatform{
+			accounts: []Account{{ID: 1, Platform: PlatformOpenAI, Status: StatusActive, Schedulable: true}},
+		},
+	}
+	cfg := &config.Config{RunMode: config.RunModeSimple}
+	cfg.Gateway.Scheduling.SimpleModeDefaultGroupID = 9
+	svc := &OpenAIGatewayService{accountRepo: repo, cfg: cfg}
+
+	accounts, err := svc.listSchedulableAccounts(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, []int64{9}, repo.groupCalls)
+	require.Empty(t, repo.platformCalls)
+}
@@ -0,0 +1,19 @@
+package service
+
+// SessionHashDebugResult 粘性会话 hash 调试结果
+type SessionHashDebugResult struct {
+	SessionHash string `json:"session_hash"`
+	Source      string `json:"source"`
+}
+
+// DebugSessionHash 解析给定请求体并计算粘性会话 hash，同时返回命中的派生分支，
+// 用于排查线上粘性会话未按预期命中账号的问题。不计入用户账单，也不会发往上游。
+func (s *GatewayService) DebugSessionHash(body []byte, protocol string) (*SessionHashDebugResult, error) {
+	parsed, err := ParseGatewayRequest(body, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, source := s.GenerateSessionHashWithSource(parsed)
+	return &SessionHashDebugResult{SessionHash: hash, Source: string(source)}, nil
+}
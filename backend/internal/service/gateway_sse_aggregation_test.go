@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestHandleNonStreamingResponse_AggregatesSSEWhenUpstreamIgnoresStreamFalse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &GatewayService{cfg: &config.Config{}}
+
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"type\":\"message\",\"role\":\"assistant\",\"model\":\"claude-x\",\"content\":[],\"usage\":{\"input_tokens\":10,\"output_tokens\":0}}}\n\n" +
+		"event: content_block_start\n" +
+		"data: {\"type\":\"content_block_start\",\"index\":0,\"content_block\":{\"type\":\"text\",\"text\":\"\"}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"Hello, \"}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"world!\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{"Content-Type": []string{"text/event-stream; charset=utf-8"}},
+	}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	usage, err := svc.handleNonStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, "claude-x", "claude-x")
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	require.Equal(t, 10, usage.InputTokens)
+	require.Equal(t, 5, usage.OutputTokens)
+
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	body := rec.Body.Bytes()
+	require.Equal(t, "Hello, world!", gjson.GetBytes(body, "content.0.text").String())
+	require.Equal(t, "end_turn", gjson.GetBytes(body, "stop_reason").String())
+	require.Equal(t, "msg_1", gjson.GetBytes(body, "id").String())
+}
+
+func TestHandleNonStreamingResponse_PlainJSONUnaffected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &GatewayService{cfg: &config.Config{}}
+
+	jsonBody := `{"id":"msg_2","type":"message","role":"assistant","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":3,"output_tokens":1}}`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(jsonBody)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	usage, err := svc.handleNonStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, "claude-x", "claude-x")
+	require.NoError(t, err)
+	require.Equal(t, 3, usage.InputTokens)
+	require.JSONEq(t, jsonBody, rec.Body.String())
+}
+
+func TestAggregateAnthropicSSEToJSON_AccumulatesToolUseInput(t *testing.T) {
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_3\",\"content\":[],\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: content_block_start\n" +
+		"data: {\"type\":\"content_block_start\",\"index\":0,\"content_block\":{\"type\":\"tool_use\",\"id\":\"tool_1\",\"name\":\"get_weather\"}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"{\\\"city\\\":\"}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"\\\"SF\\\"}\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	result, err := aggregateAnthropicSSEToJSON(bytes.NewBufferString(streamBody))
+	require.NoError(t, err)
+	require.Equal(t, "get_weather", gjson.GetBytes(result, "content.0.name").String())
+	require.Equal(t, "SF", gjson.GetBytes(result, "content.0.input.city").String())
+}
+
+func TestAggregateAnthropicSSEToJSON_NoMessageStartReturnsError(t *testing.T) {
+	_, err := aggregateAnthropicSSEToJSON(bytes.NewBufferString("event: ping\ndata: {}\n\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ---------- parseSSEUsage / reconcileStreamUsage 单元测试 ----------
+
+func TestParseSSEUsage_StandardAnthropicSequence(t *testing.T) {
+	s := &GatewayService{}
+	usage := &ClaudeUsage{}
+	seen := &sseUsageSeen{}
+
+	s.parseSSEUsage(`{"type":"message_start","message":{"usage":{"input_tokens":100,"cache_creation_input_tokens":10,"cache_read_input_tokens":5}}}`, usage, seen)
+	s.parseSSEUsage(`{"type":"message_delta","usage":{"output_tokens":42}}`, usage, seen)
+
+	reconcileStreamUsage(usage, seen, 1, "claude-3-5-sonnet")
+
+	require.Equal(t, 100, usage.InputTokens)
+	require.Equal(t, 42, usage.OutputTokens)
+	require.Equal(t, 10, usage.CacheCreationInputTokens)
+	require.Equal(t, 5, usage.CacheReadInputTokens)
+}
+
+func TestParseSSEUsage_GLMStyleSequence(t *testing.T) {
+	s := &GatewayService{}
+	usage := &ClaudeUsage{}
+	seen := &sseUsageSeen{}
+
+	// GLM only ever sends a message_start with empty usage, and the full
+	// usage only shows up in message_delta at stream end.
+	s.parseSSEUsage(`{"type":"message_start","message":{"usage":{"input_tokens":0,"cache_creation_input_tokens":0,"cache_read_input_tokens":0}}}`, usage, seen)
+	s.parseSSEUsage(`{"type":"message_delta","usage":{"input_tokens":80,"output_tokens":30,"cache_creation_input_tokens":6,"cache_read_input_tokens":2}}`, usage, seen)
+
+	reconcileStreamUsage(usage, seen, 2, "glm-4.5")
+
+	require.Equal(t, 80, usage.InputTokens)
+	require.Equal(t, 30, usage.OutputTokens)
+	require.Equal(t, 6, usage.CacheCreationInputTokens)
+	require.Equal(t, 2, usage.CacheReadInputTokens)
+}
+
+func TestReconcileStreamUsage_PrefersLargestFieldAcrossEvents(t *testing.T) {
+	// Simulate a pathological upstream where message_delta resets a field to 0
+	// after message_start had already reported a non-zero value for it.
+	usage := &ClaudeUsage{InputTokens: 50, OutputTokens: 20}
+	seen := &sseUsageSeen{
+		start: &ClaudeUsage{InputTokens: 50, CacheCreationInputTokens: 8},
+		delta: &ClaudeUsage{InputTokens: 0, OutputTokens: 20},
+	}
+
+	reconcileStreamUsage(usage, seen, 3, "glm-4.5")
+
+	require.Equal(t, 50, usage.InputTokens)
+	require.Equal(t, 20, usage.OutputTokens)
+	require.Equal(t, 8, usage.CacheCreationInputTokens)
+}
+
+func TestReconcileStreamUsage_NoOpWhenNoDiscrepancy(t *testing.T) {
+	usage := &ClaudeUsage{InputTokens: 10, OutputTokens: 5}
+	seen := &sseUsageSeen{
+		start: &ClaudeUsage{InputTokens: 10},
+		delta: &ClaudeUsage{InputTokens: 10, OutputTokens: 5},
+	}
+
+	reconcileStreamUsage(usage, seen, 4, "claude-3-5-sonnet")
+	require.Equal(t, ClaudeUsage{InputTokens: 10, OutputTokens: 5}, *usage)
+}
+
+func TestReconcileStreamUsage_NilSeenIsNoop(t *testing.T) {
+	usage := &ClaudeUsage{InputTokens: 10}
+	reconcileStreamUsage(usage, nil, 5, "claude-3-5-sonnet")
+	require.Equal(t, 10, usage.InputTokens)
+}
+
+// ---------- buildInterimUsageDelta（content_block_stop 处补发的 interim usage）单元测试 ----------
+
+func TestBuildInterimUsageDelta_EstimatesOutputTokensWhenUpstreamHasNotReported(t *testing.T) {
+	usage := ClaudeUsage{InputTokens: 100}
+
+	data, err := buildInterimUsageDelta(usage, "hello world, this is the text generated so far")
+	require.NoError(t, err)
+
+	var event struct {
+		Type  string         `json:"type"`
+		Delta map[string]any `json:"delta"`
+		Usage ClaudeUsage    `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(data, &event))
+
+	require.Equal(t, "message_delta", event.Type)
+	require.Empty(t, event.Delta)
+	require.Equal(t, 100, event.Usage.InputTokens)
+	require.Greater(t, event.Usage.OutputTokens, 0)
+}
+
+func TestBuildInterimUsageDelta_KeepsReportedUsageWhenLargerThanEstimate(t *testing.T) {
+	usage := ClaudeUsage{InputTokens: 100, OutputTokens: 9999}
+
+	data, err := buildInterimUsageDelta(usage, "short")
+	require.NoError(t, err)
+
+	var event struct {
+		Usage ClaudeUsage `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(data, &event))
+	require.Equal(t, 9999, event.Usage.OutputTokens)
+}
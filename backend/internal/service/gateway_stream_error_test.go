@@ -0,0 +1,438 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleStreamingResponse_MidStream529PreservesUpstreamErrorEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	// message_start 先被正常转发，随后上游在流中途发送 event: error（529 过载）
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: error\n" +
+		"data: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	_, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.Error(t, err)
+
+	var midErr *midStreamUpstreamError
+	require.True(t, errors.As(err, &midErr), "expected midStreamUpstreamError, got %T: %v", err, err)
+
+	// 状态码需要依据上游错误体中的真实 error.type 推断，而不是笼统地当成 403
+	require.Equal(t, 529, inferStatusFromUpstreamErrorBody(midErr.body))
+	require.Equal(t, "Overloaded", extractUpstreamErrorMessage(midErr.body))
+
+	// message_start 应该已经被正常转发给客户端
+	require.Contains(t, rec.Body.String(), "message_start")
+}
+
+// flakyResponseWriter 模拟反向代理下一次性的瞬时写入错误：第一次 Write 失败，之后恢复正常。
+type flakyResponseWriter struct {
+	header     http.Header
+	buf        bytes.Buffer
+	writeCalls int
+	failFirstN int
+}
+
+func (w *flakyResponseWriter) Header() http.Header { return w.header }
+
+func (w *flakyResponseWriter) Write(p []byte) (int, error) {
+	w.writeCalls++
+	if w.writeCalls <= w.failFirstN {
+		return 0, errors.New("simulated transient write error")
+	}
+	return w.buf.Write(p)
+}
+
+func (w *flakyResponseWriter) WriteHeader(statusCode int) {}
+
+func (w *flakyResponseWriter) Flush() {}
+
+func TestHandleStreamingResponse_RetriesRecoverableClientWriteError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize, ClientWriteRetryDelayMs: 1}}
+	svc := &GatewayService{cfg: cfg}
+
+	flaky := &flakyResponseWriter{header: http.Header{}, failFirstN: 1}
+	c, _ := gin.CreateTestContext(flaky)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	// 首次写入失败被单次重试恢复，不应判定为客户端断开，且数据最终写入成功
+	require.False(t, result.clientDisconnect)
+	require.Contains(t, flaky.buf.String(), "message_start")
+}
+
+// slowStreamBody 模拟上游在发送 message_start 后挂起不再输出数据，
+// 用于触发 StreamDataIntervalTimeout 超时逻辑。
+func slowStreamBody(t *testing.T) io.ReadCloser {
+	t.Helper()
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { _ = pw.Close() })
+	go func() {
+		_, _ = pw.Write([]byte("event: message_start\n" +
+			"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":3,\"output_tokens\":0}}}\n\n"))
+		// 之后挂起，直到测试结束关闭管道
+		<-t.Context().Done()
+	}()
+	return pr
+}
+
+func TestHandleStreamingResponse_TimeoutSendsErrorEventByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize, StreamDataIntervalTimeout: 1}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Body: slowStreamBody(t), Header: http.Header{}}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.Error(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, 3, result.usage.InputTokens)
+
+	require.Contains(t, rec.Body.String(), "event: error")
+	require.Contains(t, rec.Body.String(), "stream_timeout")
+	require.NotContains(t, rec.Body.String(), "message_stop")
+}
+
+func TestHandleStreamingResponse_GracefulTimeoutSendsMessageStop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{
+		MaxLineSize:               defaultMaxLineSize,
+		StreamDataIntervalTimeout: 1,
+		GracefulStreamTimeout:     true,
+	}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Body: slowStreamBody(t), Header: http.Header{}}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, 3, result.usage.InputTokens)
+
+	body := rec.Body.String()
+	require.NotContains(t, body, "event: error")
+	require.Contains(t, body, "event: message_delta")
+	require.Contains(t, body, `"stop_reason":"max_tokens"`)
+	require.Contains(t, body, "event: message_stop")
+}
+
+func TestHandleStreamingResponse_GroupMaxOutputTokensStopsRelay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	maxOutputTokens := 100
+	ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{ID: 1, MaxOutputTokens: &maxOutputTokens})
+
+	// 累计 output_tokens 在第二条 message_delta 中超过分组硬上限 (100)，
+	// 第三条 message_delta (output_tokens=300) 不应出现在客户端收到的响应中。
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{},\"usage\":{\"output_tokens\":50}}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{},\"usage\":{\"output_tokens\":150}}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{},\"usage\":{\"output_tokens\":300}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(ctx, resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, 150, result.usage.OutputTokens, "should stop at the delta that crosses the cap, not read further")
+
+	body := rec.Body.String()
+	require.Contains(t, body, `"output_tokens":150`)
+	require.NotContains(t, body, `"output_tokens":300`, "relay should abort before forwarding data past the cap")
+	require.Contains(t, body, `"stop_reason":"max_tokens"`)
+	require.Contains(t, body, "event: message_stop")
+}
+
+func TestHandleStreamingResponse_SynthesizesMessageDeltaWhenUpstreamOmitsIt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	// 上游只在 message_start 给出 input_tokens，随后直接跳到 message_stop，
+	// 全程没有携带 usage 的 message_delta 事件（部分简化实现的上游会这样做）。
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":10,\"output_tokens\":0}}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"hi\"}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, 10, result.usage.InputTokens)
+
+	body := rec.Body.String()
+	require.Contains(t, body, "event: message_delta")
+	require.Contains(t, body, `"input_tokens":10`)
+
+	// 合成的 message_delta 必须出现在 message_stop 之前。
+	deltaIdx := strings.Index(body, "event: message_delta")
+	stopIdx := strings.Index(body, "event: message_stop")
+	require.True(t, deltaIdx >= 0 && stopIdx > deltaIdx)
+}
+
+func TestHandleStreamingResponse_DoesNotDuplicateExistingMessageDelta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":10,\"output_tokens\":0}}}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	body := rec.Body.String()
+	require.Equal(t, 1, strings.Count(body, "event: message_delta"))
+}
+
+func TestHandleStreamingResponse_AccountMaxLineSizeOverridesGlobalDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: 64 * 1024}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	// 单行超过全局 MaxLineSize（64KB），但小于账号覆盖值（256KB）。
+	longLine := "data: {\"type\":\"ping\",\"pad\":\"" + strings.Repeat("a", 128*1024) + "\"}\n"
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: ping\n" + longLine + "\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	account := &Account{ID: 1, MaxLineSize: 256 * 1024}
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, account, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Contains(t, rec.Body.String(), "message_stop")
+}
+
+func TestHandleStreamingResponse_AccountWithoutOverrideUsesGlobalDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: 64 * 1024}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	// 单行超过全局 MaxLineSize（64KB），账号未设置覆盖值，应按全局默认值触发 response_too_large。
+	longLine := "data: {\"type\":\"ping\",\"pad\":\"" + strings.Repeat("a", 128*1024) + "\"}\n"
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+		"event: ping\n" + longLine + "\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	account := &Account{ID: 1}
+	_, err := svc.handleStreamingResponse(context.Background(), resp, c, account, time.Now(), "model", "model", false)
+	require.Error(t, err)
+
+	body := rec.Body.String()
+	require.Contains(t, body, "event: error")
+	require.Contains(t, body, "response_too_large")
+}
+
+func TestHandleStreamingResponse_InterimStreamUsageEmitsDeltaOnContentBlockStop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize, InterimStreamUsage: true}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":10,\"output_tokens\":0}}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"hi there\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	body := rec.Body.String()
+
+	// 每个 content_block_stop 前都应补发一条不带 stop_reason 的 message_delta，携带
+	// 目前为止按累计文本估算的 output_tokens，随后才是真正的 content_block_stop 事件。
+	interimIdx := strings.Index(body, "event: message_delta")
+	stopIdx := strings.Index(body, "event: content_block_stop")
+	require.True(t, interimIdx >= 0 && stopIdx > interimIdx, "interim message_delta should precede content_block_stop")
+	require.Equal(t, 2, strings.Count(body, "event: message_delta"), "interim delta plus the real upstream message_delta")
+
+	interimBlockEnd := strings.Index(body[interimIdx:], "\n\n")
+	interimBlock := body[interimIdx : interimIdx+interimBlockEnd]
+	require.NotContains(t, interimBlock, "stop_reason", "interim delta must not carry a stop_reason, or clients may think the stream ended")
+	require.Contains(t, interimBlock, `"output_tokens":2`, "output_tokens should be estimated from the accumulated text (\"hi there\") since upstream hasn't reported usage yet")
+}
+
+func TestHandleStreamingResponse_InterimStreamUsageDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}
+	svc := &GatewayService{cfg: cfg}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	streamBody := "event: message_start\n" +
+		"data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":10,\"output_tokens\":0}}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"hi there\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(streamBody)),
+		Header:     http.Header{},
+	}
+
+	result, err := svc.handleStreamingResponse(context.Background(), resp, c, &Account{ID: 1}, time.Now(), "model", "model", false)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	// InterimStreamUsage 未开启时，不应补发额外的 message_delta，只透传上游本身的那一条。
+	require.Equal(t, 1, strings.Count(rec.Body.String(), "event: message_delta"))
+}
+
+func TestInferStatusFromUpstreamErrorBody(t *testing.T) {
+	cases := []struct {
+		errType string
+		want    int
+	}{
+		{"overloaded_error", 529},
+		{"rate_limit_error", http.StatusTooManyRequests},
+		{"authentication_error", http.StatusUnauthorized},
+		{"permission_error", http.StatusForbidden},
+		{"not_found_error", http.StatusNotFound},
+		{"invalid_request_error", http.StatusBadRequest},
+		{"api_error", http.StatusInternalServerError},
+		{"", http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		body := []byte(`{"type":"error","error":{"type":"` + tc.errType + `","message":"x"}}`)
+		require.Equal(t, tc.want, inferStatusFromUpstreamErrorBody(body), "errType=%s", tc.errType)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeClaudeOAuthRequestBody_StripsSystemCacheControlByDefault(t *testing.T) {
+	body := []byte(`{"model":"claude-3-5-sonnet-20241022","system":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}}]}`)
+
+	got, _ := normalizeClaudeOAuthRequestBody(body, "claude-3-5-sonnet-20241022", claudeOAuthNormalizeOptions{stripSystemCacheControl: true})
+
+	var req map[string]any
+	require.NoError(t, json.Unmarshal(got, &req))
+	blocks, ok := req["system"].([]any)
+	require.True(t, ok)
+	block, ok := blocks[0].(map[string]any)
+	require.True(t, ok)
+	_, hasCacheControl := block["cache_control"]
+	require.False(t, hasCacheControl, "cache_control should be stripped when stripSystemCacheControl is true")
+}
+
+func TestNormalizeClaudeOAuthRequestBody_KeepsSystemCacheControlWhenDisabled(t *testing.T) {
+	body := []byte(`{"model":"claude-3-5-sonnet-20241022","system":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}}]}`)
+
+	got, _ := normalizeClaudeOAuthRequestBody(body, "claude-3-5-sonnet-20241022", claudeOAuthNormalizeOptions{stripSystemCacheControl: false})
+
+	var req map[string]any
+	require.NoError(t, json.Unmarshal(got, &req))
+	blocks, ok := req["system"].([]any)
+	require.True(t, ok)
+	block, ok := blocks[0].(map[string]any)
+	require.True(t, ok)
+	cacheControl, hasCacheControl := block["cache_control"]
+	require.True(t, hasCacheControl, "cache_control should be kept when stripSystemCacheControl is false")
+	require.Equal(t, map[string]any{"type": "ephemeral"}, cacheControl)
+}
+
+func TestAccount_IsSystemCacheControlKept(t *testing.T) {
+	base := Account{Platform: PlatformAnthropic, Type: AccountTypeOAuth}
+
+	t.Run("defaults to false", func(t *testing.T) {
+		acc := base
+		require.False(t, acc.IsSystemCacheControlKept())
+	})
+
+	t.Run("true when extra flag set", func(t *testing.T) {
+		acc := base
+		acc.Extra = map[string]any{"keep_system_cache_control": true}
+		require.True(t, acc.IsSystemCacheControlKept())
+	})
+
+	t.Run("ignored for non-OAuth accounts", func(t *testing.T) {
+		acc := base
+		acc.Type = AccountTypeAPIKey
+		acc.Extra = map[string]any{"keep_system_cache_control": true}
+		require.False(t, acc.IsSystemCacheControlKept())
+	})
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpstreamRequest_AppliesGroupUpstreamHeaders(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{
+		ID:              1,
+		UpstreamHeaders: map[string]string{"x-custom-header": "from-group"},
+	})
+
+	req, err := svc.buildUpstreamRequest(ctx, nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "from-group", req.Header.Get("x-custom-header"))
+}
+
+func TestBuildUpstreamRequest_GroupHeadersCannotOverrideAuth(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{
+		ID: 1,
+		UpstreamHeaders: map[string]string{
+			"x-api-key":    "attacker-supplied",
+			"content-type": "text/plain",
+		},
+	})
+
+	req, err := svc.buildUpstreamRequest(ctx, nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "sk-test", req.Header.Get("x-api-key"))
+	require.Equal(t, "application/json", req.Header.Get("content-type"))
+}
+
+func TestBuildUpstreamRequest_AccountHeadersOverrideGroupHeaders(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{
+		ID:   1,
+		Type: AccountTypeAPIKey,
+		Extra: map[string]any{
+			"upstream_headers": map[string]any{"x-custom-header": "from-account"},
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{
+		ID:              1,
+		UpstreamHeaders: map[string]string{"x-custom-header": "from-group"},
+	})
+
+	req, err := svc.buildUpstreamRequest(ctx, nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "from-account", req.Header.Get("x-custom-header"))
+}
+
+func TestBuildUpstreamRequest_NoGroupInContextLeavesHeadersUnset(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	req, err := svc.buildUpstreamRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get("x-custom-header"))
+}
+
+func TestBuildUpstreamRequest_DefaultsAnthropicVersionWhenNotRequired(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	req, err := svc.buildUpstreamRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "2023-06-01", req.Header.Get("anthropic-version"))
+}
+
+func TestBuildUpstreamRequest_RejectsMissingAnthropicVersionWhenRequired(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{
+		ID:   1,
+		Type: AccountTypeAPIKey,
+		Credentials: map[string]any{
+			"require_anthropic_version": true,
+		},
+	}
+
+	req, err := svc.buildUpstreamRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.Nil(t, req)
+	require.ErrorIs(t, err, ErrAnthropicVersionRequired)
+}
+
+func TestBuildUpstreamRequest_PropagatesClientTraceHeaders(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+	c.Request.Header.Set("traceparent", "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01")
+	c.Request.Header.Set("b3", "0123456789abcdef0123456789abcdef-0123456789abcdef-1")
+
+	req, err := svc.buildUpstreamRequest(context.Background(), c, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01", req.Header.Get("traceparent"))
+	require.Equal(t, "0123456789abcdef0123456789abcdef-0123456789abcdef-1", req.Header.Get("b3"))
+}
+
+func TestBuildUpstreamRequest_NoTraceHeadersWhenGenerationDisabled(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	req, err := svc.buildUpstreamRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get("traceparent"))
+	require.Empty(t, req.Header.Get("b3"))
+}
+
+func TestBuildUpstreamRequest_GeneratesTraceHeadersWhenMissingAndEnabled(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{Gateway: config.GatewayConfig{GenerateTraceHeadersWhenMissing: true}}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	req, err := svc.buildUpstreamRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, req.Header.Get("traceparent"))
+	require.Regexp(t, `^[0-9a-f]{32}-[0-9a-f]{16}-1$`, req.Header.Get("b3"))
+}
+
+func TestBuildUpstreamRequest_DoesNotGenerateWhenClientAlreadySentTraceparent(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{Gateway: config.GatewayConfig{GenerateTraceHeadersWhenMissing: true}}}
+	account := &Account{ID: 1, Type: AccountTypeAPIKey}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+	c.Request.Header.Set("traceparent", "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01")
+
+	req, err := svc.buildUpstreamRequest(context.Background(), c, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01", req.Header.Get("traceparent"))
+	require.Empty(t, req.Header.Get("b3"))
+}
+
+func TestBuildCountTokensRequest_RejectsMissingAnthropicVersionWhenRequired(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	account := &Account{
+		ID:   1,
+		Type: AccountTypeAPIKey,
+		Credentials: map[string]any{
+			"require_anthropic_version": true,
+		},
+	}
+
+	req, err := svc.buildCountTokensRequest(context.Background(), nil, account, []byte("{}"), "sk-test", "api_key", "claude-3-5-sonnet", false)
+	require.Nil(t, req)
+	require.ErrorIs(t, err, ErrAnthropicVersionRequired)
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+	"github.com/stretchr/testify/require"
+)
+
+var errGroupWindowStatsQueryFailed = errors.New("group window stats query failed")
+
+// groupWindowStatsRepoStub 只实现 isGroupSchedulableForWindowCost 会用到的
+// GetGroupWindowStats，其余方法继承自内嵌的 nil UsageLogRepository，调用即 panic。
+type groupWindowStatsRepoStub struct {
+	UsageLogRepository
+	stats *usagestats.AccountStats
+	err   error
+	calls int
+}
+
+func (s *groupWindowStatsRepoStub) GetGroupWindowStats(ctx context.Context, groupID int64, startTime time.Time) (*usagestats.AccountStats, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stats, nil
+}
+
+// groupWindowCostCacheStub 只实现 isGroupSchedulableForWindowCost 会用到的
+// GetGroupWindowCost/SetGroupWindowCost，其余方法继承自内嵌的 nil SessionLimitCache。
+type groupWindowCostCacheStub struct {
+	SessionLimitCache
+	cost    float64
+	hit     bool
+	getErr  error
+	setCost float64
+	setHit  bool
+}
+
+func (s *groupWindowCostCacheStub) GetGroupWindowCost(ctx context.Context, groupID int64) (float64, bool, error) {
+	if s.getErr != nil {
+		return 0, false, s.getErr
+	}
+	return s.cost, s.hit, nil
+}
+
+func (s *groupWindowCostCacheStub) SetGroupWindowCost(ctx context.Context, groupID int64, cost float64) error {
+	s.setCost = cost
+	s.setHit = true
+	return nil
+}
+
+func windowCostLimit(limit float64) *float64 { return &limit }
+
+func TestIsGroupSchedulableForWindowCost_NoLimitConfigured(t *testing.T) {
+	svc := &GatewayService{}
+	group := &Group{ID: 1}
+
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false))
+}
+
+func TestIsGroupSchedulableForWindowCost_NilGroup(t *testing.T) {
+	svc := &GatewayService{}
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), nil, false))
+}
+
+func TestIsGroupSchedulableForWindowCost_CacheHitUnderLimit(t *testing.T) {
+	cache := &groupWindowCostCacheStub{cost: 5, hit: true}
+	repo := &groupWindowStatsRepoStub{}
+	svc := &GatewayService{usageLogRepo: repo, sessionLimitCache: cache}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false))
+	require.Equal(t, 0, repo.calls, "cache hit should not fall through to the database")
+}
+
+func TestIsGroupSchedulableForWindowCost_CacheHitOverLimit_NewSessionRejected(t *testing.T) {
+	cache := &groupWindowCostCacheStub{cost: 15, hit: true}
+	svc := &GatewayService{sessionLimitCache: cache}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.False(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false))
+}
+
+func TestIsGroupSchedulableForWindowCost_CacheHitOverLimit_StickySessionAllowed(t *testing.T) {
+	cache := &groupWindowCostCacheStub{cost: 15, hit: true}
+	svc := &GatewayService{sessionLimitCache: cache}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, true))
+}
+
+func TestIsGroupSchedulableForWindowCost_CacheMissFallsBackToRepo(t *testing.T) {
+	cache := &groupWindowCostCacheStub{hit: false}
+	repo := &groupWindowStatsRepoStub{stats: &usagestats.AccountStats{StandardCost: 15}}
+	svc := &GatewayService{usageLogRepo: repo, sessionLimitCache: cache}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.False(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false))
+	require.Equal(t, 1, repo.calls)
+	require.Equal(t, 15.0, cache.setCost, "should populate the cache after a miss")
+	require.True(t, cache.setHit)
+}
+
+func TestIsGroupSchedulableForWindowCost_RepoErrorFailsOpen(t *testing.T) {
+	repo := &groupWindowStatsRepoStub{err: errGroupWindowStatsQueryFailed}
+	svc := &GatewayService{usageLogRepo: repo}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false), "query failures should fail open")
+}
+
+func TestIsGroupSchedulableForWindowCost_NoSessionLimitCacheQueriesRepoDirectly(t *testing.T) {
+	repo := &groupWindowStatsRepoStub{stats: &usagestats.AccountStats{StandardCost: 5}}
+	svc := &GatewayService{usageLogRepo: repo}
+	group := &Group{ID: 1, WindowCostLimitUSD: windowCostLimit(10)}
+
+	require.True(t, svc.isGroupSchedulableForWindowCost(context.Background(), group, false))
+	require.Equal(t, 1, repo.calls)
+}
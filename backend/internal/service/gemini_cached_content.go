@@ -0,0 +1,61 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// geminiCachedContentEntry 记录为某个粘性会话创建的 Gemini 显式缓存资源。
+type geminiCachedContentEntry struct {
+	name      string // 上游返回的 cachedContents 资源名，如 "cachedContents/xxxx"
+	digest    string // 创建该资源时使用的稳定前缀摘要，用于判断是否仍可复用
+	expiresAt time.Time
+}
+
+// geminiCachedContentCache 是进程内、按粘性会话 key 维护的显式缓存资源登记表。
+// 与 featureIncompatCache 一样，它是短暂、尽力而为的状态：不持久化、不跨实例
+// 共享，重启或过期后自然回退为内联请求，因此无需像 TempUnschedCache 那样注入
+// 外部存储实现。
+type geminiCachedContentCache struct {
+	mu      sync.RWMutex
+	entries map[string]geminiCachedContentEntry
+}
+
+func newGeminiCachedContentCache() *geminiCachedContentCache {
+	return &geminiCachedContentCache{entries: make(map[string]geminiCachedContentEntry)}
+}
+
+// get 返回仍然有效、且摘要与当前稳定前缀一致的缓存资源名。
+// nil 接收者视为缓存未命中，调用方无需额外判空。
+func (c *geminiCachedContentCache) get(sessionKey, digest string) (string, bool) {
+	if c == nil || sessionKey == "" || digest == "" {
+		return "", false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[sessionKey]
+	if !ok || entry.digest != digest || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.name, true
+}
+
+// set 登记一个新创建的缓存资源；ttl 非正或 name 为空时视为无效输入，不写入。
+func (c *geminiCachedContentCache) set(sessionKey, digest, name string, ttl time.Duration) {
+	if c == nil || sessionKey == "" || digest == "" || name == "" || ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[sessionKey] = geminiCachedContentEntry{name: name, digest: digest, expiresAt: time.Now().Add(ttl)}
+}
+
+// delete 清除某个会话登记的缓存资源，用于上游报告资源不存在/已过期时的纠正。
+func (c *geminiCachedContentCache) delete(sessionKey string) {
+	if c == nil || sessionKey == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, sessionKey)
+}
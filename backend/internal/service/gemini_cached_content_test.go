@@ -0,0 +1,167 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+// mockGeminiCachedContentUpstream 记录每次 cachedContents 创建请求，
+// 返回一个递增的 mock 资源名，避免真实网络调用。
+type mockGeminiCachedContentUpstream struct {
+	calls int
+}
+
+func (m *mockGeminiCachedContentUpstream) Do(req *http.Request, proxyURL string, accountID int64, accountConcurrency int) (*http.Response, error) {
+	m.calls++
+	respBody, _ := json.Marshal(map[string]string{"name": fmt.Sprintf("cachedContents/mock-%d", m.calls)})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(respBody)),
+	}, nil
+}
+
+func (m *mockGeminiCachedContentUpstream) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, enableTLSFingerprint bool) (*http.Response, error) {
+	return m.Do(req, proxyURL, accountID, accountConcurrency)
+}
+
+func newTestGeminiCachedContentService(cfg *config.Config, upstream HTTPUpstream) *GeminiMessagesCompatService {
+	return &GeminiMessagesCompatService{
+		cfg:                 cfg,
+		httpUpstream:        upstream,
+		geminiCachedContent: newGeminiCachedContentCache(),
+	}
+}
+
+func geminiCachedContentTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Gemini.CachedContent.Enabled = true
+	cfg.Gemini.CachedContent.MinContentLength = 10
+	cfg.Gemini.CachedContent.TTL = time.Minute
+	return cfg
+}
+
+func TestApplyCachedContentIfEnabled_CreatesThenReusesAcrossRequests(t *testing.T) {
+	upstream := &mockGeminiCachedContentUpstream{}
+	svc := newTestGeminiCachedContentService(geminiCachedContentTestConfig(), upstream)
+
+	account := &Account{Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "test-key"}}
+	body := []byte(`{"systemInstruction":{"parts":[{"text":"a long and stable system prompt used across requests"}]},"contents":[{"role":"user","parts":[{"text":"hi"}]}]}`)
+	ctx := context.WithValue(context.Background(), ctxkey.GeminiSessionKey, "gemini:session-1")
+
+	first := svc.applyCachedContentIfEnabled(ctx, account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, 1, upstream.calls, "first call for a new session should create a cached content resource")
+
+	var firstPayload map[string]any
+	require.NoError(t, json.Unmarshal(first, &firstPayload))
+	require.Equal(t, "cachedContents/mock-1", firstPayload["cachedContent"])
+	require.NotContains(t, firstPayload, "systemInstruction")
+
+	second := svc.applyCachedContentIfEnabled(ctx, account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, 1, upstream.calls, "a second request in the same session must reuse the cached resource, not create another")
+
+	var secondPayload map[string]any
+	require.NoError(t, json.Unmarshal(second, &secondPayload))
+	require.Equal(t, "cachedContents/mock-1", secondPayload["cachedContent"])
+}
+
+func TestApplyCachedContentIfEnabled_DisabledFallsBackToInline(t *testing.T) {
+	cfg := geminiCachedContentTestConfig()
+	cfg.Gemini.CachedContent.Enabled = false
+	upstream := &mockGeminiCachedContentUpstream{}
+	svc := newTestGeminiCachedContentService(cfg, upstream)
+
+	account := &Account{Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "test-key"}}
+	body := []byte(`{"systemInstruction":{"parts":[{"text":"a long and stable system prompt used across requests"}]}}`)
+	ctx := context.WithValue(context.Background(), ctxkey.GeminiSessionKey, "gemini:session-1")
+
+	got := svc.applyCachedContentIfEnabled(ctx, account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, body, got)
+	require.Zero(t, upstream.calls)
+}
+
+func TestApplyCachedContentIfEnabled_MissingSessionKeyFallsBackToInline(t *testing.T) {
+	upstream := &mockGeminiCachedContentUpstream{}
+	svc := newTestGeminiCachedContentService(geminiCachedContentTestConfig(), upstream)
+
+	account := &Account{Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "test-key"}}
+	body := []byte(`{"systemInstruction":{"parts":[{"text":"a long and stable system prompt used across requests"}]}}`)
+
+	got := svc.applyCachedContentIfEnabled(context.Background(), account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, body, got)
+	require.Zero(t, upstream.calls)
+}
+
+func TestApplyCachedContentIfEnabled_ShortPrefixSkipsCaching(t *testing.T) {
+	cfg := geminiCachedContentTestConfig()
+	cfg.Gemini.CachedContent.MinContentLength = 1000
+	upstream := &mockGeminiCachedContentUpstream{}
+	svc := newTestGeminiCachedContentService(cfg, upstream)
+
+	account := &Account{Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "test-key"}}
+	body := []byte(`{"systemInstruction":{"parts":[{"text":"hi"}]}}`)
+	ctx := context.WithValue(context.Background(), ctxkey.GeminiSessionKey, "gemini:session-1")
+
+	got := svc.applyCachedContentIfEnabled(ctx, account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, body, got)
+	require.Zero(t, upstream.calls)
+}
+
+func TestApplyCachedContentIfEnabled_CreateFailureFallsBackToInline(t *testing.T) {
+	svc := newTestGeminiCachedContentService(geminiCachedContentTestConfig(), &failingHTTPUpstream{})
+
+	account := &Account{Type: AccountTypeAPIKey, Credentials: map[string]any{"api_key": "test-key"}}
+	body := []byte(`{"systemInstruction":{"parts":[{"text":"a long and stable system prompt used across requests"}]}}`)
+	ctx := context.WithValue(context.Background(), ctxkey.GeminiSessionKey, "gemini:session-1")
+
+	got := svc.applyCachedContentIfEnabled(ctx, account, "generateContent", "gemini-2.5-pro", "", body)
+	require.Equal(t, body, got, "upstream create failure must fall back to the original inline body")
+}
+
+type failingHTTPUpstream struct{}
+
+func (failingHTTPUpstream) Do(req *http.Request, proxyURL string, accountID int64, accountConcurrency int) (*http.Response, error) {
+	return nil, fmt.Errorf("upstream unavailable")
+}
+
+func (failingHTTPUpstream) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, enableTLSFingerprint bool) (*http.Response, error) {
+	return nil, fmt.Errorf("upstream unavailable")
+}
+
+func TestGeminiCachedContentCache_SetGetExpire(t *testing.T) {
+	cache := newGeminiCachedContentCache()
+
+	_, ok := cache.get("session-1", "digest-1")
+	require.False(t, ok)
+
+	cache.set("session-1", "digest-1", "cachedContents/abc", 20*time.Millisecond)
+	name, ok := cache.get("session-1", "digest-1")
+	require.True(t, ok)
+	require.Equal(t, "cachedContents/abc", name)
+
+	_, ok = cache.get("session-1", "digest-2")
+	require.False(t, ok, "a changed digest must not reuse a stale cache entry")
+
+	time.Sleep(40 * time.Millisecond)
+	_, ok = cache.get("session-1", "digest-1")
+	require.False(t, ok, "entry should expire after its ttl")
+}
+
+func TestGeminiCachedContentCache_NilSafe(t *testing.T) {
+	var cache *geminiCachedContentCache
+	_, ok := cache.get("session-1", "digest-1")
+	require.False(t, ok)
+	cache.set("session-1", "digest-1", "cachedContents/abc", time.Minute)
+	cache.delete("session-1")
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -41,6 +42,14 @@ const (
 // Ref: https://ai.google.dev/gemini-api/docs/thought-signatures
 const geminiDummyThoughtSignature = "skip_thought_signature_validator"
 
+// GeminiImageFetcher 拉取 url 类型的图片内容，用于将 Claude 消息中的 image
+// source 转换为 Gemini inlineData。
+type GeminiImageFetcher interface {
+	// FetchImage 拉取指定 URL 的图片内容，maxBytes 为允许读取的最大字节数，
+	// 超过该大小应返回 error 而不是截断数据。
+	FetchImage(ctx context.Context, url string, maxBytes int64) (data []byte, contentType string, err error)
+}
+
 type GeminiMessagesCompatService struct {
 	accountRepo               AccountRepository
 	groupRepo                 GroupRepository
@@ -50,7 +59,9 @@ type GeminiMessagesCompatService struct {
 	rateLimitService          *RateLimitService
 	httpUpstream              HTTPUpstream
 	antigravityGatewayService *AntigravityGatewayService
+	imageFetcher              GeminiImageFetcher
 	cfg                       *config.Config
+	geminiCachedContent       *geminiCachedContentCache
 }
 
 func NewGeminiMessagesCompatService(
@@ -62,6 +73,7 @@ func NewGeminiMessagesCompatService(
 	rateLimitService *RateLimitService,
 	httpUpstream HTTPUpstream,
 	antigravityGatewayService *AntigravityGatewayService,
+	imageFetcher GeminiImageFetcher,
 	cfg *config.Config,
 ) *GeminiMessagesCompatService {
 	return &GeminiMessagesCompatService{
@@ -73,7 +85,9 @@ func NewGeminiMessagesCompatService(
 		rateLimitService:          rateLimitService,
 		httpUpstream:              httpUpstream,
 		antigravityGatewayService: antigravityGatewayService,
+		imageFetcher:              imageFetcher,
 		cfg:                       cfg,
+		geminiCachedContent:       newGeminiCachedContentCache(),
 	}
 }
 
@@ -419,6 +433,50 @@ func (s *GeminiMessagesCompatService) validateUpstreamBaseURL(raw string) (strin
 	return normalized, nil
 }
 
+// validateImageURL 校验 Claude 消息中 image source 的 url，复用统一的
+// URL 白名单策略，避免网关被用于对内网地址发起 SSRF 探测。
+func (s *GeminiMessagesCompatService) validateImageURL(raw string) (string, error) {
+	if s.cfg != nil && !s.cfg.Security.URLAllowlist.Enabled {
+		normalized, err := urlvalidator.ValidateURLFormat(raw, s.cfg.Security.URLAllowlist.AllowInsecureHTTP)
+		if err != nil {
+			return "", fmt.Errorf("invalid image url: %w", err)
+		}
+		return normalized, nil
+	}
+	normalized, err := urlvalidator.ValidateHTTPSURL(raw, urlvalidator.ValidationOptions{
+		AllowedHosts:     s.cfg.Security.URLAllowlist.ImageHosts,
+		RequireAllowlist: true,
+		AllowPrivate:     s.cfg.Security.URLAllowlist.AllowPrivateHosts,
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid image url: %w", err)
+	}
+	return normalized, nil
+}
+
+// imageURLFetcher 返回一个用于拉取 url 类型图片的闭包；当图片拉取功能关闭
+// 或未注入 fetcher 时返回 nil，调用方应当降级为文本提示。
+func (s *GeminiMessagesCompatService) imageURLFetcher(ctx context.Context) func(raw string) (data []byte, mimeType string, err error) {
+	if s.imageFetcher == nil || s.cfg == nil || !s.cfg.Gemini.ImageFetch.Enabled {
+		return nil
+	}
+	maxBytes := s.cfg.Gemini.ImageFetch.MaxBytes
+	timeout := time.Duration(s.cfg.Gemini.ImageFetch.TimeoutSeconds) * time.Second
+	return func(raw string) ([]byte, string, error) {
+		normalized, err := s.validateImageURL(raw)
+		if err != nil {
+			return nil, "", err
+		}
+		fetchCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			fetchCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return s.imageFetcher.FetchImage(fetchCtx, normalized, maxBytes)
+	}
+}
+
 // HasAntigravityAccounts 检查是否有可用的 antigravity 账户
 func (s *GeminiMessagesCompatService) HasAntigravityAccounts(ctx context.Context, groupID *int64) (bool, error) {
 	accounts, err := s.listSchedulableAccountsOnce(ctx, groupID, PlatformAntigravity, false)
@@ -532,7 +590,7 @@ func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Contex
 		mappedModel = account.GetMappedModel(req.Model)
 	}
 
-	geminiReq, err := convertClaudeMessagesToGeminiGenerateContent(body)
+	geminiReq, err := convertClaudeMessagesToGeminiGenerateContent(body, s.imageURLFetcher(ctx))
 	if err != nil {
 		return nil, s.writeClaudeError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
 	}
@@ -751,7 +809,7 @@ func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Contex
 					stageName = "thinking+tools"
 					signatureRetryStage = 2
 				}
-				retryGeminiReq, txErr := convertClaudeMessagesToGeminiGenerateContent(strippedClaudeBody)
+				retryGeminiReq, txErr := convertClaudeMessagesToGeminiGenerateContent(strippedClaudeBody, s.imageURLFetcher(ctx))
 				if txErr == nil {
 					log.Printf("Gemini account %d: detected signature-related 400, retrying with downgraded Claude blocks (%s)", account.ID, stageName)
 					geminiReq = retryGeminiReq
@@ -968,7 +1026,11 @@ func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Contex
 			if err != nil {
 				return nil, s.writeClaudeError(c, http.StatusBadGateway, "upstream_error", "Failed to read upstream stream")
 			}
-			claudeResp, usageObj2 := convertGeminiToClaudeMessage(collected, originalModel)
+			blockReasonMode := s.promptBlockMode()
+			if blockReason := extractGeminiBlockReason(collected); blockReason != "" && blockReasonMode == "error" {
+				return nil, s.writeClaudeError(c, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("Prompt blocked by upstream safety filters (reason: %s)", blockReason))
+			}
+			claudeResp, usageObj2 := convertGeminiToClaudeMessage(collected, originalModel, blockReasonMode)
 			c.JSON(http.StatusOK, claudeResp)
 			usage = usageObj2
 			if usageObj != nil && (usageObj.InputTokens > 0 || usageObj.OutputTokens > 0) {
@@ -1009,6 +1071,107 @@ func isGeminiSignatureRelatedError(respBody []byte) bool {
 	return strings.Contains(msg, "thought_signature") || strings.Contains(msg, "signature")
 }
 
+// applyCachedContentIfEnabled 尝试复用（或创建）Gemini 显式缓存资源来承载请求中
+// 稳定的 systemInstruction，并将其替换为 cachedContent 引用，降低重复大上下文
+// 场景下的计费与延迟。仅对启用该特性的 AI Studio API Key 账号生效；粘性会话
+// 缺失、前缀过短、创建失败等任意情况都直接回退为原始内联请求体，不阻断主流程。
+func (s *GeminiMessagesCompatService) applyCachedContentIfEnabled(ctx context.Context, account *Account, action, mappedModel, proxyURL string, body []byte) []byte {
+	if s.cfg == nil || !s.cfg.Gemini.CachedContent.Enabled {
+		return body
+	}
+	if account.Type != AccountTypeAPIKey || (action != "generateContent" && action != "streamGenerateContent") {
+		return body
+	}
+	sessionKey, _ := ctx.Value(ctxkey.GeminiSessionKey).(string)
+	if sessionKey == "" {
+		return body
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body
+	}
+	if _, hasCached := payload["cachedContent"]; hasCached {
+		return body
+	}
+	systemInstruction, ok := payload["systemInstruction"]
+	if !ok {
+		return body
+	}
+	sysBytes, err := json.Marshal(systemInstruction)
+	if err != nil || len(sysBytes) < s.cfg.Gemini.CachedContent.MinContentLength {
+		return body
+	}
+	digest := shortHash(sysBytes)
+
+	name, ok := s.geminiCachedContent.get(sessionKey, digest)
+	if !ok {
+		apiKey := account.GetCredential("api_key")
+		baseURL := account.GetGeminiBaseURL(geminicli.AIStudioBaseURL)
+		normalizedBaseURL, err := s.validateUpstreamBaseURL(baseURL)
+		if strings.TrimSpace(apiKey) == "" || err != nil {
+			return body
+		}
+		name, err = s.createGeminiCachedContent(ctx, normalizedBaseURL, apiKey, proxyURL, account, mappedModel, systemInstruction)
+		if err != nil {
+			log.Printf("[Gemini] create cached content failed, falling back to inline request: %v", err)
+			return body
+		}
+		s.geminiCachedContent.set(sessionKey, digest, name, s.cfg.Gemini.CachedContent.TTL)
+	}
+
+	delete(payload, "systemInstruction")
+	payload["cachedContent"] = name
+	rewritten, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
+
+// createGeminiCachedContent 调用 AI Studio 的 cachedContents 接口创建一个新的
+// 显式缓存资源，返回上游分配的资源名（形如 "cachedContents/xxxx"）。
+func (s *GeminiMessagesCompatService) createGeminiCachedContent(ctx context.Context, baseURL, apiKey, proxyURL string, account *Account, model string, systemInstruction any) (string, error) {
+	reqBody, err := json.Marshal(map[string]any{
+		"model":             "models/" + strings.TrimPrefix(model, "models/"),
+		"systemInstruction": systemInstruction,
+		"ttl":               fmt.Sprintf("%ds", int(s.cfg.Gemini.CachedContent.TTL.Seconds())),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	fullURL := strings.TrimRight(baseURL, "/") + "/v1beta/cachedContents"
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	upstreamReq.Header.Set("x-goog-api-key", apiKey)
+
+	resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cachedContents create failed: status=%d body=%s", resp.StatusCode, string(respBody))
+	}
+
+	var created struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil || created.Name == "" {
+		return "", errors.New("cachedContents create response missing name")
+	}
+	return created.Name, nil
+}
+
 func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.Context, account *Account, originalModel string, action string, stream bool, body []byte) (*ForwardResult, error) {
 	startTime := time.Now()
 
@@ -1048,6 +1211,8 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 		proxyURL = account.Proxy.URL()
 	}
 
+	body = s.applyCachedContentIfEnabled(ctx, account, action, mappedModel, proxyURL, body)
+
 	useUpstreamStream := stream
 	upstreamAction := action
 	if account.Type == AccountTypeOAuth && !stream && action == "generateContent" && strings.TrimSpace(account.GetCredential("project_id")) != "" {
@@ -1201,7 +1366,7 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 			}
 			if action == "countTokens" {
 				estimated := estimateGeminiCountTokens(body)
-				c.JSON(http.StatusOK, map[string]any{"totalTokens": estimated})
+				c.JSON(http.StatusOK, s.geminiCountTokensEstimateResponse(estimated, mappedModel))
 				return &ForwardResult{
 					RequestID:    "",
 					Usage:        ClaudeUsage{},
@@ -1270,7 +1435,7 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 			}
 			if action == "countTokens" {
 				estimated := estimateGeminiCountTokens(body)
-				c.JSON(http.StatusOK, map[string]any{"totalTokens": estimated})
+				c.JSON(http.StatusOK, s.geminiCountTokensEstimateResponse(estimated, mappedModel))
 				return &ForwardResult{
 					RequestID:    "",
 					Usage:        ClaudeUsage{},
@@ -1310,7 +1475,7 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 		// Checked before error policy so it always works regardless of custom error codes.
 		if action == "countTokens" && isOAuth && isGeminiInsufficientScope(resp.Header, respBody) {
 			estimated := estimateGeminiCountTokens(body)
-			c.JSON(http.StatusOK, map[string]any{"totalTokens": estimated})
+			c.JSON(http.StatusOK, s.geminiCountTokensEstimateResponse(estimated, mappedModel))
 			return &ForwardResult{
 				RequestID:    requestID,
 				Usage:        ClaudeUsage{},
@@ -1815,6 +1980,15 @@ type geminiStreamResult struct {
 	firstTokenMs *int
 }
 
+// promptBlockMode 返回 promptFeedback.blockReason 的处理方式，默认为 "text"
+// （见 GeminiConfig.PromptBlockMode 的文档注释）。
+func (s *GeminiMessagesCompatService) promptBlockMode() string {
+	if s.cfg == nil || s.cfg.Gemini.PromptBlockMode == "" {
+		return "text"
+	}
+	return s.cfg.Gemini.PromptBlockMode
+}
+
 func (s *GeminiMessagesCompatService) handleNonStreamingResponse(c *gin.Context, resp *http.Response, originalModel string) (*ClaudeUsage, error) {
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
 	if err != nil {
@@ -1826,7 +2000,12 @@ func (s *GeminiMessagesCompatService) handleNonStreamingResponse(c *gin.Context,
 		return nil, s.writeClaudeError(c, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
 	}
 
-	claudeResp, usage := convertGeminiToClaudeMessage(geminiResp, originalModel)
+	blockReasonMode := s.promptBlockMode()
+	if blockReason := extractGeminiBlockReason(geminiResp); blockReason != "" && blockReasonMode == "error" {
+		return nil, s.writeClaudeError(c, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("Prompt blocked by upstream safety filters (reason: %s)", blockReason))
+	}
+
+	claudeResp, usage := convertGeminiToClaudeMessage(geminiResp, originalModel, blockReasonMode)
 	c.JSON(http.StatusOK, claudeResp)
 
 	return usage, nil
@@ -1904,6 +2083,44 @@ func (s *GeminiMessagesCompatService) handleStreamingResponse(c *gin.Context, re
 			continue
 		}
 
+		if blockReason := extractGeminiBlockReason(geminiResp); blockReason != "" {
+			switch s.promptBlockMode() {
+			case "error":
+				writeSSE(c.Writer, "error", map[string]any{
+					"error": fmt.Sprintf("prompt blocked by upstream safety filters (reason: %s)", blockReason),
+				})
+				flusher.Flush()
+				return nil, fmt.Errorf("gemini prompt blocked: %s", blockReason)
+			case "ignore":
+				// 保留原有行为：不提示，按空 content 结束。
+			default:
+				if openBlockIndex < 0 {
+					openBlockType = "text"
+					openBlockIndex = nextBlockIndex
+					nextBlockIndex++
+					writeSSE(c.Writer, "content_block_start", map[string]any{
+						"type":  "content_block_start",
+						"index": openBlockIndex,
+						"content_block": map[string]any{
+							"type": "text",
+							"text": "",
+						},
+					})
+					text := fmt.Sprintf("[Prompt blocked by upstream safety filters (reason: %s)]", blockReason)
+					writeSSE(c.Writer, "content_block_delta", map[string]any{
+						"type":  "content_block_delta",
+						"index": openBlockIndex,
+						"delta": map[string]any{
+							"type": "text_delta",
+							"text": text,
+						},
+					})
+					seenText = text
+					flusher.Flush()
+				}
+			}
+		}
+
 		if fr := extractGeminiFinishReason(geminiResp); fr != "" {
 			finishReason = fr
 		}
@@ -2287,6 +2504,16 @@ func isGeminiInsufficientScope(headers http.Header, body []byte) bool {
 	return strings.Contains(lower, "insufficient authentication scopes") || strings.Contains(lower, "access_token_scope_insufficient")
 }
 
+// geminiCountTokensEstimateResponse 构建 countTokens 估算 fallback 的响应体，按需附加
+// 解析后（映射/补全后）的 model 字段，与 Anthropic/Antigravity 的 count_tokens 响应保持一致。
+func (s *GeminiMessagesCompatService) geminiCountTokensEstimateResponse(estimated int, model string) map[string]any {
+	resp := map[string]any{"totalTokens": estimated}
+	if s.cfg != nil && s.cfg.Gateway.IncludeModelInCountTokensResponse && model != "" {
+		resp["model"] = model
+	}
+	return resp
+}
+
 func estimateGeminiCountTokens(reqBody []byte) int {
 	var obj map[string]any
 	if err := json.Unmarshal(reqBody, &obj); err != nil {
@@ -2590,7 +2817,7 @@ func unwrapGeminiResponse(raw []byte) (map[string]any, error) {
 	return outer, nil
 }
 
-func convertGeminiToClaudeMessage(geminiResp map[string]any, originalModel string) (map[string]any, *ClaudeUsage) {
+func convertGeminiToClaudeMessage(geminiResp map[string]any, originalModel string, blockReasonMode string) (map[string]any, *ClaudeUsage) {
 	usage := extractGeminiUsage(geminiResp)
 	if usage == nil {
 		usage = &ClaudeUsage{}
@@ -2638,6 +2865,19 @@ func convertGeminiToClaudeMessage(geminiResp map[string]any, originalModel strin
 		stopReason = "tool_use"
 	}
 
+	// 整个 prompt 被安全过滤器拦截时 candidates 为空，convertGeminiToClaudeMessage 会
+	// 生成一条空 content 的消息；按配置将其替换为说明拦截原因的文本块，避免客户端
+	// 看到一条没有任何内容也没有任何提示的"正常"回复。
+	if len(contentBlocks) == 0 && blockReasonMode != "ignore" {
+		if blockReason := extractGeminiBlockReason(geminiResp); blockReason != "" {
+			contentBlocks = append(contentBlocks, map[string]any{
+				"type": "text",
+				"text": fmt.Sprintf("[Prompt blocked by upstream safety filters (reason: %s)]", blockReason),
+			})
+			stopReason = "end_turn"
+		}
+	}
+
 	resp := map[string]any{
 		"id":            "msg_" + randomHex(12),
 		"type":          "message",
@@ -2698,7 +2938,8 @@ func (s *GeminiMessagesCompatService) handleGeminiUpstreamError(ctx context.Cont
 		return
 	}
 	if s.rateLimitService != nil && (statusCode == 401 || statusCode == 403 || statusCode == 529) {
-		s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, headers, body)
+		// 429 由本方法下面的逻辑单独处理模型级/账号级限流，此处不会触发该分支
+		s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, headers, body, "")
 		return
 	}
 	if statusCode != 429 {
@@ -2856,6 +3097,17 @@ func ensureGeminiFunctionCallThoughtSignatures(body []byte) []byte {
 	return b
 }
 
+// extractGeminiBlockReason 提取 promptFeedback.blockReason：Gemini 因安全过滤器整体拦截
+// prompt 时会设置该字段，此时 candidates 通常为空或缺失。
+func extractGeminiBlockReason(geminiResp map[string]any) string {
+	feedback, ok := geminiResp["promptFeedback"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	reason, _ := feedback["blockReason"].(string)
+	return reason
+}
+
 func extractGeminiFinishReason(geminiResp map[string]any) string {
 	if candidates, ok := geminiResp["candidates"].([]any); ok && len(candidates) > 0 {
 		if cand, ok := candidates[0].(map[string]any); ok {
@@ -2917,7 +3169,7 @@ func mapGeminiFinishReasonToClaudeStopReason(finishReason string) string {
 	}
 }
 
-func convertClaudeMessagesToGeminiGenerateContent(body []byte) ([]byte, error) {
+func convertClaudeMessagesToGeminiGenerateContent(body []byte, fetchImageURL func(raw string) (data []byte, mimeType string, err error)) ([]byte, error) {
 	var req map[string]any
 	if err := json.Unmarshal(body, &req); err != nil {
 		return nil, err
@@ -2926,7 +3178,7 @@ func convertClaudeMessagesToGeminiGenerateContent(body []byte) ([]byte, error) {
 	toolUseIDToName := make(map[string]string)
 
 	systemText := extractClaudeSystemText(req["system"])
-	contents, err := convertClaudeMessagesToGeminiContents(req["messages"], toolUseIDToName)
+	contents, err := convertClaudeMessagesToGeminiContents(req["messages"], toolUseIDToName, fetchImageURL)
 	if err != nil {
 		return nil, err
 	}
@@ -3006,7 +3258,7 @@ func extractClaudeSystemText(system any) string {
 	}
 }
 
-func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[string]string) ([]any, error) {
+func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[string]string, fetchImageURL func(raw string) (data []byte, mimeType string, err error)) ([]any, error) {
 	arr, ok := messages.([]any)
 	if !ok {
 		return nil, errors.New("messages must be an array")
@@ -3053,6 +3305,11 @@ func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[str
 					id, _ := bm["id"].(string)
 					name, _ := bm["name"].(string)
 					if strings.TrimSpace(id) != "" && strings.TrimSpace(name) != "" {
+						// 同一 tool_use id 出现多次且映射到不同名称时，后出现的会覆盖先前的，
+						// 导致对应 tool_result 被错误映射；记录告警但不中断转换。
+						if existing, ok := toolUseIDToName[id]; ok && existing != name {
+							log.Printf("Warning: duplicate tool_use id %q maps to different names (%q -> %q), using latest", id, existing, name)
+						}
 						toolUseIDToName[id] = name
 					}
 					signature, _ := bm["signature"].(string)
@@ -3071,19 +3328,25 @@ func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[str
 					toolUseID, _ := bm["tool_use_id"].(string)
 					name := toolUseIDToName[toolUseID]
 					if name == "" {
-						name = "tool"
+						// 未知/孤立的 tool_use_id（缺失或已被覆盖），退化为一个可辨识的占位名称
+						// 而不是笼统的 "tool"，便于排查是哪个 tool_result 丢失了对应的 tool_use。
+						if strings.TrimSpace(toolUseID) != "" {
+							log.Printf("Warning: tool_result references unknown tool_use id %q, using fallback name", toolUseID)
+							name = "unknown_tool_" + toolUseID
+						} else {
+							name = "tool"
+						}
 					}
 					parts = append(parts, map[string]any{
 						"functionResponse": map[string]any{
-							"name": name,
-							"response": map[string]any{
-								"content": extractClaudeContentText(bm["content"]),
-							},
+							"name":     name,
+							"response": buildGeminiFunctionResponseBody(bm),
 						},
 					})
 				case "image":
 					if src, ok := bm["source"].(map[string]any); ok {
-						if srcType, _ := src["type"].(string); srcType == "base64" {
+						switch srcType, _ := src["type"].(string); srcType {
+						case "base64":
 							mediaType, _ := src["media_type"].(string)
 							data, _ := src["data"].(string)
 							if mediaType != "" && data != "" {
@@ -3094,6 +3357,11 @@ func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[str
 									},
 								})
 							}
+						case "url":
+							url, _ := src["url"].(string)
+							if part := resolveClaudeImageURLPart(url, fetchImageURL); part != nil {
+								parts = append(parts, part)
+							}
 						}
 					}
 				default:
@@ -3115,6 +3383,44 @@ func convertClaudeMessagesToGeminiContents(messages any, toolUseIDToName map[str
 	return out, nil
 }
 
+// resolveClaudeImageURLPart 将 url 类型的 image source 转换为 Gemini inlineData part。
+// 当 fetchImageURL 为 nil（拉取功能关闭）或拉取失败时，降级为一条文本提示，
+// 而不是静默丢弃该 image block。
+func resolveClaudeImageURLPart(url string, fetchImageURL func(raw string) (data []byte, mimeType string, err error)) map[string]any {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return nil
+	}
+	if fetchImageURL == nil {
+		return map[string]any{"text": fmt.Sprintf("[image omitted: fetching url images is disabled (%s)]", url)}
+	}
+	data, mimeType, err := fetchImageURL(url)
+	if err != nil {
+		return map[string]any{"text": fmt.Sprintf("[image omitted: failed to fetch %s: %v]", url, err)}
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	return map[string]any{
+		"inlineData": map[string]any{
+			"mimeType": mimeType,
+			"data":     base64.StdEncoding.EncodeToString(data),
+		},
+	}
+}
+
+// buildGeminiFunctionResponseBody 把 Claude tool_result 内容块转换为 Gemini functionResponse.response，
+// 保留 is_error 标记：失败的工具调用放入 "error" 字段而不是 "content"，
+// 使模型能区分成功/失败结果并据此调整后续行为。
+func buildGeminiFunctionResponseBody(toolResult map[string]any) map[string]any {
+	text := extractClaudeContentText(toolResult["content"])
+	isError, _ := toolResult["is_error"].(bool)
+	if isError {
+		return map[string]any{"error": text}
+	}
+	return map[string]any{"content": text}
+}
+
 func extractClaudeContentText(v any) string {
 	switch t := v.(type) {
 	case string:
@@ -3223,9 +3529,18 @@ func cleanToolSchema(schema any) any {
 			// 递归清理嵌套对象
 			cleaned[key] = cleanToolSchema(value)
 		}
-		// 规范化 type 字段为大写
-		if typeVal, ok := cleaned["type"].(string); ok {
+		// 规范化 type 字段为大写；type 也可能是联合类型数组（如 ["string","null"]），
+		// Gemini 不支持数组形式，取其中第一个非 null 的类型作为单一类型
+		switch typeVal := cleaned["type"].(type) {
+		case string:
 			cleaned["type"] = strings.ToUpper(typeVal)
+		case []any:
+			for _, t := range typeVal {
+				if ts, ok := t.(string); ok && ts != "null" {
+					cleaned["type"] = strings.ToUpper(ts)
+					break
+				}
+			}
 		}
 		return cleaned
 	case []any:
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -131,6 +133,44 @@ func TestConvertClaudeToolsToGeminiTools_CustomType(t *testing.T) {
 	}
 }
 
+// TestCleanToolSchema_NormalizesUnionTypeArray 测试 type 为联合类型数组（如 ["string","null"]）
+// 时会被规范化为单一大写类型，丢弃 null
+func TestCleanToolSchema_NormalizesUnionTypeArray(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"nickname": map[string]any{
+				"type": []any{"string", "null"},
+			},
+			"age": map[string]any{
+				"type": []any{"null", "integer"},
+			},
+			"plain": map[string]any{
+				"type": "string",
+			},
+		},
+	}
+
+	cleaned, ok := cleanToolSchema(schema).(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "OBJECT", cleaned["type"])
+
+	properties, ok := cleaned["properties"].(map[string]any)
+	require.True(t, ok)
+
+	nickname, ok := properties["nickname"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "STRING", nickname["type"])
+
+	age, ok := properties["age"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "INTEGER", age["type"])
+
+	plain, ok := properties["plain"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "STRING", plain["type"])
+}
+
 func TestConvertClaudeMessagesToGeminiGenerateContent_AddsThoughtSignatureForToolUse(t *testing.T) {
 	claudeReq := map[string]any{
 		"model":      "claude-haiku-4-5-20251001",
@@ -169,7 +209,7 @@ func TestConvertClaudeMessagesToGeminiGenerateContent_AddsThoughtSignatureForToo
 	}
 	b, _ := json.Marshal(claudeReq)
 
-	out, err := convertClaudeMessagesToGeminiGenerateContent(b)
+	out, err := convertClaudeMessagesToGeminiGenerateContent(b, nil)
 	if err != nil {
 		t.Fatalf("convert failed: %v", err)
 	}
@@ -182,6 +222,234 @@ func TestConvertClaudeMessagesToGeminiGenerateContent_AddsThoughtSignatureForToo
 	}
 }
 
+func TestConvertClaudeMessagesToGeminiContents_ImageBase64Passthrough(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{
+					"type": "image",
+					"source": map[string]any{
+						"type":       "base64",
+						"media_type": "image/png",
+						"data":       "base64data",
+					},
+				},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	require.Len(t, parts, 1)
+	inlineData := parts[0].(map[string]any)["inlineData"].(map[string]any)
+	require.Equal(t, "image/png", inlineData["mimeType"])
+	require.Equal(t, "base64data", inlineData["data"])
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ImageURLFetched(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{
+					"type": "image",
+					"source": map[string]any{
+						"type": "url",
+						"url":  "https://example.com/cat.png",
+					},
+				},
+			},
+		},
+	}
+
+	fetch := func(raw string) ([]byte, string, error) {
+		require.Equal(t, "https://example.com/cat.png", raw)
+		return []byte("raw-bytes"), "image/png", nil
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, fetch)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	require.Len(t, parts, 1)
+	inlineData := parts[0].(map[string]any)["inlineData"].(map[string]any)
+	require.Equal(t, "image/png", inlineData["mimeType"])
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("raw-bytes")), inlineData["data"])
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ImageURLDisabledDegradesToText(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{
+					"type": "image",
+					"source": map[string]any{
+						"type": "url",
+						"url":  "https://example.com/cat.png",
+					},
+				},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	require.Len(t, parts, 1)
+	text, ok := parts[0].(map[string]any)["text"].(string)
+	require.True(t, ok)
+	require.Contains(t, text, "https://example.com/cat.png")
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ImageURLFetchFailureDegradesToText(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{
+					"type": "image",
+					"source": map[string]any{
+						"type": "url",
+						"url":  "https://example.com/cat.png",
+					},
+				},
+			},
+		},
+	}
+
+	fetch := func(raw string) ([]byte, string, error) {
+		return nil, "", errors.New("boom")
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, fetch)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	require.Len(t, parts, 1)
+	text, ok := parts[0].(map[string]any)["text"].(string)
+	require.True(t, ok)
+	require.Contains(t, text, "boom")
+}
+
+func TestConvertClaudeMessagesToGeminiContents_DuplicateToolUseIDUsesLatestName(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "assistant",
+			"content": []any{
+				map[string]any{"type": "tool_use", "id": "call_1", "name": "get_weather", "input": map[string]any{}},
+			},
+		},
+		map[string]any{
+			"role": "assistant",
+			"content": []any{
+				map[string]any{"type": "tool_use", "id": "call_1", "name": "get_time", "input": map[string]any{}},
+			},
+		},
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{"type": "tool_result", "tool_use_id": "call_1", "content": "ok"},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+	require.Len(t, contents, 3)
+
+	parts := contents[2].(map[string]any)["parts"].([]any)
+	functionResponse := parts[0].(map[string]any)["functionResponse"].(map[string]any)
+	require.Equal(t, "get_time", functionResponse["name"])
+}
+
+func TestConvertClaudeMessagesToGeminiContents_OrphanToolResultGetsFallbackName(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{"type": "tool_result", "tool_use_id": "call_missing", "content": "ok"},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	functionResponse := parts[0].(map[string]any)["functionResponse"].(map[string]any)
+	require.Equal(t, "unknown_tool_call_missing", functionResponse["name"])
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ToolResultWithoutIDDefaultsToTool(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{"type": "tool_result", "content": "ok"},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	functionResponse := parts[0].(map[string]any)["functionResponse"].(map[string]any)
+	require.Equal(t, "tool", functionResponse["name"])
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ToolResultErrorMapsToErrorField(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "assistant",
+			"content": []any{
+				map[string]any{"type": "tool_use", "id": "call_1", "name": "get_weather", "input": map[string]any{}},
+			},
+		},
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{"type": "tool_result", "tool_use_id": "call_1", "content": "city not found", "is_error": true},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+
+	parts := contents[1].(map[string]any)["parts"].([]any)
+	functionResponse := parts[0].(map[string]any)["functionResponse"].(map[string]any)
+	response := functionResponse["response"].(map[string]any)
+	require.Equal(t, "city not found", response["error"])
+	require.NotContains(t, response, "content")
+}
+
+func TestConvertClaudeMessagesToGeminiContents_ToolResultSuccessMapsToContentField(t *testing.T) {
+	messages := []any{
+		map[string]any{
+			"role": "user",
+			"content": []any{
+				map[string]any{"type": "tool_result", "tool_use_id": "call_1", "content": "sunny", "is_error": false},
+			},
+		},
+	}
+
+	contents, err := convertClaudeMessagesToGeminiContents(messages, map[string]string{}, nil)
+	require.NoError(t, err)
+
+	parts := contents[0].(map[string]any)["parts"].([]any)
+	functionResponse := parts[0].(map[string]any)["functionResponse"].(map[string]any)
+	response := functionResponse["response"].(map[string]any)
+	require.Equal(t, "sunny", response["content"])
+	require.NotContains(t, response, "error")
+}
+
 func TestEnsureGeminiFunctionCallThoughtSignatures_InsertsWhenMissing(t *testing.T) {
 	geminiReq := map[string]any{
 		"contents": []any{
@@ -272,3 +540,91 @@ func TestExtractGeminiUsage_ThoughtsTokenCount(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractGeminiBlockReason(t *testing.T) {
+	tests := []struct {
+		name string
+		resp map[string]any
+		want string
+	}{
+		{
+			name: "blocked prompt",
+			resp: map[string]any{
+				"promptFeedback": map[string]any{
+					"blockReason": "SAFETY",
+				},
+			},
+			want: "SAFETY",
+		},
+		{
+			name: "no promptFeedback",
+			resp: map[string]any{},
+			want: "",
+		},
+		{
+			name: "promptFeedback without blockReason",
+			resp: map[string]any{
+				"promptFeedback": map[string]any{
+					"safetyRatings": []any{},
+				},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, extractGeminiBlockReason(tt.resp))
+		})
+	}
+}
+
+func TestConvertGeminiToClaudeMessage_BlockedPrompt(t *testing.T) {
+	blockedResp := map[string]any{
+		"promptFeedback": map[string]any{
+			"blockReason": "SAFETY",
+		},
+		"usageMetadata": map[string]any{
+			"promptTokenCount": float64(42),
+		},
+	}
+
+	t.Run("text mode surfaces block reason", func(t *testing.T) {
+		claudeResp, usage := convertGeminiToClaudeMessage(blockedResp, "claude-3-5-sonnet", "text")
+		require.NotNil(t, usage)
+		content, ok := claudeResp["content"].([]any)
+		require.True(t, ok)
+		require.Len(t, content, 1)
+		block, ok := content[0].(map[string]any)
+		require.True(t, ok)
+		require.Equal(t, "text", block["type"])
+		require.Contains(t, block["text"], "SAFETY")
+		require.Equal(t, "end_turn", claudeResp["stop_reason"])
+	})
+
+	t.Run("ignore mode keeps content empty", func(t *testing.T) {
+		claudeResp, _ := convertGeminiToClaudeMessage(blockedResp, "claude-3-5-sonnet", "ignore")
+		content, ok := claudeResp["content"].([]any)
+		require.True(t, ok)
+		require.Empty(t, content)
+	})
+
+	t.Run("unblocked response is unaffected", func(t *testing.T) {
+		resp := map[string]any{
+			"candidates": []any{
+				map[string]any{
+					"content": map[string]any{
+						"parts": []any{
+							map[string]any{"text": "hello"},
+						},
+					},
+				},
+			},
+		}
+		claudeResp, _ := convertGeminiToClaudeMessage(resp, "claude-3-5-sonnet", "text")
+		content, ok := claudeResp["content"].([]any)
+		require.True(t, ok)
+		require.Len(t, content, 1)
+		block := content[0].(map[string]any)
+		require.Equal(t, "hello", block["text"])
+	})
+}
@@ -155,7 +155,7 @@ func (m *mockAccountRepoForGemini) ClearAntigravityQuotaScopes(ctx context.Conte
 func (m *mockAccountRepoForGemini) ClearModelRateLimits(ctx context.Context, id int64) error {
 	return nil
 }
-func (m *mockAccountRepoForGemini) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string) error {
+func (m *mockAccountRepoForGemini) UpdateSessionWindow(ctx context.Context, id int64, start, end *time.Time, status string, utilization *int) error {
 	return nil
 }
 func (m *mockAccountRepoForGemini) UpdateExtra(ctx context.Context, id int64, updates map[string]any) error {
@@ -269,6 +269,18 @@ func (m *mockGatewayCacheForGemini) DeleteSessionAccountID(ctx context.Context,
 	return nil
 }
 
+func (m *mockGatewayCacheForGemini) IndexSessionForAPIKey(ctx context.Context, apiKeyID, groupID int64, sessionHash string, ttl time.Duration) error {
+	return nil
+}
+
+func (m *mockGatewayCacheForGemini) ListSessionsByAPIKey(ctx context.Context, apiKeyID int64) ([]StickySession, error) {
+	return nil, nil
+}
+
+func (m *mockGatewayCacheForGemini) DeleteSessionsByAPIKey(ctx context.Context, apiKeyID int64) error {
+	return nil
+}
+
 // TestGeminiMessagesCompatService_SelectAccountForModelWithExclusions_GeminiPlatform 测试 Gemini 单平台选择
 func TestGeminiMessagesCompatService_SelectAccountForModelWithExclusions_GeminiPlatform(t *testing.T) {
 	ctx := context.Background()
@@ -1211,3 +1211,96 @@ func TestGenerateSessionHash_GeminiEndToEnd(t *testing.T) {
 	h3 := svc.GenerateSessionHash(parsed3)
 	require.NotEqual(t, h, h3, "different user with same Gemini request should get different hash")
 }
+
+// ============ GenerateSessionHashWithSource 分支来源测试 ============
+
+func TestGenerateSessionHashWithSource_NilParsedRequest(t *testing.T) {
+	svc := &GatewayService{}
+	hash, source := svc.GenerateSessionHashWithSource(nil)
+	require.Empty(t, hash)
+	require.Equal(t, SessionHashSourceNone, source)
+}
+
+func TestGenerateSessionHashWithSource_EmptyRequest(t *testing.T) {
+	svc := &GatewayService{}
+	hash, source := svc.GenerateSessionHashWithSource(&ParsedRequest{})
+	require.Empty(t, hash)
+	require.Equal(t, SessionHashSourceNone, source)
+}
+
+func TestGenerateSessionHashWithSource_Metadata(t *testing.T) {
+	svc := &GatewayService{}
+
+	parsed := &ParsedRequest{
+		MetadataUserID: "session_123e4567-e89b-12d3-a456-426614174000",
+		Messages: []any{
+			map[string]any{"role": "user", "content": "hello"},
+		},
+	}
+
+	hash, source := svc.GenerateSessionHashWithSource(parsed)
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", hash)
+	require.Equal(t, SessionHashSourceMetadata, source)
+}
+
+func TestGenerateSessionHashWithSource_Cacheable(t *testing.T) {
+	svc := &GatewayService{}
+
+	parsed := &ParsedRequest{
+		Messages: []any{
+			map[string]any{
+				"role": "user",
+				"content": []any{
+					map[string]any{
+						"type":          "text",
+						"text":          "cached context",
+						"cache_control": map[string]any{"type": "ephemeral"},
+					},
+				},
+			},
+		},
+	}
+
+	hash, source := svc.GenerateSessionHashWithSource(parsed)
+	require.NotEmpty(t, hash)
+	require.Equal(t, SessionHashSourceCacheable, source)
+}
+
+func TestGenerateSessionHashWithSource_Fallback(t *testing.T) {
+	svc := &GatewayService{}
+
+	parsed := &ParsedRequest{
+		System:    "You are a helpful assistant.",
+		HasSystem: true,
+		Messages: []any{
+			map[string]any{"role": "user", "content": "hello"},
+		},
+	}
+
+	hash, source := svc.GenerateSessionHashWithSource(parsed)
+	require.NotEmpty(t, hash)
+	require.Equal(t, SessionHashSourceFallback, source)
+}
+
+func TestGenerateSessionHashWithSource_MetadataTakesPriorityOverCacheable(t *testing.T) {
+	svc := &GatewayService{}
+
+	parsed := &ParsedRequest{
+		MetadataUserID: "session_123e4567-e89b-12d3-a456-426614174000",
+		Messages: []any{
+			map[string]any{
+				"role": "user",
+				"content": []any{
+					map[string]any{
+						"type":          "text",
+						"text":          "cached context",
+						"cache_control": map[string]any{"type": "ephemeral"},
+					},
+				},
+			},
+		},
+	}
+
+	_, source := svc.GenerateSessionHashWithSource(parsed)
+	require.Equal(t, SessionHashSourceMetadata, source)
+}
@@ -21,6 +21,13 @@ type Group struct {
 	MonthlyLimitUSD     *float64
 	DefaultValidityDays int
 
+	// Currency 是计费展示货币代码（如 USD、CNY、EUR），仅影响预估费用/用量账单等响应中
+	// 展示给前端的货币标注，内部计费计算始终以美元为基准单位
+	Currency string
+
+	// 订阅限额用尽后的计费策略（仅订阅模式分组生效），见 SubscriptionOverflowPolicy* 常量
+	SubscriptionOverflowPolicy string
+
 	// 图片生成计费配置（antigravity 和 gemini 平台使用）
 	ImagePrice1K *float64
 	ImagePrice2K *float64
@@ -48,6 +55,43 @@ type Group struct {
 	// 分组排序
 	SortOrder int
 
+	// 是否跳过 OAuth 账号 metadata.user_id 的会话伪装重写，透传客户端原始 metadata
+	DisableMetadataRewrite bool
+
+	// 单次请求允许的最大 messages 数量，覆盖全局 gateway.max_messages；nil 表示使用全局配置
+	MaxMessages *int
+
+	// 分组每日请求次数上限（与 DailyLimitUSD 的费用限额相互独立）；nil 或 <=0 表示不限制
+	DailyRequestLimit *int
+
+	// 流式响应累计 output tokens 硬上限，超出后即使客户端 max_tokens 更高也提前终止上游转发；nil 表示不限制
+	MaxOutputTokens *int
+
+	// 分组级上游默认请求头，构建上游请求时应用于该分组下的 API-key 账号（认证类头部不受影响）
+	UpstreamHeaders map[string]string
+
+	// Intent 路由配置
+	// key: x-sub2api-intent 请求头携带的 intent（精确匹配，不支持通配符）
+	// value: 优先账号 ID 列表
+	IntentRouting        map[string][]int64
+	IntentRoutingEnabled bool
+
+	// 允许的上游端点白名单（见 GatewayEndpoint* 常量），为空表示不限制
+	// 用于限制只对部分端点（如 count_tokens）开放访问的受限凭据
+	AllowedEndpoints []string
+
+	// 是否要求客户端必须携带 anthropic-version 请求头，缺失时拒绝请求而非默认填充 2023-06-01
+	RequireAnthropicVersion bool
+
+	// 混合调度下，是否仅在原生平台账户全部饱和/不可用时才使用已启用混合调度的 antigravity 账户（严格 fallback）；
+	// 为 false 时沿用 gateway.scheduling.mixed_scheduling_preference 的混合排序行为
+	MixedSchedulingNativeSaturationOnly bool
+
+	// 分组内所有账号在滚动窗口内的 StandardCost 总和上限（美元）；nil 或 <=0 表示不限制
+	WindowCostLimitUSD *float64
+	// WindowCostLimitUSD 对应的滚动窗口时长（小时）；nil 或 <=0 时默认为 5 小时
+	WindowCostWindowHours *int
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -55,6 +99,31 @@ type Group struct {
 	AccountCount  int64
 }
 
+// GatewayEndpoint* 标识网关对外暴露的上游端点，用于 Group.AllowedEndpoints 白名单匹配
+const (
+	GatewayEndpointMessages    = "messages"
+	GatewayEndpointCountTokens = "count_tokens"
+)
+
+// IsEndpointAllowed 检查分组是否允许访问指定端点
+// AllowedEndpoints 为空表示不限制，所有端点均可访问
+func (g *Group) IsEndpointAllowed(endpoint string) bool {
+	if g == nil || len(g.AllowedEndpoints) == 0 {
+		return true
+	}
+	for _, allowed := range g.AllowedEndpoints {
+		if allowed == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresAnthropicVersionHeader 检查分组是否要求客户端必须携带 anthropic-version 请求头
+func (g *Group) RequiresAnthropicVersionHeader() bool {
+	return g != nil && g.RequireAnthropicVersion
+}
+
 func (g *Group) IsActive() bool {
 	return g.Status == StatusActive
 }
@@ -67,6 +136,11 @@ func (g *Group) IsFreeSubscription() bool {
 	return g.IsSubscriptionType() && g.RateMultiplier == 0
 }
 
+// FallsBackToBalanceOnOverflow 判断订阅限额用尽后是否改为从用户余额扣费
+func (g *Group) FallsBackToBalanceOnOverflow() bool {
+	return g.SubscriptionOverflowPolicy == SubscriptionOverflowPolicyFallbackBalance
+}
+
 func (g *Group) HasDailyLimit() bool {
 	return g.DailyLimitUSD != nil && *g.DailyLimitUSD > 0
 }
@@ -79,6 +153,23 @@ func (g *Group) HasMonthlyLimit() bool {
 	return g.MonthlyLimitUSD != nil && *g.MonthlyLimitUSD > 0
 }
 
+func (g *Group) HasDailyRequestLimit() bool {
+	return g.DailyRequestLimit != nil && *g.DailyRequestLimit > 0
+}
+
+// HasWindowCostLimit 判断分组是否启用了窗口费用上限
+func (g *Group) HasWindowCostLimit() bool {
+	return g.WindowCostLimitUSD != nil && *g.WindowCostLimitUSD > 0
+}
+
+// WindowCostWindowDuration 返回窗口费用滚动窗口的时长，未配置或非法时默认为 5 小时
+func (g *Group) WindowCostWindowDuration() time.Duration {
+	if g.WindowCostWindowHours != nil && *g.WindowCostWindowHours > 0 {
+		return time.Duration(*g.WindowCostWindowHours) * time.Hour
+	}
+	return 5 * time.Hour
+}
+
 // GetImagePrice 根据 image_size 返回对应的图片生成价格
 // 如果分组未配置价格，返回 nil（调用方应使用默认值）
 func (g *Group) GetImagePrice(imageSize string) *float64 {
@@ -134,6 +225,19 @@ func (g *Group) GetRoutingAccountIDs(requestedModel string) []int64 {
 	return nil
 }
 
+// GetIntentAccountIDs 根据请求 intent 获取路由账号 ID 列表
+// 返回匹配的优先账号 ID 列表，如果未启用、未配置或没有匹配规则则返回 nil。
+// 与 GetRoutingAccountIDs 不同，intent 是一组受控的固定取值，因此只做精确匹配，不支持通配符。
+func (g *Group) GetIntentAccountIDs(intent string) []int64 {
+	if !g.IntentRoutingEnabled || len(g.IntentRouting) == 0 || intent == "" {
+		return nil
+	}
+	if accountIDs, ok := g.IntentRouting[intent]; ok && len(accountIDs) > 0 {
+		return accountIDs
+	}
+	return nil
+}
+
 // matchModelPattern 检查模型是否匹配模式
 // 支持 * 通配符，如 "claude-opus-*" 匹配 "claude-opus-4-20250514"
 func matchModelPattern(pattern, model string) bool {
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// alwaysFundedBillingCacheStub 提供一个始终有余额的 BillingCache，用于隔离测试分组每日请求限额检查，
+// 避免触发真正的余额/订阅数据库查询
+type alwaysFundedBillingCacheStub struct{}
+
+func (alwaysFundedBillingCacheStub) GetUserBalance(ctx context.Context, userID int64) (float64, error) {
+	return 100, nil
+}
+func (alwaysFundedBillingCacheStub) SetUserBalance(ctx context.Context, userID int64, balance float64) error {
+	return nil
+}
+func (alwaysFundedBillingCacheStub) DeductUserBalance(ctx context.Context, userID int64, amount float64) error {
+	return nil
+}
+func (alwaysFundedBillingCacheStub) InvalidateUserBalance(ctx context.Context, userID int64) error { return nil }
+func (alwaysFundedBillingCacheStub) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*SubscriptionCacheData, error) {
+	return nil, errors.New("not implemented")
+}
+func (alwaysFundedBillingCacheStub) SetSubscriptionCache(ctx context.Context, userID, groupID int64, data *SubscriptionCacheData) error {
+	return nil
+}
+func (alwaysFundedBillingCacheStub) UpdateSubscriptionUsage(ctx context.Context, userID, groupID int64, cost float64) error {
+	return nil
+}
+func (alwaysFundedBillingCacheStub) InvalidateSubscriptionCache(ctx context.Context, userID, groupID int64) error {
+	return nil
+}
+
+// groupRequestLimitCacheStub 模拟按分组自增计数，便于在不依赖 Redis 的情况下验证超限/重置行为
+type groupRequestLimitCacheStub struct {
+	counts  map[int64]int64
+	resetAt time.Time
+	err     error
+}
+
+func (c *groupRequestLimitCacheStub) IncrementDailyRequestCount(ctx context.Context, groupID int64) (int64, time.Time, error) {
+	if c.err != nil {
+		return 0, time.Time{}, c.err
+	}
+	if c.counts == nil {
+		c.counts = make(map[int64]int64)
+	}
+	c.counts[groupID]++
+	return c.counts[groupID], c.resetAt, nil
+}
+
+func TestCheckBillingEligibility_DailyRequestLimitExceeded(t *testing.T) {
+	limit := 2
+	group := &Group{ID: 1, DailyRequestLimit: &limit}
+	cache := &groupRequestLimitCacheStub{resetAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	svc := NewBillingCacheService(alwaysFundedBillingCacheStub{}, nil, nil, &config.Config{}, cache)
+	t.Cleanup(svc.Stop)
+
+	ctx := context.Background()
+	require.NoError(t, svc.CheckBillingEligibility(ctx, &User{ID: 1}, nil, group, nil))
+	require.NoError(t, svc.CheckBillingEligibility(ctx, &User{ID: 1}, nil, group, nil))
+
+	err := svc.CheckBillingEligibility(ctx, &User{ID: 1}, nil, group, nil)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrDailyRequestLimitExceeded))
+}
+
+func TestCheckBillingEligibility_NoDailyRequestLimitSkipsCounter(t *testing.T) {
+	group := &Group{ID: 1}
+	cache := &groupRequestLimitCacheStub{}
+
+	svc := NewBillingCacheService(alwaysFundedBillingCacheStub{}, nil, nil, &config.Config{}, cache)
+	t.Cleanup(svc.Stop)
+
+	require.NoError(t, svc.CheckBillingEligibility(context.Background(), &User{ID: 1}, nil, group, nil))
+	require.Empty(t, cache.counts, "counter should not be touched when the group has no daily request limit configured")
+}
+
+func TestCheckBillingEligibility_DailyRequestLimitFailsOpenOnCacheError(t *testing.T) {
+	limit := 1
+	group := &Group{ID: 1, DailyRequestLimit: &limit}
+	cache := &groupRequestLimitCacheStub{err: errors.New("redis unavailable")}
+
+	svc := NewBillingCacheService(alwaysFundedBillingCacheStub{}, nil, nil, &config.Config{}, cache)
+	t.Cleanup(svc.Stop)
+
+	require.NoError(t, svc.CheckBillingEligibility(context.Background(), &User{ID: 1}, nil, group, nil))
+}
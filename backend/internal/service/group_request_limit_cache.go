@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// GroupRequestLimitCache 按分组统计当日请求次数，用于 Group.DailyRequestLimit 限额检查
+//
+// Key 格式: group_daily_requests:{groupID}:{YYYYMMDD}（按配置时区计算的自然日）
+// 计数在当日结束时自动过期，无需手动清理
+type GroupRequestLimitCache interface {
+	// IncrementDailyRequestCount 将分组当日请求计数 +1，返回自增后的计数值以及计数重置（次日零点）的时间
+	IncrementDailyRequestCount(ctx context.Context, groupID int64) (count int64, resetAt time.Time, err error)
+}
@@ -90,3 +90,29 @@ func TestGroup_GetImagePrice_PartialConfig(t *testing.T) {
 	require.Nil(t, group.GetImagePrice("2K"))
 	require.Nil(t, group.GetImagePrice("4K"))
 }
+
+// TestGroup_IsEndpointAllowed_EmptyAllowlistAllowsAll 测试未配置白名单时不限制任何端点
+func TestGroup_IsEndpointAllowed_EmptyAllowlistAllowsAll(t *testing.T) {
+	group := &Group{}
+
+	require.True(t, group.IsEndpointAllowed(GatewayEndpointMessages))
+	require.True(t, group.IsEndpointAllowed(GatewayEndpointCountTokens))
+	require.True(t, group.IsEndpointAllowed("unknown"))
+}
+
+// TestGroup_IsEndpointAllowed_RestrictsToAllowlist 测试配置白名单后只放行列表内的端点
+func TestGroup_IsEndpointAllowed_RestrictsToAllowlist(t *testing.T) {
+	group := &Group{
+		AllowedEndpoints: []string{GatewayEndpointCountTokens},
+	}
+
+	require.True(t, group.IsEndpointAllowed(GatewayEndpointCountTokens))
+	require.False(t, group.IsEndpointAllowed(GatewayEndpointMessages))
+}
+
+// TestGroup_IsEndpointAllowed_NilGroupAllowsAll 测试 nil 分组指针时不限制（与未 hydrate 的兜底行为一致）
+func TestGroup_IsEndpointAllowed_NilGroupAllowsAll(t *testing.T) {
+	var group *Group
+
+	require.True(t, group.IsEndpointAllowed(GatewayEndpointMessages))
+}
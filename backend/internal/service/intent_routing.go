@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// IsValidIntent checks requestedIntent against the allowed-intents list configured via
+// Gateway.IntentRouting. The feature is opt-in: when validationEnabled is false, any
+// non-empty intent is accepted as-is so operators can start tagging traffic before
+// wiring up validation.
+func IsValidIntent(validationEnabled bool, validIntents []string, requestedIntent string) bool {
+	if requestedIntent == "" {
+		return false
+	}
+	if !validationEnabled {
+		return true
+	}
+	for _, intent := range validIntents {
+		if intent == requestedIntent {
+			return true
+		}
+	}
+	return false
+}
+
+// intentAccountIDsFromContext 结合当前请求声明的 intent（ctxkey.RequestIntent）与请求所属
+// 分组的 Intent 路由配置（Group.IntentRouting），返回该 intent 限定的优先账号 ID 列表。
+// 没有声明 intent、分组未启用 intent 路由、或没有匹配规则时返回 nil（不做任何限制）。
+func intentAccountIDsFromContext(ctx context.Context) []int64 {
+	intent, ok := ctx.Value(ctxkey.RequestIntent).(string)
+	if !ok || intent == "" {
+		return nil
+	}
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	if !ok || group == nil {
+		return nil
+	}
+	return group.GetIntentAccountIDs(intent)
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsValidIntent_DisabledAcceptsAny 校验未启用时任意非空 intent 都放行
+func TestIsValidIntent_DisabledAcceptsAny(t *testing.T) {
+	require.True(t, IsValidIntent(false, nil, "coding"))
+	require.True(t, IsValidIntent(false, []string{"chat"}, "coding"))
+}
+
+// TestIsValidIntent_EmptyAlwaysRejected 空 intent 始终不通过，无论是否启用校验
+func TestIsValidIntent_EmptyAlwaysRejected(t *testing.T) {
+	require.False(t, IsValidIntent(false, nil, ""))
+	require.False(t, IsValidIntent(true, []string{"coding"}, ""))
+}
+
+// TestIsValidIntent_EnabledRejectsUnknown 启用校验后未在白名单中的 intent 被拒绝
+func TestIsValidIntent_EnabledRejectsUnknown(t *testing.T) {
+	require.False(t, IsValidIntent(true, []string{"coding", "chat"}, "research"))
+	require.True(t, IsValidIntent(true, []string{"coding", "chat"}, "chat"))
+}
+
+// TestGroup_GetIntentAccountIDs_ExactMatchOnly intent 只做精确匹配，不支持通配符
+func TestGroup_GetIntentAccountIDs_ExactMatchOnly(t *testing.T) {
+	group := &Group{
+		IntentRoutingEnabled: true,
+		IntentRouting: map[string][]int64{
+			"coding": {1, 2},
+		},
+	}
+	require.Equal(t, []int64{1, 2}, group.GetIntentAccountIDs("coding"))
+	require.Nil(t, group.GetIntentAccountIDs("cod"))
+	require.Nil(t, group.GetIntentAccountIDs(""))
+}
+
+// TestGroup_GetIntentAccountIDs_DisabledOrEmpty 未启用或未配置时返回 nil
+func TestGroup_GetIntentAccountIDs_DisabledOrEmpty(t *testing.T) {
+	group := &Group{
+		IntentRoutingEnabled: false,
+		IntentRouting:        map[string][]int64{"coding": {1}},
+	}
+	require.Nil(t, group.GetIntentAccountIDs("coding"))
+
+	group = &Group{IntentRoutingEnabled: true}
+	require.Nil(t, group.GetIntentAccountIDs("coding"))
+}
+
+// TestIntentAccountIDsFromContext_NoIntentOrGroup 缺少 intent 或分组信息时不做限制
+func TestIntentAccountIDsFromContext_NoIntentOrGroup(t *testing.T) {
+	require.Nil(t, intentAccountIDsFromContext(context.Background()))
+
+	ctx := context.WithValue(context.Background(), ctxkey.RequestIntent, "coding")
+	require.Nil(t, intentAccountIDsFromContext(ctx))
+}
+
+// TestIntentAccountIDsFromContext_Matches 结合 context 中的 intent 与分组配置返回候选账号
+func TestIntentAccountIDsFromContext_Matches(t *testing.T) {
+	group := &Group{
+		IntentRoutingEnabled: true,
+		IntentRouting: map[string][]int64{
+			"coding": {10, 20},
+		},
+	}
+	ctx := context.WithValue(context.Background(), ctxkey.RequestIntent, "coding")
+	ctx = context.WithValue(ctx, ctxkey.Group, group)
+	require.Equal(t, []int64{10, 20}, intentAccountIDsFromContext(ctx))
+}
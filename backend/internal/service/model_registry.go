@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// ModelValidationResult is the outcome of validating a requested model name
+// against the known-models registry.
+type ModelValidationResult struct {
+	// Known is false when the requested model is not recognized.
+	Known bool
+	// Suggestions holds the closest known model names, ordered by edit
+	// distance, capped at GatewayModelValidationConfig.MaxSuggestions.
+	Suggestions []string
+}
+
+// ValidateKnownModel checks requestedModel against the known-models registry
+// configured via Gateway.ModelValidation. The feature is opt-in: when
+// disabled, or when no registry can be determined (neither a static list nor
+// any account model_mapping is configured), it always reports the model as
+// known so newly released upstream models are never blocked.
+func (s *GatewayService) ValidateKnownModel(ctx context.Context, groupID *int64, requestedModel string) ModelValidationResult {
+	return validateKnownModel(s.cfg, requestedModel, func() []string {
+		return s.GetAvailableModels(ctx, groupID, "")
+	})
+}
+
+// ValidateKnownModel is the OpenAI-gateway counterpart of
+// GatewayService.ValidateKnownModel, deriving the registry from OpenAI
+// platform accounts when no static list is configured.
+func (s *OpenAIGatewayService) ValidateKnownModel(ctx context.Context, groupID *int64, requestedModel string) ModelValidationResult {
+	return validateKnownModel(s.cfg, requestedModel, func() []string {
+		accounts, err := s.listSchedulableAccounts(ctx, groupID)
+		if err != nil {
+			return nil
+		}
+		modelSet := make(map[string]struct{})
+		for _, acc := range accounts {
+			for model := range acc.GetModelMapping() {
+				modelSet[model] = struct{}{}
+			}
+		}
+		models := make([]string, 0, len(modelSet))
+		for model := range modelSet {
+			models = append(models, model)
+		}
+		return models
+	})
+}
+
+// validateKnownModel holds the registry-agnostic validation logic shared by
+// GatewayService and OpenAIGatewayService: deriveRegistry is only invoked
+// when no static Gateway.ModelValidation.KnownModels list is configured.
+func validateKnownModel(cfg *config.Config, requestedModel string, deriveRegistry func() []string) ModelValidationResult {
+	known := ModelValidationResult{Known: true}
+
+	if requestedModel == "" || cfg == nil || !cfg.Gateway.ModelValidation.Enabled {
+		return known
+	}
+
+	registry := cfg.Gateway.ModelValidation.KnownModels
+	if len(registry) == 0 {
+		registry = deriveRegistry()
+	}
+	if len(registry) == 0 {
+		// No registry could be derived; fail open rather than block requests.
+		return known
+	}
+
+	for _, name := range registry {
+		if name == requestedModel {
+			return known
+		}
+	}
+
+	return ModelValidationResult{
+		Known:       false,
+		Suggestions: closestModelNames(requestedModel, registry, cfg.Gateway.ModelValidation.MaxSuggestions),
+	}
+}
+
+// closestModelNames returns up to max entries from candidates ordered by
+// ascending Levenshtein distance to target.
+func closestModelNames(target string, candidates []string, max int) []string {
+	if max <= 0 {
+		return nil
+	}
+	type scored struct {
+		name     string
+		distance int
+	}
+	scoredNames := make([]scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		scoredNames = append(scoredNames, scored{name: candidate, distance: levenshteinDistance(target, candidate)})
+	}
+	// Simple insertion sort: candidate lists are small (typically a handful of models).
+	for i := 1; i < len(scoredNames); i++ {
+		for j := i; j > 0 && scoredNames[j].distance < scoredNames[j-1].distance; j-- {
+			scoredNames[j], scoredNames[j-1] = scoredNames[j-1], scoredNames[j]
+		}
+	}
+	if len(scoredNames) > max {
+		scoredNames = scoredNames[:max]
+	}
+	suggestions := make([]string, 0, len(scoredNames))
+	for _, s := range scoredNames {
+		suggestions = append(suggestions, s.name)
+	}
+	return suggestions
+}
+
+// levenshteinDistance computes the classic edit distance between a and b.
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	if len(ar) == 0 {
+		return len(br)
+	}
+	if len(br) == 0 {
+		return len(ar)
+	}
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			deletion := prev[j] + 1
+			insertion := curr[j-1] + 1
+			substitution := prev[j-1] + cost
+			min := deletion
+			if insertion < min {
+				min = insertion
+			}
+			if substitution < min {
+				min = substitution
+			}
+			curr[j] = min
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
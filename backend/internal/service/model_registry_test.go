@@ -0,0 +1,71 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"claude", "", 6},
+		{"claude", "claude", 0},
+		{"claude-3-5-sonnet", "calude-3-5-sonnet", 2},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, levenshteinDistance(tt.a, tt.b), "levenshteinDistance(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestClosestModelNames(t *testing.T) {
+	known := []string{"claude-3-5-sonnet-20241022", "claude-3-opus-20240229", "claude-3-5-haiku-20241022"}
+
+	suggestions := closestModelNames("calude-3-5-sonnet-20241022", known, 2)
+	require.Len(t, suggestions, 2)
+	require.Equal(t, "claude-3-5-sonnet-20241022", suggestions[0], "closest typo should rank first")
+
+	require.Empty(t, closestModelNames("claude-3-5-sonnet-20241022", known, 0))
+}
+
+func TestGatewayService_ValidateKnownModel_DisabledByDefault(t *testing.T) {
+	svc := &GatewayService{cfg: &config.Config{}}
+	result := svc.ValidateKnownModel(context.Background(), nil, "totally-made-up-model")
+	require.True(t, result.Known, "validation must be opt-in and default to allowing unknown models")
+}
+
+func TestGatewayService_ValidateKnownModel_StaticRegistryRejectsTypoWithSuggestions(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.ModelValidation.Enabled = true
+	cfg.Gateway.ModelValidation.KnownModels = []string{"claude-3-5-sonnet-20241022", "claude-3-opus-20240229"}
+	cfg.Gateway.ModelValidation.MaxSuggestions = 2
+
+	svc := &GatewayService{cfg: cfg}
+
+	result := svc.ValidateKnownModel(context.Background(), nil, "claude-3-5-sonet-20241022")
+	require.False(t, result.Known)
+	require.NotEmpty(t, result.Suggestions)
+	require.Equal(t, "claude-3-5-sonnet-20241022", result.Suggestions[0])
+
+	result = svc.ValidateKnownModel(context.Background(), nil, "claude-3-opus-20240229")
+	require.True(t, result.Known, "exact registry match should be accepted")
+}
+
+func TestGatewayService_ValidateKnownModel_EmptyRegistryFailsOpen(t *testing.T) {
+	repo := &mockAccountRepoForPlatform{accounts: []Account{}, accountsByID: map[int64]*Account{}}
+	cfg := &config.Config{}
+	cfg.Gateway.ModelValidation.Enabled = true
+
+	svc := &GatewayService{cfg: cfg, accountRepo: repo}
+
+	result := svc.ValidateKnownModel(context.Background(), nil, "anything-goes")
+	require.True(t, result.Known, "an empty/undeterminable registry must not block requests")
+}
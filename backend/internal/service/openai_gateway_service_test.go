@@ -204,6 +204,18 @@ func (c *stubGatewayCache) DeleteSessionAccountID(ctx context.Context, groupID i
 	return nil
 }
 
+func (c *stubGatewayCache) IndexSessionForAPIKey(ctx context.Context, apiKeyID, groupID int64, sessionHash string, ttl time.Duration) error {
+	return nil
+}
+
+func (c *stubGatewayCache) ListSessionsByAPIKey(ctx context.Context, apiKeyID int64) ([]StickySession, error) {
+	return nil, nil
+}
+
+func (c *stubGatewayCache) DeleteSessionsByAPIKey(ctx context.Context, apiKeyID int64) error {
+	return nil
+}
+
 func TestOpenAISelectAccountWithLoadAwareness_FiltersUnschedulable(t *testing.T) {
 	now := time.Now()
 	resetAt := now.Add(10 * time.Minute)
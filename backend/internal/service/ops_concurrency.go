@@ -256,6 +256,28 @@ func (s *OpsService) GetConcurrencyStats(
 	return platform, group, account, &collectedAt, nil
 }
 
+// GetPoolUtilizationSummary returns a single aggregated capacity view across the
+// whole account pool: total slots, in-use slots, waiting count, and a per-platform
+// breakdown. It is a thin summary layer on top of GetConcurrencyStats.
+func (s *OpsService) GetPoolUtilizationSummary(ctx context.Context) (*PoolUtilizationSummary, *time.Time, error) {
+	platform, _, _, collectedAt, err := s.GetConcurrencyStats(ctx, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &PoolUtilizationSummary{Platform: platform}
+	for _, p := range platform {
+		summary.TotalSlots += p.MaxCapacity
+		summary.InUseSlots += p.CurrentInUse
+		summary.WaitingCount += p.WaitingInQueue
+	}
+	if summary.TotalSlots > 0 {
+		summary.LoadPercentage = float64(summary.InUseSlots) / float64(summary.TotalSlots) * 100
+	}
+
+	return summary, collectedAt, nil
+}
+
 // listAllActiveUsersForOps returns all active users with their concurrency settings.
 func (s *OpsService) listAllActiveUsersForOps(ctx context.Context) ([]User, error) {
 	if s == nil || s.userRepo == nil {
@@ -11,6 +11,18 @@ type PlatformConcurrencyInfo struct {
 	WaitingInQueue int64   `json:"waiting_in_queue"`
 }
 
+// PoolUtilizationSummary is a single at-a-glance capacity view over the whole
+// account pool, aggregating totals across every platform on top of the more
+// granular per-platform/group/account breakdowns returned by GetConcurrencyStats.
+type PoolUtilizationSummary struct {
+	TotalSlots     int64   `json:"total_slots"`
+	InUseSlots     int64   `json:"in_use_slots"`
+	WaitingCount   int64   `json:"waiting_count"`
+	LoadPercentage float64 `json:"load_percentage"`
+
+	Platform map[string]*PlatformConcurrencyInfo `json:"platform"`
+}
+
 // GroupConcurrencyInfo aggregates concurrency usage by group.
 //
 // Note: one account can belong to multiple groups; group totals are therefore not additive across groups.
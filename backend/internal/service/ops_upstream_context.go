@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -24,8 +25,38 @@ const (
 	// OpsSkipPassthroughKey 由 applyErrorPassthroughRule 在命中 skip_monitoring=true 的规则时设置。
 	// ops_error_logger 中间件检查此 key，为 true 时跳过错误记录。
 	OpsSkipPassthroughKey = "ops_skip_passthrough"
+
+	opsUpstreamMaxEventsContextKey = "ops_upstream_max_events"
 )
 
+// defaultMaxOpsUpstreamErrorEvents 是 BindOpsUpstreamMaxErrorEvents 未被调用（或传入非正值）时
+// 使用的兜底上限，与 config.GatewayConfig.MaxOpsUpstreamErrorEvents 的默认值保持一致。
+const defaultMaxOpsUpstreamErrorEvents = 20
+
+// BindOpsUpstreamMaxErrorEvents 将单次请求允许累计的 OpsUpstreamErrorEvent 上限绑定到请求上下文，
+// 由各 handler 在请求入口处调用（通常与 BindErrorPassthroughService 放在一起）。
+func BindOpsUpstreamMaxErrorEvents(c *gin.Context, maxEvents int) {
+	if c == nil || maxEvents <= 0 {
+		return
+	}
+	c.Set(opsUpstreamMaxEventsContextKey, maxEvents)
+}
+
+func boundOpsUpstreamMaxErrorEvents(c *gin.Context) int {
+	if c == nil {
+		return defaultMaxOpsUpstreamErrorEvents
+	}
+	v, ok := c.Get(opsUpstreamMaxEventsContextKey)
+	if !ok {
+		return defaultMaxOpsUpstreamErrorEvents
+	}
+	n, ok := v.(int)
+	if !ok || n <= 0 {
+		return defaultMaxOpsUpstreamErrorEvents
+	}
+	return n
+}
+
 func setOpsUpstreamError(c *gin.Context, upstreamStatusCode int, upstreamMessage, upstreamDetail string) {
 	if c == nil {
 		return
@@ -106,11 +137,41 @@ func appendOpsUpstreamError(c *gin.Context, ev OpsUpstreamErrorEvent) {
 
 	evCopy := ev
 	existing = append(existing, &evCopy)
+	existing = capOpsUpstreamErrorEvents(existing, boundOpsUpstreamMaxErrorEvents(c))
 	c.Set(OpsUpstreamErrorsKey, existing)
 
 	checkSkipMonitoringForUpstreamEvent(c, &evCopy)
 }
 
+// capOpsUpstreamErrorEvents 在事件数超过 maxEvents 时，保留最早与最近的若干条，
+// 中间用一条 kind=truncated 的汇总事件替代——首次失败通常指向根因，最近几次失败
+// 则反映 failover 耗尽前的最终状态，两者对排障都最有价值，中间的重复切换信息可以舍弃。
+func capOpsUpstreamErrorEvents(events []*OpsUpstreamErrorEvent, maxEvents int) []*OpsUpstreamErrorEvent {
+	if maxEvents <= 0 || len(events) <= maxEvents {
+		return events
+	}
+	if maxEvents < 3 {
+		// 容量太小放不下占位事件，直接保留最近的 maxEvents 条。
+		return events[len(events)-maxEvents:]
+	}
+
+	head := maxEvents / 2
+	tail := maxEvents - head - 1
+	dropped := len(events) - head - tail
+
+	placeholder := &OpsUpstreamErrorEvent{
+		AtUnixMs: events[head].AtUnixMs,
+		Kind:     "truncated",
+		Message:  fmt.Sprintf("%d intermediate upstream error event(s) omitted to bound memory usage", dropped),
+	}
+
+	capped := make([]*OpsUpstreamErrorEvent, 0, maxEvents)
+	capped = append(capped, events[:head]...)
+	capped = append(capped, placeholder)
+	capped = append(capped, events[len(events)-tail:]...)
+	return capped
+}
+
 // checkSkipMonitoringForUpstreamEvent checks whether the upstream error event
 // matches a passthrough rule with skip_monitoring=true and, if so, sets the
 // OpsSkipPassthroughKey on the context.  This ensures intermediate retry /
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapOpsUpstreamErrorEvents_UnderLimitLeavesUntouched(t *testing.T) {
+	events := make([]*OpsUpstreamErrorEvent, 3)
+	for i := range events {
+		events[i] = &OpsUpstreamErrorEvent{Kind: "http_error"}
+	}
+	require.Equal(t, events, capOpsUpstreamErrorEvents(events, 5))
+}
+
+func TestCapOpsUpstreamErrorEvents_OverLimitKeepsHeadAndTailWithPlaceholder(t *testing.T) {
+	events := make([]*OpsUpstreamErrorEvent, 10)
+	for i := range events {
+		events[i] = &OpsUpstreamErrorEvent{AccountID: int64(i)}
+	}
+
+	capped := capOpsUpstreamErrorEvents(events, 5)
+	require.Len(t, capped, 5)
+	require.Equal(t, int64(0), capped[0].AccountID)
+	require.Equal(t, int64(1), capped[1].AccountID)
+	require.Equal(t, "truncated", capped[2].Kind)
+	require.Equal(t, int64(8), capped[3].AccountID)
+	require.Equal(t, int64(9), capped[4].AccountID)
+}
+
+func TestCapOpsUpstreamErrorEvents_TinyLimitKeepsMostRecentOnly(t *testing.T) {
+	events := make([]*OpsUpstreamErrorEvent, 5)
+	for i := range events {
+		events[i] = &OpsUpstreamErrorEvent{AccountID: int64(i)}
+	}
+
+	capped := capOpsUpstreamErrorEvents(events, 2)
+	require.Len(t, capped, 2)
+	require.Equal(t, int64(3), capped[0].AccountID)
+	require.Equal(t, int64(4), capped[1].AccountID)
+}
+
+func TestCapOpsUpstreamErrorEvents_NonPositiveLimitLeavesUntouched(t *testing.T) {
+	events := make([]*OpsUpstreamErrorEvent, 4)
+	for i := range events {
+		events[i] = &OpsUpstreamErrorEvent{}
+	}
+	require.Equal(t, events, capOpsUpstreamErrorEvents(events, 0))
+}
+
+func TestBoundOpsUpstreamMaxErrorEvents_FallsBackToDefaultWhenUnbound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	require.Equal(t, defaultMaxOpsUpstreamErrorEvents, boundOpsUpstreamMaxErrorEvents(c))
+}
+
+func TestAppendOpsUpstreamError_BoundedUnderManyFailovers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	BindOpsUpstreamMaxErrorEvents(c, 6)
+
+	for i := 0; i < 100; i++ {
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{Kind: "failover", UpstreamStatusCode: 529})
+	}
+
+	v, ok := c.Get(OpsUpstreamErrorsKey)
+	require.True(t, ok)
+	events, ok := v.([]*OpsUpstreamErrorEvent)
+	require.True(t, ok)
+	require.Len(t, events, 6)
+
+	foundTruncated := false
+	for _, ev := range events {
+		if ev.Kind == "truncated" {
+			foundTruncated = true
+		}
+	}
+	require.True(t, foundTruncated)
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// modelScopeRateLimitRepo records SetRateLimited / SetModelRateLimit calls for
+// verifying which scope a 429 ends up applied to.
+type modelScopeRateLimitRepo struct {
+	mockAccountRepoForGemini
+	accountWideCalls int
+	modelScopeCalls  map[string]time.Time
+}
+
+func (r *modelScopeRateLimitRepo) SetRateLimited(ctx context.Context, id int64, resetAt time.Time) error {
+	r.accountWideCalls++
+	return nil
+}
+
+func (r *modelScopeRateLimitRepo) SetModelRateLimit(ctx context.Context, id int64, scope string, resetAt time.Time) error {
+	if r.modelScopeCalls == nil {
+		r.modelScopeCalls = make(map[string]time.Time)
+	}
+	r.modelScopeCalls[scope] = resetAt
+	return nil
+}
+
+func TestHandle429_ModelScopedRateLimitDisabled_UsesAccountWideLimit(t *testing.T) {
+	repo := &modelScopeRateLimitRepo{}
+	svc := NewRateLimitService(repo, nil, &config.Config{}, nil, nil)
+	account := &Account{ID: 1, Platform: PlatformAnthropic, Type: AccountTypeAPIKey}
+
+	headers := http.Header{}
+	headers.Set("anthropic-ratelimit-unified-reset", "9999999999")
+
+	shouldDisable := svc.HandleUpstreamError(context.Background(), account, 429, headers, nil, "claude-opus")
+
+	require.False(t, shouldDisable)
+	require.Equal(t, 1, repo.accountWideCalls)
+	require.Empty(t, repo.modelScopeCalls)
+}
+
+func TestHandle429_ModelScopedRateLimitEnabled_OnlyExcludesRequestedModel(t *testing.T) {
+	repo := &modelScopeRateLimitRepo{}
+	svc := NewRateLimitService(repo, nil, &config.Config{}, nil, nil)
+	account := &Account{
+		ID:          1,
+		Platform:    PlatformAnthropic,
+		Type:        AccountTypeAPIKey,
+		Status:      StatusActive,
+		Schedulable: true,
+		Credentials: map[string]any{
+			"model_scoped_rate_limit_enabled": true,
+		},
+	}
+
+	headers := http.Header{}
+	headers.Set("anthropic-ratelimit-unified-reset", "9999999999")
+
+	shouldDisable := svc.HandleUpstreamError(context.Background(), account, 429, headers, nil, "claude-opus")
+
+	require.False(t, shouldDisable)
+	require.Zero(t, repo.accountWideCalls)
+	require.Contains(t, repo.modelScopeCalls, "claude-opus")
+
+	// Apply the recorded limit back onto the account, mirroring how a reload
+	// from the repository would surface it, then verify only the rate-limited
+	// model is excluded from scheduling.
+	account.Extra = map[string]any{
+		modelRateLimitsKey: map[string]any{
+			"claude-opus": map[string]any{
+				"rate_limit_reset_at": repo.modelScopeCalls["claude-opus"].UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	require.False(t, account.IsSchedulableForModel("claude-opus"))
+	require.True(t, account.IsSchedulableForModel("claude-haiku"))
+}
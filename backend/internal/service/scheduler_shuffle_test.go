@@ -3,6 +3,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -189,6 +190,111 @@ func TestShuffleWithinPriorityAndLastUsed_DifferentLastUsedAt_OrderPreserved(t *
 	}
 }
 
+// ============ sortCandidatesForFallback 选号种子测试 ============
+
+func TestSortCandidatesForFallback_SameSeed_DeterministicOrder(t *testing.T) {
+	s := &GatewayService{}
+	build := func() []*Account {
+		return []*Account{
+			{ID: 1, Priority: 1},
+			{ID: 2, Priority: 1},
+			{ID: 3, Priority: 1},
+			{ID: 4, Priority: 1},
+			{ID: 5, Priority: 1},
+		}
+	}
+
+	ctx := WithSelectionSeed(context.Background(), 42)
+
+	first := build()
+	s.sortCandidatesForFallback(ctx, first, false, "random")
+	firstOrder := make([]int64, len(first))
+	for i, acc := range first {
+		firstOrder[i] = acc.ID
+	}
+
+	for i := 0; i < 10; i++ {
+		accounts := build()
+		s.sortCandidatesForFallback(ctx, accounts, false, "random")
+		order := make([]int64, len(accounts))
+		for j, acc := range accounts {
+			order[j] = acc.ID
+		}
+		require.Equal(t, firstOrder, order, "same seed should yield identical selection order")
+	}
+}
+
+func TestSortCandidatesForFallback_NoSeed_StillRandomizes(t *testing.T) {
+	s := &GatewayService{}
+	build := func() []*Account {
+		return []*Account{
+			{ID: 1, Priority: 1},
+			{ID: 2, Priority: 1},
+			{ID: 3, Priority: 1},
+			{ID: 4, Priority: 1},
+			{ID: 5, Priority: 1},
+		}
+	}
+
+	seen := map[int64]bool{}
+	for i := 0; i < 50; i++ {
+		accounts := build()
+		s.sortCandidatesForFallback(context.Background(), accounts, false, "random")
+		seen[accounts[0].ID] = true
+	}
+	require.GreaterOrEqual(t, len(seen), 2, "without a seed, fallback order should still vary")
+}
+
+func TestSortCandidatesForFallback_Weighted_SameSeed_DeterministicOrder(t *testing.T) {
+	s := &GatewayService{}
+	build := func() []*Account {
+		return []*Account{
+			{ID: 1, Priority: 1, Concurrency: 10},
+			{ID: 2, Priority: 1, Concurrency: 20},
+			{ID: 3, Priority: 1, Concurrency: 30},
+		}
+	}
+
+	ctx := WithSelectionSeed(context.Background(), 7)
+
+	first := build()
+	s.sortCandidatesForFallback(ctx, first, false, "weighted")
+	firstOrder := make([]int64, len(first))
+	for i, acc := range first {
+		firstOrder[i] = acc.ID
+	}
+
+	for i := 0; i < 10; i++ {
+		accounts := build()
+		s.sortCandidatesForFallback(ctx, accounts, false, "weighted")
+		order := make([]int64, len(accounts))
+		for j, acc := range accounts {
+			order[j] = acc.ID
+		}
+		require.Equal(t, firstOrder, order, "same seed should yield identical weighted order")
+	}
+}
+
+// TestSortCandidatesForFallback_Weighted_DistributionProportionalToWeight 统计验证：
+// "weighted" 模式下，候选账号排在首位的频率应与其 Concurrency 权重成正比。
+func TestSortCandidatesForFallback_Weighted_DistributionProportionalToWeight(t *testing.T) {
+	s := &GatewayService{}
+	const trials = 20000
+	firstCount := map[int64]int{}
+
+	for i := 0; i < trials; i++ {
+		accounts := []*Account{
+			{ID: 1, Priority: 1, Concurrency: 90},
+			{ID: 2, Priority: 1, Concurrency: 10},
+		}
+		s.sortCandidatesForFallback(context.Background(), accounts, false, "weighted")
+		firstCount[accounts[0].ID]++
+	}
+
+	ratio := float64(firstCount[1]) / float64(trials)
+	require.InDelta(t, 0.9, ratio, 0.03)
+}
+
 // ============ sameLastUsedAt 测试 ============
 
 func TestSameLastUsedAt(t *testing.T) {
@@ -284,6 +390,84 @@ func TestSameAccountGroup(t *testing.T) {
 
 // ============ sortAccountsByPriorityAndLastUsed 集成随机化测试 ============
 
+// ============ sortAccountsByPriorityAndCheapest 测试 ============
+
+func rateMultiplier(v float64) *float64 {
+	return &v
+}
+
+func TestSortAccountsByPriorityAndCheapest_PrefersLowestRate(t *testing.T) {
+	accounts := []*Account{
+		{ID: 1, Priority: 1, RateMultiplier: rateMultiplier(1.5)},
+		{ID: 2, Priority: 1, RateMultiplier: rateMultiplier(0.5)},
+		{ID: 3, Priority: 1, RateMultiplier: rateMultiplier(1.0)},
+	}
+
+	sortAccountsByPriorityAndCheapest(accounts, false)
+	require.Equal(t, int64(2), accounts[0].ID)
+	require.Equal(t, int64(3), accounts[1].ID)
+	require.Equal(t, int64(1), accounts[2].ID)
+}
+
+func TestSortAccountsByPriorityAndCheapest_HigherPriorityWinsRegardlessOfRate(t *testing.T) {
+	accounts := []*Account{
+		{ID: 1, Priority: 2, RateMultiplier: rateMultiplier(0.1)},
+		{ID: 2, Priority: 1, RateMultiplier: rateMultiplier(2.0)},
+	}
+
+	sortAccountsByPriorityAndCheapest(accounts, false)
+	require.Equal(t, int64(2), accounts[0].ID, "lower priority value should still win over cheaper rate")
+}
+
+func TestSortAccountsByPriorityAndCheapest_SameRate_ShuffledAmongEquals(t *testing.T) {
+	accounts := []*Account{
+		{ID: 1, Priority: 1, RateMultiplier: rateMultiplier(1.0)},
+		{ID: 2, Priority: 1, RateMultiplier: rateMultiplier(1.0)},
+		{ID: 3, Priority: 1, RateMultiplier: rateMultiplier(1.0)},
+	}
+
+	seen := map[int64]bool{}
+	for i := 0; i < 100; i++ {
+		cpy := make([]*Account, len(accounts))
+		copy(cpy, accounts)
+		sortAccountsByPriorityAndCheapest(cpy, false)
+		seen[cpy[0].ID] = true
+	}
+	require.GreaterOrEqual(t, len(seen), 2, "accounts with equal rate should be shuffled among equals")
+}
+
+func TestSortCandidatesForFallback_CheapestMode_PrefersLowestBillingRateMultiplier(t *testing.T) {
+	s := &GatewayService{}
+	accounts := []*Account{
+		{ID: 1, Priority: 1, RateMultiplier: rateMultiplier(1.5)},
+		{ID: 2, Priority: 1, RateMultiplier: rateMultiplier(0.2)},
+		{ID: 3, Priority: 1, RateMultiplier: rateMultiplier(0.8)},
+	}
+
+	s.sortCandidatesForFallback(context.Background(), accounts, false, "cheapest")
+	require.Equal(t, int64(2), accounts[0].ID, "cheapest account should be preferred among equal-priority candidates")
+}
+
+func TestSameAccountGroupCheapest(t *testing.T) {
+	t.Run("same rate and priority", func(t *testing.T) {
+		a := &Account{Priority: 1, RateMultiplier: rateMultiplier(1.0)}
+		b := &Account{Priority: 1, RateMultiplier: rateMultiplier(1.0)}
+		require.True(t, sameAccountGroupCheapest(a, b))
+	})
+
+	t.Run("different rate", func(t *testing.T) {
+		a := &Account{Priority: 1, RateMultiplier: rateMultiplier(1.0)}
+		b := &Account{Priority: 1, RateMultiplier: rateMultiplier(2.0)}
+		require.False(t, sameAccountGroupCheapest(a, b))
+	})
+
+	t.Run("different priority", func(t *testing.T) {
+		a := &Account{Priority: 1, RateMultiplier: rateMultiplier(1.0)}
+		b := &Account{Priority: 2, RateMultiplier: rateMultiplier(1.0)}
+		require.False(t, sameAccountGroupCheapest(a, b))
+	})
+}
+
 func TestSortAccountsByPriorityAndLastUsed_WithShuffle(t *testing.T) {
 	t.Run("same priority and nil LastUsedAt are shuffled", func(t *testing.T) {
 		accounts := []*Account{
@@ -316,3 +500,96 @@ func TestSortAccountsByPriorityAndLastUsed_WithShuffle(t *testing.T) {
 		require.Equal(t, int64(3), accounts[2].ID)
 	})
 }
+
+// ============ accountSelectionWeight / 加权选择测试 ============
+
+func TestAccountSelectionWeight_UsesConcurrencyOrFallsBackToOne(t *testing.T) {
+	require.Equal(t, 5.0, accountSelectionWeight(&Account{Concurrency: 5}))
+	require.Equal(t, 1.0, accountSelectionWeight(&Account{Concurrency: 0}))
+	require.Equal(t, 1.0, accountSelectionWeight(&Account{Concurrency: -1}))
+}
+
+func TestWeightedShuffleWithinPriority_DifferentPriorities_OrderPreserved(t *testing.T) {
+	accounts := []*Account{
+		{ID: 1, Priority: 1, Concurrency: 10},
+		{ID: 2, Priority: 2, Concurrency: 10},
+		{ID: 3, Priority: 3, Concurrency: 10},
+	}
+
+	for i := 0; i < 20; i++ {
+		cpy := make([]*Account, len(accounts))
+		copy(cpy, accounts)
+		weightedShuffleWithinPriority(cpy)
+		require.Equal(t, int64(1), cpy[0].ID)
+		require.Equal(t, int64(2), cpy[1].ID)
+		require.Equal(t, int64(3), cpy[2].ID)
+	}
+}
+
+// TestWeightedShuffleWithinPriority_DistributionProportionalToWeight 统计验证：同优先级内
+// 加权打乱后排在首位的频率应与账号的 Concurrency 权重成正比，允许一定误差范围。
+func TestWeightedShuffleWithinPriority_DistributionProportionalToWeight(t *testing.T) {
+	const trials = 20000
+	firstCount := map[int64]int{}
+	accounts := []*Account{
+		{ID: 1, Priority: 1, Concurrency: 90}, // 权重占比 0.9
+		{ID: 2, Priority: 1, Concurrency: 10}, // 权重占比 0.1
+	}
+
+	for i := 0; i < trials; i++ {
+		cpy := make([]*Account, len(accounts))
+		copy(cpy, accounts)
+		weightedShuffleWithinPriority(cpy)
+		firstCount[cpy[0].ID]++
+	}
+
+	ratio := float64(firstCount[1]) / float64(trials)
+	require.InDelta(t, 0.9, ratio, 0.03, "account with 9x the weight should be selected first roughly 9x as often")
+}
+
+func TestSelectByWeight_EmptyReturnsNil(t *testing.T) {
+	require.Nil(t, selectByWeight(nil))
+}
+
+func TestSelectByWeight_SingleElement(t *testing.T) {
+	accounts := []accountWithLoad{{account: &Account{ID: 1, Concurrency: 5}}}
+	selected := selectByWeight(accounts)
+	require.Equal(t, int64(1), selected.account.ID)
+}
+
+// TestSelectByWeight_DistributionProportionalToWeight 统计验证：selectByWeight 选中各账号的
+// 频率应与其 Concurrency 权重成正比。
+func TestSelectByWeight_DistributionProportionalToWeight(t *testing.T) {
+	const trials = 20000
+	counts := map[int64]int{}
+	accounts := []accountWithLoad{
+		{account: &Account{ID: 1, Concurrency: 80}},
+		{account: &Account{ID: 2, Concurrency: 20}},
+	}
+
+	for i := 0; i < trials; i++ {
+		selected := selectByWeight(accounts)
+		counts[selected.account.ID]++
+	}
+
+	ratio := float64(counts[1]) / float64(trials)
+	require.InDelta(t, 0.8, ratio, 0.03, "account with 4x the weight should be selected roughly 4x as often")
+}
+
+func TestSelectByWeight_ZeroConcurrencyTreatedAsWeightOne(t *testing.T) {
+	const trials = 5000
+	counts := map[int64]int{}
+	// 两个账号均为不限并发（权重退化为 1），应各占约一半
+	accounts := []accountWithLoad{
+		{account: &Account{ID: 1, Concurrency: 0}},
+		{account: &Account{ID: 2, Concurrency: 0}},
+	}
+
+	for i := 0; i < trials; i++ {
+		selected := selectByWeight(accounts)
+		counts[selected.account.ID]++
+	}
+
+	ratio := float64(counts[1]) / float64(trials)
+	require.InDelta(t, 0.5, ratio, 0.04)
+}
@@ -455,6 +455,15 @@ func (s *SchedulerSnapshotService) rebuildBucket(ctx context.Context, bucket Sch
 	return nil
 }
 
+// TriggerFullRebuild 立即重建所有调度快照，绕过 full_rebuild_interval_seconds 节流，
+// 供管理端在批量变更账号后手动调用，使新增/变更账号立即可调度。
+func (s *SchedulerSnapshotService) TriggerFullRebuild() error {
+	if s == nil {
+		return ErrSchedulerCacheNotReady
+	}
+	return s.triggerFullRebuild("manual")
+}
+
 func (s *SchedulerSnapshotService) triggerFullRebuild(reason string) error {
 	if s.cache == nil {
 		return ErrSchedulerCacheNotReady
@@ -530,7 +539,7 @@ func (s *SchedulerSnapshotService) loadAccountsFromDB(ctx context.Context, bucke
 	}
 	groupID := bucket.GroupID
 	if s.isRunModeSimple() {
-		groupID = 0
+		groupID = s.simpleModeGroupID()
 	}
 
 	if useMixed {
@@ -571,7 +580,7 @@ func (s *SchedulerSnapshotService) bucketFor(groupID *int64, platform string, mo
 
 func (s *SchedulerSnapshotService) normalizeGroupID(groupID *int64) int64 {
 	if s.isRunModeSimple() {
-		return 0
+		return s.simpleModeGroupID()
 	}
 	if groupID == nil || *groupID <= 0 {
 		return 0
@@ -581,7 +590,7 @@ func (s *SchedulerSnapshotService) normalizeGroupID(groupID *int64) int64 {
 
 func (s *SchedulerSnapshotService) normalizeGroupIDs(groupIDs []int64) []int64 {
 	if s.isRunModeSimple() {
-		return []int64{0}
+		return []int64{s.simpleModeGroupID()}
 	}
 	if len(groupIDs) == 0 {
 		return []int64{0}
@@ -645,6 +654,15 @@ func (s *SchedulerSnapshotService) isRunModeSimple() bool {
 	return s.cfg != nil && s.cfg.RunMode == config.RunModeSimple
 }
 
+// simpleModeGroupID 返回 simple 模式下仍应限定的分组 ID，0 表示维持原行为（忽略分组，调度全平台账号）。
+// 由 gateway.scheduling.simple_mode_default_group_id 配置。
+func (s *SchedulerSnapshotService) simpleModeGroupID() int64 {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Gateway.Scheduling.SimpleModeDefaultGroupID
+}
+
 func (s *SchedulerSnapshotService) outboxPollInterval() time.Duration {
 	if s.cfg == nil {
 		return time.Second
@@ -0,0 +1,142 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSchedulerCache is a minimal in-memory SchedulerCache used to exercise
+// snapshot read/write/rebuild-lock behavior without a real Redis backend.
+type fakeSchedulerCache struct {
+	mu        sync.Mutex
+	snapshots map[string][]Account
+}
+
+func newFakeSchedulerCache() *fakeSchedulerCache {
+	return &fakeSchedulerCache{snapshots: make(map[string][]Account)}
+}
+
+func (c *fakeSchedulerCache) GetSnapshot(ctx context.Context, bucket SchedulerBucket) ([]*Account, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	accounts, ok := c.snapshots[bucket.String()]
+	if !ok {
+		return nil, false, nil
+	}
+	out := make([]*Account, len(accounts))
+	for i := range accounts {
+		a := accounts[i]
+		out[i] = &a
+	}
+	return out, true, nil
+}
+
+func (c *fakeSchedulerCache) SetSnapshot(ctx context.Context, bucket SchedulerBucket, accounts []Account) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshots[bucket.String()] = accounts
+	return nil
+}
+
+func (c *fakeSchedulerCache) GetAccount(ctx context.Context, accountID int64) (*Account, error) {
+	return nil, nil
+}
+
+func (c *fakeSchedulerCache) SetAccount(ctx context.Context, account *Account) error { return nil }
+
+func (c *fakeSchedulerCache) DeleteAccount(ctx context.Context, accountID int64) error { return nil }
+
+func (c *fakeSchedulerCache) UpdateLastUsed(ctx context.Context, updates map[int64]time.Time) error {
+	return nil
+}
+
+func (c *fakeSchedulerCache) TryLockBucket(ctx context.Context, bucket SchedulerBucket, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *fakeSchedulerCache) ListBuckets(ctx context.Context) ([]SchedulerBucket, error) {
+	return nil, nil
+}
+
+func (c *fakeSchedulerCache) GetOutboxWatermark(ctx context.Context) (int64, error) { return 0, nil }
+
+func (c *fakeSchedulerCache) SetOutboxWatermark(ctx context.Context, id int64) error { return nil }
+
+// fakeSchedulableAccountRepo serves whatever account list is currently set,
+// simulating a bulk account change made between two scheduling lookups.
+type fakeSchedulableAccountRepo struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (r *fakeSchedulableAccountRepo) ListSchedulableByPlatform(ctx context.Context, platform string) ([]Account, error) {
+	var out []Account
+	for _, a := range r.accounts {
+		if a.Platform == platform {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeSchedulableAccountRepo) ListSchedulableByGroupIDAndPlatform(ctx context.Context, groupID int64, platform string) ([]Account, error) {
+	return r.ListSchedulableByPlatform(ctx, platform)
+}
+
+func (r *fakeSchedulableAccountRepo) ListSchedulableByPlatforms(ctx context.Context, platforms []string) ([]Account, error) {
+	return nil, nil
+}
+
+func (r *fakeSchedulableAccountRepo) ListSchedulableByGroupIDAndPlatforms(ctx context.Context, groupID int64, platforms []string) ([]Account, error) {
+	return nil, nil
+}
+
+func containsAccountID(accounts []Account, id int64) bool {
+	for _, a := range accounts {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TestTriggerFullRebuild_MakesNewlyEnabledAccountImmediatelySchedulable 验证手动触发全量
+// 重建后，批量变更中新增/启用的账号无需等待下一次节流周期即可出现在调度结果中。
+func TestTriggerFullRebuild_MakesNewlyEnabledAccountImmediatelySchedulable(t *testing.T) {
+	cache := newFakeSchedulerCache()
+	repo := &fakeSchedulableAccountRepo{accounts: []Account{
+		{ID: 1, Platform: PlatformAnthropic},
+	}}
+	svc := NewSchedulerSnapshotService(cache, nil, repo, nil, nil)
+
+	ctx := context.Background()
+	accounts, _, err := svc.ListSchedulableAccounts(ctx, nil, PlatformAnthropic, true)
+	require.NoError(t, err)
+	require.True(t, containsAccountID(accounts, 1))
+	require.False(t, containsAccountID(accounts, 2))
+
+	// Bulk account change: a new account is enabled.
+	repo.accounts = append(repo.accounts, Account{ID: 2, Platform: PlatformAnthropic})
+
+	// Without a rebuild, the stale cached snapshot is still served.
+	accounts, _, err = svc.ListSchedulableAccounts(ctx, nil, PlatformAnthropic, true)
+	require.NoError(t, err)
+	require.False(t, containsAccountID(accounts, 2), "snapshot should still be stale before a forced rebuild")
+
+	require.NoError(t, svc.TriggerFullRebuild())
+
+	accounts, _, err = svc.ListSchedulableAccounts(ctx, nil, PlatformAnthropic, true)
+	require.NoError(t, err)
+	require.True(t, containsAccountID(accounts, 2), "newly-enabled account should be schedulable immediately after a forced rebuild")
+}
+
+func TestTriggerFullRebuild_NilCacheReturnsNotReady(t *testing.T) {
+	svc := NewSchedulerSnapshotService(nil, nil, nil, nil, nil)
+	require.ErrorIs(t, svc.TriggerFullRebuild(), ErrSchedulerCacheNotReady)
+}
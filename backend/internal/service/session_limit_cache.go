@@ -61,4 +61,17 @@ type SessionLimitCache interface {
 	// GetWindowCostBatch 批量获取窗口费用缓存
 	// 返回 map[accountID]cost，缓存未命中的账号不在 map 中
 	GetWindowCostBatch(ctx context.Context, accountIDs []int64) (map[int64]float64, error)
+
+	// ========== 分组窗口费用缓存 ==========
+	// Key 格式: window_cost:group:{groupID}
+	// 用于缓存分组内所有账号在当前窗口内聚合的标准费用，减少数据库聚合查询压力
+
+	// GetGroupWindowCost 获取缓存的分组窗口费用
+	// 返回 (cost, true, nil) 如果缓存命中
+	// 返回 (0, false, nil) 如果缓存未命中
+	// 返回 (0, false, err) 如果发生错误
+	GetGroupWindowCost(ctx context.Context, groupID int64) (cost float64, hit bool, err error)
+
+	// SetGroupWindowCost 设置分组窗口费用缓存
+	SetGroupWindowCost(ctx context.Context, groupID int64, cost float64) error
 }
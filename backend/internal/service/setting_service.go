@@ -854,3 +854,37 @@ func (s *SettingService) SetStreamTimeoutSettings(ctx context.Context, settings
 
 	return s.settingRepo.Set(ctx, SettingKeyStreamTimeoutSettings, string(data))
 }
+
+// GetJWTRotationState 获取持久化的 JWT 签名密钥轮换状态，未轮换过时返回 nil, nil。
+func (s *SettingService) GetJWTRotationState(ctx context.Context) (*JWTRotationState, error) {
+	value, err := s.settingRepo.GetValue(ctx, SettingKeyJWTRotationState)
+	if err != nil {
+		if errors.Is(err, ErrSettingNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get jwt rotation state: %w", err)
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	var state JWTRotationState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return nil, fmt.Errorf("unmarshal jwt rotation state: %w", err)
+	}
+	return &state, nil
+}
+
+// SetJWTRotationState 持久化 JWT 签名密钥轮换状态，使其它副本能够通过 GetJWTRotationState 感知到本次轮换。
+func (s *SettingService) SetJWTRotationState(ctx context.Context, state *JWTRotationState) error {
+	if state == nil {
+		return fmt.Errorf("state cannot be nil")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal jwt rotation state: %w", err)
+	}
+
+	return s.settingRepo.Set(ctx, SettingKeyJWTRotationState, string(data))
+}
@@ -1,5 +1,7 @@
 package service
 
+import "time"
+
 type SystemSettings struct {
 	RegistrationEnabled   bool
 	EmailVerifyEnabled    bool
@@ -117,3 +119,12 @@ func DefaultStreamTimeoutSettings() *StreamTimeoutSettings {
 		ThresholdWindowMinutes: 10,
 	}
 }
+
+// JWTRotationState 持久化的 JWT 签名密钥轮换状态，使多副本部署下所有实例
+// 都能感知到轮换结果，而不是只有执行轮换的那个实例在内存中生效。
+type JWTRotationState struct {
+	PrimarySecret     string    `json:"primary_secret"`
+	PreviousSecret    string    `json:"previous_secret,omitempty"`
+	PreviousExpiresAt time.Time `json:"previous_expires_at,omitempty"`
+	RotatedAt         time.Time `json:"rotated_at"`
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// streamTee 异步把上游原始 SSE 流抄送一份到本地调试文件，用于排查个别请求的
+// 流式响应问题。写入通过带缓冲的 channel 交给独立 goroutine 处理，缓冲区满时
+// 直接丢弃该行并记录日志，确保抄送永远不会拖慢转发给客户端的主路径。
+type streamTee struct {
+	lines chan string
+	done  chan struct{}
+}
+
+// newStreamTee 在 dir 下为 requestID 创建调试文件并启动异步写入 goroutine。
+// 创建目录或文件失败时返回 nil，调用方据此静默跳过抄送。
+func newStreamTee(dir, requestID string) *streamTee {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Printf("stream tee: failed to create dir %s: %v", dir, err)
+		return nil
+	}
+	requestID = sanitizeStreamTeeRequestID(requestID)
+	path := filepath.Join(dir, fmt.Sprintf("%s-%d.sse", requestID, time.Now().UnixNano()))
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf("stream tee: failed to create file %s: %v", path, err)
+		return nil
+	}
+
+	t := &streamTee{
+		lines: make(chan string, 256),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(t.done)
+		defer func() { _ = f.Close() }()
+		for line := range t.lines {
+			if _, err := f.WriteString(line); err != nil {
+				log.Printf("stream tee: write failed for %s: %v", path, err)
+				return
+			}
+		}
+	}()
+	return t
+}
+
+// sanitizeStreamTeeRequestID 清理用于拼接调试文件名的 requestID。该值来自上游响应的
+// x-request-id 响应头，并非本机可控输入，若原样拼接路径，携带 "../" 片段的值可让写入
+// 逃逸出 dir 目录。先把反斜杠归一化为正斜杠再取 filepath.Base 去掉目录部分，确保在
+// 任意平台上都只保留最后一段文件名。
+func sanitizeStreamTeeRequestID(requestID string) string {
+	requestID = strings.ReplaceAll(requestID, "\\", "/")
+	requestID = filepath.Base(requestID)
+	if requestID == "" || requestID == "." || requestID == ".." || requestID == "/" {
+		return "unknown"
+	}
+	return requestID
+}
+
+// write 将一行原始上游数据排入异步写入队列；nil 接收者或队列已满时直接丢弃，
+// 不会阻塞调用方。
+func (t *streamTee) write(line string) {
+	if t == nil {
+		return
+	}
+	select {
+	case t.lines <- line:
+	default:
+		log.Printf("stream tee: buffer full, dropping line")
+	}
+}
+
+// close 停止接收新行，并等待写入 goroutine 落盘、关闭文件。
+func (t *streamTee) close() {
+	if t == nil {
+		return
+	}
+	close(t.lines)
+	<-t.done
+}
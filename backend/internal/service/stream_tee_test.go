@@ -0,0 +1,88 @@
+//go:build unit
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamTee_WritesFullStreamToFile(t *testing.T) {
+	dir := t.TempDir()
+	tee := newStreamTee(dir, "req-123")
+	require.NotNil(t, tee)
+
+	lines := []string{
+		"event: message_start\n",
+		"data: {\"type\":\"message_start\"}\n",
+		"\n",
+		"event: content_block_delta\n",
+		"data: {\"type\":\"content_block_delta\"}\n",
+		"\n",
+	}
+	for _, line := range lines {
+		tee.write(line)
+	}
+	tee.close()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+
+	want := ""
+	for _, line := range lines {
+		want += line
+	}
+	require.Equal(t, want, string(content))
+}
+
+func TestStreamTee_NilReceiverIsNoOp(t *testing.T) {
+	var tee *streamTee
+	tee.write("line\n")
+	tee.close()
+}
+
+func TestStreamTee_InvalidDirSkipsTeeing(t *testing.T) {
+	dir := t.TempDir()
+	blocked := filepath.Join(dir, "blocked")
+	require.NoError(t, os.WriteFile(blocked, []byte("not a dir"), 0o644))
+
+	tee := newStreamTee(filepath.Join(blocked, "nested"), "req-456")
+	require.Nil(t, tee)
+}
+
+func TestStreamTee_PathTraversalRequestIDStaysInsideDir(t *testing.T) {
+	dir := t.TempDir()
+	// x-request-id 来自上游响应头，不受本机控制；恶意值不应让写入逃逸出 dir。
+	tee := newStreamTee(dir, "../../../../etc/passwd")
+	require.NotNil(t, tee)
+	tee.write("line\n")
+	tee.close()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NotContains(t, entries[0].Name(), "..")
+	require.NotContains(t, entries[0].Name(), string(filepath.Separator))
+}
+
+func TestSanitizeStreamTeeRequestID(t *testing.T) {
+	cases := map[string]string{
+		"req-123":                 "req-123",
+		"":                        "unknown",
+		"..":                      "unknown",
+		".":                       "unknown",
+		"../../etc/passwd":        "passwd",
+		"../../../tmp/evil":       "evil",
+		"..\\..\\windows\\system": "system",
+	}
+	for input, want := range cases {
+		require.Equal(t, want, sanitizeStreamTeeRequestID(input), "input=%q", input)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionOverflowsToBalance_FallsBackWhenDailyLimitWouldBeExceeded(t *testing.T) {
+	dailyLimit := 10.0
+	group := &Group{
+		ID:                         1,
+		SubscriptionType:           SubscriptionTypeSubscription,
+		DailyLimitUSD:              &dailyLimit,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicyFallbackBalance,
+	}
+	sub := &UserSubscription{ID: 1, DailyUsageUSD: 9}
+
+	require.True(t, subscriptionOverflowsToBalance(group, sub, 2))
+}
+
+func TestSubscriptionOverflowsToBalance_StaysOnSubscriptionWhenWithinLimit(t *testing.T) {
+	dailyLimit := 10.0
+	group := &Group{
+		ID:                         1,
+		SubscriptionType:           SubscriptionTypeSubscription,
+		DailyLimitUSD:              &dailyLimit,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicyFallbackBalance,
+	}
+	sub := &UserSubscription{ID: 1, DailyUsageUSD: 1}
+
+	require.False(t, subscriptionOverflowsToBalance(group, sub, 2))
+}
+
+func TestSubscriptionOverflowsToBalance_SubscriptionOnlyPolicyNeverFallsBack(t *testing.T) {
+	dailyLimit := 10.0
+	group := &Group{
+		ID:                         1,
+		SubscriptionType:           SubscriptionTypeSubscription,
+		DailyLimitUSD:              &dailyLimit,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicySubscriptionOnly,
+	}
+	sub := &UserSubscription{ID: 1, DailyUsageUSD: 9}
+
+	require.False(t, subscriptionOverflowsToBalance(group, sub, 2))
+}
+
+func TestSubscriptionOverflowsToBalance_NoLimitsConfiguredNeverFallsBack(t *testing.T) {
+	group := &Group{
+		ID:                         1,
+		SubscriptionType:           SubscriptionTypeSubscription,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicyFallbackBalance,
+	}
+	sub := &UserSubscription{ID: 1}
+
+	require.False(t, subscriptionOverflowsToBalance(group, sub, 1000))
+}
+
+func TestSubscriptionOverflowsToBalance_NilSubscriptionNeverFallsBack(t *testing.T) {
+	dailyLimit := 10.0
+	group := &Group{
+		ID:                         1,
+		DailyLimitUSD:              &dailyLimit,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicyFallbackBalance,
+	}
+
+	require.False(t, subscriptionOverflowsToBalance(group, nil, 1000))
+}
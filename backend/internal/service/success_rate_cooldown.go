@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// successRateWindowSize 成功率滑动窗口统计的请求样本数上限。
+const successRateWindowSize = 20
+
+// successRateTracker 记录各账号最近请求的成功/失败结果（进程内环形缓冲区，重启后自动清空），
+// 用于在没有命中 TempUnschedulableRules 任何显式错误码规则的情况下（例如上游频繁返回空响应、
+// 连接中断等“软失败”），根据成功率异常下降自动触发与临时不可调度相同的冷却。
+type successRateTracker struct {
+	mu      sync.Mutex
+	windows map[int64]*successRateWindow
+}
+
+type successRateWindow struct {
+	outcomes [successRateWindowSize]bool
+	count    int // 已写入的样本数，达到 successRateWindowSize 后不再增长
+	next     int // 下一个写入位置（环形）
+	failures int // 当前窗口内失败样本数
+}
+
+func newSuccessRateTracker() *successRateTracker {
+	return &successRateTracker{windows: make(map[int64]*successRateWindow)}
+}
+
+// record 记录一次请求结果，返回记录后窗口内的成功率及样本数。
+func (t *successRateTracker) record(accountID int64, success bool) (rate float64, samples int) {
+	if t == nil {
+		return 1, 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w, ok := t.windows[accountID]
+	if !ok {
+		w = &successRateWindow{}
+		t.windows[accountID] = w
+	}
+
+	if w.count == successRateWindowSize {
+		if !w.outcomes[w.next] {
+			w.failures--
+		}
+	} else {
+		w.count++
+	}
+	w.outcomes[w.next] = success
+	if !success {
+		w.failures++
+	}
+	w.next = (w.next + 1) % successRateWindowSize
+
+	samples = w.count
+	if samples == 0 {
+		return 1, 0
+	}
+	return float64(samples-w.failures) / float64(samples), samples
+}
+
+// reset 清空某账号的成功率窗口，在账号被临时不可调度或恢复调度后调用，避免触发冷却的历史样本立即再次生效。
+func (t *successRateTracker) reset(accountID int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	delete(t.windows, accountID)
+	t.mu.Unlock()
+}
+
+// successRateCooldown 计算并应用成功率冷却时长。
+const successRateCooldownLogPrefix = "[SuccessRateCooldown]"
+
+// recordAccountOutcomeAndMaybeCooldown 记录一次请求结果，若账号启用了成功率冷却且成功率
+// 低于其配置阈值（需达到最少样本数），则触发与 TempUnschedulableRules 相同的临时不可调度。
+func recordAccountOutcomeAndMaybeCooldown(ctx context.Context, tracker *successRateTracker, repo AccountRepository, account *Account, success bool) {
+	if tracker == nil || account == nil || !account.IsSuccessRateCooldownEnabled() {
+		return
+	}
+
+	rate, samples := tracker.record(account.ID, success)
+	if samples < account.SuccessRateCooldownMinSamples() {
+		return
+	}
+	if rate >= account.SuccessRateCooldownThreshold() {
+		return
+	}
+
+	minutes := account.SuccessRateCooldownMinutes()
+	until := time.Now().Add(time.Duration(minutes) * time.Minute)
+	reason := "success rate below threshold (auto temp-unschedule)"
+	if err := repo.SetTempUnschedulable(ctx, account.ID, until, reason); err != nil {
+		log.Printf("%s temp_unschedule_failed account=%d error=%v", successRateCooldownLogPrefix, account.ID, err)
+		return
+	}
+	log.Printf("%s temp_unscheduled account=%d rate=%.2f samples=%d until=%v",
+		successRateCooldownLogPrefix, account.ID, rate, samples, until.Format("15:04:05"))
+	tracker.reset(account.ID)
+}
@@ -0,0 +1,142 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type successRateCooldownRepoStub struct {
+	mockAccountRepoForGemini
+	tempCalls int
+	lastUntil time.Time
+}
+
+func (r *successRateCooldownRepoStub) SetTempUnschedulable(ctx context.Context, id int64, until time.Time, reason string) error {
+	r.tempCalls++
+	r.lastUntil = until
+	return nil
+}
+
+func TestSuccessRateTracker_ComputesRateOverSlidingWindow(t *testing.T) {
+	tracker := newSuccessRateTracker()
+
+	for i := 0; i < 5; i++ {
+		rate, samples := tracker.record(1, true)
+		require.Equal(t, i+1, samples)
+		require.Equal(t, 1.0, rate)
+	}
+
+	rate, samples := tracker.record(1, false)
+	require.Equal(t, 6, samples)
+	require.InDelta(t, 5.0/6.0, rate, 0.0001)
+}
+
+func TestSuccessRateTracker_EvictsOldestSampleOnceWindowFull(t *testing.T) {
+	tracker := newSuccessRateTracker()
+
+	for i := 0; i < successRateWindowSize; i++ {
+		tracker.record(1, false)
+	}
+	rate, samples := tracker.record(1, false)
+	require.Equal(t, successRateWindowSize, samples)
+	require.Equal(t, 0.0, rate)
+
+	for i := 0; i < successRateWindowSize; i++ {
+		tracker.record(1, true)
+	}
+	rate, samples = tracker.record(1, true)
+	require.Equal(t, successRateWindowSize, samples)
+	require.Equal(t, 1.0, rate)
+}
+
+func TestSuccessRateTracker_ResetClearsWindow(t *testing.T) {
+	tracker := newSuccessRateTracker()
+	tracker.record(1, false)
+	tracker.record(1, false)
+
+	tracker.reset(1)
+
+	rate, samples := tracker.record(1, true)
+	require.Equal(t, 1, samples)
+	require.Equal(t, 1.0, rate)
+}
+
+func TestRecordAccountOutcomeAndMaybeCooldown_LowSuccessRateTriggersCooldown(t *testing.T) {
+	account := &Account{
+		ID: 1,
+		Credentials: map[string]any{
+			"success_rate_cooldown_enabled":     true,
+			"success_rate_cooldown_threshold":   0.5,
+			"success_rate_cooldown_min_samples": 4,
+			"success_rate_cooldown_minutes":     15,
+		},
+	}
+	tracker := newSuccessRateTracker()
+	repo := &successRateCooldownRepoStub{}
+
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	require.Equal(t, 0, repo.tempCalls, "should not trigger before reaching min samples")
+
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	require.Equal(t, 1, repo.tempCalls, "success rate 0%% over 4 samples should trigger cooldown")
+	require.True(t, repo.lastUntil.After(time.Now().Add(14*time.Minute)))
+}
+
+func TestRecordAccountOutcomeAndMaybeCooldown_RecoversAfterCooldownReset(t *testing.T) {
+	account := &Account{
+		ID: 1,
+		Credentials: map[string]any{
+			"success_rate_cooldown_enabled":     true,
+			"success_rate_cooldown_threshold":   0.5,
+			"success_rate_cooldown_min_samples": 2,
+			"success_rate_cooldown_minutes":     10,
+		},
+	}
+	tracker := newSuccessRateTracker()
+	repo := &successRateCooldownRepoStub{}
+
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	require.Equal(t, 1, repo.tempCalls)
+
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, true)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, true)
+	require.Equal(t, 1, repo.tempCalls, "should not re-trigger once the reset window is healthy again")
+}
+
+func TestRecordAccountOutcomeAndMaybeCooldown_DisabledByDefault(t *testing.T) {
+	account := &Account{ID: 1}
+	tracker := newSuccessRateTracker()
+	repo := &successRateCooldownRepoStub{}
+
+	for i := 0; i < successRateWindowSize; i++ {
+		recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	}
+	require.Equal(t, 0, repo.tempCalls)
+}
+
+func TestRecordAccountOutcomeAndMaybeCooldown_WithinThresholdNeverTriggers(t *testing.T) {
+	account := &Account{
+		ID: 1,
+		Credentials: map[string]any{
+			"success_rate_cooldown_enabled":     true,
+			"success_rate_cooldown_threshold":   0.5,
+			"success_rate_cooldown_min_samples": 4,
+		},
+	}
+	tracker := newSuccessRateTracker()
+	repo := &successRateCooldownRepoStub{}
+
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, true)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, true)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, true)
+	recordAccountOutcomeAndMaybeCooldown(context.Background(), tracker, repo, account, false)
+	require.Equal(t, 0, repo.tempCalls)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// TokenHealthCheckService OAuth token健康检查服务
+// 定期使用 TokenProvider 尝试获取 access_token，验证账号的 OAuth 凭证是否仍然有效。
+// 与 TokenRefreshService 不同，本服务不关心 token 是否临近过期，而是主动探测凭证是否
+// 已经失效（例如用户在其他地方吊销了授权），避免用户请求命中一个已经失效的账号后才发现。
+type TokenHealthCheckService struct {
+	accountRepo AccountRepository
+	validators  []TokenValidator
+	cfg         *config.TokenHealthCheckConfig
+
+	mu            sync.Mutex
+	failureCounts map[int64]int
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewTokenHealthCheckService 创建token健康检查服务
+func NewTokenHealthCheckService(
+	accountRepo AccountRepository,
+	claudeTokenProvider *ClaudeTokenProvider,
+	geminiTokenProvider *GeminiTokenProvider,
+	cfg *config.Config,
+) *TokenHealthCheckService {
+	return &TokenHealthCheckService{
+		accountRepo: accountRepo,
+		validators: []TokenValidator{
+			NewClaudeTokenValidator(claudeTokenProvider),
+			NewGeminiTokenValidator(geminiTokenProvider),
+		},
+		cfg:           &cfg.TokenHealthCheck,
+		failureCounts: make(map[int64]int),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start 启动后台健康检查服务
+func (s *TokenHealthCheckService) Start() {
+	if !s.cfg.Enabled {
+		log.Println("[TokenHealthCheck] Service disabled by configuration")
+		return
+	}
+
+	s.wg.Add(1)
+	go s.checkLoop()
+
+	log.Printf("[TokenHealthCheck] Service started (check every %d minutes, max_concurrency=%d, max_consecutive_failures=%d)",
+		s.cfg.CheckIntervalMinutes, s.cfg.MaxConcurrency, s.cfg.MaxConsecutiveFailures)
+}
+
+// Stop 停止健康检查服务
+func (s *TokenHealthCheckService) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	log.Println("[TokenHealthCheck] Service stopped")
+}
+
+// checkLoop 健康检查循环
+func (s *TokenHealthCheckService) checkLoop() {
+	defer s.wg.Done()
+
+	checkInterval := time.Duration(s.cfg.CheckIntervalMinutes) * time.Minute
+	if checkInterval < time.Minute {
+		checkInterval = 30 * time.Minute
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	// 启动时立即执行一次检查
+	s.processHealthCheck()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processHealthCheck()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// processHealthCheck 执行一次健康检查，并发受 MaxConcurrency 限制
+func (s *TokenHealthCheckService) processHealthCheck() {
+	ctx := context.Background()
+
+	accounts, err := s.accountRepo.ListActive(ctx)
+	if err != nil {
+		log.Printf("[TokenHealthCheck] Failed to list accounts: %v", err)
+		return
+	}
+
+	maxConcurrency := s.cfg.MaxConcurrency
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	sem := make(chan struct{}, maxConcurrency)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		checked int
+		failed  int
+		errored int
+	)
+
+	for i := range accounts {
+		account := &accounts[i]
+
+		var validator TokenValidator
+		for _, v := range s.validators {
+			if v.CanValidate(account) {
+				validator = v
+				break
+			}
+		}
+		if validator == nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(account *Account, validator TokenValidator) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			mu.Lock()
+			checked++
+			mu.Unlock()
+
+			if s.checkAccount(ctx, account, validator) {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				if s.recordFailure(ctx, account) {
+					mu.Lock()
+					errored++
+					mu.Unlock()
+				}
+			} else {
+				s.recordSuccess(account.ID)
+			}
+		}(account, validator)
+	}
+
+	wg.Wait()
+
+	log.Printf("[TokenHealthCheck] Cycle complete: checked=%d, failed=%d, marked_errored=%d", checked, failed, errored)
+}
+
+// checkAccount 校验单个账号的token健康状态，返回是否校验失败
+func (s *TokenHealthCheckService) checkAccount(ctx context.Context, account *Account, validator TokenValidator) bool {
+	if err := validator.Validate(ctx, account); err != nil {
+		log.Printf("[TokenHealthCheck] Account %d (%s) token validation failed: %v", account.ID, account.Name, err)
+		return true
+	}
+	return false
+}
+
+// recordFailure 记录一次校验失败，达到最大连续失败次数后将账号标记为 error 状态，返回是否已标记
+func (s *TokenHealthCheckService) recordFailure(ctx context.Context, account *Account) bool {
+	s.mu.Lock()
+	s.failureCounts[account.ID]++
+	count := s.failureCounts[account.ID]
+	s.mu.Unlock()
+
+	maxFailures := s.cfg.MaxConsecutiveFailures
+	if maxFailures < 1 {
+		maxFailures = 1
+	}
+	if count < maxFailures {
+		return false
+	}
+
+	errorMsg := fmt.Sprintf("Token health check failed %d consecutive times", count)
+	if err := s.accountRepo.SetError(ctx, account.ID, errorMsg); err != nil {
+		log.Printf("[TokenHealthCheck] Failed to set error status for account %d: %v", account.ID, err)
+		return false
+	}
+	log.Printf("[TokenHealthCheck] Account %d marked as errored after %d consecutive failures", account.ID, count)
+	return true
+}
+
+// recordSuccess 清除账号的连续失败计数
+func (s *TokenHealthCheckService) recordSuccess(accountID int64) {
+	s.mu.Lock()
+	delete(s.failureCounts, accountID)
+	s.mu.Unlock()
+}
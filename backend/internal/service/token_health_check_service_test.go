@@ -0,0 +1,111 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenHealthCheckAccountRepo struct {
+	mockAccountRepoForGemini
+	mu            sync.Mutex
+	accounts      []Account
+	erroredIDs    []int64
+	setErrorCalls int
+}
+
+func (r *tokenHealthCheckAccountRepo) ListActive(ctx context.Context) ([]Account, error) {
+	return r.accounts, nil
+}
+
+func (r *tokenHealthCheckAccountRepo) SetError(ctx context.Context, id int64, errorMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.setErrorCalls++
+	r.erroredIDs = append(r.erroredIDs, id)
+	return nil
+}
+
+type tokenValidatorStub struct {
+	platform string
+	err      error
+}
+
+func (v *tokenValidatorStub) CanValidate(account *Account) bool {
+	return account.Platform == v.platform && account.Type == AccountTypeOAuth
+}
+
+func (v *tokenValidatorStub) Validate(ctx context.Context, account *Account) error {
+	return v.err
+}
+
+func TestTokenHealthCheckService_MarksAccountErroredAfterPersistentFailure(t *testing.T) {
+	repo := &tokenHealthCheckAccountRepo{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformAnthropic, Type: AccountTypeOAuth},
+		},
+	}
+	svc := &TokenHealthCheckService{
+		accountRepo:   repo,
+		validators:    []TokenValidator{&tokenValidatorStub{platform: PlatformAnthropic, err: errors.New("invalid_grant")}},
+		cfg:           &config.TokenHealthCheckConfig{MaxConcurrency: 1, MaxConsecutiveFailures: 2},
+		failureCounts: make(map[int64]int),
+		stopCh:        make(chan struct{}),
+	}
+
+	svc.processHealthCheck()
+	require.Equal(t, 0, repo.setErrorCalls, "account should not be errored after a single failure")
+
+	svc.processHealthCheck()
+	require.Equal(t, 1, repo.setErrorCalls, "account should be errored after reaching the consecutive failure threshold")
+	require.Equal(t, []int64{1}, repo.erroredIDs)
+}
+
+func TestTokenHealthCheckService_SuccessResetsFailureCount(t *testing.T) {
+	validator := &tokenValidatorStub{platform: PlatformGemini, err: errors.New("token expired")}
+	repo := &tokenHealthCheckAccountRepo{
+		accounts: []Account{
+			{ID: 2, Platform: PlatformGemini, Type: AccountTypeOAuth},
+		},
+	}
+	svc := &TokenHealthCheckService{
+		accountRepo:   repo,
+		validators:    []TokenValidator{validator},
+		cfg:           &config.TokenHealthCheckConfig{MaxConcurrency: 1, MaxConsecutiveFailures: 2},
+		failureCounts: make(map[int64]int),
+		stopCh:        make(chan struct{}),
+	}
+
+	svc.processHealthCheck()
+	require.Equal(t, 1, svc.failureCounts[2])
+
+	validator.err = nil
+	svc.processHealthCheck()
+	require.Equal(t, 0, repo.setErrorCalls)
+	_, stillTracked := svc.failureCounts[2]
+	require.False(t, stillTracked, "failure count should be cleared after a successful check")
+}
+
+func TestTokenHealthCheckService_SkipsAccountsWithNoMatchingValidator(t *testing.T) {
+	repo := &tokenHealthCheckAccountRepo{
+		accounts: []Account{
+			{ID: 3, Platform: PlatformOpenAI, Type: AccountTypeOAuth},
+		},
+	}
+	svc := &TokenHealthCheckService{
+		accountRepo:   repo,
+		validators:    []TokenValidator{&tokenValidatorStub{platform: PlatformAnthropic, err: errors.New("should not be called")}},
+		cfg:           &config.TokenHealthCheckConfig{MaxConcurrency: 1, MaxConsecutiveFailures: 1},
+		failureCounts: make(map[int64]int),
+		stopCh:        make(chan struct{}),
+	}
+
+	svc.processHealthCheck()
+	require.Equal(t, 0, repo.setErrorCalls)
+}
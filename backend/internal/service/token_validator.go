@@ -0,0 +1,56 @@
+package service
+
+import "context"
+
+// TokenValidator 定义平台特定的token健康检查策略接口
+// 通过此接口可以扩展支持不同平台（Anthropic/Gemini）
+type TokenValidator interface {
+	// CanValidate 检查此校验器是否能处理指定账号
+	CanValidate(account *Account) bool
+
+	// Validate 尝试获取一次有效的 access_token，失败说明账号的 OAuth 凭证已不可用
+	// （TokenProvider 内部会先尝试刷新，因此这里验证的是"刷新后仍不可用"）
+	Validate(ctx context.Context, account *Account) error
+}
+
+// ClaudeTokenValidator 基于 ClaudeTokenProvider 校验 Anthropic OAuth 账号的 token 健康状态
+type ClaudeTokenValidator struct {
+	tokenProvider *ClaudeTokenProvider
+}
+
+// NewClaudeTokenValidator 创建Claude token健康检查器
+func NewClaudeTokenValidator(tokenProvider *ClaudeTokenProvider) *ClaudeTokenValidator {
+	return &ClaudeTokenValidator{tokenProvider: tokenProvider}
+}
+
+// CanValidate 只处理 anthropic 平台的 oauth 类型账号
+func (v *ClaudeTokenValidator) CanValidate(account *Account) bool {
+	return account.Platform == PlatformAnthropic && account.Type == AccountTypeOAuth
+}
+
+// Validate 尝试获取 access_token，间接触发刷新校验
+func (v *ClaudeTokenValidator) Validate(ctx context.Context, account *Account) error {
+	_, err := v.tokenProvider.GetAccessToken(ctx, account)
+	return err
+}
+
+// GeminiTokenValidator 基于 GeminiTokenProvider 校验 Gemini OAuth 账号的 token 健康状态
+type GeminiTokenValidator struct {
+	tokenProvider *GeminiTokenProvider
+}
+
+// NewGeminiTokenValidator 创建Gemini token健康检查器
+func NewGeminiTokenValidator(tokenProvider *GeminiTokenProvider) *GeminiTokenValidator {
+	return &GeminiTokenValidator{tokenProvider: tokenProvider}
+}
+
+// CanValidate 只处理 gemini 平台的 oauth 类型账号
+func (v *GeminiTokenValidator) CanValidate(account *Account) bool {
+	return account.Platform == PlatformGemini && account.Type == AccountTypeOAuth
+}
+
+// Validate 尝试获取 access_token，间接触发刷新校验
+func (v *GeminiTokenValidator) Validate(ctx context.Context, account *Account) error {
+	_, err := v.tokenProvider.GetAccessToken(ctx, account)
+	return err
+}
@@ -17,6 +17,9 @@ type UsageLog struct {
 	// ReasoningEffort is the request's reasoning effort level (OpenAI Responses API),
 	// e.g. "low" / "medium" / "high" / "xhigh". Nil means not provided / not applicable.
 	ReasoningEffort *string
+	// Tag is the client-declared billing tag (x-sub2api-tag header), used to group
+	// usage by project/feature for cost reporting. Nil means not provided.
+	Tag *string
 
 	GroupID        *int64
 	SubscriptionID *int64
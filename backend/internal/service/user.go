@@ -25,6 +25,10 @@ type User struct {
 	// map[groupID]rateMultiplier
 	GroupRates map[int64]float64
 
+	// ModelMapping 用户级默认模型映射，在分组/账号映射之前应用
+	// map[requestedModel]targetModel，支持通配符（最长优先匹配）
+	ModelMapping map[string]string
+
 	// TOTP 双因素认证字段
 	TotpSecretEncrypted *string    // AES-256-GCM 加密的 TOTP 密钥
 	TotpEnabled         bool       // 是否启用 TOTP
@@ -60,6 +64,20 @@ func (u *User) CanBindGroup(groupID int64, isExclusive bool) bool {
 	return false
 }
 
+// GetMappedModel 获取用户级映射后的模型名（支持通配符，最长优先匹配）
+// 如果未配置 mapping，返回原始模型名
+func (u *User) GetMappedModel(requestedModel string) string {
+	if len(u.ModelMapping) == 0 {
+		return requestedModel
+	}
+	// 精确匹配优先
+	if mappedModel, exists := u.ModelMapping[requestedModel]; exists {
+		return mappedModel
+	}
+	// 通配符匹配（最长优先）
+	return matchWildcardMapping(u.ModelMapping, requestedModel)
+}
+
 func (u *User) SetPassword(password string) error {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
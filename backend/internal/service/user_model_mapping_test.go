@@ -0,0 +1,141 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserGetMappedModel(t *testing.T) {
+	tests := []struct {
+		name           string
+		mapping        map[string]string
+		requestedModel string
+		expected       string
+	}{
+		{
+			name:           "no mapping returns original",
+			mapping:        nil,
+			requestedModel: "claude-3-5-sonnet-20241022",
+			expected:       "claude-3-5-sonnet-20241022",
+		},
+		{
+			name: "exact match",
+			mapping: map[string]string{
+				"claude-3-5-sonnet-20241022": "claude-3-5-haiku-20241022",
+			},
+			requestedModel: "claude-3-5-sonnet-20241022",
+			expected:       "claude-3-5-haiku-20241022",
+		},
+		{
+			name: "wildcard longest match",
+			mapping: map[string]string{
+				"claude-*":        "claude-default",
+				"claude-sonnet-*": "claude-sonnet-mapped",
+			},
+			requestedModel: "claude-sonnet-4-5",
+			expected:       "claude-sonnet-mapped",
+		},
+		{
+			name: "no match returns original",
+			mapping: map[string]string{
+				"gemini-*": "gemini-mapped",
+			},
+			requestedModel: "claude-sonnet-4-5",
+			expected:       "claude-sonnet-4-5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ModelMapping: tt.mapping}
+			result := user.GetMappedModel(tt.requestedModel)
+			if result != tt.expected {
+				t.Errorf("GetMappedModel(%q) = %q, want %q", tt.requestedModel, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestApplyUserModelMapping_SetsOriginalModel 验证命中用户级映射时会改写
+// parsed.Model/Body，并将原始模型记录到 parsed.OriginalModel 供计费使用。
+func TestApplyUserModelMapping_SetsOriginalModel(t *testing.T) {
+	svc := &GatewayService{}
+	user := &User{ModelMapping: map[string]string{
+		"claude-3-5-sonnet-20241022": "claude-3-5-haiku-20241022",
+	}}
+	parsed := &ParsedRequest{
+		Model: "claude-3-5-sonnet-20241022",
+		Body:  []byte(`{"model":"claude-3-5-sonnet-20241022"}`),
+	}
+
+	svc.ApplyUserModelMapping(parsed, user)
+
+	if parsed.Model != "claude-3-5-haiku-20241022" {
+		t.Errorf("parsed.Model = %q, want %q", parsed.Model, "claude-3-5-haiku-20241022")
+	}
+	if parsed.OriginalModel != "claude-3-5-sonnet-20241022" {
+		t.Errorf("parsed.OriginalModel = %q, want %q", parsed.OriginalModel, "claude-3-5-sonnet-20241022")
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(parsed.Body, &body); err != nil {
+		t.Fatalf("unmarshal body: %v", err)
+	}
+	if body["model"] != "claude-3-5-haiku-20241022" {
+		t.Errorf("body model = %v, want %v", body["model"], "claude-3-5-haiku-20241022")
+	}
+}
+
+// TestApplyUserModelMapping_NoMappingLeavesOriginalModelEmpty 验证未命中映射时
+// parsed.Model 保持不变，且 OriginalModel 不会被误置，确保 Forward 的计费逻辑
+// 回退到 reqModel 本身。
+func TestApplyUserModelMapping_NoMappingLeavesOriginalModelEmpty(t *testing.T) {
+	svc := &GatewayService{}
+	user := &User{}
+	parsed := &ParsedRequest{
+		Model: "claude-3-5-sonnet-20241022",
+		Body:  []byte(`{"model":"claude-3-5-sonnet-20241022"}`),
+	}
+
+	svc.ApplyUserModelMapping(parsed, user)
+
+	if parsed.Model != "claude-3-5-sonnet-20241022" {
+		t.Errorf("parsed.Model changed unexpectedly: %q", parsed.Model)
+	}
+	if parsed.OriginalModel != "" {
+		t.Errorf("parsed.OriginalModel = %q, want empty", parsed.OriginalModel)
+	}
+}
+
+// TestUserModelMapping_ComposesWithAccountMapping 验证用户级映射在账号级映射之前
+// 生效：先将请求模型重定向为用户配置的目标模型，账号再基于该（已映射）模型继续映射，
+// 同时原始模型始终保留在 parsed.OriginalModel 中供计费还原。
+func TestUserModelMapping_ComposesWithAccountMapping(t *testing.T) {
+	svc := &GatewayService{}
+	user := &User{ModelMapping: map[string]string{
+		"claude-3-5-sonnet-20241022": "claude-3-5-haiku-20241022",
+	}}
+	account := &Account{
+		Credentials: map[string]any{
+			"model_mapping": map[string]any{
+				"claude-3-5-haiku-20241022": "claude-haiku-upstream",
+			},
+		},
+	}
+	parsed := &ParsedRequest{
+		Model: "claude-3-5-sonnet-20241022",
+		Body:  []byte(`{"model":"claude-3-5-sonnet-20241022"}`),
+	}
+
+	svc.ApplyUserModelMapping(parsed, user)
+	finalModel := account.GetMappedModel(parsed.Model)
+
+	if finalModel != "claude-haiku-upstream" {
+		t.Errorf("finalModel = %q, want %q", finalModel, "claude-haiku-upstream")
+	}
+	if parsed.OriginalModel != "claude-3-5-sonnet-20241022" {
+		t.Errorf("parsed.OriginalModel = %q, want %q", parsed.OriginalModel, "claude-3-5-sonnet-20241022")
+	}
+}
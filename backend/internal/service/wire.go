@@ -52,6 +52,18 @@ func ProvideTokenRefreshService(
 	return svc
 }
 
+// ProvideTokenHealthCheckService creates and starts TokenHealthCheckService
+func ProvideTokenHealthCheckService(
+	accountRepo AccountRepository,
+	claudeTokenProvider *ClaudeTokenProvider,
+	geminiTokenProvider *GeminiTokenProvider,
+	cfg *config.Config,
+) *TokenHealthCheckService {
+	svc := NewTokenHealthCheckService(accountRepo, claudeTokenProvider, geminiTokenProvider, cfg)
+	svc.Start()
+	return svc
+}
+
 // ProvideDashboardAggregationService 创建并启动仪表盘聚合服务
 func ProvideDashboardAggregationService(repo DashboardAggregationRepository, timingWheel *TimingWheelService, cfg *config.Config) *DashboardAggregationService {
 	svc := NewDashboardAggregationService(repo, timingWheel, cfg)
@@ -264,6 +276,7 @@ var ProviderSet = wire.NewSet(
 	NewCRSSyncService,
 	ProvideUpdateService,
 	ProvideTokenRefreshService,
+	ProvideTokenHealthCheckService,
 	ProvideAccountExpiryService,
 	ProvideSubscriptionExpiryService,
 	ProvideTimingWheelService,